@@ -53,6 +53,21 @@ func (o *OriginData) StateBytes() []byte {
 	return o.sb
 }
 
+// State returns the downloaded BeaconState value.
+func (o *OriginData) State() state.BeaconState {
+	return o.st
+}
+
+// BlockRoot returns the hash_tree_root of the downloaded block.
+func (o *OriginData) BlockRoot() [32]byte {
+	return o.br
+}
+
+// StateRoot returns the hash_tree_root of the downloaded state.
+func (o *OriginData) StateRoot() [32]byte {
+	return o.sr
+}
+
 // BlockBytes returns the ssz-encoded bytes of the downloaded ReadOnlySignedBeaconBlock value.
 func (o *OriginData) BlockBytes() []byte {
 	return o.bb
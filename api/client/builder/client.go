@@ -30,6 +30,7 @@ const (
 	getStatus                  = "/eth/v1/builder/status"
 	postBlindedBeaconBlockPath = "/eth/v1/builder/blinded_blocks"
 	postRegisterValidatorPath  = "/eth/v1/builder/validators"
+	getValidatorRegistration   = "/relay/v1/data/validator_registration"
 )
 
 var errMalformedHostname = errors.New("hostname must include port, separated by one colon, like example.com:3500")
@@ -88,6 +89,7 @@ type BuilderClient interface {
 	RegisterValidator(ctx context.Context, svr []*ethpb.SignedValidatorRegistrationV1) error
 	SubmitBlindedBlock(ctx context.Context, sb interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, error)
 	Status(ctx context.Context) error
+	ValidatorRegistration(ctx context.Context, pubkey [48]byte) (*ethpb.ValidatorRegistrationV1, error)
 }
 
 // Client provides a collection of helper methods for calling Builder API endpoints.
@@ -137,6 +139,12 @@ func (c *Client) NodeURL() string {
 
 type reqOption func(*http.Request)
 
+func withQuery(q url.Values) reqOption {
+	return func(r *http.Request) {
+		r.URL.RawQuery = q.Encode()
+	}
+}
+
 // do is a generic, opinionated request function to reduce boilerplate amongst the methods in this package api/client/builder/types.go.
 func (c *Client) do(ctx context.Context, method string, path string, body io.Reader, opts ...reqOption) (res []byte, err error) {
 	ctx, span := trace.StartSpan(ctx, "builder.client.do")
@@ -354,6 +362,28 @@ func (c *Client) Status(ctx context.Context) error {
 	return err
 }
 
+// ValidatorRegistration returns the latest registration of a validator received by the relay, using the data API of
+// the relay. An error wrapping ErrNotFound is returned when the relay has no registration for the validator.
+func (c *Client) ValidatorRegistration(ctx context.Context, pubkey [48]byte) (*ethpb.ValidatorRegistrationV1, error) {
+	ctx, span := trace.StartSpan(ctx, "builder.client.ValidatorRegistration")
+	defer span.End()
+
+	q := url.Values{}
+	q.Set("pubkey", fmt.Sprintf("%#x", pubkey))
+	rb, err := c.do(ctx, http.MethodGet, getValidatorRegistration, nil, withQuery(q))
+	if err != nil {
+		return nil, err
+	}
+	reg := &SignedValidatorRegistration{}
+	if err := json.Unmarshal(rb, reg); err != nil {
+		return nil, errors.Wrapf(err, "error unmarshaling the validator registration response, using pubkey=%#x", pubkey)
+	}
+	if reg.Message == nil {
+		return nil, errors.Wrapf(errMalformedRequest, "no registration message in the response, using pubkey=%#x", pubkey)
+	}
+	return reg.Message, nil
+}
+
 func non200Err(response *http.Response) error {
 	bodyBytes, err := io.ReadAll(response.Body)
 	var errMessage ErrorMessage
@@ -119,6 +119,48 @@ func TestClient_RegisterValidator(t *testing.T) {
 	require.NoError(t, c.RegisterValidator(ctx, []*eth.SignedValidatorRegistrationV1{reg}))
 }
 
+func TestClient_ValidatorRegistration(t *testing.T) {
+	ctx := context.Background()
+	pubkey := "0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"
+	expectedPath := "/relay/v1/data/validator_registration"
+	response := `{"message":{"fee_recipient":"0xabcf8e0d4e9587369b2301d0790347320302cc09","gas_limit":"30000000","timestamp":"1663311456","pubkey":"0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"},"signature":"0x1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505cc411d61252fb6cb3fa0017b679f8bb2305b26a285fa2737f175668d0dff91cc1b66ac1fb663c9bc59509846d6ec05345bd908eda73e670af888da41af171505"}`
+	hc := &http.Client{
+		Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+			require.Equal(t, expectedPath, r.URL.Path)
+			require.Equal(t, pubkey, r.URL.Query().Get("pubkey"))
+			require.Equal(t, http.MethodGet, r.Method)
+			return &http.Response{
+				StatusCode: http.StatusOK,
+				Body:       io.NopCloser(bytes.NewBufferString(response)),
+				Request:    r.Clone(ctx),
+			}, nil
+		}),
+	}
+	c := &Client{
+		hc:      hc,
+		baseURL: &url.URL{Host: "localhost:3500", Scheme: "http"},
+	}
+	reg, err := c.ValidatorRegistration(ctx, bytesutil.ToBytes48(ezDecode(t, pubkey)))
+	require.NoError(t, err)
+	require.DeepEqual(t, ezDecode(t, "0xabcf8e0d4e9587369b2301d0790347320302cc09"), reg.FeeRecipient)
+	require.Equal(t, uint64(30000000), reg.GasLimit)
+	require.Equal(t, uint64(1663311456), reg.Timestamp)
+	require.DeepEqual(t, ezDecode(t, pubkey), reg.Pubkey)
+
+	hc = &http.Client{
+		Transport: roundtrip(func(r *http.Request) (*http.Response, error) {
+			return &http.Response{
+				StatusCode: http.StatusNotFound,
+				Body:       io.NopCloser(bytes.NewBufferString(`{"code":404,"message":"no registration found for validator"}`)),
+				Request:    r.Clone(ctx),
+			}, nil
+		}),
+	}
+	c.hc = hc
+	_, err = c.ValidatorRegistration(ctx, bytesutil.ToBytes48(ezDecode(t, pubkey)))
+	require.ErrorIs(t, err, ErrNotFound)
+}
+
 func TestClient_GetHeader(t *testing.T) {
 	ctx := context.Background()
 	expectedPath := "/eth/v1/builder/header/23/0xcf8e0d4e9587369b2301d0790347320302cc0943d5a1884560367e8208d920f2/0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"
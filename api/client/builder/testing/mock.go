@@ -48,3 +48,8 @@ func (MockClient) SubmitBlindedBlock(_ context.Context, _ interfaces.ReadOnlySig
 func (MockClient) Status(_ context.Context) error {
 	return nil
 }
+
+// ValidatorRegistration --
+func (MockClient) ValidatorRegistration(_ context.Context, _ [48]byte) (*ethpb.ValidatorRegistrationV1, error) {
+	return nil, builder.ErrNotFound
+}
@@ -15,6 +15,8 @@ const (
 	localKeysPath    = "/eth/v1/keystores"
 	remoteKeysPath   = "/eth/v1/remotekeys"
 	feeRecipientPath = "/eth/v1/validator/{pubkey}/feerecipient"
+
+	proposerSettingsPreviewPath = "/v2/validator/proposer-settings/preview"
 )
 
 // Client provides a collection of helper methods for calling the Keymanager API endpoints.
@@ -119,3 +121,17 @@ func (c *Client) GetFeeRecipientAddress(ctx context.Context, pubkey string) (*ap
 	}
 	return feejson, nil
 }
+
+// GetProposerSettingsPreview calls the validator client API returning the effective proposer settings of every key,
+// along with what the beacon node and its relays know about the key.
+func (c *Client) GetProposerSettingsPreview(ctx context.Context) (*apimiddleware.ProposerSettingsPreviewResponseJson, error) {
+	b, err := c.Get(ctx, proposerSettingsPreviewPath, client.WithAuthorizationToken(c.Token()))
+	if err != nil {
+		return nil, err
+	}
+	preview := &apimiddleware.ProposerSettingsPreviewResponseJson{}
+	if err := json.Unmarshal(b, preview); err != nil {
+		return nil, errors.Wrap(err, "failed to parse proposer settings preview")
+	}
+	return preview, nil
+}
@@ -7,6 +7,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice"
@@ -159,6 +160,8 @@ func (s *Service) saveHead(ctx context.Context, newHeadRoot [32]byte, headBlock
 		return errors.Wrap(err, "could not save head root in DB")
 	}
 
+	s.queueLightClientUpdates(headBlock)
+
 	// Forward an event capturing a new chain head over a common event feed
 	// done in a goroutine to avoid blocking the critical runtime main routine.
 	go func() {
@@ -406,15 +409,20 @@ func (s *Service) saveOrphanedOperations(ctx context.Context, orphanedRoot [32]b
 		for _, as := range orphanedBlk.Block().Body().AttesterSlashings() {
 			if err := s.cfg.SlashingPool.InsertAttesterSlashing(ctx, s.headStateReadOnly(ctx), as); err != nil {
 				log.WithError(err).Error("Could not insert reorg attester slashing")
+				continue
 			}
+			s.sendOperationEvent(opfeed.AttesterSlashingReceived, &opfeed.AttesterSlashingReceivedData{AttesterSlashing: as})
 		}
 		for _, vs := range orphanedBlk.Block().Body().ProposerSlashings() {
 			if err := s.cfg.SlashingPool.InsertProposerSlashing(ctx, s.headStateReadOnly(ctx), vs); err != nil {
 				log.WithError(err).Error("Could not insert reorg proposer slashing")
+				continue
 			}
+			s.sendOperationEvent(opfeed.ProposerSlashingReceived, &opfeed.ProposerSlashingReceivedData{ProposerSlashing: vs})
 		}
 		for _, v := range orphanedBlk.Block().Body().VoluntaryExits() {
 			s.cfg.ExitPool.InsertVoluntaryExit(v)
+			s.sendOperationEvent(opfeed.ExitReceived, &opfeed.ExitReceivedData{Exit: v})
 		}
 		if orphanedBlk.Version() >= version.Capella {
 			changes, err := orphanedBlk.Block().Body().BLSToExecutionChanges()
@@ -430,3 +438,15 @@ func (s *Service) saveOrphanedOperations(ctx context.Context, orphanedRoot [32]b
 	}
 	return nil
 }
+
+// sendOperationEvent notifies the operation feed subscribers, if any, of an operation
+// which was inserted back into the pools.
+func (s *Service) sendOperationEvent(typ feed.EventType, data interface{}) {
+	if s.cfg.OperationNotifier == nil {
+		return
+	}
+	s.cfg.OperationNotifier.OperationFeed().Send(&feed.Event{
+		Type: typ,
+		Data: data,
+	})
+}
@@ -8,6 +8,8 @@ import (
 	"time"
 
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	testDB "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/blstoexec"
@@ -387,6 +389,12 @@ func TestSaveOrphanedOps(t *testing.T) {
 		util.SaveBlock(t, ctx, beaconDB, blk)
 	}
 
+	notifier := &mock.MockOperationNotifier{}
+	service.cfg.OperationNotifier = notifier
+	opChannel := make(chan *feed.Event, 3)
+	opSub := notifier.OperationFeed().Subscribe(opChannel)
+	defer opSub.Unsubscribe()
+
 	require.NoError(t, service.saveOrphanedOperations(ctx, r3, r4))
 	require.Equal(t, 3, service.cfg.AttPool.AggregatedAttestationCount())
 	wantAtts := []*ethpb.Attestation{
@@ -404,6 +412,11 @@ func TestSaveOrphanedOps(t *testing.T) {
 	exits, err := service.cfg.ExitPool.PendingExits()
 	require.NoError(t, err)
 	require.Equal(t, 1, len(exits))
+	// The operations inserted back into the pools are sent to the operation feed.
+	for _, typ := range []feed.EventType{opfeed.AttesterSlashingReceived, opfeed.ProposerSlashingReceived, opfeed.ExitReceived} {
+		e := <-opChannel
+		require.Equal(t, typ, e.Type)
+	}
 }
 
 func TestSaveOrphanedAtts_CanFilter(t *testing.T) {
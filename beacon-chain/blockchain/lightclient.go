@@ -0,0 +1,178 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"go.opencensus.io/trace"
+)
+
+// LightClientFinalityUpdate returns the latest light client finality update, or nil if none was computed yet.
+func (s *Service) LightClientFinalityUpdate() *ethpbv2.LightClientFinalityUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lightClientFinalityUpdate
+}
+
+// LightClientOptimisticUpdate returns the latest light client optimistic update, or nil if none was computed yet.
+func (s *Service) LightClientOptimisticUpdate() *ethpbv2.LightClientOptimisticUpdate {
+	s.lightClientLock.RLock()
+	defer s.lightClientLock.RUnlock()
+	return s.lightClientOptimisticUpdate
+}
+
+// lightClientHeadsSize is the number of canonical heads waiting for their light client updates to be computed. Heads
+// are dropped while the queue is full, the updates of the following heads supersede theirs.
+const lightClientHeadsSize = 8
+
+// queueLightClientUpdates queues a new canonical head for the light client updates worker, without blocking the head
+// update.
+func (s *Service) queueLightClientUpdates(headBlock interfaces.ReadOnlySignedBeaconBlock) {
+	if !features.Get().EnableLightClient {
+		return
+	}
+	select {
+	case s.lightClientHeads <- headBlock:
+	default:
+		log.WithField("slot", headBlock.Block().Slot()).Debug("Light client updates queue is full, dropping head")
+	}
+}
+
+// runLightClientUpdates computes the light client updates of the canonical heads one at a time. Computing them
+// requires merkle proofs of the parent state, which is done in the background to avoid adding more load to the block
+// import.
+func (s *Service) runLightClientUpdates() {
+	for {
+		select {
+		case headBlock := <-s.lightClientHeads:
+			if err := s.processLightClientUpdates(s.ctx, headBlock); err != nil {
+				log.WithError(err).Error("Could not process light client updates")
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// processLightClientUpdates computes the light client update for the sync aggregate of the block. The update is
+// saved if it is the best update of its sync committee period, and the finality and optimistic updates it contains
+// are published if they are newer than the ones published before.
+func (s *Service) processLightClientUpdates(ctx context.Context, signed interfaces.ReadOnlySignedBeaconBlock) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.processLightClientUpdates")
+	defer span.End()
+
+	if signed.Version() < version.Altair {
+		return nil
+	}
+	attestedState, err := s.cfg.StateGen.StateByRoot(ctx, signed.Block().ParentRoot())
+	if err != nil {
+		return errors.Wrap(err, "could not get attested state")
+	}
+	// The parent of the first Altair block has no sync committees.
+	if attestedState.Version() < version.Altair {
+		return nil
+	}
+	var finalizedBlock interfaces.ReadOnlySignedBeaconBlock
+	finalizedRoot := bytesutil.ToBytes32(attestedState.FinalizedCheckpoint().Root)
+	if finalizedRoot != params.BeaconConfig().ZeroHash {
+		finalizedBlock, err = s.getBlock(ctx, finalizedRoot)
+		if err != nil {
+			return errors.Wrap(err, "could not get finalized block")
+		}
+	}
+	update, err := lightclient.NewLightClientUpdate(ctx, signed, attestedState, finalizedBlock)
+	if errors.Is(err, lightclient.ErrNotEnoughParticipants) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not create light client update")
+	}
+
+	s.lightClientLock.Lock()
+	defer s.lightClientLock.Unlock()
+
+	period := lightclient.SyncCommitteePeriod(update.AttestedHeader.Slot)
+	best, err := s.cfg.BeaconDB.LightClientUpdates(ctx, period, period)
+	if err != nil {
+		return errors.Wrap(err, "could not get best light client update")
+	}
+	if len(best) == 0 || lightclient.IsBetterUpdate(update, best[0]) {
+		if err := s.cfg.BeaconDB.SaveLightClientUpdate(ctx, period, update); err != nil {
+			return errors.Wrap(err, "could not save light client update")
+		}
+	}
+
+	dataVersion := lightclient.DataVersion(update.AttestedHeader.Slot)
+	finality := lightclient.FinalityUpdate(update)
+	if lightclient.IsFinalityUpdate(update) && isNewerFinalityUpdate(finality, s.lightClientFinalityUpdate) {
+		s.lightClientFinalityUpdate = finality
+		s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.LightClientFinalityUpdate,
+			Data: &ethpbv2.LightClientFinalityUpdateWithVersion{Version: dataVersion, Data: finality},
+		})
+		if s.cfg.P2p != nil {
+			if err := s.cfg.P2p.Broadcast(ctx, finality); err != nil {
+				log.WithError(err).Debug("Could not broadcast light client finality update")
+			}
+		}
+	}
+	optimistic := lightclient.OptimisticUpdate(update)
+	if s.lightClientOptimisticUpdate == nil || optimistic.AttestedHeader.Slot > s.lightClientOptimisticUpdate.AttestedHeader.Slot {
+		s.lightClientOptimisticUpdate = optimistic
+		s.cfg.StateNotifier.StateFeed().Send(&feed.Event{
+			Type: statefeed.LightClientOptimisticUpdate,
+			Data: &ethpbv2.LightClientOptimisticUpdateWithVersion{Version: dataVersion, Data: optimistic},
+		})
+		if s.cfg.P2p != nil {
+			if err := s.cfg.P2p.Broadcast(ctx, optimistic); err != nil {
+				log.WithError(err).Debug("Could not broadcast light client optimistic update")
+			}
+		}
+	}
+	return nil
+}
+
+// saveLightClientBootstrap saves the light client bootstrap of the finalized block with the given root, so that
+// light clients can start syncing from the finalized checkpoint.
+func (s *Service) saveLightClientBootstrap(ctx context.Context, root [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.saveLightClientBootstrap")
+	defer span.End()
+
+	blk, err := s.getBlock(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized block")
+	}
+	if blk.Version() < version.Altair {
+		return nil
+	}
+	st, err := s.cfg.StateGen.StateByRoot(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized state")
+	}
+	bootstrap, err := lightclient.NewLightClientBootstrap(ctx, st, blk)
+	if err != nil {
+		return errors.Wrap(err, "could not create light client bootstrap")
+	}
+	return s.cfg.BeaconDB.SaveLightClientBootstrap(ctx, root, bootstrap)
+}
+
+// isNewerFinalityUpdate returns true if the update finalizes a newer header than the current update, or the
+// same header with a supermajority of the sync committee when the current update does not have one.
+func isNewerFinalityUpdate(update, current *ethpbv2.LightClientFinalityUpdate) bool {
+	if current == nil {
+		return true
+	}
+	if update.FinalizedHeader.Slot != current.FinalizedHeader.Slot {
+		return update.FinalizedHeader.Slot > current.FinalizedHeader.Slot
+	}
+	return lightclient.HasSupermajority(update.SyncAggregate) && !lightclient.HasSupermajority(current.SyncAggregate)
+}
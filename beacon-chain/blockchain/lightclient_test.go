@@ -0,0 +1,153 @@
+package blockchain
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestService_processLightClientUpdates(t *testing.T) {
+	ctx := context.Background()
+	broadcaster := &mockBroadcaster{}
+	s, tr := minimalTestService(t, WithP2PBroadcaster(broadcaster))
+	events := make(chan *feed.Event, 2)
+	sub := tr.notif.StateFeed().Subscribe(events)
+	defer sub.Unsubscribe()
+
+	f := util.NewBeaconBlockAltair()
+	f.Block.Slot = 1
+	finalized, err := blocks.NewSignedBeaconBlock(f)
+	require.NoError(t, err)
+	require.NoError(t, tr.db.SaveBlock(ctx, finalized))
+	finalizedRoot, err := f.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	// The attested block and its post state.
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(10))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}))
+	a := util.NewBeaconBlockAltair()
+	a.Block.Slot = 10
+	bodyRoot, err := a.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: 10, BodyRoot: bodyRoot[:]})))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	a.Block.StateRoot = stateRoot[:]
+	attested, err := blocks.NewSignedBeaconBlock(a)
+	require.NoError(t, err)
+	require.NoError(t, tr.db.SaveBlock(ctx, attested))
+	attestedRoot, err := a.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, tr.db.SaveState(ctx, st, attestedRoot))
+
+	newBlock := func(slot primitives.Slot, participants uint64) *ethpb.SignedBeaconBlockAltair {
+		b := util.NewBeaconBlockAltair()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = attestedRoot[:]
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < participants; i++ {
+			bits.SetBitAt(i, true)
+		}
+		b.Block.Body.SyncAggregate.SyncCommitteeBits = bits
+		return b
+	}
+	signed, err := blocks.NewSignedBeaconBlock(newBlock(11, 300))
+	require.NoError(t, err)
+	require.NoError(t, s.processLightClientUpdates(ctx, signed))
+
+	finality := s.LightClientFinalityUpdate()
+	require.NotNil(t, finality)
+	assert.Equal(t, primitives.Slot(1), finality.FinalizedHeader.Slot)
+	assert.Equal(t, primitives.Slot(11), finality.SignatureSlot)
+	optimistic := s.LightClientOptimisticUpdate()
+	require.NotNil(t, optimistic)
+	assert.Equal(t, primitives.Slot(10), optimistic.AttestedHeader.Slot)
+	assert.Equal(t, true, broadcaster.broadcastCalled)
+
+	e := <-events
+	assert.Equal(t, statefeed.LightClientFinalityUpdate, int(e.Type))
+	_, ok := e.Data.(*ethpbv2.LightClientFinalityUpdateWithVersion)
+	assert.Equal(t, true, ok)
+	e = <-events
+	assert.Equal(t, statefeed.LightClientOptimisticUpdate, int(e.Type))
+
+	updates, err := tr.db.LightClientUpdates(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(updates))
+	assert.Equal(t, uint64(300), updates[0].SyncAggregate.SyncCommitteeBits.Count())
+
+	// A later block signing the same parent with a supermajority is the best update of the period,
+	// and replaces the finality update of the same finalized header.
+	signed, err = blocks.NewSignedBeaconBlock(newBlock(12, 400))
+	require.NoError(t, err)
+	require.NoError(t, s.processLightClientUpdates(ctx, signed))
+	assert.Equal(t, primitives.Slot(12), s.LightClientFinalityUpdate().SignatureSlot)
+	// The optimistic update does not change as the attested header is the same.
+	assert.Equal(t, primitives.Slot(11), s.LightClientOptimisticUpdate().SignatureSlot)
+	updates, err = tr.db.LightClientUpdates(ctx, 0, 0)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(updates))
+	assert.Equal(t, uint64(400), updates[0].SyncAggregate.SyncCommitteeBits.Count())
+
+	// Blocks without enough sync committee participants do not produce updates.
+	signed, err = blocks.NewSignedBeaconBlock(newBlock(13, 0))
+	require.NoError(t, err)
+	require.NoError(t, s.processLightClientUpdates(ctx, signed))
+	assert.Equal(t, primitives.Slot(12), s.LightClientFinalityUpdate().SignatureSlot)
+
+	// The bootstrap of the attested block is saved once it is finalized.
+	require.NoError(t, s.saveLightClientBootstrap(ctx, attestedRoot))
+	bootstrap, err := tr.db.LightClientBootstrap(ctx, attestedRoot)
+	require.NoError(t, err)
+	require.NotNil(t, bootstrap)
+	assert.Equal(t, primitives.Slot(10), bootstrap.Header.Slot)
+}
+
+func TestService_queueLightClientUpdates(t *testing.T) {
+	s, _ := minimalTestService(t)
+	signed, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockAltair())
+	require.NoError(t, err)
+
+	s.queueLightClientUpdates(signed)
+	assert.Equal(t, 0, len(s.lightClientHeads))
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableLightClient: true})
+	defer resetCfg()
+	// The heads are dropped instead of blocking the head update once the queue is full.
+	for i := 0; i < lightClientHeadsSize+1; i++ {
+		s.queueLightClientUpdates(signed)
+	}
+	assert.Equal(t, lightClientHeadsSize, len(s.lightClientHeads))
+}
+
+func TestIsNewerFinalityUpdate(t *testing.T) {
+	update := func(finalizedSlot primitives.Slot, participants uint64) *ethpbv2.LightClientFinalityUpdate {
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < participants; i++ {
+			bits.SetBitAt(i, true)
+		}
+		return &ethpbv2.LightClientFinalityUpdate{
+			FinalizedHeader: &ethpbv1.BeaconBlockHeader{Slot: finalizedSlot},
+			SyncAggregate:   &ethpbv1.SyncAggregate{SyncCommitteeBits: bits},
+		}
+	}
+	assert.Equal(t, true, isNewerFinalityUpdate(update(32, 1), nil))
+	assert.Equal(t, true, isNewerFinalityUpdate(update(64, 1), update(32, 500)))
+	assert.Equal(t, false, isNewerFinalityUpdate(update(32, 500), update(64, 1)))
+	assert.Equal(t, true, isNewerFinalityUpdate(update(32, 400), update(32, 300)))
+	assert.Equal(t, false, isNewerFinalityUpdate(update(32, 500), update(32, 400)))
+}
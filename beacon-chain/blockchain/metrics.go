@@ -9,6 +9,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
@@ -19,6 +20,18 @@ import (
 )
 
 var (
+	finalizedRewardsPreBurn = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pulse_finalized_rewards_pre_burn_gwei_total",
+		Help: "Sum of the rewards of finalized blocks and epochs before the PulseChain burn, by reward source",
+	}, []string{"source"})
+	finalizedRewardsPostBurn = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pulse_finalized_rewards_post_burn_gwei_total",
+		Help: "Sum of the rewards of finalized blocks and epochs after the PulseChain burn, by reward source",
+	}, []string{"source"})
+	finalizedRewardsBurned = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "pulse_finalized_rewards_burned_gwei_total",
+		Help: "Amount of rewards of finalized blocks and epochs removed by the PulseChain burn, by reward source",
+	}, []string{"source"})
 	beaconSlot = promauto.NewGauge(prometheus.GaugeOpts{
 		Name: "beacon_slot",
 		Help: "Latest slot of the beacon chain state",
@@ -214,6 +227,18 @@ var (
 			Buckets: []float64{1, 2, 4, 8, 16, 32},
 		},
 	)
+	lastValidatorHistoryEpoch = promauto.NewGauge(prometheus.GaugeOpts{
+		Name: "validator_history_last_indexed_epoch",
+		Help: "Highest finalized epoch for which the balances and statuses of the validators were saved",
+	})
+	validatorHistoryEpochs = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "validator_history_indexed_epochs_total",
+		Help: "Number of finalized epochs added to the validator history index",
+	})
+	droppedValidatorHistorySnapshots = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "validator_history_dropped_snapshots_total",
+		Help: "Number of validator history snapshots dropped because too many were waiting for finalization",
+	})
 )
 
 // reportSlotMetrics reports slot related metrics.
@@ -375,3 +400,15 @@ func reportAttestationInclusion(blk interfaces.ReadOnlyBeaconBlock) {
 		attestationInclusionDelay.Observe(float64(blk.Slot() - att.Data.Slot))
 	}
 }
+
+// reportFinalizedBurn adds the burn of newly finalized epochs to the burn counters.
+func reportFinalizedBurn(burns []*pulse.EpochBurn) {
+	for _, b := range burns {
+		for _, src := range pulse.BurnSources {
+			t := b.Source(src)
+			finalizedRewardsPreBurn.WithLabelValues(src.String()).Add(float64(t.PreBurn))
+			finalizedRewardsPostBurn.WithLabelValues(src.String()).Add(float64(t.PostBurn))
+			finalizedRewardsBurned.WithLabelValues(src.String()).Add(float64(t.Burned()))
+		}
+	}
+}
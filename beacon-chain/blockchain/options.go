@@ -4,6 +4,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositcache"
+	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
@@ -16,6 +17,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
@@ -125,6 +127,14 @@ func WithStateNotifier(n statefeed.Notifier) Option {
 	}
 }
 
+// WithOperationNotifier to notify an event feed of operations inserted back into the pools.
+func WithOperationNotifier(n opfeed.Notifier) Option {
+	return func(s *Service) error {
+		s.cfg.OperationNotifier = n
+		return nil
+	}
+}
+
 // WithForkChoiceStore to update an optimized fork-choice representation.
 func WithForkChoiceStore(f forkchoice.ForkChoicer) Option {
 	return func(s *Service) error {
@@ -157,6 +167,16 @@ func WithSlasherAttestationsFeed(f *event.Feed) Option {
 	}
 }
 
+// WithValidatorHistory to save the balances, effective balances and statuses of the validators at the start of every
+// finalized epoch, keeping the given number of epochs. A retention of zero keeps all epochs.
+func WithValidatorHistory(retention primitives.Epoch) Option {
+	return func(s *Service) error {
+		s.cfg.ValidatorHistory = true
+		s.cfg.ValidatorHistoryRetention = retention
+		return nil
+	}
+}
+
 // WithFinalizedStateAtStartUp to store finalized state at start up.
 func WithFinalizedStateAtStartUp(st state.BeaconState) Option {
 	return func(s *Service) error {
@@ -10,6 +10,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	coreTime "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
@@ -122,7 +123,13 @@ func (s *Service) onBlock(ctx context.Context, signed interfaces.ReadOnlySignedB
 		return err
 	}
 	stateTransitionStartTime := time.Now()
-	postState, err := transition.ExecuteStateTransition(ctx, preState, signed)
+	burn := pulse.NewBurnTracker()
+	transitionCtx := pulse.WithBurnTracker(ctx, burn)
+	st, err := s.advanceToEpochStarts(transitionCtx, preState, b.ParentRoot(), b.Slot())
+	if err != nil {
+		return invalidBlock{error: err}
+	}
+	postState, err := transition.ExecuteStateTransition(transitionCtx, st, signed)
 	if err != nil {
 		return invalidBlock{error: err}
 	}
@@ -145,6 +152,9 @@ func (s *Service) onBlock(ctx context.Context, signed interfaces.ReadOnlySignedB
 	if err := s.savePostStateInfo(ctx, blockRoot, signed, postState); err != nil {
 		return err
 	}
+	if err := s.cfg.BeaconDB.SaveBlockBurn(ctx, b.Slot(), blockRoot, burn.Epochs()); err != nil {
+		return errors.Wrap(err, "could not save block burn")
+	}
 
 	if err := s.insertBlockToForkchoiceStore(ctx, signed.Block(), blockRoot, postState); err != nil {
 		return errors.Wrapf(err, "could not insert block %d to fork choice store", signed.Block().Slot())
@@ -356,6 +366,7 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.ReadOnlySi
 	postVersionAndHeaders := make([]*versionAndHeader, len(blks))
 	var set *bls.SignatureBatch
 	boundaries := make(map[[32]byte]state.BeaconState)
+	burns := make([]*pulse.BurnTracker, len(blks))
 	for i, b := range blks {
 		v, h, err := getStateVersionAndPayload(preState)
 		if err != nil {
@@ -366,7 +377,13 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.ReadOnlySi
 			header:  h,
 		}
 
-		set, preState, err = transition.ExecuteStateTransitionNoVerifyAnySig(ctx, preState, b)
+		burns[i] = pulse.NewBurnTracker()
+		transitionCtx := pulse.WithBurnTracker(ctx, burns[i])
+		preState, err = s.advanceToEpochStarts(transitionCtx, preState, b.Block().ParentRoot(), b.Block().Slot())
+		if err != nil {
+			return invalidBlock{error: err}
+		}
+		set, preState, err = transition.ExecuteStateTransitionNoVerifyAnySig(transitionCtx, preState, b)
 		if err != nil {
 			return invalidBlock{error: err}
 		}
@@ -425,6 +442,10 @@ func (s *Service) onBlockBatch(ctx context.Context, blks []interfaces.ReadOnlySi
 			tracing.AnnotateError(span, err)
 			return err
 		}
+		if err := s.cfg.BeaconDB.SaveBlockBurn(ctx, b.Block().Slot(), blockRoots[i], burns[i].Epochs()); err != nil {
+			tracing.AnnotateError(span, err)
+			return err
+		}
 		if err := s.cfg.BeaconDB.SaveStateSummary(ctx, &ethpb.StateSummary{
 			Slot: b.Block().Slot(),
 			Root: blockRoots[i][:],
@@ -4,10 +4,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -123,6 +126,13 @@ func (s *Service) updateFinalized(ctx context.Context, cp *ethpb.Checkpoint) err
 	if err := s.cfg.BeaconDB.SaveFinalizedCheckpoint(ctx, cp); err != nil {
 		return err
 	}
+	// Burn accounting is for reporting only, it must not prevent the node from following the chain.
+	burns, err := s.cfg.BeaconDB.AggregateFinalizedBurn(ctx)
+	if err != nil {
+		log.WithError(err).Error("Could not aggregate finalized reward burn")
+	}
+	reportFinalizedBurn(burns)
+	s.saveFinalizedValidatorHistory(ctx, cp.Epoch)
 
 	fRoot := bytesutil.ToBytes32(cp.Root)
 	optimistic, err := s.cfg.ForkChoiceStore.IsOptimistic(fRoot)
@@ -142,6 +152,11 @@ func (s *Service) updateFinalized(ctx context.Context, cp *ethpb.Checkpoint) err
 		if err := s.cfg.StateGen.MigrateToCold(s.ctx, fRoot); err != nil {
 			log.WithError(err).Error("could not migrate to cold")
 		}
+		if features.Get().EnableLightClient {
+			if err := s.saveLightClientBootstrap(s.ctx, fRoot); err != nil {
+				log.WithError(err).Error("Could not save light client bootstrap")
+			}
+		}
 	}()
 	return nil
 }
@@ -230,13 +245,44 @@ func (s *Service) insertFinalizedDeposits(ctx context.Context, fRoot [32]byte) e
 	// to be included(rather than the last one to be processed). This was most likely
 	// done as the state cannot represent signed integers.
 	eth1DepositIndex -= 1
-	if err = s.cfg.DepositCache.InsertFinalizedDeposits(ctx, int64(eth1DepositIndex)); err != nil {
+	// The finalized deposits can only be attributed to the eth1 data block once all of its deposits are included.
+	var executionHash common.Hash
+	var executionNumber uint64
+	eth1Data := finalizedState.Eth1Data()
+	if eth1Data.DepositCount == finalizedState.Eth1DepositIndex() {
+		executionHash = common.BytesToHash(eth1Data.BlockHash)
+		if s.cfg.BlockFetcher != nil {
+			exists, height, err := s.cfg.BlockFetcher.BlockExists(ctx, executionHash)
+			if err != nil {
+				log.WithError(err).Debug("Could not fetch execution block of finalized eth1 data")
+			} else if exists && height != nil {
+				executionNumber = height.Uint64()
+			}
+		}
+	}
+	if err = s.cfg.DepositCache.InsertFinalizedDeposits(ctx, int64(eth1DepositIndex), executionHash, executionNumber); err != nil {
 		return err
 	}
 	// Deposit proofs are only used during state transition and can be safely removed to save space.
 	if err = s.cfg.DepositCache.PruneProofs(ctx, int64(eth1DepositIndex)); err != nil {
 		return errors.Wrap(err, "could not prune deposit proofs")
 	}
+	return s.saveDepositSnapshot(ctx)
+}
+
+// saveDepositSnapshot persists the snapshot of the finalized deposit tree, so that the
+// deposit tree can be restored without all historical deposits.
+func (s *Service) saveDepositSnapshot(ctx context.Context) error {
+	snapshot, err := s.cfg.DepositCache.DepositSnapshot(ctx)
+	if errors.Is(err, depositsnapshot.ErrEmptyExecutionBlock) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not get deposit snapshot")
+	}
+	if err := s.cfg.BeaconDB.SaveDepositSnapshot(ctx, snapshot); err != nil {
+		return errors.Wrap(err, "could not save deposit snapshot")
+	}
 	return nil
 }
 
@@ -15,6 +15,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
@@ -36,6 +37,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
@@ -60,6 +62,18 @@ type Service struct {
 	wsVerifier            *WeakSubjectivityVerifier
 	clockSetter           startup.ClockSetter
 	clockWaiter           startup.ClockWaiter
+
+	lightClientLock             sync.RWMutex
+	lightClientFinalityUpdate   *ethpbv2.LightClientFinalityUpdate
+	lightClientOptimisticUpdate *ethpbv2.LightClientOptimisticUpdate
+	lightClientHeads            chan interfaces.ReadOnlySignedBeaconBlock
+
+	validatorHistoryLock        sync.Mutex
+	pendingValidatorHistory     map[primitives.Epoch][]*pendingEpochSnapshot
+	pendingValidatorHistorySize int
+	validatorHistoryNext        primitives.Epoch
+	missingValidatorHistory     []primitives.Epoch
+	validatorHistoryBackfill    chan struct{}
 }
 
 // config options for the service.
@@ -76,6 +90,7 @@ type config struct {
 	P2p                     p2p.Broadcaster
 	MaxRoutines             int
 	StateNotifier           statefeed.Notifier
+	OperationNotifier       opfeed.Notifier
 	ForkChoiceStore         f.ForkChoicer
 	AttService              *attestations.Service
 	StateGen                *stategen.State
@@ -84,6 +99,9 @@ type config struct {
 	BlockFetcher            execution.POWBlockFetcher
 	FinalizedStateAtStartUp state.BeaconState
 	ExecutionEngineCaller   execution.EngineCaller
+	// ValidatorHistory enables the snapshots of the validators at the start of every finalized epoch.
+	ValidatorHistory          bool
+	ValidatorHistoryRetention primitives.Epoch
 }
 
 var ErrMissingClockSetter = errors.New("blockchain Service initialized without a startup.ClockSetter")
@@ -93,12 +111,15 @@ var ErrMissingClockSetter = errors.New("blockchain Service initialized without a
 func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	srv := &Service{
-		ctx:                  ctx,
-		cancel:               cancel,
-		boundaryRoots:        [][32]byte{},
-		checkpointStateCache: cache.NewCheckpointStateCache(),
-		initSyncBlocks:       make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock),
-		cfg:                  &config{ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache()},
+		ctx:                      ctx,
+		cancel:                   cancel,
+		boundaryRoots:            [][32]byte{},
+		checkpointStateCache:     cache.NewCheckpointStateCache(),
+		initSyncBlocks:           make(map[[32]byte]interfaces.ReadOnlySignedBeaconBlock),
+		lightClientHeads:         make(chan interfaces.ReadOnlySignedBeaconBlock, lightClientHeadsSize),
+		pendingValidatorHistory:  make(map[primitives.Epoch][]*pendingEpochSnapshot),
+		validatorHistoryBackfill: make(chan struct{}, 1),
+		cfg:                      &config{ProposerSlotIndexCache: cache.NewProposerPayloadIDsCache()},
 	}
 	for _, opt := range opts {
 		if err := opt(srv); err != nil {
@@ -131,6 +152,15 @@ func (s *Service) Start() {
 	}
 	s.spawnProcessAttestationsRoutine()
 	go s.runLateBlockTasks()
+	if features.Get().EnableLightClient {
+		go s.runLightClientUpdates()
+	}
+	if s.cfg.ValidatorHistory {
+		if err := s.initValidatorHistory(s.ctx); err != nil {
+			log.WithError(err).Error("Could not initialize validator history")
+		}
+		go s.runValidatorHistoryBackfill()
+	}
 }
 
 // Stop the blockchain service's main event loop and associated goroutines.
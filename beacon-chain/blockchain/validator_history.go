@@ -0,0 +1,260 @@
+package blockchain
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// maxPendingValidatorHistory is the maximum number of snapshots of the validators kept until finalization. Each
+// snapshot holds the whole registry, so during long periods of non-finality the snapshots of the later epochs are
+// dropped, and regenerated from the finalized states once the epochs are finalized.
+const maxPendingValidatorHistory = 32
+
+// pendingEpochSnapshot is a snapshot of the validators at the start of an epoch which is not finalized yet. The
+// state at the start of an epoch only depends on the last block before it, so the snapshot is kept along with the
+// root and slot of that block until finalization tells which snapshot is canonical.
+type pendingEpochSnapshot struct {
+	parentRoot [32]byte
+	parentSlot primitives.Slot
+	snapshot   *precompute.EpochSnapshot
+}
+
+// advanceToEpochStarts processes the slots of the pre state of a block up to the start of each epoch the block's slot
+// is past, when the validator history is enabled, and keeps the snapshots of the validators at these epochs until
+// they are finalized. The state transition of the block continues from the returned state, so that the snapshots are
+// taken from the epoch transitions the block import processes anyway, without processing them a second time.
+func (s *Service) advanceToEpochStarts(
+	ctx context.Context, st state.BeaconState, parentRoot [32]byte, slot primitives.Slot,
+) (state.BeaconState, error) {
+	if !s.cfg.ValidatorHistory {
+		return st, nil
+	}
+	ctx, span := trace.StartSpan(ctx, "blockChain.advanceToEpochStarts")
+	defer span.End()
+
+	parentSlot := st.Slot()
+	for e := slots.ToEpoch(parentSlot) + 1; e <= slots.ToEpoch(slot); e++ {
+		start, err := slots.EpochStart(e)
+		if err != nil {
+			return nil, err
+		}
+		// Only the state of the parent slot can be advanced with the next slot cache.
+		if st.Slot() == parentSlot {
+			st, err = transition.ProcessSlotsUsingNextSlotCache(ctx, st, parentRoot[:], start)
+		} else {
+			st, err = transition.ProcessSlots(ctx, st, start)
+		}
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not process slots up to the start of epoch %d", e)
+		}
+		if !s.needsEpochSnapshot(e, parentRoot) {
+			continue
+		}
+		snapshot, err := precompute.NewEpochSnapshot(ctx, st)
+		if err != nil {
+			return nil, err
+		}
+		s.validatorHistoryLock.Lock()
+		s.pendingValidatorHistory[e] = append(s.pendingValidatorHistory[e], &pendingEpochSnapshot{
+			parentRoot: parentRoot,
+			parentSlot: parentSlot,
+			snapshot:   snapshot,
+		})
+		s.pendingValidatorHistorySize++
+		s.validatorHistoryLock.Unlock()
+	}
+	return st, nil
+}
+
+// needsEpochSnapshot returns true if the snapshot of the epoch advanced from the parent block is not pending yet and
+// there is room for it.
+func (s *Service) needsEpochSnapshot(epoch primitives.Epoch, parentRoot [32]byte) bool {
+	s.validatorHistoryLock.Lock()
+	defer s.validatorHistoryLock.Unlock()
+	for _, p := range s.pendingValidatorHistory[epoch] {
+		if p.parentRoot == parentRoot {
+			return false
+		}
+	}
+	if s.pendingValidatorHistorySize >= maxPendingValidatorHistory {
+		droppedValidatorHistorySnapshots.Inc()
+		log.WithField("epoch", epoch).Warn("Too many validator history snapshots waiting for finalization, " +
+			"the snapshot will be regenerated once the epoch is finalized")
+		return false
+	}
+	return true
+}
+
+// initValidatorHistory sets the first finalized epoch whose snapshot is saved to the epoch after the last saved
+// snapshot, and regenerates the snapshots of the epochs finalized since then, which were pending in memory when the
+// node stopped. When no snapshot was saved yet, the history starts with the epochs finalized from now on.
+func (s *Service) initValidatorHistory(ctx context.Context) error {
+	cp, err := s.cfg.BeaconDB.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	next := cp.Epoch + 1
+	last, err := s.cfg.BeaconDB.LastValidatorHistoryEpoch(ctx)
+	switch {
+	case err == nil:
+		next = last + 1
+	case !errors.Is(err, db.ErrNotFound):
+		return errors.Wrap(err, "could not get last validator history epoch")
+	}
+	s.validatorHistoryLock.Lock()
+	s.validatorHistoryNext = next
+	s.validatorHistoryLock.Unlock()
+	s.saveFinalizedValidatorHistory(ctx, cp.Epoch)
+	return nil
+}
+
+// saveFinalizedValidatorHistory saves the canonical snapshots of the validators of the epochs up to the finalized
+// epoch, drops the other ones, and deletes the snapshots out of the retention window. The canonical snapshot of an
+// epoch is the one advanced from the latest finalized block before the epoch. The finalized epochs without a
+// canonical snapshot, which was dropped or not taken by this run of the node, are regenerated in the background.
+// Failures are only logged, like for the other indexes which are not needed to follow the chain.
+func (s *Service) saveFinalizedValidatorHistory(ctx context.Context, finalized primitives.Epoch) {
+	if !s.cfg.ValidatorHistory {
+		return
+	}
+	s.validatorHistoryLock.Lock()
+	defer s.validatorHistoryLock.Unlock()
+
+	from := s.validatorHistoryNext
+	retention := s.cfg.ValidatorHistoryRetention
+	if retention > 0 && finalized >= retention && from < finalized-retention+1 {
+		from = finalized - retention + 1
+	}
+	var missing []primitives.Epoch
+	for e := from; e <= finalized; e++ {
+		var canonical *pendingEpochSnapshot
+		for _, p := range s.pendingValidatorHistory[e] {
+			if (canonical == nil || p.parentSlot > canonical.parentSlot) && s.cfg.BeaconDB.IsFinalizedBlock(ctx, p.parentRoot) {
+				canonical = p
+			}
+		}
+		if canonical == nil {
+			missing = append(missing, e)
+			continue
+		}
+		if err := s.cfg.BeaconDB.SaveValidatorHistory(ctx, canonical.snapshot); err != nil {
+			log.WithError(err).WithField("epoch", e).Error("Could not save validator history")
+			continue
+		}
+		lastValidatorHistoryEpoch.Set(float64(e))
+		validatorHistoryEpochs.Inc()
+		log.WithFields(logrus.Fields{
+			"epoch":      e,
+			"validators": len(canonical.snapshot.Balances),
+		}).Debug("Saved validator history")
+	}
+	for e, pending := range s.pendingValidatorHistory {
+		if e <= finalized {
+			s.pendingValidatorHistorySize -= len(pending)
+			delete(s.pendingValidatorHistory, e)
+		}
+	}
+	if finalized >= s.validatorHistoryNext {
+		s.validatorHistoryNext = finalized + 1
+	}
+	if len(missing) > 0 {
+		s.missingValidatorHistory = append(s.missingValidatorHistory, missing...)
+		select {
+		case s.validatorHistoryBackfill <- struct{}{}:
+		default:
+		}
+	}
+
+	if retention > 0 && finalized >= retention {
+		if err := s.cfg.BeaconDB.DeleteValidatorHistoryBefore(ctx, finalized-retention+1); err != nil {
+			log.WithError(err).Error("Could not delete validator history out of the retention window")
+		}
+	}
+}
+
+// runValidatorHistoryBackfill regenerates the snapshots of the finalized epochs missing from the validator history
+// one at a time, as it requires replaying the finalized states.
+func (s *Service) runValidatorHistoryBackfill() {
+	for {
+		select {
+		case <-s.validatorHistoryBackfill:
+			s.validatorHistoryLock.Lock()
+			epochs := s.missingValidatorHistory
+			s.missingValidatorHistory = nil
+			s.validatorHistoryLock.Unlock()
+			for _, e := range epochs {
+				if s.ctx.Err() != nil {
+					return
+				}
+				if err := s.backfillEpochSnapshot(s.ctx, e); err != nil {
+					log.WithError(err).WithField("epoch", e).Error("Could not regenerate validator history")
+				}
+			}
+		case <-s.ctx.Done():
+			return
+		}
+	}
+}
+
+// backfillEpochSnapshot saves the snapshot of the validators at the start of a finalized epoch, advanced from the
+// state of the latest finalized block before the epoch.
+func (s *Service) backfillEpochSnapshot(ctx context.Context, epoch primitives.Epoch) error {
+	ctx, span := trace.StartSpan(ctx, "blockChain.backfillEpochSnapshot")
+	defer span.End()
+
+	start, err := slots.EpochStart(epoch)
+	if err != nil {
+		return err
+	}
+	root, err := s.finalizedRootBelow(ctx, start)
+	if err != nil {
+		return err
+	}
+	st, err := s.cfg.StateGen.StateByRoot(ctx, root)
+	if err != nil {
+		return errors.Wrap(err, "could not get finalized state")
+	}
+	if st.Slot() < start {
+		if st, err = transition.ProcessSlots(ctx, st, start); err != nil {
+			return errors.Wrapf(err, "could not process slots up to the start of epoch %d", epoch)
+		}
+	}
+	snapshot, err := precompute.NewEpochSnapshot(ctx, st)
+	if err != nil {
+		return err
+	}
+	if err := s.cfg.BeaconDB.SaveValidatorHistory(ctx, snapshot); err != nil {
+		return errors.Wrap(err, "could not save validator history")
+	}
+	validatorHistoryEpochs.Inc()
+	log.WithField("epoch", epoch).Debug("Regenerated validator history")
+	return nil
+}
+
+// finalizedRootBelow returns the root of the latest finalized block with a slot lower than the given slot.
+func (s *Service) finalizedRootBelow(ctx context.Context, slot primitives.Slot) ([32]byte, error) {
+	for {
+		found, roots, err := s.cfg.BeaconDB.HighestRootsBelowSlot(ctx, slot)
+		if err != nil {
+			return [32]byte{}, err
+		}
+		for _, r := range roots {
+			if s.cfg.BeaconDB.IsFinalizedBlock(ctx, r) {
+				return r, nil
+			}
+		}
+		if found == 0 {
+			return [32]byte{}, errors.Errorf("no finalized block below slot %d", slot)
+		}
+		slot = found
+	}
+}
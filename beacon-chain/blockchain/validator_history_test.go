@@ -0,0 +1,127 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestService_ValidatorHistory(t *testing.T) {
+	service, tr := minimalTestService(t, WithValidatorHistory(0))
+	ctx := tr.ctx
+
+	genesis := util.NewBeaconBlock()
+	util.SaveBlock(t, ctx, tr.db, genesis)
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, tr.db.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	ffe := params.BeaconConfig().FarFutureEpoch
+	st, err := util.NewBeaconState(func(s *ethpb.BeaconState) error {
+		s.Validators = []*ethpb.Validator{
+			{EffectiveBalance: maxBalance, ExitEpoch: ffe, WithdrawableEpoch: ffe},
+			{EffectiveBalance: maxBalance, ExitEpoch: ffe, WithdrawableEpoch: ffe},
+		}
+		s.Balances = []uint64{maxBalance, maxBalance + 1}
+		return nil
+	})
+	require.NoError(t, err)
+	forkSt := st.Copy()
+
+	// The block is past the start of epoch 1, whose snapshot is kept until it is finalized.
+	slot := params.BeaconConfig().SlotsPerEpoch + 1
+	advanced, err := service.advanceToEpochStarts(ctx, st, genesisRoot, slot)
+	require.NoError(t, err)
+	assert.Equal(t, params.BeaconConfig().SlotsPerEpoch, advanced.Slot())
+	// A snapshot advanced from a block which is not finalized is dropped at finalization.
+	_, err = service.advanceToEpochStarts(ctx, forkSt, [32]byte{'a'}, slot)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(service.pendingValidatorHistory[1]))
+
+	_, err = tr.db.LastValidatorHistoryEpoch(ctx)
+	require.NotNil(t, err)
+	service.saveFinalizedValidatorHistory(ctx, 1)
+	assert.Equal(t, 0, len(service.pendingValidatorHistory))
+	last, err := tr.db.LastValidatorHistoryEpoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(1), last)
+	history, err := tr.db.ValidatorHistory(ctx, []primitives.ValidatorIndex{1}, 0, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(history[1]))
+	assert.Equal(t, primitives.Epoch(1), history[1][0].Epoch)
+	assert.Equal(t, maxBalance, history[1][0].EffectiveBalance)
+}
+
+func TestService_ValidatorHistory_MaxPending(t *testing.T) {
+	service, tr := minimalTestService(t, WithValidatorHistory(0))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+
+	// Snapshots are dropped when too many are waiting for finalization, and regenerated once finalized.
+	service.pendingValidatorHistorySize = maxPendingValidatorHistory
+	advanced, err := service.advanceToEpochStarts(tr.ctx, st, [32]byte{'a'}, params.BeaconConfig().SlotsPerEpoch+1)
+	require.NoError(t, err)
+	assert.Equal(t, params.BeaconConfig().SlotsPerEpoch, advanced.Slot())
+	assert.Equal(t, 0, len(service.pendingValidatorHistory))
+}
+
+func TestService_ValidatorHistory_Backfill(t *testing.T) {
+	service, tr := minimalTestService(t, WithValidatorHistory(0))
+	ctx := tr.ctx
+
+	genesis := util.NewBeaconBlock()
+	util.SaveBlock(t, ctx, tr.db, genesis)
+	genesisRoot, err := genesis.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, tr.db.SaveGenesisBlockRoot(ctx, genesisRoot))
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	ffe := params.BeaconConfig().FarFutureEpoch
+	st, err := util.NewBeaconState(func(s *ethpb.BeaconState) error {
+		s.Validators = []*ethpb.Validator{{
+			PublicKey:             make([]byte, fieldparams.BLSPubkeyLength),
+			WithdrawalCredentials: make([]byte, 32),
+			EffectiveBalance:      maxBalance,
+			ExitEpoch:             ffe,
+			WithdrawableEpoch:     ffe,
+		}}
+		s.Balances = []uint64{maxBalance}
+		return nil
+	})
+	require.NoError(t, err)
+	require.NoError(t, tr.db.SaveState(ctx, st, genesisRoot))
+	snapshot, err := precompute.NewEpochSnapshot(ctx, st)
+	require.NoError(t, err)
+	require.NoError(t, tr.db.SaveValidatorHistory(ctx, snapshot))
+	require.NoError(t, tr.db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: genesisRoot[:]}))
+
+	// The epochs finalized since the last saved snapshot are regenerated from the finalized states.
+	require.NoError(t, service.initValidatorHistory(ctx))
+	require.DeepEqual(t, []primitives.Epoch{1, 2}, service.missingValidatorHistory)
+	assert.Equal(t, primitives.Epoch(3), service.validatorHistoryNext)
+	for _, e := range service.missingValidatorHistory {
+		require.NoError(t, service.backfillEpochSnapshot(ctx, e))
+	}
+	history, err := tr.db.ValidatorHistory(ctx, []primitives.ValidatorIndex{0}, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(history[0]))
+	assert.Equal(t, primitives.Epoch(2), history[0][2].Epoch)
+	assert.Equal(t, maxBalance, history[0][2].EffectiveBalance)
+}
+
+func TestService_ValidatorHistory_Disabled(t *testing.T) {
+	service, tr := minimalTestService(t)
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	advanced, err := service.advanceToEpochStarts(tr.ctx, st, [32]byte{}, params.BeaconConfig().SlotsPerEpoch+1)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(0), advanced.Slot())
+	assert.Equal(t, 0, len(service.pendingValidatorHistory))
+}
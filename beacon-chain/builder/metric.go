@@ -27,4 +27,32 @@ var (
 			Buckets: []float64{1, 2, 5, 10, 20, 50, 100, 200, 500, 1000},
 		},
 	)
+	relayGetHeaderFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "builder_relay_get_header_failures_total",
+			Help: "The number of get header requests for which a relay failed to return a valid bid in time",
+		},
+		[]string{"relay"},
+	)
+	relayMissedSlots = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "builder_relay_missed_slots_total",
+			Help: "The number of slots for which a relay failed to reveal the payload of its winning bid",
+		},
+		[]string{"relay"},
+	)
+	relayBidsWon = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "builder_relay_bids_won_total",
+			Help: "The number of slots for which a relay provided the highest valid bid",
+		},
+		[]string{"relay"},
+	)
+	relayCircuitBroken = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Name: "builder_relay_circuit_broken",
+			Help: "Set to 1 while a relay is circuit broken and not used for block construction, 0 otherwise",
+		},
+		[]string{"relay"},
+	)
 )
@@ -0,0 +1,146 @@
+package builder
+
+import (
+	"bytes"
+	"fmt"
+	"math/big"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// emptyTransactionsRoot represents the returned value of ssz.TransactionsRoot([][]byte{}) and
+// can be used as a constant to avoid recomputing this value in every call.
+var emptyTransactionsRoot = [32]byte{127, 254, 36, 30, 166, 1, 135, 253, 176, 24, 123, 250, 34, 222, 53, 209, 249, 190, 215, 171, 6, 29, 148, 1, 253, 71, 227, 74, 84, 251, 237, 225}
+
+// relay wraps the client of a single MEV relay together with its circuit breaker state. A relay is circuit
+// broken when it failed `MaxRelayConsecutiveFailures` times in a row, in which case it is skipped for an
+// epoch after its last failure, or when it failed in `MaxRelayEpochFailures` slots of the last epoch.
+type relay struct {
+	client builder.BuilderClient
+
+	sync.RWMutex
+	consecutiveFailures primitives.Slot
+	lastFailure         primitives.Slot
+	failedSlots         []primitives.Slot
+}
+
+func newRelay(c builder.BuilderClient) *relay {
+	return &relay{client: c}
+}
+
+// url returns the endpoint of the relay, used to identify it in logs and metrics.
+func (r *relay) url() string {
+	return r.client.NodeURL()
+}
+
+// recordFailure records that the relay failed to serve a request, or to reveal a payload, at the given slot.
+func (r *relay) recordFailure(slot primitives.Slot) {
+	r.Lock()
+	defer r.Unlock()
+	r.consecutiveFailures++
+	r.lastFailure = slot
+	if n := len(r.failedSlots); n == 0 || r.failedSlots[n-1] != slot {
+		r.failedSlots = append(r.failedSlots, slot)
+	}
+	// Failures older than an epoch no longer count towards the rolling window.
+	i := 0
+	for i < len(r.failedSlots) && r.failedSlots[i]+params.BeaconConfig().SlotsPerEpoch <= slot {
+		i++
+	}
+	r.failedSlots = r.failedSlots[i:]
+}
+
+// recordSuccess resets the consecutive failure count of the relay.
+func (r *relay) recordSuccess() {
+	r.Lock()
+	defer r.Unlock()
+	r.consecutiveFailures = 0
+}
+
+// circuitBroken returns true if the relay must not be used at the given slot.
+func (r *relay) circuitBroken(slot primitives.Slot) bool {
+	r.RLock()
+	defer r.RUnlock()
+	cfg := params.BeaconConfig()
+	if r.consecutiveFailures >= cfg.MaxRelayConsecutiveFailures && slot < r.lastFailure+cfg.SlotsPerEpoch {
+		return true
+	}
+	var missed primitives.Slot
+	for _, s := range r.failedSlots {
+		if s+cfg.SlotsPerEpoch > slot {
+			missed++
+		}
+	}
+	return missed >= cfg.MaxRelayEpochFailures
+}
+
+// validateBid checks that a bid returned by a relay is usable for a block at the given slot, on top of the given
+// parent, and with the timestamp of the slot. Bids which fail these checks are discarded before the bids are ranked,
+// and count as a failure of the relay which returned them.
+func validateBid(signedBid builder.SignedBid, slot primitives.Slot, parentHash [32]byte, genesisTime time.Time) (*big.Int, error) {
+	if signedBid == nil || signedBid.IsNil() {
+		return nil, errors.New("nil bid")
+	}
+	fork, err := forks.Fork(slots.ToEpoch(slot))
+	if err != nil {
+		return nil, errors.Wrap(err, "unable to get fork information")
+	}
+	forkName, ok := params.BeaconConfig().ForkVersionNames[bytesutil.ToBytes4(fork.CurrentVersion)]
+	if !ok {
+		return nil, errors.New("unable to find current fork in schedule")
+	}
+	if !strings.EqualFold(version.String(signedBid.Version()), forkName) {
+		return nil, fmt.Errorf("bid version %s is different from the fork version %s at slot %d", version.String(signedBid.Version()), forkName, slot)
+	}
+	bid, err := signedBid.Message()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid")
+	}
+	if bid.IsNil() {
+		return nil, errors.New("nil bid")
+	}
+	v := bytesutil.LittleEndianBytesToBigInt(bid.Value())
+	if v.Sign() == 0 {
+		return nil, errors.New("bid with 0 value")
+	}
+	header, err := bid.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get bid header")
+	}
+	if !bytes.Equal(header.ParentHash(), parentHash[:]) {
+		return nil, fmt.Errorf("incorrect parent hash %#x != %#x", header.ParentHash(), parentHash)
+	}
+	t, err := slots.ToTime(uint64(genesisTime.Unix()), slot)
+	if err != nil {
+		return nil, err
+	}
+	if header.Timestamp() != uint64(t.Unix()) {
+		return nil, fmt.Errorf("incorrect timestamp %d != %d", header.Timestamp(), uint64(t.Unix()))
+	}
+	txRoot, err := header.TransactionsRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get transaction root")
+	}
+	if bytesutil.ToBytes32(txRoot) == emptyTransactionsRoot {
+		return nil, errors.New("header with an empty tx root")
+	}
+	d, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err := signing.VerifySigningRoot(bid, bid.Pubkey(), signedBid.Signature(), d); err != nil {
+		return nil, errors.Wrap(err, "invalid builder signature")
+	}
+	return v, nil
+}
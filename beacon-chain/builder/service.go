@@ -2,7 +2,11 @@ package builder
 
 import (
 	"context"
+	"fmt"
+	"math/big"
 	"reflect"
+	"strings"
+	"sync"
 	"time"
 
 	"github.com/pkg/errors"
@@ -10,6 +14,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
@@ -22,56 +27,92 @@ import (
 // ErrNoBuilder is used when builder endpoint is not configured.
 var ErrNoBuilder = errors.New("builder endpoint not configured")
 
+// ErrNoRelayAvailable is used when every configured relay is circuit broken.
+var ErrNoRelayAvailable = errors.New("all relays are circuit broken")
+
+// ErrNoTimeFetcher is used when the genesis time, required to check the timestamp of the bids, is not configured.
+var ErrNoTimeFetcher = errors.New("time fetcher not configured")
+
+// getHeaderTimeout is the deadline for the relays to respond with a header, bids received later are ignored. This
+// value is known as `BUILDER_PROPOSAL_DELAY_TOLERANCE` in builder spec.
+const getHeaderTimeout = 1 * time.Second
+
 // BlockBuilder defines the interface for interacting with the block builder
 type BlockBuilder interface {
 	SubmitBlindedBlock(ctx context.Context, block interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, error)
 	GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error)
 	RegisterValidator(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error
 	RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
+	RelayRegistrations(ctx context.Context, pubKey [48]byte) []*RelayRegistration
+	CircuitBroken(slot primitives.Slot) bool
 	Configured() bool
 }
 
+// RelayRegistration is the registration of a validator known by a relay. Registration is nil if the relay could not
+// provide it, in which case Err tells why.
+type RelayRegistration struct {
+	URL          string
+	Registration *ethpb.ValidatorRegistrationV1
+	Err          error
+}
+
 // config defines a config struct for dependencies into the service.
 type config struct {
-	builderClient builder.BuilderClient
-	beaconDB      db.HeadAccessDatabase
-	headFetcher   blockchain.HeadFetcher
+	builderClients []builder.BuilderClient
+	beaconDB       db.HeadAccessDatabase
+	headFetcher    blockchain.HeadFetcher
+	timeFetcher    blockchain.TimeFetcher
+}
+
+// wonBid records the relay whose bid was picked for a slot, so that the blinded block is only revealed to it.
+type wonBid struct {
+	slot  primitives.Slot
+	relay *relay
 }
 
 // Service defines a service that provides a client for interacting with the beacon chain and MEV relay network.
 type Service struct {
 	cfg               *config
-	c                 builder.BuilderClient
+	relays            []*relay
 	ctx               context.Context
 	cancel            context.CancelFunc
 	registrationCache *cache.RegistrationCache
+	wonBidsLock       sync.Mutex
+	wonBids           map[[32]byte]wonBid
 }
 
 // NewService instantiates a new service.
 func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	s := &Service{
-		ctx:    ctx,
-		cancel: cancel,
-		cfg:    &config{},
+		ctx:     ctx,
+		cancel:  cancel,
+		cfg:     &config{},
+		wonBids: make(map[[32]byte]wonBid),
 	}
 	for _, opt := range opts {
 		if err := opt(s); err != nil {
 			return nil, err
 		}
 	}
-	if s.cfg.builderClient != nil && !reflect.ValueOf(s.cfg.builderClient).IsNil() {
-		s.c = s.cfg.builderClient
+	for _, c := range s.cfg.builderClients {
+		if c == nil || reflect.ValueOf(c).IsNil() {
+			continue
+		}
+		r := newRelay(c)
+		s.relays = append(s.relays, r)
 
 		// Is the builder up?
-		if err := s.c.Status(ctx); err != nil {
-			log.WithError(err).Error("Failed to check builder status")
+		if err := c.Status(ctx); err != nil {
+			log.WithError(err).WithField("endpoint", r.url()).Error("Failed to check builder status")
 		} else {
-			log.WithField("endpoint", s.c.NodeURL()).Info("Builder has been configured")
-			log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
-				"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
+			log.WithField("endpoint", r.url()).Info("Builder has been configured")
 		}
 	}
+	if len(s.relays) > 0 {
+		log.Warn("Outsourcing block construction to external builders adds non-trivial delay to block propagation time.  " +
+			"Builder-constructed blocks or fallback blocks may get orphaned. Use at your own risk!")
+	}
 	return s, nil
 }
 
@@ -86,7 +127,8 @@ func (s *Service) Stop() error {
 	return nil
 }
 
-// SubmitBlindedBlock submits a blinded block to the builder relay network.
+// SubmitBlindedBlock submits a blinded block to the relay whose bid the block was built with. If that relay is not
+// known, for instance because the block was built by another beacon node, the relays are tried in turn.
 func (s *Service) SubmitBlindedBlock(ctx context.Context, b interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, error) {
 	ctx, span := trace.StartSpan(ctx, "builder.SubmitBlindedBlock")
 	defer span.End()
@@ -94,14 +136,54 @@ func (s *Service) SubmitBlindedBlock(ctx context.Context, b interfaces.ReadOnlyS
 	defer func() {
 		submitBlindedBlockLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		return nil, ErrNoBuilder
 	}
+	if b == nil || b.IsNil() {
+		return nil, errors.New("nil block")
+	}
+	h, err := b.Block().Body().Execution()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get execution header")
+	}
+	slot := b.Block().Slot()
 
-	return s.c.SubmitBlindedBlock(ctx, b)
+	s.wonBidsLock.Lock()
+	won, ok := s.wonBids[bytesutil.ToBytes32(h.BlockHash())]
+	s.wonBidsLock.Unlock()
+	if ok {
+		span.AddAttributes(trace.StringAttribute("relay", won.relay.url()))
+		payload, err := won.relay.client.SubmitBlindedBlock(ctx, b)
+		if err != nil {
+			won.relay.recordFailure(slot)
+			relayMissedSlots.WithLabelValues(won.relay.url()).Inc()
+			tracing.AnnotateError(span, err)
+			return nil, errors.Wrapf(err, "relay %s failed to reveal the payload", won.relay.url())
+		}
+		won.relay.recordSuccess()
+		return payload, nil
+	}
+
+	log.WithFields(log.Fields{
+		"slot":      slot,
+		"blockHash": fmt.Sprintf("%#x", h.BlockHash()),
+	}).Warn("Unknown relay for blinded block, submitting it to every relay until one reveals the payload")
+	var errs []string
+	for _, r := range s.relays {
+		payload, err := r.client.SubmitBlindedBlock(ctx, b)
+		if err != nil {
+			errs = append(errs, fmt.Sprintf("%s: %v", r.url(), err))
+			continue
+		}
+		return payload, nil
+	}
+	err = fmt.Errorf("no relay revealed the payload: %s", strings.Join(errs, "; "))
+	tracing.AnnotateError(span, err)
+	return nil, err
 }
 
-// GetHeader retrieves the header for a given slot and parent hash from the builder relay network.
+// GetHeader requests a header for a given slot and parent hash from every relay which is not circuit broken, in
+// parallel, and returns the valid bid with the highest value received before the deadline.
 func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHash [32]byte, pubKey [48]byte) (builder.SignedBid, error) {
 	ctx, span := trace.StartSpan(ctx, "builder.GetHeader")
 	defer span.End()
@@ -109,23 +191,127 @@ func (s *Service) GetHeader(ctx context.Context, slot primitives.Slot, parentHas
 	defer func() {
 		getHeaderLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		tracing.AnnotateError(span, ErrNoBuilder)
 		return nil, ErrNoBuilder
 	}
+	if s.cfg.timeFetcher == nil {
+		tracing.AnnotateError(span, ErrNoTimeFetcher)
+		return nil, ErrNoTimeFetcher
+	}
+	relays := s.availableRelays(slot)
+	if len(relays) == 0 {
+		tracing.AnnotateError(span, ErrNoRelayAvailable)
+		return nil, ErrNoRelayAvailable
+	}
 
-	h, err := s.c.GetHeader(ctx, slot, parentHash, pubKey)
-	tracing.AnnotateError(span, err)
-	return h, err
+	ctx, cancel := context.WithTimeout(ctx, getHeaderTimeout)
+	defer cancel()
+
+	type result struct {
+		relay *relay
+		bid   builder.SignedBid
+		value *big.Int
+		err   error
+	}
+	genesisTime := s.cfg.timeFetcher.GenesisTime()
+	results := make(chan result, len(relays))
+	for _, r := range relays {
+		go func(r *relay) {
+			bid, err := r.client.GetHeader(ctx, slot, parentHash, pubKey)
+			if err != nil {
+				results <- result{relay: r, err: err}
+				return
+			}
+			v, err := validateBid(bid, slot, parentHash, genesisTime)
+			results <- result{relay: r, bid: bid, value: v, err: err}
+		}(r)
+	}
+
+	var best result
+	var errs []string
+	for range relays {
+		res := <-results
+		if errors.Is(res.err, builder.ErrNoContent) {
+			// Relays have no bid to offer for some slots, this is not a failure of the relay.
+			log.WithFields(log.Fields{
+				"slot":  slot,
+				"relay": res.relay.url(),
+			}).Debug("Relay has no bid for the slot")
+			errs = append(errs, fmt.Sprintf("%s: %v", res.relay.url(), res.err))
+			continue
+		}
+		if res.err != nil {
+			res.relay.recordFailure(slot)
+			relayGetHeaderFailures.WithLabelValues(res.relay.url()).Inc()
+			log.WithError(res.err).WithFields(log.Fields{
+				"slot":  slot,
+				"relay": res.relay.url(),
+			}).Warn("Relay failed to provide a valid bid")
+			errs = append(errs, fmt.Sprintf("%s: %v", res.relay.url(), res.err))
+			continue
+		}
+		res.relay.recordSuccess()
+		if best.bid == nil || res.value.Cmp(best.value) > 0 {
+			best = res
+		}
+	}
+	if best.bid == nil {
+		err := fmt.Errorf("no valid bid received: %s", strings.Join(errs, "; "))
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	if err := s.recordWonBid(slot, best.relay, best.bid); err != nil {
+		tracing.AnnotateError(span, err)
+		return nil, err
+	}
+	relayBidsWon.WithLabelValues(best.relay.url()).Inc()
+	span.AddAttributes(trace.StringAttribute("relay", best.relay.url()))
+	return best.bid, nil
 }
 
-// Status retrieves the status of the builder relay network.
-func (s *Service) Status() error {
-	// Return early if builder isn't initialized in service.
-	if s.c == nil {
-		return nil
+// CircuitBroken returns true if none of the configured relays can be used at the given slot.
+func (s *Service) CircuitBroken(slot primitives.Slot) bool {
+	return len(s.availableRelays(slot)) == 0
+}
+
+func (s *Service) availableRelays(slot primitives.Slot) []*relay {
+	available := make([]*relay, 0, len(s.relays))
+	for _, r := range s.relays {
+		if r.circuitBroken(slot) {
+			relayCircuitBroken.WithLabelValues(r.url()).Set(1)
+			continue
+		}
+		relayCircuitBroken.WithLabelValues(r.url()).Set(0)
+		available = append(available, r)
+	}
+	return available
+}
+
+// recordWonBid remembers the relay which provided the winning bid for a slot, and forgets the bids of slots older
+// than an epoch.
+func (s *Service) recordWonBid(slot primitives.Slot, r *relay, signedBid builder.SignedBid) error {
+	bid, err := signedBid.Message()
+	if err != nil {
+		return errors.Wrap(err, "could not get bid")
+	}
+	header, err := bid.Header()
+	if err != nil {
+		return errors.Wrap(err, "could not get bid header")
+	}
+	s.wonBidsLock.Lock()
+	defer s.wonBidsLock.Unlock()
+	for h, won := range s.wonBids {
+		if won.slot+params.BeaconConfig().SlotsPerEpoch < slot {
+			delete(s.wonBids, h)
+		}
 	}
+	s.wonBids[bytesutil.ToBytes32(header.BlockHash())] = wonBid{slot: slot, relay: r}
+	return nil
+}
 
+// Status retrieves the status of the builder relay network.
+func (s *Service) Status() error {
 	return nil
 }
 
@@ -138,7 +324,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 	defer func() {
 		registerValidatorLatency.Observe(float64(time.Since(start).Milliseconds()))
 	}()
-	if s.c == nil {
+	if len(s.relays) == 0 {
 		return ErrNoBuilder
 	}
 
@@ -163,7 +349,7 @@ func (s *Service) RegisterValidator(ctx context.Context, reg []*ethpb.SignedVali
 		valid = append(valid, r)
 		indexToRegistration[nx] = r.Message
 	}
-	if err := s.c.RegisterValidator(ctx, valid); err != nil {
+	if err := s.registerWithRelays(ctx, valid); err != nil {
 		return errors.Wrap(err, "could not register validator(s)")
 	}
 
@@ -190,9 +376,54 @@ func (s *Service) RegistrationByValidatorID(ctx context.Context, id primitives.V
 	}
 }
 
-// Configured returns true if the user has configured a builder client.
+// RelayRegistrations requests the registration of a validator from every relay in parallel.
+func (s *Service) RelayRegistrations(ctx context.Context, pubKey [48]byte) []*RelayRegistration {
+	ctx, span := trace.StartSpan(ctx, "builder.RelayRegistrations")
+	defer span.End()
+
+	regs := make([]*RelayRegistration, len(s.relays))
+	var wg sync.WaitGroup
+	for i, r := range s.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			reg, err := r.client.ValidatorRegistration(ctx, pubKey)
+			regs[i] = &RelayRegistration{URL: r.url(), Registration: reg, Err: err}
+		}(i, r)
+	}
+	wg.Wait()
+	return regs
+}
+
+// registerWithRelays sends the validator registrations to every relay in parallel. It only fails if no relay
+// accepted the registrations.
+func (s *Service) registerWithRelays(ctx context.Context, reg []*ethpb.SignedValidatorRegistrationV1) error {
+	errs := make([]error, len(s.relays))
+	var wg sync.WaitGroup
+	for i, r := range s.relays {
+		wg.Add(1)
+		go func(i int, r *relay) {
+			defer wg.Done()
+			errs[i] = r.client.RegisterValidator(ctx, reg)
+		}(i, r)
+	}
+	wg.Wait()
+	var failures []string
+	for i, err := range errs {
+		if err != nil {
+			log.WithError(err).WithField("relay", s.relays[i].url()).Error("Could not register validator(s) with relay")
+			failures = append(failures, fmt.Sprintf("%s: %v", s.relays[i].url(), err))
+		}
+	}
+	if len(failures) == len(s.relays) {
+		return errors.New(strings.Join(failures, "; "))
+	}
+	return nil
+}
+
+// Configured returns true if the user has configured at least one builder client.
 func (s *Service) Configured() bool {
-	return s.c != nil && !reflect.ValueOf(s.c).IsNil()
+	return len(s.relays) > 0
 }
 
 func (s *Service) pollRelayerStatus(ctx context.Context) {
@@ -201,9 +432,9 @@ func (s *Service) pollRelayerStatus(ctx context.Context) {
 	for {
 		select {
 		case <-ticker.C:
-			if s.c != nil {
-				if err := s.c.Status(ctx); err != nil {
-					log.WithError(err).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
+			for _, r := range s.relays {
+				if err := r.client.Status(ctx); err != nil {
+					log.WithError(err).WithField("relay", r.url()).Error("Failed to call relayer status endpoint, perhaps mev-boost or relayers are down")
 				}
 			}
 		case <-ctx.Done():
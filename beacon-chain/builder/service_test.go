@@ -5,13 +5,26 @@ import (
 	"testing"
 	"time"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
 	buildertesting "github.com/prysmaticlabs/prysm/v4/api/client/builder/testing"
 	blockchainTesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	dbtesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz"
+	v1 "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
 	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
 func Test_NewServiceWithBuilder(t *testing.T) {
@@ -68,3 +81,369 @@ func Test_BuilderMethodsWithouClient(t *testing.T) {
 	err = s.RegisterValidator(context.Background(), nil)
 	assert.ErrorContains(t, ErrNoBuilder.Error(), err)
 }
+
+type testRelay struct {
+	url          string
+	bids         map[primitives.Slot]builder.SignedBid
+	err          error
+	delay        time.Duration
+	registered   bool
+	submitted    bool
+	errSubmit    error
+	errRegister  error
+	getHeaderHit int
+	registration *eth.ValidatorRegistrationV1
+}
+
+func (r *testRelay) NodeURL() string {
+	return r.url
+}
+
+func (r *testRelay) GetHeader(ctx context.Context, slot primitives.Slot, _ [32]byte, _ [48]byte) (builder.SignedBid, error) {
+	r.getHeaderHit++
+	select {
+	case <-time.After(r.delay):
+	case <-ctx.Done():
+		return nil, ctx.Err()
+	}
+	return r.bids[slot], r.err
+}
+
+func (r *testRelay) RegisterValidator(context.Context, []*eth.SignedValidatorRegistrationV1) error {
+	r.registered = r.errRegister == nil
+	return r.errRegister
+}
+
+func (r *testRelay) SubmitBlindedBlock(context.Context, interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, error) {
+	r.submitted = true
+	if r.errSubmit != nil {
+		return nil, r.errSubmit
+	}
+	return blocks.WrappedExecutionPayloadCapella(&v1.ExecutionPayloadCapella{}, 0)
+}
+
+func (*testRelay) Status(context.Context) error {
+	return nil
+}
+
+func (r *testRelay) ValidatorRegistration(context.Context, [48]byte) (*eth.ValidatorRegistrationV1, error) {
+	return r.registration, r.err
+}
+
+// setupCapellaConfig schedules every fork up to capella at genesis, so that the capella bids of the tests are valid
+// at any slot.
+func setupCapellaConfig(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 0
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+}
+
+// testGenesis is the genesis time of the chain the test bids are built for.
+var testGenesis = time.Unix(1000, 0)
+
+// testBidSlots is the number of slots, from genesis, the test relays have a bid for.
+const testBidSlots = 32
+
+func testBid(t *testing.T, value uint64, blockHash byte, parentHash [32]byte, slot primitives.Slot) builder.SignedBid {
+	ts, err := slots.ToTime(uint64(testGenesis.Unix()), slot)
+	require.NoError(t, err)
+	return testBidWithTimestamp(t, value, blockHash, parentHash, uint64(ts.Unix()))
+}
+
+// testBids returns the bids of a test relay for the first testBidSlots slots.
+func testBids(t *testing.T, value uint64, blockHash byte, parentHash [32]byte) map[primitives.Slot]builder.SignedBid {
+	bids := make(map[primitives.Slot]builder.SignedBid, testBidSlots)
+	for slot := primitives.Slot(0); slot < testBidSlots; slot++ {
+		bids[slot] = testBid(t, value, blockHash, parentHash, slot)
+	}
+	return bids
+}
+
+func testBidWithTimestamp(t *testing.T, value uint64, blockHash byte, parentHash [32]byte, timestamp uint64) builder.SignedBid {
+	sb, err := builder.WrappedSignedBuilderBidCapella(testBidProto(t, value, blockHash, parentHash, timestamp))
+	require.NoError(t, err)
+	return sb
+}
+
+func testBidProto(t *testing.T, value uint64, blockHash byte, parentHash [32]byte, timestamp uint64) *eth.SignedBuilderBidCapella {
+	sk, err := bls.RandKey()
+	require.NoError(t, err)
+	bid := &eth.BuilderBidCapella{
+		Header: &v1.ExecutionPayloadHeaderCapella{
+			ParentHash:       parentHash[:],
+			FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
+			StateRoot:        make([]byte, fieldparams.RootLength),
+			ReceiptsRoot:     make([]byte, fieldparams.RootLength),
+			LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
+			PrevRandao:       make([]byte, fieldparams.RootLength),
+			BaseFeePerGas:    make([]byte, fieldparams.RootLength),
+			BlockHash:        bytesutil.PadTo([]byte{blockHash}, fieldparams.RootLength),
+			TransactionsRoot: bytesutil.PadTo([]byte{1}, fieldparams.RootLength),
+			WithdrawalsRoot:  make([]byte, fieldparams.RootLength),
+			Timestamp:        timestamp,
+		},
+		Pubkey: sk.PublicKey().Marshal(),
+		Value:  bytesutil.PadTo(bytesutil.Uint64ToBytesLittleEndian(value), 32),
+	}
+	domain, err := signing.ComputeDomain(params.BeaconConfig().DomainApplicationBuilder, nil, nil)
+	require.NoError(t, err)
+	sr, err := signing.ComputeSigningRoot(bid, domain)
+	require.NoError(t, err)
+	return &eth.SignedBuilderBidCapella{Message: bid, Signature: sk.Sign(sr[:]).Marshal()}
+}
+
+func testBlindedBlock(t *testing.T, slot primitives.Slot, blockHash byte) interfaces.ReadOnlySignedBeaconBlock {
+	b := util.NewBlindedBeaconBlockCapella()
+	b.Block.Slot = slot
+	b.Block.Body.ExecutionPayloadHeader.BlockHash = bytesutil.PadTo([]byte{blockHash}, fieldparams.RootLength)
+	sb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	return sb
+}
+
+func Test_GetHeader_MultipleRelays(t *testing.T) {
+	setupCapellaConfig(t)
+	ctx := context.Background()
+	parentHash := [32]byte{'a'}
+	low := &testRelay{url: "low", bids: testBids(t, 1, 'l', parentHash)}
+	high := &testRelay{url: "high", bids: testBids(t, 3, 'h', parentHash)}
+	wrongParent := &testRelay{url: "wrong-parent", bids: testBids(t, 5, 'w', [32]byte{'b'})}
+	slow := &testRelay{url: "slow", bids: testBids(t, 10, 's', parentHash), delay: time.Minute}
+	failing := &testRelay{url: "failing", err: errors.New("relay down")}
+	s, err := NewService(ctx,
+		WithBuilderClient(low),
+		WithBuilderClient(high),
+		WithBuilderClient(wrongParent),
+		WithBuilderClient(slow),
+		WithBuilderClient(failing),
+		WithTimeFetcher(&blockchainTesting.ChainService{Genesis: testGenesis}),
+	)
+	require.NoError(t, err)
+	require.Equal(t, 5, len(s.relays))
+
+	// The highest valid bid received before the deadline wins.
+	bid, err := s.GetHeader(ctx, 1, parentHash, [48]byte{})
+	require.NoError(t, err)
+	require.DeepEqual(t, high.bids[1], bid)
+
+	// The blinded block is only revealed to the winning relay.
+	_, err = s.SubmitBlindedBlock(ctx, testBlindedBlock(t, 1, 'h'))
+	require.NoError(t, err)
+	assert.Equal(t, true, high.submitted)
+	assert.Equal(t, false, low.submitted)
+	assert.Equal(t, false, slow.submitted)
+
+	// The relays which failed get circuit broken independently of the others.
+	for slot := primitives.Slot(2); slot < 2+params.BeaconConfig().MaxRelayConsecutiveFailures-1; slot++ {
+		_, err = s.GetHeader(ctx, slot, parentHash, [48]byte{})
+		require.NoError(t, err)
+	}
+	slot := 1 + params.BeaconConfig().MaxRelayConsecutiveFailures
+	for _, r := range []*testRelay{wrongParent, slow, failing} {
+		assert.Equal(t, true, s.relays[indexOfRelay(s, r)].circuitBroken(slot), r.url)
+	}
+	assert.Equal(t, false, s.relays[0].circuitBroken(slot))
+	assert.Equal(t, false, s.relays[1].circuitBroken(slot))
+	assert.Equal(t, false, s.CircuitBroken(slot))
+	hits := failing.getHeaderHit
+	_, err = s.GetHeader(ctx, slot, parentHash, [48]byte{})
+	require.NoError(t, err)
+	assert.Equal(t, hits, failing.getHeaderHit)
+
+	// A broken relay is tried again an epoch after its last failure.
+	assert.Equal(t, false, s.relays[indexOfRelay(s, failing)].circuitBroken(slot+params.BeaconConfig().SlotsPerEpoch))
+}
+
+func Test_GetHeader_NoContent(t *testing.T) {
+	setupCapellaConfig(t)
+	ctx := context.Background()
+	parentHash := [32]byte{'a'}
+	noBid := &testRelay{url: "no-bid", err: builder.ErrNoContent}
+	s, err := NewService(ctx, WithBuilderClient(noBid), WithTimeFetcher(&blockchainTesting.ChainService{Genesis: testGenesis}))
+	require.NoError(t, err)
+
+	// Relays without a bid for the slot are not circuit broken.
+	for slot := primitives.Slot(1); slot <= params.BeaconConfig().MaxRelayConsecutiveFailures; slot++ {
+		_, err = s.GetHeader(ctx, slot, parentHash, [48]byte{})
+		require.ErrorContains(t, "no valid bid received", err)
+	}
+	assert.Equal(t, false, s.CircuitBroken(params.BeaconConfig().MaxRelayConsecutiveFailures+1))
+}
+
+func Test_GetHeader_InvalidBidsDropped(t *testing.T) {
+	setupCapellaConfig(t)
+	ctx := context.Background()
+	slot := primitives.Slot(5)
+	ts, err := slots.ToTime(uint64(testGenesis.Unix()), slot)
+	require.NoError(t, err)
+	parentHash := [32]byte{'a'}
+	valid := &testRelay{url: "valid", bids: testBids(t, 1, 'v', parentHash)}
+	wrongTime := &testRelay{url: "wrong-timestamp", bids: map[primitives.Slot]builder.SignedBid{
+		slot: testBidWithTimestamp(t, 10, 't', parentHash, uint64(ts.Unix())+1),
+	}}
+	s, err := NewService(ctx,
+		WithBuilderClient(valid),
+		WithBuilderClient(wrongTime),
+		WithTimeFetcher(&blockchainTesting.ChainService{Genesis: testGenesis}),
+	)
+	require.NoError(t, err)
+
+	// The highest bid has an invalid timestamp, it is dropped before the bids are ranked.
+	bid, err := s.GetHeader(ctx, slot, parentHash, [48]byte{})
+	require.NoError(t, err)
+	require.DeepEqual(t, valid.bids[slot], bid)
+}
+
+func Test_GetHeader_NoTimeFetcher(t *testing.T) {
+	setupCapellaConfig(t)
+	ctx := context.Background()
+	parentHash := [32]byte{'a'}
+	r := &testRelay{url: "r"}
+	s, err := NewService(ctx, WithBuilderClient(r))
+	require.NoError(t, err)
+
+	// Bids can not be validated without the genesis time, so the relays are not requested.
+	_, err = s.GetHeader(ctx, 1, parentHash, [48]byte{})
+	require.ErrorIs(t, err, ErrNoTimeFetcher)
+	assert.Equal(t, 0, r.getHeaderHit)
+}
+
+func Test_validateBid(t *testing.T) {
+	setupCapellaConfig(t)
+	slot := primitives.Slot(5)
+	ts, err := slots.ToTime(uint64(testGenesis.Unix()), slot)
+	require.NoError(t, err)
+	parentHash := [32]byte{'a'}
+
+	v, err := validateBid(testBid(t, 3, 'h', parentHash, slot), slot, parentHash, testGenesis)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(3), v.Uint64())
+
+	_, err = validateBid(testBid(t, 3, 'h', parentHash, slot+1), slot, parentHash, testGenesis)
+	require.ErrorContains(t, "incorrect timestamp", err)
+	_, err = validateBid(testBid(t, 3, 'h', [32]byte{'b'}, slot), slot, parentHash, testGenesis)
+	require.ErrorContains(t, "incorrect parent hash", err)
+	_, err = validateBid(testBid(t, 0, 'h', parentHash, slot), slot, parentHash, testGenesis)
+	require.ErrorContains(t, "bid with 0 value", err)
+
+	pb := testBidProto(t, 3, 'h', parentHash, uint64(ts.Unix()))
+	pb.Message.Header.TransactionsRoot = emptyTransactionsRoot[:]
+	emptyTxRoot, err := builder.WrappedSignedBuilderBidCapella(pb)
+	require.NoError(t, err)
+	_, err = validateBid(emptyTxRoot, slot, parentHash, testGenesis)
+	require.ErrorContains(t, "header with an empty tx root", err)
+
+	pb = testBidProto(t, 3, 'h', parentHash, uint64(ts.Unix()))
+	pb.Message.Value = bytesutil.PadTo([]byte{4}, 32)
+	badSignature, err := builder.WrappedSignedBuilderBidCapella(pb)
+	require.NoError(t, err)
+	_, err = validateBid(badSignature, slot, parentHash, testGenesis)
+	require.ErrorContains(t, "invalid builder signature", err)
+
+	// Bids must match the fork of the slot they are built for.
+	cfg := params.BeaconConfig().Copy()
+	cfg.CapellaForkEpoch = 10
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+	_, err = validateBid(testBid(t, 3, 'h', parentHash, slot), slot, parentHash, testGenesis)
+	require.ErrorContains(t, "is different from the fork version", err)
+}
+
+func TestEmptyTransactionsRoot(t *testing.T) {
+	r, err := ssz.TransactionsRoot([][]byte{})
+	require.NoError(t, err)
+	require.DeepEqual(t, r, emptyTransactionsRoot)
+}
+
+func Test_SubmitBlindedBlock_MissedSlot(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.MaxRelayEpochFailures = 2
+	cfg.AltairForkEpoch = 0
+	cfg.BellatrixForkEpoch = 0
+	cfg.CapellaForkEpoch = 0
+	cfg.InitializeForkSchedule()
+	params.OverrideBeaconConfig(cfg)
+
+	ctx := context.Background()
+	parentHash := [32]byte{'a'}
+	r1 := &testRelay{url: "r1", bids: testBids(t, 2, 'x', parentHash), errSubmit: errors.New("withheld")}
+	r2 := &testRelay{url: "r2", bids: testBids(t, 1, 'y', parentHash)}
+	s, err := NewService(ctx, WithBuilderClient(r1), WithBuilderClient(r2), WithTimeFetcher(&blockchainTesting.ChainService{Genesis: testGenesis}))
+	require.NoError(t, err)
+
+	for _, slot := range []primitives.Slot{10, 20} {
+		bid, err := s.GetHeader(ctx, slot, parentHash, [48]byte{})
+		require.NoError(t, err)
+		require.DeepEqual(t, r1.bids[slot], bid)
+		_, err = s.SubmitBlindedBlock(ctx, testBlindedBlock(t, slot, 'x'))
+		require.ErrorContains(t, "relay r1 failed to reveal the payload", err)
+		assert.Equal(t, false, r2.submitted)
+	}
+	// Two missed slots within an epoch break the circuit of the first relay, the second one is used instead.
+	assert.Equal(t, true, s.relays[0].circuitBroken(21))
+	bid, err := s.GetHeader(ctx, 21, parentHash, [48]byte{})
+	require.NoError(t, err)
+	require.DeepEqual(t, r2.bids[21], bid)
+	assert.Equal(t, false, s.relays[0].circuitBroken(10+params.BeaconConfig().SlotsPerEpoch))
+
+	// Blocks built with an unknown bid are submitted to the relays in turn.
+	_, err = s.SubmitBlindedBlock(ctx, testBlindedBlock(t, 22, 'z'))
+	require.NoError(t, err)
+	assert.Equal(t, true, r2.submitted)
+
+	r2.bids = nil
+	s.relays[1].recordFailure(21)
+	s.relays[1].recordFailure(22)
+	assert.Equal(t, true, s.CircuitBroken(22))
+	_, err = s.GetHeader(ctx, 22, parentHash, [48]byte{})
+	require.ErrorIs(t, err, ErrNoRelayAvailable)
+}
+
+func Test_RegisterValidator_MultipleRelays(t *testing.T) {
+	ctx := context.Background()
+	headFetcher := &blockchainTesting.ChainService{}
+	r1 := &testRelay{url: "r1"}
+	r2 := &testRelay{url: "r2", errRegister: errors.New("bad request")}
+	s, err := NewService(ctx, WithRegistrationCache(), WithHeadFetcher(headFetcher), WithBuilderClient(r1), WithBuilderClient(r2))
+	require.NoError(t, err)
+	pubkey := bytesutil.ToBytes48([]byte("pubkey"))
+	reg := &eth.ValidatorRegistrationV1{Pubkey: pubkey[:], Timestamp: uint64(time.Now().UTC().Unix()), FeeRecipient: make([]byte, 20)}
+	require.NoError(t, s.RegisterValidator(ctx, []*eth.SignedValidatorRegistrationV1{{Message: reg}}))
+	assert.Equal(t, true, r1.registered)
+
+	r1.errRegister = errors.New("bad request")
+	require.ErrorContains(t, "could not register validator(s)", s.RegisterValidator(ctx, []*eth.SignedValidatorRegistrationV1{{Message: reg}}))
+}
+
+func Test_RelayRegistrations(t *testing.T) {
+	ctx := context.Background()
+	pubkey := bytesutil.ToBytes48([]byte("pubkey"))
+	reg := &eth.ValidatorRegistrationV1{Pubkey: pubkey[:], GasLimit: 30000000, FeeRecipient: make([]byte, 20)}
+	r1 := &testRelay{url: "r1", registration: reg}
+	r2 := &testRelay{url: "r2", err: builder.ErrNotFound}
+	s, err := NewService(ctx, WithBuilderClient(r1), WithBuilderClient(r2))
+	require.NoError(t, err)
+
+	regs := s.RelayRegistrations(ctx, pubkey)
+	require.Equal(t, 2, len(regs))
+	assert.Equal(t, "r1", regs[0].URL)
+	assert.DeepEqual(t, reg, regs[0].Registration)
+	assert.NoError(t, regs[0].Err)
+	assert.Equal(t, "r2", regs[1].URL)
+	assert.Equal(t, true, regs[1].Registration == nil)
+	require.ErrorIs(t, regs[1].Err, builder.ErrNotFound)
+}
+
+func indexOfRelay(s *Service, c *testRelay) int {
+	for i, r := range s.relays {
+		if r.client == c {
+			return i
+		}
+	}
+	return -1
+}
@@ -5,6 +5,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
+	builderservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/builder"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
@@ -32,6 +33,8 @@ type MockBuilderService struct {
 	RegistrationCache     *cache.RegistrationCache
 	ErrGetHeader          error
 	ErrRegisterValidator  error
+	RelaysCircuitBroken   bool
+	RelayRegistrationsMap map[[48]byte][]*builderservice.RelayRegistration
 	Cfg                   *Config
 }
 
@@ -40,6 +43,11 @@ func (s *MockBuilderService) Configured() bool {
 	return s.HasConfigured
 }
 
+// CircuitBroken for mocking.
+func (s *MockBuilderService) CircuitBroken(primitives.Slot) bool {
+	return s.RelaysCircuitBroken
+}
+
 // SubmitBlindedBlock for mocking.
 func (s *MockBuilderService) SubmitBlindedBlock(_ context.Context, _ interfaces.ReadOnlySignedBeaconBlock) (interfaces.ExecutionData, error) {
 	if s.Payload != nil {
@@ -83,3 +91,8 @@ func (s *MockBuilderService) RegistrationByValidatorID(ctx context.Context, id p
 func (s *MockBuilderService) RegisterValidator(context.Context, []*ethpb.SignedValidatorRegistrationV1) error {
 	return s.ErrRegisterValidator
 }
+
+// RelayRegistrations for mocking.
+func (s *MockBuilderService) RelayRegistrations(_ context.Context, pubKey [48]byte) []*builderservice.RelayRegistration {
+	return s.RelayRegistrationsMap[pubKey]
+}
@@ -11,12 +11,12 @@ import (
 	"sort"
 	"sync"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
-	"github.com/prysmaticlabs/prysm/v4/config/params"
-	"github.com/prysmaticlabs/prysm/v4/container/trie"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/sirupsen/logrus"
@@ -39,10 +39,10 @@ type DepositFetcher interface {
 	NonFinalizedDeposits(ctx context.Context, lastFinalizedIndex int64, untilBlk *big.Int) []*ethpb.Deposit
 }
 
-// FinalizedDeposits stores the trie of deposits that have been included
+// FinalizedDeposits stores the EIP-4881 deposit tree of deposits that have been included
 // in the beacon state up to the latest finalized checkpoint.
 type FinalizedDeposits struct {
-	Deposits        *trie.SparseMerkleTrie
+	Deposits        *depositsnapshot.DepositTree
 	MerkleTrieIndex int64
 }
 
@@ -53,24 +53,22 @@ type DepositCache struct {
 	pendingDeposits   []*ethpb.DepositContainer
 	deposits          []*ethpb.DepositContainer
 	finalizedDeposits *FinalizedDeposits
-	depositsByKey     map[[fieldparams.BLSPubkeyLength]byte][]*ethpb.DepositContainer
-	depositsLock      sync.RWMutex
+	// snapshot is the deposit tree snapshot the cache was initialized from, if any.
+	// Deposits included in the snapshot are not necessarily present in the cache.
+	snapshot      *depositsnapshot.DepositTreeSnapshot
+	depositsByKey map[[fieldparams.BLSPubkeyLength]byte][]*ethpb.DepositContainer
+	depositsLock  sync.RWMutex
 }
 
 // New instantiates a new deposit cache
 func New() (*DepositCache, error) {
-	finalizedDepositsTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-	if err != nil {
-		return nil, err
-	}
-
 	// finalizedDeposits.MerkleTrieIndex is initialized to -1 because it represents the index of the last trie item.
 	// Inserting the first item into the trie will set the value of the index to 0.
 	return &DepositCache{
 		pendingDeposits:   []*ethpb.DepositContainer{},
 		deposits:          []*ethpb.DepositContainer{},
 		depositsByKey:     map[[fieldparams.BLSPubkeyLength]byte][]*ethpb.DepositContainer{},
-		finalizedDeposits: &FinalizedDeposits{Deposits: finalizedDepositsTrie, MerkleTrieIndex: -1},
+		finalizedDeposits: &FinalizedDeposits{Deposits: depositsnapshot.New(), MerkleTrieIndex: -1},
 	}, nil
 }
 
@@ -91,8 +89,8 @@ func (dc *DepositCache) InsertDeposit(ctx context.Context, d *ethpb.Deposit, blo
 	dc.depositsLock.Lock()
 	defer dc.depositsLock.Unlock()
 
-	if int(index) != len(dc.deposits) {
-		return errors.Errorf("wanted deposit with index %d to be inserted but received %d", len(dc.deposits), index)
+	if nextIndex := dc.nextDepositIndex(); index != nextIndex {
+		return errors.Errorf("wanted deposit with index %d to be inserted but received %d", nextIndex, index)
 	}
 	// Keep the slice sorted on insertion in order to avoid costly sorting on retrieval.
 	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Index >= index })
@@ -128,8 +126,19 @@ func (dc *DepositCache) InsertDepositContainers(ctx context.Context, ctrs []*eth
 	historicalDepositsCount.Add(float64(len(ctrs)))
 }
 
-// InsertFinalizedDeposits inserts deposits up to eth1DepositIndex (inclusive) into the finalized deposits cache.
-func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1DepositIndex int64) error {
+// nextDepositIndex returns the merkle index of the next deposit to be inserted into the cache.
+func (dc *DepositCache) nextDepositIndex() int64 {
+	if len(dc.deposits) == 0 {
+		return dc.finalizedDeposits.MerkleTrieIndex + 1
+	}
+	return dc.deposits[len(dc.deposits)-1].Index + 1
+}
+
+// InsertFinalizedDeposits inserts deposits up to eth1DepositIndex (inclusive) into the finalized deposits cache
+// and marks them as finalized at the given execution block. The execution block must be the one at which exactly
+// eth1DepositIndex+1 deposits were made, and is left out of the deposit snapshot if its hash is empty. The block of
+// the last finalized deposit is used if the execution block number is unknown, as no deposits were made after it.
+func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1DepositIndex int64, executionHash common.Hash, executionNumber uint64) error {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.InsertFinalizedDeposits")
 	defer span.End()
 	dc.depositsLock.Lock()
@@ -145,14 +154,15 @@ func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1Deposit
 	}
 	// In the event we have less deposits than we need to
 	// finalize we finalize till the index on which we do have it.
-	if len(dc.deposits) <= int(eth1DepositIndex) {
-		eth1DepositIndex = int64(len(dc.deposits)) - 1
+	if lastIndex := dc.nextDepositIndex() - 1; lastIndex < eth1DepositIndex {
+		eth1DepositIndex = lastIndex
 	}
 	// If we finalize to some lower deposit index, we
 	// ignore it.
 	if int(eth1DepositIndex) < insertIndex {
 		return nil
 	}
+	var lastHeight uint64
 	for _, d := range dc.deposits {
 		if d.Index <= dc.finalizedDeposits.MerkleTrieIndex {
 			continue
@@ -168,6 +178,15 @@ func (dc *DepositCache) InsertFinalizedDeposits(ctx context.Context, eth1Deposit
 			return errors.Wrap(err, "could not insert deposit hash")
 		}
 		insertIndex++
+		lastHeight = d.Eth1BlockHeight
+	}
+	if executionHash != (common.Hash{}) {
+		if executionNumber == 0 {
+			executionNumber = lastHeight
+		}
+		if err := depositTrie.Finalize(eth1DepositIndex, executionHash, executionNumber); err != nil {
+			return errors.Wrap(err, "could not finalize deposit tree")
+		}
 	}
 
 	dc.finalizedDeposits = &FinalizedDeposits{
@@ -230,12 +249,17 @@ func (dc *DepositCache) DepositsNumberAndRootAtHeight(ctx context.Context, block
 	dc.depositsLock.RLock()
 	defer dc.depositsLock.RUnlock()
 	heightIdx := sort.Search(len(dc.deposits), func(i int) bool { return dc.deposits[i].Eth1BlockHeight > blockHeight.Uint64() })
-	// send the deposit root of the empty trie, if eth1follow distance is greater than the time of the earliest
-	// deposit.
 	if heightIdx == 0 {
+		// Deposits included in the snapshot the cache was initialized from are not kept in the cache.
+		if dc.snapshot != nil && dc.snapshot.ExecutionBlockHeight() <= blockHeight.Uint64() {
+			return dc.snapshot.DepositCount(), dc.snapshot.DepositRoot()
+		}
+		// send the deposit root of the empty trie, if eth1follow distance is greater than the time of the earliest
+		// deposit.
 		return 0, [32]byte{}
 	}
-	return uint64(heightIdx), bytesutil.ToBytes32(dc.deposits[heightIdx-1].DepositRoot)
+	ctr := dc.deposits[heightIdx-1]
+	return uint64(ctr.Index + 1), bytesutil.ToBytes32(ctr.DepositRoot)
 }
 
 // DepositByPubkey looks through historical deposits and finds one which contains
@@ -260,6 +284,48 @@ func (dc *DepositCache) DepositByPubkey(ctx context.Context, pubKey []byte) (*et
 	return deposit, blockNum
 }
 
+// InsertFinalizedSnapshot initializes the finalized deposits of the cache from an EIP-4881 deposit tree snapshot.
+// Deposits included in the snapshot do not need to be inserted into the cache. The snapshot is ignored if the
+// cache already finalized as many deposits.
+func (dc *DepositCache) InsertFinalizedSnapshot(ctx context.Context, snapshot *depositsnapshot.DepositTreeSnapshot) error {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.InsertFinalizedSnapshot")
+	defer span.End()
+	if snapshot == nil {
+		return errors.New("nil deposit snapshot")
+	}
+	tree, err := depositsnapshot.FromSnapshot(*snapshot)
+	if err != nil {
+		return errors.Wrap(err, "could not create deposit tree from snapshot")
+	}
+	dc.depositsLock.Lock()
+	defer dc.depositsLock.Unlock()
+
+	index := int64(snapshot.DepositCount()) - 1 // lint:ignore uintcast -- Deposit count should not exceed int64 in your lifetime.
+	if index <= dc.finalizedDeposits.MerkleTrieIndex {
+		return nil
+	}
+	dc.finalizedDeposits = &FinalizedDeposits{
+		Deposits:        tree,
+		MerkleTrieIndex: index,
+	}
+	dc.snapshot = snapshot
+	return nil
+}
+
+// DepositSnapshot returns the EIP-4881 snapshot of the finalized deposits tree.
+func (dc *DepositCache) DepositSnapshot(ctx context.Context) (*depositsnapshot.DepositTreeSnapshot, error) {
+	ctx, span := trace.StartSpan(ctx, "DepositsCache.DepositSnapshot")
+	defer span.End()
+	dc.depositsLock.RLock()
+	defer dc.depositsLock.RUnlock()
+
+	snapshot, err := dc.finalizedDeposits.Deposits.GetSnapshot()
+	if err != nil {
+		return nil, err
+	}
+	return &snapshot, nil
+}
+
 // FinalizedDeposits returns the finalized deposits trie.
 func (dc *DepositCache) FinalizedDeposits(ctx context.Context) *FinalizedDeposits {
 	ctx, span := trace.StartSpan(ctx, "DepositsCache.FinalizedDeposits")
@@ -302,11 +368,16 @@ func (dc *DepositCache) PruneProofs(ctx context.Context, untilDepositIndex int64
 	dc.depositsLock.Lock()
 	defer dc.depositsLock.Unlock()
 
-	if untilDepositIndex >= int64(len(dc.deposits)) {
-		untilDepositIndex = int64(len(dc.deposits) - 1)
+	if len(dc.deposits) == 0 {
+		return nil
+	}
+	if lastIndex := dc.nextDepositIndex() - 1; untilDepositIndex > lastIndex {
+		untilDepositIndex = lastIndex
 	}
 
-	for i := untilDepositIndex; i >= 0; i-- {
+	// The cache may not hold the deposits included in the snapshot it was initialized from,
+	// so the position of a deposit is relative to the first deposit in the cache.
+	for i := untilDepositIndex - dc.deposits[0].Index; i >= 0; i-- {
 		if ctx.Err() != nil {
 			return ctx.Err()
 		}
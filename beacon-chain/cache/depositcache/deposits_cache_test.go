@@ -7,6 +7,8 @@ import (
 	"math/big"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/container/trie"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
@@ -416,7 +418,7 @@ func TestFinalizedDeposits_DepositsCachedCorrectly(t *testing.T) {
 		Index: 3,
 	})
 
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2, [32]byte{}, 0))
 
 	cachedDeposits := dc.FinalizedDeposits(context.Background())
 	require.NotNil(t, cachedDeposits, "Deposits not cached")
@@ -474,9 +476,9 @@ func TestFinalizedDeposits_UtilizesPreviouslyCachedDeposits(t *testing.T) {
 		Index: 2,
 	}
 	dc.deposits = oldFinalizedDeposits
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 1))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 1, [32]byte{}, 0))
 
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2, [32]byte{}, 0))
 
 	dc.deposits = append(dc.deposits, []*ethpb.DepositContainer{newFinalizedDeposit}...)
 
@@ -503,7 +505,7 @@ func TestFinalizedDeposits_HandleZeroDeposits(t *testing.T) {
 	dc, err := New()
 	require.NoError(t, err)
 
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2, [32]byte{}, 0))
 
 	cachedDeposits := dc.FinalizedDeposits(context.Background())
 	require.NotNil(t, cachedDeposits, "Deposits not cached")
@@ -548,7 +550,7 @@ func TestFinalizedDeposits_HandleSmallerThanExpectedDeposits(t *testing.T) {
 	}
 	dc.deposits = finalizedDeposits
 
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 5))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 5, [32]byte{}, 0))
 
 	cachedDeposits := dc.FinalizedDeposits(context.Background())
 	require.NotNil(t, cachedDeposits, "Deposits not cached")
@@ -623,10 +625,10 @@ func TestFinalizedDeposits_HandleLowerEth1DepositIndex(t *testing.T) {
 	}
 	dc.deposits = finalizedDeposits
 
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 5))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 5, [32]byte{}, 0))
 
 	// Reinsert finalized deposits with a lower index.
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2, [32]byte{}, 0))
 
 	cachedDeposits := dc.FinalizedDeposits(context.Background())
 	require.NotNil(t, cachedDeposits, "Deposits not cached")
@@ -694,7 +696,7 @@ func TestNonFinalizedDeposits_ReturnsAllNonFinalizedDeposits(t *testing.T) {
 			},
 			Index: 3,
 		})
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 1))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 1, [32]byte{}, 0))
 
 	deps := dc.NonFinalizedDeposits(context.Background(), 1, nil)
 	assert.Equal(t, 2, len(deps))
@@ -751,7 +753,7 @@ func TestNonFinalizedDeposits_ReturnsNonFinalizedDepositsUpToBlockNumber(t *test
 			},
 			Index: 3,
 		})
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 1))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 1, [32]byte{}, 0))
 
 	deps := dc.NonFinalizedDeposits(context.Background(), 1, big.NewInt(10))
 	assert.Equal(t, 1, len(deps))
@@ -799,10 +801,10 @@ func TestFinalizedDeposits_ReturnsTrieCorrectly(t *testing.T) {
 	assert.NoError(t, err)
 
 	// Perform this in a non-sensical ordering
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 10))
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2))
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 3))
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 4))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 10, [32]byte{}, 0))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 2, [32]byte{}, 0))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 3, [32]byte{}, 0))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 4, [32]byte{}, 0))
 
 	// Mimic finalized deposit trie fetch.
 	fd := dc.FinalizedDeposits(context.Background())
@@ -817,9 +819,9 @@ func TestFinalizedDeposits_ReturnsTrieCorrectly(t *testing.T) {
 		}
 		insertIndex++
 	}
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 15))
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 15))
-	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 14))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 15, [32]byte{}, 0))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 15, [32]byte{}, 0))
+	require.NoError(t, dc.InsertFinalizedDeposits(context.Background(), 14, [32]byte{}, 0))
 
 	fd = dc.FinalizedDeposits(context.Background())
 	deps = dc.NonFinalizedDeposits(context.Background(), fd.MerkleTrieIndex, big.NewInt(30))
@@ -1049,6 +1051,89 @@ func TestDepositMap_WorksCorrectly(t *testing.T) {
 	assert.DeepEqual(t, nilDep, dep)
 }
 
+func TestFinalizedDeposits_Snapshot(t *testing.T) {
+	ctx := context.Background()
+	dc, err := New()
+	require.NoError(t, err)
+
+	_, err = dc.DepositSnapshot(ctx)
+	require.ErrorIs(t, err, depositsnapshot.ErrEmptyExecutionBlock)
+
+	tree := depositsnapshot.New()
+	var roots [][32]byte
+	var ctrs []*ethpb.DepositContainer
+	for i := 0; i < 10; i++ {
+		d := &ethpb.Deposit{
+			Data: &ethpb.Deposit_Data{
+				PublicKey:             bytesutil.PadTo([]byte{byte(i)}, 48),
+				WithdrawalCredentials: make([]byte, 32),
+				Signature:             make([]byte, 96),
+			},
+			Proof: makeDepositProof(),
+		}
+		h, err := d.Data.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, tree.Insert(h[:], i))
+		root, err := tree.HashTreeRoot()
+		require.NoError(t, err)
+		roots = append(roots, root)
+		require.NoError(t, dc.InsertDeposit(ctx, d, uint64(100+i), int64(i), root))
+		ctrs = append(ctrs, &ethpb.DepositContainer{Deposit: d, Eth1BlockHeight: uint64(100 + i), DepositRoot: root[:], Index: int64(i)})
+	}
+	require.NoError(t, dc.InsertFinalizedDeposits(ctx, 5, common.Hash{'a'}, 0))
+	snapshot, err := dc.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(6), snapshot.DepositCount())
+	assert.Equal(t, roots[5], snapshot.DepositRoot())
+	assert.Equal(t, [32]byte{'a'}, snapshot.ExecutionBlockHash())
+	assert.Equal(t, uint64(105), snapshot.ExecutionBlockHeight(), "block of the last finalized deposit should be used")
+
+	// Deposits finalized without an execution block are not part of the snapshot.
+	require.NoError(t, dc.InsertFinalizedDeposits(ctx, 7, [32]byte{}, 0))
+	assert.Equal(t, int64(7), dc.FinalizedDeposits(ctx).MerkleTrieIndex)
+	unchanged, err := dc.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, snapshot, unchanged)
+
+	// Initialize a new cache from the snapshot with only the deposits which are not finalized.
+	restored, err := New()
+	require.NoError(t, err)
+	require.NoError(t, restored.InsertFinalizedSnapshot(ctx, snapshot))
+	assert.ErrorContains(t, "wanted deposit with index 6 to be inserted but received 0", restored.InsertDeposit(ctx, ctrs[0].Deposit, 100, 0, roots[0]))
+	restored.InsertDepositContainers(ctx, ctrs[6:])
+
+	count, root := restored.DepositsNumberAndRootAtHeight(ctx, big.NewInt(105))
+	assert.Equal(t, uint64(6), count)
+	assert.Equal(t, roots[5], root)
+	count, root = restored.DepositsNumberAndRootAtHeight(ctx, big.NewInt(107))
+	assert.Equal(t, uint64(8), count)
+	assert.Equal(t, roots[7], root)
+	count, _ = restored.DepositsNumberAndRootAtHeight(ctx, big.NewInt(99))
+	assert.Equal(t, uint64(0), count)
+
+	fd := restored.FinalizedDeposits(ctx)
+	assert.Equal(t, int64(5), fd.MerkleTrieIndex)
+	for i, d := range restored.NonFinalizedDeposits(ctx, fd.MerkleTrieIndex, nil) {
+		h, err := d.Data.HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, fd.Deposits.Insert(h[:], i+6))
+	}
+	rebuiltRoot, err := fd.Deposits.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, roots[9], rebuiltRoot)
+
+	require.NoError(t, restored.InsertFinalizedDeposits(ctx, 7, common.Hash{'b'}, 200))
+	require.NoError(t, restored.PruneProofs(ctx, 7))
+	deps := restored.AllDeposits(ctx, nil)
+	require.Equal(t, 4, len(deps))
+	assert.DeepEqual(t, [][]byte(nil), deps[1].Proof)
+	assert.NotNil(t, deps[2].Proof)
+
+	// An older snapshot is ignored.
+	require.NoError(t, restored.InsertFinalizedSnapshot(ctx, snapshot))
+	assert.Equal(t, int64(7), restored.FinalizedDeposits(ctx).MerkleTrieIndex)
+}
+
 func makeDepositProof() [][]byte {
 	proof := make([][]byte, int(params.BeaconConfig().DepositContractTreeDepth)+1)
 	for i := range proof {
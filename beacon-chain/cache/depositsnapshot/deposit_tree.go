@@ -6,6 +6,7 @@ package depositsnapshot
 import (
 	"crypto/sha256"
 
+	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/math"
@@ -25,6 +26,10 @@ var (
 	ErrNoDeposits = errors.New("number of deposits should be greater than 0")
 	// ErrTooManyDeposits occurs when the number of deposits exceeds the capacity of the tree.
 	ErrTooManyDeposits = errors.New("number of deposits should not be greater than the capacity of the tree")
+	// ErrInvalidLeafIndex occurs when a leaf is not inserted at the next index of the tree.
+	ErrInvalidLeafIndex = errors.New("leaf should be inserted at the next index of the tree")
+	// ErrFinalizeTooManyDeposits occurs when finalizing more deposits than the tree contains.
+	ErrFinalizeTooManyDeposits = errors.New("number of deposits to finalize should not be greater than the number of deposits")
 )
 
 // DepositTree is the Merkle tree representation of deposits.
@@ -40,8 +45,6 @@ type executionBlock struct {
 }
 
 // New creates an empty deposit tree.
-//
-//nolint:unused
 func New() *DepositTree {
 	var leaves [][32]byte
 	merkle := create(leaves, DepositContractDepth)
@@ -52,10 +55,8 @@ func New() *DepositTree {
 	}
 }
 
-// getSnapshot returns a deposit tree snapshot.
-//
-//nolint:unused
-func (d *DepositTree) getSnapshot() (DepositTreeSnapshot, error) {
+// GetSnapshot returns a deposit tree snapshot.
+func (d *DepositTree) GetSnapshot() (DepositTreeSnapshot, error) {
 	if d.finalizedExecutionBlock == (executionBlock{}) {
 		return DepositTreeSnapshot{}, ErrEmptyExecutionBlock
 	}
@@ -64,37 +65,50 @@ func (d *DepositTree) getSnapshot() (DepositTreeSnapshot, error) {
 	return fromTreeParts(finalized, depositCount, d.finalizedExecutionBlock)
 }
 
-// fromSnapshot returns a deposit tree from a deposit tree snapshot.
-//
-//nolint:unused
-func fromSnapshot(snapshot DepositTreeSnapshot) (DepositTree, error) {
+// FromSnapshot returns a deposit tree from a deposit tree snapshot.
+func FromSnapshot(snapshot DepositTreeSnapshot) (*DepositTree, error) {
 	root, err := snapshot.CalculateRoot()
 	if err != nil {
-		return DepositTree{}, err
+		return nil, err
 	}
 	if snapshot.depositRoot != root {
-		return DepositTree{}, ErrInvalidSnapshotRoot
+		return nil, ErrInvalidSnapshotRoot
 	}
 	if snapshot.depositCount >= math.PowerOf2(uint64(DepositContractDepth)) {
-		return DepositTree{}, ErrTooManyDeposits
+		return nil, ErrTooManyDeposits
 	}
 	tree, err := fromSnapshotParts(snapshot.finalized, snapshot.depositCount, DepositContractDepth)
 	if err != nil {
-		return DepositTree{}, err
+		return nil, err
 	}
 	if snapshot.depositCount == 0 {
-		return DepositTree{}, ErrNoDeposits
+		return nil, ErrNoDeposits
 	}
-	return DepositTree{
+	return &DepositTree{
 		tree:                    tree,
 		mixInLength:             snapshot.depositCount,
 		finalizedExecutionBlock: snapshot.executionBlock,
 	}, nil
 }
 
+// Finalize marks all deposits up to and including eth1DepositIndex as finalized, recording the
+// execution block in which they were included.
+func (d *DepositTree) Finalize(eth1DepositIndex int64, executionHash common.Hash, executionNumber uint64) error {
+	if eth1DepositIndex < 0 {
+		return nil
+	}
+	depositCount := uint64(eth1DepositIndex) + 1
+	if depositCount > d.mixInLength {
+		return ErrFinalizeTooManyDeposits
+	}
+	// Deposits which are already finalized cannot be finalized again at an earlier execution block.
+	if finalized, _ := d.tree.GetFinalized([][32]byte{}); depositCount < finalized {
+		return nil
+	}
+	return d.finalize(&eth.Eth1Data{DepositCount: depositCount, BlockHash: executionHash[:]}, executionNumber)
+}
+
 // finalize marks a deposit as finalized.
-//
-//nolint:unused
 func (d *DepositTree) finalize(eth1data *eth.Eth1Data, executionBlockHeight uint64) error {
 	var blockHash [32]byte
 	copy(blockHash[:], eth1data.BlockHash)
@@ -110,17 +124,16 @@ func (d *DepositTree) finalize(eth1data *eth.Eth1Data, executionBlockHeight uint
 }
 
 // getProof returns the Deposit tree proof.
-//
-//nolint:unused
 func (d *DepositTree) getProof(index uint64) ([32]byte, [][32]byte, error) {
 	if d.mixInLength <= 0 {
 		return [32]byte{}, nil, ErrInvalidMixInLength
 	}
-	finalizedDeposits, _ := d.tree.GetFinalized([][32]byte{})
-	if finalizedDeposits != 0 {
-		finalizedDeposits = finalizedDeposits - 1
+	if index >= d.mixInLength {
+		return [32]byte{}, nil, ErrInvalidIndex
 	}
-	if index <= finalizedDeposits {
+	// Finalized deposits have been pruned from the tree, so no proof can be generated for them.
+	finalizedDeposits, _ := d.tree.GetFinalized([][32]byte{})
+	if index < finalizedDeposits {
 		return [32]byte{}, nil, ErrInvalidIndex
 	}
 	leaf, proof := generateProof(d.tree, index, DepositContractDepth)
@@ -131,16 +144,12 @@ func (d *DepositTree) getProof(index uint64) ([32]byte, [][32]byte, error) {
 }
 
 // getRoot returns the root of the deposit tree.
-//
-//nolint:unused
 func (d *DepositTree) getRoot() [32]byte {
 	root := d.tree.GetRoot()
 	return sha256.Sum256(append(root[:], bytesutil.Uint64ToBytesLittleEndian32(d.mixInLength)...))
 }
 
 // pushLeaf adds a new leaf to the tree.
-//
-//nolint:unused
 func (d *DepositTree) pushLeaf(leaf [32]byte) error {
 	var err error
 	d.tree, err = d.tree.PushLeaf(leaf, DepositContractDepth)
@@ -150,3 +159,50 @@ func (d *DepositTree) pushLeaf(leaf [32]byte) error {
 	d.mixInLength++
 	return nil
 }
+
+// Insert adds a new deposit leaf to the tree. The index must be the next free index of the tree.
+func (d *DepositTree) Insert(item []byte, index int) error {
+	if len(item) != 32 {
+		return errors.Errorf("wanted a 32 byte leaf but got %d bytes", len(item))
+	}
+	if index < 0 || uint64(index) != d.mixInLength {
+		return errors.Wrapf(ErrInvalidLeafIndex, "wanted index %d but got %d", d.mixInLength, index)
+	}
+	return d.pushLeaf(bytesutil.ToBytes32(item))
+}
+
+// HashTreeRoot returns the root of the deposit tree, mixed in with the number of deposits.
+func (d *DepositTree) HashTreeRoot() ([32]byte, error) {
+	return d.getRoot(), nil
+}
+
+// NumOfItems returns the number of deposits in the tree, including finalized deposits.
+func (d *DepositTree) NumOfItems() int {
+	return int(d.mixInLength)
+}
+
+// MerkleProof returns the proof of the deposit at the given index, mixed in with the number of deposits.
+// Proofs can only be generated for deposits which are not finalized.
+func (d *DepositTree) MerkleProof(index int) ([][]byte, error) {
+	if index < 0 {
+		return nil, ErrInvalidIndex
+	}
+	_, proof, err := d.getProof(uint64(index))
+	if err != nil {
+		return nil, err
+	}
+	result := make([][]byte, len(proof))
+	for i := range proof {
+		result[i] = bytesutil.SafeCopyBytes(proof[i][:])
+	}
+	return result, nil
+}
+
+// Copy performs a deep copy of the tree.
+func (d *DepositTree) Copy() *DepositTree {
+	return &DepositTree{
+		tree:                    copyNode(d.tree),
+		mixInLength:             d.mixInLength,
+		finalizedExecutionBlock: d.finalizedExecutionBlock,
+	}
+}
@@ -2,6 +2,7 @@ package depositsnapshot
 
 import (
 	"crypto/sha256"
+	"encoding/binary"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
@@ -10,12 +11,16 @@ import (
 var (
 	// ErrZeroIndex occurs when the value of index is 0.
 	ErrZeroIndex = errors.New("index should be greater than 0")
+	// ErrInvalidSnapshotEncoding occurs when a snapshot cannot be decoded from its ssz encoding.
+	ErrInvalidSnapshotEncoding = errors.New("invalid ssz encoding of deposit tree snapshot")
 )
 
+// snapshotFixedSize is the size of the fixed part of the ssz encoded snapshot: the offset of the
+// finalized list, the deposit root, the deposit count, the execution block hash and height.
+const snapshotFixedSize = 4 + 32 + 8 + 32 + 8
+
 // DepositTreeSnapshot represents the data used to create a
 // deposit tree given a snapshot.
-//
-//nolint:unused
 type DepositTreeSnapshot struct {
 	finalized      [][32]byte
 	depositRoot    [32]byte
@@ -43,9 +48,75 @@ func (ds *DepositTreeSnapshot) CalculateRoot() ([32]byte, error) {
 	return sha256.Sum256(append(root[:], bytesutil.Uint64ToBytesLittleEndian32(ds.depositCount)...)), nil
 }
 
+// Finalized returns the hashes of the finalized subtrees of the deposit tree.
+func (ds *DepositTreeSnapshot) Finalized() [][32]byte {
+	finalized := make([][32]byte, len(ds.finalized))
+	copy(finalized, ds.finalized)
+	return finalized
+}
+
+// DepositRoot returns the root of the deposit tree at the time of the snapshot.
+func (ds *DepositTreeSnapshot) DepositRoot() [32]byte {
+	return ds.depositRoot
+}
+
+// DepositCount returns the number of deposits in the snapshot.
+func (ds *DepositTreeSnapshot) DepositCount() uint64 {
+	return ds.depositCount
+}
+
+// ExecutionBlockHash returns the hash of the execution block the snapshot was finalized at.
+func (ds *DepositTreeSnapshot) ExecutionBlockHash() [32]byte {
+	return ds.executionBlock.Hash
+}
+
+// ExecutionBlockHeight returns the height of the execution block the snapshot was finalized at.
+func (ds *DepositTreeSnapshot) ExecutionBlockHeight() uint64 {
+	return ds.executionBlock.Depth
+}
+
+// MarshalSSZ encodes the snapshot as the EIP-4881 DepositTreeSnapshot ssz container.
+func (ds *DepositTreeSnapshot) MarshalSSZ() ([]byte, error) {
+	if len(ds.finalized) > DepositContractDepth {
+		return nil, errors.Wrapf(ErrInvalidSnapshotEncoding, "%d finalized hashes exceed the limit of %d", len(ds.finalized), DepositContractDepth)
+	}
+	enc := make([]byte, 0, snapshotFixedSize+32*len(ds.finalized))
+	enc = binary.LittleEndian.AppendUint32(enc, snapshotFixedSize)
+	enc = append(enc, ds.depositRoot[:]...)
+	enc = binary.LittleEndian.AppendUint64(enc, ds.depositCount)
+	enc = append(enc, ds.executionBlock.Hash[:]...)
+	enc = binary.LittleEndian.AppendUint64(enc, ds.executionBlock.Depth)
+	for _, f := range ds.finalized {
+		enc = append(enc, f[:]...)
+	}
+	return enc, nil
+}
+
+// UnmarshalSSZ decodes the snapshot from the EIP-4881 DepositTreeSnapshot ssz container.
+func (ds *DepositTreeSnapshot) UnmarshalSSZ(enc []byte) error {
+	if len(enc) < snapshotFixedSize {
+		return errors.Wrapf(ErrInvalidSnapshotEncoding, "got %d bytes, wanted at least %d", len(enc), snapshotFixedSize)
+	}
+	if offset := binary.LittleEndian.Uint32(enc[0:4]); offset != snapshotFixedSize {
+		return errors.Wrapf(ErrInvalidSnapshotEncoding, "invalid offset %d of finalized hashes", offset)
+	}
+	list := enc[snapshotFixedSize:]
+	if len(list)%32 != 0 || len(list)/32 > DepositContractDepth {
+		return errors.Wrapf(ErrInvalidSnapshotEncoding, "invalid length %d of finalized hashes", len(list))
+	}
+	finalized := make([][32]byte, len(list)/32)
+	for i := range finalized {
+		copy(finalized[i][:], list[i*32:(i+1)*32])
+	}
+	ds.finalized = finalized
+	copy(ds.depositRoot[:], enc[4:36])
+	ds.depositCount = binary.LittleEndian.Uint64(enc[36:44])
+	copy(ds.executionBlock.Hash[:], enc[44:76])
+	ds.executionBlock.Depth = binary.LittleEndian.Uint64(enc[76:84])
+	return nil
+}
+
 // fromTreeParts constructs the deposit tree from pre-existing data.
-//
-//nolint:unused
 func fromTreeParts(finalised [][32]byte, depositCount uint64, executionBlock executionBlock) (DepositTreeSnapshot, error) {
 	snapshot := DepositTreeSnapshot{
 		finalized:      finalised,
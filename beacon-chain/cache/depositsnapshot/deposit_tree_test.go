@@ -0,0 +1,142 @@
+package depositsnapshot
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func depositLeaves(t *testing.T, n int) [][]byte {
+	leaves := make([][]byte, n)
+	for i := range leaves {
+		leaf := hexString(t, fmt.Sprintf("%064d", i+1))
+		leaves[i] = leaf[:]
+	}
+	return leaves
+}
+
+func TestDepositTree_MatchesSparseMerkleTrie(t *testing.T) {
+	leaves := depositLeaves(t, 21)
+	tree := New()
+	for i, leaf := range leaves {
+		require.NoError(t, tree.Insert(leaf, i))
+	}
+	assert.Equal(t, len(leaves), tree.NumOfItems())
+	sparse, err := trie.GenerateTrieFromItems(leaves, DepositContractDepth)
+	require.NoError(t, err)
+	want, err := sparse.HashTreeRoot()
+	require.NoError(t, err)
+	root, err := tree.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, want, root)
+
+	for i := range leaves {
+		wantProof, err := sparse.MerkleProof(i)
+		require.NoError(t, err)
+		proof, err := tree.MerkleProof(i)
+		require.NoError(t, err)
+		require.DeepEqual(t, wantProof, proof)
+		assert.Equal(t, true, trie.VerifyMerkleProofWithDepth(root[:], leaves[i], uint64(i), proof, DepositContractDepth))
+	}
+
+	err = tree.Insert(leaves[0], 5)
+	require.ErrorIs(t, err, ErrInvalidLeafIndex)
+}
+
+func TestDepositTree_Finalize(t *testing.T) {
+	leaves := depositLeaves(t, 21)
+	tree := New()
+	for i, leaf := range leaves {
+		require.NoError(t, tree.Insert(leaf, i))
+	}
+	root, err := tree.HashTreeRoot()
+	require.NoError(t, err)
+
+	require.ErrorIs(t, tree.Finalize(21, common.Hash{'a'}, 100), ErrFinalizeTooManyDeposits)
+	require.NoError(t, tree.Finalize(11, common.Hash{'a'}, 100))
+	finalizedRoot, err := tree.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, root, finalizedRoot)
+
+	_, err = tree.MerkleProof(11)
+	require.ErrorIs(t, err, ErrInvalidIndex)
+	proof, err := tree.MerkleProof(12)
+	require.NoError(t, err)
+	assert.Equal(t, true, trie.VerifyMerkleProofWithDepth(root[:], leaves[12], 12, proof, DepositContractDepth))
+
+	// Finalizing fewer deposits is ignored.
+	require.NoError(t, tree.Finalize(5, common.Hash{'b'}, 90))
+	snapshot, err := tree.GetSnapshot()
+	require.NoError(t, err)
+	assert.Equal(t, uint64(12), snapshot.DepositCount())
+	assert.Equal(t, [32]byte{'a'}, snapshot.ExecutionBlockHash())
+	assert.Equal(t, uint64(100), snapshot.ExecutionBlockHeight())
+	finalizedTrie, err := trie.GenerateTrieFromItems(leaves[:12], DepositContractDepth)
+	require.NoError(t, err)
+	want, err := finalizedTrie.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, want, snapshot.DepositRoot())
+}
+
+func TestDepositTree_Copy(t *testing.T) {
+	leaves := depositLeaves(t, 10)
+	tree := New()
+	for i, leaf := range leaves[:5] {
+		require.NoError(t, tree.Insert(leaf, i))
+	}
+	root, err := tree.HashTreeRoot()
+	require.NoError(t, err)
+
+	cp := tree.Copy()
+	for i, leaf := range leaves[5:] {
+		require.NoError(t, cp.Insert(leaf, i+5))
+	}
+	copyRoot, err := cp.HashTreeRoot()
+	require.NoError(t, err)
+	assert.NotEqual(t, root, copyRoot)
+	assert.Equal(t, 5, tree.NumOfItems())
+	unchangedRoot, err := tree.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, root, unchangedRoot)
+}
+
+func TestDepositTree_FromSnapshot(t *testing.T) {
+	leaves := depositLeaves(t, 30)
+	tree := New()
+	for i, leaf := range leaves[:20] {
+		require.NoError(t, tree.Insert(leaf, i))
+	}
+	require.NoError(t, tree.Finalize(12, common.Hash{'a'}, 100))
+	snapshot, err := tree.GetSnapshot()
+	require.NoError(t, err)
+
+	enc, err := snapshot.MarshalSSZ()
+	require.NoError(t, err)
+	decoded := DepositTreeSnapshot{}
+	require.NoError(t, decoded.UnmarshalSSZ(enc))
+	require.DeepEqual(t, snapshot, decoded)
+	require.ErrorIs(t, decoded.UnmarshalSSZ(enc[:len(enc)-1]), ErrInvalidSnapshotEncoding)
+
+	restored, err := FromSnapshot(decoded)
+	require.NoError(t, err)
+	for i := 13; i < len(leaves); i++ {
+		if i < 20 {
+			require.NoError(t, restored.Insert(leaves[i], i))
+		} else {
+			require.NoError(t, tree.Insert(leaves[i], i))
+			require.NoError(t, restored.Insert(leaves[i], i))
+		}
+	}
+	want, err := tree.HashTreeRoot()
+	require.NoError(t, err)
+	root, err := restored.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, want, root)
+	proof, err := restored.MerkleProof(25)
+	require.NoError(t, err)
+	assert.Equal(t, true, trie.VerifyMerkleProofWithDepth(root[:], leaves[25], 25, proof, DepositContractDepth))
+}
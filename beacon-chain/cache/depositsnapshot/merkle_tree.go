@@ -204,13 +204,20 @@ func (_ *LeafNode) Left() MerkleTreeNode {
 // InnerNode represents an inner node with two children and satisfies the MerkleTreeNode interface.
 type InnerNode struct {
 	left, right MerkleTreeNode
+	// root caches the root of the node, it is reset whenever a leaf is pushed below the node.
+	root *[32]byte
 }
 
 // GetRoot returns the root of the Merkle tree.
 func (n *InnerNode) GetRoot() [32]byte {
+	if n.root != nil {
+		return *n.root
+	}
 	left := n.left.GetRoot()
 	right := n.right.GetRoot()
-	return hash.Hash(append(left[:], right[:]...))
+	root := hash.Hash(append(left[:], right[:]...))
+	n.root = &root
+	return root
 }
 
 // IsFull returns whether there is space left for deposits.
@@ -250,6 +257,7 @@ func (n *InnerNode) GetFinalized(result [][32]byte) (uint64, [][32]byte) {
 
 // PushLeaf adds a new leaf node at the next available zero node.
 func (n *InnerNode) PushLeaf(leaf [32]byte, depth uint64) (MerkleTreeNode, error) {
+	n.root = nil
 	if !n.left.IsFull() {
 		left, err := n.left.PushLeaf(leaf, depth-1)
 		if err == nil {
@@ -278,6 +286,16 @@ func (n *InnerNode) Left() MerkleTreeNode {
 	return n.left
 }
 
+// copyNode returns a deep copy of the given node. Only inner nodes are modified in place
+// when leaves are pushed or deposits are finalized, so all other nodes can be shared.
+func copyNode(node MerkleTreeNode) MerkleTreeNode {
+	n, ok := node.(*InnerNode)
+	if !ok {
+		return node
+	}
+	return &InnerNode{left: copyNode(n.left), right: copyNode(n.right), root: n.root}
+}
+
 // ZeroNode represents an empty node without a deposit and satisfies the MerkleTreeNode interface.
 type ZeroNode struct {
 	depth uint64
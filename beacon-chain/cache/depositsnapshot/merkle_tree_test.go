@@ -44,7 +44,7 @@ func Test_create(t *testing.T) {
 			name:   "depth of 1",
 			leaves: [][32]byte{hexString(t, fmt.Sprintf("%064d", 0))},
 			depth:  1,
-			want:   &InnerNode{&LeafNode{}, &ZeroNode{}},
+			want:   &InnerNode{left: &LeafNode{}, right: &ZeroNode{}},
 		},
 	}
 	for _, tt := range tests {
@@ -87,7 +87,7 @@ func Test_fromSnapshotParts(t *testing.T) {
 			deposits:  2,
 			level:     4,
 			want: &InnerNode{
-				left:  &InnerNode{&InnerNode{&FinalizedNode{depositCount: 2, hash: hexString(t, fmt.Sprintf("%064d", 0))}, &ZeroNode{1}}, &ZeroNode{2}},
+				left:  &InnerNode{left: &InnerNode{left: &FinalizedNode{depositCount: 2, hash: hexString(t, fmt.Sprintf("%064d", 0))}, right: &ZeroNode{1}}, right: &ZeroNode{2}},
 				right: &ZeroNode{3},
 			},
 		},
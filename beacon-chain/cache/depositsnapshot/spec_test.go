@@ -244,13 +244,13 @@ func compareProof(t *testing.T, tree1, tree2 *DepositTree, index uint64) {
 }
 
 func cloneFromSnapshot(t *testing.T, snapshot DepositTreeSnapshot, testCases []testCase) *DepositTree {
-	cp, err := fromSnapshot(snapshot)
+	cp, err := FromSnapshot(snapshot)
 	require.NoError(t, err)
 	for _, c := range testCases {
 		err = cp.pushLeaf(c.DepositDataRoot)
 		require.NoError(t, err)
 	}
-	return &cp
+	return cp
 }
 
 func TestDepositCases(t *testing.T) {
@@ -281,7 +281,7 @@ func TestFinalization(t *testing.T) {
 	require.NoError(t, err)
 	// ensure finalization doesn't change root
 	require.Equal(t, tree.getRoot(), originalRoot)
-	snapshotData, err := tree.getSnapshot()
+	snapshotData, err := tree.GetSnapshot()
 	require.NoError(t, err)
 	require.DeepEqual(t, testCases[100].Snapshot.DepositTreeSnapshot, snapshotData)
 	// create a copy of the tree from a snapshot by replaying
@@ -299,7 +299,7 @@ func TestFinalization(t *testing.T) {
 	//	root should still be the same
 	require.Equal(t, originalRoot, tree.getRoot())
 	// create a copy of the tree by taking a snapshot again
-	snapshotData, err = tree.getSnapshot()
+	snapshotData, err = tree.GetSnapshot()
 	require.NoError(t, err)
 	cp = cloneFromSnapshot(t, snapshotData, testCases[106:128])
 	// create a copy of the tree by replaying ALL deposits from nothing
@@ -329,14 +329,14 @@ func TestSnapshotCases(t *testing.T) {
 			BlockHash:    c.Eth1Data.BlockHash[:],
 		}, c.BlockHeight)
 		require.NoError(t, err)
-		s, err := tree.getSnapshot()
+		s, err := tree.GetSnapshot()
 		require.NoError(t, err)
 		require.DeepEqual(t, c.Snapshot.DepositTreeSnapshot, s)
 	}
 }
 
 func TestEmptyTreeSnapshot(t *testing.T) {
-	_, err := New().getSnapshot()
+	_, err := New().GetSnapshot()
 	require.ErrorContains(t, "empty execution block", err)
 }
 
@@ -350,6 +350,6 @@ func TestInvalidSnapshot(t *testing.T) {
 			Depth: 0,
 		},
 	}
-	_, err := fromSnapshot(invalidSnapshot)
+	_, err := FromSnapshot(invalidSnapshot)
 	require.ErrorContains(t, "snapshot root is invalid", err)
 }
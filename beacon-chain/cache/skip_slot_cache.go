@@ -9,6 +9,7 @@ import (
 	lru "github.com/hashicorp/golang-lru"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	lruwrpr "github.com/prysmaticlabs/prysm/v4/cache/lru"
 	"go.opencensus.io/trace"
@@ -34,6 +35,13 @@ type SkipSlotCache struct {
 	inProgress map[[32]byte]bool
 }
 
+// skipSlotCacheEntry holds a cached state together with the burn applied to rewards while the state was
+// advanced through the skipped slots, so that callers accounting for the burn can replay it on a hit.
+type skipSlotCacheEntry struct {
+	state state.BeaconState
+	burn  *pulse.BurnTracker
+}
+
 // NewSkipSlotCache initializes the map and underlying cache.
 func NewSkipSlotCache() *SkipSlotCache {
 	return &SkipSlotCache{
@@ -53,14 +61,14 @@ func (c *SkipSlotCache) Disable() {
 }
 
 // Get waits for any in progress calculation to complete before returning a
-// cached response, if any.
-func (c *SkipSlotCache) Get(ctx context.Context, r [32]byte) (state.BeaconState, error) {
+// cached response, if any, along with the burn recorded while computing it.
+func (c *SkipSlotCache) Get(ctx context.Context, r [32]byte) (state.BeaconState, *pulse.BurnTracker, error) {
 	ctx, span := trace.StartSpan(ctx, "skipSlotCache.Get")
 	defer span.End()
 	if c.disabled {
 		// Return a miss result if cache is not enabled.
 		skipSlotCacheMiss.Inc()
-		return nil, nil
+		return nil, nil, nil
 	}
 
 	delay := minDelay
@@ -70,7 +78,7 @@ func (c *SkipSlotCache) Get(ctx context.Context, r [32]byte) (state.BeaconState,
 	inProgress := false
 	for {
 		if ctx.Err() != nil {
-			return nil, ctx.Err()
+			return nil, nil, ctx.Err()
 		}
 
 		c.lock.RLock()
@@ -94,11 +102,12 @@ func (c *SkipSlotCache) Get(ctx context.Context, r [32]byte) (state.BeaconState,
 	if exists && item != nil {
 		skipSlotCacheHit.Inc()
 		span.AddAttributes(trace.BoolAttribute("hit", true))
-		return item.(state.BeaconState).Copy(), nil
+		entry := item.(*skipSlotCacheEntry)
+		return entry.state.Copy(), entry.burn, nil
 	}
 	skipSlotCacheMiss.Inc()
 	span.AddAttributes(trace.BoolAttribute("hit", false))
-	return nil, nil
+	return nil, nil, nil
 }
 
 // MarkInProgress a request so that any other similar requests will block on
@@ -131,11 +140,12 @@ func (c *SkipSlotCache) MarkNotInProgress(r [32]byte) {
 	delete(c.inProgress, r)
 }
 
-// Put the response in the cache.
-func (c *SkipSlotCache) Put(_ context.Context, r [32]byte, state state.BeaconState) {
+// Put the response in the cache, along with the burn recorded while computing it.
+// The burn tracker must not be modified once it is cached.
+func (c *SkipSlotCache) Put(_ context.Context, r [32]byte, state state.BeaconState, burn *pulse.BurnTracker) {
 	if c.disabled {
 		return
 	}
 	// Copy state so cached value is not mutated.
-	c.cache.Add(r, state.Copy())
+	c.cache.Add(r, &skipSlotCacheEntry{state: state.Copy(), burn: burn})
 }
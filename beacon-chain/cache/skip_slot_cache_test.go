@@ -5,6 +5,7 @@ import (
 	"testing"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
@@ -17,7 +18,7 @@ func TestSkipSlotCache_RoundTrip(t *testing.T) {
 	c := cache.NewSkipSlotCache()
 
 	r := [32]byte{'a'}
-	s, err := c.Get(ctx, r)
+	s, _, err := c.Get(ctx, r)
 	require.NoError(t, err)
 	assert.Equal(t, state.BeaconState(nil), s, "Empty cache returned an object")
 
@@ -28,10 +29,13 @@ func TestSkipSlotCache_RoundTrip(t *testing.T) {
 	})
 	require.NoError(t, err)
 
-	c.Put(ctx, r, s)
+	burn := pulse.NewBurnTracker()
+	burn.Record(0, pulse.AttestationBurn, 0, 100, 90)
+	c.Put(ctx, r, s, burn)
 	c.MarkNotInProgress(r)
 
-	res, err := c.Get(ctx, r)
+	res, resBurn, err := c.Get(ctx, r)
 	require.NoError(t, err)
 	assert.DeepEqual(t, res.ToProto(), s.ToProto(), "Expected equal protos to return from cache")
+	assert.Equal(t, burn, resBurn, "Expected the burn of the cached state")
 }
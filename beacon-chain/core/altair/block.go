@@ -6,6 +6,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	p2pType "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
@@ -92,6 +93,7 @@ func processSyncAggregate(ctx context.Context, s state.BeaconState, sync *ethpb.
 		return nil, nil, 0, err
 	}
 
+	epoch := slots.ToEpoch(s.Slot())
 	earnedProposerReward := uint64(0)
 	for i := uint64(0); i < sync.SyncCommitteeBits.Len(); i++ {
 		vIdx, exists := s.ValidatorIndexByPubkey(bytesutil.ToBytes48(committeeKeys[i]))
@@ -106,7 +108,8 @@ func processSyncAggregate(ctx context.Context, s state.BeaconState, sync *ethpb.
 				return nil, nil, 0, err
 			}
 			votedKeys = append(votedKeys, pubKey)
-			if err := helpers.IncreaseBalance(s, vIdx, participantReward, true); err != nil {
+			reward := pulse.ApplyTrackedBurn(ctx, epoch, pulse.SyncCommitteeBurn, vIdx, participantReward)
+			if err := helpers.IncreaseBalance(s, vIdx, reward, false); err != nil {
 				return nil, nil, 0, err
 			}
 			earnedProposerReward += proposerReward
@@ -116,7 +119,8 @@ func processSyncAggregate(ctx context.Context, s state.BeaconState, sync *ethpb.
 			}
 		}
 	}
-	if err := helpers.IncreaseBalance(s, proposerIndex, earnedProposerReward, true); err != nil {
+	reward := pulse.ApplyTrackedBurn(ctx, epoch, pulse.ProposerBurn, proposerIndex, earnedProposerReward)
+	if err := helpers.IncreaseBalance(s, proposerIndex, reward, false); err != nil {
 		return nil, nil, 0, err
 	}
 	return s, votedKeys, earnedProposerReward, err
@@ -7,9 +7,11 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/math"
 	"go.opencensus.io/trace"
 )
@@ -206,6 +208,7 @@ func ProcessEpochParticipation(
 // ProcessRewardsAndPenaltiesPrecompute processes the rewards and penalties of individual validator.
 // This is an optimized version by passing in precomputed validator attesting records and total epoch balances.
 func ProcessRewardsAndPenaltiesPrecompute(
+	ctx context.Context,
 	beaconState state.BeaconState,
 	bal *precompute.Balance,
 	vals []*precompute.Validator,
@@ -227,13 +230,15 @@ func ProcessRewardsAndPenaltiesPrecompute(
 		return nil, errors.Wrap(err, "could not get attestation delta")
 	}
 
+	epoch := time.CurrentEpoch(beaconState)
 	balances := beaconState.Balances()
 	for i := 0; i < numOfVals; i++ {
 		vals[i].BeforeEpochTransitionBalance = balances[i]
 
 		// Compute the post balance of the validator after accounting for the
 		// attester and proposer rewards and penalties.
-		balances[i], err = helpers.IncreaseBalanceWithVal(balances[i], attsRewards[i], true)
+		reward := pulse.ApplyTrackedBurn(ctx, epoch, pulse.AttestationBurn, primitives.ValidatorIndex(i), attsRewards[i])
+		balances[i], err = helpers.IncreaseBalanceWithVal(balances[i], reward, false)
 		if err != nil {
 			return nil, err
 		}
@@ -249,12 +254,38 @@ func ProcessRewardsAndPenaltiesPrecompute(
 	return beaconState, nil
 }
 
+// AttDelta contains the rewards and penalties of a single validator for the previous epoch attestation duties,
+// broken down by component. The rewards are the raw values before the PulseChain burn is applied.
+type AttDelta struct {
+	HeadReward        uint64
+	SourceReward      uint64
+	SourcePenalty     uint64
+	TargetReward      uint64
+	TargetPenalty     uint64
+	InactivityPenalty uint64
+}
+
 // AttestationsDelta computes and returns the rewards and penalties differences for individual validators based on the
 // voting records.
 func AttestationsDelta(beaconState state.BeaconState, bal *precompute.Balance, vals []*precompute.Validator) (rewards, penalties []uint64, err error) {
-	numOfVals := beaconState.NumValidators()
-	rewards = make([]uint64, numOfVals)
-	penalties = make([]uint64, numOfVals)
+	deltas, err := AttestationsDeltaBreakdown(beaconState, bal, vals)
+	if err != nil {
+		return nil, nil, err
+	}
+	rewards = make([]uint64, len(deltas))
+	penalties = make([]uint64, len(deltas))
+	for i, d := range deltas {
+		rewards[i] = d.HeadReward + d.SourceReward + d.TargetReward
+		penalties[i] = d.SourcePenalty + d.TargetPenalty + d.InactivityPenalty
+	}
+	return rewards, penalties, nil
+}
+
+// AttestationsDeltaBreakdown computes the per component attestation rewards and penalties of the given validators
+// based on the voting records. The validators don't need to be part of the state's registry, which allows
+// computing the rewards of hypothetical validators.
+func AttestationsDeltaBreakdown(beaconState state.BeaconState, bal *precompute.Balance, vals []*precompute.Validator) ([]*AttDelta, error) {
+	deltas := make([]*AttDelta, len(vals))
 
 	cfg := params.BeaconConfig()
 	prevEpoch := time.PrevEpoch(beaconState)
@@ -271,29 +302,29 @@ func AttestationsDelta(beaconState state.BeaconState, bal *precompute.Balance, v
 	bias := cfg.InactivityScoreBias
 	inactivityPenaltyQuotient, err := beaconState.InactivityPenaltyQuotient()
 	if err != nil {
-		return nil, nil, err
+		return nil, err
 	}
 	inactivityDenominator := bias * inactivityPenaltyQuotient
 
 	for i, v := range vals {
-		rewards[i], penalties[i], err = attestationDelta(bal, v, baseRewardMultiplier.Uint64(), inactivityDenominator, leak)
+		deltas[i], err = attestationDelta(bal, v, baseRewardMultiplier.Uint64(), inactivityDenominator, leak)
 		if err != nil {
-			return nil, nil, err
+			return nil, err
 		}
 	}
 
-	return rewards, penalties, nil
+	return deltas, nil
 }
 
 func attestationDelta(
 	bal *precompute.Balance,
 	val *precompute.Validator,
 	baseRewardMultiplier, inactivityDenominator uint64,
-	inactivityLeak bool) (reward, penalty uint64, err error) {
+	inactivityLeak bool) (*AttDelta, error) {
 	eligible := val.IsActivePrevEpoch || (val.IsSlashed && !val.IsWithdrawableCurrentEpoch)
 	// Per spec `ActiveCurrentEpoch` can't be 0 to process attestation delta.
 	if !eligible || bal.ActiveCurrentEpoch.Cmp(big.NewInt(0)) == 0 {
-		return 0, 0, nil
+		return &AttDelta{}, nil
 	}
 
 	cfg := params.BeaconConfig()
@@ -305,7 +336,7 @@ func attestationDelta(
 	srcWeight := new(big.Int).SetUint64(cfg.TimelySourceWeight)
 	tgtWeight := new(big.Int).SetUint64(cfg.TimelyTargetWeight)
 	headWeight := new(big.Int).SetUint64(cfg.TimelyHeadWeight)
-	reward, penalty = uint64(0), uint64(0)
+	d := &AttDelta{}
 
 	// rewardDenominator = activeIncrements * weightDenominator
 	rewardDenominator := new(big.Int).Div(bal.ActiveCurrentEpoch, increment)
@@ -316,12 +347,12 @@ func attestationDelta(
 		if !inactivityLeak {
 			n := new(big.Int).Mul(baseReward, srcWeight)
 			n.Mul(n, new(big.Int).Div(bal.PrevEpochAttested, increment))
-			reward += n.Div(n, rewardDenominator).Uint64()
+			d.SourceReward = n.Div(n, rewardDenominator).Uint64()
 		}
 	} else {
 		n := new(big.Int).Mul(baseReward, srcWeight)
 		n.Div(n, weightDenominator)
-		penalty += n.Uint64()
+		d.SourcePenalty = n.Uint64()
 	}
 
 	// Process target reward / penalty
@@ -329,12 +360,12 @@ func attestationDelta(
 		if !inactivityLeak {
 			n := new(big.Int).Mul(baseReward, tgtWeight)
 			n.Mul(n, new(big.Int).Div(bal.PrevEpochTargetAttested, increment))
-			reward += n.Div(n, rewardDenominator).Uint64()
+			d.TargetReward = n.Div(n, rewardDenominator).Uint64()
 		}
 	} else {
 		n := new(big.Int).Mul(baseReward, tgtWeight)
 		n.Div(n, weightDenominator)
-		penalty += n.Uint64()
+		d.TargetPenalty = n.Uint64()
 	}
 
 	// Process head reward / penalty
@@ -342,7 +373,7 @@ func attestationDelta(
 		if !inactivityLeak {
 			n := new(big.Int).Mul(baseReward, headWeight)
 			n.Mul(n, new(big.Int).Div(bal.PrevEpochHeadAttested, increment))
-			reward += n.Div(n, rewardDenominator).Uint64()
+			d.HeadReward = n.Div(n, rewardDenominator).Uint64()
 		}
 	}
 
@@ -353,8 +384,8 @@ func attestationDelta(
 		additionalPenalty := new(big.Int).SetUint64(effectiveBalance)
 		additionalPenalty.Mul(additionalPenalty, new(big.Int).SetUint64(val.InactivityScore))
 		additionalPenalty.Div(additionalPenalty, new(big.Int).SetUint64(inactivityDenominator))
-		penalty += additionalPenalty.Uint64()
+		d.InactivityPenalty = additionalPenalty.Uint64()
 	}
 
-	return reward, penalty, nil
+	return d, nil
 }
@@ -242,6 +242,28 @@ func TestAttestationsDelta(t *testing.T) {
 	require.DeepEqual(t, want, penalties)
 }
 
+func TestAttestationsDeltaBreakdown(t *testing.T) {
+	s, err := testState()
+	require.NoError(t, err)
+	validators, balance, err := InitializePrecomputeValidators(context.Background(), s)
+	require.NoError(t, err)
+	validators, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
+	require.NoError(t, err)
+	deltas, err := AttestationsDeltaBreakdown(s, balance, validators)
+	require.NoError(t, err)
+	rewards, penalties, err := AttestationsDelta(s, balance, validators)
+	require.NoError(t, err)
+
+	require.Equal(t, len(rewards), len(deltas))
+	for i, d := range deltas {
+		require.Equal(t, rewards[i], d.HeadReward+d.SourceReward+d.TargetReward)
+		require.Equal(t, penalties[i], d.SourcePenalty+d.TargetPenalty+d.InactivityPenalty)
+	}
+	// The first validator didn't attest and the last one attested to source, target and head.
+	require.DeepEqual(t, &AttDelta{SourcePenalty: 1252195, TargetPenalty: 2325505}, deltas[0])
+	require.Equal(t, true, deltas[3].HeadReward > 0 && deltas[3].SourceReward > 0 && deltas[3].TargetReward > 0)
+}
+
 func TestAttestationsDeltaBellatrix(t *testing.T) {
 	s, err := testStateBellatrix()
 	require.NoError(t, err)
@@ -280,7 +302,7 @@ func TestProcessRewardsAndPenaltiesPrecompute_Ok(t *testing.T) {
 	require.NoError(t, err)
 	validators, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
 	require.NoError(t, err)
-	s, err = ProcessRewardsAndPenaltiesPrecompute(s, balance, validators)
+	s, err = ProcessRewardsAndPenaltiesPrecompute(context.Background(), s, balance, validators)
 	require.NoError(t, err)
 
 	balances := s.Balances()
@@ -313,12 +335,12 @@ func TestProcessRewardsAndPenaltiesPrecompute_InactivityLeak(t *testing.T) {
 	validators, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
 	require.NoError(t, err)
 	sCopy := s.Copy()
-	s, err = ProcessRewardsAndPenaltiesPrecompute(s, balance, validators)
+	s, err = ProcessRewardsAndPenaltiesPrecompute(context.Background(), s, balance, validators)
 	require.NoError(t, err)
 
 	// Copied state where finality happened long ago
 	require.NoError(t, sCopy.SetSlot(params.BeaconConfig().SlotsPerEpoch*1000))
-	sCopy, err = ProcessRewardsAndPenaltiesPrecompute(sCopy, balance, validators)
+	sCopy, err = ProcessRewardsAndPenaltiesPrecompute(context.Background(), sCopy, balance, validators)
 	require.NoError(t, err)
 
 	balances := s.Balances()
@@ -399,7 +421,7 @@ func TestProcessRewardsAndPenaltiesPrecompute_GenesisEpoch(t *testing.T) {
 	validators, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
 	require.NoError(t, err)
 	require.NoError(t, s.SetSlot(0))
-	s, err = ProcessRewardsAndPenaltiesPrecompute(s, balance, validators)
+	s, err = ProcessRewardsAndPenaltiesPrecompute(context.Background(), s, balance, validators)
 	require.NoError(t, err)
 
 	balances := s.Balances()
@@ -417,7 +439,7 @@ func TestProcessRewardsAndPenaltiesPrecompute_BadState(t *testing.T) {
 	require.NoError(t, err)
 	_, balance, err = ProcessEpochParticipation(context.Background(), s, balance, validators)
 	require.NoError(t, err)
-	_, err = ProcessRewardsAndPenaltiesPrecompute(s, balance, []*precompute.Validator{})
+	_, err = ProcessRewardsAndPenaltiesPrecompute(context.Background(), s, balance, []*precompute.Validator{})
 	require.ErrorContains(t, "validator registries not the same length as state's validator registries", err)
 }
 
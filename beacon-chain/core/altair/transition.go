@@ -58,7 +58,7 @@ func ProcessEpoch(ctx context.Context, state state.BeaconState) (state.BeaconSta
 	}
 
 	// New in Altair.
-	state, err = ProcessRewardsAndPenaltiesPrecompute(state, bp, vp)
+	state, err = ProcessRewardsAndPenaltiesPrecompute(ctx, state, bp, vp)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process rewards and penalties")
 	}
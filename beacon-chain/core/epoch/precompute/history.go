@@ -0,0 +1,99 @@
+package precompute
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"go.opencensus.io/trace"
+)
+
+// ValidatorRecord is the balance, effective balance and status of a validator at the start of an epoch.
+type ValidatorRecord struct {
+	Epoch            primitives.Epoch
+	Balance          uint64
+	EffectiveBalance uint64
+	Status           ethpb.ValidatorStatus
+}
+
+// EpochSnapshot holds the balances, effective balances and statuses of all the validators at the start of an
+// epoch, once the epoch transition has been processed. The slices are indexed by validator index.
+type EpochSnapshot struct {
+	Epoch             primitives.Epoch
+	Balances          []uint64
+	EffectiveBalances []uint64
+	Statuses          []ethpb.ValidatorStatus
+}
+
+// Record returns the record of the validator with the given index, which must be part of the snapshot.
+func (s *EpochSnapshot) Record(idx primitives.ValidatorIndex) ValidatorRecord {
+	return ValidatorRecord{
+		Epoch:            s.Epoch,
+		Balance:          s.Balances[idx],
+		EffectiveBalance: s.EffectiveBalances[idx],
+		Status:           s.Statuses[idx],
+	}
+}
+
+// NewEpochSnapshot returns the snapshot of the validators of a state at the first slot of an epoch.
+func NewEpochSnapshot(ctx context.Context, s state.ReadOnlyBeaconState) (*EpochSnapshot, error) {
+	_, span := trace.StartSpan(ctx, "precomputeEpoch.NewEpochSnapshot")
+	defer span.End()
+
+	if !slots.IsEpochStart(s.Slot()) {
+		return nil, errors.Errorf("state slot %d is not the start of an epoch", s.Slot())
+	}
+	epoch := slots.ToEpoch(s.Slot())
+	snapshot := &EpochSnapshot{
+		Epoch:             epoch,
+		Balances:          s.Balances(),
+		EffectiveBalances: make([]uint64, s.NumValidators()),
+		Statuses:          make([]ethpb.ValidatorStatus, s.NumValidators()),
+	}
+	if len(snapshot.Balances) != s.NumValidators() {
+		return nil, errors.Errorf("state has %d balances for %d validators", len(snapshot.Balances), s.NumValidators())
+	}
+	if err := s.ReadFromEveryValidator(func(idx int, val state.ReadOnlyValidator) error {
+		snapshot.EffectiveBalances[idx] = val.EffectiveBalance()
+		snapshot.Statuses[idx] = ValidatorSubStatus(val, epoch)
+		return nil
+	}); err != nil {
+		return nil, errors.Wrap(err, "could not compute validator statuses")
+	}
+	return snapshot, nil
+}
+
+// ValidatorSubStatus returns the status of the validator at the given epoch, as defined by the beacon API.
+func ValidatorSubStatus(val state.ReadOnlyValidator, epoch primitives.Epoch) ethpb.ValidatorStatus {
+	farFutureEpoch := params.BeaconConfig().FarFutureEpoch
+
+	if val.ActivationEpoch() > epoch {
+		if val.ActivationEligibilityEpoch() == farFutureEpoch {
+			return ethpb.ValidatorStatus_PENDING_INITIALIZED
+		}
+		return ethpb.ValidatorStatus_PENDING_QUEUED
+	}
+	if epoch < val.ExitEpoch() {
+		if val.ExitEpoch() == farFutureEpoch {
+			return ethpb.ValidatorStatus_ACTIVE_ONGOING
+		}
+		if val.Slashed() {
+			return ethpb.ValidatorStatus_ACTIVE_SLASHED
+		}
+		return ethpb.ValidatorStatus_ACTIVE_EXITING
+	}
+	if epoch < val.WithdrawableEpoch() {
+		if val.Slashed() {
+			return ethpb.ValidatorStatus_EXITED_SLASHED
+		}
+		return ethpb.ValidatorStatus_EXITED_UNSLASHED
+	}
+	if val.EffectiveBalance() != 0 {
+		return ethpb.ValidatorStatus_WITHDRAWAL_POSSIBLE
+	}
+	return ethpb.ValidatorStatus_WITHDRAWAL_DONE
+}
@@ -0,0 +1,72 @@
+package precompute_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestNewEpochSnapshot(t *testing.T) {
+	ffe := params.BeaconConfig().FarFutureEpoch
+	maxBalance := params.BeaconConfig().MaxEffectiveBalance
+	validators := []*ethpb.Validator{
+		// Pending.
+		{ActivationEligibilityEpoch: ffe, ActivationEpoch: ffe, ExitEpoch: ffe, WithdrawableEpoch: ffe},
+		{ActivationEligibilityEpoch: 1, ActivationEpoch: 5, ExitEpoch: ffe, WithdrawableEpoch: ffe},
+		// Active.
+		{EffectiveBalance: maxBalance, ExitEpoch: ffe, WithdrawableEpoch: ffe},
+		{EffectiveBalance: maxBalance, ExitEpoch: 5, WithdrawableEpoch: 10},
+		{EffectiveBalance: maxBalance, Slashed: true, ExitEpoch: 5, WithdrawableEpoch: 10},
+		// Exited.
+		{EffectiveBalance: maxBalance, ExitEpoch: 1, WithdrawableEpoch: 10},
+		{EffectiveBalance: maxBalance, Slashed: true, ExitEpoch: 1, WithdrawableEpoch: 10},
+		// Withdrawable.
+		{EffectiveBalance: maxBalance, ExitEpoch: 1, WithdrawableEpoch: 2},
+		{ExitEpoch: 1, WithdrawableEpoch: 2},
+	}
+	balances := make([]uint64, len(validators))
+	for i := range balances {
+		balances[i] = uint64(i) + maxBalance
+	}
+	s, err := state_native.InitializeFromProtoPhase0(&ethpb.BeaconState{
+		Slot:       params.BeaconConfig().SlotsPerEpoch.Mul(2),
+		Validators: validators,
+		Balances:   balances,
+	})
+	require.NoError(t, err)
+
+	snapshot, err := precompute.NewEpochSnapshot(context.Background(), s)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(2), snapshot.Epoch)
+	assert.DeepEqual(t, balances, snapshot.Balances)
+	assert.DeepEqual(t, []ethpbv1.ValidatorStatus{
+		ethpbv1.ValidatorStatus_PENDING_INITIALIZED,
+		ethpbv1.ValidatorStatus_PENDING_QUEUED,
+		ethpbv1.ValidatorStatus_ACTIVE_ONGOING,
+		ethpbv1.ValidatorStatus_ACTIVE_EXITING,
+		ethpbv1.ValidatorStatus_ACTIVE_SLASHED,
+		ethpbv1.ValidatorStatus_EXITED_UNSLASHED,
+		ethpbv1.ValidatorStatus_EXITED_SLASHED,
+		ethpbv1.ValidatorStatus_WITHDRAWAL_POSSIBLE,
+		ethpbv1.ValidatorStatus_WITHDRAWAL_DONE,
+	}, snapshot.Statuses)
+	r := snapshot.Record(2)
+	assert.Equal(t, precompute.ValidatorRecord{
+		Epoch:            2,
+		Balance:          balances[2],
+		EffectiveBalance: maxBalance,
+		Status:           ethpbv1.ValidatorStatus_ACTIVE_ONGOING,
+	}, r)
+
+	require.NoError(t, s.SetSlot(s.Slot()+1))
+	_, err = precompute.NewEpochSnapshot(context.Background(), s)
+	require.ErrorContains(t, "is not the start of an epoch", err)
+}
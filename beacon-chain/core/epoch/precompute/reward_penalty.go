@@ -1,10 +1,12 @@
 package precompute
 
 import (
+	"context"
 	"math/big"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
@@ -17,6 +19,7 @@ type proposerRewardsFunc func(state.ReadOnlyBeaconState, *Balance, []*Validator)
 // ProcessRewardsAndPenaltiesPrecompute processes the rewards and penalties of individual validator.
 // This is an optimized version by passing in precomputed validator attesting records and total epoch balances.
 func ProcessRewardsAndPenaltiesPrecompute(
+	ctx context.Context,
 	state state.BeaconState,
 	pBal *Balance,
 	vp []*Validator,
@@ -42,13 +45,15 @@ func ProcessRewardsAndPenaltiesPrecompute(
 	if err != nil {
 		return nil, errors.Wrap(err, "could not get proposer attestation delta")
 	}
+	epoch := time.CurrentEpoch(state)
 	validatorBals := state.Balances()
 	for i := 0; i < numOfVals; i++ {
 		vp[i].BeforeEpochTransitionBalance = validatorBals[i]
 
 		// Compute the post balance of the validator after accounting for the
 		// attester and proposer rewards and penalties.
-		validatorBals[i], err = helpers.IncreaseBalanceWithVal(validatorBals[i], attsRewards[i]+proposerRewards[i], true)
+		reward := pulse.ApplyTrackedBurn(ctx, epoch, pulse.AttestationBurn, primitives.ValidatorIndex(i), attsRewards[i]+proposerRewards[i])
+		validatorBals[i], err = helpers.IncreaseBalanceWithVal(validatorBals[i], reward, false)
 		if err != nil {
 			return nil, err
 		}
@@ -46,7 +46,7 @@ func TestProcessRewardsAndPenaltiesPrecompute(t *testing.T) {
 	vp, bp, err = ProcessAttestations(context.Background(), beaconState, vp, bp)
 	require.NoError(t, err)
 
-	processedState, err := ProcessRewardsAndPenaltiesPrecompute(beaconState, bp, vp, AttestationsDelta, ProposersDelta)
+	processedState, err := ProcessRewardsAndPenaltiesPrecompute(context.Background(), beaconState, bp, vp, AttestationsDelta, ProposersDelta)
 	require.NoError(t, err)
 	require.Equal(t, true, processedState.Version() == version.Phase0)
 
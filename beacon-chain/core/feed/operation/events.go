@@ -2,6 +2,7 @@
 package operation
 
 import (
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
@@ -22,6 +23,17 @@ const (
 
 	// BLSToExecutionChangeReceived is sent after a BLS to execution change object has been received from gossip or rpc.
 	BLSToExecutionChangeReceived
+
+	// AttesterSlashingReceived is sent after an attester slashing object has been inserted into the pool from gossip, rpc,
+	// the slasher or a reorg.
+	AttesterSlashingReceived
+
+	// ProposerSlashingReceived is sent after a proposer slashing object has been inserted into the pool from gossip, rpc,
+	// the slasher or a reorg.
+	ProposerSlashingReceived
+
+	// BlockGossipReceived is sent after a block received from gossip passed validation, before it is imported.
+	BlockGossipReceived
 )
 
 // UnAggregatedAttReceivedData is the data sent with UnaggregatedAttReceived events.
@@ -52,3 +64,19 @@ type SyncCommitteeContributionReceivedData struct {
 type BLSToExecutionChangeReceivedData struct {
 	Change *ethpb.SignedBLSToExecutionChange
 }
+
+// AttesterSlashingReceivedData is the data sent with AttesterSlashingReceived events.
+type AttesterSlashingReceivedData struct {
+	AttesterSlashing *ethpb.AttesterSlashing
+}
+
+// ProposerSlashingReceivedData is the data sent with ProposerSlashingReceived events.
+type ProposerSlashingReceivedData struct {
+	ProposerSlashing *ethpb.ProposerSlashing
+}
+
+// BlockGossipReceivedData is the data sent with BlockGossipReceived events.
+type BlockGossipReceivedData struct {
+	// SignedBlock is the block which passed gossip validation.
+	SignedBlock interfaces.ReadOnlySignedBeaconBlock
+}
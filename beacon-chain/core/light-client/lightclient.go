@@ -0,0 +1,365 @@
+// Package lightclient implements the construction of the data served to light clients, as defined in the
+// Altair light client sync protocol, from the blocks and states processed by the beacon node.
+package lightclient
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+const (
+	// syncCommitteeBranchLength is the depth of the sync committee fields in the beacon state tree.
+	syncCommitteeBranchLength = 5
+	// finalityBranchLength is the depth of the finalized root in the beacon state tree.
+	finalityBranchLength = 6
+)
+
+var (
+	// ErrNotEnoughParticipants is returned when the sync aggregate of a block does not have enough
+	// participants for light clients to accept it.
+	ErrNotEnoughParticipants = errors.New("sync aggregate does not have enough participants")
+	// ErrUnsupportedVersion is returned for blocks and states which do not have sync committees.
+	ErrUnsupportedVersion = errors.New("light client data is not supported before altair")
+)
+
+// NewLightClientUpdate creates the light client update for the sync aggregate contained in block, which signs
+// its parent block. The attested state is the post state of the parent block, and the finalized block is the block
+// of the attested state's finalized checkpoint. The finalized block may be nil when the checkpoint root is zero,
+// in which case the finalized header of the update is empty.
+//
+// Spec code:
+// def create_light_client_update(state: BeaconState, block: SignedBeaconBlock, attested_state: BeaconState,
+// attested_block: SignedBeaconBlock, finalized_block: Optional[SignedBeaconBlock]) -> LightClientUpdate
+func NewLightClientUpdate(
+	ctx context.Context,
+	block interfaces.ReadOnlySignedBeaconBlock,
+	attestedState state.BeaconState,
+	finalizedBlock interfaces.ReadOnlySignedBeaconBlock,
+) (*ethpbv2.LightClientUpdate, error) {
+	if err := consensusblocks.BeaconBlockIsNil(block); err != nil {
+		return nil, err
+	}
+	if block.Version() < version.Altair || attestedState.Version() < version.Altair {
+		return nil, ErrUnsupportedVersion
+	}
+	syncAggregate, err := block.Block().Body().SyncAggregate()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get sync aggregate")
+	}
+	if syncAggregate.SyncCommitteeBits.Count() < params.BeaconConfig().MinSyncCommitteeParticipants {
+		return nil, ErrNotEnoughParticipants
+	}
+
+	attestedHeader, err := latestBlockHeader(ctx, attestedState)
+	if err != nil {
+		return nil, err
+	}
+	attestedRoot, err := attestedHeader.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash attested header")
+	}
+	parentRoot := block.Block().ParentRoot()
+	if attestedRoot != parentRoot {
+		return nil, errors.Errorf("attested header root %#x does not match the block's parent root %#x", attestedRoot, parentRoot)
+	}
+	if attestedHeader.Slot >= block.Block().Slot() {
+		return nil, errors.Errorf("attested slot %d is not lower than the signature slot %d", attestedHeader.Slot, block.Block().Slot())
+	}
+
+	update := &ethpbv2.LightClientUpdate{
+		AttestedHeader:          attestedHeader,
+		NextSyncCommittee:       emptySyncCommittee(),
+		NextSyncCommitteeBranch: emptyBranch(syncCommitteeBranchLength),
+		FinalizedHeader:         emptyHeader(),
+		FinalityBranch:          emptyBranch(finalityBranchLength),
+		SyncAggregate: &ethpbv1.SyncAggregate{
+			SyncCommitteeBits:      bytesutil.SafeCopyBytes(syncAggregate.SyncCommitteeBits),
+			SyncCommitteeSignature: bytesutil.SafeCopyBytes(syncAggregate.SyncCommitteeSignature),
+		},
+		SignatureSlot: block.Block().Slot(),
+	}
+
+	// The next sync committee is only known to light clients which are synced to the signature period.
+	if SyncCommitteePeriod(attestedHeader.Slot) == SyncCommitteePeriod(block.Block().Slot()) {
+		committee, err := attestedState.NextSyncCommittee()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get next sync committee")
+		}
+		update.NextSyncCommittee = syncCommitteeToV2(committee)
+		update.NextSyncCommitteeBranch, err = attestedState.NextSyncCommitteeProof(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute next sync committee proof")
+		}
+	}
+
+	finalizedRoot := bytesutil.ToBytes32(attestedState.FinalizedCheckpoint().Root)
+	if finalizedRoot != params.BeaconConfig().ZeroHash {
+		if err := consensusblocks.BeaconBlockIsNil(finalizedBlock); err != nil {
+			return nil, errors.Wrap(err, "finalized block is required for a non zero finalized checkpoint")
+		}
+		header, err := blockHeader(finalizedBlock)
+		if err != nil {
+			return nil, err
+		}
+		root, err := header.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash finalized header")
+		}
+		if root != finalizedRoot {
+			return nil, errors.Errorf("finalized header root %#x does not match the finalized checkpoint root %#x", root, finalizedRoot)
+		}
+		update.FinalizedHeader = header
+	}
+	update.FinalityBranch, err = attestedState.FinalizedRootProof(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute finalized root proof")
+	}
+	return update, nil
+}
+
+// NewLightClientBootstrap creates the bootstrap data which allows a light client to start syncing from the
+// given block, using the block's post state.
+func NewLightClientBootstrap(ctx context.Context, st state.BeaconState, block interfaces.ReadOnlySignedBeaconBlock) (*ethpbv2.LightClientBootstrap, error) {
+	if err := consensusblocks.BeaconBlockIsNil(block); err != nil {
+		return nil, err
+	}
+	if block.Version() < version.Altair || st.Version() < version.Altair {
+		return nil, ErrUnsupportedVersion
+	}
+	header, err := latestBlockHeader(ctx, st)
+	if err != nil {
+		return nil, err
+	}
+	headerRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash header")
+	}
+	blockRoot, err := block.Block().HashTreeRoot()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not hash block")
+	}
+	if headerRoot != blockRoot {
+		return nil, errors.Errorf("state header root %#x does not match the block root %#x", headerRoot, blockRoot)
+	}
+	committee, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get current sync committee")
+	}
+	branch, err := st.CurrentSyncCommitteeProof(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not compute current sync committee proof")
+	}
+	return &ethpbv2.LightClientBootstrap{
+		Header:                     header,
+		CurrentSyncCommittee:       syncCommitteeToV2(committee),
+		CurrentSyncCommitteeBranch: branch,
+	}, nil
+}
+
+// FinalityUpdate returns the finality update contained in the light client update.
+func FinalityUpdate(update *ethpbv2.LightClientUpdate) *ethpbv2.LightClientFinalityUpdate {
+	return &ethpbv2.LightClientFinalityUpdate{
+		AttestedHeader:  update.AttestedHeader,
+		FinalizedHeader: update.FinalizedHeader,
+		FinalityBranch:  update.FinalityBranch,
+		SyncAggregate:   update.SyncAggregate,
+		SignatureSlot:   update.SignatureSlot,
+	}
+}
+
+// OptimisticUpdate returns the optimistic update contained in the light client update.
+func OptimisticUpdate(update *ethpbv2.LightClientUpdate) *ethpbv2.LightClientOptimisticUpdate {
+	return &ethpbv2.LightClientOptimisticUpdate{
+		AttestedHeader: update.AttestedHeader,
+		SyncAggregate:  update.SyncAggregate,
+		SignatureSlot:  update.SignatureSlot,
+	}
+}
+
+// SyncCommitteePeriod returns the sync committee period of the slot.
+func SyncCommitteePeriod(slot primitives.Slot) uint64 {
+	return slots.SyncCommitteePeriod(slots.ToEpoch(slot))
+}
+
+// DataVersion returns the fork version of the light client data whose attested header has the given slot.
+func DataVersion(slot primitives.Slot) ethpbv2.Version {
+	epoch := slots.ToEpoch(slot)
+	switch {
+	case epoch >= params.BeaconConfig().CapellaForkEpoch:
+		return ethpbv2.Version_CAPELLA
+	case epoch >= params.BeaconConfig().BellatrixForkEpoch:
+		return ethpbv2.Version_BELLATRIX
+	default:
+		return ethpbv2.Version_ALTAIR
+	}
+}
+
+// IsSyncCommitteeUpdate returns true if the update carries the next sync committee.
+func IsSyncCommitteeUpdate(update *ethpbv2.LightClientUpdate) bool {
+	return !isEmptyBranch(update.NextSyncCommitteeBranch)
+}
+
+// IsFinalityUpdate returns true if the update proves a finalized header.
+func IsFinalityUpdate(update *ethpbv2.LightClientUpdate) bool {
+	return !isEmptyBranch(update.FinalityBranch)
+}
+
+// HasSupermajority returns true if at least two thirds of the sync committee participated in the sync aggregate.
+func HasSupermajority(syncAggregate *ethpbv1.SyncAggregate) bool {
+	return syncAggregate.SyncCommitteeBits.Count()*3 >= syncAggregate.SyncCommitteeBits.Len()*2
+}
+
+// IsBetterUpdate returns true if the new update is better than the old one, to decide which update is kept as
+// the best update of a sync committee period.
+//
+// Spec code:
+// def is_better_update(new_update: LightClientUpdate, old_update: LightClientUpdate) -> bool
+func IsBetterUpdate(newUpdate, oldUpdate *ethpbv2.LightClientUpdate) bool {
+	// Compare supermajority (> 2/3) sync committee participation.
+	newParticipants := newUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	oldParticipants := oldUpdate.SyncAggregate.SyncCommitteeBits.Count()
+	newSupermajority := HasSupermajority(newUpdate.SyncAggregate)
+	oldSupermajority := HasSupermajority(oldUpdate.SyncAggregate)
+	if newSupermajority != oldSupermajority {
+		return newSupermajority
+	}
+	if !newSupermajority && newParticipants != oldParticipants {
+		return newParticipants > oldParticipants
+	}
+
+	// Compare presence of the relevant sync committee.
+	newRelevantCommittee := IsSyncCommitteeUpdate(newUpdate) &&
+		SyncCommitteePeriod(newUpdate.AttestedHeader.Slot) == SyncCommitteePeriod(newUpdate.SignatureSlot)
+	oldRelevantCommittee := IsSyncCommitteeUpdate(oldUpdate) &&
+		SyncCommitteePeriod(oldUpdate.AttestedHeader.Slot) == SyncCommitteePeriod(oldUpdate.SignatureSlot)
+	if newRelevantCommittee != oldRelevantCommittee {
+		return newRelevantCommittee
+	}
+
+	// Compare indication of any finality.
+	newFinality := IsFinalityUpdate(newUpdate)
+	oldFinality := IsFinalityUpdate(oldUpdate)
+	if newFinality != oldFinality {
+		return newFinality
+	}
+
+	// Compare sync committee finality.
+	if newFinality {
+		newCommitteeFinality := SyncCommitteePeriod(newUpdate.FinalizedHeader.Slot) == SyncCommitteePeriod(newUpdate.AttestedHeader.Slot)
+		oldCommitteeFinality := SyncCommitteePeriod(oldUpdate.FinalizedHeader.Slot) == SyncCommitteePeriod(oldUpdate.AttestedHeader.Slot)
+		if newCommitteeFinality != oldCommitteeFinality {
+			return newCommitteeFinality
+		}
+	}
+
+	// Tiebreaker 1: Sync committee participation beyond supermajority.
+	if newParticipants != oldParticipants {
+		return newParticipants > oldParticipants
+	}
+	// Tiebreaker 2: Prefer older data (fewer changes to best).
+	if newUpdate.AttestedHeader.Slot != oldUpdate.AttestedHeader.Slot {
+		return newUpdate.AttestedHeader.Slot < oldUpdate.AttestedHeader.Slot
+	}
+	return newUpdate.SignatureSlot < oldUpdate.SignatureSlot
+}
+
+// latestBlockHeader returns the header of the block whose post state is st, with its state root filled in.
+func latestBlockHeader(ctx context.Context, st state.BeaconState) (*ethpbv1.BeaconBlockHeader, error) {
+	h := st.LatestBlockHeader()
+	if h == nil {
+		return nil, errors.New("state has no latest block header")
+	}
+	if h.Slot != st.Slot() {
+		return nil, errors.Errorf("state slot %d does not match the latest block header slot %d", st.Slot(), h.Slot)
+	}
+	stateRoot := h.StateRoot
+	if bytes.Equal(stateRoot, params.BeaconConfig().ZeroHash[:]) {
+		r, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash state")
+		}
+		stateRoot = r[:]
+	}
+	return &ethpbv1.BeaconBlockHeader{
+		Slot:          h.Slot,
+		ProposerIndex: h.ProposerIndex,
+		ParentRoot:    bytesutil.SafeCopyBytes(h.ParentRoot),
+		StateRoot:     bytesutil.SafeCopyBytes(stateRoot),
+		BodyRoot:      bytesutil.SafeCopyBytes(h.BodyRoot),
+	}, nil
+}
+
+func blockHeader(block interfaces.ReadOnlySignedBeaconBlock) (*ethpbv1.BeaconBlockHeader, error) {
+	h, err := block.Header()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get block header")
+	}
+	return &ethpbv1.BeaconBlockHeader{
+		Slot:          h.Header.Slot,
+		ProposerIndex: h.Header.ProposerIndex,
+		ParentRoot:    h.Header.ParentRoot,
+		StateRoot:     h.Header.StateRoot,
+		BodyRoot:      h.Header.BodyRoot,
+	}, nil
+}
+
+func syncCommitteeToV2(committee *ethpb.SyncCommittee) *ethpbv2.SyncCommittee {
+	pubkeys := make([][]byte, len(committee.Pubkeys))
+	for i, pk := range committee.Pubkeys {
+		pubkeys[i] = bytesutil.SafeCopyBytes(pk)
+	}
+	return &ethpbv2.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: bytesutil.SafeCopyBytes(committee.AggregatePubkey),
+	}
+}
+
+func emptyHeader() *ethpbv1.BeaconBlockHeader {
+	return &ethpbv1.BeaconBlockHeader{
+		ParentRoot: make([]byte, fieldparams.RootLength),
+		StateRoot:  make([]byte, fieldparams.RootLength),
+		BodyRoot:   make([]byte, fieldparams.RootLength),
+	}
+}
+
+func emptySyncCommittee() *ethpbv2.SyncCommittee {
+	pubkeys := make([][]byte, fieldparams.SyncCommitteeLength)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, fieldparams.BLSPubkeyLength)
+	}
+	return &ethpbv2.SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: make([]byte, fieldparams.BLSPubkeyLength),
+	}
+}
+
+func emptyBranch(length int) [][]byte {
+	branch := make([][]byte, length)
+	for i := range branch {
+		branch[i] = make([]byte, fieldparams.RootLength)
+	}
+	return branch
+}
+
+func isEmptyBranch(branch [][]byte) bool {
+	for _, node := range branch {
+		if !bytes.Equal(node, params.BeaconConfig().ZeroHash[:]) {
+			return false
+		}
+	}
+	return true
+}
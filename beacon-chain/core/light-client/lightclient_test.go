@@ -0,0 +1,261 @@
+package lightclient_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/container/trie"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+type testChain struct {
+	attestedState  state.BeaconState
+	attestedRoot   [32]byte
+	block          interfaces.ReadOnlySignedBeaconBlock
+	finalizedBlock interfaces.ReadOnlySignedBeaconBlock
+}
+
+func setupChain(t *testing.T, attestedSlot, signatureSlot primitives.Slot, participants uint64) *testChain {
+	ctx := context.Background()
+
+	f := util.NewBeaconBlockAltair()
+	f.Block.Slot = 1
+	finalizedBlock, err := blocks.NewSignedBeaconBlock(f)
+	require.NoError(t, err)
+	finalizedRoot, err := f.Block.HashTreeRoot()
+	require.NoError(t, err)
+
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(attestedSlot))
+	require.NoError(t, st.SetLatestBlockHeader(util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: attestedSlot, ProposerIndex: 3})))
+	require.NoError(t, st.SetFinalizedCheckpoint(&ethpb.Checkpoint{Epoch: 1, Root: finalizedRoot[:]}))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	header := st.LatestBlockHeader()
+	header.StateRoot = stateRoot[:]
+	attestedRoot, err := header.HashTreeRoot()
+	require.NoError(t, err)
+
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = signatureSlot
+	b.Block.ParentRoot = attestedRoot[:]
+	bits := bitfield.NewBitvector512()
+	for i := uint64(0); i < participants; i++ {
+		bits.SetBitAt(i, true)
+	}
+	b.Block.Body.SyncAggregate.SyncCommitteeBits = bits
+	block, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+
+	return &testChain{attestedState: st, attestedRoot: attestedRoot, block: block, finalizedBlock: finalizedBlock}
+}
+
+func TestNewLightClientUpdate(t *testing.T) {
+	ctx := context.Background()
+	c := setupChain(t, 10, 11, 400)
+	stateRoot, err := c.attestedState.HashTreeRoot(ctx)
+	require.NoError(t, err)
+
+	update, err := lightclient.NewLightClientUpdate(ctx, c.block, c.attestedState, c.finalizedBlock)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(11), update.SignatureSlot)
+	assert.Equal(t, primitives.Slot(10), update.AttestedHeader.Slot)
+	assert.DeepEqual(t, stateRoot[:], update.AttestedHeader.StateRoot)
+	root, err := update.AttestedHeader.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, c.attestedRoot, root)
+	assert.Equal(t, uint64(400), update.SyncAggregate.SyncCommitteeBits.Count())
+
+	require.Equal(t, true, lightclient.IsSyncCommitteeUpdate(update))
+	committeeRoot, err := update.NextSyncCommittee.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, true, trie.VerifyMerkleProof(stateRoot[:], committeeRoot[:], 55, update.NextSyncCommitteeBranch))
+
+	require.Equal(t, true, lightclient.IsFinalityUpdate(update))
+	finalizedRoot, err := update.FinalizedHeader.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Slot(1), update.FinalizedHeader.Slot)
+	assert.Equal(t, true, trie.VerifyMerkleProof(stateRoot[:], finalizedRoot[:], 105, update.FinalityBranch))
+
+	// The SSZ encoding of the update round trips.
+	enc, err := update.MarshalSSZ()
+	require.NoError(t, err)
+	decoded := &ethpbv2.LightClientUpdate{}
+	require.NoError(t, decoded.UnmarshalSSZ(enc))
+	assert.DeepSSZEqual(t, update, decoded)
+
+	finality := lightclient.FinalityUpdate(update)
+	assert.DeepEqual(t, update.FinalizedHeader, finality.FinalizedHeader)
+	optimistic := lightclient.OptimisticUpdate(update)
+	assert.DeepEqual(t, update.AttestedHeader, optimistic.AttestedHeader)
+}
+
+func TestNewLightClientUpdate_NextPeriod(t *testing.T) {
+	ctx := context.Background()
+	periodStart := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	c := setupChain(t, periodStart-1, periodStart, 400)
+
+	update, err := lightclient.NewLightClientUpdate(ctx, c.block, c.attestedState, c.finalizedBlock)
+	require.NoError(t, err)
+	assert.Equal(t, false, lightclient.IsSyncCommitteeUpdate(update))
+	assert.Equal(t, true, lightclient.IsFinalityUpdate(update))
+}
+
+func TestNewLightClientUpdate_Errors(t *testing.T) {
+	ctx := context.Background()
+
+	c := setupChain(t, 10, 11, params.BeaconConfig().MinSyncCommitteeParticipants-1)
+	_, err := lightclient.NewLightClientUpdate(ctx, c.block, c.attestedState, c.finalizedBlock)
+	require.ErrorIs(t, err, lightclient.ErrNotEnoughParticipants)
+
+	c = setupChain(t, 10, 11, 400)
+	_, err = lightclient.NewLightClientUpdate(ctx, c.block, c.attestedState, nil)
+	require.ErrorContains(t, "finalized block is required", err)
+
+	_, err = lightclient.NewLightClientUpdate(ctx, c.block, c.attestedState, c.block)
+	require.ErrorContains(t, "does not match the finalized checkpoint root", err)
+
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = 11
+	syncAggregate, err := c.block.Block().Body().SyncAggregate()
+	require.NoError(t, err)
+	b.Block.Body.SyncAggregate = syncAggregate
+	block, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	_, err = lightclient.NewLightClientUpdate(ctx, block, c.attestedState, c.finalizedBlock)
+	require.ErrorContains(t, "does not match the block's parent root", err)
+
+	phase0, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	_, err = lightclient.NewLightClientUpdate(ctx, phase0, c.attestedState, c.finalizedBlock)
+	require.ErrorIs(t, err, lightclient.ErrUnsupportedVersion)
+}
+
+func TestNewLightClientBootstrap(t *testing.T) {
+	ctx := context.Background()
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(5))
+
+	b := util.NewBeaconBlockAltair()
+	b.Block.Slot = 5
+	bodyRoot, err := b.Block.Body.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, st.SetLatestBlockHeader(util.HydrateBeaconHeader(&ethpb.BeaconBlockHeader{Slot: 5, BodyRoot: bodyRoot[:]})))
+	stateRoot, err := st.HashTreeRoot(ctx)
+	require.NoError(t, err)
+	b.Block.StateRoot = stateRoot[:]
+	block, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+
+	bootstrap, err := lightclient.NewLightClientBootstrap(ctx, st, block)
+	require.NoError(t, err)
+	blockRoot, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	headerRoot, err := bootstrap.Header.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, blockRoot, headerRoot)
+	committeeRoot, err := bootstrap.CurrentSyncCommittee.HashTreeRoot()
+	require.NoError(t, err)
+	assert.Equal(t, true, trie.VerifyMerkleProof(stateRoot[:], committeeRoot[:], 54, bootstrap.CurrentSyncCommitteeBranch))
+
+	other := util.NewBeaconBlockAltair()
+	other.Block.Slot = 5
+	otherBlock, err := blocks.NewSignedBeaconBlock(other)
+	require.NoError(t, err)
+	_, err = lightclient.NewLightClientBootstrap(ctx, st, otherBlock)
+	require.ErrorContains(t, "does not match the block root", err)
+}
+
+func TestIsBetterUpdate(t *testing.T) {
+	update := func(participants uint64, attestedSlot, signatureSlot primitives.Slot, committee, finality bool) *ethpbv2.LightClientUpdate {
+		bits := bitfield.NewBitvector512()
+		for i := uint64(0); i < participants; i++ {
+			bits.SetBitAt(i, true)
+		}
+		branch := func(length int, set bool) [][]byte {
+			b := make([][]byte, length)
+			for i := range b {
+				b[i] = make([]byte, 32)
+				if set {
+					b[i][0] = 1
+				}
+			}
+			return b
+		}
+		return &ethpbv2.LightClientUpdate{
+			AttestedHeader:          &ethpbv1.BeaconBlockHeader{Slot: attestedSlot},
+			NextSyncCommitteeBranch: branch(5, committee),
+			FinalizedHeader:         &ethpbv1.BeaconBlockHeader{Slot: 1},
+			FinalityBranch:          branch(6, finality),
+			SyncAggregate:           &ethpbv1.SyncAggregate{SyncCommitteeBits: bits},
+			SignatureSlot:           signatureSlot,
+		}
+	}
+	tests := []struct {
+		name     string
+		new, old *ethpbv2.LightClientUpdate
+		want     bool
+	}{
+		{
+			name: "supermajority wins",
+			new:  update(400, 10, 11, false, false),
+			old:  update(300, 10, 11, true, true),
+			want: true,
+		},
+		{
+			name: "more participants without supermajority",
+			new:  update(300, 10, 11, false, false),
+			old:  update(200, 10, 11, true, true),
+			want: true,
+		},
+		{
+			name: "relevant sync committee",
+			new:  update(400, 10, 11, true, false),
+			old:  update(500, 10, 11, false, true),
+			want: true,
+		},
+		{
+			name: "finality",
+			new:  update(400, 10, 11, true, false),
+			old:  update(400, 10, 11, true, true),
+			want: false,
+		},
+		{
+			name: "participation beyond supermajority",
+			new:  update(500, 10, 11, true, true),
+			old:  update(400, 10, 11, true, true),
+			want: true,
+		},
+		{
+			name: "older attested header",
+			new:  update(400, 12, 13, true, true),
+			old:  update(400, 10, 13, true, true),
+			want: false,
+		},
+		{
+			name: "older signature slot",
+			new:  update(400, 10, 11, true, true),
+			old:  update(400, 10, 13, true, true),
+			want: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, lightclient.IsBetterUpdate(tt.new, tt.old))
+		})
+	}
+}
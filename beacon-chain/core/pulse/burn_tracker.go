@@ -0,0 +1,235 @@
+package pulse
+
+import (
+	"context"
+	"sort"
+	"sync"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// BurnSource identifies the kind of reward that the burn was applied to.
+type BurnSource int
+
+const (
+	// AttestationBurn is applied to the attestation rewards paid during epoch processing. Before Altair,
+	// this includes the proposer inclusion rewards, which are paid during epoch processing as well.
+	AttestationBurn BurnSource = iota
+	// SyncCommitteeBurn is applied to the rewards of sync committee participants during block processing.
+	SyncCommitteeBurn
+	// ProposerBurn is applied to the proposer rewards for attestations and sync aggregates during block processing.
+	ProposerBurn
+)
+
+// String returns the name of the burn source, as used in metric labels and API responses.
+func (s BurnSource) String() string {
+	switch s {
+	case AttestationBurn:
+		return "attestation"
+	case SyncCommitteeBurn:
+		return "sync_committee"
+	case ProposerBurn:
+		return "proposer"
+	default:
+		return "unknown"
+	}
+}
+
+// BurnSources lists all the burn sources.
+var BurnSources = []BurnSource{AttestationBurn, SyncCommitteeBurn, ProposerBurn}
+
+// BurnTotals holds the sum of rewards before and after the burn was applied, in Gwei.
+type BurnTotals struct {
+	PreBurn  uint64
+	PostBurn uint64
+}
+
+// Burned returns the amount of Gwei removed by the burn.
+func (t BurnTotals) Burned() uint64 {
+	return t.PreBurn - t.PostBurn
+}
+
+// Add adds the other totals to t.
+func (t *BurnTotals) Add(o BurnTotals) {
+	t.PreBurn += o.PreBurn
+	t.PostBurn += o.PostBurn
+}
+
+// EpochBurn holds the burn totals of the rewards paid during an epoch, by source.
+type EpochBurn struct {
+	Epoch   primitives.Epoch
+	Sources [3]BurnTotals
+}
+
+// Source returns the totals of the given burn source.
+func (e *EpochBurn) Source(s BurnSource) BurnTotals {
+	return e.Sources[s]
+}
+
+// Total returns the totals of all burn sources.
+func (e *EpochBurn) Total() BurnTotals {
+	var t BurnTotals
+	for _, s := range e.Sources {
+		t.Add(s)
+	}
+	return t
+}
+
+// Add adds the totals of the other epoch burn to e, regardless of their epochs.
+func (e *EpochBurn) Add(o *EpochBurn) {
+	for i := range e.Sources {
+		e.Sources[i].Add(o.Sources[i])
+	}
+}
+
+// BurnTracker accumulates the burn applied to rewards during state transitions. A tracker is attached to
+// the context passed to the state transition with WithBurnTracker, so that only the callers which want
+// to account for the burn, such as the processing of canonical blocks, pay for it.
+type BurnTracker struct {
+	lock       sync.Mutex
+	epochs     map[primitives.Epoch]*EpochBurn
+	tracked    map[primitives.ValidatorIndex]bool
+	validators map[primitives.Epoch]map[primitives.ValidatorIndex]*BurnTotals
+}
+
+// NewBurnTracker returns a tracker of the burn totals per epoch, which also keeps the totals of the given validators.
+func NewBurnTracker(validators ...primitives.ValidatorIndex) *BurnTracker {
+	t := &BurnTracker{
+		epochs:     make(map[primitives.Epoch]*EpochBurn),
+		tracked:    make(map[primitives.ValidatorIndex]bool, len(validators)),
+		validators: make(map[primitives.Epoch]map[primitives.ValidatorIndex]*BurnTotals),
+	}
+	for _, idx := range validators {
+		t.tracked[idx] = true
+	}
+	return t
+}
+
+// NewChild returns an empty tracker which keeps the totals of the same validators as t. t may be nil,
+// in which case the returned tracker only keeps the totals per epoch.
+func (t *BurnTracker) NewChild() *BurnTracker {
+	if t == nil {
+		return NewBurnTracker()
+	}
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	validators := make([]primitives.ValidatorIndex, 0, len(t.tracked))
+	for idx := range t.tracked {
+		validators = append(validators, idx)
+	}
+	return NewBurnTracker(validators...)
+}
+
+// Record adds a reward of the given validator, before and after the burn, to the totals of the epoch.
+func (t *BurnTracker) Record(epoch primitives.Epoch, source BurnSource, idx primitives.ValidatorIndex, preBurn, postBurn uint64) {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	e, ok := t.epochs[epoch]
+	if !ok {
+		e = &EpochBurn{Epoch: epoch}
+		t.epochs[epoch] = e
+	}
+	e.Sources[source].Add(BurnTotals{PreBurn: preBurn, PostBurn: postBurn})
+	if !t.tracked[idx] {
+		return
+	}
+	vals, ok := t.validators[epoch]
+	if !ok {
+		vals = make(map[primitives.ValidatorIndex]*BurnTotals)
+		t.validators[epoch] = vals
+	}
+	v, ok := vals[idx]
+	if !ok {
+		v = &BurnTotals{}
+		vals[idx] = v
+	}
+	v.Add(BurnTotals{PreBurn: preBurn, PostBurn: postBurn})
+}
+
+// Merge adds all the totals recorded by the other tracker to t.
+func (t *BurnTracker) Merge(o *BurnTracker) {
+	if o == nil || o == t {
+		return
+	}
+	o.lock.Lock()
+	defer o.lock.Unlock()
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	for epoch, oe := range o.epochs {
+		e, ok := t.epochs[epoch]
+		if !ok {
+			e = &EpochBurn{Epoch: epoch}
+			t.epochs[epoch] = e
+		}
+		e.Add(oe)
+	}
+	for epoch, ovals := range o.validators {
+		for idx, ov := range ovals {
+			if !t.tracked[idx] {
+				continue
+			}
+			vals, ok := t.validators[epoch]
+			if !ok {
+				vals = make(map[primitives.ValidatorIndex]*BurnTotals)
+				t.validators[epoch] = vals
+			}
+			v, ok := vals[idx]
+			if !ok {
+				v = &BurnTotals{}
+				vals[idx] = v
+			}
+			v.Add(*ov)
+		}
+	}
+}
+
+// Epochs returns a copy of the recorded epoch burn totals, sorted by epoch.
+func (t *BurnTracker) Epochs() []*EpochBurn {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	res := make([]*EpochBurn, 0, len(t.epochs))
+	for _, e := range t.epochs {
+		c := *e
+		res = append(res, &c)
+	}
+	sort.Slice(res, func(i, j int) bool {
+		return res[i].Epoch < res[j].Epoch
+	})
+	return res
+}
+
+// Validator returns the totals recorded for a tracked validator during the given epoch.
+func (t *BurnTracker) Validator(epoch primitives.Epoch, idx primitives.ValidatorIndex) BurnTotals {
+	t.lock.Lock()
+	defer t.lock.Unlock()
+	if v, ok := t.validators[epoch][idx]; ok {
+		return *v
+	}
+	return BurnTotals{}
+}
+
+type burnTrackerKey struct{}
+
+// WithBurnTracker returns a copy of the context which carries the given burn tracker.
+func WithBurnTracker(ctx context.Context, t *BurnTracker) context.Context {
+	return context.WithValue(ctx, burnTrackerKey{}, t)
+}
+
+// BurnTrackerFromContext returns the burn tracker carried by the context, or nil.
+func BurnTrackerFromContext(ctx context.Context) *BurnTracker {
+	t, ok := ctx.Value(burnTrackerKey{}).(*BurnTracker)
+	if !ok {
+		return nil
+	}
+	return t
+}
+
+// ApplyTrackedBurn applies the PulseChain burn to a reward of the given validator, and records the amounts
+// before and after the burn in the burn tracker of the context, if there is one.
+func ApplyTrackedBurn(ctx context.Context, epoch primitives.Epoch, source BurnSource, idx primitives.ValidatorIndex, reward uint64) uint64 {
+	afterBurn := ApplyBurn(reward)
+	if t := BurnTrackerFromContext(ctx); t != nil {
+		t.Record(epoch, source, idx, reward, afterBurn)
+	}
+	return afterBurn
+}
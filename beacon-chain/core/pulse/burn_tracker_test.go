@@ -0,0 +1,52 @@
+package pulse
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestBurnTracker(t *testing.T) {
+	tr := NewBurnTracker(2)
+	tr.Record(5, AttestationBurn, 1, 100, 75)
+	tr.Record(5, AttestationBurn, 2, 200, 150)
+	tr.Record(5, ProposerBurn, 2, 40, 30)
+	tr.Record(4, SyncCommitteeBurn, 3, 8, 6)
+
+	epochs := tr.Epochs()
+	require.Equal(t, 2, len(epochs))
+	require.Equal(t, primitives.Epoch(4), epochs[0].Epoch)
+	require.Equal(t, BurnTotals{PreBurn: 8, PostBurn: 6}, epochs[0].Source(SyncCommitteeBurn))
+	require.Equal(t, primitives.Epoch(5), epochs[1].Epoch)
+	require.Equal(t, BurnTotals{PreBurn: 300, PostBurn: 225}, epochs[1].Source(AttestationBurn))
+	require.Equal(t, BurnTotals{PreBurn: 340, PostBurn: 255}, epochs[1].Total())
+	require.Equal(t, uint64(85), epochs[1].Total().Burned())
+
+	// Only the requested validators are tracked individually.
+	require.Equal(t, BurnTotals{PreBurn: 240, PostBurn: 180}, tr.Validator(5, 2))
+	require.Equal(t, BurnTotals{}, tr.Validator(5, 1))
+
+	other := NewBurnTracker()
+	other.Record(5, AttestationBurn, 2, 4, 3)
+	other.Record(6, AttestationBurn, 2, 4, 3)
+	tr.Merge(other)
+	epochs = tr.Epochs()
+	require.Equal(t, 3, len(epochs))
+	require.Equal(t, BurnTotals{PreBurn: 304, PostBurn: 228}, epochs[1].Source(AttestationBurn))
+	// The merged tracker did not track validator 2.
+	require.Equal(t, BurnTotals{PreBurn: 240, PostBurn: 180}, tr.Validator(5, 2))
+}
+
+func TestApplyTrackedBurn(t *testing.T) {
+	require.Equal(t, ApplyBurn(1000), ApplyTrackedBurn(context.Background(), 1, AttestationBurn, 0, 1000))
+
+	tr := NewBurnTracker(0)
+	ctx := WithBurnTracker(context.Background(), tr)
+	require.Equal(t, tr, BurnTrackerFromContext(ctx))
+	afterBurn := ApplyTrackedBurn(ctx, 1, ProposerBurn, 0, 1000)
+	require.Equal(t, ApplyBurn(1000), afterBurn)
+	require.Equal(t, BurnTotals{PreBurn: 1000, PostBurn: afterBurn}, tr.Validator(1, 0))
+	require.Equal(t, BurnTotals{PreBurn: 1000, PostBurn: afterBurn}, tr.Epochs()[0].Source(ProposerBurn))
+}
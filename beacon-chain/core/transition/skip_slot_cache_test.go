@@ -5,6 +5,7 @@ import (
 	"sync"
 	"testing"
 
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	state_native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
@@ -45,6 +46,41 @@ func TestSkipSlotCache_OK(t *testing.T) {
 	assert.DeepEqual(t, originalState.ToProto(), bState.ToProto(), "Skipped slots cache leads to different states")
 }
 
+func TestSkipSlotCache_ReplaysBurn(t *testing.T) {
+	ctx := context.Background()
+	st, _ := util.DeterministicGenesisStateAltair(t, params.BeaconConfig().MaxValidatorsPerCommittee)
+	flags := make([]byte, st.NumValidators())
+	for i := range flags {
+		flags[i] = 0b111
+	}
+	require.NoError(t, st.SetCurrentParticipationBits(flags))
+	require.NoError(t, st.SetPreviousParticipationBits(flags))
+	target := 2 * params.BeaconConfig().SlotsPerEpoch
+
+	// The rewards of the previous epoch are paid at the end of the first epoch after genesis.
+	transition.SkipSlotCache.Disable()
+	want := pulse.NewBurnTracker()
+	_, err := transition.ProcessSlots(pulse.WithBurnTracker(ctx, want), st.Copy(), target)
+	require.NoError(t, err)
+	require.Equal(t, true, len(want.Epochs()) > 0)
+	require.Equal(t, true, want.Epochs()[0].Total().Burned() > 0)
+
+	transition.SkipSlotCache.Enable()
+	defer transition.SkipSlotCache.Disable()
+	// States cached by callers which do not account for the burn are not used by the callers which do.
+	_, err = transition.ProcessSlots(ctx, st.Copy(), target)
+	require.NoError(t, err)
+	miss := pulse.NewBurnTracker()
+	_, err = transition.ProcessSlots(pulse.WithBurnTracker(ctx, miss), st.Copy(), target)
+	require.NoError(t, err)
+	hit := pulse.NewBurnTracker()
+	_, err = transition.ProcessSlots(pulse.WithBurnTracker(ctx, hit), st.Copy(), target)
+	require.NoError(t, err)
+
+	assert.DeepEqual(t, want.Epochs(), miss.Epochs())
+	assert.DeepEqual(t, want.Epochs(), hit.Epochs(), "Skipped slots cache hit lost the burn")
+}
+
 func TestSkipSlotCache_ConcurrentMixup(t *testing.T) {
 	bState, privs := util.DeterministicGenesisState(t, params.MinimalSpecConfig().MinGenesisActiveValidatorCount)
 	pbState, err := state_native.ProtobufBeaconStatePhase0(bState.ToProto())
@@ -8,6 +8,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	types "github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
@@ -19,6 +20,9 @@ type nextSlotCache struct {
 	lastRoot  []byte
 	prevState state.BeaconState
 	lastState state.BeaconState
+	// prevBurn and lastBurn hold the burn applied to rewards while advancing the cached states.
+	prevBurn *pulse.BurnTracker
+	lastBurn *pulse.BurnTracker
 }
 
 var (
@@ -38,18 +42,24 @@ var (
 // It returns the last updated state if it matches. Otherwise it returns the previously
 // updated state if it matches its root. If no root matches it returns nil
 func NextSlotState(root []byte, wantedSlot types.Slot) state.BeaconState {
+	st, _ := nextSlotStateAndBurn(root, wantedSlot)
+	return st
+}
+
+// nextSlotStateAndBurn works like NextSlotState, and also returns the burn recorded while advancing the state.
+func nextSlotStateAndBurn(root []byte, wantedSlot types.Slot) (state.BeaconState, *pulse.BurnTracker) {
 	nsc.Lock()
 	defer nsc.Unlock()
 	if bytes.Equal(root, nsc.lastRoot) && nsc.lastState.Slot() <= wantedSlot {
 		nextSlotCacheHit.Inc()
-		return nsc.lastState.Copy()
+		return nsc.lastState.Copy(), nsc.lastBurn
 	}
 	if bytes.Equal(root, nsc.prevRoot) && nsc.prevState.Slot() <= wantedSlot {
 		nextSlotCacheHit.Inc()
-		return nsc.prevState.Copy()
+		return nsc.prevState.Copy(), nsc.prevBurn
 	}
 	nextSlotCacheMiss.Inc()
-	return nil
+	return nil, nil
 }
 
 // UpdateNextSlotCache updates the `nextSlotCache`. It saves the input state after advancing the state slot by 1
@@ -58,7 +68,8 @@ func NextSlotState(root []byte, wantedSlot types.Slot) state.BeaconState {
 func UpdateNextSlotCache(ctx context.Context, root []byte, state state.BeaconState) error {
 	// Advancing one slot by using a copied state.
 	copied := state.Copy()
-	copied, err := ProcessSlots(ctx, copied, copied.Slot()+1)
+	burn := pulse.NewBurnTracker()
+	copied, err := ProcessSlots(pulse.WithBurnTracker(ctx, burn), copied, copied.Slot()+1)
 	if err != nil {
 		return errors.Wrap(err, "could not process slots")
 	}
@@ -68,8 +79,10 @@ func UpdateNextSlotCache(ctx context.Context, root []byte, state state.BeaconSta
 
 	nsc.prevRoot = nsc.lastRoot
 	nsc.prevState = nsc.lastState
+	nsc.prevBurn = nsc.lastBurn
 	nsc.lastRoot = bytesutil.SafeCopyBytes(root)
 	nsc.lastState = copied
+	nsc.lastBurn = burn
 	return nil
 }
 
@@ -15,6 +15,7 @@ import (
 	e "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/execution"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/time"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
@@ -147,9 +148,14 @@ func ProcessSlotsUsingNextSlotCache(
 	ctx, span := trace.StartSpan(ctx, "core.state.ProcessSlotsUsingNextSlotCache")
 	defer span.End()
 
-	nextSlotState := NextSlotState(parentRoot, slot)
-	if nextSlotState != nil {
+	nextSlotState, burn := nextSlotStateAndBurn(parentRoot, slot)
+	// The cached state is only used when it is ahead of the given state, which may already have been advanced.
+	if nextSlotState != nil && nextSlotState.Slot() > parentState.Slot() {
 		parentState = nextSlotState
+		// The burn of the slots processed ahead of time is accounted to the caller, as if it had processed them.
+		if t := pulse.BurnTrackerFromContext(ctx); t != nil {
+			t.Merge(burn)
+		}
 	}
 	if parentState.Slot() == slot {
 		return parentState, nil
@@ -205,24 +211,42 @@ func ProcessSlots(ctx context.Context, state state.BeaconState, slot primitives.
 		return nil, err
 	}
 
+	// When the caller accounts for the burn, the burn applied to rewards while advancing through the slots is
+	// recorded and cached along with the resulting state, so that it can be replayed on a cache hit. Cached states
+	// without a recorded burn are only used by the callers which do not account for it.
+	callerBurn := pulse.BurnTrackerFromContext(ctx)
+	var burn *pulse.BurnTracker
+	if callerBurn != nil {
+		burn = callerBurn.NewChild()
+		ctx = pulse.WithBurnTracker(ctx, burn)
+	}
+
 	// Restart from cached value, if one exists.
-	cachedState, err := SkipSlotCache.Get(ctx, key)
+	cachedState, cachedBurn, err := SkipSlotCache.Get(ctx, key)
 	if err != nil {
 		return nil, err
 	}
 
-	if cachedState != nil && !cachedState.IsNil() && cachedState.Slot() < slot {
+	if cachedState != nil && !cachedState.IsNil() && cachedState.Slot() < slot && (burn == nil || cachedBurn != nil) {
 		highestSlot = cachedState.Slot()
 		state = cachedState
+		if burn != nil {
+			burn.Merge(cachedBurn)
+		}
 	}
 	if err := SkipSlotCache.MarkInProgress(key); errors.Is(err, cache.ErrAlreadyInProgress) {
-		cachedState, err = SkipSlotCache.Get(ctx, key)
+		cachedState, cachedBurn, err = SkipSlotCache.Get(ctx, key)
 		if err != nil {
 			return nil, err
 		}
-		if cachedState != nil && !cachedState.IsNil() && cachedState.Slot() < slot {
+		if cachedState != nil && !cachedState.IsNil() && cachedState.Slot() < slot && (burn == nil || cachedBurn != nil) {
 			highestSlot = cachedState.Slot()
 			state = cachedState
+			if burn != nil {
+				burn = callerBurn.NewChild()
+				burn.Merge(cachedBurn)
+				ctx = pulse.WithBurnTracker(ctx, burn)
+			}
 		}
 	} else if err != nil {
 		return nil, err
@@ -236,7 +260,7 @@ func ProcessSlots(ctx context.Context, state state.BeaconState, slot primitives.
 			tracing.AnnotateError(span, ctx.Err())
 			// Cache last best value.
 			if highestSlot < state.Slot() {
-				if SkipSlotCache.Put(ctx, key, state); err != nil {
+				if SkipSlotCache.Put(ctx, key, state, burn); err != nil {
 					log.WithError(err).Error("Failed to put skip slot cache value")
 				}
 			}
@@ -295,7 +319,10 @@ func ProcessSlots(ctx context.Context, state state.BeaconState, slot primitives.
 	}
 
 	if highestSlot < state.Slot() {
-		SkipSlotCache.Put(ctx, key, state)
+		SkipSlotCache.Put(ctx, key, state, burn)
+	}
+	if callerBurn != nil {
+		callerBurn.Merge(burn)
 	}
 
 	return state, nil
@@ -380,7 +407,7 @@ func ProcessEpochPrecompute(ctx context.Context, state state.BeaconState) (state
 		return nil, errors.Wrap(err, "could not process justification")
 	}
 
-	state, err = precompute.ProcessRewardsAndPenaltiesPrecompute(state, bp, vp, precompute.AttestationsDelta, precompute.ProposersDelta)
+	state, err = precompute.ProcessRewardsAndPenaltiesPrecompute(ctx, state, bp, vp, precompute.AttestationsDelta, precompute.ProposersDelta)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not process rewards and penalties")
 	}
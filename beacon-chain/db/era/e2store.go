@@ -0,0 +1,77 @@
+package era
+
+import (
+	"encoding/binary"
+	"io"
+
+	"github.com/pkg/errors"
+)
+
+// e2store entries are made of an 8 byte header, holding the type of the entry, the length of its data and two
+// reserved bytes which must be zero, followed by the data.
+// See https://github.com/status-im/nimbus-eth2/blob/stable/docs/e2store.md
+const headerSize = 8
+
+var (
+	typeVersion                     = [2]byte{0x65, 0x32}
+	typeCompressedSignedBeaconBlock = [2]byte{0x01, 0x00}
+	typeCompressedBeaconState       = [2]byte{0x02, 0x00}
+	typeSlotIndex                   = [2]byte{0x69, 0x32}
+)
+
+var errInvalidEntry = errors.New("invalid e2store entry")
+
+// entry locates an e2store entry in a file.
+type entry struct {
+	typ [2]byte
+	// offset is the position of the entry header in the file.
+	offset int64
+	length uint32
+}
+
+// dataOffset is the position of the entry data in the file.
+func (e entry) dataOffset() int64 {
+	return e.offset + headerSize
+}
+
+// writeEntry writes an e2store entry and returns the number of bytes written.
+func writeEntry(w io.Writer, typ [2]byte, data []byte) (int64, error) {
+	if uint64(len(data)) > uint64(^uint32(0)) {
+		return 0, errors.Wrapf(errInvalidEntry, "data length %d exceeds the maximum entry length", len(data))
+	}
+	header := make([]byte, headerSize)
+	copy(header, typ[:])
+	binary.LittleEndian.PutUint32(header[2:], uint32(len(data)))
+	if _, err := w.Write(header); err != nil {
+		return 0, err
+	}
+	if _, err := w.Write(data); err != nil {
+		return 0, err
+	}
+	return int64(headerSize + len(data)), nil
+}
+
+// readEntries reads the headers of all entries of an e2store file, without reading their data.
+func readEntries(r io.ReaderAt, size int64) ([]entry, error) {
+	var entries []entry
+	header := make([]byte, headerSize)
+	for offset := int64(0); offset < size; {
+		if _, err := r.ReadAt(header, offset); err != nil {
+			return nil, errors.Wrapf(errInvalidEntry, "could not read header at offset %d: %v", offset, err)
+		}
+		if header[6] != 0 || header[7] != 0 {
+			return nil, errors.Wrapf(errInvalidEntry, "reserved bytes are not zero at offset %d", offset)
+		}
+		e := entry{
+			typ:    [2]byte{header[0], header[1]},
+			offset: offset,
+			length: binary.LittleEndian.Uint32(header[2:6]),
+		}
+		if e.dataOffset()+int64(e.length) > size {
+			return nil, errors.Wrapf(errInvalidEntry, "entry at offset %d overflows the file", offset)
+		}
+		entries = append(entries, e)
+		offset = e.dataOffset() + int64(e.length)
+	}
+	return entries, nil
+}
@@ -0,0 +1,221 @@
+// Package era reads and writes era files, which store the finalized blocks of a period of
+// SLOTS_PER_HISTORICAL_ROOT slots along with the state at the end of the period, and exports and
+// imports the finalized history of the beacon node database as era files.
+// See https://github.com/status-im/nimbus-eth2/blob/stable/docs/the_auditors_handbook/src/02.4_the_era_file_format.md
+package era
+
+import (
+	"bytes"
+	"encoding/binary"
+	"fmt"
+	"io"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stateutil"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/hash"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+)
+
+// Extension is the file extension of era files.
+const Extension = ".era"
+
+// FileName returns the name of the era file of the given era, which is made of the name of the network config,
+// the era number and the first 4 bytes of the historical root of the era.
+func FileName(configName string, era uint64, historicalRoot [32]byte) string {
+	return fmt.Sprintf("%s-%05d-%x%s", configName, era, historicalRoot[:4], Extension)
+}
+
+// StartSlot returns the slot of the state stored in the era file of the given era. The blocks of the era file are
+// the ones of the SLOTS_PER_HISTORICAL_ROOT slots before it.
+func StartSlot(era uint64) primitives.Slot {
+	return primitives.Slot(era) * params.BeaconConfig().SlotsPerHistoricalRoot
+}
+
+// HistoricalRoot returns the root identifying the era of the state, which is the era ending at the slot of the
+// state. It is the root of the historical batch of the block and state roots of the era, which is equal to the
+// entry of the era in historical_roots before Capella and to the root of its entry in historical_summaries after.
+// The root of the genesis era is the genesis validators root.
+func HistoricalRoot(st state.ReadOnlyBeaconState) ([32]byte, error) {
+	if st.Slot() == params.BeaconConfig().GenesisSlot {
+		return bytesutil.ToBytes32(st.GenesisValidatorsRoot()), nil
+	}
+	length := uint64(params.BeaconConfig().SlotsPerHistoricalRoot)
+	blockRoots, err := stateutil.ArraysRoot(st.BlockRoots(), length)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not compute block roots root")
+	}
+	stateRoots, err := stateutil.ArraysRoot(st.StateRoots(), length)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not compute state roots root")
+	}
+	return hash.Hash(append(blockRoots[:], stateRoots[:]...)), nil
+}
+
+// Writer writes an era file. The blocks of the era must be written in ascending slot order, followed by the state
+// of the era, before closing the writer.
+type Writer struct {
+	w           io.Writer
+	offset      int64
+	era         uint64
+	blocks      []int64
+	stateOffset int64
+}
+
+// NewWriter starts writing the era file of the given era to w.
+func NewWriter(w io.Writer, era uint64) (*Writer, error) {
+	ew := &Writer{w: w, era: era}
+	if era > 0 {
+		ew.blocks = make([]int64, params.BeaconConfig().SlotsPerHistoricalRoot)
+	}
+	if err := ew.write(typeVersion, nil); err != nil {
+		return nil, err
+	}
+	return ew, nil
+}
+
+// WriteBlock writes the SSZ encoded signed block of the given slot.
+func (w *Writer) WriteBlock(slot primitives.Slot, block []byte) error {
+	if w.era == 0 || slot >= StartSlot(w.era) || slot < StartSlot(w.era-1) {
+		return errors.Errorf("slot %d is not part of era %d", slot, w.era)
+	}
+	if w.stateOffset != 0 {
+		return errors.New("blocks must be written before the state")
+	}
+	compressed, err := compress(block)
+	if err != nil {
+		return err
+	}
+	w.blocks[slot-StartSlot(w.era-1)] = w.offset
+	return w.write(typeCompressedSignedBeaconBlock, compressed)
+}
+
+// WriteState writes the SSZ encoded state of the era.
+func (w *Writer) WriteState(st []byte) error {
+	if w.stateOffset != 0 {
+		return errors.New("state was already written")
+	}
+	compressed, err := compress(st)
+	if err != nil {
+		return err
+	}
+	w.stateOffset = w.offset
+	return w.write(typeCompressedBeaconState, compressed)
+}
+
+// Close writes the slot indices of the blocks and the state. It does not close the underlying writer.
+func (w *Writer) Close() error {
+	if w.stateOffset == 0 {
+		return errors.New("state was not written")
+	}
+	if w.era > 0 {
+		if err := w.writeSlotIndex(StartSlot(w.era-1), w.blocks); err != nil {
+			return errors.Wrap(err, "could not write block index")
+		}
+	}
+	return errors.Wrap(w.writeSlotIndex(StartSlot(w.era), []int64{w.stateOffset}), "could not write state index")
+}
+
+// writeSlotIndex writes a slot index, where the offsets of the entries are relative to the start of the index and
+// slots without an entry have an offset of zero.
+func (w *Writer) writeSlotIndex(startSlot primitives.Slot, offsets []int64) error {
+	data := make([]byte, 8*(len(offsets)+2))
+	binary.LittleEndian.PutUint64(data, uint64(startSlot))
+	for i, offset := range offsets {
+		if offset != 0 {
+			binary.LittleEndian.PutUint64(data[8*(i+1):], uint64(offset-w.offset))
+		}
+	}
+	binary.LittleEndian.PutUint64(data[8*(len(offsets)+1):], uint64(len(offsets)))
+	return w.write(typeSlotIndex, data)
+}
+
+func (w *Writer) write(typ [2]byte, data []byte) error {
+	n, err := writeEntry(w.w, typ, data)
+	if err != nil {
+		return err
+	}
+	w.offset += n
+	return nil
+}
+
+// Reader reads the blocks and the state of an era file.
+type Reader struct {
+	r      io.ReaderAt
+	blocks []entry
+	state  entry
+}
+
+// NewReader reads the entries of the era file of the given size.
+func NewReader(r io.ReaderAt, size int64) (*Reader, error) {
+	entries, err := readEntries(r, size)
+	if err != nil {
+		return nil, err
+	}
+	if len(entries) == 0 || entries[0].typ != typeVersion {
+		return nil, errors.Wrap(errInvalidEntry, "era file does not start with a version entry")
+	}
+	er := &Reader{r: r}
+	states := 0
+	for _, e := range entries[1:] {
+		switch e.typ {
+		case typeVersion:
+			return nil, errors.Wrap(errInvalidEntry, "era files with several groups are not supported")
+		case typeCompressedSignedBeaconBlock:
+			if states > 0 {
+				return nil, errors.Wrap(errInvalidEntry, "block entry after the state entry")
+			}
+			er.blocks = append(er.blocks, e)
+		case typeCompressedBeaconState:
+			er.state = e
+			states++
+		}
+	}
+	if states != 1 {
+		return nil, errors.Wrapf(errInvalidEntry, "era file has %d state entries, expected 1", states)
+	}
+	return er, nil
+}
+
+// BlockCount returns the number of blocks in the era file.
+func (r *Reader) BlockCount() int {
+	return len(r.blocks)
+}
+
+// Block returns the SSZ encoded signed block at the given position in the era file.
+func (r *Reader) Block(i int) ([]byte, error) {
+	if i < 0 || i >= len(r.blocks) {
+		return nil, errors.Errorf("block %d out of range, era file has %d blocks", i, len(r.blocks))
+	}
+	return r.read(r.blocks[i])
+}
+
+// State returns the SSZ encoded state of the era file.
+func (r *Reader) State() ([]byte, error) {
+	return r.read(r.state)
+}
+
+func (r *Reader) read(e entry) ([]byte, error) {
+	section := io.NewSectionReader(r.r, e.dataOffset(), int64(e.length))
+	data, err := io.ReadAll(snappy.NewReader(section))
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not decompress entry at offset %d", e.offset)
+	}
+	return data, nil
+}
+
+// compress compresses the data with the snappy framing format used by era files.
+func compress(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	sw := snappy.NewBufferedWriter(&buf)
+	if _, err := sw.Write(data); err != nil {
+		return nil, err
+	}
+	if err := sw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
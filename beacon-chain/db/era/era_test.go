@@ -0,0 +1,80 @@
+package era
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestWriterReader_RoundTrip(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 2)
+	require.NoError(t, err)
+	start := StartSlot(1)
+	require.NoError(t, w.WriteBlock(start, []byte("block 0")))
+	require.NoError(t, w.WriteBlock(start+5, bytes.Repeat([]byte("block 5"), 1000)))
+	require.ErrorContains(t, "is not part of era 2", w.WriteBlock(StartSlot(2), []byte("block")))
+	require.NoError(t, w.WriteState([]byte("state")))
+	require.ErrorContains(t, "blocks must be written before the state", w.WriteBlock(start+6, []byte("block 6")))
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, 2, r.BlockCount())
+	blk, err := r.Block(0)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte("block 0"), blk)
+	blk, err = r.Block(1)
+	require.NoError(t, err)
+	assert.DeepEqual(t, bytes.Repeat([]byte("block 5"), 1000), blk)
+	_, err = r.Block(2)
+	require.ErrorContains(t, "out of range", err)
+	st, err := r.State()
+	require.NoError(t, err)
+	assert.DeepEqual(t, []byte("state"), st)
+
+	// The block index has an entry for each slot of the era, followed by the state index.
+	entries, err := readEntries(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	require.Equal(t, 6, len(entries))
+	assert.Equal(t, typeSlotIndex, entries[4].typ)
+	assert.Equal(t, uint32(8*(params.BeaconConfig().SlotsPerHistoricalRoot+2)), entries[4].length)
+	assert.Equal(t, typeSlotIndex, entries[5].typ)
+	assert.Equal(t, uint32(24), entries[5].length)
+}
+
+func TestWriter_GenesisEra(t *testing.T) {
+	var buf bytes.Buffer
+	w, err := NewWriter(&buf, 0)
+	require.NoError(t, err)
+	require.ErrorContains(t, "is not part of era 0", w.WriteBlock(0, []byte("block")))
+	require.ErrorContains(t, "state was not written", w.Close())
+	require.NoError(t, w.WriteState([]byte("state")))
+	require.NoError(t, w.Close())
+
+	r, err := NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.NoError(t, err)
+	assert.Equal(t, 0, r.BlockCount())
+}
+
+func TestNewReader_Invalid(t *testing.T) {
+	var buf bytes.Buffer
+	_, err := writeEntry(&buf, typeCompressedBeaconState, []byte{})
+	require.NoError(t, err)
+	_, err = NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.ErrorContains(t, "does not start with a version entry", err)
+
+	buf.Reset()
+	_, err = writeEntry(&buf, typeVersion, nil)
+	require.NoError(t, err)
+	_, err = NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()))
+	require.ErrorContains(t, "0 state entries", err)
+
+	_, err = writeEntry(&buf, typeCompressedBeaconState, []byte{1, 2, 3})
+	require.NoError(t, err)
+	_, err = NewReader(bytes.NewReader(buf.Bytes()), int64(buf.Len()-1))
+	require.ErrorContains(t, "overflows the file", err)
+}
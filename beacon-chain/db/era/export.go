@@ -0,0 +1,188 @@
+package era
+
+import (
+	"bufio"
+	"context"
+	"os"
+	"path/filepath"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// LastFinalizedEra returns the highest era whose state is finalized in the database.
+func LastFinalizedEra(ctx context.Context, db iface.ReadOnlyDatabase) (uint64, error) {
+	cp, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	finalizedSlot, err := slots.EpochStart(cp.Epoch)
+	if err != nil {
+		return 0, err
+	}
+	return uint64(finalizedSlot / params.BeaconConfig().SlotsPerHistoricalRoot), nil
+}
+
+// Export writes the era files of the eras from start to end, which must be finalized, to dir and returns their
+// paths. The states of the eras are regenerated by replaying the finalized blocks on top of the closest state saved
+// in the database, so the history of the eras must not have been pruned.
+func Export(ctx context.Context, db iface.ReadOnlyDatabase, dir string, start, end uint64) ([]string, error) {
+	ctx, span := trace.StartSpan(ctx, "era.Export")
+	defer span.End()
+
+	if start > end {
+		return nil, errors.Errorf("start era %d is after end era %d", start, end)
+	}
+	last, err := LastFinalizedEra(ctx, db)
+	if err != nil {
+		return nil, err
+	}
+	if end > last {
+		return nil, errors.Errorf("end era %d is not finalized, the last finalized era is %d", end, last)
+	}
+	if err := file.MkdirAll(dir); err != nil {
+		return nil, errors.Wrapf(err, "could not create directory %s", dir)
+	}
+
+	lastSlot := StartSlot(last)
+	history := stategen.NewCanonicalHistory(db, finalizedChecker{db: db}, finalizedSlotter(lastSlot))
+	paths := make([]string, 0, end-start+1)
+	for era := start; era <= end; era++ {
+		if ctx.Err() != nil {
+			return paths, ctx.Err()
+		}
+		var st state.BeaconState
+		if era == 0 {
+			st, err = db.GenesisState(ctx)
+		} else {
+			st, err = history.ReplayerForSlot(StartSlot(era)).ReplayToSlot(ctx, StartSlot(era))
+		}
+		if err != nil {
+			return paths, errors.Wrapf(err, "could not get state of era %d", era)
+		}
+		if st == nil || st.IsNil() {
+			return paths, errors.Errorf("state of era %d not found", era)
+		}
+		path, err := exportEra(ctx, db, dir, era, st)
+		if err != nil {
+			return paths, errors.Wrapf(err, "could not export era %d", era)
+		}
+		log.WithFields(logrus.Fields{
+			"era":  era,
+			"path": path,
+		}).Info("Exported era file")
+		paths = append(paths, path)
+	}
+	return paths, nil
+}
+
+func exportEra(ctx context.Context, db iface.ReadOnlyDatabase, dir string, era uint64, st state.BeaconState) (path string, err error) {
+	root, err := HistoricalRoot(st)
+	if err != nil {
+		return "", err
+	}
+	path = filepath.Join(dir, FileName(params.BeaconConfig().ConfigName, era, root))
+	f, err := os.OpenFile(path, os.O_CREATE|os.O_TRUNC|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return "", err
+	}
+	defer func() {
+		if closeErr := f.Close(); closeErr != nil && err == nil {
+			err = closeErr
+		}
+		if err != nil {
+			if rmErr := os.Remove(path); rmErr != nil {
+				log.WithError(rmErr).Error("Could not remove incomplete era file")
+			}
+		}
+	}()
+
+	bw := bufio.NewWriter(f)
+	w, err := NewWriter(bw, era)
+	if err != nil {
+		return "", err
+	}
+	if era > 0 {
+		if err := writeBlocks(ctx, db, w, era, st); err != nil {
+			return "", err
+		}
+	}
+	enc, err := st.MarshalSSZ()
+	if err != nil {
+		return "", errors.Wrap(err, "could not marshal state")
+	}
+	if err := w.WriteState(enc); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+	return path, bw.Flush()
+}
+
+// writeBlocks writes the blocks of the era, which are the blocks whose roots are in the block roots of the era state.
+func writeBlocks(ctx context.Context, db iface.ReadOnlyDatabase, w *Writer, era uint64, st state.BeaconState) error {
+	blockRoots := st.BlockRoots()
+	startSlot := StartSlot(era - 1)
+	var previous []byte
+	for i, r := range blockRoots {
+		slot := startSlot + primitives.Slot(i)
+		// The root of the previous block is repeated in the block roots for empty slots.
+		if previous != nil && bytesutil.ToBytes32(r) == bytesutil.ToBytes32(previous) {
+			continue
+		}
+		previous = r
+		blk, err := db.Block(ctx, bytesutil.ToBytes32(r))
+		if err != nil {
+			return errors.Wrapf(err, "could not get block at slot %d", slot)
+		}
+		if blk == nil || blk.IsNil() {
+			return errors.Errorf("block %#x at slot %d is not in the database", r, slot)
+		}
+		// The first root of the era may belong to a block of the previous era.
+		if blk.Block().Slot() != slot {
+			continue
+		}
+		if blk.IsBlinded() {
+			return errors.Errorf("block at slot %d is stored without its execution payload, "+
+				"era files can only be exported from a database saving full execution payloads", slot)
+		}
+		enc, err := blk.MarshalSSZ()
+		if err != nil {
+			return errors.Wrapf(err, "could not marshal block at slot %d", slot)
+		}
+		if err := w.WriteBlock(slot, enc); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// finalizedChecker considers the blocks of the finalized index canonical, which is all that is needed to replay
+// the finalized history without a fork choice store.
+type finalizedChecker struct {
+	db iface.ReadOnlyDatabase
+}
+
+// IsCanonical returns true if the block is finalized.
+func (c finalizedChecker) IsCanonical(ctx context.Context, blockRoot [32]byte) (bool, error) {
+	return c.db.IsFinalizedBlock(ctx, blockRoot), nil
+}
+
+// finalizedSlotter reports the slot of the last finalized era as the current slot, so that states can be
+// replayed up to it.
+type finalizedSlotter primitives.Slot
+
+// CurrentSlot returns the slot of the last finalized era.
+func (s finalizedSlotter) CurrentSlot() primitives.Slot {
+	return primitives.Slot(s)
+}
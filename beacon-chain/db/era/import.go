@@ -0,0 +1,587 @@
+package era
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/iface"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/encoding/ssz/detect"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+	"go.opencensus.io/trace"
+)
+
+// backfillBatchSize is the number of imported blocks added to the finalized index at once when they are linked
+// to the blocks below the checkpoint sync origin.
+const backfillBatchSize = 1024
+
+var errVerification = errors.New("era file verification failed")
+
+// eraFile is an era file found in the import directory.
+type eraFile struct {
+	path   string
+	era    uint64
+	prefix string
+}
+
+// Import verifies the era files found in dir and saves their blocks and states in the database.
+//
+// The historical roots of the eras are checked against the historical roots and summaries of a trusted state, and
+// the blocks and states of each era against the block and state roots of its era state. The trusted state is the
+// checkpoint sync origin state or the finalized state of the database if it is more recent than the eras. When
+// the database only holds the genesis state, the state of the last era file is trusted instead, like a checkpoint
+// sync state would be, and the node is seeded with the imported history: the last era state which can be saved
+// becomes the finalized checkpoint from which the node starts syncing. When the node was checkpoint synced, the
+// imported blocks below the origin are linked to the backfilled blocks, as if they had been backfilled.
+//
+// The highest imported era is recorded in the database, and the era files up to it are skipped on later imports,
+// apart from the last imported one, whose state may be saved once the blocks of the next era are imported.
+func Import(ctx context.Context, db iface.HeadAccessDatabase, dir string) error {
+	ctx, span := trace.StartSpan(ctx, "era.Import")
+	defer span.End()
+
+	files, err := listEraFiles(dir)
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return errors.Errorf("no era files found in %s", dir)
+	}
+	lastImported, err := db.LastImportedEra(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get last imported era")
+	}
+	if lastImported > 0 {
+		if files[len(files)-1].era <= lastImported {
+			log.WithField("lastImportedEra", lastImported).Info("Era files already imported")
+			return nil
+		}
+		i := sort.Search(len(files), func(i int) bool {
+			return files[i].era >= lastImported
+		})
+		files = files[i:]
+	}
+	genesis, err := db.GenesisState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis state")
+	}
+	if genesis == nil || genesis.IsNil() {
+		return errors.New("the genesis state must be saved in the database before importing era files")
+	}
+
+	anchor, seed, err := trustedState(ctx, db, files)
+	if err != nil {
+		return err
+	}
+	if seed {
+		root, err := anchor.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		log.WithFields(logrus.Fields{
+			"slot":      anchor.Slot(),
+			"stateRoot": fmt.Sprintf("%#x", root),
+		}).Warn("Seeding the database from era files, the state of the last era file is trusted like a checkpoint sync state")
+	}
+	trustedRoots, err := historicalRoots(anchor)
+	if err != nil {
+		return err
+	}
+
+	var (
+		current, next state.BeaconState
+		pending       state.BeaconState
+		last          *ethpb.Checkpoint
+	)
+	for i, f := range files {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		current = next
+		if current == nil {
+			if current, err = readState(f); err != nil {
+				return err
+			}
+		}
+		next = nil
+		if i+1 < len(files) && files[i+1].era == f.era+1 {
+			if next, err = readState(files[i+1]); err != nil {
+				return err
+			}
+		}
+		if err := verifyEraState(ctx, f, current, genesis, trustedRoots); err != nil {
+			return err
+		}
+		if f.era == 0 {
+			continue
+		}
+		imported := f.era <= lastImported
+		var count int
+		if !imported {
+			if count, err = importBlocks(ctx, db, f, current); err != nil {
+				return err
+			}
+		}
+		// The block of the pending state may be the first block of this era.
+		if pending != nil {
+			if cp, err := saveEraState(ctx, db, pending); err != nil {
+				return err
+			} else if cp != nil {
+				last = cp
+			}
+			pending = nil
+		}
+		verified, err := isStateVerified(ctx, current, next, anchor)
+		if err != nil {
+			return err
+		}
+		if verified {
+			pending = current
+		}
+		if imported {
+			continue
+		}
+		log.WithFields(logrus.Fields{
+			"era":    f.era,
+			"blocks": count,
+		}).Info("Imported era file")
+	}
+	if pending != nil {
+		cp, err := saveEraState(ctx, db, pending)
+		if err != nil {
+			return err
+		}
+		if cp != nil {
+			last = cp
+		}
+	}
+
+	if seed {
+		if err := seedFinalizedCheckpoint(ctx, db, last); err != nil {
+			return err
+		}
+	} else if err := linkBackfill(ctx, db); err != nil {
+		return err
+	}
+	return db.SaveLastImportedEra(ctx, files[len(files)-1].era)
+}
+
+// listEraFiles returns the era files of the directory sorted by era.
+func listEraFiles(dir string) ([]eraFile, error) {
+	paths, err := filepath.Glob(filepath.Join(dir, "*"+Extension))
+	if err != nil {
+		return nil, err
+	}
+	files := make([]eraFile, 0, len(paths))
+	for _, p := range paths {
+		// The config name may contain dashes, the era number and historical root may not.
+		parts := strings.Split(strings.TrimSuffix(filepath.Base(p), Extension), "-")
+		if len(parts) < 3 {
+			return nil, errors.Errorf("invalid era file name %s", p)
+		}
+		era, err := strconv.ParseUint(parts[len(parts)-2], 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid era number in era file name %s", p)
+		}
+		files = append(files, eraFile{path: p, era: era, prefix: parts[len(parts)-1]})
+	}
+	sort.Slice(files, func(i, j int) bool {
+		return files[i].era < files[j].era
+	})
+	for i := 1; i < len(files); i++ {
+		if files[i].era == files[i-1].era {
+			return nil, errors.Errorf("era files %s and %s are of the same era", files[i-1].path, files[i].path)
+		}
+	}
+	return files, nil
+}
+
+// trustedState returns the state whose historical roots the eras are verified against, and whether the database is
+// seeded from the era files.
+func trustedState(ctx context.Context, db iface.HeadAccessDatabase, files []eraFile) (state.BeaconState, bool, error) {
+	lastEraSlot := StartSlot(files[len(files)-1].era)
+	originRoot, err := db.OriginCheckpointBlockRoot(ctx)
+	switch {
+	case err == nil:
+		st, err := db.State(ctx, originRoot)
+		if err != nil {
+			return nil, false, errors.Wrap(err, "could not get origin checkpoint state")
+		}
+		if st == nil || st.IsNil() {
+			return nil, false, errors.Errorf("origin checkpoint state for root %#x not found", originRoot)
+		}
+		if st.Slot() < lastEraSlot {
+			return nil, false, errors.Errorf("era files go past the checkpoint sync origin at slot %d", st.Slot())
+		}
+		return st, false, nil
+	case !errors.Is(err, kv.ErrNotFoundOriginBlockRoot):
+		return nil, false, errors.Wrap(err, "could not get origin checkpoint block root")
+	}
+
+	cp, err := db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return nil, false, errors.Wrap(err, "could not get finalized checkpoint")
+	}
+	if cp.Epoch > 0 {
+		st, err := db.State(ctx, bytesutil.ToBytes32(cp.Root))
+		if err != nil {
+			return nil, false, errors.Wrap(err, "could not get finalized state")
+		}
+		if st == nil || st.IsNil() {
+			return nil, false, errors.Errorf("finalized state for root %#x not found", cp.Root)
+		}
+		if st.Slot() < lastEraSlot {
+			return nil, false, errors.Errorf("era files go past the finalized checkpoint at slot %d, "+
+				"era files can only seed a database holding the genesis state only", st.Slot())
+		}
+		return st, false, nil
+	}
+
+	st, err := readState(files[len(files)-1])
+	if err != nil {
+		return nil, false, err
+	}
+	return st, true, nil
+}
+
+// historicalRoots returns the historical roots of all the eras before the state, from the historical roots
+// of the eras before Capella followed by the roots of the historical summaries of the eras after.
+func historicalRoots(st state.BeaconState) ([][32]byte, error) {
+	roots, err := st.HistoricalRoots()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get historical roots")
+	}
+	trusted := make([][32]byte, 0, len(roots))
+	for _, r := range roots {
+		trusted = append(trusted, bytesutil.ToBytes32(r))
+	}
+	if st.Version() < version.Capella {
+		return trusted, nil
+	}
+	summaries, err := st.HistoricalSummaries()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not get historical summaries")
+	}
+	for _, s := range summaries {
+		r, err := s.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not compute historical summary root")
+		}
+		trusted = append(trusted, r)
+	}
+	return trusted, nil
+}
+
+func readState(f eraFile) (state.BeaconState, error) {
+	r, closer, err := openEraFile(f.path)
+	if err != nil {
+		return nil, err
+	}
+	defer closer()
+	enc, err := r.State()
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not read state of era file %s", f.path)
+	}
+	cf, err := detect.FromState(enc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not detect config and fork of the state of era file %s", f.path)
+	}
+	st, err := cf.UnmarshalBeaconState(enc)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not unmarshal state of era file %s", f.path)
+	}
+	return st, nil
+}
+
+func openEraFile(path string) (*Reader, func(), error) {
+	f, err := os.Open(path) // #nosec G304
+	if err != nil {
+		return nil, nil, err
+	}
+	closer := func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).WithField("path", path).Error("Could not close era file")
+		}
+	}
+	info, err := f.Stat()
+	if err != nil {
+		closer()
+		return nil, nil, err
+	}
+	r, err := NewReader(f, info.Size())
+	if err != nil {
+		closer()
+		return nil, nil, errors.Wrapf(err, "could not read era file %s", path)
+	}
+	return r, closer, nil
+}
+
+// verifyEraState checks that the state is the state of the era, and that the block and state roots of the era it
+// holds are the ones recorded in the historical roots of the trusted state.
+func verifyEraState(ctx context.Context, f eraFile, st, genesis state.BeaconState, trusted [][32]byte) error {
+	if st.Slot() != StartSlot(f.era) {
+		return errors.Wrapf(errVerification, "state of era file %s is at slot %d, expected %d", f.path, st.Slot(), StartSlot(f.era))
+	}
+	if bytesutil.ToBytes32(st.GenesisValidatorsRoot()) != bytesutil.ToBytes32(genesis.GenesisValidatorsRoot()) {
+		return errors.Wrapf(errVerification, "era file %s is for another chain", f.path)
+	}
+	root, err := HistoricalRoot(st)
+	if err != nil {
+		return err
+	}
+	if !strings.EqualFold(f.prefix, fmt.Sprintf("%x", root[:4])) {
+		return errors.Wrapf(errVerification, "historical root %#x of era file %s does not match its name", root, f.path)
+	}
+	if f.era == 0 {
+		stateRoot, err := st.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		genesisRoot, err := genesis.HashTreeRoot(ctx)
+		if err != nil {
+			return err
+		}
+		if stateRoot != genesisRoot {
+			return errors.Wrapf(errVerification, "state of era file %s is not the genesis state", f.path)
+		}
+		return nil
+	}
+	if uint64(len(trusted)) < f.era {
+		return errors.Wrapf(errVerification, "era %d is not in the historical roots of the trusted state", f.era)
+	}
+	if trusted[f.era-1] != root {
+		return errors.Wrapf(errVerification, "historical root %#x of era file %s does not match the trusted root %#x",
+			root, f.path, trusted[f.era-1])
+	}
+	return nil
+}
+
+// isStateVerified returns true if the root of the era state is known from a verified state: the state of the
+// next era, or the trusted state when it is less than an era ahead or is the era state itself.
+func isStateVerified(ctx context.Context, st, next, trusted state.BeaconState) (bool, error) {
+	root, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return false, err
+	}
+	if trusted.Slot() == st.Slot() {
+		trustedRoot, err := trusted.HashTreeRoot(ctx)
+		if err != nil {
+			return false, err
+		}
+		return root == trustedRoot, nil
+	}
+	length := params.BeaconConfig().SlotsPerHistoricalRoot
+	i := st.Slot() % length
+	if next != nil {
+		return bytesutil.ToBytes32(next.StateRoots()[i]) == root, nil
+	}
+	if trusted.Slot() > st.Slot() && trusted.Slot() <= st.Slot()+length {
+		return bytesutil.ToBytes32(trusted.StateRoots()[i]) == root, nil
+	}
+	return false, nil
+}
+
+// importBlocks saves the blocks of the era file, after checking that they are the blocks of the era state, and
+// returns the number of blocks saved.
+func importBlocks(ctx context.Context, db iface.HeadAccessDatabase, f eraFile, st state.BeaconState) (int, error) {
+	r, closer, err := openEraFile(f.path)
+	if err != nil {
+		return 0, err
+	}
+	defer closer()
+
+	startSlot := StartSlot(f.era - 1)
+	blockRoots := st.BlockRoots()
+	schedule := forks.NewOrderedSchedule(params.BeaconConfig())
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, 0, r.BlockCount())
+	for i := 0; i < r.BlockCount(); i++ {
+		enc, err := r.Block(i)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not read block %d of era file %s", i, f.path)
+		}
+		blk, err := unmarshalBlock(schedule, enc)
+		if err != nil {
+			return 0, errors.Wrapf(err, "could not unmarshal block %d of era file %s", i, f.path)
+		}
+		slot := blk.Block().Slot()
+		if slot < startSlot || slot >= st.Slot() {
+			return 0, errors.Wrapf(errVerification, "block at slot %d is not part of era file %s", slot, f.path)
+		}
+		root, err := blk.Block().HashTreeRoot()
+		if err != nil {
+			return 0, err
+		}
+		if bytesutil.ToBytes32(blockRoots[slot-startSlot]) != root {
+			return 0, errors.Wrapf(errVerification, "block %#x at slot %d of era file %s is not in the block roots of the era",
+				root, slot, f.path)
+		}
+		blks = append(blks, blk)
+	}
+	return len(blks), db.SaveBlocks(ctx, blks)
+}
+
+// unmarshalBlock unmarshals an SSZ encoded signed block of the fork active at its slot.
+func unmarshalBlock(schedule forks.OrderedSchedule, enc []byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+	// The slot follows the 4 byte offset of the block and the 96 byte signature.
+	if len(enc) < 108 {
+		return nil, errors.New("block is too short")
+	}
+	slot := primitives.Slot(binary.LittleEndian.Uint64(enc[100:108]))
+	v, err := schedule.VersionForEpoch(slots.ToEpoch(slot))
+	if err != nil {
+		return nil, err
+	}
+	cf, err := detect.FromForkVersion(v)
+	if err != nil {
+		return nil, err
+	}
+	return cf.UnmarshalBeaconBlock(enc)
+}
+
+// saveEraState saves the verified era state if the block it was produced by is at the slot of the state and is in
+// the database, and returns the checkpoint of the state. Era states at empty slots are not saved, as saved states
+// must be the post-states of their blocks.
+func saveEraState(ctx context.Context, db iface.HeadAccessDatabase, st state.BeaconState) (*ethpb.Checkpoint, error) {
+	header := ethpb.CopyBeaconBlockHeader(st.LatestBlockHeader())
+	if header.Slot != st.Slot() {
+		return nil, nil
+	}
+	stateRoot, err := st.HashTreeRoot(ctx)
+	if err != nil {
+		return nil, err
+	}
+	header.StateRoot = stateRoot[:]
+	blockRoot, err := header.HashTreeRoot()
+	if err != nil {
+		return nil, err
+	}
+	if !db.HasBlock(ctx, blockRoot) {
+		return nil, nil
+	}
+	if err := db.SaveState(ctx, st, blockRoot); err != nil {
+		return nil, errors.Wrapf(err, "could not save state at slot %d", st.Slot())
+	}
+	if err := db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: st.Slot(), Root: blockRoot[:]}); err != nil {
+		return nil, errors.Wrapf(err, "could not save state summary at slot %d", st.Slot())
+	}
+	return &ethpb.Checkpoint{Epoch: slots.ToEpoch(st.Slot()), Root: blockRoot[:]}, nil
+}
+
+// seedFinalizedCheckpoint makes the checkpoint of the last saved era state the finalized and justified checkpoints
+// and the head of the database, so that the node starts syncing from it.
+func seedFinalizedCheckpoint(ctx context.Context, db iface.HeadAccessDatabase, cp *ethpb.Checkpoint) error {
+	if cp == nil {
+		log.Warn("None of the era states could be saved, the node will sync from genesis")
+		return nil
+	}
+	if err := db.SaveJustifiedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save justified checkpoint")
+	}
+	if err := db.SaveFinalizedCheckpoint(ctx, cp); err != nil {
+		return errors.Wrap(err, "could not save finalized checkpoint")
+	}
+	if err := db.SaveHeadBlockRoot(ctx, bytesutil.ToBytes32(cp.Root)); err != nil {
+		return errors.Wrap(err, "could not save head block root")
+	}
+	log.WithFields(logrus.Fields{
+		"epoch": cp.Epoch,
+		"root":  fmt.Sprintf("%#x", cp.Root),
+	}).Info("Seeded the database from era files")
+	return nil
+}
+
+// linkBackfill adds the imported blocks below the lowest backfilled block to the finalized index and moves the
+// backfill position down to the lowest of them, as the backfill service would once it downloaded them.
+func linkBackfill(ctx context.Context, db iface.HeadAccessDatabase) error {
+	low, err := db.BackfillBlockRoot(ctx)
+	if errors.Is(err, kv.ErrNotFoundBackfillBlockRoot) {
+		return nil
+	}
+	if err != nil {
+		return errors.Wrap(err, "could not get backfill block root")
+	}
+	genesisRoot, err := db.GenesisBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get genesis block root")
+	}
+	// Databases initialized before backfill was implemented record the genesis block root as the backfill
+	// position, in which case nothing below the origin block was backfilled.
+	if low == genesisRoot {
+		if low, err = db.OriginCheckpointBlockRoot(ctx); err != nil {
+			return errors.Wrap(err, "could not get origin checkpoint block root")
+		}
+	}
+	linked := 0
+	for {
+		batch, err := blocksBelow(ctx, db, low, genesisRoot)
+		if err != nil {
+			return err
+		}
+		if len(batch) == 0 {
+			break
+		}
+		if err := db.BackfillFinalizedIndex(ctx, batch, low); err != nil {
+			return errors.Wrap(err, "could not update finalized index with imported blocks")
+		}
+		lowest, err := batch[0].Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		if err := db.SaveBackfillBlockRoot(ctx, lowest); err != nil {
+			return errors.Wrap(err, "could not save backfill block root")
+		}
+		low = lowest
+		linked += len(batch)
+	}
+	if linked > 0 {
+		log.WithField("blocks", linked).Info("Linked imported blocks to the backfilled history")
+	}
+	return nil
+}
+
+// blocksBelow returns up to backfillBatchSize ancestors of the block in the database, in ascending slot order,
+// stopping at the genesis block.
+func blocksBelow(ctx context.Context, db iface.HeadAccessDatabase, root, genesisRoot [32]byte) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	blk, err := db.Block(ctx, root)
+	if err != nil {
+		return nil, err
+	}
+	if blk == nil || blk.IsNil() {
+		return nil, errors.Errorf("block %#x not found", root)
+	}
+	batch := make([]interfaces.ReadOnlySignedBeaconBlock, 0, backfillBatchSize)
+	for len(batch) < backfillBatchSize {
+		parent := blk.Block().ParentRoot()
+		if parent == genesisRoot {
+			break
+		}
+		blk, err = db.Block(ctx, parent)
+		if err != nil {
+			return nil, err
+		}
+		if blk == nil || blk.IsNil() {
+			break
+		}
+		batch = append(batch, blk)
+	}
+	for i, j := 0, len(batch)-1; i < j; i, j = i+1, j-1 {
+		batch[i], batch[j] = batch[j], batch[i]
+	}
+	return batch, nil
+}
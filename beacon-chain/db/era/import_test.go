@@ -0,0 +1,192 @@
+package era
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// exportTestChain exports the eras of a chain finalized at the start of era 2 and returns its genesis state,
+// the roots of its blocks and the directory of the era files.
+func exportTestChain(t *testing.T) (state.BeaconState, map[primitives.Slot][32]byte, string) {
+	ctx := context.Background()
+	// The database is closed before the era files are imported into another one.
+	db, err := kv.NewKVStore(ctx, t.TempDir())
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, db.Close())
+	}()
+	genesis, privs := util.DeterministicGenesisState(t, 64)
+	require.NoError(t, db.SaveGenesisData(ctx, genesis))
+
+	st := genesis.Copy()
+	roots := make(map[primitives.Slot][32]byte)
+	for _, slot := range []primitives.Slot{1, 2, 3, StartSlot(1), StartSlot(2)} {
+		if st.Slot() < slot-1 {
+			st, err = transition.ProcessSlots(ctx, st, slot-1)
+			require.NoError(t, err)
+		}
+		next, err := transition.ProcessSlots(ctx, st.Copy(), slot)
+		require.NoError(t, err)
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ProposerIndex, err = helpers.BeaconProposerIndex(ctx, next)
+		require.NoError(t, err)
+		parentRoot, err := next.LatestBlockHeader().HashTreeRoot()
+		require.NoError(t, err)
+		b.Block.ParentRoot = parentRoot[:]
+		b.Block.Body.RandaoReveal, err = util.RandaoReveal(next, slots.ToEpoch(slot), privs)
+		require.NoError(t, err)
+		b.Block.Body.Eth1Data = st.Eth1Data()
+		sig, err := util.BlockSignature(st, b.Block, privs)
+		require.NoError(t, err)
+		b.Signature = sig.Marshal()
+		wsb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		st, err = transition.ExecuteStateTransition(ctx, st, wsb)
+		require.NoError(t, err)
+		require.NoError(t, db.SaveBlock(ctx, wsb))
+		roots[slot], err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+	}
+	finalized := roots[StartSlot(2)]
+	require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: StartSlot(2), Root: finalized[:]}))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: slots.ToEpoch(StartSlot(2)), Root: finalized[:]}))
+
+	last, err := LastFinalizedEra(ctx, db)
+	require.NoError(t, err)
+	require.Equal(t, uint64(2), last)
+	_, err = Export(ctx, db, t.TempDir(), 0, 3)
+	require.ErrorContains(t, "end era 3 is not finalized", err)
+
+	dir := filepath.Join(t.TempDir(), "era")
+	paths, err := Export(ctx, db, dir, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(paths))
+	root, err := HistoricalRoot(st)
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join(dir, FileName(params.BeaconConfig().ConfigName, 2, root)), paths[2])
+	return genesis, roots, dir
+}
+
+// setupTestConfig sets up a config whose genesis state is not embedded in the binary.
+func setupTestConfig(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.ConfigName = "era-test"
+	params.OverrideBeaconConfig(cfg)
+}
+
+func TestExportImport_Seed(t *testing.T) {
+	setupTestConfig(t)
+	ctx := context.Background()
+	genesis, roots, dir := exportTestChain(t)
+
+	db := dbtest.SetupDB(t)
+	require.NoError(t, db.SaveGenesisData(ctx, genesis))
+	require.NoError(t, Import(ctx, db, dir))
+
+	for slot, root := range roots {
+		if slot == StartSlot(2) {
+			continue
+		}
+		blk, err := db.Block(ctx, root)
+		require.NoError(t, err)
+		require.NotNil(t, blk)
+		assert.Equal(t, slot, blk.Block().Slot())
+	}
+	// The state of era 1 is verified against the trusted state of era 2, and becomes the finalized checkpoint as the
+	// block of the state of era 2 is not part of the exported eras.
+	st, err := db.State(ctx, roots[StartSlot(1)])
+	require.NoError(t, err)
+	require.NotNil(t, st)
+	assert.Equal(t, StartSlot(1), st.Slot())
+	assert.Equal(t, false, db.HasBlock(ctx, roots[StartSlot(2)]))
+	cp, err := db.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, slots.ToEpoch(StartSlot(1)), cp.Epoch)
+	finalized := roots[StartSlot(1)]
+	assert.DeepEqual(t, finalized[:], cp.Root)
+	head, err := db.HeadBlock(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, StartSlot(1), head.Block().Slot())
+	assert.Equal(t, true, db.IsFinalizedBlock(ctx, roots[2]))
+
+	// The imported eras are skipped on the next import.
+	lastImported, err := db.LastImportedEra(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(2), lastImported)
+	require.NoError(t, Import(ctx, db, dir))
+}
+
+func TestImport_InvalidEra(t *testing.T) {
+	setupTestConfig(t)
+	ctx := context.Background()
+	genesis, _, dir := exportTestChain(t)
+
+	// An era file whose name does not match its historical root is rejected.
+	paths, err := filepath.Glob(filepath.Join(dir, "*-00001-*"+Extension))
+	require.NoError(t, err)
+	require.Equal(t, 1, len(paths))
+	require.NoError(t, os.Rename(paths[0], filepath.Join(dir, FileName(params.BeaconConfig().ConfigName, 1, [32]byte{}))))
+
+	db := dbtest.SetupDB(t)
+	require.NoError(t, db.SaveGenesisData(ctx, genesis))
+	require.ErrorIs(t, Import(ctx, db, dir), errVerification)
+
+	require.ErrorContains(t, "no era files found", Import(ctx, db, t.TempDir()))
+}
+
+func TestExportImport_Backfill(t *testing.T) {
+	setupTestConfig(t)
+	ctx := context.Background()
+	genesis, roots, dir := exportTestChain(t)
+
+	// The node was checkpoint synced from the state of era 1, whose block is the first block of era 2.
+	files, err := listEraFiles(dir)
+	require.NoError(t, err)
+	require.Equal(t, 3, len(files))
+	r, closer, err := openEraFile(files[1].path)
+	require.NoError(t, err)
+	origin, err := r.State()
+	require.NoError(t, err)
+	closer()
+	r, closer, err = openEraFile(files[2].path)
+	require.NoError(t, err)
+	originBlock, err := r.Block(0)
+	require.NoError(t, err)
+	closer()
+	require.NoError(t, os.Remove(files[2].path))
+
+	db := dbtest.SetupDB(t)
+	require.NoError(t, db.SaveGenesisData(ctx, genesis))
+	require.NoError(t, db.SaveOrigin(ctx, origin, originBlock))
+	require.NoError(t, Import(ctx, db, dir))
+
+	for _, slot := range []primitives.Slot{1, 2, 3} {
+		assert.Equal(t, true, db.HasBlock(ctx, roots[slot]))
+		assert.Equal(t, true, db.IsFinalizedBlock(ctx, roots[slot]))
+	}
+	backfill, err := db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, roots[1], backfill)
+	// The finalized checkpoint stays at the checkpoint sync origin.
+	cp, err := db.FinalizedCheckpoint(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, slots.ToEpoch(StartSlot(1)), cp.Epoch)
+}
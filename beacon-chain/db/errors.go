@@ -19,3 +19,6 @@ var ErrNotFoundBackfillBlockRoot = kv.ErrNotFoundBackfillBlockRoot
 
 // ErrNotFoundGenesisBlockRoot means no genesis block root was found, indicating the db was not initialized with genesis
 var ErrNotFoundGenesisBlockRoot = kv.ErrNotFoundGenesisBlockRoot
+
+// ErrStateDiffBase is raised when a state diff cannot be saved against the given base state.
+var ErrStateDiffBase = kv.ErrStateDiffBase
@@ -8,12 +8,17 @@ import (
 	"io"
 
 	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	montypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor/types"
 	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/backup"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
@@ -51,12 +56,28 @@ type ReadOnlyDatabase interface {
 	DepositContractAddress(ctx context.Context) ([]byte, error)
 	// ExecutionChainData operations.
 	ExecutionChainData(ctx context.Context) (*ethpb.ETH1ChainData, error)
+	DepositSnapshot(ctx context.Context) (*depositsnapshot.DepositTreeSnapshot, error)
 	// Fee recipients operations.
 	FeeRecipientByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (common.Address, error)
 	RegistrationByValidatorID(ctx context.Context, id primitives.ValidatorIndex) (*ethpb.ValidatorRegistrationV1, error)
 	// origin checkpoint sync support
 	OriginCheckpointBlockRoot(ctx context.Context) ([32]byte, error)
 	BackfillBlockRoot(ctx context.Context) ([32]byte, error)
+	// History pruning.
+	EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error)
+	// Era file import.
+	LastImportedEra(ctx context.Context) (uint64, error)
+	// PulseChain reward burn.
+	EpochBurns(ctx context.Context, start, end primitives.Epoch) ([]*pulse.EpochBurn, error)
+	// Validator balance and status history.
+	ValidatorHistory(ctx context.Context, indices []primitives.ValidatorIndex, start, end primitives.Epoch) (map[primitives.ValidatorIndex][]precompute.ValidatorRecord, error)
+	LastValidatorHistoryEpoch(ctx context.Context) (primitives.Epoch, error)
+	// Validator monitor.
+	ValidatorPerformance(ctx context.Context, indices []primitives.ValidatorIndex, start, end primitives.Epoch) (map[primitives.ValidatorIndex][]*montypes.ValidatorPerformance, error)
+	MonitoredValidators(ctx context.Context) ([]primitives.ValidatorIndex, error)
+	// Light client data.
+	LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) ([]*ethpbv2.LightClientUpdate, error)
+	LightClientBootstrap(ctx context.Context, blockRoot [32]byte) (*ethpbv2.LightClientBootstrap, error)
 }
 
 // NoHeadAccessDatabase defines a struct without access to chain head data.
@@ -71,6 +92,7 @@ type NoHeadAccessDatabase interface {
 	// State related methods.
 	SaveState(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot [32]byte) error
 	SaveStates(ctx context.Context, states []state.ReadOnlyBeaconState, blockRoots [][32]byte) error
+	SaveStateDiff(ctx context.Context, state state.ReadOnlyBeaconState, blockRoot, baseRoot [32]byte) error
 	DeleteState(ctx context.Context, blockRoot [32]byte) error
 	DeleteStates(ctx context.Context, blockRoots [][32]byte) error
 	SaveStateSummary(ctx context.Context, summary *ethpb.StateSummary) error
@@ -83,6 +105,7 @@ type NoHeadAccessDatabase interface {
 	SaveDepositContractAddress(ctx context.Context, addr common.Address) error
 	// SaveExecutionChainData operations.
 	SaveExecutionChainData(ctx context.Context, data *ethpb.ETH1ChainData) error
+	SaveDepositSnapshot(ctx context.Context, snapshot *depositsnapshot.DepositTreeSnapshot) error
 	// Run any required database migrations.
 	RunMigrations(ctx context.Context) error
 	// Fee recipients operations.
@@ -90,6 +113,20 @@ type NoHeadAccessDatabase interface {
 	SaveRegistrationsByValidatorIDs(ctx context.Context, ids []primitives.ValidatorIndex, regs []*ethpb.ValidatorRegistrationV1) error
 
 	CleanUpDirtyStates(ctx context.Context, slotsPerArchivedPoint primitives.Slot) error
+	DeleteHistoricalDataBeforeSlot(ctx context.Context, cutoff primitives.Slot, maxSlots int) (primitives.Slot, error)
+	// PulseChain reward burn.
+	SaveBlockBurn(ctx context.Context, slot primitives.Slot, blockRoot [32]byte, burns []*pulse.EpochBurn) error
+	AggregateFinalizedBurn(ctx context.Context) ([]*pulse.EpochBurn, error)
+	// Validator balance and status history.
+	SaveValidatorHistory(ctx context.Context, snapshot *precompute.EpochSnapshot) error
+	DeleteValidatorHistoryBefore(ctx context.Context, epoch primitives.Epoch) error
+	// Validator monitor.
+	SaveValidatorPerformance(ctx context.Context, records []*montypes.ValidatorPerformance) error
+	SaveMonitoredValidators(ctx context.Context, indices []primitives.ValidatorIndex) error
+	DeleteMonitoredValidators(ctx context.Context, indices []primitives.ValidatorIndex) error
+	// Light client data.
+	SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdate) error
+	SaveLightClientBootstrap(ctx context.Context, blockRoot [32]byte, bootstrap *ethpbv2.LightClientBootstrap) error
 }
 
 // HeadAccessDatabase defines a struct with access to reading chain head data.
@@ -108,6 +145,10 @@ type HeadAccessDatabase interface {
 	// initialization method needed for origin checkpoint sync
 	SaveOrigin(ctx context.Context, serState, serBlock []byte) error
 	SaveBackfillBlockRoot(ctx context.Context, blockRoot [32]byte) error
+	BackfillFinalizedIndex(ctx context.Context, blocks []interfaces.ReadOnlySignedBeaconBlock, finalizedChildRoot [32]byte) error
+
+	// Era file import.
+	SaveLastImportedEra(ctx context.Context, era uint64) error
 }
 
 // SlasherDatabase interface for persisting data related to detecting slashable offenses on Ethereum.
@@ -126,6 +167,9 @@ type SlasherDatabase interface {
 	SaveBlockProposals(
 		ctx context.Context, proposal []*slashertypes.SignedBlockHeaderWrapper,
 	) error
+	SaveDetectedSlashings(
+		ctx context.Context, slashings []*slashertypes.DetectedSlashing,
+	) error
 	LastEpochWrittenForValidators(
 		ctx context.Context, validatorIndices []primitives.ValidatorIndex,
 	) ([]*slashertypes.AttestedEpochForValidator, error)
@@ -150,10 +194,16 @@ type SlasherDatabase interface {
 	PruneProposalsAtEpoch(
 		ctx context.Context, maxEpoch primitives.Epoch,
 	) (numPruned uint, err error)
+	PruneDetectedSlashingsAtEpoch(
+		ctx context.Context, maxEpoch primitives.Epoch,
+	) (numPruned uint, err error)
 	HighestAttestations(
 		ctx context.Context,
 		indices []primitives.ValidatorIndex,
 	) ([]*ethpb.HighestAttestation, error)
+	DetectedSlashings(
+		ctx context.Context, pageToken []byte, limit int, filter func(*slashertypes.DetectedSlashing) bool,
+	) ([]*slashertypes.DetectedSlashing, []byte, error)
 	DatabasePath() string
 	ClearDB() error
 }
@@ -0,0 +1,193 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// burnTotalsSize is the size of the encoded pre-burn and post-burn totals of every burn source.
+var burnTotalsSize = 16 * len(pulse.EpochBurn{}.Sources)
+
+// SaveBlockBurn records the burn applied to rewards while processing the block with the given root, until it is
+// known whether the block is part of the finalized chain. The records are keyed by slot, so that the records of
+// blocks which were not finalized can be found and deleted by AggregateFinalizedBurn.
+func (s *Store) SaveBlockBurn(ctx context.Context, slot primitives.Slot, blockRoot [32]byte, burns []*pulse.EpochBurn) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveBlockBurn")
+	defer span.End()
+
+	if len(burns) == 0 {
+		return nil
+	}
+	enc := make([]byte, 0, len(burns)*(8+burnTotalsSize))
+	for _, b := range burns {
+		enc = append(enc, bytesutil.EpochToBytesBigEndian(b.Epoch)...)
+		enc = append(enc, marshalBurnTotals(b)...)
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(blockBurnBucket).Put(blockBurnKey(slot, blockRoot), enc)
+	})
+}
+
+// AggregateFinalizedBurn adds the block burn records of the canonical blocks up to the finalized checkpoint to
+// the per epoch burn totals, and deletes all the block burn records up to the finalized checkpoint. It returns the
+// burn which was added to the totals, by epoch.
+func (s *Store) AggregateFinalizedBurn(ctx context.Context) ([]*pulse.EpochBurn, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.AggregateFinalizedBurn")
+	defer span.End()
+
+	var added []*pulse.EpochBurn
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(checkpointBucket).Get(finalizedCheckpointKey)
+		if enc == nil {
+			return nil
+		}
+		cp := &ethpb.Checkpoint{}
+		if err := decode(ctx, enc, cp); err != nil {
+			return err
+		}
+		enc = tx.Bucket(blocksBucket).Get(cp.Root)
+		if enc == nil {
+			return nil
+		}
+		fb, err := unmarshalBlock(ctx, enc)
+		if err != nil {
+			return err
+		}
+		end := blockBurnKey(fb.Block().Slot()+1, [32]byte{})
+
+		byEpoch := make(map[primitives.Epoch]*pulse.EpochBurn)
+		finalized := tx.Bucket(finalizedBlockRootsIndexBucket)
+		bkt := tx.Bucket(blockBurnBucket)
+		var keys [][]byte
+		c := bkt.Cursor()
+		for k, v := c.First(); k != nil && bytes.Compare(k, end) < 0; k, v = c.Next() {
+			keys = append(keys, bytesutil.SafeCopyBytes(k))
+			// Blocks of the finalized epoch which are not ancestors of the finalized block are indexed
+			// with a placeholder container, they are not part of the canonical chain.
+			container := finalized.Get(k[8:])
+			if container == nil || bytes.Equal(container, containerFinalizedButNotCanonical) {
+				continue
+			}
+			burns, err := unmarshalBlockBurn(v)
+			if err != nil {
+				return errors.Wrapf(err, "corrupt block burn record for root=%#x", k[8:])
+			}
+			for _, b := range burns {
+				e, ok := byEpoch[b.Epoch]
+				if !ok {
+					e = &pulse.EpochBurn{Epoch: b.Epoch}
+					byEpoch[b.Epoch] = e
+				}
+				e.Add(b)
+			}
+		}
+		// Modifying a bucket while iterating over it with a cursor is not supported by bolt.
+		for _, k := range keys {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+
+		epochs := tx.Bucket(epochBurnBucket)
+		for epoch, b := range byEpoch {
+			key := bytesutil.EpochToBytesBigEndian(epoch)
+			total := &pulse.EpochBurn{Epoch: epoch}
+			if enc := epochs.Get(key); enc != nil {
+				if err := unmarshalBurnTotals(enc, total); err != nil {
+					return errors.Wrapf(err, "corrupt burn totals for epoch %d", epoch)
+				}
+			}
+			total.Add(b)
+			if err := epochs.Put(key, marshalBurnTotals(total)); err != nil {
+				return err
+			}
+			added = append(added, b)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	sort.Slice(added, func(i, j int) bool {
+		return added[i].Epoch < added[j].Epoch
+	})
+	return added, nil
+}
+
+// EpochBurns returns the burn totals of the finalized epochs in the range [start, end]. Epochs for which no burn
+// was recorded are omitted.
+func (s *Store) EpochBurns(ctx context.Context, start, end primitives.Epoch) ([]*pulse.EpochBurn, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.EpochBurns")
+	defer span.End()
+
+	if end < start {
+		return nil, errors.Errorf("end epoch %d is lower than start epoch %d", end, start)
+	}
+	var burns []*pulse.EpochBurn
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(epochBurnBucket).Cursor()
+		for k, v := c.Seek(bytesutil.EpochToBytesBigEndian(start)); k != nil; k, v = c.Next() {
+			epoch := bytesutil.BytesToEpochBigEndian(k)
+			if epoch > end {
+				break
+			}
+			b := &pulse.EpochBurn{Epoch: epoch}
+			if err := unmarshalBurnTotals(v, b); err != nil {
+				return errors.Wrapf(err, "corrupt burn totals for epoch %d", epoch)
+			}
+			burns = append(burns, b)
+		}
+		return nil
+	})
+	return burns, err
+}
+
+func blockBurnKey(slot primitives.Slot, root [32]byte) []byte {
+	return append(bytesutil.SlotToBytesBigEndian(slot), root[:]...)
+}
+
+func marshalBurnTotals(b *pulse.EpochBurn) []byte {
+	enc := make([]byte, burnTotalsSize)
+	for i, t := range b.Sources {
+		binary.BigEndian.PutUint64(enc[16*i:], t.PreBurn)
+		binary.BigEndian.PutUint64(enc[16*i+8:], t.PostBurn)
+	}
+	return enc
+}
+
+func unmarshalBurnTotals(enc []byte, b *pulse.EpochBurn) error {
+	if len(enc) != burnTotalsSize {
+		return errors.Errorf("unexpected length %d, expected %d", len(enc), burnTotalsSize)
+	}
+	for i := range b.Sources {
+		b.Sources[i].PreBurn = binary.BigEndian.Uint64(enc[16*i:])
+		b.Sources[i].PostBurn = binary.BigEndian.Uint64(enc[16*i+8:])
+	}
+	return nil
+}
+
+func unmarshalBlockBurn(enc []byte) ([]*pulse.EpochBurn, error) {
+	size := 8 + burnTotalsSize
+	if len(enc)%size != 0 {
+		return nil, errors.Errorf("unexpected length %d, not a multiple of %d", len(enc), size)
+	}
+	burns := make([]*pulse.EpochBurn, 0, len(enc)/size)
+	for i := 0; i < len(enc); i += size {
+		b := &pulse.EpochBurn{Epoch: bytesutil.BytesToEpochBigEndian(enc[i : i+8])}
+		if err := unmarshalBurnTotals(enc[i+8:i+size], b); err != nil {
+			return nil, err
+		}
+		burns = append(burns, b)
+	}
+	return burns, nil
+}
@@ -0,0 +1,79 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func epochBurn(epoch primitives.Epoch, source pulse.BurnSource, preBurn, postBurn uint64) *pulse.EpochBurn {
+	b := &pulse.EpochBurn{Epoch: epoch}
+	b.Sources[source] = pulse.BurnTotals{PreBurn: preBurn, PostBurn: postBurn}
+	return b
+}
+
+func TestStore_AggregateFinalizedBurn(t *testing.T) {
+	ctx := context.Background()
+	db, roots := setupPruningDB(t, 64, 32)
+
+	// A block at slot 5 which is not part of the canonical chain.
+	fork := util.NewBeaconBlock()
+	fork.Block.Slot = 5
+	fork.Block.ParentRoot = roots[4][:]
+	fork.Block.Body.Graffiti = bytesutil.PadTo([]byte("fork"), 32)
+	forkBlock, err := blocks.NewSignedBeaconBlock(fork)
+	require.NoError(t, err)
+	forkRoot, err := fork.Block.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, forkBlock))
+
+	require.NoError(t, db.SaveBlockBurn(ctx, 3, roots[3], []*pulse.EpochBurn{epochBurn(0, pulse.ProposerBurn, 40, 30)}))
+	require.NoError(t, db.SaveBlockBurn(ctx, 5, forkRoot, []*pulse.EpochBurn{epochBurn(0, pulse.ProposerBurn, 400, 300)}))
+	require.NoError(t, db.SaveBlockBurn(ctx, 32, roots[32], []*pulse.EpochBurn{
+		epochBurn(0, pulse.AttestationBurn, 1000, 750),
+		epochBurn(1, pulse.SyncCommitteeBurn, 8, 6),
+	}))
+	require.NoError(t, db.SaveBlockBurn(ctx, 40, roots[40], []*pulse.EpochBurn{epochBurn(1, pulse.ProposerBurn, 20, 15)}))
+
+	// Nothing is aggregated before finalization.
+	added, err := db.AggregateFinalizedBurn(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(added))
+
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[32][:]}))
+	added, err = db.AggregateFinalizedBurn(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(added))
+	require.Equal(t, pulse.BurnTotals{PreBurn: 1040, PostBurn: 780}, added[0].Total())
+
+	burns, err := db.EpochBurns(ctx, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(burns))
+	require.Equal(t, primitives.Epoch(0), burns[0].Epoch)
+	require.Equal(t, pulse.BurnTotals{PreBurn: 40, PostBurn: 30}, burns[0].Source(pulse.ProposerBurn))
+	require.Equal(t, pulse.BurnTotals{PreBurn: 1000, PostBurn: 750}, burns[0].Source(pulse.AttestationBurn))
+	require.Equal(t, pulse.BurnTotals{PreBurn: 8, PostBurn: 6}, burns[1].Total())
+
+	// Aggregating again does not count the same blocks twice.
+	added, err = db.AggregateFinalizedBurn(ctx)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(added))
+
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: roots[64][:]}))
+	_, err = db.AggregateFinalizedBurn(ctx)
+	require.NoError(t, err)
+	burns, err = db.EpochBurns(ctx, 1, 1)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(burns))
+	require.Equal(t, pulse.BurnTotals{PreBurn: 28, PostBurn: 21}, burns[0].Total())
+
+	_, err = db.EpochBurns(ctx, 2, 1)
+	require.ErrorContains(t, "lower than start epoch", err)
+}
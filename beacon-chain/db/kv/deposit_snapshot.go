@@ -0,0 +1,50 @@
+package kv
+
+import (
+	"context"
+	"errors"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
+	"github.com/prysmaticlabs/prysm/v4/monitoring/tracing"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveDepositSnapshot saves the finalized EIP-4881 deposit tree snapshot.
+func (s *Store) SaveDepositSnapshot(ctx context.Context, snapshot *depositsnapshot.DepositTreeSnapshot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveDepositSnapshot")
+	defer span.End()
+
+	if snapshot == nil {
+		err := errors.New("cannot save nil deposit snapshot")
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	enc, err := snapshot.MarshalSSZ()
+	if err != nil {
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(powchainBucket).Put(depositSnapshotKey, enc)
+	})
+	tracing.AnnotateError(span, err)
+	return err
+}
+
+// DepositSnapshot retrieves the finalized deposit tree snapshot, or nil if none has been saved.
+func (s *Store) DepositSnapshot(ctx context.Context) (*depositsnapshot.DepositTreeSnapshot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DepositSnapshot")
+	defer span.End()
+
+	var snapshot *depositsnapshot.DepositTreeSnapshot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(powchainBucket).Get(depositSnapshotKey)
+		if len(enc) == 0 {
+			return nil
+		}
+		snapshot = &depositsnapshot.DepositTreeSnapshot{}
+		return snapshot.UnmarshalSSZ(enc)
+	})
+	return snapshot, err
+}
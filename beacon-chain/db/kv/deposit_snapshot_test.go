@@ -0,0 +1,40 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_DepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	snapshot, err := db.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, true, snapshot == nil)
+	require.ErrorContains(t, "cannot save nil deposit snapshot", db.SaveDepositSnapshot(ctx, nil))
+
+	tree := depositsnapshot.New()
+	for i := 0; i < 5; i++ {
+		require.NoError(t, tree.Insert(depositSnapshotLeaf(byte(i+1)), i))
+	}
+	require.NoError(t, tree.Finalize(3, common.Hash{'a'}, 100))
+	want, err := tree.GetSnapshot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveDepositSnapshot(ctx, &want))
+
+	snapshot, err = db.DepositSnapshot(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, &want, snapshot)
+}
+
+func depositSnapshotLeaf(b byte) []byte {
+	leaf := make([]byte, 32)
+	leaf[31] = b
+	return leaf
+}
@@ -0,0 +1,36 @@
+package kv
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// LastImportedEra returns the highest era imported from era files, or zero if no era file was imported.
+func (s *Store) LastImportedEra(ctx context.Context) (uint64, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LastImportedEra")
+	defer span.End()
+
+	var era uint64
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(chainMetadataBucket).Get(lastImportedEraKey)
+		if len(enc) == 0 {
+			return nil
+		}
+		era = bytesutil.BytesToUint64BigEndian(enc)
+		return nil
+	})
+	return era, err
+}
+
+// SaveLastImportedEra saves the highest era imported from era files.
+func (s *Store) SaveLastImportedEra(ctx context.Context, era uint64) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveLastImportedEra")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(chainMetadataBucket).Put(lastImportedEraKey, bytesutil.Uint64ToBytesBigEndian(era))
+	})
+}
@@ -0,0 +1,22 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_LastImportedEra(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	era, err := db.LastImportedEra(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(0), era)
+
+	require.NoError(t, db.SaveLastImportedEra(ctx, 42))
+	era, err = db.LastImportedEra(ctx)
+	require.NoError(t, err)
+	require.Equal(t, uint64(42), era)
+}
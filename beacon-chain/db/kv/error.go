@@ -21,3 +21,7 @@ var ErrNotFoundBackfillBlockRoot = errors.Wrap(ErrNotFound, "BackfillBlockRoot")
 
 // ErrNotFoundFeeRecipient is a not found error specifically for the fee recipient getter
 var ErrNotFoundFeeRecipient = errors.Wrap(ErrNotFound, "fee recipient")
+
+// ErrStateDiffBase is raised when a state diff cannot be saved against the given base state, because the base
+// state is not stored in full or belongs to a different fork than the state.
+var ErrStateDiffBase = errors.New("invalid state diff base")
@@ -4,6 +4,7 @@ import (
 	"bytes"
 	"context"
 
+	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
@@ -22,6 +23,8 @@ var previousFinalizedCheckpointKey = []byte("previous-finalized-checkpoint")
 // in this index.
 var containerFinalizedButNotCanonical = []byte("recent block needs reindexing to determine canonical")
 
+var errNotConnectedToFinalized = errors.New("unable to finalize backfilled blocks, not connected to the finalized index")
+
 // The finalized block roots index tracks beacon blocks which are finalized in the canonical chain.
 // The finalized checkpoint contains the epoch which was finalized and the highest beacon block
 // root where block.slot <= start_slot(epoch). As a result, we cannot index the finalized canonical
@@ -163,6 +166,75 @@ func (s *Store) updateFinalizedBlockRoots(ctx context.Context, tx *bolt.Tx, chec
 	return bkt.Put(previousFinalizedCheckpointKey, enc)
 }
 
+// BackfillFinalizedIndex updates the finalized block roots index with a contiguous batch of backfilled
+// blocks. The blocks must be sorted by slot in ascending order, with each block being the parent of
+// the next one, and the last block being the parent of the already indexed finalizedChildRoot block.
+// Backfilled blocks are ancestors of the origin checkpoint block, so they are finalized and canonical.
+func (s *Store) BackfillFinalizedIndex(ctx context.Context, blks []interfaces.ReadOnlySignedBeaconBlock, finalizedChildRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.BackfillFinalizedIndex")
+	defer span.End()
+	if len(blks) == 0 {
+		return nil
+	}
+
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		if err := blocks.BeaconBlockIsNil(b); err != nil {
+			return err
+		}
+		r, err := b.Block().HashTreeRoot()
+		if err != nil {
+			return err
+		}
+		roots[i] = r
+	}
+	containers := make([][]byte, len(blks))
+	for i, b := range blks {
+		child := finalizedChildRoot
+		if i+1 < len(blks) {
+			child = roots[i+1]
+			if blks[i+1].Block().ParentRoot() != roots[i] {
+				return errors.Wrapf(errNotConnectedToFinalized, "block root=%#x is not the parent of block root=%#x", roots[i], child)
+			}
+		}
+		parentRoot := b.Block().ParentRoot()
+		enc, err := encode(ctx, &ethpb.FinalizedBlockRootContainer{
+			ParentRoot: parentRoot[:],
+			ChildRoot:  child[:],
+		})
+		if err != nil {
+			return err
+		}
+		containers[i] = enc
+	}
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(finalizedBlockRootsIndexBucket)
+		childEnc := bkt.Get(finalizedChildRoot[:])
+		if childEnc == nil {
+			return errors.Wrapf(errNotConnectedToFinalized, "finalized child root=%#x is not in the finalized index", finalizedChildRoot)
+		}
+		// The child container (the previous lowest indexed block) already records its parent root,
+		// which must be the highest block of this batch.
+		if !bytes.Equal(childEnc, containerFinalizedButNotCanonical) {
+			child := &ethpb.FinalizedBlockRootContainer{}
+			if err := decode(ctx, childEnc, child); err != nil {
+				return err
+			}
+			if !bytes.Equal(child.ParentRoot, roots[len(roots)-1][:]) {
+				return errors.Wrapf(errNotConnectedToFinalized, "block root=%#x is not the parent of finalized child root=%#x", roots[len(roots)-1], finalizedChildRoot)
+			}
+		}
+		for i := range roots {
+			if err := bkt.Put(roots[i][:], containers[i]); err != nil {
+				tracing.AnnotateError(span, err)
+				return err
+			}
+		}
+		return nil
+	})
+}
+
 // IsFinalizedBlock returns true if the block root is present in the finalized block root index.
 // A beacon block root contained exists in this index if it is considered finalized and canonical.
 // Note: beacon blocks from the latest finalized epoch return true, whether or not they are
@@ -193,6 +193,48 @@ func TestStore_IsFinalizedChildBlock(t *testing.T) {
 	})
 }
 
+func TestStore_BackfillFinalizedIndex(t *testing.T) {
+	slotsPerEpoch := uint64(params.BeaconConfig().SlotsPerEpoch)
+	db := setupDB(t)
+	ctx := context.Background()
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisBlockRoot))
+
+	blks := makeBlocks(t, 0, slotsPerEpoch*2+1, genesisBlockRoot)
+	origin := blks[len(blks)-1]
+	originRoot, err := origin.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, origin))
+	require.NoError(t, db.SaveOriginCheckpointBlockRoot(ctx, originRoot))
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, st, originRoot))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: originRoot[:]}))
+	for i := 0; i < len(blks)-1; i++ {
+		assert.Equal(t, false, db.IsFinalizedBlock(ctx, bytesutil.ToBytes32(sszRootOrDie(t, blks[i]))))
+	}
+
+	// A batch which is not the parent of the finalized child must be rejected.
+	require.ErrorIs(t, db.BackfillFinalizedIndex(ctx, blks[:slotsPerEpoch], originRoot), errNotConnectedToFinalized)
+	// A batch which is not contiguous must be rejected.
+	gapped := []interfaces.ReadOnlySignedBeaconBlock{blks[slotsPerEpoch], blks[2*slotsPerEpoch-1]}
+	require.ErrorIs(t, db.BackfillFinalizedIndex(ctx, gapped, originRoot), errNotConnectedToFinalized)
+
+	upper := blks[slotsPerEpoch : 2*slotsPerEpoch]
+	require.NoError(t, db.SaveBlocks(ctx, upper))
+	require.NoError(t, db.BackfillFinalizedIndex(ctx, upper, originRoot))
+	lower := blks[:slotsPerEpoch]
+	require.NoError(t, db.SaveBlocks(ctx, lower))
+	require.NoError(t, db.BackfillFinalizedIndex(ctx, lower, bytesutil.ToBytes32(sszRootOrDie(t, upper[0]))))
+
+	for i := 0; i < len(blks)-1; i++ {
+		root := bytesutil.ToBytes32(sszRootOrDie(t, blks[i]))
+		assert.Equal(t, true, db.IsFinalizedBlock(ctx, root), "Block at index %d was not considered finalized in the index", i)
+		child, err := db.FinalizedChildBlock(ctx, root)
+		require.NoError(t, err)
+		assert.DeepEqual(t, sszRootOrDie(t, blks[i+1]), sszRootOrDie(t, child))
+	}
+}
+
 func sszRootOrDie(t *testing.T, block interfaces.ReadOnlySignedBeaconBlock) []byte {
 	root, err := block.Block().HashTreeRoot()
 	require.NoError(t, err)
@@ -129,6 +129,19 @@ var Buckets = [][]byte{
 
 	feeRecipientBucket,
 	registrationBucket,
+
+	blockBurnBucket,
+	epochBurnBucket,
+
+	validatorHistoryBucket,
+
+	validatorPerformanceBucket,
+	monitoredValidatorsBucket,
+
+	lightClientUpdatesBucket,
+	lightClientBootstrapsBucket,
+
+	stateDiffBucket,
 }
 
 // NewKVStore initializes a new boltDB key-value store at the directory
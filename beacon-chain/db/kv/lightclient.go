@@ -0,0 +1,91 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// SaveLightClientUpdate saves the best light client update of a sync committee period, replacing any
+// update previously saved for the period.
+func (s *Store) SaveLightClientUpdate(ctx context.Context, period uint64, update *ethpbv2.LightClientUpdate) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientUpdate")
+	defer span.End()
+
+	enc, err := encode(ctx, update)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(lightClientUpdatesBucket).Put(periodKey(period), enc)
+	})
+}
+
+// LightClientUpdates returns the best light client updates of the sync committee periods in the range
+// [startPeriod, endPeriod]. Periods for which no update was saved are omitted.
+func (s *Store) LightClientUpdates(ctx context.Context, startPeriod, endPeriod uint64) ([]*ethpbv2.LightClientUpdate, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientUpdates")
+	defer span.End()
+
+	if endPeriod < startPeriod {
+		return nil, errors.Errorf("end period %d is lower than start period %d", endPeriod, startPeriod)
+	}
+	var updates []*ethpbv2.LightClientUpdate
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(lightClientUpdatesBucket).Cursor()
+		for k, v := c.Seek(periodKey(startPeriod)); k != nil; k, v = c.Next() {
+			if binary.BigEndian.Uint64(k) > endPeriod {
+				break
+			}
+			update := &ethpbv2.LightClientUpdate{}
+			if err := decode(ctx, v, update); err != nil {
+				return errors.Wrapf(err, "corrupt light client update for period %d", binary.BigEndian.Uint64(k))
+			}
+			updates = append(updates, update)
+		}
+		return nil
+	})
+	return updates, err
+}
+
+// SaveLightClientBootstrap saves the light client bootstrap of the block with the given root.
+func (s *Store) SaveLightClientBootstrap(ctx context.Context, blockRoot [32]byte, bootstrap *ethpbv2.LightClientBootstrap) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveLightClientBootstrap")
+	defer span.End()
+
+	enc, err := encode(ctx, bootstrap)
+	if err != nil {
+		return err
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(lightClientBootstrapsBucket).Put(blockRoot[:], enc)
+	})
+}
+
+// LightClientBootstrap returns the light client bootstrap of the block with the given root, or nil if
+// no bootstrap was saved for the block.
+func (s *Store) LightClientBootstrap(ctx context.Context, blockRoot [32]byte) (*ethpbv2.LightClientBootstrap, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.LightClientBootstrap")
+	defer span.End()
+
+	var bootstrap *ethpbv2.LightClientBootstrap
+	err := s.db.View(func(tx *bolt.Tx) error {
+		enc := tx.Bucket(lightClientBootstrapsBucket).Get(blockRoot[:])
+		if enc == nil {
+			return nil
+		}
+		bootstrap = &ethpbv2.LightClientBootstrap{}
+		return decode(ctx, enc, bootstrap)
+	})
+	return bootstrap, err
+}
+
+func periodKey(period uint64) []byte {
+	key := make([]byte, 8)
+	binary.BigEndian.PutUint64(key, period)
+	return key
+}
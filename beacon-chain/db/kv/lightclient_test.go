@@ -0,0 +1,98 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/go-bitfield"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func testLightClientHeader(slot primitives.Slot) *ethpbv1.BeaconBlockHeader {
+	return &ethpbv1.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   make([]byte, 32),
+	}
+}
+
+func testSyncCommittee() *ethpbv2.SyncCommittee {
+	pubkeys := make([][]byte, 512)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, 48)
+	}
+	return &ethpbv2.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: make([]byte, 48)}
+}
+
+func testBranch(length int) [][]byte {
+	branch := make([][]byte, length)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	return branch
+}
+
+func testLightClientUpdate(signatureSlot primitives.Slot) *ethpbv2.LightClientUpdate {
+	return &ethpbv2.LightClientUpdate{
+		AttestedHeader:          testLightClientHeader(signatureSlot - 1),
+		NextSyncCommittee:       testSyncCommittee(),
+		NextSyncCommitteeBranch: testBranch(5),
+		FinalizedHeader:         testLightClientHeader(0),
+		FinalityBranch:          testBranch(6),
+		SyncAggregate: &ethpbv1.SyncAggregate{
+			SyncCommitteeBits:      bitfield.NewBitvector512(),
+			SyncCommitteeSignature: make([]byte, 96),
+		},
+		SignatureSlot: signatureSlot,
+	}
+}
+
+func TestStore_LightClientUpdates(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	updates, err := db.LightClientUpdates(ctx, 0, 10)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(updates))
+
+	for _, period := range []uint64{1, 2, 4} {
+		require.NoError(t, db.SaveLightClientUpdate(ctx, period, testLightClientUpdate(primitives.Slot(period*100))))
+	}
+	// Saving an update again replaces the update of the period.
+	require.NoError(t, db.SaveLightClientUpdate(ctx, 2, testLightClientUpdate(250)))
+
+	updates, err = db.LightClientUpdates(ctx, 2, 4)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(updates))
+	assert.Equal(t, primitives.Slot(250), updates[0].SignatureSlot)
+	assert.Equal(t, primitives.Slot(400), updates[1].SignatureSlot)
+	assert.DeepSSZEqual(t, testLightClientUpdate(400), updates[1])
+
+	_, err = db.LightClientUpdates(ctx, 4, 2)
+	require.ErrorContains(t, "lower than start period", err)
+}
+
+func TestStore_LightClientBootstrap(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+	root := [32]byte{'a'}
+
+	bootstrap, err := db.LightClientBootstrap(ctx, root)
+	require.NoError(t, err)
+	assert.Equal(t, (*ethpbv2.LightClientBootstrap)(nil), bootstrap)
+
+	want := &ethpbv2.LightClientBootstrap{
+		Header:                     testLightClientHeader(32),
+		CurrentSyncCommittee:       testSyncCommittee(),
+		CurrentSyncCommitteeBranch: testBranch(5),
+	}
+	require.NoError(t, db.SaveLightClientBootstrap(ctx, root, want))
+	bootstrap, err = db.LightClientBootstrap(ctx, root)
+	require.NoError(t, err)
+	assert.DeepSSZEqual(t, want, bootstrap)
+}
@@ -0,0 +1,370 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// EarliestAvailableSlot returns the lowest slot above genesis for which blocks and states may still be
+// found in the database. It is zero unless history has been pruned with DeleteHistoricalDataBeforeSlot.
+func (s *Store) EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.EarliestAvailableSlot")
+	defer span.End()
+
+	var slot primitives.Slot
+	err := s.db.View(func(tx *bolt.Tx) error {
+		slot = earliestAvailableSlot(tx)
+		return nil
+	})
+	return slot, err
+}
+
+// DeleteHistoricalDataBeforeSlot deletes the blocks and states in the slot range (genesis, cutoff), along with
+// every record keyed by their slots or roots: slot, parent root, finalized and validator indices, state
+// summaries, the attestations indexed by the deleted blocks, the deprecated archived root index, block burn
+// records, light client bootstraps and state diffs. At most maxSlots slots are deleted in a single call, so that
+// pruning a large history does not hold the write lock for too long. The returned slot is the new earliest
+// available slot; callers should repeat the call until it stops changing.
+//
+// The cutoff must not be above the finalized checkpoint, and is lowered to the slot of the highest saved state
+// at or below it. The genesis, origin checkpoint, justified and finalized blocks and states are never deleted.
+// If the block pointed at by the backfill block root is deleted, the backfill block root is moved up to the
+// lowest remaining block so that it keeps referring to a block in the database.
+func (s *Store) DeleteHistoricalDataBeforeSlot(ctx context.Context, cutoff primitives.Slot, maxSlots int) (primitives.Slot, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.DeleteHistoricalDataBeforeSlot")
+	defer span.End()
+
+	if maxSlots <= 0 {
+		return 0, errors.New("maximum number of slots to prune must be positive")
+	}
+
+	var earliest primitives.Slot
+	var deletedRoots [][32]byte
+	err := s.db.Update(func(tx *bolt.Tx) error {
+		earliest = earliestAvailableSlot(tx)
+		// States of the remaining blocks are regenerated by replaying blocks on top of a saved state, so
+		// pruning stops at the highest saved state at or below the cutoff.
+		cutoff = highestStateSlotAtOrBelow(tx, cutoff)
+		if cutoff <= earliest {
+			return nil
+		}
+		protected, err := protectedRoots(ctx, tx)
+		if err != nil {
+			return err
+		}
+
+		// Find the upper bound of this batch, ie the slot after the last one of the first maxSlots block slots.
+		from := bytesutil.SlotToBytesBigEndian(params.BeaconConfig().GenesisSlot + 1)
+		upTo := cutoff
+		c := tx.Bucket(blockSlotIndicesBucket).Cursor()
+		n := 0
+		for k, _ := c.Seek(from); k != nil; k, _ = c.Next() {
+			slot := bytesutil.BytesToSlotBigEndian(k)
+			if slot >= cutoff {
+				break
+			}
+			if n == maxSlots {
+				upTo = slot
+				break
+			}
+			n++
+		}
+
+		// States are deleted first, as their slots are looked up from their blocks and summaries.
+		stateRoots, err := slotIndexRoots(tx.Bucket(stateSlotIndicesBucket), from, upTo, protected)
+		if err != nil {
+			return errors.Wrap(err, "could not read state slot index")
+		}
+		for _, root := range stateRoots {
+			if err := s.deleteState(ctx, tx, root); err != nil {
+				return errors.Wrapf(err, "could not delete state of root %#x", root)
+			}
+		}
+		// States may be indexed without a saved state, for instance when only a state diff was saved.
+		if _, err := pruneSlotIndex(tx.Bucket(stateSlotIndicesBucket), from, upTo, protected); err != nil {
+			return errors.Wrap(err, "could not prune state slot index")
+		}
+		blockRoots, err := pruneSlotIndex(tx.Bucket(blockSlotIndicesBucket), from, upTo, protected)
+		if err != nil {
+			return errors.Wrap(err, "could not prune block slot index")
+		}
+
+		for _, root := range blockRoots {
+			if err := pruneAttestations(ctx, tx, root); err != nil {
+				return err
+			}
+			for _, b := range [][]byte{blocksBucket, blockParentRootIndicesBucket, finalizedBlockRootsIndexBucket, lightClientBootstrapsBucket, stateDiffBucket} {
+				if err := tx.Bucket(b).Delete(root[:]); err != nil {
+					return err
+				}
+			}
+		}
+		deletedRoots = append(blockRoots, stateRoots...)
+		for _, root := range deletedRoots {
+			if err := tx.Bucket(stateSummaryBucket).Delete(root[:]); err != nil {
+				return err
+			}
+		}
+		if err := pruneBlockBurns(tx, from, upTo); err != nil {
+			return err
+		}
+		if err := pruneArchivedRoots(tx, from, upTo); err != nil {
+			return err
+		}
+		if err := pruneChildIndices(tx, protected); err != nil {
+			return err
+		}
+		if err := updateBackfillBlockRoot(tx, from); err != nil {
+			return err
+		}
+
+		earliest = upTo
+		return tx.Bucket(chainMetadataBucket).Put(earliestAvailableSlotKey, bytesutil.SlotToBytesBigEndian(earliest))
+	})
+	if err != nil {
+		return 0, err
+	}
+	// The summaries are deleted from the cache once the transaction succeeded, like in deleteStateSummary.
+	for _, root := range deletedRoots {
+		s.blockCache.Del(string(root[:]))
+		s.stateSummaryCache.delete(root)
+	}
+	return earliest, nil
+}
+
+func earliestAvailableSlot(tx *bolt.Tx) primitives.Slot {
+	enc := tx.Bucket(chainMetadataBucket).Get(earliestAvailableSlotKey)
+	if len(enc) == 0 {
+		return 0
+	}
+	return bytesutil.BytesToSlotBigEndian(enc)
+}
+
+func highestStateSlotAtOrBelow(tx *bolt.Tx, slot primitives.Slot) primitives.Slot {
+	key := bytesutil.SlotToBytesBigEndian(slot)
+	c := tx.Bucket(stateSlotIndicesBucket).Cursor()
+	k, _ := c.Seek(key)
+	if k == nil || !bytes.Equal(k, key) {
+		k, _ = c.Prev()
+	}
+	if k == nil {
+		return params.BeaconConfig().GenesisSlot
+	}
+	return bytesutil.BytesToSlotBigEndian(k)
+}
+
+// protectedRoots returns the roots of the blocks and states that must be kept regardless of their slot.
+func protectedRoots(ctx context.Context, tx *bolt.Tx) (map[[32]byte]bool, error) {
+	protected := make(map[[32]byte]bool)
+	for _, k := range [][]byte{genesisBlockRootKey, originCheckpointBlockRootKey} {
+		if r := tx.Bucket(blocksBucket).Get(k); len(r) == 32 {
+			protected[bytesutil.ToBytes32(r)] = true
+		}
+	}
+	for _, k := range [][]byte{justifiedCheckpointKey, finalizedCheckpointKey} {
+		enc := tx.Bucket(checkpointBucket).Get(k)
+		if enc == nil {
+			continue
+		}
+		cp := &ethpb.Checkpoint{}
+		if err := decode(ctx, enc, cp); err != nil {
+			return nil, err
+		}
+		protected[bytesutil.ToBytes32(cp.Root)] = true
+	}
+	return protected, nil
+}
+
+// pruneSlotIndex removes the entries of a slot index in the range [from, upTo), except for protected roots,
+// and returns the roots that were removed.
+func pruneSlotIndex(bkt *bolt.Bucket, from []byte, upTo primitives.Slot, protected map[[32]byte]bool) ([][32]byte, error) {
+	end := bytesutil.SlotToBytesBigEndian(upTo)
+	var pruned [][32]byte
+	var keep [][]byte
+	var keys [][]byte
+	c := bkt.Cursor()
+	for k, v := c.Seek(from); k != nil && bytes.Compare(k, end) < 0; k, v = c.Next() {
+		roots, err := splitRoots(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "corrupt value in slot index for slot=%d", bytesutil.BytesToSlotBigEndian(k))
+		}
+		var kept []byte
+		for _, r := range roots {
+			if protected[r] {
+				kept = append(kept, r[:]...)
+				continue
+			}
+			pruned = append(pruned, r)
+		}
+		keys = append(keys, bytesutil.SafeCopyBytes(k))
+		keep = append(keep, kept)
+	}
+	// Modifying a bucket while iterating over it with a cursor is not supported by bolt.
+	for i, k := range keys {
+		if len(keep[i]) > 0 {
+			if err := bkt.Put(k, keep[i]); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if err := bkt.Delete(k); err != nil {
+			return nil, err
+		}
+	}
+	return pruned, nil
+}
+
+// slotIndexRoots returns the roots of a slot index in the range [from, upTo), except for protected roots.
+func slotIndexRoots(bkt *bolt.Bucket, from []byte, upTo primitives.Slot, protected map[[32]byte]bool) ([][32]byte, error) {
+	end := bytesutil.SlotToBytesBigEndian(upTo)
+	var res [][32]byte
+	c := bkt.Cursor()
+	for k, v := c.Seek(from); k != nil && bytes.Compare(k, end) < 0; k, v = c.Next() {
+		roots, err := splitRoots(v)
+		if err != nil {
+			return nil, errors.Wrapf(err, "corrupt value in slot index for slot=%d", bytesutil.BytesToSlotBigEndian(k))
+		}
+		for _, r := range roots {
+			if !protected[r] {
+				res = append(res, r)
+			}
+		}
+	}
+	return res, nil
+}
+
+// pruneAttestations deletes the attestations indexed by the given head block root, and removes them from all
+// the attestation indices.
+func pruneAttestations(ctx context.Context, tx *bolt.Tx, blockRoot [32]byte) error {
+	attRoots, err := splitRoots(tx.Bucket(attestationHeadBlockRootBucket).Get(blockRoot[:]))
+	if err != nil {
+		return errors.Wrapf(err, "corrupt value in attestation head block root index for root=%#x", blockRoot)
+	}
+	atts := tx.Bucket(attestationsBucket)
+	for _, r := range attRoots {
+		if enc := atts.Get(r[:]); enc != nil {
+			att := &ethpb.Attestation{}
+			if err := decode(ctx, enc, att); err != nil {
+				return err
+			}
+			if att.Data != nil && att.Data.Source != nil && att.Data.Target != nil {
+				indices := map[string][]byte{
+					string(attestationSourceRootIndicesBucket):  att.Data.Source.Root,
+					string(attestationSourceEpochIndicesBucket): bytesutil.Bytes8(uint64(att.Data.Source.Epoch)),
+					string(attestationTargetRootIndicesBucket):  att.Data.Target.Root,
+					string(attestationTargetEpochIndicesBucket): bytesutil.Bytes8(uint64(att.Data.Target.Epoch)),
+				}
+				if err := deleteValueForIndices(ctx, indices, r[:], tx); err != nil {
+					return errors.Wrap(err, "could not delete attestation indices")
+				}
+			}
+		}
+		if err := atts.Delete(r[:]); err != nil {
+			return err
+		}
+	}
+	return tx.Bucket(attestationHeadBlockRootBucket).Delete(blockRoot[:])
+}
+
+// pruneBlockBurns deletes the block burn records in the slot range [from, upTo).
+func pruneBlockBurns(tx *bolt.Tx, from []byte, upTo primitives.Slot) error {
+	bkt := tx.Bucket(blockBurnBucket)
+	end := bytesutil.SlotToBytesBigEndian(upTo)
+	var keys [][]byte
+	c := bkt.Cursor()
+	for k, _ := c.Seek(from); k != nil && bytes.Compare(k[:8], end) < 0; k, _ = c.Next() {
+		keys = append(keys, bytesutil.SafeCopyBytes(k))
+	}
+	for _, k := range keys {
+		if err := bkt.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneArchivedRoots deletes the entries of the deprecated archived root index in the slot range [from, upTo).
+// The bucket only exists until the archived index migration has run.
+func pruneArchivedRoots(tx *bolt.Tx, from []byte, upTo primitives.Slot) error {
+	bkt := tx.Bucket(archivedRootBucket)
+	if bkt == nil {
+		return nil
+	}
+	start := bytesutil.BytesToSlotBigEndian(from)
+	var keys [][]byte
+	c := bkt.Cursor()
+	for k, _ := c.First(); k != nil; k, _ = c.Next() {
+		// The archived index is keyed by little endian slots, so it can not be sought by range.
+		if len(k) != 8 {
+			continue
+		}
+		slot := primitives.Slot(bytesutil.FromBytes8(k))
+		if slot >= start && slot < upTo {
+			keys = append(keys, bytesutil.SafeCopyBytes(k))
+		}
+	}
+	for _, k := range keys {
+		if err := bkt.Delete(k); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// pruneChildIndices removes deleted blocks from the parent root index of the protected blocks, which are the
+// only blocks left below the pruned range that can have children in it.
+func pruneChildIndices(tx *bolt.Tx, protected map[[32]byte]bool) error {
+	idx := tx.Bucket(blockParentRootIndicesBucket)
+	blks := tx.Bucket(blocksBucket)
+	for r := range protected {
+		children, err := splitRoots(idx.Get(r[:]))
+		if err != nil {
+			return errors.Wrapf(err, "corrupt value in parent root index for root=%#x", r)
+		}
+		if len(children) == 0 {
+			continue
+		}
+		var kept []byte
+		for _, child := range children {
+			if blks.Get(child[:]) != nil {
+				kept = append(kept, child[:]...)
+			}
+		}
+		if len(kept) == len(children)*32 {
+			continue
+		}
+		if len(kept) == 0 {
+			if err := idx.Delete(r[:]); err != nil {
+				return err
+			}
+			continue
+		}
+		if err := idx.Put(r[:], kept); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// updateBackfillBlockRoot moves the backfill block root to the lowest block above genesis left in the database
+// if the block it points at has been deleted.
+func updateBackfillBlockRoot(tx *bolt.Tx, from []byte) error {
+	bkt := tx.Bucket(blocksBucket)
+	bfRoot := bkt.Get(backfillBlockRootKey)
+	if len(bfRoot) == 0 || bkt.Get(bfRoot) != nil {
+		return nil
+	}
+	_, v := tx.Bucket(blockSlotIndicesBucket).Cursor().Seek(from)
+	if len(v) < 32 {
+		return nil
+	}
+	return bkt.Put(backfillBlockRootKey, bytesutil.SafeCopyBytes(v[:32]))
+}
@@ -0,0 +1,184 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+// setupPruningDB saves a chain of blocks for slots 0 to n, with state summaries for every block and
+// states at every multiple of stateInterval. It returns the block roots indexed by slot.
+func setupPruningDB(t *testing.T, n, stateInterval uint64) (*Store, [][32]byte) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	genesis, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	genesisRoot, err := genesis.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlock(ctx, genesis))
+	require.NoError(t, db.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	blks := append([]interfaces.ReadOnlySignedBeaconBlock{genesis}, makeBlocks(t, 0, n, genesisRoot)...)
+	require.NoError(t, db.SaveBlocks(ctx, blks[1:]))
+	roots := make([][32]byte, len(blks))
+	for i, b := range blks {
+		roots[i], err = b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.NoError(t, db.SaveStateSummary(ctx, &ethpb.StateSummary{Slot: b.Block().Slot(), Root: roots[i][:]}))
+		if uint64(i)%stateInterval == 0 {
+			st, err := util.NewBeaconState()
+			require.NoError(t, err)
+			require.NoError(t, st.SetSlot(b.Block().Slot()))
+			require.NoError(t, db.SaveState(ctx, st, roots[i]))
+		}
+	}
+	return db, roots
+}
+
+func TestStore_DeleteHistoricalDataBeforeSlot(t *testing.T) {
+	ctx := context.Background()
+	db, roots := setupPruningDB(t, 128, 32)
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 3, Root: roots[96][:]}))
+	require.NoError(t, db.SaveBackfillBlockRoot(ctx, roots[1]))
+
+	earliest, err := db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(0), earliest)
+
+	// The cutoff is lowered to the state saved at slot 64.
+	earliest, err = db.DeleteHistoricalDataBeforeSlot(ctx, 70, 1000)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(64), earliest)
+	stored, err := db.EarliestAvailableSlot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, earliest, stored)
+
+	for slot := 1; slot < 64; slot++ {
+		require.Equal(t, false, db.HasBlock(ctx, roots[slot]), "block at slot %d was not deleted", slot)
+		require.Equal(t, false, db.HasStateSummary(ctx, roots[slot]), "summary at slot %d was not deleted", slot)
+		_, found, err := db.BlockRootsBySlot(ctx, primitives.Slot(slot))
+		require.NoError(t, err)
+		require.Equal(t, 0, len(found))
+	}
+	require.Equal(t, false, db.HasState(ctx, roots[32]))
+	require.Equal(t, false, db.IsFinalizedBlock(ctx, roots[32]))
+	for slot := 64; slot <= 128; slot++ {
+		require.Equal(t, true, db.HasBlock(ctx, roots[slot]), "block at slot %d was deleted", slot)
+	}
+	require.Equal(t, true, db.HasState(ctx, roots[64]))
+	require.Equal(t, true, db.HasBlock(ctx, roots[0]))
+	require.Equal(t, true, db.HasState(ctx, roots[0]))
+
+	children, err := db.BlockRoots(ctx, filters.NewFilter().SetParentRoot(roots[0][:]))
+	require.NoError(t, err)
+	require.Equal(t, 0, len(children))
+
+	bfRoot, err := db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, roots[64], bfRoot)
+
+	// Pruning below the earliest available slot is a no-op.
+	earliest, err = db.DeleteHistoricalDataBeforeSlot(ctx, 40, 1000)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(64), earliest)
+}
+
+func TestStore_DeleteHistoricalDataBeforeSlot_Batches(t *testing.T) {
+	ctx := context.Background()
+	db, roots := setupPruningDB(t, 64, 32)
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: roots[64][:]}))
+
+	var earliest primitives.Slot
+	var calls int
+	for {
+		next, err := db.DeleteHistoricalDataBeforeSlot(ctx, 64, 10)
+		require.NoError(t, err)
+		if next == earliest {
+			break
+		}
+		earliest = next
+		calls++
+	}
+	require.Equal(t, primitives.Slot(64), earliest)
+	require.Equal(t, 7, calls)
+	require.Equal(t, false, db.HasBlock(ctx, roots[63]))
+	require.Equal(t, true, db.HasBlock(ctx, roots[64]))
+}
+
+func TestStore_DeleteHistoricalDataBeforeSlot_KeepsProtectedRoots(t *testing.T) {
+	ctx := context.Background()
+	db, roots := setupPruningDB(t, 96, 32)
+	require.NoError(t, db.SaveOriginCheckpointBlockRoot(ctx, roots[16]))
+	require.NoError(t, db.SaveJustifiedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[40][:]}))
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 1, Root: roots[40][:]}))
+
+	earliest, err := db.DeleteHistoricalDataBeforeSlot(ctx, 64, 1000)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(64), earliest)
+	require.Equal(t, true, db.HasBlock(ctx, roots[16]))
+	require.Equal(t, true, db.HasBlock(ctx, roots[40]))
+	require.Equal(t, false, db.HasBlock(ctx, roots[15]))
+	_, found, err := db.BlockRootsBySlot(ctx, 16)
+	require.NoError(t, err)
+	require.DeepEqual(t, [][32]byte{roots[16]}, found)
+
+	_, err = db.DeleteHistoricalDataBeforeSlot(ctx, 64, 0)
+	require.ErrorContains(t, "must be positive", err)
+}
+
+func TestStore_DeleteHistoricalDataBeforeSlot_PrunesRootAndSlotKeyedBuckets(t *testing.T) {
+	ctx := context.Background()
+	db, roots := setupPruningDB(t, 64, 32)
+	require.NoError(t, db.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: roots[64][:]}))
+
+	att := util.HydrateAttestation(&ethpb.Attestation{AggregationBits: []byte{0b11}, Data: &ethpb.AttestationData{BeaconBlockRoot: roots[10][:]}})
+	attRoot, err := att.Data.HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveBlockBurn(ctx, 10, roots[10], []*pulse.EpochBurn{{Epoch: 0}}))
+	require.NoError(t, db.db.Update(func(tx *bolt.Tx) error {
+		enc, err := encode(ctx, att)
+		if err != nil {
+			return err
+		}
+		if err := tx.Bucket(attestationsBucket).Put(attRoot[:], enc); err != nil {
+			return err
+		}
+		if err := tx.Bucket(attestationHeadBlockRootBucket).Put(roots[10][:], attRoot[:]); err != nil {
+			return err
+		}
+		if err := tx.Bucket(attestationTargetEpochIndicesBucket).Put(bytesutil.Bytes8(0), attRoot[:]); err != nil {
+			return err
+		}
+		bkt, err := tx.CreateBucketIfNotExists(archivedRootBucket)
+		if err != nil {
+			return err
+		}
+		return bkt.Put(bytesutil.Uint64ToBytesLittleEndian(10), roots[10][:])
+	}))
+
+	_, err = db.DeleteHistoricalDataBeforeSlot(ctx, 64, 1000)
+	require.NoError(t, err)
+
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		require.Equal(t, 0, len(tx.Bucket(attestationsBucket).Get(attRoot[:])))
+		require.Equal(t, 0, len(tx.Bucket(attestationHeadBlockRootBucket).Get(roots[10][:])))
+		require.Equal(t, 0, len(tx.Bucket(attestationTargetEpochIndicesBucket).Get(bytesutil.Bytes8(0))))
+		require.Equal(t, 0, len(tx.Bucket(archivedRootBucket).Get(bytesutil.Uint64ToBytesLittleEndian(10))))
+		k, _ := tx.Bucket(blockBurnBucket).Cursor().First()
+		require.Equal(t, 0, len(k))
+		return nil
+	}))
+	require.Equal(t, false, db.HasState(ctx, roots[32]))
+	require.Equal(t, false, db.HasStateSummary(ctx, roots[32]))
+}
@@ -21,6 +21,24 @@ var (
 	feeRecipientBucket      = []byte("fee-recipient")
 	registrationBucket      = []byte("registration")
 
+	// PulseChain reward burn accounting buckets.
+	blockBurnBucket = []byte("block-burn")
+	epochBurnBucket = []byte("epoch-burn")
+
+	// Validator balance and status history bucket.
+	validatorHistoryBucket = []byte("validator-history")
+
+	// Validator monitor buckets.
+	validatorPerformanceBucket = []byte("validator-performance")
+	monitoredValidatorsBucket  = []byte("monitored-validators")
+
+	// Light client buckets.
+	lightClientUpdatesBucket    = []byte("light-client-updates")
+	lightClientBootstrapsBucket = []byte("light-client-bootstraps")
+
+	// State diffs bucket.
+	stateDiffBucket = []byte("state-diffs")
+
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
 	slotsHasObjectBucket = []byte("slots-has-objects")
 	// Deprecated: This bucket was migrated in PR 6461. Do not use, except for migrations.
@@ -45,6 +63,7 @@ var (
 	justifiedCheckpointKey     = []byte("justified-checkpoint")
 	finalizedCheckpointKey     = []byte("finalized-checkpoint")
 	powchainDataKey            = []byte("powchain-data")
+	depositSnapshotKey         = []byte("deposit-snapshot")
 	lastValidatedCheckpointKey = []byte("last-validated-checkpoint")
 
 	// Below keys are used to identify objects are to be fork compatible.
@@ -59,6 +78,10 @@ var (
 	originCheckpointBlockRootKey = []byte("origin-checkpoint-block-root")
 	// block root tracking the progress of backfill, or pointing at genesis if backfill has not been initiated
 	backfillBlockRootKey = []byte("backfill-block-root")
+	// lowest slot above genesis for which blocks and states are kept, once history has been pruned
+	earliestAvailableSlotKey = []byte("earliest-available-slot")
+	// highest era imported from era files
+	lastImportedEraKey = []byte("last-imported-era")
 
 	// Deprecated: This index key was migrated in PR 6461. Do not use, except for migrations.
 	lastArchivedIndexKey = []byte("last-archived")
@@ -0,0 +1,468 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	statenative "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+)
+
+// A state diff stores a state as the fields which changed since a base state stored in full, so that the states in
+// between two archived points take a fraction of the space of a full state and can still be loaded without replaying
+// blocks. Diffs are always taken against a full state, never against another diff, so loading a state from a diff
+// reads a single full state. The encoding is the block root of the base state followed by one entry per changed
+// field of the state proto:
+//
+//	field number (uvarint) | operation (byte) | payload
+//
+// compressed with snappy.
+const (
+	// stateDiffReplace sets a singular field: value size (uvarint) | value.
+	stateDiffReplace byte = iota
+	// stateDiffClear resets a singular field to its zero value.
+	stateDiffClear
+	// stateDiffUint64List sets a list of uint64, such as the balances: list length (uvarint) | difference with the
+	// element of the base at the same index, or with 0 for appended elements, for each element (varint).
+	stateDiffUint64List
+	// stateDiffPatchList sets a list of bytes or messages, such as the validators or the randao mixes: list length
+	// (uvarint) | number of changed elements (uvarint) | index (uvarint), size (uvarint) and value of each changed or
+	// appended element, in increasing index order.
+	stateDiffPatchList
+)
+
+// SaveStateDiff stores a state as the difference with the state of the base block root, which must be stored in
+// full and be of the same fork as the state. The state can then be read with State like any other saved state.
+func (s *Store) SaveStateDiff(ctx context.Context, st state.ReadOnlyBeaconState, blockRoot, baseRoot [32]byte) error {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.SaveStateDiff")
+	defer span.End()
+	if st == nil || st.IsNil() {
+		return errors.New("nil state")
+	}
+	base, err := s.fullState(ctx, baseRoot)
+	if err != nil {
+		return err
+	}
+	if base == nil {
+		return errors.Wrapf(ErrStateDiffBase, "no full state with block root %#x", baseRoot)
+	}
+	if base.Version() != st.Version() {
+		return errors.Wrapf(ErrStateDiffBase, "base state is %s but state is %s", version.String(base.Version()), version.String(st.Version()))
+	}
+	basePb, ok := base.ToProtoUnsafe().(proto.Message)
+	if !ok {
+		return errors.New("non valid inner base state")
+	}
+	statePb, ok := st.ToProtoUnsafe().(proto.Message)
+	if !ok {
+		return errors.New("non valid inner state")
+	}
+	enc, err := encodeStateDiff(baseRoot, basePb, statePb)
+	if err != nil {
+		return errors.Wrap(err, "could not encode state diff")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(stateDiffBucket).Put(blockRoot[:], enc)
+	})
+}
+
+// fullState returns the state of a block root if it is stored in full, or nil.
+func (s *Store) fullState(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	enc, err := s.stateBytes(ctx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	valEntries, err := s.validatorEntries(ctx, blockRoot)
+	if err != nil {
+		return nil, err
+	}
+	return s.unmarshalState(ctx, enc, valEntries)
+}
+
+// stateFromDiff rebuilds the state of a block root stored as a diff, or returns nil if there is no diff for the block
+// root.
+func (s *Store) stateFromDiff(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error) {
+	ctx, span := trace.StartSpan(ctx, "BeaconDB.stateFromDiff")
+	defer span.End()
+	var enc []byte
+	if err := s.db.View(func(tx *bolt.Tx) error {
+		enc = bytesutil.SafeCopyBytes(tx.Bucket(stateDiffBucket).Get(blockRoot[:]))
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	if len(enc) == 0 {
+		return nil, nil
+	}
+	enc, err := snappy.Decode(nil, enc)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decompress state diff")
+	}
+	if len(enc) < 32 {
+		return nil, errors.New("state diff is too short")
+	}
+	baseRoot := bytesutil.ToBytes32(enc[:32])
+	base, err := s.fullState(ctx, baseRoot)
+	if err != nil {
+		return nil, err
+	}
+	if base == nil {
+		return nil, errors.Wrapf(ErrNotFoundState, "no base state with block root %#x for state diff", baseRoot)
+	}
+	pb, ok := base.ToProto().(proto.Message)
+	if !ok {
+		return nil, errors.New("non valid inner base state")
+	}
+	if err := applyStateDiff(pb, enc[32:]); err != nil {
+		return nil, errors.Wrapf(err, "could not apply state diff of block root %#x", blockRoot)
+	}
+	return initializeStateFromProto(pb)
+}
+
+// initializeStateFromProto returns the beacon state of the fork of a state proto.
+func initializeStateFromProto(pb proto.Message) (state.BeaconState, error) {
+	switch pbState := pb.(type) {
+	case *ethpb.BeaconState:
+		return statenative.InitializeFromProtoUnsafePhase0(pbState)
+	case *ethpb.BeaconStateAltair:
+		return statenative.InitializeFromProtoUnsafeAltair(pbState)
+	case *ethpb.BeaconStateBellatrix:
+		return statenative.InitializeFromProtoUnsafeBellatrix(pbState)
+	case *ethpb.BeaconStateCapella:
+		return statenative.InitializeFromProtoUnsafeCapella(pbState)
+	default:
+		return nil, errors.Errorf("unsupported state type %T", pb)
+	}
+}
+
+func encodeStateDiff(baseRoot [32]byte, base, target proto.Message) ([]byte, error) {
+	bm, tm := base.ProtoReflect(), target.ProtoReflect()
+	if bm.Descriptor() != tm.Descriptor() {
+		return nil, errors.Errorf("base state is %s but state is %s", bm.Descriptor().FullName(), tm.Descriptor().FullName())
+	}
+	enc := append([]byte{}, baseRoot[:]...)
+	fields := tm.Descriptor().Fields()
+	for i := 0; i < fields.Len(); i++ {
+		var err error
+		enc, err = appendFieldDiff(enc, fields.Get(i), bm, tm)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return snappy.Encode(nil, enc), nil
+}
+
+func appendFieldDiff(enc []byte, fd protoreflect.FieldDescriptor, base, target protoreflect.Message) ([]byte, error) {
+	if fd.IsMap() {
+		return nil, errors.Errorf("unsupported map field %s", fd.FullName())
+	}
+	if fd.IsList() {
+		if fd.Kind() == protoreflect.Uint64Kind {
+			return appendUint64ListDiff(enc, fd, base.Get(fd).List(), target.Get(fd).List()), nil
+		}
+		return appendPatchListDiff(enc, fd, base.Get(fd).List(), target.Get(fd).List())
+	}
+	if !target.Has(fd) {
+		if base.Has(fd) {
+			enc = appendFieldDiffHeader(enc, fd, stateDiffClear)
+		}
+		return enc, nil
+	}
+	if base.Has(fd) && fieldValuesEqual(fd, base.Get(fd), target.Get(fd)) {
+		return enc, nil
+	}
+	value, err := marshalFieldValue(fd, target.Get(fd))
+	if err != nil {
+		return nil, err
+	}
+	enc = appendFieldDiffHeader(enc, fd, stateDiffReplace)
+	enc = binary.AppendUvarint(enc, uint64(len(value)))
+	return append(enc, value...), nil
+}
+
+func appendUint64ListDiff(enc []byte, fd protoreflect.FieldDescriptor, base, target protoreflect.List) []byte {
+	if base.Len() == target.Len() {
+		equal := true
+		for i := 0; i < target.Len() && equal; i++ {
+			equal = base.Get(i).Uint() == target.Get(i).Uint()
+		}
+		if equal {
+			return enc
+		}
+	}
+	enc = appendFieldDiffHeader(enc, fd, stateDiffUint64List)
+	enc = binary.AppendUvarint(enc, uint64(target.Len()))
+	for i := 0; i < target.Len(); i++ {
+		var old uint64
+		if i < base.Len() {
+			old = base.Get(i).Uint()
+		}
+		enc = binary.AppendVarint(enc, int64(target.Get(i).Uint()-old))
+	}
+	return enc
+}
+
+func appendPatchListDiff(enc []byte, fd protoreflect.FieldDescriptor, base, target protoreflect.List) ([]byte, error) {
+	var changed []int
+	for i := 0; i < target.Len(); i++ {
+		if i >= base.Len() || !fieldValuesEqual(fd, base.Get(i), target.Get(i)) {
+			changed = append(changed, i)
+		}
+	}
+	if len(changed) == 0 && base.Len() == target.Len() {
+		return enc, nil
+	}
+	enc = appendFieldDiffHeader(enc, fd, stateDiffPatchList)
+	enc = binary.AppendUvarint(enc, uint64(target.Len()))
+	enc = binary.AppendUvarint(enc, uint64(len(changed)))
+	for _, i := range changed {
+		value, err := marshalFieldValue(fd, target.Get(i))
+		if err != nil {
+			return nil, err
+		}
+		enc = binary.AppendUvarint(enc, uint64(i))
+		enc = binary.AppendUvarint(enc, uint64(len(value)))
+		enc = append(enc, value...)
+	}
+	return enc, nil
+}
+
+func appendFieldDiffHeader(enc []byte, fd protoreflect.FieldDescriptor, op byte) []byte {
+	enc = binary.AppendUvarint(enc, uint64(fd.Number()))
+	return append(enc, op)
+}
+
+func fieldValuesEqual(fd protoreflect.FieldDescriptor, a, b protoreflect.Value) bool {
+	switch fd.Kind() {
+	case protoreflect.Uint64Kind:
+		return a.Uint() == b.Uint()
+	case protoreflect.BytesKind:
+		return bytes.Equal(a.Bytes(), b.Bytes())
+	case protoreflect.MessageKind:
+		am, bm := a.Message().Interface(), b.Message().Interface()
+		// States share most of their validators and other messages with their parent states, comparing the pointers
+		// first avoids walking through the messages which did not change.
+		return am == bm || proto.Equal(am, bm)
+	default:
+		return false
+	}
+}
+
+func marshalFieldValue(fd protoreflect.FieldDescriptor, v protoreflect.Value) ([]byte, error) {
+	switch fd.Kind() {
+	case protoreflect.Uint64Kind:
+		return binary.AppendUvarint(nil, v.Uint()), nil
+	case protoreflect.BytesKind:
+		return v.Bytes(), nil
+	case protoreflect.MessageKind:
+		return proto.MarshalOptions{Deterministic: true}.Marshal(v.Message().Interface())
+	default:
+		return nil, errors.Errorf("unsupported kind %s of field %s", fd.Kind(), fd.FullName())
+	}
+}
+
+// unmarshalFieldValue decodes a value encoded by marshalFieldValue. The zero value of the field, or of the list
+// element, is used to create the messages.
+func unmarshalFieldValue(fd protoreflect.FieldDescriptor, enc []byte, zero protoreflect.Value) (protoreflect.Value, error) {
+	switch fd.Kind() {
+	case protoreflect.Uint64Kind:
+		v, n := binary.Uvarint(enc)
+		if n <= 0 || n != len(enc) {
+			return protoreflect.Value{}, errors.Errorf("invalid value of field %s", fd.FullName())
+		}
+		return protoreflect.ValueOfUint64(v), nil
+	case protoreflect.BytesKind:
+		return protoreflect.ValueOfBytes(bytesutil.SafeCopyBytes(enc)), nil
+	case protoreflect.MessageKind:
+		m := zero.Message()
+		if err := proto.Unmarshal(enc, m.Interface()); err != nil {
+			return protoreflect.Value{}, errors.Wrapf(err, "invalid value of field %s", fd.FullName())
+		}
+		return protoreflect.ValueOfMessage(m), nil
+	default:
+		return protoreflect.Value{}, errors.Errorf("unsupported kind %s of field %s", fd.Kind(), fd.FullName())
+	}
+}
+
+// applyStateDiff applies the field changes of a state diff, without the base block root, to a copy of its base state.
+func applyStateDiff(pb proto.Message, enc []byte) error {
+	m := pb.ProtoReflect()
+	fields := m.Descriptor().Fields()
+	r := &stateDiffReader{buf: enc}
+	for len(r.buf) > 0 {
+		num := r.uvarint()
+		op := r.byte()
+		if r.err != nil {
+			return r.err
+		}
+		fd := fields.ByNumber(protoreflect.FieldNumber(num))
+		if fd == nil {
+			return errors.Errorf("unknown field number %d", num)
+		}
+		if fd.IsList() != (op == stateDiffUint64List || op == stateDiffPatchList) {
+			return errors.Errorf("invalid operation %d for field %s", op, fd.FullName())
+		}
+		var err error
+		switch op {
+		case stateDiffReplace:
+			var v protoreflect.Value
+			v, err = unmarshalFieldValue(fd, r.bytes(), m.NewField(fd))
+			if r.err != nil {
+				return r.err
+			}
+			if err == nil {
+				m.Set(fd, v)
+			}
+		case stateDiffClear:
+			m.Clear(fd)
+		case stateDiffUint64List:
+			err = r.applyUint64List(m.Mutable(fd).List())
+		case stateDiffPatchList:
+			err = r.applyPatchList(fd, m.Mutable(fd).List())
+		default:
+			return errors.Errorf("unknown operation %d for field %s", op, fd.FullName())
+		}
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// stateDiffReader reads the values of a state diff, and keeps the first decoding error.
+type stateDiffReader struct {
+	buf []byte
+	err error
+}
+
+func (r *stateDiffReader) fail() {
+	if r.err == nil {
+		r.err = errors.New("invalid state diff encoding")
+	}
+	r.buf = nil
+}
+
+func (r *stateDiffReader) uvarint() uint64 {
+	v, n := binary.Uvarint(r.buf)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *stateDiffReader) varint() int64 {
+	v, n := binary.Varint(r.buf)
+	if n <= 0 {
+		r.fail()
+		return 0
+	}
+	r.buf = r.buf[n:]
+	return v
+}
+
+func (r *stateDiffReader) byte() byte {
+	if len(r.buf) == 0 {
+		r.fail()
+		return 0
+	}
+	b := r.buf[0]
+	r.buf = r.buf[1:]
+	return b
+}
+
+// bytes reads a value prefixed by its size.
+func (r *stateDiffReader) bytes() []byte {
+	size := r.uvarint()
+	if size > uint64(len(r.buf)) {
+		r.fail()
+		return nil
+	}
+	b := r.buf[:size]
+	r.buf = r.buf[size:]
+	return b
+}
+
+// length reads the length of a list of which every element takes at least one byte of the remaining buffer.
+func (r *stateDiffReader) length() int {
+	n := r.uvarint()
+	if n > uint64(len(r.buf)) {
+		r.fail()
+		return 0
+	}
+	return int(n)
+}
+
+func (r *stateDiffReader) applyUint64List(l protoreflect.List) error {
+	n := r.length()
+	values := make([]uint64, n)
+	for i := range values {
+		var old uint64
+		if i < l.Len() {
+			old = l.Get(i).Uint()
+		}
+		values[i] = old + uint64(r.varint())
+	}
+	if r.err != nil {
+		return r.err
+	}
+	if n < l.Len() {
+		l.Truncate(n)
+	}
+	for i, v := range values {
+		if i < l.Len() {
+			l.Set(i, protoreflect.ValueOfUint64(v))
+		} else {
+			l.Append(protoreflect.ValueOfUint64(v))
+		}
+	}
+	return nil
+}
+
+func (r *stateDiffReader) applyPatchList(fd protoreflect.FieldDescriptor, l protoreflect.List) error {
+	n := int(r.uvarint())
+	count := r.length()
+	if r.err != nil {
+		return r.err
+	}
+	if n < l.Len() {
+		l.Truncate(n)
+	}
+	for j := 0; j < count; j++ {
+		i := r.uvarint()
+		enc := r.bytes()
+		if r.err != nil {
+			return r.err
+		}
+		v, err := unmarshalFieldValue(fd, enc, l.NewElement())
+		if err != nil {
+			return err
+		}
+		switch {
+		case i < uint64(l.Len()):
+			l.Set(int(i), v)
+		case i == uint64(l.Len()):
+			l.Append(v)
+		default:
+			return errors.Errorf("invalid index %d of field %s", i, fd.FullName())
+		}
+	}
+	if l.Len() != n {
+		return errors.Errorf("invalid length %d of field %s", l.Len(), fd.FullName())
+	}
+	return nil
+}
@@ -0,0 +1,125 @@
+package kv
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	bolt "go.etcd.io/bbolt"
+)
+
+func TestStore_SaveStateDiff(t *testing.T) {
+	for _, historical := range []bool{false, true} {
+		t.Run(fmt.Sprintf("historical space representation %v", historical), func(t *testing.T) {
+			resetCfg := features.InitWithReset(&features.Flags{EnableHistoricalSpaceRepresentation: historical})
+			defer resetCfg()
+			db := setupDB(t)
+			ctx := context.Background()
+
+			base, err := util.NewBeaconStateCapella()
+			require.NoError(t, err)
+			require.NoError(t, base.SetSlot(2048))
+			require.NoError(t, base.SetValidators(validators(8)))
+			require.NoError(t, base.SetBalances([]uint64{32, 33, 34, 35, 36, 37, 38, 39}))
+			require.NoError(t, base.SetInactivityScores(make([]uint64, 8)))
+			require.NoError(t, base.SetEth1DepositIndex(5))
+			require.NoError(t, base.AppendEth1DataVotes(&ethpb.Eth1Data{DepositRoot: make([]byte, 32), BlockHash: make([]byte, 32)}))
+			baseRoot := [32]byte{'A'}
+			require.NoError(t, db.SaveState(ctx, base, baseRoot))
+
+			st := base.Copy()
+			require.NoError(t, st.SetSlot(2080))
+			require.NoError(t, st.SetBalances([]uint64{31, 33, 36, 35, 36, 37, 38, 39, 32}))
+			require.NoError(t, st.SetInactivityScores(make([]uint64, 9)))
+			newValidators := validators(1)
+			require.NoError(t, st.AppendValidator(newValidators[0]))
+			val, err := st.ValidatorAtIndex(2)
+			require.NoError(t, err)
+			val.ExitEpoch = 100
+			require.NoError(t, st.UpdateValidatorAtIndex(2, val))
+			require.NoError(t, st.UpdateRandaoMixesAtIndex(3, bytesutil.PadTo([]byte("mix"), 32)))
+			require.NoError(t, st.SetEth1DepositIndex(0))
+			require.NoError(t, st.SetEth1DataVotes(nil))
+			require.NoError(t, st.SetNextWithdrawalIndex(7))
+			root := [32]byte{'B'}
+
+			require.NoError(t, db.SaveStateDiff(ctx, st, root, baseRoot))
+			assert.Equal(t, true, db.HasState(ctx, root))
+			saved, err := db.State(ctx, root)
+			require.NoError(t, err)
+			require.DeepSSZEqual(t, st.ToProtoUnsafe(), saved.ToProtoUnsafe())
+
+			// The base state is not modified by loading the diff.
+			savedBase, err := db.State(ctx, baseRoot)
+			require.NoError(t, err)
+			require.DeepSSZEqual(t, base.ToProtoUnsafe(), savedBase.ToProtoUnsafe())
+
+			require.NoError(t, db.DeleteState(ctx, root))
+			assert.Equal(t, false, db.HasState(ctx, root))
+			saved, err = db.State(ctx, root)
+			require.NoError(t, err)
+			assert.Equal(t, state.ReadOnlyBeaconState(nil), saved)
+		})
+	}
+}
+
+func TestStore_SaveStateDiff_InvalidBase(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	st, err := util.NewBeaconStateCapella()
+	require.NoError(t, err)
+	err = db.SaveStateDiff(ctx, st, [32]byte{'B'}, [32]byte{'A'})
+	require.ErrorIs(t, err, ErrStateDiffBase)
+
+	base, err := util.NewBeaconStateBellatrix()
+	require.NoError(t, err)
+	require.NoError(t, db.SaveState(ctx, base, [32]byte{'A'}))
+	err = db.SaveStateDiff(ctx, st, [32]byte{'B'}, [32]byte{'A'})
+	require.ErrorIs(t, err, ErrStateDiffBase)
+
+	// A diff cannot be the base of another diff.
+	require.NoError(t, db.SaveStateDiff(ctx, base, [32]byte{'B'}, [32]byte{'A'}))
+	err = db.SaveStateDiff(ctx, base, [32]byte{'C'}, [32]byte{'B'})
+	require.ErrorIs(t, err, ErrStateDiffBase)
+}
+
+func TestStore_StateDiff_Smaller(t *testing.T) {
+	db := setupDB(t)
+	ctx := context.Background()
+
+	base, err := util.NewBeaconStateCapella()
+	require.NoError(t, err)
+	require.NoError(t, base.SetValidators(validators(1000)))
+	balances := make([]uint64, 1000)
+	for i := range balances {
+		balances[i] = params.BeaconConfig().MaxEffectiveBalance
+	}
+	require.NoError(t, base.SetBalances(balances))
+	require.NoError(t, db.SaveState(ctx, base, [32]byte{'A'}))
+
+	st := base.Copy()
+	newBalances := make([]uint64, len(balances))
+	for i := range balances {
+		newBalances[i] = balances[i] + uint64(i%100)
+	}
+	require.NoError(t, st.SetBalances(newBalances))
+	require.NoError(t, db.SaveStateDiff(ctx, st, [32]byte{'B'}, [32]byte{'A'}))
+
+	full, err := marshalState(ctx, st)
+	require.NoError(t, err)
+	root := [32]byte{'B'}
+	require.NoError(t, db.db.View(func(tx *bolt.Tx) error {
+		diff := tx.Bucket(stateDiffBucket).Get(root[:])
+		assert.Equal(t, true, len(diff) < len(full)/10, "diff of %d bytes for a state of %d bytes", len(diff), len(full))
+		return nil
+	}))
+}
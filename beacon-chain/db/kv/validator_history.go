@@ -0,0 +1,168 @@
+package kv
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// validatorHistoryChunkSize is the number of validators stored in a single validator history record, so that
+// querying a few validators does not require decoding the records of the whole registry.
+const validatorHistoryChunkSize = 4096
+
+// validatorHistoryRecordSize is the size of the balance, effective balance and status of a validator.
+const validatorHistoryRecordSize = 17
+
+// SaveValidatorHistory saves the snapshot of the validators at the start of an epoch. The snapshot is split in
+// chunks of validators keyed by epoch and chunk number, and each chunk stores the balances, the effective balances
+// and the statuses of its validators one after the other, compressed with snappy.
+func (s *Store) SaveValidatorHistory(ctx context.Context, snapshot *precompute.EpochSnapshot) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveValidatorHistory")
+	defer span.End()
+
+	n := len(snapshot.Balances)
+	if len(snapshot.EffectiveBalances) != n || len(snapshot.Statuses) != n {
+		return errors.New("validator history snapshot fields have different lengths")
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorHistoryBucket)
+		for start := 0; start < n; start += validatorHistoryChunkSize {
+			end := start + validatorHistoryChunkSize
+			if end > n {
+				end = n
+			}
+			size := end - start
+			enc := make([]byte, size*validatorHistoryRecordSize)
+			for i := 0; i < size; i++ {
+				binary.LittleEndian.PutUint64(enc[8*i:], snapshot.Balances[start+i])
+				binary.LittleEndian.PutUint64(enc[8*(size+i):], snapshot.EffectiveBalances[start+i])
+				enc[16*size+i] = byte(snapshot.Statuses[start+i])
+			}
+			key := validatorHistoryKey(snapshot.Epoch, uint64(start/validatorHistoryChunkSize))
+			if err := bkt.Put(key, snappy.Encode(nil, enc)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ValidatorHistory returns the records of the given validators for the epochs in the range [start, end], sorted by
+// epoch. Epochs without a snapshot, or at which a validator was not part of the registry yet, are omitted.
+func (s *Store) ValidatorHistory(
+	ctx context.Context, indices []primitives.ValidatorIndex, start, end primitives.Epoch,
+) (map[primitives.ValidatorIndex][]precompute.ValidatorRecord, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ValidatorHistory")
+	defer span.End()
+
+	if end < start {
+		return nil, errors.Errorf("end epoch %d is lower than start epoch %d", end, start)
+	}
+	byChunk := make(map[uint64][]primitives.ValidatorIndex)
+	for _, idx := range indices {
+		chunk := uint64(idx) / validatorHistoryChunkSize
+		byChunk[chunk] = append(byChunk[chunk], idx)
+	}
+	history := make(map[primitives.ValidatorIndex][]precompute.ValidatorRecord, len(indices))
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorHistoryBucket)
+		c := bkt.Cursor()
+		// Records are sorted by epoch, so the epochs with a snapshot are found by seeking the first chunk of each.
+		k, _ := c.Seek(validatorHistoryKey(start, 0))
+		for k != nil {
+			epoch := bytesutil.BytesToEpochBigEndian(k[:8])
+			if epoch > end {
+				break
+			}
+			for chunk, chunkIndices := range byChunk {
+				enc := bkt.Get(validatorHistoryKey(epoch, chunk))
+				if enc == nil {
+					continue
+				}
+				enc, err := snappy.Decode(nil, enc)
+				if err != nil {
+					return errors.Wrapf(err, "could not decode validator history of epoch %d", epoch)
+				}
+				if len(enc)%validatorHistoryRecordSize != 0 {
+					return errors.Errorf("corrupt validator history of epoch %d, unexpected length %d", epoch, len(enc))
+				}
+				size := uint64(len(enc) / validatorHistoryRecordSize)
+				for _, idx := range chunkIndices {
+					i := uint64(idx) % validatorHistoryChunkSize
+					if i >= size {
+						continue
+					}
+					history[idx] = append(history[idx], precompute.ValidatorRecord{
+						Epoch:            epoch,
+						Balance:          binary.LittleEndian.Uint64(enc[8*i:]),
+						EffectiveBalance: binary.LittleEndian.Uint64(enc[8*(size+i):]),
+						Status:           ethpb.ValidatorStatus(enc[16*size+i]),
+					})
+				}
+			}
+			if epoch == end {
+				break
+			}
+			k, _ = c.Seek(validatorHistoryKey(epoch+1, 0))
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return history, nil
+}
+
+// LastValidatorHistoryEpoch returns the highest epoch for which a snapshot of the validators was saved. It returns
+// ErrNotFound if no snapshot was saved.
+func (s *Store) LastValidatorHistoryEpoch(ctx context.Context) (primitives.Epoch, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.LastValidatorHistoryEpoch")
+	defer span.End()
+
+	var epoch primitives.Epoch
+	err := s.db.View(func(tx *bolt.Tx) error {
+		k, _ := tx.Bucket(validatorHistoryBucket).Cursor().Last()
+		if k == nil {
+			return errors.Wrap(ErrNotFound, "validator history")
+		}
+		epoch = bytesutil.BytesToEpochBigEndian(k[:8])
+		return nil
+	})
+	return epoch, err
+}
+
+// DeleteValidatorHistoryBefore deletes the snapshots of the validators of the epochs lower than the given epoch.
+func (s *Store) DeleteValidatorHistoryBefore(ctx context.Context, epoch primitives.Epoch) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteValidatorHistoryBefore")
+	defer span.End()
+
+	end := validatorHistoryKey(epoch, 0)
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorHistoryBucket)
+		var keys [][]byte
+		c := bkt.Cursor()
+		for k, _ := c.First(); k != nil && bytes.Compare(k, end) < 0; k, _ = c.Next() {
+			keys = append(keys, bytesutil.SafeCopyBytes(k))
+		}
+		// Modifying a bucket while iterating over it with a cursor is not supported by bolt.
+		for _, k := range keys {
+			if err := bkt.Delete(k); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func validatorHistoryKey(epoch primitives.Epoch, chunk uint64) []byte {
+	return append(bytesutil.EpochToBytesBigEndian(epoch), bytesutil.Uint64ToBytesBigEndian(chunk)...)
+}
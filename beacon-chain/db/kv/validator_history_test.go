@@ -0,0 +1,65 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func testSnapshot(epoch primitives.Epoch, n int) *precompute.EpochSnapshot {
+	s := &precompute.EpochSnapshot{
+		Epoch:             epoch,
+		Balances:          make([]uint64, n),
+		EffectiveBalances: make([]uint64, n),
+		Statuses:          make([]ethpb.ValidatorStatus, n),
+	}
+	for i := 0; i < n; i++ {
+		s.Balances[i] = uint64(epoch)*1000 + uint64(i)
+		s.EffectiveBalances[i] = uint64(i)
+		s.Statuses[i] = ethpb.ValidatorStatus_ACTIVE_ONGOING
+	}
+	return s
+}
+
+func TestStore_ValidatorHistory(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	_, err := db.LastValidatorHistoryEpoch(ctx)
+	require.ErrorIs(t, err, ErrNotFound)
+
+	// The registry grows across chunks between the snapshots.
+	require.NoError(t, db.SaveValidatorHistory(ctx, testSnapshot(3, validatorHistoryChunkSize)))
+	require.NoError(t, db.SaveValidatorHistory(ctx, testSnapshot(5, validatorHistoryChunkSize+10)))
+	require.NoError(t, db.SaveValidatorHistory(ctx, testSnapshot(6, validatorHistoryChunkSize+10)))
+	last, err := db.LastValidatorHistoryEpoch(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, primitives.Epoch(6), last)
+
+	newIdx := primitives.ValidatorIndex(validatorHistoryChunkSize + 5)
+	history, err := db.ValidatorHistory(ctx, []primitives.ValidatorIndex{1, newIdx}, 0, 5)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []precompute.ValidatorRecord{
+		{Epoch: 3, Balance: 3001, EffectiveBalance: 1, Status: ethpb.ValidatorStatus_ACTIVE_ONGOING},
+		{Epoch: 5, Balance: 5001, EffectiveBalance: 1, Status: ethpb.ValidatorStatus_ACTIVE_ONGOING},
+	}, history[1])
+	// The validator was not part of the registry at epoch 3.
+	require.Equal(t, 1, len(history[newIdx]))
+	assert.Equal(t, primitives.Epoch(5), history[newIdx][0].Epoch)
+	assert.Equal(t, uint64(5000)+uint64(newIdx), history[newIdx][0].Balance)
+
+	require.NoError(t, db.DeleteValidatorHistoryBefore(ctx, 5))
+	history, err = db.ValidatorHistory(ctx, []primitives.ValidatorIndex{1}, 0, 10)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(history[1]))
+	assert.Equal(t, primitives.Epoch(5), history[1][0].Epoch)
+	assert.Equal(t, primitives.Epoch(6), history[1][1].Epoch)
+
+	_, err = db.ValidatorHistory(ctx, []primitives.ValidatorIndex{1}, 2, 1)
+	require.ErrorContains(t, "lower than start epoch", err)
+}
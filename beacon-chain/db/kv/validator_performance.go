@@ -0,0 +1,188 @@
+package kv
+
+import (
+	"context"
+	"encoding/binary"
+
+	"github.com/pkg/errors"
+	montypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// validatorPerformanceSize is the size of an encoded performance record: eight little endian integers followed by
+// a byte of flags.
+const validatorPerformanceSize = 65
+
+const (
+	attestationIncludedFlag = 1 << iota
+	timelySourceFlag
+	timelyTargetFlag
+	timelyHeadFlag
+)
+
+// SaveValidatorPerformance saves the performance records of the validator monitor, keyed by validator index and
+// epoch. A record saved for a validator and an epoch overwrites any previous one.
+func (s *Store) SaveValidatorPerformance(ctx context.Context, records []*montypes.ValidatorPerformance) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveValidatorPerformance")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(validatorPerformanceBucket)
+		for _, r := range records {
+			if err := bkt.Put(validatorPerformanceKey(r.ValidatorIndex, r.Epoch), encodeValidatorPerformance(r)); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// ValidatorPerformance returns the performance records of the given validators for the epochs in the range
+// [start, end], sorted by epoch.
+func (s *Store) ValidatorPerformance(
+	ctx context.Context, indices []primitives.ValidatorIndex, start, end primitives.Epoch,
+) (map[primitives.ValidatorIndex][]*montypes.ValidatorPerformance, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.ValidatorPerformance")
+	defer span.End()
+
+	if end < start {
+		return nil, errors.Errorf("end epoch %d is lower than start epoch %d", end, start)
+	}
+	performance := make(map[primitives.ValidatorIndex][]*montypes.ValidatorPerformance, len(indices))
+	err := s.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(validatorPerformanceBucket).Cursor()
+		for _, idx := range indices {
+			if _, ok := performance[idx]; ok {
+				continue
+			}
+			records := make([]*montypes.ValidatorPerformance, 0)
+			for k, v := c.Seek(validatorPerformanceKey(idx, start)); k != nil; k, v = c.Next() {
+				if primitives.ValidatorIndex(binary.BigEndian.Uint64(k[:8])) != idx {
+					break
+				}
+				epoch := bytesutil.BytesToEpochBigEndian(k[8:])
+				if epoch > end {
+					break
+				}
+				r, err := decodeValidatorPerformance(idx, epoch, v)
+				if err != nil {
+					return err
+				}
+				records = append(records, r)
+			}
+			performance[idx] = records
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return performance, nil
+}
+
+// MonitoredValidators returns the indices of the validators added to the validator monitor at runtime, in
+// increasing order.
+func (s *Store) MonitoredValidators(ctx context.Context) ([]primitives.ValidatorIndex, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.MonitoredValidators")
+	defer span.End()
+
+	indices := make([]primitives.ValidatorIndex, 0)
+	err := s.db.View(func(tx *bolt.Tx) error {
+		return tx.Bucket(monitoredValidatorsBucket).ForEach(func(k, _ []byte) error {
+			indices = append(indices, primitives.ValidatorIndex(binary.BigEndian.Uint64(k)))
+			return nil
+		})
+	})
+	return indices, err
+}
+
+// SaveMonitoredValidators adds validators to the set of validators tracked by the validator monitor.
+func (s *Store) SaveMonitoredValidators(ctx context.Context, indices []primitives.ValidatorIndex) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveMonitoredValidators")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(monitoredValidatorsBucket)
+		for _, idx := range indices {
+			if err := bkt.Put(bytesutil.Uint64ToBytesBigEndian(uint64(idx)), []byte{}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteMonitoredValidators removes validators from the set of validators tracked by the validator monitor.
+func (s *Store) DeleteMonitoredValidators(ctx context.Context, indices []primitives.ValidatorIndex) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DeleteMonitoredValidators")
+	defer span.End()
+
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(monitoredValidatorsBucket)
+		for _, idx := range indices {
+			if err := bkt.Delete(bytesutil.Uint64ToBytesBigEndian(uint64(idx))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func validatorPerformanceKey(idx primitives.ValidatorIndex, epoch primitives.Epoch) []byte {
+	return append(bytesutil.Uint64ToBytesBigEndian(uint64(idx)), bytesutil.EpochToBytesBigEndian(epoch)...)
+}
+
+func encodeValidatorPerformance(r *montypes.ValidatorPerformance) []byte {
+	enc := make([]byte, validatorPerformanceSize)
+	for i, v := range []uint64{
+		r.StartBalance,
+		r.EndBalance,
+		uint64(r.AttestedSlot),
+		uint64(r.InclusionSlot),
+		r.ProposedBlocks,
+		r.Aggregations,
+		r.SyncCommitteeExpected,
+		r.SyncCommitteeContributions,
+	} {
+		binary.LittleEndian.PutUint64(enc[8*i:], v)
+	}
+	var flags byte
+	for flag, set := range map[byte]bool{
+		attestationIncludedFlag: r.AttestationIncluded,
+		timelySourceFlag:        r.TimelySource,
+		timelyTargetFlag:        r.TimelyTarget,
+		timelyHeadFlag:          r.TimelyHead,
+	} {
+		if set {
+			flags |= flag
+		}
+	}
+	enc[validatorPerformanceSize-1] = flags
+	return enc
+}
+
+func decodeValidatorPerformance(idx primitives.ValidatorIndex, epoch primitives.Epoch, enc []byte) (*montypes.ValidatorPerformance, error) {
+	if len(enc) != validatorPerformanceSize {
+		return nil, errors.Errorf("corrupt performance record of validator %d at epoch %d, unexpected length %d", idx, epoch, len(enc))
+	}
+	flags := enc[validatorPerformanceSize-1]
+	return &montypes.ValidatorPerformance{
+		ValidatorIndex:             idx,
+		Epoch:                      epoch,
+		StartBalance:               binary.LittleEndian.Uint64(enc[0:]),
+		EndBalance:                 binary.LittleEndian.Uint64(enc[8:]),
+		AttestedSlot:               primitives.Slot(binary.LittleEndian.Uint64(enc[16:])),
+		InclusionSlot:              primitives.Slot(binary.LittleEndian.Uint64(enc[24:])),
+		ProposedBlocks:             binary.LittleEndian.Uint64(enc[32:]),
+		Aggregations:               binary.LittleEndian.Uint64(enc[40:]),
+		SyncCommitteeExpected:      binary.LittleEndian.Uint64(enc[48:]),
+		SyncCommitteeContributions: binary.LittleEndian.Uint64(enc[56:]),
+		AttestationIncluded:        flags&attestationIncludedFlag != 0,
+		TimelySource:               flags&timelySourceFlag != 0,
+		TimelyTarget:               flags&timelyTargetFlag != 0,
+		TimelyHead:                 flags&timelyHeadFlag != 0,
+	}, nil
+}
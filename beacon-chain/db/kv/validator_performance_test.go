@@ -0,0 +1,68 @@
+package kv
+
+import (
+	"context"
+	"testing"
+
+	montypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_ValidatorPerformance(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	var records []*montypes.ValidatorPerformance
+	for _, idx := range []primitives.ValidatorIndex{1, 2} {
+		for epoch := primitives.Epoch(1); epoch <= 4; epoch++ {
+			records = append(records, &montypes.ValidatorPerformance{
+				ValidatorIndex:             idx,
+				Epoch:                      epoch,
+				StartBalance:               uint64(epoch) * 100,
+				EndBalance:                 uint64(epoch)*100 + 1,
+				AttestationIncluded:        epoch%2 == 0,
+				AttestedSlot:               primitives.Slot(epoch) * 32,
+				InclusionSlot:              primitives.Slot(epoch)*32 + 1,
+				TimelySource:               true,
+				TimelyHead:                 epoch == 4,
+				ProposedBlocks:             uint64(idx),
+				Aggregations:               2,
+				SyncCommitteeExpected:      32,
+				SyncCommitteeContributions: 31,
+			})
+		}
+	}
+	require.NoError(t, db.SaveValidatorPerformance(ctx, records))
+
+	perf, err := db.ValidatorPerformance(ctx, []primitives.ValidatorIndex{2, 3}, 2, 3)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(perf[2]))
+	assert.DeepEqual(t, records[5], perf[2][0])
+	assert.DeepEqual(t, records[6], perf[2][1])
+	assert.Equal(t, 0, len(perf[3]))
+
+	perf, err = db.ValidatorPerformance(ctx, []primitives.ValidatorIndex{1}, 4, 100)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(perf[1]))
+	assert.DeepEqual(t, records[3], perf[1][0])
+
+	_, err = db.ValidatorPerformance(ctx, []primitives.ValidatorIndex{1}, 2, 1)
+	require.ErrorContains(t, "lower than start epoch", err)
+}
+
+func TestStore_MonitoredValidators(t *testing.T) {
+	ctx := context.Background()
+	db := setupDB(t)
+
+	indices, err := db.MonitoredValidators(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, 0, len(indices))
+
+	require.NoError(t, db.SaveMonitoredValidators(ctx, []primitives.ValidatorIndex{300, 2, 1}))
+	require.NoError(t, db.DeleteMonitoredValidators(ctx, []primitives.ValidatorIndex{2, 5}))
+	indices, err = db.MonitoredValidators(ctx)
+	require.NoError(t, err)
+	assert.DeepEqual(t, []primitives.ValidatorIndex{1, 300}, indices)
+}
@@ -44,4 +44,9 @@ func TestSaveOrigin(t *testing.T) {
 	broot, err := scb.Block().HashTreeRoot()
 	require.NoError(t, err)
 	require.Equal(t, true, db.IsFinalizedBlock(ctx, broot))
+
+	// backfill starts from the origin block and works towards genesis.
+	bfRoot, err := db.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, broot, bfRoot)
 }
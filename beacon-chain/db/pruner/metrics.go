@@ -0,0 +1,28 @@
+package pruner
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	earliestAvailableSlot = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "beacon_db_earliest_available_slot",
+			Help: "Lowest slot above genesis for which blocks and states are kept in the database.",
+		},
+	)
+	prunedSlots = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "beacon_db_pruned_slots_total",
+			Help: "Number of slots of history deleted from the database by the pruner.",
+		},
+	)
+	pruneDurationSeconds = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "beacon_db_prune_duration_seconds",
+			Help:    "Time spent deleting history older than the retention window from the database.",
+			Buckets: []float64{0.1, 0.5, 1, 5, 10, 30, 60, 300},
+		},
+	)
+)
@@ -0,0 +1,237 @@
+package pruner
+
+import (
+	"context"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime"
+	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+// defaultBatchSize is the number of slots deleted in a single database transaction.
+const defaultBatchSize = 64
+
+// BeaconDB describes the set of DB methods that the pruner Service needs to function.
+type BeaconDB interface {
+	FinalizedCheckpoint(ctx context.Context) (*ethpb.Checkpoint, error)
+	EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error)
+	DeleteHistoricalDataBeforeSlot(ctx context.Context, cutoff primitives.Slot, maxSlots int) (primitives.Slot, error)
+}
+
+// HeadStateFetcher provides the head state, which is used to compute the weak subjectivity period.
+type HeadStateFetcher interface {
+	HeadStateReadOnly(ctx context.Context) (state.ReadOnlyBeaconState, error)
+}
+
+// ServiceOption represents a functional option for the pruner Service constructor.
+type ServiceOption func(*Service) error
+
+// WithRetentionEpochs sets the number of epochs of history kept below the current epoch.
+// A value of zero keeps the weak subjectivity period of the chain, which requires WithHeadStateFetcher.
+func WithRetentionEpochs(e primitives.Epoch) ServiceOption {
+	return func(s *Service) error {
+		s.retention = e
+		return nil
+	}
+}
+
+// WithBatchSize sets the maximum number of slots deleted in a single database transaction.
+// A value of zero keeps the default batch size.
+func WithBatchSize(n int) ServiceOption {
+	return func(s *Service) error {
+		if n > 0 {
+			s.batchSize = n
+		}
+		return nil
+	}
+}
+
+// WithHeadStateFetcher sets the source of the head state used to compute the weak subjectivity period.
+func WithHeadStateFetcher(f HeadStateFetcher) ServiceOption {
+	return func(s *Service) error {
+		s.headFetcher = f
+		return nil
+	}
+}
+
+// WithInitialSyncComplete makes the service wait for initial sync to finish before it starts pruning.
+func WithInitialSyncComplete(c chan struct{}) ServiceOption {
+	return func(s *Service) error {
+		s.initialSyncComplete = c
+		return nil
+	}
+}
+
+// Service deletes finalized blocks and states which are older than the retention window from the database,
+// once per epoch. History is deleted from the bottom up in small batches, so the database always holds a
+// contiguous range of history from the earliest available slot up to the head.
+type Service struct {
+	ctx                 context.Context
+	cancel              context.CancelFunc
+	db                  BeaconDB
+	cw                  startup.ClockWaiter
+	clock               *startup.Clock
+	headFetcher         HeadStateFetcher
+	initialSyncComplete chan struct{}
+	retention           primitives.Epoch
+	batchSize           int
+}
+
+// NewService initializes the pruner Service. Like all implementations of the runtime.Service
+// interface, the service does not begin work until Start is called.
+func NewService(ctx context.Context, db BeaconDB, cw startup.ClockWaiter, opts ...ServiceOption) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		ctx:       ctx,
+		cancel:    cancel,
+		db:        db,
+		cw:        cw,
+		batchSize: defaultBatchSize,
+	}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if s.retention == 0 && s.headFetcher == nil {
+		cancel()
+		return nil, errors.New("a head state fetcher is required to retain the weak subjectivity period")
+	}
+	return s, nil
+}
+
+// Start runs the pruning loop, which deletes old history at the start of every epoch.
+func (s *Service) Start() {
+	clock, err := s.cw.WaitForClock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Pruner service failed to receive startup event")
+		return
+	}
+	s.clock = clock
+	if s.initialSyncComplete != nil {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.initialSyncComplete:
+		}
+	}
+	log.WithField("retentionEpochs", s.retention).Info("Starting beacon DB pruning")
+	s.pruneAndLog(s.ctx)
+
+	ticker := slots.NewSlotTicker(clock.GenesisTime(), params.BeaconConfig().SecondsPerSlot)
+	defer ticker.Done()
+	for {
+		select {
+		case <-s.ctx.Done():
+			return
+		case slot := <-ticker.C():
+			if !slots.IsEpochStart(slot) {
+				continue
+			}
+			s.pruneAndLog(s.ctx)
+		}
+	}
+}
+
+// Stop the pruner service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the pruner service.
+func (s *Service) Status() error {
+	return nil
+}
+
+func (s *Service) pruneAndLog(ctx context.Context) {
+	if err := s.prune(ctx); err != nil {
+		if errors.Is(ctx.Err(), context.Canceled) {
+			return
+		}
+		log.WithError(err).Error("Could not prune beacon DB")
+	}
+}
+
+// prune deletes the history below the cutoff, one batch at a time, until the earliest available slot stops moving.
+func (s *Service) prune(ctx context.Context) error {
+	start := prysmTime.Now()
+	cutoff, err := s.cutoff(ctx)
+	if err != nil {
+		return err
+	}
+	earliest, err := s.db.EarliestAvailableSlot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve earliest available slot")
+	}
+	earliestAvailableSlot.Set(float64(earliest))
+	if cutoff <= earliest {
+		return nil
+	}
+	from := earliest
+	for {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		next, err := s.db.DeleteHistoricalDataBeforeSlot(ctx, cutoff, s.batchSize)
+		if err != nil {
+			return errors.Wrapf(err, "could not delete history before slot %d", cutoff)
+		}
+		if next == earliest {
+			break
+		}
+		prunedSlots.Add(float64(next - earliest))
+		earliestAvailableSlot.Set(float64(next))
+		earliest = next
+	}
+	if earliest == from {
+		return nil
+	}
+	pruneDurationSeconds.Observe(time.Since(start).Seconds())
+	log.WithFields(logrus.Fields{
+		"earliestAvailableSlot": earliest,
+		"prunedSlots":           earliest - from,
+	}).Info("Pruned beacon DB history")
+	return nil
+}
+
+// cutoff returns the slot below which history is deleted, ie the start of the retention window or the
+// start of the finalized epoch, whichever is lower.
+func (s *Service) cutoff(ctx context.Context) (primitives.Slot, error) {
+	retention := s.retention
+	if retention == 0 {
+		st, err := s.headFetcher.HeadStateReadOnly(ctx)
+		if err != nil {
+			return 0, errors.Wrap(err, "could not retrieve head state")
+		}
+		retention, err = helpers.ComputeWeakSubjectivityPeriod(ctx, st, params.BeaconConfig())
+		if err != nil {
+			return 0, errors.Wrap(err, "could not compute weak subjectivity period")
+		}
+	}
+	current := slots.ToEpoch(s.clock.CurrentSlot())
+	if current <= retention {
+		return params.BeaconConfig().GenesisSlot, nil
+	}
+	epoch := current - retention
+	cp, err := s.db.FinalizedCheckpoint(ctx)
+	if err != nil {
+		return 0, errors.Wrap(err, "could not retrieve finalized checkpoint")
+	}
+	if cp.Epoch < epoch {
+		epoch = cp.Epoch
+	}
+	return slots.EpochStart(epoch)
+}
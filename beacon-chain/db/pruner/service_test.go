@@ -0,0 +1,123 @@
+package pruner
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+type mockDB struct {
+	finalized primitives.Epoch
+	earliest  primitives.Slot
+	cutoffs   []primitives.Slot
+}
+
+func (m *mockDB) FinalizedCheckpoint(_ context.Context) (*ethpb.Checkpoint, error) {
+	return &ethpb.Checkpoint{Epoch: m.finalized, Root: make([]byte, 32)}, nil
+}
+
+func (m *mockDB) EarliestAvailableSlot(_ context.Context) (primitives.Slot, error) {
+	return m.earliest, nil
+}
+
+func (m *mockDB) DeleteHistoricalDataBeforeSlot(_ context.Context, cutoff primitives.Slot, maxSlots int) (primitives.Slot, error) {
+	m.cutoffs = append(m.cutoffs, cutoff)
+	if m.earliest+primitives.Slot(maxSlots) < cutoff {
+		m.earliest += primitives.Slot(maxSlots)
+	} else if m.earliest < cutoff {
+		m.earliest = cutoff
+	}
+	return m.earliest, nil
+}
+
+type mockHeadFetcher struct {
+	st state.ReadOnlyBeaconState
+}
+
+func (m *mockHeadFetcher) HeadStateReadOnly(_ context.Context) (state.ReadOnlyBeaconState, error) {
+	return m.st, nil
+}
+
+// clockAtEpoch returns a clock whose current slot is the start of the given epoch.
+func clockAtEpoch(e primitives.Epoch) *startup.Clock {
+	spe := uint64(params.BeaconConfig().SlotsPerEpoch)
+	elapsed := time.Duration(uint64(e)*spe*params.BeaconConfig().SecondsPerSlot) * time.Second
+	return startup.NewClock(time.Now().Add(-elapsed), [32]byte{})
+}
+
+func TestService_NewService(t *testing.T) {
+	_, err := NewService(context.Background(), &mockDB{}, startup.NewClockSynchronizer())
+	require.ErrorContains(t, "head state fetcher is required", err)
+	_, err = NewService(context.Background(), &mockDB{}, startup.NewClockSynchronizer(), WithRetentionEpochs(10))
+	require.NoError(t, err)
+}
+
+func TestService_Cutoff(t *testing.T) {
+	ctx := context.Background()
+	spe := params.BeaconConfig().SlotsPerEpoch
+
+	t.Run("retention window", func(t *testing.T) {
+		s, err := NewService(ctx, &mockDB{finalized: 90}, nil, WithRetentionEpochs(20))
+		require.NoError(t, err)
+		s.clock = clockAtEpoch(100)
+		cutoff, err := s.cutoff(ctx)
+		require.NoError(t, err)
+		require.Equal(t, spe.Mul(80), cutoff)
+	})
+	t.Run("capped by finalized checkpoint", func(t *testing.T) {
+		s, err := NewService(ctx, &mockDB{finalized: 50}, nil, WithRetentionEpochs(20))
+		require.NoError(t, err)
+		s.clock = clockAtEpoch(100)
+		cutoff, err := s.cutoff(ctx)
+		require.NoError(t, err)
+		require.Equal(t, spe.Mul(50), cutoff)
+	})
+	t.Run("chain younger than retention window", func(t *testing.T) {
+		s, err := NewService(ctx, &mockDB{finalized: 8}, nil, WithRetentionEpochs(20))
+		require.NoError(t, err)
+		s.clock = clockAtEpoch(10)
+		cutoff, err := s.cutoff(ctx)
+		require.NoError(t, err)
+		require.Equal(t, params.BeaconConfig().GenesisSlot, cutoff)
+	})
+	t.Run("weak subjectivity period", func(t *testing.T) {
+		st, _ := util.DeterministicGenesisState(t, 64)
+		s, err := NewService(ctx, &mockDB{finalized: 100000}, nil, WithHeadStateFetcher(&mockHeadFetcher{st: st}))
+		require.NoError(t, err)
+		// With few validators, the weak subjectivity period is the minimum validator withdrawability delay.
+		wsp := params.BeaconConfig().MinValidatorWithdrawabilityDelay
+		s.clock = clockAtEpoch(wsp + 100)
+		cutoff, err := s.cutoff(ctx)
+		require.NoError(t, err)
+		require.Equal(t, spe.Mul(100), cutoff)
+	})
+}
+
+func TestService_Prune(t *testing.T) {
+	ctx := context.Background()
+	db := &mockDB{finalized: 90, earliest: 100}
+	s, err := NewService(ctx, db, nil, WithRetentionEpochs(20), WithBatchSize(1000))
+	require.NoError(t, err)
+	s.clock = clockAtEpoch(100)
+
+	require.NoError(t, s.prune(ctx))
+	cutoff := params.BeaconConfig().SlotsPerEpoch.Mul(80)
+	require.Equal(t, cutoff, db.earliest)
+	require.Equal(t, true, len(db.cutoffs) > 1)
+	for _, c := range db.cutoffs {
+		require.Equal(t, cutoff, c)
+	}
+
+	// Nothing left to prune until the retention window moves.
+	db.cutoffs = nil
+	require.NoError(t, s.prune(ctx))
+	require.Equal(t, 0, len(db.cutoffs))
+}
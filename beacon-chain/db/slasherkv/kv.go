@@ -77,6 +77,8 @@ func NewKVStore(ctx context.Context, dirPath string) (*Store, error) {
 			attestationDataRootsBucket,
 			proposalRecordsBucket,
 			slasherChunksBucket,
+			detectedSlashingsBucket,
+			detectedSlashingsByTime,
 		)
 	}); err != nil {
 		return nil, err
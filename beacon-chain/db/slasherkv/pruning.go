@@ -4,9 +4,11 @@ import (
 	"bytes"
 	"context"
 	"encoding/binary"
+	"fmt"
 
 	fssz "github.com/prysmaticlabs/fastssz"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/time/slots"
 	bolt "go.etcd.io/bbolt"
 )
@@ -156,6 +158,46 @@ func (s *Store) PruneProposalsAtEpoch(
 	return
 }
 
+// PruneDetectedSlashingsAtEpoch deletes all detected slashings from the slasher DB whose offense happened
+// at an epoch less than or equal to the specified epoch.
+func (s *Store) PruneDetectedSlashingsAtEpoch(
+	ctx context.Context, maxEpoch primitives.Epoch,
+) (numPruned uint, err error) {
+	err = s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(detectedSlashingsBucket)
+		byTime := tx.Bucket(detectedSlashingsByTime)
+		var roots [][32]byte
+		var timeKeys [][]byte
+		c := bkt.Cursor()
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if len(k) != 32 || len(v) < detectedSlashingHeaderLength {
+				return fmt.Errorf("wrong length for detected slashing, key length %d, value length %d", len(k), len(v))
+			}
+			if primitives.Epoch(binary.BigEndian.Uint64(v[8:16])) > maxEpoch {
+				continue
+			}
+			root := bytesutil.ToBytes32(k)
+			roots = append(roots, root)
+			timeKeys = append(timeKeys, detectedSlashingTimeKey(v, root))
+		}
+		// Modifying a bucket while iterating over it with a cursor is not supported by bolt.
+		for i, root := range roots {
+			if err := bkt.Delete(root[:]); err != nil {
+				return err
+			}
+			if err := byTime.Delete(timeKeys[i]); err != nil {
+				return err
+			}
+			numPruned++
+		}
+		return nil
+	})
+	return
+}
+
 func slotFromProposalKey(key []byte) primitives.Slot {
 	return primitives.Slot(binary.LittleEndian.Uint64(key[:8]))
 }
@@ -13,4 +13,6 @@ var (
 	attestationDataRootsBucket = []byte("attestation-data-roots")
 	proposalRecordsBucket      = []byte("proposal-records")
 	slasherChunksBucket        = []byte("slasher-chunks")
+	detectedSlashingsBucket    = []byte("detected-slashings")
+	detectedSlashingsByTime    = []byte("detected-slashings-by-time")
 )
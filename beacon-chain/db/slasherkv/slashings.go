@@ -0,0 +1,189 @@
+package slasherkv
+
+import (
+	"context"
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/golang/snappy"
+	"github.com/pkg/errors"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+// Prefixes of the encoded detected slashings, telling which kind of slashing follows.
+const (
+	attesterSlashingPrefix byte = iota
+	proposerSlashingPrefix
+)
+
+// Length of the detection time and epoch which are stored before the encoded slashing.
+const detectedSlashingHeaderLength = 16
+
+// SaveDetectedSlashings persists the slashings found by slasher. Slashings are keyed by their root,
+// so that a slashing detected again is only stored once, with the time it was first detected at.
+func (s *Store) SaveDetectedSlashings(ctx context.Context, slashings []*slashertypes.DetectedSlashing) error {
+	_, span := trace.StartSpan(ctx, "BeaconDB.SaveDetectedSlashings")
+	defer span.End()
+	roots := make([][32]byte, len(slashings))
+	encodedSlashings := make([][]byte, len(slashings))
+	for i, slashing := range slashings {
+		root, enc, err := encodeDetectedSlashing(slashing)
+		if err != nil {
+			return err
+		}
+		roots[i] = root
+		encodedSlashings[i] = enc
+	}
+	return s.db.Update(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(detectedSlashingsBucket)
+		byTime := tx.Bucket(detectedSlashingsByTime)
+		for i := range slashings {
+			if bkt.Get(roots[i][:]) != nil {
+				continue
+			}
+			if err := bkt.Put(roots[i][:], encodedSlashings[i]); err != nil {
+				return err
+			}
+			if err := byTime.Put(detectedSlashingTimeKey(encodedSlashings[i], roots[i]), roots[i][:]); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// DetectedSlashings retrieves up to limit slashings found by slasher which match the filter, in the order they were
+// first detected, starting at the given page token. A nil filter matches all slashings. The returned token is the one
+// of the next page, and is empty once there are no more slashings.
+func (s *Store) DetectedSlashings(
+	ctx context.Context, pageToken []byte, limit int, filter func(*slashertypes.DetectedSlashing) bool,
+) ([]*slashertypes.DetectedSlashing, []byte, error) {
+	_, span := trace.StartSpan(ctx, "BeaconDB.DetectedSlashings")
+	defer span.End()
+	if limit <= 0 {
+		return nil, nil, errors.New("limit must be positive")
+	}
+	slashings := make([]*slashertypes.DetectedSlashing, 0)
+	var next []byte
+	err := s.db.View(func(tx *bolt.Tx) error {
+		bkt := tx.Bucket(detectedSlashingsBucket)
+		c := tx.Bucket(detectedSlashingsByTime).Cursor()
+		for k, root := c.Seek(pageToken); k != nil; k, root = c.Next() {
+			if len(slashings) == limit {
+				next = bytesutil.SafeCopyBytes(k)
+				return nil
+			}
+			enc := bkt.Get(root)
+			if enc == nil {
+				continue
+			}
+			slashing, err := decodeDetectedSlashing(enc)
+			if err != nil {
+				return err
+			}
+			if filter != nil && !filter(slashing) {
+				continue
+			}
+			slashings = append(slashings, slashing)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	return slashings, next, nil
+}
+
+// Encodes a detected slashing into its root and its disk value, made of the big-endian detection time in
+// nanoseconds, the big-endian epoch of the offense, a prefix for the kind of slashing and the compressed slashing.
+func encodeDetectedSlashing(slashing *slashertypes.DetectedSlashing) ([32]byte, []byte, error) {
+	if slashing == nil {
+		return [32]byte{}, nil, errors.New("nil detected slashing")
+	}
+	var prefix byte
+	var root [32]byte
+	var epoch primitives.Epoch
+	var enc []byte
+	var err error
+	switch {
+	case slashing.AttesterSlashing != nil:
+		prefix = attesterSlashingPrefix
+		if root, err = slashing.AttesterSlashing.HashTreeRoot(); err != nil {
+			return [32]byte{}, nil, err
+		}
+		epoch = attesterSlashingEpoch(slashing.AttesterSlashing)
+		enc, err = slashing.AttesterSlashing.MarshalSSZ()
+	case slashing.ProposerSlashing != nil:
+		prefix = proposerSlashingPrefix
+		if root, err = slashing.ProposerSlashing.HashTreeRoot(); err != nil {
+			return [32]byte{}, nil, err
+		}
+		if h := slashing.ProposerSlashing.Header_1; h != nil && h.Header != nil {
+			epoch = slots.ToEpoch(h.Header.Slot)
+		}
+		enc, err = slashing.ProposerSlashing.MarshalSSZ()
+	default:
+		return [32]byte{}, nil, errors.New("detected slashing has no attester or proposer slashing")
+	}
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	value := make([]byte, detectedSlashingHeaderLength, detectedSlashingHeaderLength+1+len(enc))
+	binary.BigEndian.PutUint64(value[:8], uint64(slashing.DetectedAt.UnixNano()))
+	binary.BigEndian.PutUint64(value[8:16], uint64(epoch))
+	value = append(value, prefix)
+	return root, append(value, snappy.Encode(nil, enc)...), nil
+}
+
+func decodeDetectedSlashing(encoded []byte) (*slashertypes.DetectedSlashing, error) {
+	if len(encoded) < detectedSlashingHeaderLength+1 {
+		return nil, fmt.Errorf("wrong length for detected slashing, value length %d", len(encoded))
+	}
+	dec, err := snappy.Decode(nil, encoded[detectedSlashingHeaderLength+1:])
+	if err != nil {
+		return nil, err
+	}
+	slashing := &slashertypes.DetectedSlashing{
+		DetectedAt: time.Unix(0, int64(binary.BigEndian.Uint64(encoded[:8]))),
+	}
+	switch encoded[detectedSlashingHeaderLength] {
+	case attesterSlashingPrefix:
+		slashing.AttesterSlashing = &ethpb.AttesterSlashing{}
+		err = slashing.AttesterSlashing.UnmarshalSSZ(dec)
+	case proposerSlashingPrefix:
+		slashing.ProposerSlashing = &ethpb.ProposerSlashing{}
+		err = slashing.ProposerSlashing.UnmarshalSSZ(dec)
+	default:
+		return nil, fmt.Errorf("unknown detected slashing prefix %d", encoded[detectedSlashingHeaderLength])
+	}
+	if err != nil {
+		return nil, err
+	}
+	return slashing, nil
+}
+
+// detectedSlashingTimeKey returns the key of a detected slashing in the detection time index, made of the
+// detection time of its encoded value followed by its root.
+func detectedSlashingTimeKey(encoded []byte, root [32]byte) []byte {
+	key := make([]byte, 8, 8+len(root))
+	copy(key, encoded[:8])
+	return append(key, root[:]...)
+}
+
+// attesterSlashingEpoch returns the highest target epoch of the attestations of an attester slashing.
+func attesterSlashingEpoch(sl *ethpb.AttesterSlashing) primitives.Epoch {
+	var epoch primitives.Epoch
+	for _, att := range []*ethpb.IndexedAttestation{sl.Attestation_1, sl.Attestation_2} {
+		if att != nil && att.Data != nil && att.Data.Target != nil && att.Data.Target.Epoch > epoch {
+			epoch = att.Data.Target.Epoch
+		}
+	}
+	return epoch
+}
@@ -0,0 +1,136 @@
+package slasherkv
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStore_DetectedSlashings_SaveRetrieve(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+	slashings, next, err := beaconDB.DetectedSlashings(ctx, nil, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slashings))
+	require.Equal(t, 0, len(next))
+
+	detectedAt := time.Unix(1000, 5)
+	attesterSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: createAttestationWrapper(1, 2, []uint64{3}, []byte{1}).IndexedAttestation,
+		Attestation_2: createAttestationWrapper(0, 3, []uint64{3}, []byte{2}).IndexedAttestation,
+	}
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: createProposalWrapper(t, 4, 5, []byte{1}).SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, 4, 5, []byte{2}).SignedBeaconBlockHeader,
+	}
+	detected := []*slashertypes.DetectedSlashing{
+		{DetectedAt: detectedAt.Add(time.Second), ProposerSlashing: proposerSlashing},
+		{DetectedAt: detectedAt, AttesterSlashing: attesterSlashing},
+	}
+	require.NoError(t, beaconDB.SaveDetectedSlashings(ctx, detected))
+	// Detecting a slashing again later does not duplicate it, and keeps its first detection time.
+	redetected := []*slashertypes.DetectedSlashing{{DetectedAt: detectedAt.Add(time.Hour), AttesterSlashing: attesterSlashing}}
+	require.NoError(t, beaconDB.SaveDetectedSlashings(ctx, redetected))
+
+	slashings, next, err = beaconDB.DetectedSlashings(ctx, nil, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(next))
+	require.Equal(t, 2, len(slashings))
+	assert.Equal(t, true, slashings[0].DetectedAt.Equal(detectedAt))
+	assert.DeepSSZEqual(t, attesterSlashing, slashings[0].AttesterSlashing)
+	assert.Equal(t, true, slashings[0].ProposerSlashing == nil)
+	assert.Equal(t, true, slashings[1].DetectedAt.Equal(detectedAt.Add(time.Second)))
+	assert.DeepSSZEqual(t, proposerSlashing, slashings[1].ProposerSlashing)
+	assert.Equal(t, true, slashings[1].AttesterSlashing == nil)
+
+	err = beaconDB.SaveDetectedSlashings(ctx, []*slashertypes.DetectedSlashing{{DetectedAt: detectedAt}})
+	require.ErrorContains(t, "has no attester or proposer slashing", err)
+}
+
+func TestStore_DetectedSlashings_Pagination(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+	detected := make([]*slashertypes.DetectedSlashing, 5)
+	for i := range detected {
+		detected[i] = &slashertypes.DetectedSlashing{
+			DetectedAt: time.Unix(int64(1000+i), 0),
+			ProposerSlashing: &ethpb.ProposerSlashing{
+				Header_1: createProposalWrapper(t, primitives.Slot(i), primitives.ValidatorIndex(i), []byte{1}).SignedBeaconBlockHeader,
+				Header_2: createProposalWrapper(t, primitives.Slot(i), primitives.ValidatorIndex(i), []byte{2}).SignedBeaconBlockHeader,
+			},
+		}
+	}
+	require.NoError(t, beaconDB.SaveDetectedSlashings(ctx, detected))
+
+	var token []byte
+	var got []primitives.ValidatorIndex
+	for pages := 0; ; pages++ {
+		require.Equal(t, true, pages < 3)
+		page, next, err := beaconDB.DetectedSlashings(ctx, token, 2, nil)
+		require.NoError(t, err)
+		for _, sl := range page {
+			got = append(got, sl.ProposerSlashing.Header_1.Header.ProposerIndex)
+		}
+		if len(next) == 0 {
+			break
+		}
+		token = next
+	}
+	assert.DeepEqual(t, []primitives.ValidatorIndex{0, 1, 2, 3, 4}, got)
+
+	// Slashings not matching the filter do not count towards the limit.
+	odd := func(sl *slashertypes.DetectedSlashing) bool {
+		return sl.ProposerSlashing.Header_1.Header.ProposerIndex%2 == 1
+	}
+	page, next, err := beaconDB.DetectedSlashings(ctx, nil, 2, odd)
+	require.NoError(t, err)
+	require.Equal(t, 2, len(page))
+	assert.Equal(t, primitives.ValidatorIndex(1), page[0].ProposerSlashing.Header_1.Header.ProposerIndex)
+	assert.Equal(t, primitives.ValidatorIndex(3), page[1].ProposerSlashing.Header_1.Header.ProposerIndex)
+	page, next, err = beaconDB.DetectedSlashings(ctx, next, 2, odd)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(page))
+	require.Equal(t, 0, len(next))
+
+	_, _, err = beaconDB.DetectedSlashings(ctx, nil, 0, nil)
+	require.ErrorContains(t, "limit must be positive", err)
+}
+
+func TestStore_PruneDetectedSlashingsAtEpoch(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := setupDB(t)
+	attesterSlashing := &ethpb.AttesterSlashing{
+		Attestation_1: createAttestationWrapper(1, 2, []uint64{3}, []byte{1}).IndexedAttestation,
+		Attestation_2: createAttestationWrapper(0, 3, []uint64{3}, []byte{2}).IndexedAttestation,
+	}
+	proposerSlashing := &ethpb.ProposerSlashing{
+		Header_1: createProposalWrapper(t, 4, 5, []byte{1}).SignedBeaconBlockHeader,
+		Header_2: createProposalWrapper(t, 4, 5, []byte{2}).SignedBeaconBlockHeader,
+	}
+	require.NoError(t, beaconDB.SaveDetectedSlashings(ctx, []*slashertypes.DetectedSlashing{
+		{DetectedAt: time.Unix(1000, 0), AttesterSlashing: attesterSlashing},
+		{DetectedAt: time.Unix(1001, 0), ProposerSlashing: proposerSlashing},
+	}))
+
+	// The proposer slashing is at epoch 0, the attester slashing at its highest target epoch 3.
+	numPruned, err := beaconDB.PruneDetectedSlashingsAtEpoch(ctx, 2)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), numPruned)
+	slashings, _, err := beaconDB.DetectedSlashings(ctx, nil, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(slashings))
+	assert.DeepSSZEqual(t, attesterSlashing, slashings[0].AttesterSlashing)
+
+	numPruned, err = beaconDB.PruneDetectedSlashingsAtEpoch(ctx, 3)
+	require.NoError(t, err)
+	assert.Equal(t, uint(1), numPruned)
+	slashings, _, err = beaconDB.DetectedSlashings(ctx, nil, 10, nil)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(slashings))
+}
@@ -0,0 +1,296 @@
+package execution
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"time"
+
+	gethRPC "github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/io/logs"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	pb "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	"github.com/sirupsen/logrus"
+)
+
+var (
+	// time during which the service does not switch back to an execution client it failed over from.
+	engineFailbackDelay = 5 * time.Minute
+	// number of consecutive payloads the current execution client answers SYNCING to before failing over.
+	maxSyncingPayloads = uint64(3)
+
+	errEngineSyncing = errors.New("execution client is syncing")
+)
+
+// engine is an execution client the service can use, either the one given with --execution-endpoint or one of the
+// backups.
+type engine struct {
+	endpoint network.Endpoint
+	// client is only set for the standby engines, the current engine uses the clients of the service.
+	client RPCClient
+	// the engine is not failed back to before this time.
+	penalizedUntil time.Time
+}
+
+func (e *engine) url() string {
+	return logs.MaskCredentialsLogging(e.endpoint.Url)
+}
+
+// initEngines sets up the list of execution clients, in order of preference, when backups are configured.
+func (s *Service) initEngines() {
+	if len(s.cfg.backupHttpEndpoints) == 0 {
+		return
+	}
+	seen := make(map[string]bool)
+	for _, e := range append([]network.Endpoint{s.cfg.currHttpEndpoint}, s.cfg.backupHttpEndpoints...) {
+		if e.Url == "" || seen[e.Url] {
+			continue
+		}
+		seen[e.Url] = true
+		s.engines = append(s.engines, &engine{endpoint: e})
+	}
+	s.engineCheck = make(chan struct{}, 1)
+}
+
+// failoverEnabled returns true if the service has backup execution clients.
+func (s *Service) failoverEnabled() bool {
+	return len(s.engines) > 1
+}
+
+// monitorEngines periodically checks the health of all the execution clients, and switches to the preferred healthy
+// one when needed.
+func (s *Service) monitorEngines(ctx context.Context) {
+	// The execution clients are checked once per slot.
+	ticker := time.NewTicker(time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second)
+	defer ticker.Stop()
+	for {
+		s.checkEngines(ctx)
+		select {
+		case <-ticker.C:
+		case <-s.engineCheck:
+		case <-ctx.Done():
+			return
+		}
+	}
+}
+
+// checkEngines checks the health of the execution clients. The service switches to the first healthy client in
+// order of preference, unless it failed over from that client recently. It keeps the current client when no other
+// client is healthy.
+func (s *Service) checkEngines(ctx context.Context) {
+	s.engineLock.RLock()
+	current := s.currEngine
+	stuck := s.syncingPayloads >= maxSyncingPayloads
+	s.engineLock.RUnlock()
+
+	target := -1
+	for i, e := range s.engines {
+		var err error
+		if i == current {
+			err = checkEngineHealth(ctx, s.engineClient())
+			if err == nil && stuck {
+				err = errors.Wrapf(errEngineSyncing, "%d consecutive payloads were not validated", maxSyncingPayloads)
+			}
+		} else {
+			err = s.checkStandbyEngine(ctx, e)
+		}
+		if err != nil {
+			engineHealthGauge.WithLabelValues(e.url()).Set(0)
+			log.WithError(err).WithField("endpoint", e.url()).Debug("Execution client is not healthy")
+			continue
+		}
+		engineHealthGauge.WithLabelValues(e.url()).Set(1)
+		if target == -1 && (i == current || time.Now().After(e.penalizedUntil)) {
+			target = i
+		}
+	}
+	if target == -1 {
+		log.WithField("endpoint", s.engines[current].url()).Warn("No healthy execution client available")
+		return
+	}
+	if target == current {
+		return
+	}
+	if err := s.switchEngine(ctx, target); err != nil {
+		log.WithError(err).WithField("endpoint", s.engines[target].url()).Error("Could not switch execution client")
+	}
+}
+
+func (s *Service) checkStandbyEngine(ctx context.Context, e *engine) error {
+	s.engineLock.Lock()
+	if e.client == nil {
+		client, err := s.newRPCClientWithAuth(ctx, e.endpoint)
+		if err != nil {
+			s.engineLock.Unlock()
+			return errors.Wrap(err, "could not dial execution client")
+		}
+		e.client = client
+	}
+	client := e.client
+	s.engineLock.Unlock()
+	return checkEngineHealth(ctx, client)
+}
+
+// checkEngineHealth returns an error if the engine API of the execution client is not reachable or if the client
+// is syncing.
+func checkEngineHealth(ctx context.Context, client RPCClient) error {
+	ctx, cancel := context.WithTimeout(ctx, defaultEngineTimeout)
+	defer cancel()
+	var capabilities []string
+	if err := client.CallContext(ctx, &capabilities, ExchangeCapabilities, supportedEngineEndpoints); err != nil {
+		// Execution clients which do not implement the method are still usable.
+		if err := handleRPCError(err); !errors.Is(err, ErrMethodNotFound) {
+			return errors.Wrap(err, "engine API is not available")
+		}
+	}
+	var syncing json.RawMessage
+	if err := client.CallContext(ctx, &syncing, "eth_syncing"); err != nil {
+		return errors.Wrap(handleRPCError(err), "could not get sync status")
+	}
+	if !bytes.Equal(syncing, []byte("false")) {
+		return errEngineSyncing
+	}
+	return nil
+}
+
+// switchEngine makes the execution client at the given index the current one. Engine API calls and deposit log
+// polling then go to that client.
+func (s *Service) switchEngine(ctx context.Context, idx int) error {
+	s.engineLock.RLock()
+	prev, next := s.engines[s.currEngine], s.engines[idx]
+	prevClient := s.rpcClient
+	s.engineLock.RUnlock()
+	// The lock is not held while dialing, so that the engine API calls in flight are not blocked.
+	if err := s.setupExecutionClientConnections(ctx, next.endpoint); err != nil {
+		return err
+	}
+	s.engineLock.Lock()
+	defer s.engineLock.Unlock()
+	s.cfg.currHttpEndpoint = next.endpoint
+	if next.client != nil {
+		next.client.Close()
+		next.client = nil
+	}
+	// The previous client becomes a standby client, it keeps receiving fork choice updates.
+	prev.client = prevClient
+	prev.penalizedUntil = time.Now().Add(engineFailbackDelay)
+	s.currEngine = idx
+	s.syncingPayloads = 0
+	engineFailoverCount.Inc()
+	log.WithFields(logrus.Fields{
+		"from": prev.url(),
+		"to":   next.url(),
+	}).Warn("Switched execution client")
+	return nil
+}
+
+// nextEngine makes the next execution client in order of preference the one the service connects to. It is used
+// when the connection to the current client cannot be re-established.
+func (s *Service) nextEngine() {
+	if !s.failoverEnabled() {
+		return
+	}
+	s.engineLock.Lock()
+	defer s.engineLock.Unlock()
+	s.currEngine = (s.currEngine + 1) % len(s.engines)
+	next := s.engines[s.currEngine]
+	if next.client != nil {
+		next.client.Close()
+		next.client = nil
+	}
+	s.cfg.currHttpEndpoint = next.endpoint
+	s.syncingPayloads = 0
+}
+
+// engineClient returns the RPC client of the current execution client, which is replaced when the service fails
+// over to another execution client.
+func (s *Service) engineClient() RPCClient {
+	s.engineLock.RLock()
+	defer s.engineLock.RUnlock()
+	return s.rpcClient
+}
+
+// currEndpoint returns the endpoint of the current execution client.
+func (s *Service) currEndpoint() network.Endpoint {
+	s.engineLock.RLock()
+	defer s.engineLock.RUnlock()
+	return s.cfg.currHttpEndpoint
+}
+
+// recordPayloadStatus keeps track of the payloads the current execution client could not validate because it is
+// syncing, and triggers a health check when the client looks stuck.
+func (s *Service) recordPayloadStatus(status pb.PayloadStatus_Status) {
+	if !s.failoverEnabled() {
+		return
+	}
+	s.engineLock.Lock()
+	defer s.engineLock.Unlock()
+	if status != pb.PayloadStatus_SYNCING {
+		s.syncingPayloads = 0
+		return
+	}
+	s.syncingPayloads++
+	if s.syncingPayloads == maxSyncingPayloads {
+		s.triggerEngineCheck()
+	}
+}
+
+// recordEngineError triggers a health check when an engine API call failed because the current execution client
+// could not be reached.
+func (s *Service) recordEngineError(err error) {
+	if !s.failoverEnabled() {
+		return
+	}
+	if _, ok := err.(gethRPC.Error); ok {
+		return
+	}
+	s.triggerEngineCheck()
+}
+
+func (s *Service) triggerEngineCheck() {
+	select {
+	case s.engineCheck <- struct{}{}:
+	default:
+	}
+}
+
+// mirrorForkchoiceUpdated sends the fork choice state, without payload attributes, to the standby execution clients
+// so that they follow the head of the chain and are synced if the service fails over to them.
+func (s *Service) mirrorForkchoiceUpdated(method string, state *pb.ForkchoiceState) {
+	if !s.failoverEnabled() {
+		return
+	}
+	s.engineLock.RLock()
+	clients := make([]RPCClient, 0, len(s.engines)-1)
+	for i, e := range s.engines {
+		if i != s.currEngine && e.client != nil {
+			clients = append(clients, e.client)
+		}
+	}
+	s.engineLock.RUnlock()
+	for _, c := range clients {
+		go func(c RPCClient) {
+			d := time.Now().Add(time.Duration(params.BeaconConfig().ExecutionEngineTimeoutValue) * time.Second)
+			ctx, cancel := context.WithDeadline(s.ctx, d)
+			defer cancel()
+			result := &ForkchoiceUpdatedResponse{}
+			if err := c.CallContext(ctx, result, method, state, nil); err != nil {
+				log.WithError(handleRPCError(err)).Debug("Could not send fork choice update to standby execution client")
+			}
+		}(c)
+	}
+}
+
+// closeStandbyClients closes the connections to the standby execution clients.
+func (s *Service) closeStandbyClients() {
+	s.engineLock.Lock()
+	defer s.engineLock.Unlock()
+	for _, e := range s.engines {
+		if e.client != nil {
+			e.client.Close()
+			e.client = nil
+		}
+	}
+}
@@ -0,0 +1,262 @@
+package execution
+
+import (
+	"context"
+	"math/big"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	pb "github.com/prysmaticlabs/prysm/v4/proto/engine/v1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+// testEngine is an execution client exposing the methods used by the failover logic.
+type testEngine struct {
+	url          string
+	syncing      atomic.Bool
+	forkchoiceCh chan *pb.ForkchoiceState
+	payloads     atomic.Uint64
+}
+
+type testEngineEth struct{ e *testEngine }
+
+func (*testEngineEth) ChainId() *hexutil.Big {
+	return (*hexutil.Big)(new(big.Int).SetUint64(params.BeaconConfig().DepositChainID))
+}
+
+func (t *testEngineEth) Syncing() interface{} {
+	if t.e.syncing.Load() {
+		return map[string]string{"startingBlock": "0x0", "currentBlock": "0x1", "highestBlock": "0x2"}
+	}
+	return false
+}
+
+type testEngineAPI struct{ e *testEngine }
+
+func (*testEngineAPI) ExchangeCapabilities(methods []string) []string {
+	return methods
+}
+
+func (t *testEngineAPI) ForkchoiceUpdatedV1(state *pb.ForkchoiceState, _ *pb.PayloadAttributes) *ForkchoiceUpdatedResponse {
+	t.e.forkchoiceCh <- state
+	return &ForkchoiceUpdatedResponse{Status: &pb.PayloadStatus{Status: pb.PayloadStatus_SYNCING}}
+}
+
+func (t *testEngineAPI) NewPayloadV1(payload *pb.ExecutionPayload) *pb.PayloadStatus {
+	t.e.payloads.Add(1)
+	return &pb.PayloadStatus{Status: pb.PayloadStatus_VALID, LatestValidHash: payload.BlockHash}
+}
+
+func setupTestEngine(t *testing.T) *testEngine {
+	e := &testEngine{forkchoiceCh: make(chan *pb.ForkchoiceState, 1)}
+	srv := rpc.NewServer()
+	require.NoError(t, srv.RegisterName("eth", &testEngineEth{e: e}))
+	require.NoError(t, srv.RegisterName("engine", &testEngineAPI{e: e}))
+	hs := httptest.NewServer(srv)
+	t.Cleanup(func() {
+		hs.Close()
+		srv.Stop()
+	})
+	e.url = hs.URL
+	return e
+}
+
+func setupFailoverService(t *testing.T, engines ...*testEngine) *Service {
+	ctx, cancel := context.WithCancel(context.Background())
+	s := &Service{
+		ctx:       ctx,
+		cancel:    cancel,
+		rpcClient: RPCClientEmpty{},
+		cfg: &config{
+			beaconNodeStatsUpdater: &NopBeaconNodeStatsUpdater{},
+			currHttpEndpoint:       network.HttpEndpoint(engines[0].url),
+		},
+	}
+	for _, e := range engines[1:] {
+		require.NoError(t, WithBackupHttpEndpoint(e.url, nil)(s))
+	}
+	s.initEngines()
+	require.NoError(t, s.setupExecutionClientConnections(ctx, s.cfg.currHttpEndpoint))
+	t.Cleanup(func() {
+		require.NoError(t, s.Stop())
+	})
+	return s
+}
+
+func TestCheckEngines_FailoverAndFailback(t *testing.T) {
+	primary, backup := setupTestEngine(t), setupTestEngine(t)
+	s := setupFailoverService(t, primary, backup)
+	ctx := context.Background()
+	require.Equal(t, 2, len(s.engines))
+
+	s.checkEngines(ctx)
+	require.Equal(t, primary.url, s.ExecutionClientEndpoint())
+
+	primary.syncing.Store(true)
+	s.checkEngines(ctx)
+	require.Equal(t, backup.url, s.ExecutionClientEndpoint())
+	require.Equal(t, 1, s.currEngine)
+	require.NotNil(t, s.engines[0].client)
+	require.Equal(t, true, s.engines[1].client == nil)
+
+	// The primary is not used again right after failing over from it.
+	primary.syncing.Store(false)
+	s.checkEngines(ctx)
+	require.Equal(t, backup.url, s.ExecutionClientEndpoint())
+
+	s.engines[0].penalizedUntil = time.Time{}
+	s.checkEngines(ctx)
+	require.Equal(t, primary.url, s.ExecutionClientEndpoint())
+
+	// The current client is kept when no client is healthy.
+	primary.syncing.Store(true)
+	backup.syncing.Store(true)
+	s.checkEngines(ctx)
+	require.Equal(t, primary.url, s.ExecutionClientEndpoint())
+}
+
+func TestCheckEngines_SyncingPayloads(t *testing.T) {
+	primary, backup := setupTestEngine(t), setupTestEngine(t)
+	s := setupFailoverService(t, primary, backup)
+
+	for i := uint64(0); i < maxSyncingPayloads-1; i++ {
+		s.recordPayloadStatus(pb.PayloadStatus_SYNCING)
+	}
+	s.recordPayloadStatus(pb.PayloadStatus_VALID)
+	for i := uint64(0); i < maxSyncingPayloads-1; i++ {
+		s.recordPayloadStatus(pb.PayloadStatus_SYNCING)
+	}
+	require.Equal(t, 0, len(s.engineCheck))
+	s.recordPayloadStatus(pb.PayloadStatus_SYNCING)
+	require.Equal(t, 1, len(s.engineCheck))
+
+	// The primary reports it is synced, but does not validate payloads.
+	s.checkEngines(context.Background())
+	require.Equal(t, backup.url, s.ExecutionClientEndpoint())
+	require.Equal(t, uint64(0), s.syncingPayloads)
+}
+
+// payloadClient is an execution client which only validates payloads, its health checks fail.
+type payloadClient struct {
+	RPCClientEmpty
+	payloads atomic.Uint64
+}
+
+func (c *payloadClient) CallContext(_ context.Context, result interface{}, method string, args ...interface{}) error {
+	if method != NewPayloadMethod {
+		return errors.New("engine API is not available")
+	}
+	c.payloads.Add(1)
+	status, ok := result.(*pb.PayloadStatus)
+	if !ok {
+		return errors.New("unexpected result type")
+	}
+	status.Status = pb.PayloadStatus_VALID
+	status.LatestValidHash = args[0].(*pb.ExecutionPayload).BlockHash
+	return nil
+}
+
+// Runs with the race detector to check that failing over does not race with the engine API calls in flight.
+func TestCheckEngines_FailoverDuringNewPayload(t *testing.T) {
+	primary, backup := setupTestEngine(t), setupTestEngine(t)
+	s := setupFailoverService(t, primary, backup)
+	ctx := context.Background()
+	s.rpcClient.Close()
+	current := &payloadClient{}
+	s.rpcClient = current
+
+	payload := emptyPayload()
+	payload.BlockHash = bytesutil.PadTo([]byte{'a'}, 32)
+	wrapped, err := blocks.WrappedExecutionPayload(payload)
+	require.NoError(t, err)
+
+	stop := make(chan struct{})
+	errCh := make(chan error, 1)
+	go func() {
+		for {
+			select {
+			case <-stop:
+				errCh <- nil
+				return
+			default:
+			}
+			if _, err := s.NewPayload(ctx, wrapped); err != nil {
+				errCh <- err
+				return
+			}
+		}
+	}()
+	waitFor := func(cond func() bool) {
+		deadline := time.Now().Add(5 * time.Second)
+		for !cond() {
+			if time.Now().After(deadline) {
+				t.Fatal("timed out")
+			}
+			time.Sleep(time.Millisecond)
+		}
+	}
+
+	// Fail over while payloads are being sent to the current execution client.
+	waitFor(func() bool { return current.payloads.Load() > 0 })
+	s.checkEngines(ctx)
+	require.Equal(t, backup.url, s.ExecutionClientEndpoint())
+	waitFor(func() bool { return backup.payloads.Load() > 0 })
+	close(stop)
+	require.NoError(t, <-errCh)
+}
+
+func TestMirrorForkchoiceUpdated(t *testing.T) {
+	primary, backup := setupTestEngine(t), setupTestEngine(t)
+	s := setupFailoverService(t, primary, backup)
+	// The health check connects to the standby client.
+	s.checkEngines(context.Background())
+
+	state := &pb.ForkchoiceState{
+		HeadBlockHash:      make([]byte, 32),
+		SafeBlockHash:      make([]byte, 32),
+		FinalizedBlockHash: make([]byte, 32),
+	}
+	state.HeadBlockHash[0] = 1
+	s.mirrorForkchoiceUpdated(ForkchoiceUpdatedMethod, state)
+	select {
+	case got := <-backup.forkchoiceCh:
+		require.DeepEqual(t, state.HeadBlockHash, got.HeadBlockHash)
+	case <-time.After(5 * time.Second):
+		t.Fatal("fork choice update was not sent to the standby client")
+	}
+	require.Equal(t, 0, len(primary.forkchoiceCh))
+}
+
+func TestNextEngine(t *testing.T) {
+	s := &Service{cfg: &config{
+		currHttpEndpoint: network.HttpEndpoint("http://a"),
+		backupHttpEndpoints: []network.Endpoint{
+			network.HttpEndpoint("http://b"),
+			network.HttpEndpoint("http://a"),
+			network.HttpEndpoint("http://c"),
+		},
+	}}
+	s.initEngines()
+	require.Equal(t, 3, len(s.engines))
+	s.nextEngine()
+	require.Equal(t, "http://b", s.ExecutionClientEndpoint())
+	s.nextEngine()
+	require.Equal(t, "http://c", s.ExecutionClientEndpoint())
+	s.nextEngine()
+	require.Equal(t, "http://a", s.ExecutionClientEndpoint())
+
+	// Without backups the endpoint is left unchanged.
+	s = &Service{cfg: &config{currHttpEndpoint: network.HttpEndpoint("http://a")}}
+	s.initEngines()
+	s.nextEngine()
+	require.Equal(t, "http://a", s.ExecutionClientEndpoint())
+}
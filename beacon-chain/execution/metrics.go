@@ -75,4 +75,12 @@ var (
 		Name: "execution_payload_bodies_count",
 		Help: "The number of requested payload bodies is too large",
 	})
+	engineFailoverCount = promauto.NewCounter(prometheus.CounterOpts{
+		Name: "execution_engine_failover_count",
+		Help: "The number of times the beacon node switched to another execution client",
+	})
+	engineHealthGauge = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "execution_engine_healthy",
+		Help: "Whether the execution client is reachable and synced (1) or not (0), as of the last health check",
+	}, []string{"endpoint"})
 )
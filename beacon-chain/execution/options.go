@@ -37,6 +37,20 @@ func WithHttpEndpointAndJWTSecret(endpointString string, secret []byte) Option {
 	}
 }
 
+// WithBackupHttpEndpoint adds an execution client the service fails over to when the current one is unavailable or
+// not synced. Backups are used in the order they are added.
+func WithBackupHttpEndpoint(endpointString string, secret []byte) Option {
+	return func(s *Service) error {
+		hEndpoint := network.HttpEndpoint(endpointString)
+		if len(secret) > 0 {
+			hEndpoint.Auth.Method = authorization.Bearer
+			hEndpoint.Auth.Value = string(secret)
+		}
+		s.cfg.backupHttpEndpoints = append(s.cfg.backupHttpEndpoints, hEndpoint)
+		return nil
+	}
+}
+
 // WithHeaders adds headers to the execution node JSON-RPC requests.
 func WithHeaders(headers []string) Option {
 	return func(s *Service) error {
@@ -21,6 +21,7 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
@@ -30,7 +31,6 @@ import (
 	native "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/state-native"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
-	"github.com/prysmaticlabs/prysm/v4/container/trie"
 	contracts "github.com/prysmaticlabs/prysm/v4/contracts/deposit"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/clientstats"
@@ -125,6 +125,7 @@ type config struct {
 	eth1HeaderReqLimit      uint64
 	beaconNodeStatsUpdater  BeaconNodeStatsUpdater
 	currHttpEndpoint        network.Endpoint
+	backupHttpEndpoints     []network.Endpoint
 	headers                 []string
 	finalizedStateAtStartup state.BeaconState
 }
@@ -149,22 +150,22 @@ type Service struct {
 	headerCache             *headerCache // cache to store block hash/block height.
 	latestEth1Data          *ethpb.LatestETH1Data
 	depositContractCaller   *contracts.DepositContractCaller
-	depositTrie             *trie.SparseMerkleTrie
+	depositTrie             *depositsnapshot.DepositTree
 	chainStartData          *ethpb.ChainStartData
 	lastReceivedMerkleIndex int64 // Keeps track of the last received index to prevent log spam.
 	runError                error
 	preGenesisState         state.BeaconState
+	engineLock              sync.RWMutex
+	engines                 []*engine // execution clients in order of preference, only set when backups are configured.
+	currEngine              int
+	syncingPayloads         uint64
+	engineCheck             chan struct{}
 }
 
 // NewService sets up a new instance with an ethclient when given a web3 endpoint as a string in the config.
 func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 	ctx, cancel := context.WithCancel(ctx)
 	_ = cancel // govet fix for lost cancel. Cancel is handled in service.Stop()
-	depositTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-	if err != nil {
-		cancel()
-		return nil, errors.Wrap(err, "could not set up deposit trie")
-	}
 	genState, err := transition.EmptyGenesisState()
 	if err != nil {
 		return nil, errors.Wrap(err, "could not set up genesis state")
@@ -185,7 +186,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 			LastRequestedBlock: 0,
 		},
 		headerCache: newHeaderCache(),
-		depositTrie: depositTrie,
+		depositTrie: depositsnapshot.New(),
 		chainStartData: &ethpb.ChainStartData{
 			Eth1Data:           &ethpb.Eth1Data{},
 			ChainstartDeposits: make([]*ethpb.Deposit, 0),
@@ -200,6 +201,7 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 			return nil, err
 		}
 	}
+	s.initEngines()
 
 	if err := s.ensureValidPowchainData(ctx); err != nil {
 		return nil, errors.Wrap(err, "unable to validate powchain data")
@@ -218,12 +220,12 @@ func NewService(ctx context.Context, opts ...Option) (*Service, error) {
 
 // Start the powchain service's main event loop.
 func (s *Service) Start() {
-	if err := s.setupExecutionClientConnections(s.ctx, s.cfg.currHttpEndpoint); err != nil {
+	if err := s.setupExecutionClientConnections(s.ctx, s.currEndpoint()); err != nil {
 		log.WithError(err).Error("Could not connect to execution endpoint")
 	}
 	// If the chain has not started already and we don't have access to eth1 nodes, we will not be
 	// able to generate the genesis state.
-	if !s.chainStartData.Chainstarted && s.cfg.currHttpEndpoint.Url == "" {
+	if !s.chainStartData.Chainstarted && s.currEndpoint().Url == "" {
 		// check for genesis state before shutting down the node,
 		// if a genesis state exists, we can continue on.
 		genState, err := s.cfg.beaconDB.GenesisState(s.ctx)
@@ -240,6 +242,11 @@ func (s *Service) Start() {
 	// Poll the execution client connection and fallback if errors occur.
 	s.pollConnectionStatus(s.ctx)
 
+	// Switch to a backup execution client when the current one is unhealthy.
+	if s.failoverEnabled() {
+		go s.monitorEngines(s.ctx)
+	}
+
 	// Check transition configuration for the engine API client in the background.
 	go s.checkTransitionConfiguration(s.ctx, make(chan *feed.Event, 1))
 
@@ -251,9 +258,10 @@ func (s *Service) Stop() error {
 	if s.cancel != nil {
 		defer s.cancel()
 	}
-	if s.rpcClient != nil {
-		s.rpcClient.Close()
+	if client := s.engineClient(); client != nil {
+		client.Close()
 	}
+	s.closeStandbyClients()
 	return nil
 }
 
@@ -291,7 +299,7 @@ func (s *Service) ExecutionClientConnected() bool {
 
 // ExecutionClientEndpoint returns the URL of the current, connected execution client.
 func (s *Service) ExecutionClientEndpoint() string {
-	return s.cfg.currHttpEndpoint.Url
+	return s.currEndpoint().Url
 }
 
 // ExecutionClientConnectionErr returns the error (if any) of the current connection.
@@ -370,7 +378,12 @@ func (s *Service) initDepositCaches(ctx context.Context, ctrs []*ethpb.DepositCo
 		// to be included (rather than the last one to be processed). This was most likely
 		// done as the state cannot represent signed integers.
 		actualIndex := int64(currIndex) - 1 // lint:ignore uintcast -- deposit index will not exceed int64 in your lifetime.
-		if err = s.cfg.depositCache.InsertFinalizedDeposits(ctx, actualIndex); err != nil {
+		// The finalized deposits can only be attributed to the eth1 data block once all of its deposits are included.
+		var executionHash common.Hash
+		if fState.Eth1Data().DepositCount == currIndex {
+			executionHash = common.BytesToHash(fState.Eth1Data().BlockHash)
+		}
+		if err = s.cfg.depositCache.InsertFinalizedDeposits(ctx, actualIndex, executionHash, 0); err != nil {
 			return err
 		}
 
@@ -380,12 +393,13 @@ func (s *Service) initDepositCaches(ctx context.Context, ctrs []*ethpb.DepositCo
 		}
 	}
 	validDepositsCount.Add(float64(currIndex))
-	// Only add pending deposits if the container slice length
-	// is more than the current index in state.
-	if uint64(len(ctrs)) > currIndex {
-		for _, c := range ctrs[currIndex:] {
-			s.cfg.depositCache.InsertPendingDeposit(ctx, c.Deposit, c.Eth1BlockHeight, c.Index, bytesutil.ToBytes32(c.DepositRoot))
+	// Only add pending deposits which have not been included in the state yet. Containers
+	// may not start at index 0 when the node was initialized from a deposit snapshot.
+	for _, c := range ctrs {
+		if c.Index < int64(currIndex) { // lint:ignore uintcast -- deposit index will not exceed int64 in your lifetime.
+			continue
 		}
+		s.cfg.depositCache.InsertPendingDeposit(ctx, c.Deposit, c.Eth1BlockHeight, c.Index, bytesutil.ToBytes32(c.DepositRoot))
 	}
 	return nil
 }
@@ -428,7 +442,7 @@ func (s *Service) batchRequestHeaders(startBlock, endBlock uint64) ([]*types.Hea
 		})
 		headers = append(headers, header)
 	}
-	ioErr := s.rpcClient.BatchCall(elems)
+	ioErr := s.engineClient().BatchCall(elems)
 	if ioErr != nil {
 		return nil, ioErr
 	}
@@ -592,7 +606,7 @@ func (s *Service) run(done <-chan struct{}) {
 		case <-done:
 			s.isRunning = false
 			s.runError = nil
-			s.rpcClient.Close()
+			s.engineClient().Close()
 			s.updateConnectedETH1(false)
 			log.Debug("Context closed, exiting goroutine")
 			return
@@ -741,10 +755,13 @@ func (s *Service) initializeEth1Data(ctx context.Context, eth1DataInDB *ethpb.ET
 	if eth1DataInDB == nil {
 		return nil
 	}
-	var err error
-	s.depositTrie, err = trie.CreateTrieFromProto(eth1DataInDB.Trie)
+	snapshot, err := s.cfg.beaconDB.DepositSnapshot(ctx)
 	if err != nil {
-		return err
+		return errors.Wrap(err, "could not retrieve deposit snapshot")
+	}
+	s.depositTrie, err = depositTreeFromContainers(snapshot, eth1DataInDB.DepositContainers)
+	if err != nil {
+		return errors.Wrap(err, "could not initialize deposit tree")
 	}
 	s.chainStartData = eth1DataInDB.ChainstartData
 	if !reflect.ValueOf(eth1DataInDB.BeaconState).IsZero() {
@@ -754,6 +771,15 @@ func (s *Service) initializeEth1Data(ctx context.Context, eth1DataInDB *ethpb.ET
 		}
 	}
 	s.latestEth1Data = eth1DataInDB.CurrentEth1Data
+	if snapshot != nil {
+		if err := s.cfg.depositCache.InsertFinalizedSnapshot(ctx, snapshot); err != nil {
+			return errors.Wrap(err, "could not insert deposit snapshot")
+		}
+		// Deposit logs up to the snapshot block are already accounted for in the snapshot.
+		if snapshot.ExecutionBlockHeight() > s.latestEth1Data.LastRequestedBlock {
+			s.latestEth1Data.LastRequestedBlock = snapshot.ExecutionBlockHeight()
+		}
+	}
 	numOfItems := s.depositTrie.NumOfItems()
 	s.lastReceivedMerkleIndex = int64(numOfItems - 1)
 	if err := s.initDepositCaches(ctx, eth1DataInDB.DepositContainers); err != nil {
@@ -762,9 +788,47 @@ func (s *Service) initializeEth1Data(ctx context.Context, eth1DataInDB *ethpb.ET
 	return nil
 }
 
+// depositTreeFromContainers builds the deposit tree from the finalized deposit snapshot, if any, and
+// the deposit containers which are not included in the snapshot.
+func depositTreeFromContainers(snapshot *depositsnapshot.DepositTreeSnapshot, ctrs []*ethpb.DepositContainer) (*depositsnapshot.DepositTree, error) {
+	tree := depositsnapshot.New()
+	if snapshot != nil {
+		var err error
+		tree, err = depositsnapshot.FromSnapshot(*snapshot)
+		if err != nil {
+			return nil, err
+		}
+	}
+	var last *ethpb.DepositContainer
+	for _, c := range ctrs {
+		if c.Index < int64(tree.NumOfItems()) {
+			continue
+		}
+		leaf, err := c.Deposit.Data.HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrap(err, "could not hash deposit data")
+		}
+		if err := tree.Insert(leaf[:], int(c.Index)); err != nil {
+			return nil, err
+		}
+		last = c
+	}
+	if last != nil {
+		root, err := tree.HashTreeRoot()
+		if err != nil {
+			return nil, err
+		}
+		if root != bytesutil.ToBytes32(last.DepositRoot) {
+			return nil, errors.Errorf("deposit tree root %#x does not match deposit root %#x of deposit %d", root, last.DepositRoot, last.Index)
+		}
+	}
+	return tree, nil
+}
+
 // Validates that all deposit containers are valid and have their relevant indices
-// in order.
-func validateDepositContainers(ctrs []*ethpb.DepositContainer) bool {
+// in order. Containers may start at any index up to startIndex, as deposits below it
+// are covered by the finalized deposit snapshot.
+func validateDepositContainers(ctrs []*ethpb.DepositContainer, startIndex int64) bool {
 	ctrLen := len(ctrs)
 	// Exit for empty containers.
 	if ctrLen == 0 {
@@ -774,13 +838,17 @@ func validateDepositContainers(ctrs []*ethpb.DepositContainer) bool {
 	sort.Slice(ctrs, func(i, j int) bool {
 		return ctrs[i].Index < ctrs[j].Index
 	})
-	startIndex := int64(0)
+	if ctrs[0].Index > startIndex {
+		log.Info("Recovering missing deposit containers, node is re-requesting missing deposit data")
+		return false
+	}
+	nextIndex := ctrs[0].Index
 	for _, c := range ctrs {
-		if c.Index != startIndex {
+		if c.Index != nextIndex {
 			log.Info("Recovering missing deposit containers, node is re-requesting missing deposit data")
 			return false
 		}
-		startIndex++
+		nextIndex++
 	}
 	return true
 }
@@ -800,7 +868,15 @@ func (s *Service) ensureValidPowchainData(ctx context.Context) error {
 	if err != nil {
 		return errors.Wrap(err, "unable to retrieve eth1 data")
 	}
-	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !validateDepositContainers(eth1Data.DepositContainers) {
+	snapshot, err := s.cfg.beaconDB.DepositSnapshot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "unable to retrieve deposit snapshot")
+	}
+	startIndex := int64(0)
+	if snapshot != nil {
+		startIndex = int64(snapshot.DepositCount()) // lint:ignore uintcast -- Deposit count should not exceed int64 in your lifetime.
+	}
+	if eth1Data == nil || !eth1Data.ChainstartData.Chainstarted || !validateDepositContainers(eth1Data.DepositContainers, startIndex) {
 		pbState, err := native.ProtobufBeaconStatePhase0(s.preGenesisState.ToProtoUnsafe())
 		if err != nil {
 			return err
@@ -816,7 +892,6 @@ func (s *Service) ensureValidPowchainData(ctx context.Context) error {
 			CurrentEth1Data:   s.latestEth1Data,
 			ChainstartData:    s.chainStartData,
 			BeaconState:       pbState,
-			Trie:              s.depositTrie.ToProto(),
 			DepositContainers: s.cfg.depositCache.AllDepositContainers(ctx),
 		}
 		return s.cfg.beaconDB.SaveExecutionChainData(ctx, eth1Data)
@@ -8,6 +8,7 @@ import (
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice"
 	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
@@ -77,7 +78,12 @@ func (f *ForkChoice) Head(
 	if err := f.store.treeRootNode.updateBestDescendant(ctx, jc.Epoch, fc.Epoch, currentEpoch); err != nil {
 		return [32]byte{}, errors.Wrap(err, "could not update best descendant")
 	}
-	return f.store.head(ctx)
+	head, err := f.store.head(ctx)
+	if err != nil {
+		return [32]byte{}, err
+	}
+	f.recorder.record(&headRecord{root: head})
+	return head, nil
 }
 
 // ProcessAttestation processes attestation for vote accounting, it iterates around validator indices
@@ -85,6 +91,7 @@ func (f *ForkChoice) Head(
 func (f *ForkChoice) ProcessAttestation(ctx context.Context, validatorIndices []uint64, blockRoot [32]byte, targetEpoch primitives.Epoch) {
 	_, span := trace.StartSpan(ctx, "doublyLinkedForkchoice.ProcessAttestation")
 	defer span.End()
+	defer f.recorder.record(&attestationRecord{targetEpoch: targetEpoch, root: blockRoot, indices: validatorIndices})
 
 	for _, index := range validatorIndices {
 		// Validator indices will grow the vote cache.
@@ -137,12 +144,34 @@ func (f *ForkChoice) InsertNode(ctx context.Context, state state.BeaconState, ro
 		return errInvalidNilCheckpoint
 	}
 	finalizedEpoch := fc.Epoch
+	var rec *insertNodeRecord
+	if f.recorder != nil {
+		rec = &insertNodeRecord{
+			slot:        slot,
+			root:        root,
+			parentRoot:  parentRoot,
+			payloadHash: payloadHash,
+			justified:   checkpointFromProto(jc),
+			finalized:   checkpointFromProto(fc),
+		}
+		defer func() {
+			rec.proposerBoostRoot = f.store.proposerBoostRoot
+			f.recorder.record(rec)
+		}()
+	}
 	node, err := f.store.insert(ctx, slot, root, parentRoot, payloadHash, justifiedEpoch, finalizedEpoch)
 	if err != nil {
 		return err
 	}
 
-	jc, fc = f.store.pullTips(state, node, jc, fc)
+	jc, fc = f.store.pullTips(node, slot, jc, fc, func() (*ethpb.Checkpoint, *ethpb.Checkpoint, error) {
+		uj, uf, err := precompute.UnrealizedCheckpoints(state)
+		if err == nil && rec != nil {
+			rec.unrealizedComputed = true
+			rec.unrealizedJustified, rec.unrealizedFinalized = checkpointFromProto(uj), checkpointFromProto(uf)
+		}
+		return uj, uf, err
+	})
 	return f.updateCheckpoints(ctx, jc, fc)
 }
 
@@ -347,6 +376,7 @@ func (f *ForkChoice) ProposerBoost() [fieldparams.RootLength]byte {
 
 // SetOptimisticToValid sets the node with the given root as a fully validated node
 func (f *ForkChoice) SetOptimisticToValid(ctx context.Context, root [fieldparams.RootLength]byte) error {
+	defer f.recorder.record(&optimisticToValidRecord{root: root})
 	node, ok := f.store.nodeByRoot[root]
 	if !ok || node == nil {
 		return errors.Wrap(ErrNilNode, "could not set node to valid")
@@ -371,6 +401,7 @@ func (f *ForkChoice) FinalizedCheckpoint() *forkchoicetypes.Checkpoint {
 
 // SetOptimisticToInvalid removes a block with an invalid execution payload from fork choice store
 func (f *ForkChoice) SetOptimisticToInvalid(ctx context.Context, root, parentRoot, payloadHash [fieldparams.RootLength]byte) ([][32]byte, error) {
+	defer f.recorder.record(&optimisticToInvalidRecord{root: root, parentRoot: parentRoot, payloadHash: payloadHash})
 	return f.store.setOptimisticToInvalid(ctx, root, parentRoot, payloadHash)
 }
 
@@ -378,6 +409,7 @@ func (f *ForkChoice) SetOptimisticToInvalid(ctx context.Context, root, parentRoo
 // store-tracked list. Votes from these validators are not accounted for
 // in forkchoice.
 func (f *ForkChoice) InsertSlashedIndex(_ context.Context, index primitives.ValidatorIndex) {
+	defer f.recorder.record(&slashedIndexRecord{index: index})
 	// return early if the index was already included:
 	if f.store.slashedIndices[index] {
 		return
@@ -411,6 +443,7 @@ func (f *ForkChoice) UpdateJustifiedCheckpoint(ctx context.Context, jc *forkchoi
 	if jc == nil {
 		return errInvalidNilCheckpoint
 	}
+	defer f.recorder.record(&checkpointRecord{k: justifiedCheckpointKind, checkpoint: *jc})
 	f.store.prevJustifiedCheckpoint = f.store.justifiedCheckpoint
 	f.store.justifiedCheckpoint = jc
 	if err := f.updateJustifiedBalances(ctx, jc.Root); err != nil {
@@ -424,6 +457,7 @@ func (f *ForkChoice) UpdateFinalizedCheckpoint(fc *forkchoicetypes.Checkpoint) e
 	if fc == nil {
 		return errInvalidNilCheckpoint
 	}
+	defer f.recorder.record(&checkpointRecord{k: finalizedCheckpointKind, checkpoint: *fc})
 	f.store.finalizedCheckpoint = fc
 	return nil
 }
@@ -483,20 +517,35 @@ func (f *ForkChoice) InsertChain(ctx context.Context, chain []*forkchoicetypes.B
 	if len(chain) == 0 {
 		return nil
 	}
+	nodes := make([]*chainNodeRecord, 0, len(chain)-1)
 	for i := len(chain) - 1; i > 0; i-- {
 		b := chain[i].Block
-		r := chain[i-1].Block.ParentRoot()
-		parentRoot := b.ParentRoot()
 		payloadHash, err := blocks.GetBlockPayloadHash(b)
 		if err != nil {
 			return err
 		}
+		nodes = append(nodes, &chainNodeRecord{
+			slot:        b.Slot(),
+			root:        chain[i-1].Block.ParentRoot(),
+			parentRoot:  b.ParentRoot(),
+			payloadHash: payloadHash,
+			justified:   checkpointFromProto(chain[i].JustifiedCheckpoint),
+			finalized:   checkpointFromProto(chain[i].FinalizedCheckpoint),
+		})
+	}
+	defer f.recorder.record(&insertChainRecord{nodes: nodes})
+	return f.insertChain(ctx, nodes)
+}
+
+// insertChain inserts the given nodes, in order, into the store.
+func (f *ForkChoice) insertChain(ctx context.Context, nodes []*chainNodeRecord) error {
+	for _, n := range nodes {
 		if _, err := f.store.insert(ctx,
-			b.Slot(), r, parentRoot, payloadHash,
-			chain[i].JustifiedCheckpoint.Epoch, chain[i].FinalizedCheckpoint.Epoch); err != nil {
+			n.slot, n.root, n.parentRoot, n.payloadHash,
+			n.justified.Epoch, n.finalized.Epoch); err != nil {
 			return err
 		}
-		if err := f.updateCheckpoints(ctx, chain[i].JustifiedCheckpoint, chain[i].FinalizedCheckpoint); err != nil {
+		if err := f.updateCheckpoints(ctx, checkpointToProto(n.justified), checkpointToProto(n.finalized)); err != nil {
 			return err
 		}
 	}
@@ -506,11 +555,13 @@ func (f *ForkChoice) InsertChain(ctx context.Context, chain []*forkchoicetypes.B
 // SetGenesisTime sets the genesisTime tracked by forkchoice
 func (f *ForkChoice) SetGenesisTime(genesisTime uint64) {
 	f.store.genesisTime = genesisTime
+	f.recorder.record(&genesisTimeRecord{genesisTime: genesisTime})
 }
 
 // SetOriginRoot sets the genesis block root
 func (f *ForkChoice) SetOriginRoot(root [32]byte) {
 	f.store.originRoot = root
+	f.recorder.record(&originRootRecord{root: root})
 }
 
 // CachedHeadRoot returns the last cached head root
@@ -618,6 +669,7 @@ func (f *ForkChoice) updateJustifiedBalances(ctx context.Context, root [32]byte)
 	if err != nil {
 		return errors.Wrap(err, "could not get justified balances")
 	}
+	f.recorder.record(&balancesRecord{root: root, balances: balances})
 	f.justifiedBalances = balances
 	f.store.committeeWeight = big.NewInt(0)
 	f.numActiveValidators = 0
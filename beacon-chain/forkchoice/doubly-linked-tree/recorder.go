@@ -0,0 +1,609 @@
+package doublylinkedtree
+
+import (
+	"bufio"
+	"encoding/binary"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	ssz "github.com/prysmaticlabs/fastssz"
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// A fork choice log is a sequence of records. Each record starts with its size as a little endian uint32, followed
+// by the kind of the record as a uint8, the unix time in seconds at which it was written as a uint64, and the SSZ
+// encoding of the recorded input. A log may hold the records of several runs of a beacon node, each of them starts
+// with a start record.
+
+const (
+	recordHeaderSize = 9
+	// maxRecordSize bounds the size of the records read from a fork choice log.
+	maxRecordSize = 1 << 28
+	// chainNodeRecordSize is the size of an encoded chainNodeRecord.
+	chainNodeRecordSize = 8 + 3*32 + 2*40
+)
+
+var errInvalidRecord = errors.New("invalid fork choice record")
+
+type recordKind uint8
+
+const (
+	genesisTimeKind recordKind = iota
+	originRootKind
+	insertNodeKind
+	insertChainKind
+	attestationKind
+	slashedIndexKind
+	newSlotKind
+	headKind
+	optimisticToValidKind
+	optimisticToInvalidKind
+	justifiedCheckpointKind
+	finalizedCheckpointKind
+	justifiedBalancesKind
+	startKind
+)
+
+// record is an input of fork choice written to a fork choice log.
+type record interface {
+	fmt.Stringer
+	kind() recordKind
+	marshalSSZTo(dst []byte) []byte
+	unmarshalSSZ(r *recordReader)
+}
+
+// Recorder writes the inputs of a fork choice store to a fork choice log, so that the decisions of fork choice can
+// be replayed offline with Replay. Records are written while fork choice is locked, so they are buffered and only
+// flushed to the log at every new slot and by Flush.
+type Recorder struct {
+	sync.Mutex
+	w      *bufio.Writer
+	failed bool
+}
+
+// NewRecorder returns a recorder appending fork choice records to w.
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: bufio.NewWriter(w)}
+}
+
+// Flush writes the buffered records to the log.
+func (r *Recorder) Flush() error {
+	r.Lock()
+	defer r.Unlock()
+	if r.failed {
+		return nil
+	}
+	return r.w.Flush()
+}
+
+// SetRecorder makes the fork choice store write all its inputs to the given recorder, after a start record which
+// tells Replay to use a new store. Inputs are recorded once they have been processed, so that the justified balances
+// they required precede them in the log.
+func (f *ForkChoice) SetRecorder(r *Recorder) {
+	f.recorder = r
+	r.record(&startRecord{})
+}
+
+// record appends a record to the fork choice log, it is a no-op on a nil recorder. Fork choice does not depend on
+// the log, so a write error is only logged and stops the recording.
+func (r *Recorder) record(rec record) {
+	if r == nil {
+		return
+	}
+	buf := make([]byte, 4, 64)
+	buf = ssz.MarshalUint8(buf, uint8(rec.kind()))
+	buf = ssz.MarshalUint64(buf, uint64(time.Now().Unix()))
+	buf = rec.marshalSSZTo(buf)
+	binary.LittleEndian.PutUint32(buf, uint32(len(buf)-4))
+
+	r.Lock()
+	defer r.Unlock()
+	if r.failed {
+		return
+	}
+	if _, err := r.w.Write(buf); err != nil {
+		log.WithError(err).Error("Could not write fork choice record, stopped recording fork choice inputs")
+		r.failed = true
+		return
+	}
+	if rec.kind() == newSlotKind {
+		if err := r.w.Flush(); err != nil {
+			log.WithError(err).Error("Could not flush fork choice records, stopped recording fork choice inputs")
+			r.failed = true
+		}
+	}
+}
+
+// readRecord reads the next record of a fork choice log, along with the unix time at which it was written. It
+// returns io.EOF at the end of the log.
+func readRecord(r io.Reader) (record, uint64, error) {
+	var sizeBuf [4]byte
+	if _, err := io.ReadFull(r, sizeBuf[:]); err != nil {
+		return nil, 0, err
+	}
+	size := binary.LittleEndian.Uint32(sizeBuf[:])
+	if size < recordHeaderSize || size > maxRecordSize {
+		return nil, 0, errors.Wrapf(errInvalidRecord, "record size %d", size)
+	}
+	buf := make([]byte, size)
+	if _, err := io.ReadFull(r, buf); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, 0, err
+	}
+	var rec record
+	switch k := recordKind(buf[0]); k {
+	case genesisTimeKind:
+		rec = &genesisTimeRecord{}
+	case originRootKind:
+		rec = &originRootRecord{}
+	case insertNodeKind:
+		rec = &insertNodeRecord{}
+	case insertChainKind:
+		rec = &insertChainRecord{}
+	case attestationKind:
+		rec = &attestationRecord{}
+	case slashedIndexKind:
+		rec = &slashedIndexRecord{}
+	case newSlotKind:
+		rec = &newSlotRecord{}
+	case headKind:
+		rec = &headRecord{}
+	case optimisticToValidKind:
+		rec = &optimisticToValidRecord{}
+	case optimisticToInvalidKind:
+		rec = &optimisticToInvalidRecord{}
+	case justifiedCheckpointKind, finalizedCheckpointKind:
+		rec = &checkpointRecord{k: k}
+	case justifiedBalancesKind:
+		rec = &balancesRecord{}
+	case startKind:
+		rec = &startRecord{}
+	default:
+		return nil, 0, errors.Wrapf(errInvalidRecord, "unknown record kind %d", k)
+	}
+	ts := ssz.UnmarshallUint64(buf[1:recordHeaderSize])
+	rr := &recordReader{buf: buf[recordHeaderSize:]}
+	rec.unmarshalSSZ(rr)
+	if rr.err != nil || rr.pos != len(rr.buf) {
+		return nil, 0, errors.Wrapf(errInvalidRecord, "could not decode %s record", rec.kind())
+	}
+	return rec, ts, nil
+}
+
+func (k recordKind) String() string {
+	switch k {
+	case genesisTimeKind:
+		return "genesis time"
+	case originRootKind:
+		return "origin root"
+	case insertNodeKind:
+		return "insert node"
+	case insertChainKind:
+		return "insert chain"
+	case attestationKind:
+		return "attestation"
+	case slashedIndexKind:
+		return "slashed index"
+	case newSlotKind:
+		return "new slot"
+	case headKind:
+		return "head"
+	case optimisticToValidKind:
+		return "optimistic to valid"
+	case optimisticToInvalidKind:
+		return "optimistic to invalid"
+	case justifiedCheckpointKind:
+		return "justified checkpoint"
+	case finalizedCheckpointKind:
+		return "finalized checkpoint"
+	case justifiedBalancesKind:
+		return "justified balances"
+	default:
+		return fmt.Sprintf("unknown (%d)", uint8(k))
+	}
+}
+
+// recordReader decodes the SSZ encoded fields of a record in order.
+type recordReader struct {
+	buf []byte
+	pos int
+	err error
+}
+
+func (r *recordReader) next(n int) []byte {
+	if r.err == nil && len(r.buf)-r.pos < n {
+		r.err = errInvalidRecord
+	}
+	if r.err != nil {
+		return make([]byte, n)
+	}
+	b := r.buf[r.pos : r.pos+n]
+	r.pos += n
+	return b
+}
+
+func (r *recordReader) uint64() uint64 {
+	return ssz.UnmarshallUint64(r.next(8))
+}
+
+func (r *recordReader) bool() bool {
+	return ssz.UnmarshalBool(r.next(1))
+}
+
+func (r *recordReader) root() [32]byte {
+	return bytesutil.ToBytes32(r.next(32))
+}
+
+func (r *recordReader) checkpoint() forkchoicetypes.Checkpoint {
+	return forkchoicetypes.Checkpoint{Epoch: primitives.Epoch(r.uint64()), Root: r.root()}
+}
+
+// offset reads the offset of the variable size field of a record, which directly follows the fixed size fields.
+func (r *recordReader) offset() {
+	if ssz.ReadOffset(r.next(4)) != uint64(r.pos) && r.err == nil {
+		r.err = errInvalidRecord
+	}
+}
+
+// uint64List reads the remaining bytes of a record as a list of uint64.
+func (r *recordReader) uint64List() []uint64 {
+	if (len(r.buf)-r.pos)%8 != 0 {
+		r.err = errInvalidRecord
+		return nil
+	}
+	l := make([]uint64, 0, (len(r.buf)-r.pos)/8)
+	for r.err == nil && r.pos < len(r.buf) {
+		l = append(l, r.uint64())
+	}
+	return l
+}
+
+func marshalCheckpoint(dst []byte, cp forkchoicetypes.Checkpoint) []byte {
+	dst = ssz.MarshalUint64(dst, uint64(cp.Epoch))
+	return append(dst, cp.Root[:]...)
+}
+
+func marshalUint64List(dst []byte, l []uint64) []byte {
+	for _, v := range l {
+		dst = ssz.MarshalUint64(dst, v)
+	}
+	return dst
+}
+
+func checkpointFromProto(cp *ethpb.Checkpoint) forkchoicetypes.Checkpoint {
+	return forkchoicetypes.Checkpoint{Epoch: cp.Epoch, Root: bytesutil.ToBytes32(cp.Root)}
+}
+
+func checkpointToProto(cp forkchoicetypes.Checkpoint) *ethpb.Checkpoint {
+	return &ethpb.Checkpoint{Epoch: cp.Epoch, Root: cp.Root[:]}
+}
+
+type genesisTimeRecord struct {
+	genesisTime uint64
+}
+
+func (*genesisTimeRecord) kind() recordKind { return genesisTimeKind }
+
+func (rec *genesisTimeRecord) marshalSSZTo(dst []byte) []byte {
+	return ssz.MarshalUint64(dst, rec.genesisTime)
+}
+
+func (rec *genesisTimeRecord) unmarshalSSZ(r *recordReader) {
+	rec.genesisTime = r.uint64()
+}
+
+func (rec *genesisTimeRecord) String() string {
+	return fmt.Sprintf("genesis time %d", rec.genesisTime)
+}
+
+type originRootRecord struct {
+	root [32]byte
+}
+
+func (*originRootRecord) kind() recordKind { return originRootKind }
+
+func (rec *originRootRecord) marshalSSZTo(dst []byte) []byte {
+	return append(dst, rec.root[:]...)
+}
+
+func (rec *originRootRecord) unmarshalSSZ(r *recordReader) {
+	rec.root = r.root()
+}
+
+func (rec *originRootRecord) String() string {
+	return fmt.Sprintf("origin root %#x", rec.root)
+}
+
+// insertNodeRecord holds the values InsertNode reads from the post state of a block. The unrealized checkpoints are
+// only set when fork choice computed them, and the proposer boost root is the one after the insertion, since it
+// depends on the arrival time of the block.
+type insertNodeRecord struct {
+	slot                primitives.Slot
+	root                [32]byte
+	parentRoot          [32]byte
+	payloadHash         [32]byte
+	justified           forkchoicetypes.Checkpoint
+	finalized           forkchoicetypes.Checkpoint
+	unrealizedComputed  bool
+	unrealizedJustified forkchoicetypes.Checkpoint
+	unrealizedFinalized forkchoicetypes.Checkpoint
+	proposerBoostRoot   [32]byte
+}
+
+func (*insertNodeRecord) kind() recordKind { return insertNodeKind }
+
+func (rec *insertNodeRecord) marshalSSZTo(dst []byte) []byte {
+	dst = ssz.MarshalUint64(dst, uint64(rec.slot))
+	dst = append(dst, rec.root[:]...)
+	dst = append(dst, rec.parentRoot[:]...)
+	dst = append(dst, rec.payloadHash[:]...)
+	dst = marshalCheckpoint(dst, rec.justified)
+	dst = marshalCheckpoint(dst, rec.finalized)
+	dst = ssz.MarshalBool(dst, rec.unrealizedComputed)
+	dst = marshalCheckpoint(dst, rec.unrealizedJustified)
+	dst = marshalCheckpoint(dst, rec.unrealizedFinalized)
+	return append(dst, rec.proposerBoostRoot[:]...)
+}
+
+func (rec *insertNodeRecord) unmarshalSSZ(r *recordReader) {
+	rec.slot = primitives.Slot(r.uint64())
+	rec.root = r.root()
+	rec.parentRoot = r.root()
+	rec.payloadHash = r.root()
+	rec.justified = r.checkpoint()
+	rec.finalized = r.checkpoint()
+	rec.unrealizedComputed = r.bool()
+	rec.unrealizedJustified = r.checkpoint()
+	rec.unrealizedFinalized = r.checkpoint()
+	rec.proposerBoostRoot = r.root()
+}
+
+func (rec *insertNodeRecord) String() string {
+	return fmt.Sprintf("insert node slot=%d root=%#x parent=%#x justified=%d finalized=%d", rec.slot, rec.root,
+		rec.parentRoot, rec.justified.Epoch, rec.finalized.Epoch)
+}
+
+// chainNodeRecord holds the values InsertChain inserts for each block of a chain.
+type chainNodeRecord struct {
+	slot        primitives.Slot
+	root        [32]byte
+	parentRoot  [32]byte
+	payloadHash [32]byte
+	justified   forkchoicetypes.Checkpoint
+	finalized   forkchoicetypes.Checkpoint
+}
+
+type insertChainRecord struct {
+	nodes []*chainNodeRecord
+}
+
+func (*insertChainRecord) kind() recordKind { return insertChainKind }
+
+func (rec *insertChainRecord) marshalSSZTo(dst []byte) []byte {
+	dst = ssz.WriteOffset(dst, 4)
+	for _, n := range rec.nodes {
+		dst = ssz.MarshalUint64(dst, uint64(n.slot))
+		dst = append(dst, n.root[:]...)
+		dst = append(dst, n.parentRoot[:]...)
+		dst = append(dst, n.payloadHash[:]...)
+		dst = marshalCheckpoint(dst, n.justified)
+		dst = marshalCheckpoint(dst, n.finalized)
+	}
+	return dst
+}
+
+func (rec *insertChainRecord) unmarshalSSZ(r *recordReader) {
+	r.offset()
+	if (len(r.buf)-r.pos)%chainNodeRecordSize != 0 {
+		r.err = errInvalidRecord
+		return
+	}
+	for r.err == nil && r.pos < len(r.buf) {
+		rec.nodes = append(rec.nodes, &chainNodeRecord{
+			slot:        primitives.Slot(r.uint64()),
+			root:        r.root(),
+			parentRoot:  r.root(),
+			payloadHash: r.root(),
+			justified:   r.checkpoint(),
+			finalized:   r.checkpoint(),
+		})
+	}
+}
+
+func (rec *insertChainRecord) String() string {
+	if len(rec.nodes) == 0 {
+		return "insert chain of 0 nodes"
+	}
+	return fmt.Sprintf("insert chain of %d nodes from slot %d to %d", len(rec.nodes), rec.nodes[0].slot,
+		rec.nodes[len(rec.nodes)-1].slot)
+}
+
+type attestationRecord struct {
+	targetEpoch primitives.Epoch
+	root        [32]byte
+	indices     []uint64
+}
+
+func (*attestationRecord) kind() recordKind { return attestationKind }
+
+func (rec *attestationRecord) marshalSSZTo(dst []byte) []byte {
+	dst = ssz.MarshalUint64(dst, uint64(rec.targetEpoch))
+	dst = append(dst, rec.root[:]...)
+	dst = ssz.WriteOffset(dst, 8+32+4)
+	return marshalUint64List(dst, rec.indices)
+}
+
+func (rec *attestationRecord) unmarshalSSZ(r *recordReader) {
+	rec.targetEpoch = primitives.Epoch(r.uint64())
+	rec.root = r.root()
+	r.offset()
+	rec.indices = r.uint64List()
+}
+
+func (rec *attestationRecord) String() string {
+	return fmt.Sprintf("attestation root=%#x target=%d validators=%d", rec.root, rec.targetEpoch, len(rec.indices))
+}
+
+type slashedIndexRecord struct {
+	index primitives.ValidatorIndex
+}
+
+func (*slashedIndexRecord) kind() recordKind { return slashedIndexKind }
+
+func (rec *slashedIndexRecord) marshalSSZTo(dst []byte) []byte {
+	return ssz.MarshalUint64(dst, uint64(rec.index))
+}
+
+func (rec *slashedIndexRecord) unmarshalSSZ(r *recordReader) {
+	rec.index = primitives.ValidatorIndex(r.uint64())
+}
+
+func (rec *slashedIndexRecord) String() string {
+	return fmt.Sprintf("slashed index %d", rec.index)
+}
+
+type newSlotRecord struct {
+	slot primitives.Slot
+}
+
+func (*newSlotRecord) kind() recordKind { return newSlotKind }
+
+func (rec *newSlotRecord) marshalSSZTo(dst []byte) []byte {
+	return ssz.MarshalUint64(dst, uint64(rec.slot))
+}
+
+func (rec *newSlotRecord) unmarshalSSZ(r *recordReader) {
+	rec.slot = primitives.Slot(r.uint64())
+}
+
+func (rec *newSlotRecord) String() string {
+	return fmt.Sprintf("new slot %d", rec.slot)
+}
+
+// headRecord holds the head fork choice computed when Head was called.
+type headRecord struct {
+	root [32]byte
+}
+
+func (*headRecord) kind() recordKind { return headKind }
+
+func (rec *headRecord) marshalSSZTo(dst []byte) []byte {
+	return append(dst, rec.root[:]...)
+}
+
+func (rec *headRecord) unmarshalSSZ(r *recordReader) {
+	rec.root = r.root()
+}
+
+func (rec *headRecord) String() string {
+	return fmt.Sprintf("head %#x", rec.root)
+}
+
+type optimisticToValidRecord struct {
+	root [32]byte
+}
+
+func (*optimisticToValidRecord) kind() recordKind { return optimisticToValidKind }
+
+func (rec *optimisticToValidRecord) marshalSSZTo(dst []byte) []byte {
+	return append(dst, rec.root[:]...)
+}
+
+func (rec *optimisticToValidRecord) unmarshalSSZ(r *recordReader) {
+	rec.root = r.root()
+}
+
+func (rec *optimisticToValidRecord) String() string {
+	return fmt.Sprintf("optimistic to valid %#x", rec.root)
+}
+
+type optimisticToInvalidRecord struct {
+	root        [32]byte
+	parentRoot  [32]byte
+	payloadHash [32]byte
+}
+
+func (*optimisticToInvalidRecord) kind() recordKind { return optimisticToInvalidKind }
+
+func (rec *optimisticToInvalidRecord) marshalSSZTo(dst []byte) []byte {
+	dst = append(dst, rec.root[:]...)
+	dst = append(dst, rec.parentRoot[:]...)
+	return append(dst, rec.payloadHash[:]...)
+}
+
+func (rec *optimisticToInvalidRecord) unmarshalSSZ(r *recordReader) {
+	rec.root = r.root()
+	rec.parentRoot = r.root()
+	rec.payloadHash = r.root()
+}
+
+func (rec *optimisticToInvalidRecord) String() string {
+	return fmt.Sprintf("optimistic to invalid %#x", rec.root)
+}
+
+// checkpointRecord holds a justified or finalized checkpoint set on fork choice.
+type checkpointRecord struct {
+	k          recordKind
+	checkpoint forkchoicetypes.Checkpoint
+}
+
+func (rec *checkpointRecord) kind() recordKind { return rec.k }
+
+func (rec *checkpointRecord) marshalSSZTo(dst []byte) []byte {
+	return marshalCheckpoint(dst, rec.checkpoint)
+}
+
+func (rec *checkpointRecord) unmarshalSSZ(r *recordReader) {
+	rec.checkpoint = r.checkpoint()
+}
+
+func (rec *checkpointRecord) String() string {
+	return fmt.Sprintf("%s epoch=%d root=%#x", rec.k, rec.checkpoint.Epoch, rec.checkpoint.Root)
+}
+
+// balancesRecord holds the justified balances fork choice obtained for a checkpoint root.
+type balancesRecord struct {
+	root     [32]byte
+	balances []uint64
+}
+
+func (*balancesRecord) kind() recordKind { return justifiedBalancesKind }
+
+func (rec *balancesRecord) marshalSSZTo(dst []byte) []byte {
+	dst = append(dst, rec.root[:]...)
+	dst = ssz.WriteOffset(dst, 32+4)
+	return marshalUint64List(dst, rec.balances)
+}
+
+func (rec *balancesRecord) unmarshalSSZ(r *recordReader) {
+	rec.root = r.root()
+	r.offset()
+	rec.balances = r.uint64List()
+}
+
+func (rec *balancesRecord) String() string {
+	return fmt.Sprintf("justified balances root=%#x validators=%d", rec.root, len(rec.balances))
+}
+
+// startRecord starts the records of a run of a beacon node.
+type startRecord struct{}
+
+func (*startRecord) kind() recordKind { return startKind }
+
+func (*startRecord) marshalSSZTo(dst []byte) []byte {
+	return dst
+}
+
+func (*startRecord) unmarshalSSZ(*recordReader) {}
+
+func (*startRecord) String() string {
+	return "start of recording"
+}
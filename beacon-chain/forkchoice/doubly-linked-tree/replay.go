@@ -0,0 +1,174 @@
+package doublylinkedtree
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"math/big"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+var errUnrealizedCheckpointsNotRecorded = errors.New("unrealized checkpoints were not recorded")
+
+// ReplayStep is the state of a fork choice store after it processed a record of a fork choice log.
+type ReplayStep struct {
+	Index        int             // index of the record in the log.
+	Time         time.Time       // time at which the record was written.
+	Input        string          // description of the recorded input.
+	Err          error           // error returned by fork choice when processing the input.
+	Head         [32]byte        // last head computed by fork choice.
+	HeadSlot     primitives.Slot // slot of the head.
+	HeadWeight   *big.Int        // weight of the head.
+	RecordedHead *[32]byte       // head computed by the recorded node, only set for head records.
+	Tips         []*TipWeight    // leaves of the fork choice tree.
+}
+
+// TipWeight is the weight of a leaf of the fork choice tree.
+type TipWeight struct {
+	Root   [32]byte
+	Slot   primitives.Slot
+	Weight *big.Int
+}
+
+// Mismatch returns true if the replayed head differs from the head computed by the recorded node.
+func (s *ReplayStep) Mismatch() bool {
+	return s.RecordedHead != nil && s.Err == nil && *s.RecordedHead != s.Head
+}
+
+// replayer feeds recorded inputs to a fork choice store.
+type replayer struct {
+	f *ForkChoice
+	// genesis time of the recorded node.
+	genesisTime uint64
+	// justified balances obtained by the recorded node, by checkpoint root.
+	balances map[[32]byte][]uint64
+}
+
+// Replay feeds the inputs read from a fork choice log to a new fork choice store, replaced at the start of every
+// recorded run, and calls fn with the state of the store after each of them. The genesis time of the store is shifted so that fork choice, which depends on the
+// wall clock, processes each input as if it was the time the input was recorded at. Replay stops at the end of the
+// log or when fn returns an error.
+func Replay(ctx context.Context, r io.Reader, fn func(*ReplayStep) error) error {
+	rp := &replayer{}
+	rp.reset()
+	br := bufio.NewReader(r)
+	for i := 0; ; i++ {
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+		rec, ts, err := readRecord(br)
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return errors.Wrapf(err, "could not read record %d", i)
+		}
+		step := rp.apply(ctx, rec, ts)
+		step.Index = i
+		if err := fn(step); err != nil {
+			return err
+		}
+	}
+}
+
+// reset replaces the store with a new one, for the records of a new run of the recorded node.
+func (rp *replayer) reset() {
+	rp.f = New()
+	rp.genesisTime = 0
+	rp.balances = make(map[[32]byte][]uint64)
+	rp.f.SetBalancesByRooter(rp.justifiedBalances)
+}
+
+func (rp *replayer) justifiedBalances(_ context.Context, root [32]byte) ([]uint64, error) {
+	balances, ok := rp.balances[root]
+	if !ok {
+		return nil, errors.Errorf("no justified balances recorded for root %#x", root)
+	}
+	return balances, nil
+}
+
+// apply feeds a recorded input, written at the given unix time, to fork choice.
+func (rp *replayer) apply(ctx context.Context, rec record, ts uint64) *ReplayStep {
+	if _, ok := rec.(*startRecord); ok {
+		rp.reset()
+	}
+	f := rp.f
+	if g, ok := rec.(*genesisTimeRecord); ok {
+		rp.genesisTime = g.genesisTime
+	}
+	if rp.genesisTime != 0 {
+		f.store.genesisTime = rp.genesisTime + uint64(time.Now().Unix()) - ts
+	}
+
+	step := &ReplayStep{Time: time.Unix(int64(ts), 0), Input: rec.String()}
+	switch rec := rec.(type) {
+	case *startRecord, *genesisTimeRecord:
+	case *originRootRecord:
+		f.SetOriginRoot(rec.root)
+	case *insertNodeRecord:
+		step.Err = f.insertRecordedNode(ctx, rec)
+	case *insertChainRecord:
+		step.Err = f.insertChain(ctx, rec.nodes)
+	case *attestationRecord:
+		f.ProcessAttestation(ctx, rec.indices, rec.root, rec.targetEpoch)
+	case *slashedIndexRecord:
+		f.InsertSlashedIndex(ctx, rec.index)
+	case *newSlotRecord:
+		step.Err = f.NewSlot(ctx, rec.slot)
+	case *headRecord:
+		recorded := rec.root
+		step.RecordedHead = &recorded
+		_, step.Err = f.Head(ctx)
+	case *optimisticToValidRecord:
+		step.Err = f.SetOptimisticToValid(ctx, rec.root)
+	case *optimisticToInvalidRecord:
+		_, step.Err = f.SetOptimisticToInvalid(ctx, rec.root, rec.parentRoot, rec.payloadHash)
+	case *checkpointRecord:
+		cp := rec.checkpoint
+		if rec.k == justifiedCheckpointKind {
+			step.Err = f.UpdateJustifiedCheckpoint(ctx, &cp)
+		} else {
+			step.Err = f.UpdateFinalizedCheckpoint(&cp)
+		}
+	case *balancesRecord:
+		rp.balances[rec.root] = rec.balances
+	}
+
+	if head := f.store.headNode; head != nil {
+		step.Head, step.HeadSlot, step.HeadWeight = head.root, head.slot, new(big.Int).Set(head.weight)
+	}
+	roots, slots := f.Tips()
+	for i, root := range roots {
+		weight, err := f.Weight(root)
+		if err != nil {
+			continue
+		}
+		step.Tips = append(step.Tips, &TipWeight{Root: root, Slot: slots[i], Weight: new(big.Int).Set(weight)})
+	}
+	return step
+}
+
+// insertRecordedNode inserts a node the way InsertNode does, from the values recorded out of the post state of the
+// block.
+func (f *ForkChoice) insertRecordedNode(ctx context.Context, rec *insertNodeRecord) error {
+	// The proposer boost depends on the arrival time of the block, keep the one of the recorded node.
+	defer func() {
+		f.store.proposerBoostRoot = rec.proposerBoostRoot
+	}()
+	node, err := f.store.insert(ctx, rec.slot, rec.root, rec.parentRoot, rec.payloadHash, rec.justified.Epoch, rec.finalized.Epoch)
+	if err != nil {
+		return err
+	}
+	jc, fc := f.store.pullTips(node, rec.slot, checkpointToProto(rec.justified), checkpointToProto(rec.finalized),
+		func() (*ethpb.Checkpoint, *ethpb.Checkpoint, error) {
+			if !rec.unrealizedComputed {
+				return nil, nil, errUnrealizedCheckpointsNotRecorded
+			}
+			return checkpointToProto(rec.unrealizedJustified), checkpointToProto(rec.unrealizedFinalized), nil
+		})
+	return f.updateCheckpoints(ctx, jc, fc)
+}
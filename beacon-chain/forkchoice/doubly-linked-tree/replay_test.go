@@ -0,0 +1,154 @@
+package doublylinkedtree
+
+import (
+	"bytes"
+	"context"
+	"io"
+	"testing"
+	"time"
+
+	forkchoicetypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestRecordEncoding(t *testing.T) {
+	cp := forkchoicetypes.Checkpoint{Epoch: 3, Root: indexToHash(3)}
+	records := []record{
+		&startRecord{},
+		&genesisTimeRecord{genesisTime: 1606824023},
+		&originRootRecord{root: indexToHash(1)},
+		&insertNodeRecord{
+			slot:                10,
+			root:                indexToHash(10),
+			parentRoot:          indexToHash(9),
+			payloadHash:         indexToHash(100),
+			justified:           cp,
+			finalized:           forkchoicetypes.Checkpoint{Epoch: 2, Root: indexToHash(2)},
+			unrealizedComputed:  true,
+			unrealizedJustified: forkchoicetypes.Checkpoint{Epoch: 4, Root: indexToHash(4)},
+			unrealizedFinalized: cp,
+			proposerBoostRoot:   indexToHash(10),
+		},
+		&insertChainRecord{nodes: []*chainNodeRecord{
+			{slot: 1, root: indexToHash(1), parentRoot: indexToHash(0), justified: cp, finalized: cp},
+			{slot: 2, root: indexToHash(2), parentRoot: indexToHash(1), payloadHash: indexToHash(200)},
+		}},
+		&attestationRecord{targetEpoch: 5, root: indexToHash(5), indices: []uint64{1, 7, 42}},
+		&attestationRecord{targetEpoch: 5, root: indexToHash(5), indices: []uint64{}},
+		&slashedIndexRecord{index: 12},
+		&newSlotRecord{slot: 64},
+		&headRecord{root: indexToHash(6)},
+		&optimisticToValidRecord{root: indexToHash(7)},
+		&optimisticToInvalidRecord{root: indexToHash(8), parentRoot: indexToHash(7), payloadHash: indexToHash(80)},
+		&checkpointRecord{k: justifiedCheckpointKind, checkpoint: cp},
+		&checkpointRecord{k: finalizedCheckpointKind, checkpoint: cp},
+		&balancesRecord{root: indexToHash(3), balances: []uint64{32, 31, 0}},
+	}
+	buf := &bytes.Buffer{}
+	r := NewRecorder(buf)
+	for _, rec := range records {
+		r.record(rec)
+	}
+	require.NoError(t, r.Flush())
+	for _, want := range records {
+		got, ts, err := readRecord(buf)
+		require.NoError(t, err)
+		assert.DeepEqual(t, want, got)
+		assert.Equal(t, true, time.Since(time.Unix(int64(ts), 0)) < time.Minute)
+	}
+	_, _, err := readRecord(buf)
+	require.Equal(t, io.EOF, err)
+
+	// A truncated record is reported.
+	buf.Reset()
+	r.record(&headRecord{root: indexToHash(1)})
+	require.NoError(t, r.Flush())
+	buf.Truncate(buf.Len() - 1)
+	_, _, err = readRecord(buf)
+	require.Equal(t, io.ErrUnexpectedEOF, err)
+}
+
+func TestReplay(t *testing.T) {
+	ctx := context.Background()
+	buf := &bytes.Buffer{}
+	f := New()
+	f.SetRecorder(NewRecorder(buf))
+	f.SetBalancesByRooter(func(context.Context, [32]byte) ([]uint64, error) {
+		return []uint64{10, 10, 10, 10}, nil
+	})
+	genesis := time.Now().Add(-time.Duration(10*params.BeaconConfig().SecondsPerSlot) * time.Second)
+	f.SetGenesisTime(uint64(genesis.Unix()))
+	f.SetOriginRoot(params.BeaconConfig().ZeroHash)
+	require.NoError(t, f.UpdateJustifiedCheckpoint(ctx, &forkchoicetypes.Checkpoint{Root: params.BeaconConfig().ZeroHash}))
+	require.NoError(t, f.UpdateFinalizedCheckpoint(&forkchoicetypes.Checkpoint{Root: params.BeaconConfig().ZeroHash}))
+
+	st, root, err := prepareForkchoiceState(ctx, 0, params.BeaconConfig().ZeroHash, [32]byte{}, [32]byte{}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	st, root, err = prepareForkchoiceState(ctx, 8, indexToHash(1), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	st, root, err = prepareForkchoiceState(ctx, 10, indexToHash(2), params.BeaconConfig().ZeroHash, [32]byte{}, 0, 0)
+	require.NoError(t, err)
+	require.NoError(t, f.InsertNode(ctx, st, root))
+	require.Equal(t, indexToHash(2), f.ProposerBoost())
+
+	f.ProcessAttestation(ctx, []uint64{0, 1}, indexToHash(1), 0)
+	f.ProcessAttestation(ctx, []uint64{2}, indexToHash(2), 0)
+	require.NoError(t, f.NewSlot(ctx, 11))
+	head, err := f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(1), head)
+
+	f.InsertSlashedIndex(ctx, 0)
+	f.InsertSlashedIndex(ctx, 1)
+	head, err = f.Head(ctx)
+	require.NoError(t, err)
+	require.Equal(t, indexToHash(2), head)
+
+	// The records of a new run of the node are replayed on a new store.
+	require.NoError(t, f.recorder.Flush())
+	recorded := buf.Bytes()
+	var steps []*ReplayStep
+	require.NoError(t, Replay(ctx, bytes.NewReader(append(append([]byte{}, recorded...), recorded...)), func(step *ReplayStep) error {
+		steps = append(steps, step)
+		return nil
+	}))
+	require.Equal(t, 32, len(steps))
+	for i, step := range steps[:16] {
+		require.Equal(t, step.Input, steps[16+i].Input)
+		require.Equal(t, step.Head, steps[16+i].Head)
+	}
+	steps = steps[16:]
+	heads := 0
+	for i, step := range steps {
+		require.Equal(t, 16+i, step.Index)
+		require.NoError(t, step.Err, step.Input)
+		require.Equal(t, false, step.Mismatch(), step.Input)
+		if step.RecordedHead != nil {
+			heads++
+		}
+	}
+	require.Equal(t, 2, heads)
+	last := steps[len(steps)-1]
+	require.Equal(t, indexToHash(2), last.Head)
+	require.Equal(t, primitives.Slot(10), last.HeadSlot)
+	require.Equal(t, 2, len(last.Tips))
+	assert.Equal(t, uint64(10), last.HeadWeight.Uint64())
+
+	// Replay stops when the callback fails.
+	buf.Reset()
+	f.recorder.record(&newSlotRecord{slot: 1})
+	f.recorder.record(&newSlotRecord{slot: 2})
+	require.NoError(t, f.recorder.Flush())
+	count := 0
+	err = Replay(ctx, buf, func(*ReplayStep) error {
+		count++
+		return io.ErrShortWrite
+	})
+	require.ErrorIs(t, err, io.ErrShortWrite)
+	require.Equal(t, 1, count)
+}
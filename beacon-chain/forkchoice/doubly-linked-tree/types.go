@@ -19,6 +19,7 @@ type ForkChoice struct {
 	justifiedBalances   []uint64                    // tracks individual validator's last justified balances.
 	numActiveValidators uint64                      // tracks the total number of active validators.
 	balancesByRoot      forkchoice.BalancesByRooter // handler to obtain balances for the state with a given root
+	recorder            *Recorder                   // writes the inputs of fork choice to a log when set.
 }
 
 // Store defines the fork choice store which includes block nodes and the last view of checkpoint information.
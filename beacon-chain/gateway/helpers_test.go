@@ -20,7 +20,7 @@ func TestDefaultConfig(t *testing.T) {
 		require.Equal(t, 2, len(cfg.V1AlphaPbMux.Patterns))
 		assert.Equal(t, "/eth/v1alpha1/", cfg.V1AlphaPbMux.Patterns[0])
 		assert.Equal(t, "/eth/v1alpha2/", cfg.V1AlphaPbMux.Patterns[1])
-		assert.Equal(t, 4, len(cfg.V1AlphaPbMux.Registrations))
+		assert.Equal(t, 5, len(cfg.V1AlphaPbMux.Registrations))
 	})
 
 	t.Run("With debug endpoints", func(t *testing.T) {
@@ -34,7 +34,7 @@ func TestDefaultConfig(t *testing.T) {
 		require.Equal(t, 2, len(cfg.V1AlphaPbMux.Patterns))
 		assert.Equal(t, "/eth/v1alpha1/", cfg.V1AlphaPbMux.Patterns[0])
 		assert.Equal(t, "/eth/v1alpha2/", cfg.V1AlphaPbMux.Patterns[1])
-		assert.Equal(t, 5, len(cfg.V1AlphaPbMux.Registrations))
+		assert.Equal(t, 6, len(cfg.V1AlphaPbMux.Registrations))
 	})
 	t.Run("Without Prysm API", func(t *testing.T) {
 		cfg := DefaultConfig(true, "eth")
@@ -51,6 +51,6 @@ func TestDefaultConfig(t *testing.T) {
 		require.Equal(t, 2, len(cfg.V1AlphaPbMux.Patterns))
 		assert.Equal(t, "/eth/v1alpha1/", cfg.V1AlphaPbMux.Patterns[0])
 		assert.Equal(t, "/eth/v1alpha2/", cfg.V1AlphaPbMux.Patterns[1])
-		assert.Equal(t, 5, len(cfg.V1AlphaPbMux.Registrations))
+		assert.Equal(t, 6, len(cfg.V1AlphaPbMux.Registrations))
 	})
 }
@@ -90,4 +90,12 @@ var (
 			"validator_index",
 		},
 	)
+	// savedPerformanceRecordsCounter used to track the number of persisted validator performance records
+	savedPerformanceRecordsCounter = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "monitor",
+			Name:      "saved_performance_records_total",
+			Help:      "Number of per-epoch validator performance records saved to the database",
+		},
+	)
 )
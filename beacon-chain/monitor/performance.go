@@ -0,0 +1,89 @@
+package monitor
+
+import (
+	"context"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	montypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/sirupsen/logrus"
+)
+
+// epochRecord returns the performance record of a tracked validator for the given epoch, creating it if needed.
+// It assumes the caller holds the service Lock.
+func (s *Service) epochRecord(idx primitives.ValidatorIndex, epoch primitives.Epoch) *montypes.ValidatorPerformance {
+	if s.epochPerformance == nil {
+		s.epochPerformance = make(map[primitives.Epoch]map[primitives.ValidatorIndex]*montypes.ValidatorPerformance)
+	}
+	records, ok := s.epochPerformance[epoch]
+	if !ok {
+		records = make(map[primitives.ValidatorIndex]*montypes.ValidatorPerformance)
+		s.epochPerformance[epoch] = records
+	}
+	r, ok := records[idx]
+	if !ok {
+		r = &montypes.ValidatorPerformance{ValidatorIndex: idx, Epoch: epoch}
+		records[idx] = r
+	}
+	return r
+}
+
+// processEpochBoundary is called with the state of the first block of an epoch seen by the monitor. It records the
+// balances of the tracked validators which are active in that epoch, and persists the records of the epochs whose
+// attestations can no longer be included in a block.
+func (s *Service) processEpochBoundary(ctx context.Context, st state.ReadOnlyBeaconState, epoch primitives.Epoch) {
+	s.Lock()
+	if s.epochPerformance != nil && epoch <= s.lastRecordedEpoch {
+		s.Unlock()
+		return
+	}
+	if s.epochPerformance == nil {
+		s.epochPerformance = make(map[primitives.Epoch]map[primitives.ValidatorIndex]*montypes.ValidatorPerformance)
+	}
+	s.lastRecordedEpoch = epoch
+	for idx := range s.TrackedValidators {
+		balance, err := st.BalanceAtIndex(idx)
+		if err != nil {
+			continue
+		}
+		if epoch > 0 {
+			if prev, ok := s.epochPerformance[epoch-1][idx]; ok {
+				prev.EndBalance = balance
+			}
+		}
+		val, err := st.ValidatorAtIndexReadOnly(idx)
+		if err != nil || !helpers.IsActiveValidatorUsingTrie(val, epoch) {
+			continue
+		}
+		s.epochRecord(idx, epoch).StartBalance = balance
+	}
+	var records []*montypes.ValidatorPerformance
+	for e, epochRecords := range s.epochPerformance {
+		if e+1 >= epoch {
+			continue
+		}
+		for _, r := range epochRecords {
+			records = append(records, r)
+		}
+		delete(s.epochPerformance, e)
+	}
+	s.Unlock()
+
+	if len(records) == 0 || s.config.BeaconDB == nil {
+		return
+	}
+	if err := s.config.BeaconDB.SaveValidatorPerformance(ctx, records); err != nil {
+		log.WithError(err).Error("Could not save validator performance")
+		return
+	}
+	for _, r := range records {
+		if !r.AttestationIncluded {
+			log.WithFields(logrus.Fields{
+				"ValidatorIndex": r.ValidatorIndex,
+				"Epoch":          r.Epoch,
+			}).Warn("No attestation was included for epoch")
+		}
+	}
+	savedPerformanceRecordsCounter.Add(float64(len(records)))
+}
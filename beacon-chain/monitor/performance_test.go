@@ -0,0 +1,51 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestProcessEpochBoundary(t *testing.T) {
+	hook := logTest.NewGlobal()
+	ctx := context.Background()
+	s := setupService(t)
+	beaconDB := s.config.BeaconDB
+	st, _ := util.DeterministicGenesisStateAltair(t, 256)
+
+	s.processEpochBoundary(ctx, st, 1)
+	require.Equal(t, 4, len(s.epochPerformance[1]))
+	require.Equal(t, uint64(32000000000), s.epochPerformance[1][1].StartBalance)
+	s.epochPerformance[1][1].AttestationIncluded = true
+
+	require.NoError(t, st.UpdateBalancesAtIndex(1, 32100000000))
+	s.processEpochBoundary(ctx, st, 2)
+	require.Equal(t, uint64(32100000000), s.epochPerformance[1][1].EndBalance)
+	require.Equal(t, uint64(32100000000), s.epochPerformance[2][1].StartBalance)
+	// Attestations of epoch 1 can still be included during epoch 2, nothing is saved yet.
+	performance, err := beaconDB.ValidatorPerformance(ctx, []primitives.ValidatorIndex{1}, 0, 2)
+	require.NoError(t, err)
+	require.Equal(t, 0, len(performance[1]))
+
+	// An epoch which was already processed is ignored.
+	s.processEpochBoundary(ctx, st, 2)
+	s.processEpochBoundary(ctx, st, 3)
+	_, ok := s.epochPerformance[1]
+	require.Equal(t, false, ok)
+	require.Equal(t, 2, len(s.epochPerformance))
+
+	performance, err = beaconDB.ValidatorPerformance(ctx, []primitives.ValidatorIndex{1, 2}, 0, 3)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(performance[1]))
+	require.Equal(t, primitives.Epoch(1), performance[1][0].Epoch)
+	require.Equal(t, uint64(32000000000), performance[1][0].StartBalance)
+	require.Equal(t, uint64(32100000000), performance[1][0].EndBalance)
+	require.Equal(t, true, performance[1][0].AttestationIncluded)
+	require.Equal(t, 1, len(performance[2]))
+	require.Equal(t, false, performance[2][0].AttestationIncluded)
+	require.LogsContain(t, hook, "No attestation was included for epoch")
+}
@@ -155,6 +155,13 @@ func (s *Service) processIncludedAttestation(ctx context.Context, state state.Be
 
 			s.latestPerformance[primitives.ValidatorIndex(idx)] = latestPerf
 			s.aggregatedPerformance[primitives.ValidatorIndex(idx)] = aggregatedPerf
+			record := s.epochRecord(primitives.ValidatorIndex(idx), slots.ToEpoch(att.Data.Slot))
+			record.AttestationIncluded = true
+			record.AttestedSlot = latestPerf.attestedSlot
+			record.InclusionSlot = latestPerf.inclusionSlot
+			record.TimelySource = latestPerf.timelySource
+			record.TimelyTarget = latestPerf.timelyTarget
+			record.TimelyHead = latestPerf.timelyHead
 			log.WithFields(logFields).Info("Attestation included")
 		}
 	}
@@ -202,6 +209,7 @@ func (s *Service) processAggregatedAttestation(ctx context.Context, att *ethpb.A
 		aggregatedPerf := s.aggregatedPerformance[att.AggregatorIndex]
 		aggregatedPerf.totalAggregations++
 		s.aggregatedPerformance[att.AggregatorIndex] = aggregatedPerf
+		s.epochRecord(att.AggregatorIndex, slots.ToEpoch(att.Aggregate.Data.Slot)).Aggregations++
 		aggregationCounter.WithLabelValues(fmt.Sprintf("%d", att.AggregatorIndex)).Inc()
 	}
 
@@ -53,6 +53,7 @@ func (s *Service) processBlock(ctx context.Context, b interfaces.ReadOnlySignedB
 		slots.SyncCommitteePeriod(currEpoch) == slots.SyncCommitteePeriod(lastSyncedEpoch) {
 		s.updateSyncCommitteeTrackedVals(st)
 	}
+	s.processEpochBoundary(ctx, st, currEpoch)
 
 	s.processSyncAggregate(st, blk)
 	s.processProposedBlock(st, root, blk)
@@ -87,6 +88,7 @@ func (s *Service) processProposedBlock(state state.BeaconState, root [32]byte, b
 		aggPerf := s.aggregatedPerformance[blk.ProposerIndex()]
 		aggPerf.totalProposedCount++
 		s.aggregatedPerformance[blk.ProposerIndex()] = aggPerf
+		s.epochRecord(blk.ProposerIndex(), slots.ToEpoch(blk.Slot())).ProposedBlocks++
 
 		parentRoot := blk.ParentRoot()
 		log.WithFields(logrus.Fields{
@@ -2,16 +2,19 @@ package monitor
 
 import (
 	"context"
-	"errors"
 	"sort"
 	"sync"
 
+	"github.com/pkg/errors"
+
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	montypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -58,6 +61,7 @@ type ValidatorMonitorConfig struct {
 	AttestationNotifier operation.Notifier
 	HeadFetcher         blockchain.HeadFetcher
 	StateGen            stategen.StateManager
+	BeaconDB            db.HeadAccessDatabase
 	InitialSyncComplete chan struct{}
 }
 
@@ -70,7 +74,7 @@ type Service struct {
 	isLogging bool
 
 	// Locks access to TrackedValidators, latestPerformance, aggregatedPerformance,
-	// trackedSyncedCommitteeIndices and lastSyncedEpoch
+	// trackedSyncedCommitteeIndices, lastSyncedEpoch, epochPerformance and lastRecordedEpoch
 	sync.RWMutex
 
 	TrackedValidators           map[primitives.ValidatorIndex]bool
@@ -78,10 +82,21 @@ type Service struct {
 	aggregatedPerformance       map[primitives.ValidatorIndex]ValidatorAggregatedPerformance
 	trackedSyncCommitteeIndices map[primitives.ValidatorIndex][]primitives.CommitteeIndex
 	lastSyncedEpoch             primitives.Epoch
+	// epochPerformance holds the performance records of the epochs which are not persisted yet.
+	epochPerformance  map[primitives.Epoch]map[primitives.ValidatorIndex]*montypes.ValidatorPerformance
+	lastRecordedEpoch primitives.Epoch
 }
 
-// NewService sets up a new validator monitor service instance when given a list of validator indices to track.
+// NewService sets up a new validator monitor service instance when given a list of validator indices to track. The
+// validators added at runtime, which are persisted in the database, are tracked as well.
 func NewService(ctx context.Context, config *ValidatorMonitorConfig, tracked []primitives.ValidatorIndex) (*Service, error) {
+	if config.BeaconDB != nil {
+		persisted, err := config.BeaconDB.MonitoredValidators(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not get monitored validators")
+		}
+		tracked = append(tracked, persisted...)
+	}
 	ctx, cancel := context.WithCancel(ctx)
 	r := &Service{
 		config:                      config,
@@ -211,6 +226,9 @@ func (s *Service) monitorRoutine(stateChannel chan *feed.Event, stateSub event.S
 	for {
 		select {
 		case e := <-stateChannel:
+			if !s.hasTrackedValidators() {
+				continue
+			}
 			if e.Type == statefeed.BlockProcessed {
 				data, ok := e.Data.(*statefeed.BlockProcessedData)
 				if !ok {
@@ -221,6 +239,9 @@ func (s *Service) monitorRoutine(stateChannel chan *feed.Event, stateSub event.S
 				}
 			}
 		case e := <-opChannel:
+			if !s.hasTrackedValidators() {
+				continue
+			}
 			switch e.Type {
 			case operation.UnaggregatedAttReceived:
 				data, ok := e.Data.(*operation.UnAggregatedAttReceivedData)
@@ -261,6 +282,14 @@ func (s *Service) monitorRoutine(stateChannel chan *feed.Event, stateSub event.S
 	}
 }
 
+// hasTrackedValidators returns true if at least one validator is tracked, so that events are not processed for
+// nothing when all the validators are added at runtime.
+func (s *Service) hasTrackedValidators() bool {
+	s.RLock()
+	defer s.RUnlock()
+	return len(s.TrackedValidators) > 0
+}
+
 // TrackedIndex returns true if input  validator index exists in tracked validator list.
 // It assumes the caller holds the service Lock
 func (s *Service) trackedIndex(idx primitives.ValidatorIndex) bool {
@@ -92,6 +92,7 @@ func setupService(t *testing.T) *Service {
 			StateGen:            stategen.New(beaconDB, doublylinkedtree.New()),
 			StateNotifier:       chainService.StateNotifier(),
 			HeadFetcher:         chainService,
+			BeaconDB:            beaconDB,
 			AttestationNotifier: chainService.OperationNotifier(),
 			InitialSyncComplete: make(chan struct{}),
 		},
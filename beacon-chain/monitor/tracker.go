@@ -0,0 +1,113 @@
+package monitor
+
+import (
+	"context"
+	"sort"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// ErrUnknownValidator is returned when tracking a validator which is not part of the registry of the head state.
+var ErrUnknownValidator = errors.New("unknown validator")
+
+// ValidatorTracker allows to list and update the validators tracked by the monitor at runtime.
+type ValidatorTracker interface {
+	TrackedIndices() []primitives.ValidatorIndex
+	TrackValidators(ctx context.Context, indices []primitives.ValidatorIndex) error
+	UntrackValidators(ctx context.Context, indices []primitives.ValidatorIndex) error
+}
+
+var _ ValidatorTracker = (*Service)(nil)
+
+// TrackedIndices returns the indices of the tracked validators in increasing order.
+func (s *Service) TrackedIndices() []primitives.ValidatorIndex {
+	s.RLock()
+	defer s.RUnlock()
+	tracked := make([]primitives.ValidatorIndex, 0, len(s.TrackedValidators))
+	for idx := range s.TrackedValidators {
+		tracked = append(tracked, idx)
+	}
+	sort.Slice(tracked, func(i, j int) bool { return tracked[i] < tracked[j] })
+	return tracked
+}
+
+// TrackValidators starts tracking the given validators. They are saved in the database so that they are still
+// tracked after a restart.
+func (s *Service) TrackValidators(ctx context.Context, indices []primitives.ValidatorIndex) error {
+	st, err := s.config.HeadFetcher.HeadState(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not get head state")
+	}
+	for _, idx := range indices {
+		if uint64(idx) >= uint64(st.NumValidators()) {
+			return errors.Wrapf(ErrUnknownValidator, "validator %d", idx)
+		}
+	}
+	if s.config.BeaconDB != nil {
+		if err := s.config.BeaconDB.SaveMonitoredValidators(ctx, indices); err != nil {
+			return errors.Wrap(err, "could not save monitored validators")
+		}
+	}
+
+	s.Lock()
+	added := make([]primitives.ValidatorIndex, 0, len(indices))
+	for _, idx := range indices {
+		if s.trackedIndex(idx) {
+			continue
+		}
+		s.TrackedValidators[idx] = true
+		added = append(added, idx)
+	}
+	isLogging := s.isLogging
+	if isLogging {
+		epoch := slots.ToEpoch(st.Slot())
+		for _, idx := range added {
+			balance, err := st.BalanceAtIndex(idx)
+			if err != nil {
+				log.WithError(err).WithField("ValidatorIndex", idx).Error(
+					"Could not fetch starting balance, skipping aggregated logs.")
+				balance = 0
+			}
+			s.aggregatedPerformance[idx] = ValidatorAggregatedPerformance{
+				startEpoch:   epoch,
+				startBalance: balance,
+			}
+			s.latestPerformance[idx] = ValidatorLatestPerformance{
+				balance: balance,
+			}
+		}
+	}
+	s.Unlock()
+
+	if isLogging && len(added) > 0 {
+		s.updateSyncCommitteeTrackedVals(st)
+	}
+	log.WithField("ValidatorIndices", added).Info("Started tracking validators")
+	return nil
+}
+
+// UntrackValidators stops tracking the given validators and removes them from the database. Validators given with
+// the --monitor-indices flag are tracked again after a restart.
+func (s *Service) UntrackValidators(ctx context.Context, indices []primitives.ValidatorIndex) error {
+	if s.config.BeaconDB != nil {
+		if err := s.config.BeaconDB.DeleteMonitoredValidators(ctx, indices); err != nil {
+			return errors.Wrap(err, "could not delete monitored validators")
+		}
+	}
+
+	s.Lock()
+	defer s.Unlock()
+	for _, idx := range indices {
+		delete(s.TrackedValidators, idx)
+		delete(s.latestPerformance, idx)
+		delete(s.aggregatedPerformance, idx)
+		delete(s.trackedSyncCommitteeIndices, idx)
+		for _, records := range s.epochPerformance {
+			delete(records, idx)
+		}
+	}
+	log.WithField("ValidatorIndices", indices).Info("Stopped tracking validators")
+	return nil
+}
@@ -0,0 +1,58 @@
+package monitor
+
+import (
+	"context"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestTrackValidators(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+	beaconDB := s.config.BeaconDB
+
+	err := s.TrackValidators(ctx, []primitives.ValidatorIndex{3, 300})
+	require.ErrorIs(t, err, ErrUnknownValidator)
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 12, 15}, s.TrackedIndices())
+
+	require.NoError(t, s.TrackValidators(ctx, []primitives.ValidatorIndex{3, 12}))
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 2, 3, 12, 15}, s.TrackedIndices())
+	_, ok := s.aggregatedPerformance[3]
+	require.Equal(t, false, ok)
+
+	s.isLogging = true
+	require.NoError(t, s.TrackValidators(ctx, []primitives.ValidatorIndex{4}))
+	require.Equal(t, uint64(32000000000), s.aggregatedPerformance[4].startBalance)
+	require.Equal(t, uint64(32000000000), s.latestPerformance[4].balance)
+
+	persisted, err := beaconDB.MonitoredValidators(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []primitives.ValidatorIndex{3, 4, 12}, persisted)
+
+	// Validators added at runtime are tracked after a restart.
+	restarted, err := NewService(ctx, &ValidatorMonitorConfig{BeaconDB: beaconDB}, []primitives.ValidatorIndex{1})
+	require.NoError(t, err)
+	require.DeepEqual(t, []primitives.ValidatorIndex{1, 3, 4, 12}, restarted.TrackedIndices())
+}
+
+func TestUntrackValidators(t *testing.T) {
+	ctx := context.Background()
+	s := setupService(t)
+	beaconDB := s.config.BeaconDB
+	require.NoError(t, beaconDB.SaveMonitoredValidators(ctx, []primitives.ValidatorIndex{12, 15}))
+	s.epochRecord(12, 1)
+
+	require.NoError(t, s.UntrackValidators(ctx, []primitives.ValidatorIndex{1, 12}))
+	require.DeepEqual(t, []primitives.ValidatorIndex{2, 15}, s.TrackedIndices())
+	_, ok := s.latestPerformance[1]
+	require.Equal(t, false, ok)
+	_, ok = s.trackedSyncCommitteeIndices[12]
+	require.Equal(t, false, ok)
+	require.Equal(t, 0, len(s.epochPerformance[1]))
+
+	persisted, err := beaconDB.MonitoredValidators(ctx)
+	require.NoError(t, err)
+	require.DeepEqual(t, []primitives.ValidatorIndex{15}, persisted)
+}
@@ -0,0 +1,31 @@
+// Package types defines the records of validator performance persisted by the validator monitor.
+package types
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// ValidatorPerformance is the performance of a tracked validator during a single epoch. An attestation is
+// attributed to the epoch of the slot it attests to, blocks and sync committee contributions to the epoch of the
+// block which included them.
+type ValidatorPerformance struct {
+	ValidatorIndex primitives.ValidatorIndex
+	Epoch          primitives.Epoch
+	// StartBalance is the balance of the validator at the first block of the epoch seen by the monitor, and
+	// EndBalance its balance at the first block of the next epoch.
+	StartBalance uint64
+	EndBalance   uint64
+	// AttestationIncluded is false if no attestation of the validator for this epoch was included in a block.
+	AttestationIncluded bool
+	AttestedSlot        primitives.Slot
+	InclusionSlot       primitives.Slot
+	TimelySource        bool
+	TimelyTarget        bool
+	TimelyHead          bool
+	ProposedBlocks      uint64
+	Aggregations        uint64
+	// SyncCommitteeExpected is the number of sync committee contributions expected from the validator in the
+	// blocks of the epoch, and SyncCommitteeContributions the number which were actually included.
+	SyncCommitteeExpected      uint64
+	SyncCommitteeContributions uint64
+}
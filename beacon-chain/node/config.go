@@ -67,6 +67,20 @@ func configureBuilderCircuitBreaker(cliCtx *cli.Context) error {
 			return err
 		}
 	}
+	if cliCtx.IsSet(flags.MaxRelayConsecutiveFailures.Name) {
+		c := params.BeaconConfig().Copy()
+		c.MaxRelayConsecutiveFailures = primitives.Slot(cliCtx.Int(flags.MaxRelayConsecutiveFailures.Name))
+		if err := params.SetActive(c); err != nil {
+			return err
+		}
+	}
+	if cliCtx.IsSet(flags.MaxRelayEpochFailures.Name) {
+		c := params.BeaconConfig().Copy()
+		c.MaxRelayEpochFailures = primitives.Slot(cliCtx.Int(flags.MaxRelayEpochFailures.Name))
+		if err := params.SetActive(c); err != nil {
+			return err
+		}
+	}
 	if cliCtx.IsSet(flags.LocalBlockValueBoost.Name) {
 		c := params.BeaconConfig().Copy()
 		c.LocalBlockValueBoost = cliCtx.Uint64(flags.LocalBlockValueBoost.Name)
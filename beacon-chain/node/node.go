@@ -24,7 +24,9 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositcache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/era"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/pruner"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/slasherkv"
 	interopcoldstart "github.com/prysmaticlabs/prysm/v4/beacon-chain/deterministic-genesis"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
@@ -57,6 +59,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/container/slice"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
 	"github.com/prysmaticlabs/prysm/v4/monitoring/prometheus"
 	"github.com/prysmaticlabs/prysm/v4/runtime"
 	"github.com/prysmaticlabs/prysm/v4/runtime/debug"
@@ -108,6 +111,8 @@ type BeaconNode struct {
 	GenesisInitializer      genesis.Initializer
 	CheckpointInitializer   checkpoint.Initializer
 	forkChoicer             forkchoice.ForkChoicer
+	forkChoiceRecord        *os.File
+	forkChoiceRecorder      *doublylinkedtree.Recorder
 	clockWaiter             startup.ClockWaiter
 	initialSyncComplete     chan struct{}
 }
@@ -190,7 +195,13 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 	synchronizer := startup.NewClockSynchronizer()
 	beacon.clockWaiter = synchronizer
 
-	beacon.forkChoicer = doublylinkedtree.New()
+	forkChoicer := doublylinkedtree.New()
+	if recordFile := cliCtx.String(flags.ForkChoiceRecordFile.Name); recordFile != "" {
+		if err := beacon.startForkChoiceRecorder(forkChoicer, recordFile); err != nil {
+			return nil, err
+		}
+	}
+	beacon.forkChoicer = forkChoicer
 	depositAddress, err := execution.DepositContractAddress()
 	if err != nil {
 		return nil, err
@@ -250,6 +261,16 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Backfill Service")
+	if err := beacon.registerBackfillService(cliCtx, bfs); err != nil {
+		return nil, err
+	}
+
+	log.Debugln("Registering Pruner Service")
+	if err := beacon.registerPrunerService(cliCtx); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering Slasher Service")
 	if err := beacon.registerSlasherService(); err != nil {
 		return nil, err
@@ -260,6 +281,11 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
+	log.Debugln("Registering Validator Monitoring Service")
+	if err := beacon.registerValidatorMonitorService(beacon.initialSyncComplete); err != nil {
+		return nil, err
+	}
+
 	log.Debugln("Registering RPC Service")
 	router := mux.NewRouter()
 	if err := beacon.registerRPCService(router); err != nil {
@@ -271,11 +297,6 @@ func New(cliCtx *cli.Context, opts ...Option) (*BeaconNode, error) {
 		return nil, err
 	}
 
-	log.Debugln("Registering Validator Monitoring Service")
-	if err := beacon.registerValidatorMonitorService(beacon.initialSyncComplete); err != nil {
-		return nil, err
-	}
-
 	if !cliCtx.Bool(cmd.DisableMonitoringFlag.Name) {
 		log.Debugln("Registering Prometheus Service")
 		if err := beacon.registerPrometheusService(cliCtx); err != nil {
@@ -354,11 +375,36 @@ func (b *BeaconNode) Close() {
 	if err := b.db.Close(); err != nil {
 		log.WithError(err).Error("Failed to close database")
 	}
+	if b.forkChoiceRecord != nil {
+		if err := b.forkChoiceRecorder.Flush(); err != nil {
+			log.WithError(err).Error("Failed to flush fork choice records")
+		}
+		if err := b.forkChoiceRecord.Close(); err != nil {
+			log.WithError(err).Error("Failed to close fork choice record file")
+		}
+	}
 	b.collector.unregister()
 	b.cancel()
 	close(b.stop)
 }
 
+// startForkChoiceRecorder makes fork choice write its inputs to the given file, after the records of the previous runs.
+func (b *BeaconNode) startForkChoiceRecorder(f *doublylinkedtree.ForkChoice, recordFile string) error {
+	path, err := file.ExpandPath(recordFile)
+	if err != nil {
+		return err
+	}
+	record, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, params.BeaconIoConfig().ReadWritePermissions)
+	if err != nil {
+		return errors.Wrap(err, "could not open fork choice record file")
+	}
+	b.forkChoiceRecord = record
+	b.forkChoiceRecorder = doublylinkedtree.NewRecorder(record)
+	f.SetRecorder(b.forkChoiceRecorder)
+	log.WithField("path", path).Info("Recording fork choice inputs")
+	return nil
+}
+
 func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 	baseDir := cliCtx.String(cmd.DataDirFlag.Name)
 	dbPath := filepath.Join(baseDir, kv.BeaconNodeDbDirName)
@@ -428,6 +474,12 @@ func (b *BeaconNode) startDB(cliCtx *cli.Context, depositAddress string) error {
 		}
 	}
 
+	if eraDir := cliCtx.String(flags.EraDir.Name); eraDir != "" {
+		if err := era.Import(b.ctx, d, eraDir); err != nil {
+			return errors.Wrap(err, "could not import era files")
+		}
+	}
+
 	knownContract, err := b.db.DepositContractAddress(b.ctx)
 	if err != nil {
 		return err
@@ -498,6 +550,9 @@ func (b *BeaconNode) startSlasherDB(cliCtx *cli.Context) error {
 
 func (b *BeaconNode) startStateGen(ctx context.Context, bfs *backfill.Status, fc forkchoice.ForkChoicer) error {
 	opts := []stategen.StateGenOption{stategen.WithBackfillStatus(bfs)}
+	if b.cliCtx.Bool(flags.SaveStateDiffs.Name) {
+		opts = append(opts, stategen.WithStateDiffs())
+	}
 	sg := stategen.New(b.db, fc, opts...)
 
 	cp, err := b.db.FinalizedCheckpoint(ctx)
@@ -549,6 +604,7 @@ func (b *BeaconNode) registerP2P(cliCtx *cli.Context) error {
 		MetaDataDir:       cliCtx.String(cmd.P2PMetadata.Name),
 		TCPPort:           cliCtx.Uint(cmd.P2PTCPPort.Name),
 		UDPPort:           cliCtx.Uint(cmd.P2PUDPPort.Name),
+		QUICPort:          cliCtx.Uint(cmd.P2PQUICPort.Name),
 		MaxPeers:          cliCtx.Uint(cmd.P2PMaxPeers.Name),
 		AllowListCIDR:     cliCtx.String(cmd.P2PAllowList.Name),
 		DenyListCIDR:      slice.SplitCommaSeparated(cliCtx.StringSlice(cmd.P2PDenyList.Name)),
@@ -615,6 +671,7 @@ func (b *BeaconNode) registerBlockchainService(fc forkchoice.ForkChoicer, gs *st
 		blockchain.WithBLSToExecPool(b.blsToExecPool),
 		blockchain.WithP2PBroadcaster(b.fetchP2P()),
 		blockchain.WithStateNotifier(b),
+		blockchain.WithOperationNotifier(b),
 		blockchain.WithAttestationService(attService),
 		blockchain.WithStateGen(b.stateGen),
 		blockchain.WithSlasherAttestationsFeed(b.slasherAttestationsFeed),
@@ -720,6 +777,39 @@ func (b *BeaconNode) registerInitialSyncService(complete chan struct{}) error {
 	return b.services.RegisterService(is)
 }
 
+func (b *BeaconNode) registerBackfillService(cliCtx *cli.Context, bfs *backfill.Status) error {
+	opts := []backfill.ServiceOption{
+		backfill.WithBatchSize(uint64(flags.Get().BlockBatchLimit)),
+		backfill.WithBlocksPerSecond(cliCtx.Uint64(flags.BackfillBlocksPerSecond.Name)),
+		backfill.WithInitialSyncComplete(b.initialSyncComplete),
+	}
+	bf, err := backfill.NewService(b.ctx, b.db, bfs, b.fetchP2P(), b.clockWaiter, opts...)
+	if err != nil {
+		return err
+	}
+	return b.services.RegisterService(bf)
+}
+
+func (b *BeaconNode) registerPrunerService(cliCtx *cli.Context) error {
+	if !cliCtx.Bool(flags.BeaconDBPruning.Name) {
+		return nil
+	}
+	var chainService *blockchain.Service
+	if err := b.services.FetchService(&chainService); err != nil {
+		return err
+	}
+	opts := []pruner.ServiceOption{
+		pruner.WithRetentionEpochs(primitives.Epoch(cliCtx.Uint64(flags.PrunerRetentionEpochs.Name))),
+		pruner.WithHeadStateFetcher(chainService),
+		pruner.WithInitialSyncComplete(b.initialSyncComplete),
+	}
+	p, err := pruner.NewService(b.ctx, b.db, b.clockWaiter, opts...)
+	if err != nil {
+		return err
+	}
+	return b.services.RegisterService(p)
+}
+
 func (b *BeaconNode) registerSlasherService() error {
 	if !features.Get().EnableSlasher {
 		return nil
@@ -741,6 +831,7 @@ func (b *BeaconNode) registerSlasherService() error {
 		AttestationStateFetcher: chainService,
 		StateGen:                b.stateGen,
 		SlashingPoolInserter:    b.slashingsPool,
+		OperationNotifier:       b,
 		SyncChecker:             syncService,
 		HeadStateFetcher:        chainService,
 		ClockWaiter:             b.clockWaiter,
@@ -768,10 +859,12 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 	}
 
 	var slasherService *slasher.Service
+	var slashingInspector slasher.SlashingInspector
 	if features.Get().EnableSlasher {
 		if err := b.services.FetchService(&slasherService); err != nil {
 			return err
 		}
+		slashingInspector = slasherService
 	}
 
 	genesisValidators := b.cliCtx.Uint64(flags.InteropNumValidatorsFlag.Name)
@@ -800,7 +893,26 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 	maxMsgSize := b.cliCtx.Int(cmd.GrpcMaxCallRecvMsgSizeFlag.Name)
 	enableDebugRPCEndpoints := b.cliCtx.Bool(flags.EnableDebugRPCEndpoints.Name)
 
+	var monitorService *monitor.Service
+	if err := b.services.FetchService(&monitorService); err != nil {
+		return err
+	}
+	var adminAPIToken string
+	if tokenFile := b.cliCtx.String(flags.AdminAPITokenFile.Name); tokenFile != "" {
+		token, err := file.ReadFileAsBytes(tokenFile)
+		if err != nil {
+			return errors.Wrap(err, "could not read admin API token file")
+		}
+		adminAPIToken = strings.TrimSpace(string(token))
+		if adminAPIToken == "" {
+			return fmt.Errorf("admin API token file %s is empty", tokenFile)
+		}
+	}
+
 	p2pService := b.fetchP2P()
+	// Runtime peer administration is only supported by the libp2p service itself,
+	// the endpoints report it as unavailable otherwise.
+	peerAdmin, _ := p2pService.(p2p.PeerAdmin)
 	rpcService := rpc.NewService(b.ctx, &rpc.Config{
 		ExecutionEngineCaller:         web3Service,
 		ExecutionPayloadReconstructor: web3Service,
@@ -814,6 +926,7 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		Broadcaster:                   p2pService,
 		PeersFetcher:                  p2pService,
 		PeerManager:                   p2pService,
+		PeerAdmin:                     peerAdmin,
 		MetadataProvider:              p2pService,
 		ChainInfoFetcher:              chainService,
 		HeadFetcher:                   chainService,
@@ -821,6 +934,7 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		ForkFetcher:                   chainService,
 		ForkchoiceFetcher:             chainService,
 		FinalizationFetcher:           chainService,
+		LightClientFetcher:            chainService,
 		BlockReceiver:                 chainService,
 		AttestationReceiver:           chainService,
 		GenesisTimeFetcher:            chainService,
@@ -831,6 +945,7 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		SlashingsPool:                 b.slashingsPool,
 		BLSChangesPool:                b.blsToExecPool,
 		SlashingChecker:               slasherService,
+		SlashingInspector:             slashingInspector,
 		SyncCommitteeObjectPool:       b.syncCommitteePool,
 		ExecutionChainService:         web3Service,
 		ExecutionChainInfoFetcher:     web3Service,
@@ -849,6 +964,8 @@ func (b *BeaconNode) registerRPCService(router *mux.Router) error {
 		BlockBuilder:                  b.fetchBuilderService(),
 		Router:                        router,
 		ClockWaiter:                   b.clockWaiter,
+		ValidatorMonitor:              monitorService,
+		AdminAPIToken:                 adminAPIToken,
 	})
 
 	return b.services.RegisterService(rpcService)
@@ -950,10 +1067,8 @@ func (b *BeaconNode) registerDeterminsticGenesisService() error {
 }
 
 func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan struct{}) error {
+	// The service is always registered so that validators can be tracked at runtime through the API.
 	cliSlice := b.cliCtx.IntSlice(cmd.ValidatorMonitorIndicesFlag.Name)
-	if cliSlice == nil {
-		return nil
-	}
 	tracked := make([]primitives.ValidatorIndex, len(cliSlice))
 	for i := range tracked {
 		tracked[i] = primitives.ValidatorIndex(cliSlice[i])
@@ -968,6 +1083,7 @@ func (b *BeaconNode) registerValidatorMonitorService(initialSyncComplete chan st
 		AttestationNotifier: b,
 		StateGen:            b.stateGen,
 		HeadFetcher:         chainService,
+		BeaconDB:            b.db,
 		InitialSyncComplete: initialSyncComplete,
 	}
 	svc, err := monitor.NewService(b.ctx, monitorConfig, tracked)
@@ -985,6 +1101,7 @@ func (b *BeaconNode) registerBuilderService(cliCtx *cli.Context) error {
 
 	opts := append(b.serviceFlagOpts.builderOpts,
 		builder.WithHeadFetcher(chainService),
+		builder.WithTimeFetcher(chainService),
 		builder.WithDatabase(b.db))
 	if cliCtx.Bool(flags.EnableRegistrationCache.Name) {
 		opts = append(opts, builder.WithRegistrationCache())
@@ -34,13 +34,27 @@ func (_ *Service) InterceptPeerDial(_ peer.ID) (allow bool) {
 func (s *Service) InterceptAddrDial(pid peer.ID, m multiaddr.Multiaddr) (allow bool) {
 	// Disallow bad peers from dialing in.
 	if s.peers.IsBad(pid) {
+		gaterRejectedConnections.WithLabelValues(transportFromMultiaddr(m), "outbound").Inc()
 		return false
 	}
-	return filterConnections(s.addrFilter, m)
+	if !filterConnections(s.addrFilter, m) {
+		gaterRejectedConnections.WithLabelValues(transportFromMultiaddr(m), "outbound").Inc()
+		return false
+	}
+	if s.peers.IsAddrBanned(m) {
+		gaterRejectedConnections.WithLabelValues(transportFromMultiaddr(m), "outbound").Inc()
+		return false
+	}
+	return true
 }
 
 // InterceptAccept checks whether the incidental inbound connection is allowed.
 func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
+	defer func() {
+		if !allow {
+			gaterRejectedConnections.WithLabelValues(transportFromMultiaddr(n.RemoteMultiaddr()), "inbound").Inc()
+		}
+	}()
 	// Deny all incoming connections before we are ready
 	if !s.started {
 		return false
@@ -53,6 +67,11 @@ func (s *Service) InterceptAccept(n network.ConnMultiaddrs) (allow bool) {
 			"reason": "exceeded dial limit"}).Trace("Not accepting inbound dial from ip address")
 		return false
 	}
+	if s.peers.IsAddrBanned(n.RemoteMultiaddr()) {
+		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
+			"reason": "banned subnet"}).Trace("Not accepting inbound dial from ip address")
+		return false
+	}
 	if s.isPeerAtLimit(true /* inbound */) {
 		log.WithFields(logrus.Fields{"peer": n.RemoteMultiaddr(),
 			"reason": "at peer limit"}).Trace("Not accepting inbound dial")
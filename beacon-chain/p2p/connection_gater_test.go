@@ -509,3 +509,33 @@ func (c *maEndpoints) LocalMultiaddr() ma.Multiaddr {
 func (c *maEndpoints) RemoteMultiaddr() ma.Multiaddr {
 	return c.raddr
 }
+
+func TestService_InterceptAddrDial_QUIC(t *testing.T) {
+	s := &Service{
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, 1*time.Second, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			ScorerParams: &scorers.Config{},
+		}),
+	}
+	var err error
+	s.addrFilter, err = configureFilter(&Config{AllowListCIDR: "public", DenyListCIDR: []string{"212.67.89.112/16"}})
+	require.NoError(t, err)
+
+	// QUIC addresses must be filtered exactly like their TCP counterparts.
+	tests := []struct {
+		ip      string
+		allowed bool
+	}{
+		{ip: "91.65.69.69", allowed: true},
+		{ip: "212.67.10.122", allowed: false},
+		{ip: "192.168.1.0", allowed: false},
+	}
+	for _, tt := range tests {
+		tcpAddr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", tt.ip, 3000))
+		require.NoError(t, err)
+		quicAddr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/udp/%d/quic-v1", tt.ip, 3000))
+		require.NoError(t, err)
+		assert.Equal(t, tt.allowed, s.InterceptAddrDial("", tcpAddr), "Unexpected result for tcp address %s", tcpAddr)
+		assert.Equal(t, tt.allowed, s.InterceptAddrDial("", quicAddr), "Unexpected result for quic address %s", quicAddr)
+	}
+}
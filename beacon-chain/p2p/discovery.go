@@ -15,6 +15,7 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/go-bitfield"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	ecdsaprysm "github.com/prysmaticlabs/prysm/v4/crypto/ecdsa"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
@@ -34,6 +35,12 @@ type Listener interface {
 	LocalNode() *enode.LocalNode
 }
 
+// quicProtocol is the "quic" key, which holds the QUIC port of the node.
+type quicProtocol uint16
+
+// ENRKey returns the ENR key of the QUIC port entry.
+func (quicProtocol) ENRKey() string { return "quic" }
+
 // RefreshENR uses an epoch to refresh the enr entry for our node
 // with the tracked committee ids for the epoch, allowing our node
 // to be dynamically discoverable by others given our tracked committee ids.
@@ -167,6 +174,7 @@ func (s *Service) createListener(
 		ipAddr,
 		int(s.cfg.UDPPort),
 		int(s.cfg.TCPPort),
+		int(s.cfg.QUICPort),
 	)
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create local node")
@@ -215,7 +223,7 @@ func (s *Service) createListener(
 func (s *Service) createLocalNode(
 	privKey *ecdsa.PrivateKey,
 	ipAddr net.IP,
-	udpPort, tcpPort int,
+	udpPort, tcpPort, quicPort int,
 ) (*enode.LocalNode, error) {
 	db, err := enode.OpenDB("")
 	if err != nil {
@@ -229,6 +237,9 @@ func (s *Service) createLocalNode(
 	localNode.Set(ipEntry)
 	localNode.Set(udpEntry)
 	localNode.Set(tcpEntry)
+	if features.Get().EnableQUIC {
+		localNode.Set(quicProtocol(quicPort))
+	}
 	localNode.SetFallbackIP(ipAddr)
 	localNode.SetFallbackUDP(udpPort)
 
@@ -315,20 +326,30 @@ func (s *Service) filterPeer(node *enode.Node) bool {
 // determines whether our currently connected and
 // active peers are above our set max peer limit.
 func (s *Service) isPeerAtLimit(inbound bool) bool {
-	numOfConns := len(s.host.Network().Peers())
+	// Trusted peers are never counted against our limits.
+	untrusted := func(pids []peer.ID) int {
+		count := 0
+		for _, pid := range pids {
+			if !s.peers.IsTrustedPeer(pid) {
+				count++
+			}
+		}
+		return count
+	}
+	numOfConns := untrusted(s.host.Network().Peers())
 	maxPeers := int(s.cfg.MaxPeers)
 	// If we are measuring the limit for inbound peers
 	// we apply the high watermark buffer.
 	if inbound {
 		maxPeers += highWatermarkBuffer
 		maxInbound := s.peers.InboundLimit() + highWatermarkBuffer
-		currInbound := len(s.peers.InboundConnected())
+		currInbound := untrusted(s.peers.InboundConnected())
 		// Exit early if we are at the inbound limit.
 		if currInbound >= maxInbound {
 			return true
 		}
 	}
-	activePeers := len(s.Peers().Active())
+	activePeers := untrusted(s.Peers().Active())
 	return activePeers >= maxPeers || numOfConns >= maxPeers
 }
 
@@ -403,6 +424,10 @@ func convertToMultiAddr(nodes []*enode.Node) []ma.Multiaddr {
 	return multiAddrs
 }
 
+// convertToAddrInfo returns the peer info used to dial the node along with its
+// TCP multiaddress. When QUIC is enabled and the node advertises a quic entry
+// in its ENR, the QUIC address is listed ahead of the TCP one so that it is
+// preferred when dialing.
 func convertToAddrInfo(node *enode.Node) (*peer.AddrInfo, ma.Multiaddr, error) {
 	multiAddr, err := convertToSingleMultiAddr(node)
 	if err != nil {
@@ -412,9 +437,33 @@ func convertToAddrInfo(node *enode.Node) (*peer.AddrInfo, ma.Multiaddr, error) {
 	if err != nil {
 		return nil, nil, err
 	}
+	if !features.Get().EnableQUIC {
+		return info, multiAddr, nil
+	}
+	quicAddr, err := convertToQuicMultiAddr(node)
+	if err != nil {
+		return nil, nil, err
+	}
+	if quicAddr != nil {
+		info.Addrs = append([]ma.Multiaddr{quicAddr}, info.Addrs...)
+	}
 	return info, multiAddr, nil
 }
 
+// convertToQuicMultiAddr returns the QUIC multiaddress advertised by the node,
+// without the peer id component. A nil address is returned if the node's ENR
+// has no quic entry.
+func convertToQuicMultiAddr(node *enode.Node) (ma.Multiaddr, error) {
+	var port quicProtocol
+	if err := node.Load(&port); err != nil {
+		if enr.IsNotFound(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "could not retrieve quic port")
+	}
+	return quicMultiAddressBuilder(node.IP().String(), uint(port))
+}
+
 func convertToSingleMultiAddr(node *enode.Node) (ma.Multiaddr, error) {
 	pubkey := node.Pubkey()
 	assertedKey, err := ecdsaprysm.ConvertToInterfacePubkey(pubkey)
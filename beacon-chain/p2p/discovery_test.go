@@ -27,6 +27,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/scorers"
 	testp2p "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/wrapper"
 	leakybucket "github.com/prysmaticlabs/prysm/v4/container/leaky-bucket"
@@ -140,12 +141,59 @@ func TestMultiAddrsConversion_InvalidIPAddr(t *testing.T) {
 		genesisTime:           time.Now(),
 		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
 	}
-	node, err := s.createLocalNode(pkey, addr, 0, 0)
+	node, err := s.createLocalNode(pkey, addr, 0, 0, 0)
 	require.NoError(t, err)
 	multiAddr := convertToMultiAddr([]*enode.Node{node.Node()})
 	assert.Equal(t, 0, len(multiAddr), "Invalid ip address converted successfully")
 }
 
+func TestCreateLocalNode_QUICEntry(t *testing.T) {
+	addr, pkey := createAddrAndPrivKey(t)
+	s := &Service{
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+	}
+
+	node, err := s.createLocalNode(pkey, addr, 2000, 3000, 4000)
+	require.NoError(t, err)
+	var port quicProtocol
+	err = node.Node().Load(&port)
+	assert.Equal(t, true, enr.IsNotFound(err), "Expected no quic entry when QUIC is disabled")
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableQUIC: true})
+	defer resetCfg()
+	node, err = s.createLocalNode(pkey, addr, 2000, 3000, 4000)
+	require.NoError(t, err)
+	require.NoError(t, node.Node().Load(&port))
+	assert.Equal(t, quicProtocol(4000), port)
+}
+
+func TestConvertToAddrInfo_PrefersQUIC(t *testing.T) {
+	addr, pkey := createAddrAndPrivKey(t)
+	s := &Service{
+		genesisTime:           time.Now(),
+		genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+	}
+	resetCfg := features.InitWithReset(&features.Flags{EnableQUIC: true})
+	defer resetCfg()
+
+	node, err := s.createLocalNode(pkey, addr, 2000, 3000, 4000)
+	require.NoError(t, err)
+	info, tcpAddr, err := convertToAddrInfo(node.Node())
+	require.NoError(t, err)
+	require.Equal(t, 2, len(info.Addrs))
+	assert.Equal(t, fmt.Sprintf("/ip4/%s/udp/4000/quic-v1", addr), info.Addrs[0].String())
+	assert.Equal(t, fmt.Sprintf("/ip4/%s/tcp/3000", addr), info.Addrs[1].String())
+	assert.Equal(t, transportTCP, transportFromMultiaddr(tcpAddr))
+
+	// Nodes without a quic entry are only dialed over TCP.
+	node.Delete(quicProtocol(0))
+	info, _, err = convertToAddrInfo(node.Node())
+	require.NoError(t, err)
+	require.Equal(t, 1, len(info.Addrs))
+	assert.Equal(t, transportTCP, transportFromMultiaddr(info.Addrs[0]))
+}
+
 func TestMultiAddrConversion_OK(t *testing.T) {
 	hook := logTest.NewGlobal()
 	ipAddr, pkey := createAddrAndPrivKey(t)
@@ -494,3 +542,30 @@ func TestRefreshENR_ForkBoundaries(t *testing.T) {
 		})
 	}
 }
+
+func TestInboundPeerLimit_TrustedPeers(t *testing.T) {
+	fakePeer := testp2p.NewTestP2P(t)
+	s := &Service{
+		cfg:       &Config{MaxPeers: 30},
+		ipLimiter: leakybucket.NewCollector(ipLimit, ipBurst, 1*time.Second, false),
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+		host: fakePeer.BHost,
+	}
+
+	for i := 0; i < 30+highWatermarkBuffer; i++ {
+		pid := addPeer(t, s.peers, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+		s.peers.AddTrustedPeer(pid)
+	}
+	// Trusted peers are not counted against our limits.
+	require.Equal(t, false, s.isPeerAtLimit(false), "at limit for outbound peers")
+	require.Equal(t, false, s.isPeerAtLimit(true), "at limit for inbound peers")
+	require.Equal(t, false, s.peers.IsAboveInboundLimit(), "above inbound limit")
+
+	for i := 0; i < 30; i++ {
+		_ = addPeer(t, s.peers, peerdata.PeerConnectionState(ethpb.ConnectionState_CONNECTED))
+	}
+	require.Equal(t, true, s.isPeerAtLimit(false), "not at limit for outbound peers")
+}
@@ -2,6 +2,8 @@ package p2p
 
 import (
 	"context"
+	"net"
+	"time"
 
 	"github.com/ethereum/go-ethereum/p2p/enr"
 	pubsub "github.com/libp2p/go-libp2p-pubsub"
@@ -12,6 +14,7 @@ import (
 	"github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/metadata"
 	"google.golang.org/protobuf/proto"
@@ -84,6 +87,7 @@ type PeerManager interface {
 	RefreshENR()
 	FindPeersWithSubnet(ctx context.Context, topic string, subIndex uint64, threshold int) (bool, error)
 	AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) error)
+	AddGoodbyeMethod(reqFunc func(ctx context.Context, code types.RPCGoodbyeCode, id peer.ID) error)
 }
 
 // Sender abstracts the sending functionality from libp2p.
@@ -96,6 +100,18 @@ type PeersProvider interface {
 	Peers() *peers.Status
 }
 
+// PeerAdmin allows operators to manage our peers at runtime.
+type PeerAdmin interface {
+	PeersProvider
+	AddPeer(ctx context.Context, addr string, trusted bool) (peer.ID, error)
+	SetPeerTrusted(pid peer.ID, trusted bool)
+	BanPeer(ctx context.Context, pid peer.ID, duration time.Duration)
+	UnbanPeer(pid peer.ID)
+	BanSubnet(ctx context.Context, subnet *net.IPNet, duration time.Duration)
+	UnbanSubnet(subnet *net.IPNet)
+	DisconnectPeer(ctx context.Context, pid peer.ID, code types.RPCGoodbyeCode) error
+}
+
 // MetadataProvider returns the metadata related information for the local peer.
 type MetadataProvider interface {
 	Metadata() metadata.Metadata
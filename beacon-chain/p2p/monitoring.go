@@ -3,12 +3,20 @@ package p2p
 import (
 	"strings"
 
+	"github.com/libp2p/go-libp2p/core/network"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/libp2p/go-libp2p/core/peerstore"
+	ma "github.com/multiformats/go-multiaddr"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
 )
 
+const (
+	transportTCP   = "tcp"
+	transportQUIC  = "quic"
+	transportOther = "other"
+)
+
 var (
 	knownAgentVersions = []string{
 		"lighthouse",
@@ -138,6 +146,18 @@ var (
 		Name: "p2p_pubsub_rpc_sent_sub_total",
 		Help: "The number of subscription messages sent via rpc",
 	})
+
+	// Transport Metrics
+	connectionsByTransport = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "p2p_connections_by_transport",
+		Help: "The number of open libp2p connections by transport and direction.",
+	},
+		[]string{"transport", "direction"})
+	gaterRejectedConnections = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "p2p_gater_rejected_connections_total",
+		Help: "The number of connections rejected by the connection gater by transport and direction.",
+	},
+		[]string{"transport", "direction"})
 )
 
 func (s *Service) updateMetrics() {
@@ -173,6 +193,43 @@ func (s *Service) updateMetrics() {
 		avgScore := average(scoringData)
 		avgScoreConnectedClients.WithLabelValues(agent).Set(avgScore)
 	}
+
+	numConnsByTransport := map[string]map[string]float64{
+		transportTCP:   {"inbound": 0, "outbound": 0},
+		transportQUIC:  {"inbound": 0, "outbound": 0},
+		transportOther: {"inbound": 0, "outbound": 0},
+	}
+	for _, conn := range s.Host().Network().Conns() {
+		direction := directionLabel(conn.Stat().Direction)
+		numConnsByTransport[transportFromMultiaddr(conn.RemoteMultiaddr())][direction] += 1
+	}
+	for transport, byDirection := range numConnsByTransport {
+		for direction, total := range byDirection {
+			connectionsByTransport.WithLabelValues(transport, direction).Set(total)
+		}
+	}
+}
+
+// transportFromMultiaddr returns the metrics label of the transport used by
+// the given multiaddress.
+func transportFromMultiaddr(addr ma.Multiaddr) string {
+	if addr == nil {
+		return transportOther
+	}
+	if _, err := addr.ValueForProtocol(ma.P_QUIC_V1); err == nil {
+		return transportQUIC
+	}
+	if _, err := addr.ValueForProtocol(ma.P_TCP); err == nil {
+		return transportTCP
+	}
+	return transportOther
+}
+
+func directionLabel(dir network.Direction) string {
+	if dir == network.DirInbound {
+		return "inbound"
+	}
+	return "outbound"
 }
 
 func average(xs []float64) float64 {
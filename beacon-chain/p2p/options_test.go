@@ -15,6 +15,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/crypto"
 	"github.com/libp2p/go-libp2p/core/protocol"
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	ecdsaprysm "github.com/prysmaticlabs/prysm/v4/crypto/ecdsa"
 	"github.com/prysmaticlabs/prysm/v4/network"
@@ -127,3 +128,31 @@ func TestDefaultMultiplexers(t *testing.T) {
 	assert.Equal(t, protocol.ID("/mplex/6.7.0"), cfg.Muxers[1].ID)
 
 }
+
+func TestQUICTransport(t *testing.T) {
+	p2pCfg := &Config{
+		TCPPort:       2000,
+		UDPPort:       2000,
+		QUICPort:      3000,
+		StateNotifier: &mock.MockStateNotifier{},
+	}
+	svc := &Service{cfg: p2pCfg}
+	var err error
+	svc.privKey, err = privKey(svc.cfg)
+	require.NoError(t, err)
+	ipAddr := network.IPAddr()
+
+	var cfg libp2p.Config
+	require.NoError(t, cfg.Apply(svc.buildOptions(ipAddr, svc.privKey)...))
+	assert.Equal(t, 1, len(cfg.ListenAddrs))
+	assert.Equal(t, 1, len(cfg.Transports))
+
+	resetCfg := features.InitWithReset(&features.Flags{EnableQUIC: true})
+	defer resetCfg()
+	cfg = libp2p.Config{}
+	require.NoError(t, cfg.Apply(svc.buildOptions(ipAddr, svc.privKey)...))
+	require.Equal(t, 2, len(cfg.ListenAddrs))
+	assert.Equal(t, transportTCP, transportFromMultiaddr(cfg.ListenAddrs[0]))
+	assert.Equal(t, transportQUIC, transportFromMultiaddr(cfg.ListenAddrs[1]))
+	assert.Equal(t, 2, len(cfg.Transports))
+}
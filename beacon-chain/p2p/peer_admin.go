@@ -0,0 +1,114 @@
+package p2p
+
+import (
+	"context"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	manet "github.com/multiformats/go-multiaddr/net"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+)
+
+// AddPeer connects to the peer at the provided multiaddress or ENR. When trusted is
+// set, the peer is added to our trusted peer set before dialing it.
+func (s *Service) AddPeer(ctx context.Context, addr string, trusted bool) (peer.ID, error) {
+	var info *peer.AddrInfo
+	if strings.HasPrefix(addr, "enr:") {
+		node, err := enode.Parse(enode.ValidSchemes, addr)
+		if err != nil {
+			return "", errors.Wrap(err, "could not parse enr")
+		}
+		var multiAddr ma.Multiaddr
+		info, multiAddr, err = convertToAddrInfo(node)
+		if err != nil {
+			return "", errors.Wrap(err, "could not convert enr to address info")
+		}
+		s.peers.Add(node.Record(), info.ID, multiAddr, network.DirUnknown)
+	} else {
+		multiAddr, err := ma.NewMultiaddr(addr)
+		if err != nil {
+			return "", errors.Wrap(err, "could not parse multiaddress")
+		}
+		info, err = peer.AddrInfoFromP2pAddr(multiAddr)
+		if err != nil {
+			return "", errors.Wrap(err, "could not convert multiaddress to address info")
+		}
+	}
+	if trusted {
+		s.peers.AddTrustedPeer(info.ID)
+	}
+	if err := s.connectWithPeer(ctx, *info); err != nil {
+		return info.ID, errors.Wrapf(err, "could not connect with peer %s", info.ID)
+	}
+	s.savePeerStore()
+	return info.ID, nil
+}
+
+// SetPeerTrusted adds the peer to, or removes it from, our trusted peer set.
+func (s *Service) SetPeerTrusted(pid peer.ID, trusted bool) {
+	if trusted {
+		s.peers.AddTrustedPeer(pid)
+	} else {
+		s.peers.RemoveTrustedPeer(pid)
+	}
+	s.savePeerStore()
+}
+
+// BanPeer bans the peer for the provided duration, disconnecting from it if needed.
+func (s *Service) BanPeer(ctx context.Context, pid peer.ID, duration time.Duration) {
+	s.peers.BanPeer(pid, time.Now().Add(duration))
+	if err := s.DisconnectPeer(ctx, pid, types.GoodbyeCodeBanned); err != nil {
+		log.WithError(err).WithField("peer", pid).Debug("Could not disconnect from banned peer")
+	}
+	s.savePeerStore()
+}
+
+// UnbanPeer lifts the ban of the peer.
+func (s *Service) UnbanPeer(pid peer.ID) {
+	s.peers.UnbanPeer(pid)
+	s.savePeerStore()
+}
+
+// BanSubnet bans the ip subnet for the provided duration, disconnecting
+// from all the connected peers within it.
+func (s *Service) BanSubnet(ctx context.Context, subnet *net.IPNet, duration time.Duration) {
+	s.peers.BanSubnet(subnet, time.Now().Add(duration))
+	for _, pid := range s.peers.Connected() {
+		addr, err := s.peers.Address(pid)
+		if err != nil || addr == nil {
+			continue
+		}
+		ip, err := manet.ToIP(addr)
+		if err != nil || !subnet.Contains(ip) || s.peers.IsTrustedPeer(pid) {
+			continue
+		}
+		if err := s.DisconnectPeer(ctx, pid, types.GoodbyeCodeBanned); err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not disconnect from banned peer")
+		}
+	}
+	s.savePeerStore()
+}
+
+// UnbanSubnet lifts the ban of the ip subnet.
+func (s *Service) UnbanSubnet(subnet *net.IPNet) {
+	s.peers.UnbanSubnet(subnet)
+	s.savePeerStore()
+}
+
+// DisconnectPeer says goodbye to the peer with the provided reason and disconnects from it, through the goodbye
+// method of the sync service. Disconnecting from a peer we are not connected to is a no-op.
+func (s *Service) DisconnectPeer(ctx context.Context, pid peer.ID, code types.RPCGoodbyeCode) error {
+	if s.goodbyeMethod != nil {
+		return s.goodbyeMethod(ctx, code, pid)
+	}
+	if s.host.Network().Connectedness(pid) == network.NotConnected {
+		return nil
+	}
+	return s.Disconnect(pid)
+}
@@ -0,0 +1,106 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/scorers"
+	testp2p "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func newPeerAdminService(t *testing.T) (*Service, *testp2p.TestP2P) {
+	p1 := testp2p.NewTestP2P(t)
+	p2 := testp2p.NewTestP2P(t)
+	s := &Service{
+		cfg:  &Config{MaxPeers: 30},
+		host: p1.BHost,
+		peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+		genesisTime: time.Now(),
+	}
+	return s, p2
+}
+
+func TestService_AddPeer(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	s, p2 := newPeerAdminService(t)
+
+	_, err := s.AddPeer(context.Background(), "/ip4/127.0.0.1/tcp", false)
+	assert.ErrorContains(t, "could not parse multiaddress", err)
+	_, err = s.AddPeer(context.Background(), "enr:invalid", false)
+	assert.ErrorContains(t, "could not parse enr", err)
+
+	addr := fmt.Sprintf("%s/p2p/%s", p2.BHost.Addrs()[0], p2.BHost.ID())
+	pid, err := s.AddPeer(context.Background(), addr, true)
+	require.NoError(t, err)
+	assert.Equal(t, p2.BHost.ID(), pid)
+	assert.Equal(t, network.Connected, s.host.Network().Connectedness(pid))
+	assert.Equal(t, true, s.peers.IsTrustedPeer(pid))
+
+	s.SetPeerTrusted(pid, false)
+	assert.Equal(t, false, s.peers.IsTrustedPeer(pid))
+}
+
+func TestService_BanPeer(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	s, p2 := newPeerAdminService(t)
+	addr := fmt.Sprintf("%s/p2p/%s", p2.BHost.Addrs()[0], p2.BHost.ID())
+	pid, err := s.AddPeer(context.Background(), addr, true)
+	require.NoError(t, err)
+
+	// The goodbye is sent by the goodbye method of the sync service.
+	var goodbye types.RPCGoodbyeCode
+	s.AddGoodbyeMethod(func(_ context.Context, code types.RPCGoodbyeCode, id peer.ID) error {
+		goodbye = code
+		return s.Disconnect(id)
+	})
+
+	s.BanPeer(context.Background(), pid, time.Hour)
+	assert.Equal(t, types.GoodbyeCodeBanned, goodbye)
+	assert.Equal(t, network.NotConnected, s.host.Network().Connectedness(pid))
+	assert.Equal(t, true, s.peers.IsBad(pid))
+	// Banning a peer revokes its trust.
+	assert.Equal(t, false, s.peers.IsTrustedPeer(pid))
+	_, err = s.AddPeer(context.Background(), addr, false)
+	assert.ErrorContains(t, "refused to connect to bad peer", err)
+
+	s.UnbanPeer(pid)
+	assert.Equal(t, false, s.peers.IsBad(pid))
+	_, err = s.AddPeer(context.Background(), addr, false)
+	require.NoError(t, err)
+}
+
+func TestService_BanSubnet(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	s, p2 := newPeerAdminService(t)
+	addr := fmt.Sprintf("%s/p2p/%s", p2.BHost.Addrs()[0], p2.BHost.ID())
+	pid, err := s.AddPeer(context.Background(), addr, false)
+	require.NoError(t, err)
+	s.peers.Add(nil, pid, p2.BHost.Addrs()[0], network.DirOutbound)
+	s.peers.SetConnectionState(pid, peers.PeerConnected)
+
+	_, subnet, err := net.ParseCIDR("127.0.0.0/8")
+	require.NoError(t, err)
+	s.BanSubnet(context.Background(), subnet, time.Hour)
+	assert.Equal(t, network.NotConnected, s.host.Network().Connectedness(pid))
+	assert.Equal(t, true, s.peers.IsBad(pid))
+	bannedAddr, err := ma.NewMultiaddr("/ip4/127.0.0.1/tcp/3000")
+	require.NoError(t, err)
+	assert.Equal(t, false, s.InterceptAddrDial(pid, bannedAddr))
+
+	s.UnbanSubnet(subnet)
+	assert.Equal(t, false, s.peers.IsBad(pid))
+}
@@ -0,0 +1,139 @@
+package p2p
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enode"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/sirupsen/logrus"
+)
+
+const peerStorePath = "peerstore.json"
+
+var (
+	// How often the peer store is written to disk.
+	peerStorePersistPeriod = 5 * time.Minute
+	// How long known good peers are remembered after they were last seen,
+	// and how long peers flagged as bad by the scorers remain banned.
+	peerStoreExpiry = 24 * time.Hour
+)
+
+// loadPeerStore restores the known good peers and bans persisted during the
+// previous run of the node, returning the address info of the restored peers
+// which can be dialed, best scoring first.
+func (s *Service) loadPeerStore() []peer.AddrInfo {
+	if s.cfg.DataDir == "" {
+		return nil
+	}
+	storePath := path.Join(s.cfg.DataDir, peerStorePath)
+	if !file.FileExists(storePath) {
+		return nil
+	}
+	src, err := os.ReadFile(storePath) // #nosec G304
+	if err != nil {
+		log.WithError(err).Error("Could not read peer store file")
+		return nil
+	}
+	snapshot := &peers.Snapshot{}
+	if err := json.Unmarshal(src, snapshot); err != nil {
+		log.WithError(err).Error("Could not decode peer store file")
+		return nil
+	}
+	pids := s.peers.Restore(snapshot, peerStoreExpiry)
+	infos := make([]peer.AddrInfo, 0, len(pids))
+	for _, pid := range pids {
+		info, err := s.restoredPeerAddrInfo(pid)
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Trace("Not dialing restored peer")
+			continue
+		}
+		infos = append(infos, *info)
+	}
+	log.WithFields(logrus.Fields{
+		"peers":    len(pids),
+		"dialable": len(infos),
+		"bans":     len(snapshot.Bans),
+	}).Info("Restored peer store from disk")
+	return infos
+}
+
+// savePeerStore writes the known good peers and bans to disk.
+func (s *Service) savePeerStore() {
+	if s.cfg.DataDir == "" {
+		return
+	}
+	enc, err := json.Marshal(s.peers.Snapshot(peerStoreExpiry))
+	if err != nil {
+		log.WithError(err).Error("Could not encode peer store")
+		return
+	}
+	// Write to a temporary file first, so that a crash mid-write
+	// never leaves a truncated peer store behind.
+	storePath := path.Join(s.cfg.DataDir, peerStorePath)
+	tmpPath := storePath + ".tmp"
+	if err := file.WriteFile(tmpPath, enc); err != nil {
+		log.WithError(err).Error("Could not write peer store file")
+		return
+	}
+	if err := os.Rename(tmpPath, storePath); err != nil {
+		log.WithError(err).Error("Could not replace peer store file")
+	}
+}
+
+// restoredPeerAddrInfo builds the address info used to dial a restored peer. The peer's ENR is
+// preferred as it advertises its listening ports. Without one, only the recorded address of a
+// peer we dialed ourselves is known to be reachable.
+func (s *Service) restoredPeerAddrInfo(pid peer.ID) (*peer.AddrInfo, error) {
+	record, err := s.peers.ENR(pid)
+	if err == nil && record != nil {
+		node, err := enode.New(enode.ValidSchemes, record)
+		if err == nil && node.IP() != nil {
+			info, _, err := convertToAddrInfo(node)
+			if err == nil && info.ID == pid {
+				return info, nil
+			}
+		}
+	}
+	direction, err := s.peers.Direction(pid)
+	if err != nil {
+		return nil, err
+	}
+	if direction != network.DirOutbound {
+		return nil, errors.New("no dialable address for inbound peer")
+	}
+	addr, err := s.peers.Address(pid)
+	if err != nil {
+		return nil, err
+	}
+	if addr == nil {
+		return nil, errors.New("no address recorded for peer")
+	}
+	transport, _ := peer.SplitAddr(addr)
+	if transport == nil {
+		return nil, errors.New("invalid address recorded for peer")
+	}
+	return &peer.AddrInfo{ID: pid, Addrs: []ma.Multiaddr{transport}}, nil
+}
+
+// connectWithRestoredPeers dials the restored peers, up to our peer limit.
+func (s *Service) connectWithRestoredPeers(infos []peer.AddrInfo) {
+	if len(infos) > int(s.cfg.MaxPeers) {
+		infos = infos[:s.cfg.MaxPeers]
+	}
+	for _, info := range infos {
+		// make each dial non-blocking
+		go func(info peer.AddrInfo) {
+			if err := s.connectWithPeer(s.ctx, info); err != nil {
+				log.WithError(err).Tracef("Could not connect with restored peer %s", info.String())
+			}
+		}(info)
+	}
+}
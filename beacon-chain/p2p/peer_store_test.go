@@ -0,0 +1,97 @@
+package p2p
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/scorers"
+	ecdsaprysm "github.com/prysmaticlabs/prysm/v4/crypto/ecdsa"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestService_SaveLoadPeerStore(t *testing.T) {
+	dataDir := t.TempDir()
+	newService := func() *Service {
+		return &Service{
+			cfg: &Config{DataDir: dataDir, MaxPeers: 30},
+			peers: peers.NewStatus(context.Background(), &peers.StatusConfig{
+				PeerLimit: 30,
+				ScorerParams: &scorers.Config{
+					BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+						Threshold: maxBadResponses,
+					},
+				},
+			}),
+			genesisTime:           time.Now(),
+			genesisValidatorsRoot: bytesutil.PadTo([]byte{'A'}, 32),
+		}
+	}
+	s := newService()
+
+	// Nothing to restore on the first run.
+	assert.Equal(t, 0, len(s.loadPeerStore()))
+
+	// An inbound peer which advertised its ENR.
+	ipAddr, pkey := createAddrAndPrivKey(t)
+	localNode, err := s.createLocalNode(pkey, ipAddr, 12000, 13000, 0)
+	require.NoError(t, err)
+	pubKey, err := ecdsaprysm.ConvertToInterfacePubkey(&pkey.PublicKey)
+	require.NoError(t, err)
+	enrPeer, err := peer.IDFromPublicKey(pubKey)
+	require.NoError(t, err)
+	remoteAddr, err := ma.NewMultiaddr(fmt.Sprintf("/ip4/%s/tcp/%d", ipAddr, 45000))
+	require.NoError(t, err)
+	s.peers.Add(localNode.Node().Record(), enrPeer, remoteAddr, network.DirInbound)
+	s.peers.SetConnectionState(enrPeer, peers.PeerConnected)
+
+	// An inbound peer without an ENR cannot be dialed back on its ephemeral port.
+	_, pkey2 := createAddrAndPrivKey(t)
+	pubKey2, err := ecdsaprysm.ConvertToInterfacePubkey(&pkey2.PublicKey)
+	require.NoError(t, err)
+	inboundPeer, err := peer.IDFromPublicKey(pubKey2)
+	require.NoError(t, err)
+	s.peers.Add(nil, inboundPeer, remoteAddr, network.DirInbound)
+	s.peers.SetConnectionState(inboundPeer, peers.PeerConnected)
+
+	// A bad peer is banned across restarts.
+	_, pkey3 := createAddrAndPrivKey(t)
+	pubKey3, err := ecdsaprysm.ConvertToInterfacePubkey(&pkey3.PublicKey)
+	require.NoError(t, err)
+	badPeer, err := peer.IDFromPublicKey(pubKey3)
+	require.NoError(t, err)
+	s.peers.Add(nil, badPeer, nil, network.DirOutbound)
+	for i := 0; i < maxBadResponses; i++ {
+		s.peers.Scorers().BadResponsesScorer().Increment(badPeer)
+	}
+
+	s.savePeerStore()
+	require.Equal(t, true, file.FileExists(path.Join(dataDir, peerStorePath)))
+	_, err = os.Stat(path.Join(dataDir, peerStorePath+".tmp"))
+	assert.Equal(t, true, os.IsNotExist(err), "Temporary peer store file was not cleaned up")
+
+	restarted := newService()
+	infos := restarted.loadPeerStore()
+	require.Equal(t, 1, len(infos))
+	assert.Equal(t, enrPeer, infos[0].ID)
+	require.Equal(t, 1, len(infos[0].Addrs))
+	assert.Equal(t, fmt.Sprintf("/ip4/%s/tcp/%d", ipAddr, 13000), infos[0].Addrs[0].String())
+	assert.Equal(t, 2, len(restarted.peers.All()))
+	assert.Equal(t, true, restarted.peers.IsBad(badPeer))
+	assert.Equal(t, false, restarted.peers.IsBad(enrPeer))
+
+	// Saving again keeps the restored state around.
+	restarted.savePeerStore()
+	infos = newService().loadPeerStore()
+	assert.Equal(t, 1, len(infos))
+}
@@ -3,6 +3,7 @@ package peerdata
 import (
 	"context"
 	"errors"
+	"net"
 	"sync"
 	"time"
 
@@ -42,6 +43,14 @@ type Store struct {
 	config       *StoreConfig
 	peers        map[peer.ID]*PeerData
 	trustedPeers map[peer.ID]bool
+	bannedPeers  map[peer.ID]time.Time
+	bannedNets   map[string]*SubnetBan
+}
+
+// SubnetBan is a ban applied to all the peers within an ip subnet.
+type SubnetBan struct {
+	Subnet *net.IPNet
+	Until  time.Time
 }
 
 // PeerData aggregates protocol and application level info about a single peer.
@@ -52,6 +61,7 @@ type PeerData struct {
 	ConnState     PeerConnectionState
 	Enr           *enr.Record
 	NextValidTime time.Time
+	LastSeen      time.Time
 	// Chain related data.
 	MetaData                  metadata.Metadata
 	ChainState                *ethpb.Status
@@ -74,6 +84,8 @@ func NewStore(ctx context.Context, config *StoreConfig) *Store {
 		config:       config,
 		peers:        make(map[peer.ID]*PeerData),
 		trustedPeers: make(map[peer.ID]bool),
+		bannedPeers:  make(map[peer.ID]time.Time),
+		bannedNets:   make(map[string]*SubnetBan),
 	}
 }
 
@@ -114,6 +126,17 @@ func (s *Store) SetTrustedPeers(peers []peer.ID) {
 	}
 }
 
+// DeleteTrustedPeer removes the provided peer from our trusted peer set.
+func (s *Store) DeleteTrustedPeer(pid peer.ID) {
+	delete(s.trustedPeers, pid)
+}
+
+// TrustedPeers returns the trusted peer set.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) TrustedPeers() map[peer.ID]bool {
+	return s.trustedPeers
+}
+
 // Peers returns map of peer data objects.
 // Important: it is assumed that store mutex is locked when calling this method.
 func (s *Store) Peers() map[peer.ID]*PeerData {
@@ -126,6 +149,60 @@ func (s *Store) IsTrustedPeer(p peer.ID) bool {
 	return s.trustedPeers[p]
 }
 
+// SetBannedPeer bans the provided peer until the given time.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) SetBannedPeer(pid peer.ID, until time.Time) {
+	s.bannedPeers[pid] = until
+}
+
+// DeleteBannedPeer lifts the ban of the provided peer.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) DeleteBannedPeer(pid peer.ID) {
+	delete(s.bannedPeers, pid)
+}
+
+// BannedPeers returns map of banned peers along with the time their ban expires.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) BannedPeers() map[peer.ID]time.Time {
+	return s.bannedPeers
+}
+
+// IsBannedPeer checks that the provided peer is banned
+// at the given time.
+func (s *Store) IsBannedPeer(pid peer.ID, now time.Time) bool {
+	until, ok := s.bannedPeers[pid]
+	return ok && now.Before(until)
+}
+
+// SetBannedSubnet bans all the peers within the provided subnet until the given time.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) SetBannedSubnet(subnet *net.IPNet, until time.Time) {
+	s.bannedNets[subnet.String()] = &SubnetBan{Subnet: subnet, Until: until}
+}
+
+// DeleteBannedSubnet lifts the ban of the provided subnet.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) DeleteBannedSubnet(subnet *net.IPNet) {
+	delete(s.bannedNets, subnet.String())
+}
+
+// BannedSubnets returns map of banned subnets, keyed by their CIDR notation.
+// Important: it is assumed that store mutex is locked when calling this method.
+func (s *Store) BannedSubnets() map[string]*SubnetBan {
+	return s.bannedNets
+}
+
+// IsBannedIP checks that the provided ip address belongs
+// to a banned subnet at the given time.
+func (s *Store) IsBannedIP(ip net.IP, now time.Time) bool {
+	for _, ban := range s.bannedNets {
+		if now.Before(ban.Until) && ban.Subnet.Contains(ip) {
+			return true
+		}
+	}
+	return false
+}
+
 // Config exposes store configuration params.
 func (s *Store) Config() *StoreConfig {
 	return s.config
@@ -3,6 +3,7 @@ package peerdata_test
 import (
 	"context"
 	"testing"
+	"time"
 
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/peerdata"
@@ -97,3 +98,26 @@ func TestStore_TrustedPeers(t *testing.T) {
 	assert.Equal(t, true, store.IsTrustedPeer(pid2))
 	assert.Equal(t, true, store.IsTrustedPeer(pid3))
 }
+
+func TestStore_BannedPeers(t *testing.T) {
+	store := peerdata.NewStore(context.Background(), &peerdata.StoreConfig{
+		MaxPeers: 12,
+	})
+
+	now := time.Now()
+	pid1 := peer.ID("00001")
+	pid2 := peer.ID("00002")
+	pid3 := peer.ID("00003")
+
+	store.SetBannedPeer(pid1, now.Add(time.Hour))
+	store.SetBannedPeer(pid2, now.Add(-time.Hour))
+
+	assert.Equal(t, true, store.IsBannedPeer(pid1, now))
+	assert.Equal(t, false, store.IsBannedPeer(pid2, now), "Expired ban must not apply")
+	assert.Equal(t, false, store.IsBannedPeer(pid3, now))
+	assert.Equal(t, 2, len(store.BannedPeers()))
+
+	store.DeleteBannedPeer(pid1)
+	assert.Equal(t, false, store.IsBannedPeer(pid1, now))
+	assert.Equal(t, 1, len(store.BannedPeers()))
+}
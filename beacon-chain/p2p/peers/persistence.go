@@ -0,0 +1,262 @@
+package peers
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net"
+	"sort"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/ethereum/go-ethereum/rlp"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/peerdata"
+	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
+)
+
+// PersistedPeer is the persisted form of a known good peer.
+type PersistedPeer struct {
+	ID              string            `json:"id"`
+	Address         string            `json:"address,omitempty"`
+	Direction       network.Direction `json:"direction"`
+	ENR             string            `json:"enr,omitempty"`
+	LastSeen        time.Time         `json:"last_seen"`
+	Score           float64           `json:"score"`
+	BadResponses    int               `json:"bad_responses"`
+	ProcessedBlocks uint64            `json:"processed_blocks"`
+	Trusted         bool              `json:"trusted,omitempty"`
+}
+
+// PersistedBan is the persisted form of a banned peer or ip subnet.
+type PersistedBan struct {
+	ID     string    `json:"id,omitempty"`
+	Subnet string    `json:"subnet,omitempty"`
+	Until  time.Time `json:"until"`
+}
+
+// Snapshot holds the peer data which is kept across restarts of the node.
+type Snapshot struct {
+	Peers []*PersistedPeer `json:"peers"`
+	Bans  []*PersistedBan  `json:"bans"`
+}
+
+// Snapshot exports the known good peers that were seen within the expiry period and the trusted
+// peers, along with the banned peers and subnets. Peers currently considered bad by the scorers are
+// exported as bans lasting for the expiry period, so that they are not dialed again right after a
+// restart.
+func (p *Status) Snapshot(expiry time.Duration) *Snapshot {
+	p.store.RLock()
+	defer p.store.RUnlock()
+
+	now := prysmTime.Now()
+	bans := make(map[peer.ID]time.Time)
+	for pid, until := range p.store.BannedPeers() {
+		if now.Before(until) {
+			bans[pid] = until
+		}
+	}
+	goodPeers := make([]*PersistedPeer, 0)
+	for pid, peerData := range p.store.Peers() {
+		if !p.store.IsTrustedPeer(pid) && p.scorers.IsBadPeerNoLock(pid) {
+			until := now.Add(expiry)
+			if current, ok := bans[pid]; !ok || current.Before(until) {
+				bans[pid] = until
+			}
+			continue
+		}
+		if _, ok := bans[pid]; ok {
+			continue
+		}
+		trusted := p.store.IsTrustedPeer(pid)
+		if !trusted && (peerData.LastSeen.IsZero() || now.Sub(peerData.LastSeen) > expiry) {
+			continue
+		}
+		record := &PersistedPeer{
+			ID:              pid.String(),
+			Direction:       peerData.Direction,
+			LastSeen:        peerData.LastSeen,
+			Score:           p.scorers.ScoreNoLock(pid),
+			BadResponses:    peerData.BadResponses,
+			ProcessedBlocks: peerData.ProcessedBlocks,
+			Trusted:         trusted,
+		}
+		if peerData.Address != nil {
+			record.Address = peerData.Address.String()
+		}
+		if peerData.Enr != nil {
+			encodedENR, err := encodeENR(peerData.Enr)
+			if err != nil {
+				log.WithError(err).WithField("peer", pid).Trace("Could not encode peer ENR")
+			} else {
+				record.ENR = encodedENR
+			}
+		}
+		goodPeers = append(goodPeers, record)
+	}
+	// Trusted peers we know nothing else about are kept as well.
+	numTrusted := 0
+	for pid, trusted := range p.store.TrustedPeers() {
+		if !trusted {
+			continue
+		}
+		numTrusted++
+		if _, ok := p.store.PeerData(pid); !ok {
+			goodPeers = append(goodPeers, &PersistedPeer{ID: pid.String(), Trusted: true})
+		}
+	}
+
+	// Only keep the trusted and best scoring peers, as many as the store can hold.
+	sort.Slice(goodPeers, func(i, j int) bool {
+		if goodPeers[i].Trusted != goodPeers[j].Trusted {
+			return goodPeers[i].Trusted
+		}
+		if goodPeers[i].Score == goodPeers[j].Score {
+			return goodPeers[i].LastSeen.After(goodPeers[j].LastSeen)
+		}
+		return goodPeers[i].Score > goodPeers[j].Score
+	})
+	maxPeers := p.store.Config().MaxPeers
+	if numTrusted > maxPeers {
+		maxPeers = numTrusted
+	}
+	if len(goodPeers) > maxPeers {
+		goodPeers = goodPeers[:maxPeers]
+	}
+	bannedPeers := make([]*PersistedBan, 0, len(bans))
+	for pid, until := range bans {
+		bannedPeers = append(bannedPeers, &PersistedBan{ID: pid.String(), Until: until})
+	}
+	sort.Slice(bannedPeers, func(i, j int) bool {
+		return bannedPeers[i].ID < bannedPeers[j].ID
+	})
+	for _, ban := range p.store.BannedSubnets() {
+		if now.Before(ban.Until) {
+			bannedPeers = append(bannedPeers, &PersistedBan{Subnet: ban.Subnet.String(), Until: ban.Until})
+		}
+	}
+	return &Snapshot{Peers: goodPeers, Bans: bannedPeers}
+}
+
+// Restore loads a previously exported snapshot into the peer store. Bans which have not expired
+// yet are reinstated, trusted peers are trusted again and known good peers seen within the expiry
+// period are added as disconnected peers. Peers already known to the store are left untouched. The
+// restored good peers are returned trusted peers first and then ordered by their score, best first,
+// so that they can be used to seed dialing.
+func (p *Status) Restore(snapshot *Snapshot, expiry time.Duration) []peer.ID {
+	if snapshot == nil {
+		return []peer.ID{}
+	}
+	p.store.Lock()
+	defer p.store.Unlock()
+
+	now := prysmTime.Now()
+	for _, ban := range snapshot.Bans {
+		if !now.Before(ban.Until) {
+			continue
+		}
+		if ban.Subnet != "" {
+			_, subnet, err := net.ParseCIDR(ban.Subnet)
+			if err != nil {
+				log.WithError(err).WithField("subnet", ban.Subnet).Debug("Could not decode banned subnet")
+				continue
+			}
+			p.store.SetBannedSubnet(subnet, ban.Until)
+			continue
+		}
+		pid, err := peer.Decode(ban.ID)
+		if err != nil {
+			log.WithError(err).WithField("peer", ban.ID).Debug("Could not decode banned peer id")
+			continue
+		}
+		if current, ok := p.store.BannedPeers()[pid]; ok && current.After(ban.Until) {
+			continue
+		}
+		p.store.SetBannedPeer(pid, ban.Until)
+	}
+
+	restored := make([]*PersistedPeer, 0, len(snapshot.Peers))
+	pids := make(map[string]peer.ID, len(snapshot.Peers))
+	for _, record := range snapshot.Peers {
+		if !record.Trusted && now.Sub(record.LastSeen) > expiry {
+			continue
+		}
+		pid, err := peer.Decode(record.ID)
+		if err != nil {
+			log.WithError(err).WithField("peer", record.ID).Debug("Could not decode peer id")
+			continue
+		}
+		if p.store.IsBannedPeer(pid, now) {
+			continue
+		}
+		if record.Trusted {
+			p.store.SetTrustedPeers([]peer.ID{pid})
+		}
+		if _, ok := p.store.PeerData(pid); ok {
+			continue
+		}
+		peerData := &peerdata.PeerData{
+			Direction: record.Direction,
+			// Restored peers start disconnected, until we dial them or they dial us.
+			ConnState:            PeerDisconnected,
+			LastSeen:             record.LastSeen,
+			BadResponses:         record.BadResponses,
+			ProcessedBlocks:      record.ProcessedBlocks,
+			BlockProviderUpdated: now,
+		}
+		if record.Address != "" {
+			addr, err := ma.NewMultiaddr(record.Address)
+			if err != nil {
+				log.WithError(err).WithField("peer", pid).Debug("Could not decode peer address")
+			} else {
+				peerData.Address = addr
+			}
+		}
+		if record.ENR != "" {
+			nodeENR, err := decodeENR(record.ENR)
+			if err != nil {
+				log.WithError(err).WithField("peer", pid).Debug("Could not decode peer ENR")
+			} else {
+				peerData.Enr = nodeENR
+			}
+		}
+		p.store.SetPeerData(pid, peerData)
+		p.addIpToTracker(pid)
+		restored = append(restored, record)
+		pids[record.ID] = pid
+	}
+
+	sort.SliceStable(restored, func(i, j int) bool {
+		if restored[i].Trusted != restored[j].Trusted {
+			return restored[i].Trusted
+		}
+		return restored[i].Score > restored[j].Score
+	})
+	restoredPids := make([]peer.ID, 0, len(restored))
+	for _, record := range restored {
+		restoredPids = append(restoredPids, pids[record.ID])
+	}
+	return restoredPids
+}
+
+func encodeENR(record *enr.Record) (string, error) {
+	buf := bytes.NewBuffer([]byte{})
+	if err := record.EncodeRLP(buf); err != nil {
+		return "", errors.Wrap(err, "could not encode ENR record to bytes")
+	}
+	return base64.RawURLEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+func decodeENR(raw string) (*enr.Record, error) {
+	b, err := base64.RawURLEncoding.DecodeString(raw)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not decode ENR string")
+	}
+	record := &enr.Record{}
+	if err := rlp.DecodeBytes(b, record); err != nil {
+		return nil, errors.Wrap(err, "could not decode ENR bytes")
+	}
+	return record, nil
+}
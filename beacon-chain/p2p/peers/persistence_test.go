@@ -0,0 +1,168 @@
+package peers_test
+
+import (
+	"context"
+	"crypto/rand"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/peer"
+	ma "github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/peerdata"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestStatus_SnapshotRestore(t *testing.T) {
+	maxBadResponses := 2
+	newStatus := func() *peers.Status {
+		return peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit: 30,
+			ScorerParams: &scorers.Config{
+				BadResponsesScorerConfig: &scorers.BadResponsesScorerConfig{
+					Threshold: maxBadResponses,
+				},
+			},
+		})
+	}
+	p := newStatus()
+
+	addr, err := ma.NewMultiaddr("/ip4/213.202.254.180/tcp/13000")
+	require.NoError(t, err)
+	goodPeer := createPersistablePeer(t, p, addr, network.DirOutbound, peers.PeerConnected)
+	p.Scorers().BlockProviderScorer().IncrementProcessedBlocks(goodPeer, 64)
+	// Disconnected peers we were connected to before are still known good.
+	disconnectedPeer := createPersistablePeer(t, p, nil, network.DirInbound, peers.PeerConnected)
+	p.SetConnectionState(disconnectedPeer, peers.PeerDisconnected)
+	p.Scorers().BadResponsesScorer().Increment(disconnectedPeer)
+	// Peers we have never been connected to are not persisted.
+	neverSeenPeer := createPersistablePeer(t, p, nil, network.DirUnknown, peers.PeerDisconnected)
+	// Bad peers are persisted as bans.
+	badPeer := createPersistablePeer(t, p, nil, network.DirInbound, peers.PeerConnected)
+	for i := 0; i < maxBadResponses; i++ {
+		p.Scorers().BadResponsesScorer().Increment(badPeer)
+	}
+	require.Equal(t, true, p.IsBad(badPeer))
+
+	snapshot := p.Snapshot(time.Hour)
+	require.Equal(t, 2, len(snapshot.Peers))
+	require.Equal(t, 1, len(snapshot.Bans))
+	assert.Equal(t, badPeer.String(), snapshot.Bans[0].ID)
+	// Peers are ordered by score, best first.
+	assert.Equal(t, goodPeer.String(), snapshot.Peers[0].ID)
+	assert.Equal(t, addr.String(), snapshot.Peers[0].Address)
+	assert.Equal(t, uint64(64), snapshot.Peers[0].ProcessedBlocks)
+	assert.Equal(t, disconnectedPeer.String(), snapshot.Peers[1].ID)
+	assert.Equal(t, 1, snapshot.Peers[1].BadResponses)
+
+	restored := newStatus()
+	pids := restored.Restore(snapshot, time.Hour)
+	assert.DeepEqual(t, []peer.ID{goodPeer, disconnectedPeer}, pids)
+	state, err := restored.ConnectionState(goodPeer)
+	require.NoError(t, err)
+	assert.Equal(t, peers.PeerDisconnected, state)
+	restoredAddr, err := restored.Address(goodPeer)
+	require.NoError(t, err)
+	assert.Equal(t, addr.String(), restoredAddr.String())
+	direction, err := restored.Direction(goodPeer)
+	require.NoError(t, err)
+	assert.Equal(t, network.DirOutbound, direction)
+	assert.Equal(t, uint64(64), restored.Scorers().BlockProviderScorer().ProcessedBlocks(goodPeer))
+	_, err = restored.ConnectionState(neverSeenPeer)
+	assert.ErrorContains(t, "peer unknown", err)
+	assert.Equal(t, true, restored.IsBad(badPeer), "Expected ban to be restored")
+	assert.Equal(t, false, restored.IsBad(goodPeer))
+}
+
+func TestStatus_RestoreExpiry(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	pid1 := createPersistablePeer(t, p, nil, network.DirOutbound, peers.PeerConnected)
+	pid2 := createPersistablePeer(t, p, nil, network.DirOutbound, peers.PeerConnected)
+	snapshot := p.Snapshot(time.Hour)
+	require.Equal(t, 2, len(snapshot.Peers))
+
+	// Age out one of the peers and add an expired ban.
+	for _, record := range snapshot.Peers {
+		if record.ID == pid2.String() {
+			record.LastSeen = time.Now().Add(-2 * time.Hour)
+		}
+	}
+	bannedPeer := createPersistablePeer(t, p, nil, network.DirInbound, peers.PeerDisconnected)
+	snapshot.Bans = append(snapshot.Bans, &peers.PersistedBan{
+		ID:    bannedPeer.String(),
+		Until: time.Now().Add(-time.Minute),
+	})
+
+	restored := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	pids := restored.Restore(snapshot, time.Hour)
+	assert.DeepEqual(t, []peer.ID{pid1}, pids)
+	assert.Equal(t, false, restored.IsBad(bannedPeer), "Expired ban must not be restored")
+
+	// Restoring does not override peers which are already known.
+	restored.SetConnectionState(pid1, peers.PeerConnected)
+	assert.Equal(t, 0, len(restored.Restore(snapshot, time.Hour)))
+	state, err := restored.ConnectionState(pid1)
+	require.NoError(t, err)
+	assert.Equal(t, peers.PeerConnected, state)
+}
+
+// createPersistablePeer adds a peer with a valid peer id, which survives
+// the round trip through its string representation.
+func createPersistablePeer(t *testing.T, p *peers.Status, addr ma.Multiaddr,
+	dir network.Direction, state peerdata.PeerConnectionState) peer.ID {
+	_, pub, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	id, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	p.Add(new(enr.Record), id, addr, dir)
+	p.SetConnectionState(id, state)
+	return id
+}
+
+func TestStatus_SnapshotRestore_TrustedAndSubnetBans(t *testing.T) {
+	p := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	goodPeer := createPersistablePeer(t, p, nil, network.DirOutbound, peers.PeerConnected)
+	// Trusted peers are persisted even if we were never connected to them.
+	trustedPeer := createPersistablePeer(t, p, nil, network.DirUnknown, peers.PeerDisconnected)
+	p.AddTrustedPeer(trustedPeer)
+	_, subnet, err := net.ParseCIDR("10.1.0.0/16")
+	require.NoError(t, err)
+	p.BanSubnet(subnet, time.Now().Add(time.Hour))
+
+	snapshot := p.Snapshot(time.Hour)
+	require.Equal(t, 2, len(snapshot.Peers))
+	assert.Equal(t, trustedPeer.String(), snapshot.Peers[0].ID)
+	assert.Equal(t, true, snapshot.Peers[0].Trusted)
+	require.Equal(t, 1, len(snapshot.Bans))
+	assert.Equal(t, "10.1.0.0/16", snapshot.Bans[0].Subnet)
+
+	restored := peers.NewStatus(context.Background(), &peers.StatusConfig{
+		PeerLimit:    30,
+		ScorerParams: &scorers.Config{},
+	})
+	pids := restored.Restore(snapshot, time.Hour)
+	assert.DeepEqual(t, []peer.ID{trustedPeer, goodPeer}, pids)
+	assert.Equal(t, true, restored.IsTrustedPeer(trustedPeer))
+	assert.Equal(t, false, restored.IsTrustedPeer(goodPeer))
+	bannedAddr, err := ma.NewMultiaddr("/ip4/10.1.2.3/tcp/13000")
+	require.NoError(t, err)
+	assert.Equal(t, true, restored.IsAddrBanned(bannedAddr))
+	allowedAddr, err := ma.NewMultiaddr("/ip4/10.2.2.3/tcp/13000")
+	require.NoError(t, err)
+	assert.Equal(t, false, restored.IsAddrBanned(allowedAddr))
+}
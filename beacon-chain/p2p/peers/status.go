@@ -19,12 +19,14 @@
 //
 // Peer information is persistent for the run of the service. This allows for collection of useful
 // long-term statistics such as number of bad responses obtained from the peer, giving the basis for
-// decisions to not talk to known-bad peers (by de-scoring them).
+// decisions to not talk to known-bad peers (by de-scoring them). Known good peers and bans can also
+// be exported as a snapshot and restored on the next run, see Snapshot and Restore.
 package peers
 
 import (
 	"context"
 	"math"
+	"net"
 	"sort"
 	"time"
 
@@ -213,9 +215,10 @@ func (p *Status) IsAboveInboundLimit() bool {
 	p.store.RLock()
 	defer p.store.RUnlock()
 	totalInbound := 0
-	for _, peerData := range p.store.Peers() {
+	for pid, peerData := range p.store.Peers() {
+		// Trusted peers do not count against our limits.
 		if peerData.ConnState == PeerConnected &&
-			peerData.Direction == network.DirInbound {
+			peerData.Direction == network.DirInbound && !p.store.IsTrustedPeer(pid) {
 			totalInbound += 1
 		}
 	}
@@ -298,6 +301,11 @@ func (p *Status) SetConnectionState(pid peer.ID, state peerdata.PeerConnectionSt
 	defer p.store.Unlock()
 
 	peerData := p.store.PeerDataGetOrCreate(pid)
+	// Track when we were last connected to the peer, so that
+	// known good peers can be remembered across restarts.
+	if state == PeerConnected || peerData.ConnState == PeerConnected {
+		peerData.LastSeen = prysmTime.Now()
+	}
 	peerData.ConnState = state
 }
 
@@ -339,6 +347,12 @@ func (p *Status) isBad(pid peer.ID) bool {
 	if p.store.IsTrustedPeer(pid) {
 		return false
 	}
+	if p.store.IsBannedPeer(pid, prysmTime.Now()) {
+		return true
+	}
+	if peerData, ok := p.store.PeerData(pid); ok && p.isAddrBanned(peerData.Address) {
+		return true
+	}
 	return p.isfromBadIP(pid) || p.scorers.IsBadPeerNoLock(pid)
 }
 
@@ -560,6 +574,9 @@ func (p *Status) Prune() {
 	notBadPeer := func(pid peer.ID) bool {
 		return !p.isBad(pid)
 	}
+	notTrustedPeer := func(pid peer.ID) bool {
+		return !p.store.IsTrustedPeer(pid)
+	}
 	type peerResp struct {
 		pid   peer.ID
 		score float64
@@ -567,7 +584,7 @@ func (p *Status) Prune() {
 	peersToPrune := make([]*peerResp, 0)
 	// Select disconnected peers with a smaller bad response count.
 	for pid, peerData := range p.store.Peers() {
-		if peerData.ConnState == PeerDisconnected && notBadPeer(pid) {
+		if peerData.ConnState == PeerDisconnected && notBadPeer(pid) && notTrustedPeer(pid) {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:   pid,
 				score: p.Scorers().ScoreNoLock(pid),
@@ -615,7 +632,7 @@ func (p *Status) deprecatedPrune() {
 	peersToPrune := make([]*peerResp, 0)
 	// Select disconnected peers with a smaller bad response count.
 	for pid, peerData := range p.store.Peers() {
-		if peerData.ConnState == PeerDisconnected && notBadPeer(peerData) {
+		if peerData.ConnState == PeerDisconnected && notBadPeer(peerData) && !p.store.IsTrustedPeer(pid) {
 			peersToPrune = append(peersToPrune, &peerResp{
 				pid:     pid,
 				badResp: peerData.BadResponses,
@@ -912,6 +929,124 @@ func (p *Status) SetTrustedPeers(peers []peer.ID) {
 	p.store.SetTrustedPeers(peers)
 }
 
+// AddTrustedPeer adds the provided peer to our trusted peer set.
+func (p *Status) AddTrustedPeer(pid peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.SetTrustedPeers([]peer.ID{pid})
+}
+
+// RemoveTrustedPeer removes the provided peer from our trusted peer set.
+func (p *Status) RemoveTrustedPeer(pid peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.DeleteTrustedPeer(pid)
+}
+
+// IsTrustedPeer checks that the provided peer is in our trusted peer set.
+// Trusted peers are never pruned, disconnected for their score or counted
+// against our peer limits.
+func (p *Status) IsTrustedPeer(pid peer.ID) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.store.IsTrustedPeer(pid)
+}
+
+// TrustedPeers returns our trusted peer set.
+func (p *Status) TrustedPeers() []peer.ID {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	pids := make([]peer.ID, 0, len(p.store.TrustedPeers()))
+	for pid, trusted := range p.store.TrustedPeers() {
+		if trusted {
+			pids = append(pids, pid)
+		}
+	}
+	return pids
+}
+
+// BanPeer bans the provided peer until the given time. A banned
+// peer is removed from our trusted peer set.
+func (p *Status) BanPeer(pid peer.ID, until time.Time) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.DeleteTrustedPeer(pid)
+	p.store.SetBannedPeer(pid, until)
+}
+
+// UnbanPeer lifts the ban of the provided peer.
+func (p *Status) UnbanPeer(pid peer.ID) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.DeleteBannedPeer(pid)
+}
+
+// BannedPeers returns the currently banned peers, along with the time their ban expires.
+func (p *Status) BannedPeers() map[peer.ID]time.Time {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	now := prysmTime.Now()
+	bans := make(map[peer.ID]time.Time)
+	for pid, until := range p.store.BannedPeers() {
+		if now.Before(until) {
+			bans[pid] = until
+		}
+	}
+	return bans
+}
+
+// BanSubnet bans all the peers within the provided subnet until the given time.
+func (p *Status) BanSubnet(subnet *net.IPNet, until time.Time) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.SetBannedSubnet(subnet, until)
+}
+
+// UnbanSubnet lifts the ban of the provided subnet.
+func (p *Status) UnbanSubnet(subnet *net.IPNet) {
+	p.store.Lock()
+	defer p.store.Unlock()
+	p.store.DeleteBannedSubnet(subnet)
+}
+
+// BannedSubnets returns the currently banned subnets, ordered by their CIDR notation.
+func (p *Status) BannedSubnets() []*peerdata.SubnetBan {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	now := prysmTime.Now()
+	bans := make([]*peerdata.SubnetBan, 0, len(p.store.BannedSubnets()))
+	for _, ban := range p.store.BannedSubnets() {
+		if now.Before(ban.Until) {
+			bans = append(bans, &peerdata.SubnetBan{Subnet: ban.Subnet, Until: ban.Until})
+		}
+	}
+	sort.Slice(bans, func(i, j int) bool {
+		return bans[i].Subnet.String() < bans[j].Subnet.String()
+	})
+	return bans
+}
+
+// IsAddrBanned checks whether the ip address of the provided
+// multiaddress belongs to a banned subnet.
+func (p *Status) IsAddrBanned(addr ma.Multiaddr) bool {
+	p.store.RLock()
+	defer p.store.RUnlock()
+	return p.isAddrBanned(addr)
+}
+
+// this method assumes the store lock is acquired before
+// executing the method.
+func (p *Status) isAddrBanned(addr ma.Multiaddr) bool {
+	if addr == nil {
+		return false
+	}
+	ip, err := manet.ToIP(addr)
+	if err != nil {
+		return false
+	}
+	return p.store.IsBannedIP(ip, prysmTime.Now())
+}
+
 // this method assumes the store lock is acquired before
 // executing the method.
 func (p *Status) isfromBadIP(pid peer.ID) bool {
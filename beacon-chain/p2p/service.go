@@ -60,6 +60,7 @@ type Service struct {
 	started               bool
 	isPreGenesis          bool
 	pingMethod            func(ctx context.Context, id peer.ID) error
+	goodbyeMethod         func(ctx context.Context, code types.RPCGoodbyeCode, id peer.ID) error
 	cancel                context.CancelFunc
 	cfg                   *Config
 	peers                 *peers.Status
@@ -174,6 +175,10 @@ func (s *Service) Start() {
 	s.awaitStateInitialized()
 	s.isPreGenesis = false
 
+	// Restore the peers and bans persisted during the previous run,
+	// before any dialing takes place.
+	restoredPeers := s.loadPeerStore()
+
 	var peersToWatch []string
 	if s.cfg.RelayNodeAddr != "" {
 		peersToWatch = append(peersToWatch, s.cfg.RelayNodeAddr)
@@ -216,6 +221,8 @@ func (s *Service) Start() {
 		peersToWatch = append(peersToWatch, s.cfg.StaticPeers...)
 		s.connectWithAllPeers(addrs)
 	}
+	// Seed dialing with the known good peers from the previous run.
+	s.connectWithRestoredPeers(restoredPeers)
 	// Initialize metadata according to the
 	// current epoch.
 	s.RefreshENR()
@@ -229,6 +236,7 @@ func (s *Service) Start() {
 		ensurePeerConnections(s.ctx, s.host, peersToWatch...)
 	})
 	async.RunEvery(s.ctx, 30*time.Minute, s.Peers().Prune)
+	async.RunEvery(s.ctx, peerStorePersistPeriod, s.savePeerStore)
 	async.RunEvery(s.ctx, params.BeaconNetworkConfig().RespTimeout, s.updateMetrics)
 	async.RunEvery(s.ctx, refreshRate, s.RefreshENR)
 	async.RunEvery(s.ctx, 1*time.Minute, func() {
@@ -260,6 +268,9 @@ func (s *Service) Start() {
 // Stop the p2p service and terminate all peer connections.
 func (s *Service) Stop() error {
 	defer s.cancel()
+	if s.started {
+		s.savePeerStore()
+	}
 	s.started = false
 	if s.dv5Listener != nil {
 		s.dv5Listener.Close()
@@ -364,6 +375,12 @@ func (s *Service) AddPingMethod(reqFunc func(ctx context.Context, id peer.ID) er
 	s.pingMethod = reqFunc
 }
 
+// AddGoodbyeMethod adds the goodbye rpc method to the p2p service, so that peers are said
+// goodbye to before being disconnected by the peer administration.
+func (s *Service) AddGoodbyeMethod(reqFunc func(ctx context.Context, code types.RPCGoodbyeCode, id peer.ID) error) {
+	s.goodbyeMethod = reqFunc
+}
+
 func (s *Service) pingPeers() {
 	if s.pingMethod == nil {
 		return
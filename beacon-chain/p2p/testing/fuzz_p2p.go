@@ -12,6 +12,7 @@ import (
 	"github.com/multiformats/go-multiaddr"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/metadata"
 	"google.golang.org/protobuf/proto"
@@ -40,6 +41,11 @@ func (_ *FakeP2P) AddConnectionHandler(_, _ func(ctx context.Context, id peer.ID
 func (_ *FakeP2P) AddDisconnectionHandler(_ func(ctx context.Context, id peer.ID) error) {
 }
 
+// AddGoodbyeMethod -- fake.
+func (_ *FakeP2P) AddGoodbyeMethod(_ func(ctx context.Context, code types.RPCGoodbyeCode, id peer.ID) error) {
+
+}
+
 // AddPingMethod -- fake.
 func (_ *FakeP2P) AddPingMethod(_ func(ctx context.Context, id peer.ID) error) {
 
@@ -8,6 +8,7 @@ import (
 	"github.com/libp2p/go-libp2p/core/host"
 	"github.com/libp2p/go-libp2p/core/peer"
 	"github.com/multiformats/go-multiaddr"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
 )
 
 // MockPeerManager is mock of the PeerManager interface.
@@ -55,5 +56,9 @@ func (_ MockPeerManager) FindPeersWithSubnet(_ context.Context, _ string, _ uint
 	return true, nil
 }
 
+// AddGoodbyeMethod .
+func (_ MockPeerManager) AddGoodbyeMethod(_ func(ctx context.Context, code types.RPCGoodbyeCode, id peer.ID) error) {
+}
+
 // AddPingMethod .
 func (_ MockPeerManager) AddPingMethod(_ func(ctx context.Context, id peer.ID) error) {}
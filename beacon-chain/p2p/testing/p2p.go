@@ -24,6 +24,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/encoder"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1/metadata"
 	"github.com/sirupsen/logrus"
@@ -371,6 +372,11 @@ func (p *TestP2P) MetadataSeq() uint64 {
 	return p.LocalMetadata.SequenceNumber()
 }
 
+// AddGoodbyeMethod mocks the p2p func.
+func (_ *TestP2P) AddGoodbyeMethod(_ func(ctx context.Context, code types.RPCGoodbyeCode, id peer.ID) error) {
+	// no-op
+}
+
 // AddPingMethod mocks the p2p func.
 func (_ *TestP2P) AddPingMethod(_ func(ctx context.Context, id peer.ID) error) {
 	// no-op
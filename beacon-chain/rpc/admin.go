@@ -0,0 +1,35 @@
+package rpc
+
+import (
+	"crypto/subtle"
+	"net/http"
+	"strings"
+
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+// requireAdminToken wraps an HTTP handler which changes the state of the beacon node so that it is only served to
+// requests carrying the admin API token in a `Authorization: Bearer <token>` header. Admin endpoints are disabled
+// when no token is configured.
+func (s *Service) requireAdminToken(h http.HandlerFunc) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		if s.cfg.AdminAPIToken == "" {
+			errJson := &network.DefaultErrorJson{
+				Message: "admin endpoints are disabled, the beacon node must run with --admin-api-token-file",
+				Code:    http.StatusForbidden,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		token, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(token), []byte(s.cfg.AdminAPIToken)) != 1 {
+			errJson := &network.DefaultErrorJson{
+				Message: "missing or invalid admin API token",
+				Code:    http.StatusUnauthorized,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		h(w, r)
+	}
+}
@@ -0,0 +1,45 @@
+package rpc
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+)
+
+func TestRequireAdminToken(t *testing.T) {
+	handler := func(w http.ResponseWriter, _ *http.Request) {
+		w.WriteHeader(http.StatusNoContent)
+	}
+
+	t.Run("disabled", func(t *testing.T) {
+		s := &Service{cfg: &Config{}}
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/monitor", nil)
+		request.Header.Set("Authorization", "Bearer ")
+		writer := httptest.NewRecorder()
+		s.requireAdminToken(handler)(writer, request)
+		assert.Equal(t, http.StatusForbidden, writer.Code)
+	})
+
+	s := &Service{cfg: &Config{AdminAPIToken: "secret"}}
+	for name, tc := range map[string]struct {
+		header string
+		code   int
+	}{
+		"missing token": {header: "", code: http.StatusUnauthorized},
+		"wrong scheme":  {header: "Basic secret", code: http.StatusUnauthorized},
+		"wrong token":   {header: "Bearer secre", code: http.StatusUnauthorized},
+		"valid token":   {header: "Bearer secret", code: http.StatusNoContent},
+	} {
+		t.Run(name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/monitor", nil)
+			if tc.header != "" {
+				request.Header.Set("Authorization", tc.header)
+			}
+			writer := httptest.NewRecorder()
+			s.requireAdminToken(handler)(writer, request)
+			assert.Equal(t, tc.code, writer.Code)
+		})
+	}
+}
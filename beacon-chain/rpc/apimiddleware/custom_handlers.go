@@ -424,6 +424,12 @@ func receiveEvents(eventChan <-chan *sse.Event, w http.ResponseWriter, req *http
 				data = &SignedContributionAndProofJson{}
 			case events.BLSToExecutionChangeTopic:
 				data = &SignedBLSToExecutionChangeJson{}
+			case events.AttesterSlashingTopic:
+				data = &AttesterSlashingJson{}
+			case events.ProposerSlashingTopic:
+				data = &ProposerSlashingJson{}
+			case events.BlockGossipTopic:
+				data = &EventBlockGossipJson{}
 			case events.PayloadAttributesTopic:
 				dataSubset := &dataSubset{}
 				if err := json.Unmarshal(msg.Data, dataSubset); err != nil {
@@ -437,6 +443,18 @@ func receiveEvents(eventChan <-chan *sse.Event, w http.ResponseWriter, req *http
 				default:
 					return apimiddleware.InternalServerError(errors.New("payload version unsupported"))
 				}
+			case events.LightClientFinalityUpdateTopic, events.LightClientOptimisticUpdateTopic:
+				if string(msg.Event) == events.LightClientFinalityUpdateTopic {
+					data = &EventLightClientFinalityUpdateJson{}
+				} else {
+					data = &EventLightClientOptimisticUpdateJson{}
+				}
+				// Light client headers are wrapped in a beacon field, and the version is lowercase in the API.
+				lightClientData, err := wrapLightClientEventData(msg.Data)
+				if err != nil {
+					return apimiddleware.InternalServerError(err)
+				}
+				msg.Data = lightClientData
 			case "error":
 				data = &EventErrorJson{}
 			default:
@@ -458,6 +476,30 @@ func receiveEvents(eventChan <-chan *sse.Event, w http.ResponseWriter, req *http
 	}
 }
 
+// wrapLightClientEventData converts the JSON of a light client update event from the protobuf representation
+// to the one of the standard API.
+func wrapLightClientEventData(raw []byte) ([]byte, error) {
+	event := make(map[string]interface{})
+	if err := json.Unmarshal(raw, &event); err != nil {
+		return nil, err
+	}
+	v, ok := event["version"].(string)
+	if !ok {
+		return nil, errors.New("light client event has no version")
+	}
+	event["version"] = strings.ToLower(v)
+	data, ok := event["data"].(map[string]interface{})
+	if !ok {
+		return nil, errors.New("light client event has no data")
+	}
+	for _, field := range []string{"attested_header", "finalized_header"} {
+		if header, ok := data[field]; ok {
+			data[field] = map[string]interface{}{"beacon": header}
+		}
+	}
+	return json.Marshal(event)
+}
+
 func writeEvent(msg *sse.Event, w http.ResponseWriter, data interface{}) apimiddleware.ErrorJson {
 	if err := json.Unmarshal(msg.Data, data); err != nil {
 		return apimiddleware.InternalServerError(err)
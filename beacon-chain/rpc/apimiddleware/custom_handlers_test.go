@@ -374,6 +374,36 @@ data: {"aggregation_bits":"0x666f6f","data":{"slot":"1","index":"1","beacon_bloc
 	assert.DeepEqual(t, expectedEvent, w.Body.String())
 }
 
+func TestReceiveEvents_LightClientOptimisticUpdate(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	ch := make(chan *sse.Event)
+	w := httptest.NewRecorder()
+	w.Body = &bytes.Buffer{}
+	req := httptest.NewRequest("GET", "http://foo.example", &bytes.Buffer{})
+	req = req.WithContext(ctx)
+
+	go func() {
+		base64Val := "Zm9v"
+		data := fmt.Sprintf(`{"@type":"type.googleapis.com/ethereum.eth.v2.LightClientOptimisticUpdateWithVersion","version":"ALTAIR","data":{"attested_header":{"slot":"7","proposer_index":"1","parent_root":"%[1]s","state_root":"%[1]s","body_root":"%[1]s"},"sync_aggregate":{"sync_committee_bits":"%[1]s","sync_committee_signature":"%[1]s"},"signature_slot":"8"}}`, base64Val)
+		msg := &sse.Event{
+			Data:  []byte(data),
+			Event: []byte(events.LightClientOptimisticUpdateTopic),
+		}
+		ch <- msg
+		time.Sleep(time.Second)
+		cancel()
+	}()
+
+	errJson := receiveEvents(ch, w, req)
+	assert.Equal(t, true, errJson == nil)
+
+	expectedEvent := `event: light_client_optimistic_update
+data: {"version":"altair","data":{"attested_header":{"beacon":{"slot":"7","proposer_index":"1","parent_root":"0x666f6f","state_root":"0x666f6f","body_root":"0x666f6f"}},"sync_aggregate":{"sync_committee_bits":"0x666f6f","sync_committee_signature":"0x666f6f"},"signature_slot":"8"}}
+
+`
+	assert.DeepEqual(t, expectedEvent, w.Body.String())
+}
+
 func TestReceiveEvents_EventNotSupported(t *testing.T) {
 	ch := make(chan *sse.Event)
 	w := httptest.NewRecorder()
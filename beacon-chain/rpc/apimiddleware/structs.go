@@ -1132,6 +1132,11 @@ type ReceivedBlockDataJson struct {
 	ExecutionOptimistic bool   `json:"execution_optimistic"`
 }
 
+type EventBlockGossipJson struct {
+	Slot  string `json:"slot"`
+	Block string `json:"block" hex:"true"`
+}
+
 type AggregatedAttReceivedDataJson struct {
 	Aggregate *AttestationJson `json:"aggregate"`
 }
@@ -1201,6 +1206,34 @@ type PayloadAttributesV2Json struct {
 	Withdrawals           []*WithdrawalJson `json:"withdrawals"`
 }
 
+type EventLightClientFinalityUpdateJson struct {
+	Version string                         `json:"version"`
+	Data    *LightClientFinalityUpdateJson `json:"data"`
+}
+
+type EventLightClientOptimisticUpdateJson struct {
+	Version string                           `json:"version"`
+	Data    *LightClientOptimisticUpdateJson `json:"data"`
+}
+
+type LightClientFinalityUpdateJson struct {
+	AttestedHeader  *LightClientHeaderJson `json:"attested_header"`
+	FinalizedHeader *LightClientHeaderJson `json:"finalized_header"`
+	FinalityBranch  []string               `json:"finality_branch" hex:"true"`
+	SyncAggregate   *SyncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot   string                 `json:"signature_slot"`
+}
+
+type LightClientOptimisticUpdateJson struct {
+	AttestedHeader *LightClientHeaderJson `json:"attested_header"`
+	SyncAggregate  *SyncAggregateJson     `json:"sync_aggregate"`
+	SignatureSlot  string                 `json:"signature_slot"`
+}
+
+type LightClientHeaderJson struct {
+	Beacon *BeaconBlockHeaderJson `json:"beacon"`
+}
+
 // ---------------
 // Error handling.
 // ---------------
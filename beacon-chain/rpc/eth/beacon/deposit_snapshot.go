@@ -0,0 +1,69 @@
+package beacon
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+const octetStreamMediaType = "application/octet-stream"
+
+// GetDepositSnapshot retrieves the EIP-4881 deposit tree snapshot of the finalized deposits. Either a JSON or,
+// if requested, an SSZ encoded response is returned.
+func (bs *Server) GetDepositSnapshot(w http.ResponseWriter, r *http.Request) {
+	snapshot, err := bs.BeaconDB.DepositSnapshot(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get deposit snapshot").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if snapshot == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "No finalized deposit snapshot available",
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	if strings.Contains(r.Header.Get("Accept"), octetStreamMediaType) {
+		enc, err := snapshot.MarshalSSZ()
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrap(err, "could not encode deposit snapshot").Error(),
+				Code:    http.StatusInternalServerError,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		w.Header().Set("Content-Type", octetStreamMediaType)
+		w.Header().Set("Content-Length", strconv.Itoa(len(enc)))
+		w.WriteHeader(http.StatusOK)
+		if _, err := w.Write(enc); err != nil {
+			log.WithError(err).Error("Could not write deposit snapshot")
+		}
+		return
+	}
+
+	finalized := snapshot.Finalized()
+	data := &DepositSnapshot{
+		Finalized:            make([]string, len(finalized)),
+		DepositCount:         strconv.FormatUint(snapshot.DepositCount(), 10),
+		ExecutionBlockHeight: strconv.FormatUint(snapshot.ExecutionBlockHeight(), 10),
+	}
+	for i, f := range finalized {
+		data.Finalized[i] = hexutil.Encode(f[:])
+	}
+	root := snapshot.DepositRoot()
+	data.DepositRoot = hexutil.Encode(root[:])
+	hash := snapshot.ExecutionBlockHash()
+	data.ExecutionBlockHash = hexutil.Encode(hash[:])
+	network.WriteJson(w, &GetDepositSnapshotResponse{Data: data})
+}
@@ -0,0 +1,79 @@
+package beacon
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
+	dbTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestGetDepositSnapshot(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbTest.SetupDB(t)
+	bs := &Server{BeaconDB: beaconDB}
+
+	t.Run("no snapshot", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		bs.GetDepositSnapshot(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, http.StatusNotFound, e.Code)
+	})
+
+	tree := depositsnapshot.New()
+	for i := 0; i < 5; i++ {
+		leaf := make([]byte, 32)
+		leaf[0] = byte(i + 1)
+		require.NoError(t, tree.Insert(leaf, i))
+	}
+	require.NoError(t, tree.Finalize(3, common.Hash{'a'}, 100))
+	snapshot, err := tree.GetSnapshot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveDepositSnapshot(ctx, &snapshot))
+
+	t.Run("JSON", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		bs.GetDepositSnapshot(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &GetDepositSnapshotResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.NotNil(t, resp.Data)
+		root := snapshot.DepositRoot()
+		hash := snapshot.ExecutionBlockHash()
+		assert.Equal(t, hexutil.Encode(root[:]), resp.Data.DepositRoot)
+		assert.Equal(t, "4", resp.Data.DepositCount)
+		assert.Equal(t, hexutil.Encode(hash[:]), resp.Data.ExecutionBlockHash)
+		assert.Equal(t, "100", resp.Data.ExecutionBlockHeight)
+		require.Equal(t, len(snapshot.Finalized()), len(resp.Data.Finalized))
+		for i, f := range snapshot.Finalized() {
+			assert.Equal(t, hexutil.Encode(f[:]), resp.Data.Finalized[i])
+		}
+	})
+	t.Run("SSZ", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://example.com/eth/v1/beacon/deposit_snapshot", nil)
+		request.Header.Set("Accept", "application/octet-stream")
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		bs.GetDepositSnapshot(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "application/octet-stream", writer.Header().Get("Content-Type"))
+		decoded := depositsnapshot.DepositTreeSnapshot{}
+		require.NoError(t, decoded.UnmarshalSSZ(writer.Body.Bytes()))
+		require.DeepEqual(t, snapshot, decoded)
+	})
+}
@@ -0,0 +1,217 @@
+package lightclient
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/light-client"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+)
+
+// GetLightClientBootstrap is an HTTP handler which returns the light client bootstrap of the finalized block
+// with the given root.
+func (s *Server) GetLightClientBootstrap(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	root, err := hexutil.Decode(segments[len(segments)-1])
+	if err != nil || len(root) != 32 {
+		errJson := &network.DefaultErrorJson{
+			Message: "invalid block root: expected a 32 byte hex string",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	bootstrap, err := s.BeaconDB.LightClientBootstrap(r.Context(), bytesutil.ToBytes32(root))
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get light client bootstrap").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if bootstrap == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "light client bootstrap is not available for the block",
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, &LightClientBootstrapResponse{
+		Version: dataVersion(bootstrap.Header.Slot),
+		Data: &LightClientBootstrap{
+			Header:                     lightClientHeader(bootstrap.Header),
+			CurrentSyncCommittee:       syncCommittee(bootstrap.CurrentSyncCommittee),
+			CurrentSyncCommitteeBranch: branch(bootstrap.CurrentSyncCommitteeBranch),
+		},
+	})
+}
+
+// GetLightClientUpdatesByRange is an HTTP handler which returns the best light client updates of the count
+// sync committee periods starting at start_period. The response stops at the first period without an update.
+func (s *Server) GetLightClientUpdatesByRange(w http.ResponseWriter, r *http.Request) {
+	startPeriod, errJson := uint64QueryParam(r, "start_period")
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	count, errJson := uint64QueryParam(r, "count")
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	if count == 0 {
+		errJson := &network.DefaultErrorJson{
+			Message: "count must be greater than 0",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if count > params.BeaconNetworkConfig().MaxRequestLightClientUpdates {
+		count = params.BeaconNetworkConfig().MaxRequestLightClientUpdates
+	}
+
+	updates, err := s.BeaconDB.LightClientUpdates(r.Context(), startPeriod, startPeriod+count-1)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get light client updates").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := make([]*LightClientUpdateWithVersion, 0, len(updates))
+	for i, update := range updates {
+		if lightclient.SyncCommitteePeriod(update.AttestedHeader.Slot) != startPeriod+uint64(i) {
+			break
+		}
+		resp = append(resp, &LightClientUpdateWithVersion{
+			Version: dataVersion(update.AttestedHeader.Slot),
+			Data: &LightClientUpdate{
+				AttestedHeader:          lightClientHeader(update.AttestedHeader),
+				NextSyncCommittee:       syncCommittee(update.NextSyncCommittee),
+				NextSyncCommitteeBranch: branch(update.NextSyncCommitteeBranch),
+				FinalizedHeader:         lightClientHeader(update.FinalizedHeader),
+				FinalityBranch:          branch(update.FinalityBranch),
+				SyncAggregate:           syncAggregate(update.SyncAggregate),
+				SignatureSlot:           strconv.FormatUint(uint64(update.SignatureSlot), 10),
+			},
+		})
+	}
+	network.WriteJson(w, resp)
+}
+
+// GetLightClientFinalityUpdate is an HTTP handler which returns the latest light client finality update.
+func (s *Server) GetLightClientFinalityUpdate(w http.ResponseWriter, _ *http.Request) {
+	update := s.LightClientFetcher.LightClientFinalityUpdate()
+	if update == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "no light client finality update is available",
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, &LightClientFinalityUpdateResponse{
+		Version: dataVersion(update.AttestedHeader.Slot),
+		Data: &LightClientFinalityUpdate{
+			AttestedHeader:  lightClientHeader(update.AttestedHeader),
+			FinalizedHeader: lightClientHeader(update.FinalizedHeader),
+			FinalityBranch:  branch(update.FinalityBranch),
+			SyncAggregate:   syncAggregate(update.SyncAggregate),
+			SignatureSlot:   strconv.FormatUint(uint64(update.SignatureSlot), 10),
+		},
+	})
+}
+
+// GetLightClientOptimisticUpdate is an HTTP handler which returns the latest light client optimistic update.
+func (s *Server) GetLightClientOptimisticUpdate(w http.ResponseWriter, _ *http.Request) {
+	update := s.LightClientFetcher.LightClientOptimisticUpdate()
+	if update == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "no light client optimistic update is available",
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, &LightClientOptimisticUpdateResponse{
+		Version: dataVersion(update.AttestedHeader.Slot),
+		Data: &LightClientOptimisticUpdate{
+			AttestedHeader: lightClientHeader(update.AttestedHeader),
+			SyncAggregate:  syncAggregate(update.SyncAggregate),
+			SignatureSlot:  strconv.FormatUint(uint64(update.SignatureSlot), 10),
+		},
+	})
+}
+
+func uint64QueryParam(r *http.Request, name string) (uint64, *network.DefaultErrorJson) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("%s is required", name),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	v, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "invalid %s", name).Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return v, nil
+}
+
+func dataVersion(slot primitives.Slot) string {
+	return strings.ToLower(lightclient.DataVersion(slot).String())
+}
+
+func lightClientHeader(h *ethpbv1.BeaconBlockHeader) *LightClientHeader {
+	return &LightClientHeader{
+		Beacon: &BeaconBlockHeader{
+			Slot:          strconv.FormatUint(uint64(h.Slot), 10),
+			ProposerIndex: strconv.FormatUint(uint64(h.ProposerIndex), 10),
+			ParentRoot:    hexutil.Encode(h.ParentRoot),
+			StateRoot:     hexutil.Encode(h.StateRoot),
+			BodyRoot:      hexutil.Encode(h.BodyRoot),
+		},
+	}
+}
+
+func syncCommittee(c *ethpbv2.SyncCommittee) *SyncCommittee {
+	pubkeys := make([]string, len(c.Pubkeys))
+	for i, pubkey := range c.Pubkeys {
+		pubkeys[i] = hexutil.Encode(pubkey)
+	}
+	return &SyncCommittee{
+		Pubkeys:         pubkeys,
+		AggregatePubkey: hexutil.Encode(c.AggregatePubkey),
+	}
+}
+
+func syncAggregate(a *ethpbv1.SyncAggregate) *SyncAggregate {
+	return &SyncAggregate{
+		SyncCommitteeBits:      hexutil.Encode(a.SyncCommitteeBits),
+		SyncCommitteeSignature: hexutil.Encode(a.SyncCommitteeSignature),
+	}
+}
+
+func branch(b [][]byte) []string {
+	encoded := make([]string, len(b))
+	for i, node := range b {
+		encoded[i] = hexutil.Encode(node)
+	}
+	return encoded
+}
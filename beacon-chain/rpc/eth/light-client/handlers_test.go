@@ -0,0 +1,185 @@
+package lightclient
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func testHeader(slot primitives.Slot) *ethpbv1.BeaconBlockHeader {
+	return &ethpbv1.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   make([]byte, 32),
+	}
+}
+
+func testSyncCommittee() *ethpbv2.SyncCommittee {
+	pubkeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, 48)
+	}
+	return &ethpbv2.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: make([]byte, 48)}
+}
+
+func testBranch(length int) [][]byte {
+	b := make([][]byte, length)
+	for i := range b {
+		b[i] = make([]byte, 32)
+	}
+	return b
+}
+
+func testUpdate(signatureSlot primitives.Slot) *ethpbv2.LightClientUpdate {
+	return &ethpbv2.LightClientUpdate{
+		AttestedHeader:          testHeader(signatureSlot - 1),
+		NextSyncCommittee:       testSyncCommittee(),
+		NextSyncCommitteeBranch: testBranch(5),
+		FinalizedHeader:         testHeader(0),
+		FinalityBranch:          testBranch(6),
+		SyncAggregate: &ethpbv1.SyncAggregate{
+			SyncCommitteeBits:      bitfield.NewBitvector512(),
+			SyncCommitteeSignature: make([]byte, 96),
+		},
+		SignatureSlot: signatureSlot,
+	}
+}
+
+func TestGetLightClientBootstrap(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	s := &Server{BeaconDB: beaconDB}
+	root := [32]byte{'a'}
+	require.NoError(t, beaconDB.SaveLightClientBootstrap(ctx, root, &ethpbv2.LightClientBootstrap{
+		Header:                     testHeader(32),
+		CurrentSyncCommittee:       testSyncCommittee(),
+		CurrentSyncCommitteeBranch: testBranch(5),
+	}))
+
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/bootstrap/"+hexutil.Encode(root[:]), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientBootstrap(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &LightClientBootstrapResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "altair", resp.Version)
+		assert.Equal(t, "32", resp.Data.Header.Beacon.Slot)
+		assert.Equal(t, int(params.BeaconConfig().SyncCommitteeSize), len(resp.Data.CurrentSyncCommittee.Pubkeys))
+		assert.Equal(t, 5, len(resp.Data.CurrentSyncCommitteeBranch))
+	})
+	t.Run("not found", func(t *testing.T) {
+		other := [32]byte{'b'}
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/bootstrap/"+hexutil.Encode(other[:]), nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientBootstrap(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("invalid root", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/bootstrap/0x1234", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientBootstrap(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "invalid block root", e.Message)
+	})
+}
+
+func TestGetLightClientUpdatesByRange(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	s := &Server{BeaconDB: beaconDB}
+	periodSlots := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	for _, period := range []uint64{1, 2, 4} {
+		require.NoError(t, beaconDB.SaveLightClientUpdate(ctx, period, testUpdate(periodSlots.Mul(period)+2)))
+	}
+
+	t.Run("stops at missing period", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/updates?start_period=1&count=4", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientUpdatesByRange(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		var resp []*LightClientUpdateWithVersion
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), &resp))
+		require.Equal(t, 2, len(resp))
+		assert.Equal(t, "8194", resp[0].Data.SignatureSlot)
+		assert.Equal(t, "16386", resp[1].Data.SignatureSlot)
+	})
+	t.Run("missing count", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/updates?start_period=1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.GetLightClientUpdatesByRange(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "count is required", e.Message)
+	})
+}
+
+func TestGetLightClientFinalityAndOptimisticUpdate(t *testing.T) {
+	chain := &mock.ChainService{}
+	s := &Server{LightClientFetcher: chain}
+
+	request := httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/finality_update", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetLightClientFinalityUpdate(writer, request)
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+
+	update := testUpdate(100)
+	chain.FinalityUpdate = &ethpbv2.LightClientFinalityUpdate{
+		AttestedHeader:  update.AttestedHeader,
+		FinalizedHeader: update.FinalizedHeader,
+		FinalityBranch:  update.FinalityBranch,
+		SyncAggregate:   update.SyncAggregate,
+		SignatureSlot:   update.SignatureSlot,
+	}
+	chain.OptimisticUpdate = &ethpbv2.LightClientOptimisticUpdate{
+		AttestedHeader: update.AttestedHeader,
+		SyncAggregate:  update.SyncAggregate,
+		SignatureSlot:  update.SignatureSlot,
+	}
+
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetLightClientFinalityUpdate(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	finality := &LightClientFinalityUpdateResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), finality))
+	assert.Equal(t, "99", finality.Data.AttestedHeader.Beacon.Slot)
+	assert.Equal(t, "0", finality.Data.FinalizedHeader.Beacon.Slot)
+	assert.Equal(t, 6, len(finality.Data.FinalityBranch))
+	assert.Equal(t, "100", finality.Data.SignatureSlot)
+
+	request = httptest.NewRequest("GET", "http://foo.example/eth/v1/beacon/light_client/optimistic_update", nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.GetLightClientOptimisticUpdate(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	optimistic := &LightClientOptimisticUpdateResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), optimistic))
+	assert.Equal(t, "99", optimistic.Data.AttestedHeader.Beacon.Slot)
+	assert.Equal(t, hexutil.Encode(make([]byte, 96)), optimistic.Data.SyncAggregate.SyncCommitteeSignature)
+}
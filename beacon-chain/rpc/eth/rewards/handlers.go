@@ -1,19 +1,31 @@
 package rewards
 
 import (
+	"encoding/json"
+	"fmt"
+	"io"
 	"net/http"
 	"strconv"
 	"strings"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
 	coreblocks "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/validators"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/prysmaticlabs/prysm/v4/network"
 	"github.com/prysmaticlabs/prysm/v4/runtime/version"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
 // BlockRewards is an HTTP handler for Beacon API getBlockRewards.
@@ -131,6 +143,8 @@ func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
 		network.WriteError(w, errJson)
 		return
 	}
+	// The returned reward is the amount before the burn, while the proposer's balance is increased by the burned amount.
+	syncCommitteeReward = pulse.ApplyBurn(syncCommitteeReward)
 
 	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
 	if err != nil {
@@ -166,6 +180,444 @@ func (s *Server) BlockRewards(w http.ResponseWriter, r *http.Request) {
 	network.WriteJson(w, response)
 }
 
+// AttestationRewards is an HTTP handler for Beacon API getAttestationsRewards.
+// Rewards are reported after the PulseChain burn, ie. they are the amounts credited to the validators during epoch processing.
+func (s *Server) AttestationRewards(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	requestedEpoch, err := strconv.ParseUint(segments[len(segments)-1], 10, 64)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "invalid epoch").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	epoch := primitives.Epoch(requestedEpoch)
+	if epoch < params.BeaconConfig().AltairForkEpoch {
+		errJson := &network.DefaultErrorJson{
+			Message: "attestation rewards are not supported for Phase 0 epochs",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	currentEpoch := slots.ToEpoch(s.TimeFetcher.CurrentSlot())
+	if epoch+1 >= currentEpoch {
+		errJson := &network.DefaultErrorJson{
+			Message: "attestation rewards are available after two epoch transitions to ensure all attestations have a chance of inclusion",
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	// Attestations of the requested epoch can be included until the end of the next epoch,
+	// and they are rewarded during the epoch transition that follows.
+	nextEpochEnd, err := slots.EpochEnd(epoch + 1)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get slot of the next epoch's end").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	st, err := s.ReplayerBuilder.ReplayerForSlot(nextEpochEnd).ReplayToSlot(r.Context(), nextEpochEnd)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get state").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if st.Version() == version.Phase0 {
+		errJson := &network.DefaultErrorJson{
+			Message: "attestation rewards are not supported for Phase 0 epochs",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	valIndices, errJson := requestedValidators(r, st)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+
+	// Run the same steps as the epoch transition prior to processing rewards and penalties,
+	// because justification and inactivity score updates affect the result.
+	vals, bal, err := altair.InitializePrecomputeValidators(r.Context(), st)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not initialize precompute validators").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	vals, bal, err = altair.ProcessEpochParticipation(r.Context(), st, bal, vals)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not process epoch participation").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	st, err = precompute.ProcessJustificationAndFinalizationPreCompute(st, bal)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not process justification").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	st, vals, err = altair.ProcessInactivityScores(r.Context(), st, vals)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not process inactivity scores").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	totalRewards, err := totalAttRewards(st, bal, vals, valIndices)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get attestation rewards").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	idealRewards, err := idealAttRewards(st, bal)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get ideal attestation rewards").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get optimistic mode info").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	response := &AttestationRewardsResponse{
+		Data: AttestationRewards{
+			IdealRewards: idealRewards,
+			TotalRewards: totalRewards,
+		},
+		ExecutionOptimistic: optimistic,
+		Finalized:           s.FinalizationFetcher.FinalizedCheckpt().Epoch > epoch+1,
+	}
+	network.WriteJson(w, response)
+}
+
+// SyncCommitteeRewards is an HTTP handler for Beacon API getSyncCommitteeRewards.
+// Rewards are reported after the PulseChain burn, ie. they are the amounts credited to the validators during block processing.
+func (s *Server) SyncCommitteeRewards(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	blockId := segments[len(segments)-1]
+
+	blk, err := s.Blocker.Block(r.Context(), []byte(blockId))
+	if errJson := handleGetBlockError(blk, err); errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	if blk.Version() == version.Phase0 {
+		errJson := &network.DefaultErrorJson{
+			Message: "sync committee rewards are not supported for Phase 0 blocks",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	st, err := s.ReplayerBuilder.ReplayerForSlot(blk.Block().Slot()-1).ReplayToSlot(r.Context(), blk.Block().Slot())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get state").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	committeeIndices, err := syncCommitteeIndices(st)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get sync committee").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	valIndices, errJson := requestedValidators(r, st)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	if valIndices == nil {
+		valIndices = committeeIndices
+	}
+	inCommittee := make(map[primitives.ValidatorIndex]bool, len(committeeIndices))
+	for _, idx := range committeeIndices {
+		inCommittee[idx] = true
+	}
+	preBalances := make([]uint64, len(valIndices))
+	for i, idx := range valIndices {
+		if !inCommittee[idx] {
+			errJson := &network.DefaultErrorJson{
+				Message: fmt.Sprintf("validator %d is not in the sync committee", idx),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		preBalances[i], err = st.BalanceAtIndex(idx)
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not get validator's balance").Error(),
+				Code:    http.StatusInternalServerError,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+	}
+
+	sa, err := blk.Block().Body().SyncAggregate()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get sync aggregate").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	// Processing the sync aggregate applies the burn to every reward, so balance differences
+	// are exactly what the state transition credits.
+	_, proposerReward, err := altair.ProcessSyncAggregate(r.Context(), st, sa)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get sync aggregate rewards").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	proposerIndex := blk.Block().ProposerIndex()
+	data := make([]SyncCommitteeReward, len(valIndices))
+	for i, idx := range valIndices {
+		bal, err := st.BalanceAtIndex(idx)
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not get validator's balance").Error(),
+				Code:    http.StatusInternalServerError,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		reward := int64(bal) - int64(preBalances[i])
+		// The proposer reward for including the sync aggregate is not a sync committee reward.
+		if idx == proposerIndex {
+			reward -= int64(pulse.ApplyBurn(proposerReward))
+		}
+		data[i] = SyncCommitteeReward{
+			ValidatorIndex: strconv.FormatUint(uint64(idx), 10),
+			Reward:         strconv.FormatInt(reward, 10),
+		}
+	}
+
+	optimistic, err := s.OptimisticModeFetcher.IsOptimistic(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get optimistic mode info").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	blkRoot, err := blk.Block().HashTreeRoot()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get block root").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	response := &SyncCommitteeRewardsResponse{
+		Data:                data,
+		ExecutionOptimistic: optimistic,
+		Finalized:           s.FinalizationFetcher.IsFinalized(r.Context(), blkRoot),
+	}
+	network.WriteJson(w, response)
+}
+
+// totalAttRewards returns the attestation rewards of the given validators, or of all validators if none are given.
+func totalAttRewards(
+	st state.BeaconState,
+	bal *precompute.Balance,
+	vals []*precompute.Validator,
+	valIndices []primitives.ValidatorIndex,
+) ([]TotalAttestationReward, error) {
+	if valIndices == nil {
+		valIndices = make([]primitives.ValidatorIndex, len(vals))
+		for i := range vals {
+			valIndices[i] = primitives.ValidatorIndex(i)
+		}
+	}
+	requested := make([]*precompute.Validator, len(valIndices))
+	for i, idx := range valIndices {
+		requested[i] = vals[idx]
+	}
+	deltas, err := altair.AttestationsDeltaBreakdown(st, bal, requested)
+	if err != nil {
+		return nil, err
+	}
+	rewards := make([]TotalAttestationReward, len(deltas))
+	for i, d := range deltas {
+		head, source, target := burnedAttRewards(d)
+		rewards[i] = TotalAttestationReward{
+			ValidatorIndex: strconv.FormatUint(uint64(valIndices[i]), 10),
+			Head:           strconv.FormatUint(head, 10),
+			Source:         strconv.FormatInt(int64(source)-int64(d.SourcePenalty), 10),
+			Target:         strconv.FormatInt(int64(target)-int64(d.TargetPenalty), 10),
+			Inactivity:     strconv.FormatInt(-int64(d.InactivityPenalty), 10),
+		}
+	}
+	return rewards, nil
+}
+
+// idealAttRewards returns the rewards of a perfectly performing validator for every possible effective balance.
+func idealAttRewards(st state.BeaconState, bal *precompute.Balance) ([]IdealAttestationReward, error) {
+	increment := params.BeaconConfig().EffectiveBalanceIncrement
+	count := params.BeaconConfig().MaxEffectiveBalance / increment
+	idealVals := make([]*precompute.Validator, count)
+	for i := range idealVals {
+		idealVals[i] = &precompute.Validator{
+			IsActivePrevEpoch:            true,
+			CurrentEpochEffectiveBalance: uint64(i+1) * increment,
+			IsPrevEpochSourceAttester:    true,
+			IsPrevEpochTargetAttester:    true,
+			IsPrevEpochHeadAttester:      true,
+		}
+	}
+	deltas, err := altair.AttestationsDeltaBreakdown(st, bal, idealVals)
+	if err != nil {
+		return nil, err
+	}
+	rewards := make([]IdealAttestationReward, len(deltas))
+	for i, d := range deltas {
+		head, source, target := burnedAttRewards(d)
+		rewards[i] = IdealAttestationReward{
+			EffectiveBalance: strconv.FormatUint(idealVals[i].CurrentEpochEffectiveBalance, 10),
+			Head:             strconv.FormatUint(head, 10),
+			Source:           strconv.FormatUint(source, 10),
+			Target:           strconv.FormatUint(target, 10),
+			Inactivity:       "0",
+		}
+	}
+	return rewards, nil
+}
+
+// burnedAttRewards returns the head, source and target rewards after the PulseChain burn.
+// Epoch processing burns the sum of a validator's rewards at once, so the target reward absorbs
+// the rounding difference and the three components add up to the amount credited to the validator.
+func burnedAttRewards(d *altair.AttDelta) (head, source, target uint64) {
+	total := pulse.ApplyBurn(d.HeadReward + d.SourceReward + d.TargetReward)
+	head = pulse.ApplyBurn(d.HeadReward)
+	source = pulse.ApplyBurn(d.SourceReward)
+	return head, source, total - head - source
+}
+
+// syncCommitteeIndices returns the distinct validator indices of the state's current sync committee.
+func syncCommitteeIndices(st state.BeaconState) ([]primitives.ValidatorIndex, error) {
+	sc, err := st.CurrentSyncCommittee()
+	if err != nil {
+		return nil, err
+	}
+	seen := make(map[primitives.ValidatorIndex]bool, len(sc.Pubkeys))
+	indices := make([]primitives.ValidatorIndex, 0, len(sc.Pubkeys))
+	for _, pk := range sc.Pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		if !ok {
+			return nil, fmt.Errorf("sync committee member %#x not found in the validator registry", pk)
+		}
+		if seen[idx] {
+			continue
+		}
+		seen[idx] = true
+		indices = append(indices, idx)
+	}
+	return indices, nil
+}
+
+// requestedValidators reads the optional list of validator indices or public keys from the request body.
+// A nil slice is returned when the body is empty.
+func requestedValidators(r *http.Request, st state.ReadOnlyBeaconState) ([]primitives.ValidatorIndex, *network.DefaultErrorJson) {
+	var ids []string
+	if r.Body != nil && r.Body != http.NoBody {
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil && err != io.EOF {
+			return nil, &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not decode validator IDs").Error(),
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, nil
+	}
+	indices := make([]primitives.ValidatorIndex, len(ids))
+	for i, id := range ids {
+		if strings.HasPrefix(id, "0x") {
+			pubkey, err := hexutil.Decode(id)
+			if err != nil || len(pubkey) != fieldparams.BLSPubkeyLength {
+				return nil, &network.DefaultErrorJson{
+					Message: fmt.Sprintf("invalid validator public key %s", id),
+					Code:    http.StatusBadRequest,
+				}
+			}
+			idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pubkey))
+			if !ok {
+				return nil, &network.DefaultErrorJson{
+					Message: fmt.Sprintf("unknown validator public key %s", id),
+					Code:    http.StatusBadRequest,
+				}
+			}
+			indices[i] = idx
+			continue
+		}
+		idx, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("invalid validator index %s", id),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		if idx >= uint64(st.NumValidators()) {
+			return nil, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("validator index %d is too large, the registry contains %d validators", idx, st.NumValidators()),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		indices[i] = primitives.ValidatorIndex(idx)
+	}
+	return indices, nil
+}
+
 func handleGetBlockError(blk interfaces.ReadOnlySignedBeaconBlock, err error) *network.DefaultErrorJson {
 	if errors.Is(err, lookup.BlockIdParseError{}) {
 		return &network.DefaultErrorJson{
@@ -6,11 +6,15 @@ import (
 	"encoding/json"
 	"net/http"
 	"net/http/httptest"
+	"strconv"
 	"testing"
 
+	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/prysmaticlabs/go-bitfield"
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/altair"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/testutil"
 	mockstategen "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen/mock"
@@ -27,6 +31,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
 	"github.com/prysmaticlabs/prysm/v4/testing/require"
 	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
 )
 
 func TestBlockRewards(t *testing.T) {
@@ -174,9 +179,9 @@ func TestBlockRewards(t *testing.T) {
 		resp := &BlockRewardsResponse{}
 		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
 		assert.Equal(t, "12", resp.Data.ProposerIndex)
-		assert.Equal(t, "125067129", resp.Data.Total)
+		assert.Equal(t, "125067117", resp.Data.Total)
 		assert.Equal(t, "67081", resp.Data.Attestations)
-		assert.Equal(t, "48", resp.Data.SyncAggregate)
+		assert.Equal(t, "36", resp.Data.SyncAggregate)
 		assert.Equal(t, "62500000", resp.Data.AttesterSlashings)
 		assert.Equal(t, "62500000", resp.Data.ProposerSlashings)
 		assert.Equal(t, true, resp.ExecutionOptimistic)
@@ -196,3 +201,266 @@ func TestBlockRewards(t *testing.T) {
 		assert.Equal(t, "block rewards are not supported for Phase 0 blocks", e.Message)
 	})
 }
+
+func TestAttestationRewards(t *testing.T) {
+	params.SetupTestConfigCleanup(t)
+	cfg := params.BeaconConfig().Copy()
+	cfg.AltairForkEpoch = 0
+	params.OverrideBeaconConfig(cfg)
+
+	valCount := 64
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	slot, err := slots.EpochEnd(1)
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(slot))
+	validators := make([]*eth.Validator, 0, valCount)
+	balances := make([]uint64, 0, valCount)
+	for i := 0; i < valCount; i++ {
+		blsKey, err := bls.RandKey()
+		require.NoError(t, err)
+		validators = append(validators, &eth.Validator{
+			PublicKey:         blsKey.PublicKey().Marshal(),
+			ExitEpoch:         params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch: params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance:  params.BeaconConfig().MaxEffectiveBalance,
+		})
+		balances = append(balances, params.BeaconConfig().MaxEffectiveBalance)
+	}
+	require.NoError(t, st.SetValidators(validators))
+	require.NoError(t, st.SetBalances(balances))
+	require.NoError(t, st.SetInactivityScores(make([]uint64, valCount)))
+	require.NoError(t, st.SetCurrentParticipationBits(make([]byte, valCount)))
+	// Every validator except the one at index 1 attested correctly to the previous epoch.
+	participation := make([]byte, valCount)
+	for i := range participation {
+		participation[i] = 0b111
+	}
+	participation[1] = 0
+	require.NoError(t, st.SetPreviousParticipationBits(participation))
+
+	currentSlot := primitives.Slot(3 * params.BeaconConfig().SlotsPerEpoch)
+	mockChainService := &mock.ChainService{Optimistic: true, Slot: &currentSlot, FinalizedCheckPoint: &eth.Checkpoint{Epoch: 0}}
+	replayerBuilder := mockstategen.NewMockReplayerBuilder()
+	replayerBuilder.SetMockStateForSlot(st.Copy(), slot)
+	s := &Server{
+		OptimisticModeFetcher: mockChainService,
+		FinalizationFetcher:   mockChainService,
+		TimeFetcher:           mockChainService,
+		ReplayerBuilder:       replayerBuilder,
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		var body bytes.Buffer
+		require.NoError(t, json.NewEncoder(&body).Encode([]string{"0", hexutil.Encode(validators[1].PublicKey)}))
+		request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/attestations/0", &body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, false, resp.Finalized)
+		require.Equal(t, 2, len(resp.Data.TotalRewards))
+
+		// The rewards of a correct attester must add up to the balance increase of the epoch transition.
+		postSt, err := altair.ProcessEpoch(context.Background(), st.Copy())
+		require.NoError(t, err)
+		postBal, err := postSt.BalanceAtIndex(0)
+		require.NoError(t, err)
+		correct := resp.Data.TotalRewards[0]
+		assert.Equal(t, "0", correct.ValidatorIndex)
+		assert.Equal(t, "0", correct.Inactivity)
+		assert.Equal(t, int64(postBal-balances[0]), parseInt(t, correct.Head)+parseInt(t, correct.Source)+parseInt(t, correct.Target))
+
+		missed := resp.Data.TotalRewards[1]
+		assert.Equal(t, "1", missed.ValidatorIndex)
+		assert.Equal(t, "0", missed.Head)
+		postBal, err = postSt.BalanceAtIndex(1)
+		require.NoError(t, err)
+		assert.Equal(t, int64(postBal)-int64(balances[1]), parseInt(t, missed.Source)+parseInt(t, missed.Target)+parseInt(t, missed.Inactivity))
+		assert.Equal(t, true, parseInt(t, missed.Source) < 0)
+		assert.Equal(t, true, parseInt(t, missed.Target) < 0)
+
+		increments := params.BeaconConfig().MaxEffectiveBalance / params.BeaconConfig().EffectiveBalanceIncrement
+		require.Equal(t, int(increments), len(resp.Data.IdealRewards))
+		ideal := resp.Data.IdealRewards[increments-1]
+		assert.Equal(t, strconv.FormatUint(params.BeaconConfig().MaxEffectiveBalance, 10), ideal.EffectiveBalance)
+		assert.Equal(t, correct.Head, ideal.Head)
+		assert.Equal(t, correct.Source, ideal.Source)
+		assert.Equal(t, correct.Target, ideal.Target)
+	})
+	t.Run("all validators", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/attestations/0", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &AttestationRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, valCount, len(resp.Data.TotalRewards))
+	})
+	t.Run("epoch not yet rewarded", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/attestations/2", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("unknown validator", func(t *testing.T) {
+		var body bytes.Buffer
+		require.NoError(t, json.NewEncoder(&body).Encode([]string{"64"}))
+		request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/attestations/0", &body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.AttestationRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "validator index 64 is too large", e.Message)
+	})
+}
+
+func TestSyncCommitteeRewards(t *testing.T) {
+	valCount := 64
+
+	st, err := util.NewBeaconStateAltair()
+	require.NoError(t, err)
+	require.NoError(t, st.SetSlot(1))
+	validators := make([]*eth.Validator, 0, valCount)
+	balances := make([]uint64, 0, valCount)
+	secretKeys := make(map[[fieldparams.BLSPubkeyLength]byte]bls.SecretKey, valCount)
+	for i := 0; i < valCount; i++ {
+		blsKey, err := bls.RandKey()
+		require.NoError(t, err)
+		secretKeys[bytesutil.ToBytes48(blsKey.PublicKey().Marshal())] = blsKey
+		validators = append(validators, &eth.Validator{
+			PublicKey:         blsKey.PublicKey().Marshal(),
+			ExitEpoch:         params.BeaconConfig().FarFutureEpoch,
+			WithdrawableEpoch: params.BeaconConfig().FarFutureEpoch,
+			EffectiveBalance:  params.BeaconConfig().MaxEffectiveBalance,
+		})
+		balances = append(balances, params.BeaconConfig().MaxEffectiveBalance)
+	}
+	require.NoError(t, st.SetValidators(validators))
+	require.NoError(t, st.SetBalances(balances))
+	syncCommittee, err := altair.NextSyncCommittee(context.Background(), st)
+	require.NoError(t, err)
+	require.NoError(t, st.SetCurrentSyncCommittee(syncCommittee))
+	// This validator joined after the sync committee was selected.
+	outsider, err := bls.RandKey()
+	require.NoError(t, err)
+	require.NoError(t, st.AppendValidator(&eth.Validator{
+		PublicKey:         outsider.PublicKey().Marshal(),
+		ExitEpoch:         params.BeaconConfig().FarFutureEpoch,
+		WithdrawableEpoch: params.BeaconConfig().FarFutureEpoch,
+		EffectiveBalance:  params.BeaconConfig().MaxEffectiveBalance,
+	}))
+	require.NoError(t, st.AppendBalance(params.BeaconConfig().MaxEffectiveBalance))
+	slot0bRoot := bytesutil.PadTo([]byte("slot0root"), 32)
+	bRoots := make([][]byte, fieldparams.BlockRootsLength)
+	bRoots[0] = slot0bRoot
+	require.NoError(t, st.SetBlockRoots(bRoots))
+
+	// The first half of the committee participates.
+	domain, err := signing.Domain(st.Fork(), 0, params.BeaconConfig().DomainSyncCommittee, st.GenesisValidatorsRoot())
+	require.NoError(t, err)
+	sszBytes := primitives.SSZBytes(slot0bRoot)
+	r, err := signing.ComputeSigningRoot(&sszBytes, domain)
+	require.NoError(t, err)
+	scBits := bitfield.NewBitvector512()
+	sigs := make([]bls.Signature, 0)
+	participated := make(map[primitives.ValidatorIndex]int64)
+	missed := make(map[primitives.ValidatorIndex]int64)
+	for i, pk := range syncCommittee.Pubkeys {
+		idx, ok := st.ValidatorIndexByPubkey(bytesutil.ToBytes48(pk))
+		require.Equal(t, true, ok)
+		if i >= len(syncCommittee.Pubkeys)/2 {
+			missed[idx]++
+			continue
+		}
+		participated[idx]++
+		scBits.SetBitAt(uint64(i), true)
+		sigs = append(sigs, secretKeys[bytesutil.ToBytes48(pk)].Sign(r[:]))
+	}
+
+	b := util.HydrateSignedBeaconBlockAltair(util.NewBeaconBlockAltair())
+	b.Block.Slot = 2
+	// we have to set the proposer index to the value that will be randomly chosen (fortunately it's deterministic)
+	b.Block.ProposerIndex = 12
+	b.Block.Body.SyncAggregate = &eth.SyncAggregate{SyncCommitteeBits: scBits, SyncCommitteeSignature: bls.AggregateSignatures(sigs).Marshal()}
+	sbb, err := blocks.NewSignedBeaconBlock(b)
+	require.NoError(t, err)
+	phase0block, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+
+	activeBalance, err := helpers.TotalActiveBalance(st)
+	require.NoError(t, err)
+	_, participantReward, err := altair.SyncRewards(activeBalance)
+	require.NoError(t, err)
+
+	mockChainService := &mock.ChainService{Optimistic: true}
+	s := &Server{
+		Blocker: &testutil.MockBlocker{SlotBlockMap: map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock{
+			0: phase0block,
+			2: sbb,
+		}},
+		OptimisticModeFetcher: mockChainService,
+		FinalizationFetcher:   mockChainService,
+		ReplayerBuilder:       mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(st)),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		var body bytes.Buffer
+		ids := []string{"12", hexutil.Encode(syncCommittee.Pubkeys[len(syncCommittee.Pubkeys)-1])}
+		require.NoError(t, json.NewEncoder(&body).Encode(ids))
+		request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/sync_committee/2", &body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SyncCommitteeRewards(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &SyncCommitteeRewardsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, true, resp.ExecutionOptimistic)
+		assert.Equal(t, false, resp.Finalized)
+		require.Equal(t, 2, len(resp.Data))
+		for _, d := range resp.Data {
+			idx := primitives.ValidatorIndex(parseInt(t, d.ValidatorIndex))
+			want := participated[idx]*int64(pulse.ApplyBurn(participantReward)) - missed[idx]*int64(participantReward)
+			assert.Equal(t, strconv.FormatInt(want, 10), d.Reward)
+		}
+	})
+	t.Run("not in sync committee", func(t *testing.T) {
+		var body bytes.Buffer
+		require.NoError(t, json.NewEncoder(&body).Encode([]string{strconv.Itoa(valCount)}))
+		request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/sync_committee/2", &body)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SyncCommitteeRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+	t.Run("phase 0", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://example.com/eth/v1/beacon/rewards/sync_committee/0", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+
+		s.SyncCommitteeRewards(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.Equal(t, "sync committee rewards are not supported for Phase 0 blocks", e.Message)
+	})
+}
+
+func parseInt(t *testing.T, s string) int64 {
+	i, err := strconv.ParseInt(s, 10, 64)
+	require.NoError(t, err)
+	return i
+}
@@ -0,0 +1,266 @@
+package burn
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/transition"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/filters"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// EpochBurn is an HTTP handler which returns the rewards paid in each epoch of the range given by the start_epoch
+// and end_epoch query parameters, before and after the PulseChain burn. Only finalized epochs are reported, and
+// epochs without any recorded burn are omitted.
+func (s *Server) EpochBurn(w http.ResponseWriter, r *http.Request) {
+	start, errJson := epochQueryParam(r, "start_epoch")
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	end := start
+	if r.URL.Query().Get("end_epoch") != "" {
+		end, errJson = epochQueryParam(r, "end_epoch")
+		if errJson != nil {
+			network.WriteError(w, errJson)
+			return
+		}
+	}
+	if end < start {
+		errJson := &network.DefaultErrorJson{
+			Message: "end_epoch must not be lower than start_epoch",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	burns, err := s.BeaconDB.EpochBurns(r.Context(), start, end)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get epoch burn").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := &EpochBurnResponse{Data: make([]*EpochBurn, len(burns))}
+	var total pulse.BurnTotals
+	for i, b := range burns {
+		resp.Data[i] = &EpochBurn{
+			Epoch:         strconv.FormatUint(uint64(b.Epoch), 10),
+			Attestation:   burnTotals(b.Source(pulse.AttestationBurn)),
+			SyncCommittee: burnTotals(b.Source(pulse.SyncCommitteeBurn)),
+			Proposer:      burnTotals(b.Source(pulse.ProposerBurn)),
+			Total:         burnTotals(b.Total()),
+		}
+		total.Add(b.Total())
+	}
+	resp.Total = burnTotals(total)
+	network.WriteJson(w, resp)
+}
+
+// ValidatorBurn is an HTTP handler which returns the rewards paid to each of the requested validators in the
+// epoch, before and after the PulseChain burn. Per validator totals are not stored, so the epoch is replayed.
+func (s *Server) ValidatorBurn(w http.ResponseWriter, r *http.Request) {
+	segments := strings.Split(r.URL.Path, "/")
+	requestedEpoch, err := strconv.ParseUint(segments[len(segments)-1], 10, 64)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "invalid epoch").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	epoch := primitives.Epoch(requestedEpoch)
+	// The rewards of an epoch are complete once the epoch transition that follows it has been processed.
+	endSlot, err := slots.EpochStart(epoch + 1)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get start slot of the next epoch").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if endSlot > s.TimeFetcher.CurrentSlot() {
+		errJson := &network.DefaultErrorJson{
+			Message: "validator burn is available once the epoch transition following the epoch has been processed",
+			Code:    http.StatusNotFound,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	// The burn recorded for an epoch starts with the block processing of its first slot,
+	// so the replay starts from the state just before it.
+	startSlot, err := slots.EpochStart(epoch)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get start slot of the epoch").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	if startSlot > 0 {
+		startSlot--
+	}
+	st, err := s.ReplayerBuilder.ReplayerForSlot(startSlot).ReplayToSlot(r.Context(), startSlot)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get state").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	valIndices, errJson := requestedValidators(r, st)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	blks, err := s.canonicalBlocks(r, startSlot+1, endSlot)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not get blocks").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	tracker := pulse.NewBurnTracker(valIndices...)
+	ctx := pulse.WithBurnTracker(r.Context(), tracker)
+	for _, b := range blks {
+		st, err = stategen.ReplayProcessSlots(ctx, st, b.Block().Slot())
+		if err == nil {
+			st, err = transition.ProcessBlockForStateRoot(ctx, st, b)
+		}
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not replay block at slot %d", b.Block().Slot()).Error(),
+				Code:    http.StatusInternalServerError,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+	}
+	if _, err = stategen.ReplayProcessSlots(ctx, st, endSlot); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "could not process epoch transition").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	resp := &ValidatorBurnResponse{Data: make([]*ValidatorBurn, len(valIndices))}
+	for i, idx := range valIndices {
+		t := tracker.Validator(epoch, idx)
+		resp.Data[i] = &ValidatorBurn{
+			ValidatorIndex: strconv.FormatUint(uint64(idx), 10),
+			PreBurn:        strconv.FormatUint(t.PreBurn, 10),
+			PostBurn:       strconv.FormatUint(t.PostBurn, 10),
+			Burned:         strconv.FormatUint(t.Burned(), 10),
+		}
+	}
+	network.WriteJson(w, resp)
+}
+
+// canonicalBlocks returns the canonical blocks in the slot range [start, end], sorted by slot.
+func (s *Server) canonicalBlocks(r *http.Request, start, end primitives.Slot) ([]interfaces.ReadOnlySignedBeaconBlock, error) {
+	blks, roots, err := s.BeaconDB.Blocks(r.Context(), filters.NewFilter().SetStartSlot(start).SetEndSlot(end))
+	if err != nil {
+		return nil, err
+	}
+	canonical := make([]interfaces.ReadOnlySignedBeaconBlock, 0, len(blks))
+	for i, b := range blks {
+		ok, err := s.CanonicalFetcher.IsCanonical(r.Context(), roots[i])
+		if err != nil {
+			return nil, err
+		}
+		if ok {
+			canonical = append(canonical, b)
+		}
+	}
+	sort.Slice(canonical, func(i, j int) bool {
+		return canonical[i].Block().Slot() < canonical[j].Block().Slot()
+	})
+	return canonical, nil
+}
+
+func requestedValidators(r *http.Request, st state.ReadOnlyBeaconState) ([]primitives.ValidatorIndex, *network.DefaultErrorJson) {
+	var ids []string
+	if r.Body != nil && r.Body != http.NoBody {
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil && err != io.EOF {
+			return nil, &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not decode validator indices").Error(),
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, &network.DefaultErrorJson{
+			Message: "no validator indices requested",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	indices := make([]primitives.ValidatorIndex, len(ids))
+	for i, id := range ids {
+		idx, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("invalid validator index %s", id),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		if idx >= uint64(st.NumValidators()) {
+			return nil, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("validator index %d is too large, the registry contains %d validators", idx, st.NumValidators()),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		indices[i] = primitives.ValidatorIndex(idx)
+	}
+	return indices, nil
+}
+
+func epochQueryParam(r *http.Request, name string) (primitives.Epoch, *network.DefaultErrorJson) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("%s is required", name),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	e, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "invalid %s", name).Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return primitives.Epoch(e), nil
+}
+
+func burnTotals(t pulse.BurnTotals) *BurnTotals {
+	return &BurnTotals{
+		PreBurn:  strconv.FormatUint(t.PreBurn, 10),
+		PostBurn: strconv.FormatUint(t.PostBurn, 10),
+		Burned:   strconv.FormatUint(t.Burned(), 10),
+	}
+}
@@ -0,0 +1,182 @@
+package burn
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/pulse"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	mockstategen "github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen/mock"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestEpochBurn(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	s := &Server{BeaconDB: beaconDB}
+
+	var parent [32]byte
+	roots := make([][32]byte, 0, 65)
+	for slot := primitives.Slot(0); slot <= 64; slot++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parent[:]
+		sb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, sb))
+		parent, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		roots = append(roots, parent)
+		if slot == 0 {
+			require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, parent))
+			st, err := util.NewBeaconState()
+			require.NoError(t, err)
+			require.NoError(t, beaconDB.SaveState(ctx, st, parent))
+		}
+	}
+	b := &pulse.EpochBurn{Epoch: 0}
+	b.Sources[pulse.AttestationBurn] = pulse.BurnTotals{PreBurn: 1000, PostBurn: 750}
+	b.Sources[pulse.ProposerBurn] = pulse.BurnTotals{PreBurn: 40, PostBurn: 30}
+	require.NoError(t, beaconDB.SaveBlockBurn(ctx, 32, roots[32], []*pulse.EpochBurn{b}))
+	b = &pulse.EpochBurn{Epoch: 1}
+	b.Sources[pulse.SyncCommitteeBurn] = pulse.BurnTotals{PreBurn: 8, PostBurn: 6}
+	require.NoError(t, beaconDB.SaveBlockBurn(ctx, 40, roots[40], []*pulse.EpochBurn{b}))
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &eth.Checkpoint{Epoch: 2, Root: roots[64][:]}))
+	_, err := beaconDB.AggregateFinalizedBurn(ctx)
+	require.NoError(t, err)
+
+	t.Run("epoch range", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/prysm/pulse/burn?start_epoch=0&end_epoch=5", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.EpochBurn(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &EpochBurnResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "0", resp.Data[0].Epoch)
+		assert.DeepEqual(t, &BurnTotals{PreBurn: "1000", PostBurn: "750", Burned: "250"}, resp.Data[0].Attestation)
+		assert.DeepEqual(t, &BurnTotals{PreBurn: "0", PostBurn: "0", Burned: "0"}, resp.Data[0].SyncCommittee)
+		assert.DeepEqual(t, &BurnTotals{PreBurn: "40", PostBurn: "30", Burned: "10"}, resp.Data[0].Proposer)
+		assert.DeepEqual(t, &BurnTotals{PreBurn: "1040", PostBurn: "780", Burned: "260"}, resp.Data[0].Total)
+		assert.Equal(t, "1", resp.Data[1].Epoch)
+		assert.DeepEqual(t, &BurnTotals{PreBurn: "8", PostBurn: "6", Burned: "2"}, resp.Data[1].SyncCommittee)
+		assert.DeepEqual(t, &BurnTotals{PreBurn: "1048", PostBurn: "786", Burned: "262"}, resp.Total)
+	})
+	t.Run("single epoch", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/prysm/pulse/burn?start_epoch=1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.EpochBurn(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &EpochBurnResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].Epoch)
+		assert.DeepEqual(t, &BurnTotals{PreBurn: "8", PostBurn: "6", Burned: "2"}, resp.Total)
+	})
+	t.Run("invalid range", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/prysm/pulse/burn?start_epoch=5&end_epoch=1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.EpochBurn(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "end_epoch must not be lower than start_epoch", e.Message)
+	})
+	t.Run("missing start epoch", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/prysm/pulse/burn", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.EpochBurn(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "start_epoch is required", e.Message)
+	})
+}
+
+func TestValidatorBurn(t *testing.T) {
+	st, _ := util.DeterministicGenesisStateAltair(t, 64)
+	require.NoError(t, st.SetSlot(31))
+	// Every validator attested with all flags during epoch 0, so they are rewarded at the end of epoch 1.
+	bits := make([]byte, st.NumValidators())
+	for i := range bits {
+		bits[i] = 0b111
+	}
+	require.NoError(t, st.SetCurrentParticipationBits(bits))
+
+	currentSlot := primitives.Slot(64)
+	chain := &mock.ChainService{Slot: &currentSlot}
+	s := &Server{
+		BeaconDB:         dbtest.SetupDB(t),
+		CanonicalFetcher: chain,
+		TimeFetcher:      chain,
+		ReplayerBuilder:  mockstategen.NewMockReplayerBuilder(mockstategen.WithMockState(st)),
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		body, err := json.Marshal([]string{"3", "10"})
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/pulse/burn/validators/1", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ValidatorBurn(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorBurnResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "3", resp.Data[0].ValidatorIndex)
+		assert.Equal(t, "10", resp.Data[1].ValidatorIndex)
+		for _, d := range resp.Data {
+			pre, err := strconv.ParseUint(d.PreBurn, 10, 64)
+			require.NoError(t, err)
+			post, err := strconv.ParseUint(d.PostBurn, 10, 64)
+			require.NoError(t, err)
+			assert.Equal(t, true, pre > post)
+			assert.Equal(t, pulse.ApplyBurn(pre), post)
+			assert.Equal(t, strconv.FormatUint(pre-post, 10), d.Burned)
+		}
+	})
+	t.Run("epoch not complete", func(t *testing.T) {
+		body, err := json.Marshal([]string{"3"})
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/pulse/burn/validators/2", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ValidatorBurn(writer, request)
+		assert.Equal(t, http.StatusNotFound, writer.Code)
+	})
+	t.Run("no validators", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/pulse/burn/validators/1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ValidatorBurn(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "no validator indices requested", e.Message)
+	})
+	t.Run("unknown validator", func(t *testing.T) {
+		body, err := json.Marshal([]string{"64"})
+		require.NoError(t, err)
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/pulse/burn/validators/1", bytes.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ValidatorBurn(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+	})
+}
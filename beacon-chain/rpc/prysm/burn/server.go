@@ -0,0 +1,14 @@
+package burn
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
+)
+
+type Server struct {
+	BeaconDB         db.ReadOnlyDatabase
+	CanonicalFetcher blockchain.CanonicalFetcher
+	TimeFetcher      blockchain.TimeFetcher
+	ReplayerBuilder  stategen.ReplayerBuilder
+}
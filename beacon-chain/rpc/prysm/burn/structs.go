@@ -0,0 +1,31 @@
+package burn
+
+type EpochBurnResponse struct {
+	Data  []*EpochBurn `json:"data"`
+	Total *BurnTotals  `json:"total"`
+}
+
+type EpochBurn struct {
+	Epoch         string      `json:"epoch"`
+	Attestation   *BurnTotals `json:"attestation"`
+	SyncCommittee *BurnTotals `json:"sync_committee"`
+	Proposer      *BurnTotals `json:"proposer"`
+	Total         *BurnTotals `json:"total"`
+}
+
+type BurnTotals struct {
+	PreBurn  string `json:"pre_burn"`
+	PostBurn string `json:"post_burn"`
+	Burned   string `json:"burned"`
+}
+
+type ValidatorBurnResponse struct {
+	Data []*ValidatorBurn `json:"data"`
+}
+
+type ValidatorBurn struct {
+	ValidatorIndex string `json:"validator_index"`
+	PreBurn        string `json:"pre_burn"`
+	PostBurn       string `json:"post_burn"`
+	Burned         string `json:"burned"`
+}
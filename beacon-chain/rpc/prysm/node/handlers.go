@@ -0,0 +1,30 @@
+package node
+
+import (
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+// History is an HTTP handler which reports the range of slots for which the node keeps blocks and states.
+// Blocks and states below the earliest available slot, other than genesis, have been deleted by beacon DB pruning.
+func (s *Server) History(w http.ResponseWriter, r *http.Request) {
+	earliest, err := s.BeaconDB.EarliestAvailableSlot(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get earliest available slot").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, &HistoryResponse{
+		Data: &History{
+			EarliestAvailableSlot: strconv.FormatUint(uint64(earliest), 10),
+			Pruned:                earliest > params.BeaconConfig().GenesisSlot,
+		},
+	})
+}
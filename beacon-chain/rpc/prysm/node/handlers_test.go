@@ -0,0 +1,68 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	eth "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestHistory(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	s := &Server{BeaconDB: beaconDB}
+
+	request := httptest.NewRequest("GET", "http://foo.example/prysm/node/history", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.History(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &HistoryResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "0", resp.Data.EarliestAvailableSlot)
+	assert.Equal(t, false, resp.Data.Pruned)
+
+	// Save a chain of blocks with a state at slot 32, and prune the history below it.
+	var parent [32]byte
+	for slot := primitives.Slot(0); slot <= 40; slot++ {
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parent[:]
+		sb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		require.NoError(t, beaconDB.SaveBlock(ctx, sb))
+		parent, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		if slot == 0 {
+			require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, parent))
+		}
+		if slot%32 == 0 {
+			st, err := util.NewBeaconState()
+			require.NoError(t, err)
+			require.NoError(t, st.SetSlot(slot))
+			require.NoError(t, beaconDB.SaveState(ctx, st, parent))
+		}
+	}
+	require.NoError(t, beaconDB.SaveFinalizedCheckpoint(ctx, &eth.Checkpoint{Epoch: 1, Root: parent[:]}))
+	_, err := beaconDB.DeleteHistoricalDataBeforeSlot(ctx, 32, 100)
+	require.NoError(t, err)
+
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.History(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp = &HistoryResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "32", resp.Data.EarliestAvailableSlot)
+	assert.Equal(t, true, resp.Data.Pruned)
+}
@@ -0,0 +1,292 @@
+package node
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+// AddPeer is an HTTP handler which connects to the peer given by the multiaddress or ENR in the request body. The
+// peer is added to the trusted peers of the node when requested, which exempts it from pruning, score based
+// disconnects and our peer limits.
+func (s *Server) AddPeer(w http.ResponseWriter, r *http.Request) {
+	if !s.peerAdminEnabled(w) {
+		return
+	}
+	req := &AddPeerRequest{}
+	if errJson := decodeRequest(r, req); errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	if req.Addr == "" {
+		errJson := &network.DefaultErrorJson{
+			Message: "no peer address requested",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	pid, err := s.PeerAdmin.AddPeer(r.Context(), req.Addr, req.Trusted)
+	if err != nil {
+		// The peer id is only known once the address could be parsed.
+		code := http.StatusBadRequest
+		if pid != "" {
+			code = http.StatusInternalServerError
+		}
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not add peer").Error(),
+			Code:    code,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	network.WriteJson(w, &AddPeerResponse{Data: &AddedPeer{PeerId: pid.String()}})
+}
+
+// DisconnectPeer is an HTTP handler which says goodbye to the peer given in the request path and disconnects from
+// it. The goodbye reason is given by the reason query parameter as a goodbye code, and defaults to a generic error.
+func (s *Server) DisconnectPeer(w http.ResponseWriter, r *http.Request) {
+	if !s.peerAdminEnabled(w) {
+		return
+	}
+	segments := strings.Split(r.URL.Path, "/")
+	pid, errJson := decodePeerID(segments[len(segments)-1])
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	code := types.GoodbyeCodeGenericError
+	if rawReason := r.URL.Query().Get("reason"); rawReason != "" {
+		reason, err := strconv.ParseUint(rawReason, 10, 64)
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrap(err, "invalid goodbye reason").Error(),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		code = types.RPCGoodbyeCode(reason)
+		if _, ok := types.GoodbyeCodeMessages[code]; !ok {
+			errJson := &network.DefaultErrorJson{
+				Message: fmt.Sprintf("unknown goodbye reason %d", reason),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+	}
+	if err := s.PeerAdmin.DisconnectPeer(r.Context(), pid, code); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not disconnect peer").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// TrustedPeers is an HTTP handler which returns the trusted peers of the node.
+func (s *Server) TrustedPeers(w http.ResponseWriter, _ *http.Request) {
+	if !s.peerAdminEnabled(w) {
+		return
+	}
+	s.writeTrustedPeers(w)
+}
+
+// TrustPeer is an HTTP handler which adds the peer given in the request body to the trusted peers of the node. It
+// returns the updated list of trusted peers.
+func (s *Server) TrustPeer(w http.ResponseWriter, r *http.Request) {
+	s.setPeerTrusted(w, r, true)
+}
+
+// UntrustPeer is an HTTP handler which removes the peer given in the request body from the trusted peers of the
+// node. It returns the updated list of trusted peers.
+func (s *Server) UntrustPeer(w http.ResponseWriter, r *http.Request) {
+	s.setPeerTrusted(w, r, false)
+}
+
+// Bans is an HTTP handler which returns the peers and ip subnets currently banned by the node.
+func (s *Server) Bans(w http.ResponseWriter, _ *http.Request) {
+	if !s.peerAdminEnabled(w) {
+		return
+	}
+	s.writeBans(w)
+}
+
+// Ban is an HTTP handler which bans the peer or the ip subnet, in CIDR notation, given in the request body for the
+// requested duration. Connected peers affected by the ban are disconnected. It returns the updated list of bans.
+func (s *Server) Ban(w http.ResponseWriter, r *http.Request) {
+	if !s.peerAdminEnabled(w) {
+		return
+	}
+	req := &BanRequest{}
+	if errJson := decodeRequest(r, req); errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	duration, err := time.ParseDuration(req.Duration)
+	if err != nil || duration <= 0 {
+		errJson := &network.DefaultErrorJson{
+			Message: fmt.Sprintf("invalid ban duration %q", req.Duration),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	pid, subnet, errJson := banTarget(req.PeerId, req.Cidr)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	if subnet != nil {
+		s.PeerAdmin.BanSubnet(r.Context(), subnet, duration)
+	} else {
+		s.PeerAdmin.BanPeer(r.Context(), pid, duration)
+	}
+	s.writeBans(w)
+}
+
+// Unban is an HTTP handler which lifts the ban of the peer or the ip subnet given in the request body. It returns
+// the updated list of bans.
+func (s *Server) Unban(w http.ResponseWriter, r *http.Request) {
+	if !s.peerAdminEnabled(w) {
+		return
+	}
+	req := &UnbanRequest{}
+	if errJson := decodeRequest(r, req); errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	pid, subnet, errJson := banTarget(req.PeerId, req.Cidr)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	if subnet != nil {
+		s.PeerAdmin.UnbanSubnet(subnet)
+	} else {
+		s.PeerAdmin.UnbanPeer(pid)
+	}
+	s.writeBans(w)
+}
+
+func (s *Server) setPeerTrusted(w http.ResponseWriter, r *http.Request, trusted bool) {
+	if !s.peerAdminEnabled(w) {
+		return
+	}
+	req := &TrustedPeerRequest{}
+	if errJson := decodeRequest(r, req); errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	pid, errJson := decodePeerID(req.PeerId)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	s.PeerAdmin.SetPeerTrusted(pid, trusted)
+	s.writeTrustedPeers(w)
+}
+
+func (s *Server) writeTrustedPeers(w http.ResponseWriter) {
+	pids := s.PeerAdmin.Peers().TrustedPeers()
+	resp := &TrustedPeersResponse{Data: make([]string, len(pids))}
+	for i, pid := range pids {
+		resp.Data[i] = pid.String()
+	}
+	sort.Strings(resp.Data)
+	network.WriteJson(w, resp)
+}
+
+func (s *Server) writeBans(w http.ResponseWriter) {
+	status := s.PeerAdmin.Peers()
+	resp := &BansResponse{Data: make([]*Ban, 0)}
+	for pid, until := range status.BannedPeers() {
+		resp.Data = append(resp.Data, &Ban{PeerId: pid.String(), Until: until.UTC().Format(time.RFC3339)})
+	}
+	sort.Slice(resp.Data, func(i, j int) bool {
+		return resp.Data[i].PeerId < resp.Data[j].PeerId
+	})
+	for _, ban := range status.BannedSubnets() {
+		resp.Data = append(resp.Data, &Ban{Cidr: ban.Subnet.String(), Until: ban.Until.UTC().Format(time.RFC3339)})
+	}
+	network.WriteJson(w, resp)
+}
+
+func (s *Server) peerAdminEnabled(w http.ResponseWriter) bool {
+	if s.PeerAdmin == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "peer administration is not available",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return false
+	}
+	return true
+}
+
+func decodeRequest(r *http.Request, req interface{}) *network.DefaultErrorJson {
+	if r.Body == nil || r.Body == http.NoBody {
+		return &network.DefaultErrorJson{
+			Message: "no request body",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+		message := errors.Wrap(err, "could not decode request body").Error()
+		if err == io.EOF {
+			message = "no request body"
+		}
+		return &network.DefaultErrorJson{
+			Message: message,
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return nil
+}
+
+func decodePeerID(raw string) (peer.ID, *network.DefaultErrorJson) {
+	pid, err := peer.Decode(raw)
+	if err != nil {
+		return "", &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "invalid peer id %q", raw).Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return pid, nil
+}
+
+// banTarget decodes either the peer id or the ip subnet of a ban request, exactly one of which must be set.
+func banTarget(rawPid, rawCidr string) (peer.ID, *net.IPNet, *network.DefaultErrorJson) {
+	if (rawPid == "") == (rawCidr == "") {
+		return "", nil, &network.DefaultErrorJson{
+			Message: "exactly one of peer_id and cidr must be requested",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if rawCidr != "" {
+		_, subnet, err := net.ParseCIDR(rawCidr)
+		if err != nil {
+			return "", nil, &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "invalid cidr %q", rawCidr).Error(),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		return "", subnet, nil
+	}
+	pid, errJson := decodePeerID(rawPid)
+	return pid, nil, errJson
+}
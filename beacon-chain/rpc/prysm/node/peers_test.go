@@ -0,0 +1,268 @@
+package node
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"encoding/json"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/crypto"
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers/scorers"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+type mockPeerAdmin struct {
+	status       *peers.Status
+	addedPeer    peer.ID
+	disconnected map[peer.ID]types.RPCGoodbyeCode
+}
+
+func newMockPeerAdmin() *mockPeerAdmin {
+	return &mockPeerAdmin{
+		status: peers.NewStatus(context.Background(), &peers.StatusConfig{
+			PeerLimit:    30,
+			ScorerParams: &scorers.Config{},
+		}),
+		disconnected: make(map[peer.ID]types.RPCGoodbyeCode),
+	}
+}
+
+func (m *mockPeerAdmin) Peers() *peers.Status {
+	return m.status
+}
+
+func (m *mockPeerAdmin) AddPeer(_ context.Context, addr string, trusted bool) (peer.ID, error) {
+	if addr == "unreachable" {
+		return m.addedPeer, errors.New("dial failed")
+	}
+	if addr != "valid" {
+		return "", errors.New("invalid address")
+	}
+	if trusted {
+		m.status.AddTrustedPeer(m.addedPeer)
+	}
+	return m.addedPeer, nil
+}
+
+func (m *mockPeerAdmin) SetPeerTrusted(pid peer.ID, trusted bool) {
+	if trusted {
+		m.status.AddTrustedPeer(pid)
+	} else {
+		m.status.RemoveTrustedPeer(pid)
+	}
+}
+
+func (m *mockPeerAdmin) BanPeer(_ context.Context, pid peer.ID, duration time.Duration) {
+	m.status.BanPeer(pid, time.Now().Add(duration))
+}
+
+func (m *mockPeerAdmin) UnbanPeer(pid peer.ID) {
+	m.status.UnbanPeer(pid)
+}
+
+func (m *mockPeerAdmin) BanSubnet(_ context.Context, subnet *net.IPNet, duration time.Duration) {
+	m.status.BanSubnet(subnet, time.Now().Add(duration))
+}
+
+func (m *mockPeerAdmin) UnbanSubnet(subnet *net.IPNet) {
+	m.status.UnbanSubnet(subnet)
+}
+
+func (m *mockPeerAdmin) DisconnectPeer(_ context.Context, pid peer.ID, code types.RPCGoodbyeCode) error {
+	m.disconnected[pid] = code
+	return nil
+}
+
+func newPeerID(t *testing.T) peer.ID {
+	_, pub, err := crypto.GenerateSecp256k1Key(rand.Reader)
+	require.NoError(t, err)
+	pid, err := peer.IDFromPublicKey(pub)
+	require.NoError(t, err)
+	return pid
+}
+
+func TestPeerAdmin_NotAvailable(t *testing.T) {
+	s := &Server{}
+	request := httptest.NewRequest("GET", "http://foo.example/prysm/node/bans", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.Bans(writer, request)
+	assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+}
+
+func TestAddPeer(t *testing.T) {
+	admin := newMockPeerAdmin()
+	admin.addedPeer = newPeerID(t)
+	s := &Server{PeerAdmin: admin}
+
+	t.Run("trusted", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/node/peers", strings.NewReader(`{"addr":"valid","trusted":true}`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.AddPeer(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &AddPeerResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, admin.addedPeer.String(), resp.Data.PeerId)
+		assert.Equal(t, true, admin.status.IsTrustedPeer(admin.addedPeer))
+	})
+	tests := []struct {
+		name    string
+		body    string
+		code    int
+		message string
+	}{
+		{name: "no body", body: "", code: http.StatusBadRequest, message: "no request body"},
+		{name: "no address", body: `{"trusted":true}`, code: http.StatusBadRequest, message: "no peer address requested"},
+		{name: "invalid address", body: `{"addr":"invalid"}`, code: http.StatusBadRequest, message: "invalid address"},
+		{name: "unreachable", body: `{"addr":"unreachable"}`, code: http.StatusInternalServerError, message: "dial failed"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			request := httptest.NewRequest("POST", "http://foo.example/prysm/node/peers", strings.NewReader(tt.body))
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			s.AddPeer(writer, request)
+			assert.Equal(t, tt.code, writer.Code)
+			e := &network.DefaultErrorJson{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.StringContains(t, tt.message, e.Message)
+		})
+	}
+}
+
+func TestDisconnectPeer(t *testing.T) {
+	admin := newMockPeerAdmin()
+	s := &Server{PeerAdmin: admin}
+	pid := newPeerID(t)
+
+	request := httptest.NewRequest("DELETE", "http://foo.example/prysm/node/peers/"+pid.String(), nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.DisconnectPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, types.GoodbyeCodeGenericError, admin.disconnected[pid])
+
+	request = httptest.NewRequest("DELETE", "http://foo.example/prysm/node/peers/"+pid.String()+"?reason=129", nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.DisconnectPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, types.GoodbyeCodeTooManyPeers, admin.disconnected[pid])
+
+	for _, path := range []string{pid.String() + "?reason=42", pid.String() + "?reason=foo", "foo"} {
+		request = httptest.NewRequest("DELETE", "http://foo.example/prysm/node/peers/"+path, nil)
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.DisconnectPeer(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code, "Unexpected status for %s", path)
+	}
+}
+
+func TestTrustedPeers(t *testing.T) {
+	admin := newMockPeerAdmin()
+	s := &Server{PeerAdmin: admin}
+	pid := newPeerID(t)
+
+	request := httptest.NewRequest("POST", "http://foo.example/prysm/node/trusted_peers", strings.NewReader(`{"peer_id":"`+pid.String()+`"}`))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.TrustPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &TrustedPeersResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.DeepEqual(t, []string{pid.String()}, resp.Data)
+
+	request = httptest.NewRequest("GET", "http://foo.example/prysm/node/trusted_peers", nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.TrustedPeers(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp = &TrustedPeersResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.DeepEqual(t, []string{pid.String()}, resp.Data)
+
+	request = httptest.NewRequest("DELETE", "http://foo.example/prysm/node/trusted_peers", strings.NewReader(`{"peer_id":"`+pid.String()+`"}`))
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.UntrustPeer(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp = &TrustedPeersResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, 0, len(resp.Data))
+
+	request = httptest.NewRequest("POST", "http://foo.example/prysm/node/trusted_peers", strings.NewReader(`{"peer_id":"foo"}`))
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.TrustPeer(writer, request)
+	assert.Equal(t, http.StatusBadRequest, writer.Code)
+}
+
+func TestBans(t *testing.T) {
+	admin := newMockPeerAdmin()
+	s := &Server{PeerAdmin: admin}
+	pid := newPeerID(t)
+
+	request := httptest.NewRequest("POST", "http://foo.example/prysm/node/bans", strings.NewReader(`{"peer_id":"`+pid.String()+`","duration":"1h"}`))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.Ban(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	request = httptest.NewRequest("POST", "http://foo.example/prysm/node/bans", strings.NewReader(`{"cidr":"10.0.0.0/8","duration":"30m"}`))
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.Ban(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	assert.Equal(t, true, admin.status.IsBad(pid))
+
+	request = httptest.NewRequest("GET", "http://foo.example/prysm/node/bans", nil)
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.Bans(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp := &BansResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 2, len(resp.Data))
+	assert.Equal(t, pid.String(), resp.Data[0].PeerId)
+	assert.Equal(t, "10.0.0.0/8", resp.Data[1].Cidr)
+	until, err := time.Parse(time.RFC3339, resp.Data[1].Until)
+	require.NoError(t, err)
+	assert.Equal(t, true, until.After(time.Now().Add(29*time.Minute)))
+
+	request = httptest.NewRequest("DELETE", "http://foo.example/prysm/node/bans", strings.NewReader(`{"peer_id":"`+pid.String()+`"}`))
+	writer = httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.Unban(writer, request)
+	require.Equal(t, http.StatusOK, writer.Code)
+	resp = &BansResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	require.Equal(t, 1, len(resp.Data))
+	assert.Equal(t, "10.0.0.0/8", resp.Data[0].Cidr)
+	assert.Equal(t, false, admin.status.IsBad(pid))
+
+	for _, body := range []string{
+		`{"peer_id":"` + pid.String() + `"}`,
+		`{"peer_id":"` + pid.String() + `","duration":"-1h"}`,
+		`{"duration":"1h"}`,
+		`{"peer_id":"` + pid.String() + `","cidr":"10.0.0.0/8","duration":"1h"}`,
+		`{"cidr":"10.0.0.0","duration":"1h"}`,
+	} {
+		request = httptest.NewRequest("POST", "http://foo.example/prysm/node/bans", strings.NewReader(body))
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.Ban(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code, "Unexpected status for %s", body)
+	}
+}
@@ -0,0 +1,11 @@
+package node
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+)
+
+type Server struct {
+	BeaconDB  db.ReadOnlyDatabase
+	PeerAdmin p2p.PeerAdmin
+}
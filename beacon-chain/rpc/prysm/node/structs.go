@@ -0,0 +1,52 @@
+package node
+
+type HistoryResponse struct {
+	Data *History `json:"data"`
+}
+
+type History struct {
+	EarliestAvailableSlot string `json:"earliest_available_slot"`
+	Pruned                bool   `json:"pruned"`
+}
+
+type AddPeerRequest struct {
+	Addr    string `json:"addr"`
+	Trusted bool   `json:"trusted"`
+}
+
+type AddPeerResponse struct {
+	Data *AddedPeer `json:"data"`
+}
+
+type AddedPeer struct {
+	PeerId string `json:"peer_id"`
+}
+
+type TrustedPeerRequest struct {
+	PeerId string `json:"peer_id"`
+}
+
+type TrustedPeersResponse struct {
+	Data []string `json:"data"`
+}
+
+type BanRequest struct {
+	PeerId   string `json:"peer_id"`
+	Cidr     string `json:"cidr"`
+	Duration string `json:"duration"`
+}
+
+type UnbanRequest struct {
+	PeerId string `json:"peer_id"`
+	Cidr   string `json:"cidr"`
+}
+
+type BansResponse struct {
+	Data []*Ban `json:"data"`
+}
+
+type Ban struct {
+	PeerId string `json:"peer_id,omitempty"`
+	Cidr   string `json:"cidr,omitempty"`
+	Until  string `json:"until"`
+}
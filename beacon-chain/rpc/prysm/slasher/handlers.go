@@ -0,0 +1,327 @@
+package slasher
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/container/slice"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+const (
+	attesterSlashingType = "attester"
+	proposerSlashingType = "proposer"
+)
+
+// DetectedSlashings is an HTTP handler which returns the slashings detected by the slasher, in the order they were
+// first detected, with the conflicting attestations or block headers. The optional type query parameter, either
+// attester or proposer, and validator_index query parameter only return the slashings of that type, or of that
+// validator. Results are paginated with the page_size and page_token query parameters; the next_page_token of the
+// response is empty on the last page.
+func (s *Server) DetectedSlashings(w http.ResponseWriter, r *http.Request) {
+	if !s.slasherEnabled(w) {
+		return
+	}
+	slashingType := r.URL.Query().Get("type")
+	if slashingType != "" && slashingType != attesterSlashingType && slashingType != proposerSlashingType {
+		errJson := &network.DefaultErrorJson{
+			Message: fmt.Sprintf("invalid type %s, must be %s or %s", slashingType, attesterSlashingType, proposerSlashingType),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	var validatorIdx *uint64
+	if raw := r.URL.Query().Get("validator_index"); raw != "" {
+		idx, err := strconv.ParseUint(raw, 10, 64)
+		if err != nil {
+			errJson := &network.DefaultErrorJson{
+				Message: errors.Wrap(err, "invalid validator_index").Error(),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		validatorIdx = &idx
+	}
+	pageSize := params.BeaconConfig().DefaultPageSize
+	if raw := r.URL.Query().Get("page_size"); raw != "" {
+		size, err := strconv.Atoi(raw)
+		if err != nil || size <= 0 || size > cmd.Get().MaxRPCPageSize {
+			errJson := &network.DefaultErrorJson{
+				Message: fmt.Sprintf("invalid page_size %s, must be between 1 and %d", raw, cmd.Get().MaxRPCPageSize),
+				Code:    http.StatusBadRequest,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		pageSize = size
+	}
+	pageToken, err := hexutil.Decode(r.URL.Query().Get("page_token"))
+	if err != nil && r.URL.Query().Get("page_token") != "" {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "invalid page_token").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	filter := func(sl *slashertypes.DetectedSlashing) bool {
+		if slashingType == attesterSlashingType && sl.AttesterSlashing == nil {
+			return false
+		}
+		if slashingType == proposerSlashingType && sl.ProposerSlashing == nil {
+			return false
+		}
+		return validatorIdx == nil || slashesValidator(sl, *validatorIdx)
+	}
+	detected, next, err := s.SlashingInspector.DetectedSlashings(r.Context(), pageToken, pageSize, filter)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get detected slashings").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := &DetectedSlashingsResponse{Data: make([]*DetectedSlashing, len(detected))}
+	for i, sl := range detected {
+		resp.Data[i] = detectedSlashingFromConsensus(sl)
+	}
+	if len(next) > 0 {
+		resp.NextPageToken = hexutil.Encode(next)
+	}
+	network.WriteJson(w, resp)
+}
+
+// ValidatorSpans is an HTTP handler which returns the min and max spans the slasher stores for the validator given in
+// the path, at each epoch in the range given by the start_epoch and end_epoch query parameters. The slasher only
+// keeps spans for a limited number of epochs before the current one.
+func (s *Server) ValidatorSpans(w http.ResponseWriter, r *http.Request) {
+	if !s.slasherEnabled(w) {
+		return
+	}
+	segments := strings.Split(r.URL.Path, "/")
+	if len(segments) < 2 {
+		errJson := &network.DefaultErrorJson{
+			Message: "missing validator index",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	idx, err := strconv.ParseUint(segments[len(segments)-2], 10, 64)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "invalid validator index").Error(),
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	start, errJson := epochQueryParam(r, "start_epoch")
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	end, errJson := epochQueryParam(r, "end_epoch")
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+
+	spans, err := s.SlashingInspector.ValidatorSpans(r.Context(), primitives.ValidatorIndex(idx), start, end)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, slasher.ErrSpansOutOfRange) {
+			code = http.StatusBadRequest
+		}
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get validator spans").Error(),
+			Code:    code,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := &ValidatorSpansResponse{Data: &ValidatorSpans{
+		Index: strconv.FormatUint(idx, 10),
+		Spans: make([]*EpochSpans, len(spans)),
+	}}
+	for i, sp := range spans {
+		resp.Data.Spans[i] = &EpochSpans{
+			Epoch:   strconv.FormatUint(uint64(sp.Epoch), 10),
+			MinSpan: strconv.FormatUint(uint64(sp.MinSpan), 10),
+			MaxSpan: strconv.FormatUint(uint64(sp.MaxSpan), 10),
+		}
+	}
+	network.WriteJson(w, resp)
+}
+
+// StreamDetectedSlashings is an HTTP handler which streams the slashings detected by the slasher as server-sent
+// events, as soon as they are verified. Events are named after the type of the slashing, and their data is the
+// detected slashing in the format returned by DetectedSlashings.
+func (s *Server) StreamDetectedSlashings(w http.ResponseWriter, r *http.Request) {
+	if !s.slasherEnabled(w) {
+		return
+	}
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		errJson := &network.DefaultErrorJson{
+			Message: "streaming is not supported",
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	detectedCh := make(chan *slashertypes.DetectedSlashing, 1)
+	sub := s.SlashingInspector.SubscribeDetectedSlashings(detectedCh)
+	defer sub.Unsubscribe()
+
+	w.Header().Set("Content-Type", "text/event-stream")
+	w.Header().Set("Cache-Control", "no-cache")
+	w.Header().Set("Connection", "keep-alive")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+	for {
+		select {
+		case sl := <-detectedCh:
+			d := detectedSlashingFromConsensus(sl)
+			data, err := json.Marshal(d)
+			if err != nil {
+				return
+			}
+			if _, err := fmt.Fprintf(w, "event: %s_slashing\ndata: %s\n\n", d.Type, data); err != nil {
+				return
+			}
+			flusher.Flush()
+		case <-sub.Err():
+			return
+		case <-r.Context().Done():
+			return
+		}
+	}
+}
+
+func (s *Server) slasherEnabled(w http.ResponseWriter) bool {
+	if s.SlashingInspector == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "slasher is not running, the beacon node must run with --slasher",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return false
+	}
+	return true
+}
+
+func epochQueryParam(r *http.Request, name string) (primitives.Epoch, *network.DefaultErrorJson) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("%s is required", name),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	e, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "invalid %s", name).Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return primitives.Epoch(e), nil
+}
+
+// slashedValidators returns the indices of the validators a detected slashing is for.
+func slashedValidators(sl *slashertypes.DetectedSlashing) []uint64 {
+	if sl.AttesterSlashing != nil {
+		return slice.IntersectionUint64(
+			sl.AttesterSlashing.Attestation_1.AttestingIndices,
+			sl.AttesterSlashing.Attestation_2.AttestingIndices,
+		)
+	}
+	return []uint64{uint64(sl.ProposerSlashing.Header_1.Header.ProposerIndex)}
+}
+
+func slashesValidator(sl *slashertypes.DetectedSlashing, idx uint64) bool {
+	for _, i := range slashedValidators(sl) {
+		if i == idx {
+			return true
+		}
+	}
+	return false
+}
+
+func detectedSlashingFromConsensus(sl *slashertypes.DetectedSlashing) *DetectedSlashing {
+	indices := slashedValidators(sl)
+	d := &DetectedSlashing{
+		DetectedAt:       strconv.FormatInt(sl.DetectedAt.Unix(), 10),
+		ValidatorIndices: make([]string, len(indices)),
+	}
+	for i, idx := range indices {
+		d.ValidatorIndices[i] = strconv.FormatUint(idx, 10)
+	}
+	if sl.AttesterSlashing != nil {
+		d.Type = attesterSlashingType
+		d.AttesterSlashing = &AttesterSlashing{
+			Attestation1: indexedAttestationFromConsensus(sl.AttesterSlashing.Attestation_1),
+			Attestation2: indexedAttestationFromConsensus(sl.AttesterSlashing.Attestation_2),
+		}
+		return d
+	}
+	d.Type = proposerSlashingType
+	d.ProposerSlashing = &ProposerSlashing{
+		SignedHeader1: signedHeaderFromConsensus(sl.ProposerSlashing.Header_1),
+		SignedHeader2: signedHeaderFromConsensus(sl.ProposerSlashing.Header_2),
+	}
+	return d
+}
+
+func indexedAttestationFromConsensus(att *ethpb.IndexedAttestation) *IndexedAttestation {
+	indices := make([]string, len(att.AttestingIndices))
+	for i, idx := range att.AttestingIndices {
+		indices[i] = strconv.FormatUint(idx, 10)
+	}
+	return &IndexedAttestation{
+		AttestingIndices: indices,
+		Data: &AttestationData{
+			Slot:            strconv.FormatUint(uint64(att.Data.Slot), 10),
+			Index:           strconv.FormatUint(uint64(att.Data.CommitteeIndex), 10),
+			BeaconBlockRoot: hexutil.Encode(att.Data.BeaconBlockRoot),
+			Source: &Checkpoint{
+				Epoch: strconv.FormatUint(uint64(att.Data.Source.Epoch), 10),
+				Root:  hexutil.Encode(att.Data.Source.Root),
+			},
+			Target: &Checkpoint{
+				Epoch: strconv.FormatUint(uint64(att.Data.Target.Epoch), 10),
+				Root:  hexutil.Encode(att.Data.Target.Root),
+			},
+		},
+		Signature: hexutil.Encode(att.Signature),
+	}
+}
+
+func signedHeaderFromConsensus(header *ethpb.SignedBeaconBlockHeader) *SignedBeaconBlockHeader {
+	return &SignedBeaconBlockHeader{
+		Message: &BeaconBlockHeader{
+			Slot:          strconv.FormatUint(uint64(header.Header.Slot), 10),
+			ProposerIndex: strconv.FormatUint(uint64(header.Header.ProposerIndex), 10),
+			ParentRoot:    hexutil.Encode(header.Header.ParentRoot),
+			StateRoot:     hexutil.Encode(header.Header.StateRoot),
+			BodyRoot:      hexutil.Encode(header.Header.BodyRoot),
+		},
+		Signature: hexutil.Encode(header.Signature),
+	}
+}
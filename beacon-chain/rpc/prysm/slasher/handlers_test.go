@@ -0,0 +1,250 @@
+package slasher
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/async/event"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+type mockInspector struct {
+	detected []*slashertypes.DetectedSlashing
+	feed     event.Feed
+}
+
+// DetectedSlashings pages through the detected slashings, using the index of the next slashing as the page token.
+func (m *mockInspector) DetectedSlashings(
+	_ context.Context, pageToken []byte, limit int, filter func(*slashertypes.DetectedSlashing) bool,
+) ([]*slashertypes.DetectedSlashing, []byte, error) {
+	start := 0
+	if len(pageToken) > 0 {
+		start = int(pageToken[0])
+	}
+	page := make([]*slashertypes.DetectedSlashing, 0)
+	for i := start; i < len(m.detected); i++ {
+		if len(page) == limit {
+			return page, []byte{byte(i)}, nil
+		}
+		if filter(m.detected[i]) {
+			page = append(page, m.detected[i])
+		}
+	}
+	return page, nil, nil
+}
+
+func (m *mockInspector) SubscribeDetectedSlashings(ch chan<- *slashertypes.DetectedSlashing) event.Subscription {
+	return m.feed.Subscribe(ch)
+}
+
+func (*mockInspector) ValidatorSpans(
+	_ context.Context, _ primitives.ValidatorIndex, start, end primitives.Epoch,
+) ([]*slashertypes.EpochSpans, error) {
+	if end > 10 {
+		return nil, errors.Wrap(slasher.ErrSpansOutOfRange, "spans are kept for epochs 0 to 10")
+	}
+	spans := make([]*slashertypes.EpochSpans, 0)
+	for e := start; e <= end; e++ {
+		spans = append(spans, &slashertypes.EpochSpans{Epoch: e, MinSpan: uint16(e) + 1, MaxSpan: uint16(e) + 2})
+	}
+	return spans, nil
+}
+
+func testSlashings() []*slashertypes.DetectedSlashing {
+	return []*slashertypes.DetectedSlashing{
+		{
+			DetectedAt: time.Unix(100, 0),
+			AttesterSlashing: &ethpb.AttesterSlashing{
+				Attestation_1: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{1, 2, 3}}),
+				Attestation_2: util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{AttestingIndices: []uint64{2, 3, 4}}),
+			},
+		},
+		{
+			DetectedAt: time.Unix(200, 0),
+			ProposerSlashing: &ethpb.ProposerSlashing{
+				Header_1: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
+					Header: &ethpb.BeaconBlockHeader{Slot: 5, ProposerIndex: 4},
+				}),
+				Header_2: util.HydrateSignedBeaconHeader(&ethpb.SignedBeaconBlockHeader{
+					Header: &ethpb.BeaconBlockHeader{Slot: 5, ProposerIndex: 4, BodyRoot: bytes.Repeat([]byte{1}, 32)},
+				}),
+			},
+		},
+	}
+}
+
+func TestDetectedSlashings(t *testing.T) {
+	s := &Server{SlashingInspector: &mockInspector{detected: testSlashings()}}
+
+	t.Run("all", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/prysm/slasher/slashings", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.DetectedSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &DetectedSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+
+		att := resp.Data[0]
+		assert.Equal(t, "attester", att.Type)
+		assert.Equal(t, "100", att.DetectedAt)
+		assert.DeepEqual(t, []string{"2", "3"}, att.ValidatorIndices)
+		require.NotNil(t, att.AttesterSlashing)
+		assert.DeepEqual(t, []string{"1", "2", "3"}, att.AttesterSlashing.Attestation1.AttestingIndices)
+		assert.Equal(t, true, att.ProposerSlashing == nil)
+
+		prop := resp.Data[1]
+		assert.Equal(t, "proposer", prop.Type)
+		assert.Equal(t, "200", prop.DetectedAt)
+		assert.DeepEqual(t, []string{"4"}, prop.ValidatorIndices)
+		require.NotNil(t, prop.ProposerSlashing)
+		assert.Equal(t, "5", prop.ProposerSlashing.SignedHeader2.Message.Slot)
+		assert.Equal(t, "0x"+strings.Repeat("01", 32), prop.ProposerSlashing.SignedHeader2.Message.BodyRoot)
+	})
+	t.Run("filters", func(t *testing.T) {
+		for query, want := range map[string]int{
+			"type=attester":                   1,
+			"type=proposer":                   1,
+			"validator_index=1":               0,
+			"validator_index=2":               1,
+			"validator_index=3":               1,
+			"validator_index=4":               1,
+			"validator_index=4&type=attester": 0,
+		} {
+			request := httptest.NewRequest("GET", "http://foo.example/prysm/slasher/slashings?"+query, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			s.DetectedSlashings(writer, request)
+			assert.Equal(t, http.StatusOK, writer.Code)
+			resp := &DetectedSlashingsResponse{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+			assert.Equal(t, want, len(resp.Data), query)
+		}
+	})
+	t.Run("pagination", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/prysm/slasher/slashings?page_size=1", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.DetectedSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &DetectedSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "attester", resp.Data[0].Type)
+		assert.Equal(t, "0x01", resp.NextPageToken)
+
+		request = httptest.NewRequest("GET", "http://foo.example/prysm/slasher/slashings?page_size=1&page_token=0x01", nil)
+		writer = httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.DetectedSlashings(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp = &DetectedSlashingsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, "proposer", resp.Data[0].Type)
+		assert.Equal(t, "", resp.NextPageToken)
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, query := range []string{"type=foo", "validator_index=foo", "page_size=0", "page_size=foo", "page_token=foo"} {
+			request := httptest.NewRequest("GET", "http://foo.example/prysm/slasher/slashings?"+query, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			s.DetectedSlashings(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code, query)
+		}
+	})
+	t.Run("slasher not running", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/prysm/slasher/slashings", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		(&Server{}).DetectedSlashings(writer, request)
+		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+}
+
+func TestValidatorSpans(t *testing.T) {
+	s := &Server{SlashingInspector: &mockInspector{}}
+
+	request := httptest.NewRequest("GET", "http://foo.example/prysm/slasher/validators/7/spans?start_epoch=2&end_epoch=4", nil)
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.ValidatorSpans(writer, request)
+	assert.Equal(t, http.StatusOK, writer.Code)
+	resp := &ValidatorSpansResponse{}
+	require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+	assert.Equal(t, "7", resp.Data.Index)
+	require.Equal(t, 3, len(resp.Data.Spans))
+	assert.DeepEqual(t, &EpochSpans{Epoch: "2", MinSpan: "3", MaxSpan: "4"}, resp.Data.Spans[0])
+	assert.Equal(t, "4", resp.Data.Spans[2].Epoch)
+
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, url := range []string{
+			"http://foo.example/prysm/slasher/validators/foo/spans?start_epoch=2&end_epoch=4",
+			"http://foo.example/prysm/slasher/validators/7/spans?end_epoch=4",
+			"http://foo.example/prysm/slasher/validators/7/spans?start_epoch=2",
+			"http://foo.example/prysm/slasher/validators/7/spans?start_epoch=2&end_epoch=11",
+		} {
+			request := httptest.NewRequest("GET", url, nil)
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			s.ValidatorSpans(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code, url)
+		}
+	})
+}
+
+func TestStreamDetectedSlashings(t *testing.T) {
+	inspector := &mockInspector{}
+	s := &Server{SlashingInspector: inspector}
+	srv := httptest.NewServer(http.HandlerFunc(s.StreamDetectedSlashings))
+	defer srv.Close()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	request, err := http.NewRequestWithContext(ctx, "GET", srv.URL, nil)
+	require.NoError(t, err)
+	resp, err := http.DefaultClient.Do(request)
+	require.NoError(t, err)
+	defer func() {
+		require.NoError(t, resp.Body.Close())
+	}()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "text/event-stream", resp.Header.Get("Content-Type"))
+
+	// The handler subscribes before sending the response headers.
+	for _, sl := range testSlashings() {
+		inspector.feed.Send(sl)
+	}
+	reader := bufio.NewReader(resp.Body)
+	for _, want := range []string{"attester", "proposer"} {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, fmt.Sprintf("event: %s_slashing\n", want), line)
+		line, err = reader.ReadString('\n')
+		require.NoError(t, err)
+		data, ok := strings.CutPrefix(strings.TrimSpace(line), "data: ")
+		require.Equal(t, true, ok)
+		d := &DetectedSlashing{}
+		require.NoError(t, json.Unmarshal([]byte(data), d))
+		assert.Equal(t, want, d.Type)
+		line, err = reader.ReadString('\n')
+		require.NoError(t, err)
+		assert.Equal(t, "\n", line)
+	}
+}
@@ -0,0 +1,9 @@
+package slasher
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
+)
+
+type Server struct {
+	SlashingInspector slasher.SlashingInspector
+}
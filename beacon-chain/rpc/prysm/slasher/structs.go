@@ -0,0 +1,71 @@
+package slasher
+
+type DetectedSlashingsResponse struct {
+	Data          []*DetectedSlashing `json:"data"`
+	NextPageToken string              `json:"next_page_token"`
+}
+
+type DetectedSlashing struct {
+	Type             string            `json:"type"`
+	DetectedAt       string            `json:"detected_at"`
+	ValidatorIndices []string          `json:"validator_indices"`
+	AttesterSlashing *AttesterSlashing `json:"attester_slashing,omitempty"`
+	ProposerSlashing *ProposerSlashing `json:"proposer_slashing,omitempty"`
+}
+
+type AttesterSlashing struct {
+	Attestation1 *IndexedAttestation `json:"attestation_1"`
+	Attestation2 *IndexedAttestation `json:"attestation_2"`
+}
+
+type IndexedAttestation struct {
+	AttestingIndices []string         `json:"attesting_indices"`
+	Data             *AttestationData `json:"data"`
+	Signature        string           `json:"signature"`
+}
+
+type AttestationData struct {
+	Slot            string      `json:"slot"`
+	Index           string      `json:"index"`
+	BeaconBlockRoot string      `json:"beacon_block_root"`
+	Source          *Checkpoint `json:"source"`
+	Target          *Checkpoint `json:"target"`
+}
+
+type Checkpoint struct {
+	Epoch string `json:"epoch"`
+	Root  string `json:"root"`
+}
+
+type ProposerSlashing struct {
+	SignedHeader1 *SignedBeaconBlockHeader `json:"signed_header_1"`
+	SignedHeader2 *SignedBeaconBlockHeader `json:"signed_header_2"`
+}
+
+type SignedBeaconBlockHeader struct {
+	Message   *BeaconBlockHeader `json:"message"`
+	Signature string             `json:"signature"`
+}
+
+type BeaconBlockHeader struct {
+	Slot          string `json:"slot"`
+	ProposerIndex string `json:"proposer_index"`
+	ParentRoot    string `json:"parent_root"`
+	StateRoot     string `json:"state_root"`
+	BodyRoot      string `json:"body_root"`
+}
+
+type ValidatorSpansResponse struct {
+	Data *ValidatorSpans `json:"data"`
+}
+
+type ValidatorSpans struct {
+	Index string        `json:"index"`
+	Spans []*EpochSpans `json:"spans"`
+}
+
+type EpochSpans struct {
+	Epoch   string `json:"epoch"`
+	MinSpan string `json:"min_span"`
+	MaxSpan string `json:"max_span"`
+}
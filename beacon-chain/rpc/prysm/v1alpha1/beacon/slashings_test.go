@@ -11,6 +11,8 @@ import (
 	"google.golang.org/protobuf/proto"
 
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/slashings"
 	mockp2p "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
 	"github.com/prysmaticlabs/prysm/v4/testing/assert"
@@ -77,6 +79,43 @@ func TestServer_SubmitAttesterSlashing(t *testing.T) {
 	assert.Equal(t, true, mb.BroadcastCalled, "Expected broadcast to be called when flag is set")
 }
 
+func TestServer_SubmitSlashings_SendOperationEvents(t *testing.T) {
+	ctx := context.Background()
+	st, privs := util.DeterministicGenesisState(t, 64)
+	notifier := &mock.MockOperationNotifier{}
+	bs := &Server{
+		HeadFetcher: &mock.ChainService{
+			State: st,
+		},
+		SlashingsPool:     slashings.NewPool(),
+		Broadcaster:       &mockp2p.MockBroadcaster{},
+		OperationNotifier: notifier,
+	}
+	opChannel := make(chan *feed.Event, 2)
+	opSub := notifier.OperationFeed().Subscribe(opChannel)
+	defer opSub.Unsubscribe()
+
+	proposerSlashing, err := util.GenerateProposerSlashingForValidator(st, privs[2], primitives.ValidatorIndex(2))
+	require.NoError(t, err)
+	_, err = bs.SubmitProposerSlashing(ctx, proposerSlashing)
+	require.NoError(t, err)
+	attesterSlashing, err := util.GenerateAttesterSlashingForValidator(st, privs[3], primitives.ValidatorIndex(3))
+	require.NoError(t, err)
+	_, err = bs.SubmitAttesterSlashing(ctx, attesterSlashing)
+	require.NoError(t, err)
+
+	e := <-opChannel
+	assert.Equal(t, feed.EventType(operation.ProposerSlashingReceived), e.Type)
+	proposerData, ok := e.Data.(*operation.ProposerSlashingReceivedData)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, proposerSlashing, proposerData.ProposerSlashing)
+	e = <-opChannel
+	assert.Equal(t, feed.EventType(operation.AttesterSlashingReceived), e.Type)
+	attesterData, ok := e.Data.(*operation.AttesterSlashingReceivedData)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, attesterSlashing, attesterData.AttesterSlashing)
+}
+
 func TestServer_SubmitProposerSlashing_DontBroadcast(t *testing.T) {
 	resetCfg := features.InitWithReset(&features.Flags{DisableBroadcastSlashings: true})
 	defer resetCfg()
@@ -704,7 +704,7 @@ func (bs *Server) GetValidatorPerformance(
 		if err != nil {
 			return nil, err
 		}
-		headState, err = precompute.ProcessRewardsAndPenaltiesPrecompute(headState, bp, vp, precompute.AttestationsDelta, precompute.ProposersDelta)
+		headState, err = precompute.ProcessRewardsAndPenaltiesPrecompute(ctx, headState, bp, vp, precompute.AttestationsDelta, precompute.ProposersDelta)
 		if err != nil {
 			return nil, err
 		}
@@ -722,7 +722,7 @@ func (bs *Server) GetValidatorPerformance(
 		if err != nil {
 			return nil, err
 		}
-		headState, err = altair.ProcessRewardsAndPenaltiesPrecompute(headState, bp, vp)
+		headState, err = altair.ProcessRewardsAndPenaltiesPrecompute(ctx, headState, bp, vp)
 		if err != nil {
 			return nil, err
 		}
@@ -8,7 +8,6 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/pkg/errors"
-	"github.com/prysmaticlabs/prysm/v4/api/client/builder"
 	blockchainTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	builderTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/builder/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
@@ -277,8 +276,6 @@ func TestServer_getPayloadHeader(t *testing.T) {
 	cfg.CapellaForkEpoch = fakeCapellaEpoch
 	cfg.InitializeForkSchedule()
 	params.OverrideBeaconConfig(cfg)
-	emptyRoot, err := ssz.TransactionsRoot([][]byte{})
-	require.NoError(t, err)
 	ti, err := slots.ToTime(uint64(time.Now().Unix()), 0)
 	require.NoError(t, err)
 
@@ -382,50 +379,7 @@ func TestServer_getPayloadHeader(t *testing.T) {
 			},
 			err: "can't get header",
 		},
-		{
-			name: "0 bid",
-			mock: &builderTest.MockBuilderService{
-				Bid: &ethpb.SignedBuilderBid{
-					Message: &ethpb.BuilderBid{
-						Header: &v1.ExecutionPayloadHeader{
-							BlockNumber: 123,
-						},
-					},
-				},
-			},
-			fetcher: &blockchainTest.ChainService{
-				Block: func() interfaces.ReadOnlySignedBeaconBlock {
-					wb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
-					require.NoError(t, err)
-					wb.SetSlot(primitives.Slot(params.BeaconConfig().BellatrixForkEpoch) * params.BeaconConfig().SlotsPerEpoch)
-					return wb
-				}(),
-			},
-			err: "builder returned header with 0 bid amount",
-		},
-		{
-			name: "invalid tx root",
-			mock: &builderTest.MockBuilderService{
-				Bid: &ethpb.SignedBuilderBid{
-					Message: &ethpb.BuilderBid{
-						Value: []byte{1},
-						Header: &v1.ExecutionPayloadHeader{
-							BlockNumber:      123,
-							TransactionsRoot: emptyRoot[:],
-						},
-					},
-				},
-			},
-			fetcher: &blockchainTest.ChainService{
-				Block: func() interfaces.ReadOnlySignedBeaconBlock {
-					wb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
-					require.NoError(t, err)
-					wb.SetSlot(primitives.Slot(params.BeaconConfig().BellatrixForkEpoch) * params.BeaconConfig().SlotsPerEpoch)
-					return wb
-				}(),
-			},
-			err: "builder returned header with an empty tx root",
-		},
+
 		{
 			name: "can get header",
 			mock: &builderTest.MockBuilderService{
@@ -441,21 +395,7 @@ func TestServer_getPayloadHeader(t *testing.T) {
 			},
 			returnedHeader: bid.Header,
 		},
-		{
-			name: "wrong bid version",
-			mock: &builderTest.MockBuilderService{
-				BidCapella: sBidCapella,
-			},
-			fetcher: &blockchainTest.ChainService{
-				Block: func() interfaces.ReadOnlySignedBeaconBlock {
-					wb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlockBellatrix())
-					require.NoError(t, err)
-					wb.SetSlot(primitives.Slot(params.BeaconConfig().BellatrixForkEpoch) * params.BeaconConfig().SlotsPerEpoch)
-					return wb
-				}(),
-			},
-			err: "is different from head block version",
-		},
+
 		{
 			name: "different bid version during hard fork",
 			mock: &builderTest.MockBuilderService{
@@ -499,44 +439,6 @@ func TestServer_getPayloadHeader(t *testing.T) {
 	}
 }
 
-func TestServer_validateBuilderSignature(t *testing.T) {
-	sk, err := bls.RandKey()
-	require.NoError(t, err)
-	bid := &ethpb.BuilderBid{
-		Header: &v1.ExecutionPayloadHeader{
-			ParentHash:       make([]byte, fieldparams.RootLength),
-			FeeRecipient:     make([]byte, fieldparams.FeeRecipientLength),
-			StateRoot:        make([]byte, fieldparams.RootLength),
-			ReceiptsRoot:     make([]byte, fieldparams.RootLength),
-			LogsBloom:        make([]byte, fieldparams.LogsBloomLength),
-			PrevRandao:       make([]byte, fieldparams.RootLength),
-			BaseFeePerGas:    make([]byte, fieldparams.RootLength),
-			BlockHash:        make([]byte, fieldparams.RootLength),
-			TransactionsRoot: make([]byte, fieldparams.RootLength),
-			BlockNumber:      1,
-		},
-		Pubkey: sk.PublicKey().Marshal(),
-		Value:  bytesutil.PadTo([]byte{1, 2, 3}, 32),
-	}
-	d := params.BeaconConfig().DomainApplicationBuilder
-	domain, err := signing.ComputeDomain(d, nil, nil)
-	require.NoError(t, err)
-	sr, err := signing.ComputeSigningRoot(bid, domain)
-	require.NoError(t, err)
-	pbBid := &ethpb.SignedBuilderBid{
-		Message:   bid,
-		Signature: sk.Sign(sr[:]).Marshal(),
-	}
-	sBid, err := builder.WrappedSignedBuilderBid(pbBid)
-	require.NoError(t, err)
-	require.NoError(t, validateBuilderSignature(sBid))
-
-	pbBid.Message.Value = make([]byte, 32)
-	sBid, err = builder.WrappedSignedBuilderBid(pbBid)
-	require.NoError(t, err)
-	require.ErrorIs(t, validateBuilderSignature(sBid), signing.ErrSigFailedToVerify)
-}
-
 func Test_matchingWithdrawalsRoot(t *testing.T) {
 	t.Run("could not get local withdrawals", func(t *testing.T) {
 		local := &v1.ExecutionPayload{}
@@ -587,9 +489,3 @@ func Test_matchingWithdrawalsRoot(t *testing.T) {
 		require.Equal(t, true, matched)
 	})
 }
-
-func TestEmptyTransactionsRoot(t *testing.T) {
-	r, err := ssz.TransactionsRoot([][]byte{})
-	require.NoError(t, err)
-	require.DeepEqual(t, r, emptyTransactionsRoot)
-}
@@ -50,7 +50,9 @@ func (vs *Server) validatorRegistered(ctx context.Context, id primitives.Validat
 	return true, nil
 }
 
-// circuitBreakBuilder returns true if the builder is not allowed to be used due to circuit breaker conditions.
+// circuitBreakBuilder returns true if the builder is not allowed to be used due to circuit breaker conditions. On top
+// of the chain liveness conditions below, each relay has its own circuit breaker and the builder is circuit broken
+// once all of them are.
 func (vs *Server) circuitBreakBuilder(s primitives.Slot) (bool, error) {
 	if vs.ForkchoiceFetcher == nil {
 		return true, errors.New("no fork choicer configured")
@@ -73,6 +75,11 @@ func (vs *Server) circuitBreakBuilder(s primitives.Slot) (bool, error) {
 		return true, nil
 	}
 
+	if vs.BlockBuilder != nil && vs.BlockBuilder.CircuitBroken(s) {
+		log.WithField("currentSlot", s).Warn("Circuit breaker activated for every relay. Ignore if mev-boost is not used")
+		return true, nil
+	}
+
 	// Not much reason to check missed slots epoch rolling window if input slot is less than epoch.
 	if s < params.BeaconConfig().SlotsPerEpoch {
 		return false, nil
@@ -71,6 +71,23 @@ func TestServer_circuitBreakBuilder(t *testing.T) {
 	require.Equal(t, false, b)
 }
 
+func TestServer_circuitBreakBuilder_Relays(t *testing.T) {
+	hook := logTest.NewGlobal()
+	s := &Server{ForkchoiceFetcher: &blockchainTest.ChainService{ForkChoiceStore: doublylinkedtree.New()}}
+	s.ForkchoiceFetcher.SetForkChoiceGenesisTime(uint64(time.Now().Unix()))
+	mockBuilder := &testing2.MockBuilderService{HasConfigured: true}
+	s.BlockBuilder = mockBuilder
+	b, err := s.circuitBreakBuilder(1)
+	require.NoError(t, err)
+	require.Equal(t, false, b)
+
+	mockBuilder.RelaysCircuitBroken = true
+	b, err = s.circuitBreakBuilder(1)
+	require.NoError(t, err)
+	require.Equal(t, true, b)
+	require.LogsContain(t, hook, "Circuit breaker activated for every relay. Ignore if mev-boost is not used")
+}
+
 func TestServer_validatorRegistered(t *testing.T) {
 	b, err := builder.NewService(context.Background())
 	require.NoError(t, err)
@@ -140,6 +157,11 @@ func TestServer_canUseBuilder(t *testing.T) {
 	reg, err = proposerServer.canUseBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots-1, 0)
 	require.NoError(t, err)
 	require.Equal(t, true, reg)
+
+	proposerServer.BlockBuilder.(*testing2.MockBuilderService).RelaysCircuitBroken = true
+	reg, err = proposerServer.canUseBuilder(ctx, params.BeaconConfig().MaxBuilderConsecutiveMissedSlots-1, 0)
+	require.NoError(t, err)
+	require.Equal(t, false, reg)
 }
 
 func createState(
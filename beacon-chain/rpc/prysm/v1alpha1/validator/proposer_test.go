@@ -15,6 +15,7 @@ import (
 	builderTest "github.com/prysmaticlabs/prysm/v4/beacon-chain/builder/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositcache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	b "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
@@ -1465,7 +1466,7 @@ func TestProposer_DepositTrie_RebuildTrie(t *testing.T) {
 	// Mutate it since its a pointer
 	d[0].Deposit.Data.WithdrawalCredentials = junkCreds[:]
 	// Insert junk to corrupt trie.
-	err = depositCache.InsertFinalizedDeposits(ctx, 2)
+	err = depositCache.InsertFinalizedDeposits(ctx, 2, [32]byte{}, 0)
 	require.NoError(t, err)
 
 	// Add original back
@@ -1496,7 +1497,7 @@ func TestProposer_ValidateDepositTrie(t *testing.T) {
 	tt := []struct {
 		name            string
 		eth1dataCreator func() *ethpb.Eth1Data
-		trieCreator     func() *trie.SparseMerkleTrie
+		trieCreator     func() *depositsnapshot.DepositTree
 		success         bool
 	}{
 		{
@@ -1504,10 +1505,8 @@ func TestProposer_ValidateDepositTrie(t *testing.T) {
 			eth1dataCreator: func() *ethpb.Eth1Data {
 				return &ethpb.Eth1Data{DepositRoot: []byte{}, DepositCount: 10, BlockHash: []byte{}}
 			},
-			trieCreator: func() *trie.SparseMerkleTrie {
-				newTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-				assert.NoError(t, err)
-				return newTrie
+			trieCreator: func() *depositsnapshot.DepositTree {
+				return depositsnapshot.New()
 			},
 			success: false,
 		},
@@ -1516,17 +1515,16 @@ func TestProposer_ValidateDepositTrie(t *testing.T) {
 			eth1dataCreator: func() *ethpb.Eth1Data {
 				newTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
 				assert.NoError(t, err)
-				assert.NoError(t, newTrie.Insert([]byte{'a'}, 0))
-				assert.NoError(t, newTrie.Insert([]byte{'b'}, 1))
-				assert.NoError(t, newTrie.Insert([]byte{'c'}, 2))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'a'}, 32), 0))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'b'}, 32), 1))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'c'}, 32), 2))
 				return &ethpb.Eth1Data{DepositRoot: []byte{'B'}, DepositCount: 3, BlockHash: []byte{}}
 			},
-			trieCreator: func() *trie.SparseMerkleTrie {
-				newTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-				assert.NoError(t, err)
-				assert.NoError(t, newTrie.Insert([]byte{'a'}, 0))
-				assert.NoError(t, newTrie.Insert([]byte{'b'}, 1))
-				assert.NoError(t, newTrie.Insert([]byte{'c'}, 2))
+			trieCreator: func() *depositsnapshot.DepositTree {
+				newTrie := depositsnapshot.New()
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'a'}, 32), 0))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'b'}, 32), 1))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'c'}, 32), 2))
 				return newTrie
 			},
 			success: false,
@@ -1536,19 +1534,18 @@ func TestProposer_ValidateDepositTrie(t *testing.T) {
 			eth1dataCreator: func() *ethpb.Eth1Data {
 				newTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
 				assert.NoError(t, err)
-				assert.NoError(t, newTrie.Insert([]byte{'a'}, 0))
-				assert.NoError(t, newTrie.Insert([]byte{'b'}, 1))
-				assert.NoError(t, newTrie.Insert([]byte{'c'}, 2))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'a'}, 32), 0))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'b'}, 32), 1))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'c'}, 32), 2))
 				rt, err := newTrie.HashTreeRoot()
 				require.NoError(t, err)
 				return &ethpb.Eth1Data{DepositRoot: rt[:], DepositCount: 3, BlockHash: []byte{}}
 			},
-			trieCreator: func() *trie.SparseMerkleTrie {
-				newTrie, err := trie.NewTrie(params.BeaconConfig().DepositContractTreeDepth)
-				assert.NoError(t, err)
-				assert.NoError(t, newTrie.Insert([]byte{'a'}, 0))
-				assert.NoError(t, newTrie.Insert([]byte{'b'}, 1))
-				assert.NoError(t, newTrie.Insert([]byte{'c'}, 2))
+			trieCreator: func() *depositsnapshot.DepositTree {
+				newTrie := depositsnapshot.New()
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'a'}, 32), 0))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'b'}, 32), 1))
+				assert.NoError(t, newTrie.Insert(bytesutil.PadTo([]byte{'c'}, 32), 2))
 				return newTrie
 			},
 			success: true,
@@ -0,0 +1,161 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+)
+
+// maxHistoryRecords is the maximum number of validator records, ie the number of requested validators times the
+// number of requested epochs, that a single validator history request may return.
+const maxHistoryRecords = 1 << 20
+
+// ValidatorHistory is an HTTP handler which returns the balance, effective balance and status of each of the
+// requested validators at the start of every indexed epoch in the range given by the start_epoch and end_epoch
+// query parameters. The validator indices are given as a JSON array of strings in the request body, and duplicate
+// indices are only returned once. Epochs which were not indexed, or at which a validator was not part of the
+// registry yet, are omitted from its history.
+func (s *Server) ValidatorHistory(w http.ResponseWriter, r *http.Request) {
+	last, err := s.BeaconDB.LastValidatorHistoryEpoch(r.Context())
+	if err != nil {
+		if errors.Is(err, db.ErrNotFound) {
+			errJson := &network.DefaultErrorJson{
+				Message: "validator history is not available, the beacon node must run with --validator-history",
+				Code:    http.StatusNotFound,
+			}
+			network.WriteError(w, errJson)
+			return
+		}
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get last indexed epoch").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	start, errJson := epochQueryParam(r, "start_epoch")
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	end := last
+	if r.URL.Query().Get("end_epoch") != "" {
+		end, errJson = epochQueryParam(r, "end_epoch")
+		if errJson != nil {
+			network.WriteError(w, errJson)
+			return
+		}
+	}
+	if end < start {
+		errJson := &network.DefaultErrorJson{
+			Message: "end_epoch must not be lower than start_epoch",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	indices, errJson := requestedValidators(r)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	// The number of epochs is one more than their difference, which is compared instead so that it cannot overflow.
+	if uint64(end-start) >= maxHistoryRecords/uint64(len(indices)) {
+		errJson := &network.DefaultErrorJson{
+			Message: fmt.Sprintf("too many records requested, the number of validators times the number of epochs "+
+				"must not exceed %d", maxHistoryRecords),
+			Code: http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	history, err := s.BeaconDB.ValidatorHistory(r.Context(), indices, start, end)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get validator history").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := &ValidatorHistoryResponse{Data: make([]*ValidatorHistory, len(indices))}
+	for i, idx := range indices {
+		records := history[idx]
+		vh := &ValidatorHistory{
+			Index:   strconv.FormatUint(uint64(idx), 10),
+			History: make([]*ValidatorRecord, len(records)),
+		}
+		for j, rec := range records {
+			vh.History[j] = &ValidatorRecord{
+				Epoch:            strconv.FormatUint(uint64(rec.Epoch), 10),
+				Balance:          strconv.FormatUint(rec.Balance, 10),
+				EffectiveBalance: strconv.FormatUint(rec.EffectiveBalance, 10),
+				Status:           strings.ToLower(rec.Status.String()),
+			}
+		}
+		resp.Data[i] = vh
+	}
+	network.WriteJson(w, resp)
+}
+
+func requestedValidators(r *http.Request) ([]primitives.ValidatorIndex, *network.DefaultErrorJson) {
+	var ids []string
+	if r.Body != nil && r.Body != http.NoBody {
+		if err := json.NewDecoder(r.Body).Decode(&ids); err != nil && err != io.EOF {
+			return nil, &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not decode validator indices").Error(),
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+	if len(ids) == 0 {
+		return nil, &network.DefaultErrorJson{
+			Message: "no validator indices requested",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	indices := make([]primitives.ValidatorIndex, 0, len(ids))
+	seen := make(map[primitives.ValidatorIndex]bool, len(ids))
+	for _, id := range ids {
+		idx, err := strconv.ParseUint(id, 10, 64)
+		if err != nil {
+			return nil, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("invalid validator index %s", id),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		if seen[primitives.ValidatorIndex(idx)] {
+			continue
+		}
+		seen[primitives.ValidatorIndex(idx)] = true
+		indices = append(indices, primitives.ValidatorIndex(idx))
+	}
+	return indices, nil
+}
+
+func epochQueryParam(r *http.Request, name string) (primitives.Epoch, *network.DefaultErrorJson) {
+	raw := r.URL.Query().Get(name)
+	if raw == "" {
+		return 0, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("%s is required", name),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	e, err := strconv.ParseUint(raw, 10, 64)
+	if err != nil {
+		return 0, &network.DefaultErrorJson{
+			Message: errors.Wrapf(err, "invalid %s", name).Error(),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	return primitives.Epoch(e), nil
+}
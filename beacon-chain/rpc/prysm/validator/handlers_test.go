@@ -0,0 +1,105 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/epoch/precompute"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestValidatorHistory(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	s := &Server{BeaconDB: beaconDB}
+
+	request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/history?start_epoch=0", strings.NewReader(`["0"]`))
+	writer := httptest.NewRecorder()
+	writer.Body = &bytes.Buffer{}
+	s.ValidatorHistory(writer, request)
+	assert.Equal(t, http.StatusNotFound, writer.Code)
+
+	for epoch := primitives.Epoch(1); epoch <= 3; epoch++ {
+		require.NoError(t, beaconDB.SaveValidatorHistory(ctx, &precompute.EpochSnapshot{
+			Epoch:             epoch,
+			Balances:          []uint64{uint64(epoch) * 10, uint64(epoch) * 20},
+			EffectiveBalances: []uint64{32, 32},
+			Statuses:          []ethpb.ValidatorStatus{ethpb.ValidatorStatus_ACTIVE_ONGOING, ethpb.ValidatorStatus_ACTIVE_EXITING},
+		}))
+	}
+
+	t.Run("epoch range", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/history?start_epoch=2", strings.NewReader(`["1","5"]`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ValidatorHistory(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].Index)
+		assert.DeepEqual(t, []*ValidatorRecord{
+			{Epoch: "2", Balance: "40", EffectiveBalance: "32", Status: "active_exiting"},
+			{Epoch: "3", Balance: "60", EffectiveBalance: "32", Status: "active_exiting"},
+		}, resp.Data[0].History)
+		// Validator 5 is not part of the registry.
+		assert.Equal(t, "5", resp.Data[1].Index)
+		assert.Equal(t, 0, len(resp.Data[1].History))
+	})
+	t.Run("duplicate indices", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/history?start_epoch=3", strings.NewReader(`["1","0","1"]`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ValidatorHistory(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].Index)
+		assert.Equal(t, "0", resp.Data[1].Index)
+	})
+	t.Run("end epoch", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/history?start_epoch=0&end_epoch=1", strings.NewReader(`["0"]`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ValidatorHistory(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorHistoryResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.DeepEqual(t, []*ValidatorRecord{
+			{Epoch: "1", Balance: "10", EffectiveBalance: "32", Status: "active_ongoing"},
+		}, resp.Data[0].History)
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		for url, body := range map[string]string{
+			"http://foo.example/prysm/validators/history":                                    `["0"]`,
+			"http://foo.example/prysm/validators/history?start_epoch=3&end_epoch=2":          `["0"]`,
+			"http://foo.example/prysm/validators/history?start_epoch=0":                      `[]`,
+			"http://foo.example/prysm/validators/history?start_epoch=0&end_epoch=foo":        `["0"]`,
+			"http://foo.example/prysm/validators/history?start_epoch=1":                      `["foo"]`,
+			"http://foo.example/prysm/validators/history?start_epoch=0&end_epoch=9999999999": `["0"]`,
+			// The number of requested epochs does not overflow.
+			"http://foo.example/prysm/validators/history?start_epoch=0&end_epoch=18446744073709551615": `["0"]`,
+		} {
+			request := httptest.NewRequest("POST", url, strings.NewReader(body))
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			s.ValidatorHistory(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code, url)
+			e := &network.DefaultErrorJson{}
+			require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+			assert.Equal(t, http.StatusBadRequest, e.Code)
+		}
+	})
+}
@@ -0,0 +1,169 @@
+package validator
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// ValidatorPerformance is an HTTP handler which returns the per epoch performance of the requested validators, as
+// recorded by the validator monitor, for the epochs in the range given by the start_epoch and end_epoch query
+// parameters. end_epoch defaults to the current epoch. The validator indices are given as a JSON array of strings in
+// the request body. Epochs during which a validator was not tracked, or not active, are omitted from its records.
+func (s *Server) ValidatorPerformance(w http.ResponseWriter, r *http.Request) {
+	start, errJson := epochQueryParam(r, "start_epoch")
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	end := slots.ToEpoch(s.GenesisTimeFetcher.CurrentSlot())
+	if r.URL.Query().Get("end_epoch") != "" {
+		end, errJson = epochQueryParam(r, "end_epoch")
+		if errJson != nil {
+			network.WriteError(w, errJson)
+			return
+		}
+	}
+	if end < start {
+		errJson := &network.DefaultErrorJson{
+			Message: "end_epoch must not be lower than start_epoch",
+			Code:    http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	indices, errJson := requestedValidators(r)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	if epochs := uint64(end-start) + 1; epochs > maxHistoryRecords/uint64(len(indices)) {
+		errJson := &network.DefaultErrorJson{
+			Message: fmt.Sprintf("too many records requested, the number of validators times the number of epochs "+
+				"must not exceed %d", maxHistoryRecords),
+			Code: http.StatusBadRequest,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	performance, err := s.BeaconDB.ValidatorPerformance(r.Context(), indices, start, end)
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get validator performance").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := &ValidatorPerformanceResponse{Data: make([]*ValidatorPerformance, len(indices))}
+	for i, idx := range indices {
+		records := performance[idx]
+		vp := &ValidatorPerformance{
+			Index:       strconv.FormatUint(uint64(idx), 10),
+			Performance: make([]*EpochPerformance, len(records)),
+		}
+		for j, rec := range records {
+			vp.Performance[j] = &EpochPerformance{
+				Epoch:                      strconv.FormatUint(uint64(rec.Epoch), 10),
+				StartBalance:               strconv.FormatUint(rec.StartBalance, 10),
+				EndBalance:                 strconv.FormatUint(rec.EndBalance, 10),
+				AttestationIncluded:        rec.AttestationIncluded,
+				AttestedSlot:               strconv.FormatUint(uint64(rec.AttestedSlot), 10),
+				InclusionSlot:              strconv.FormatUint(uint64(rec.InclusionSlot), 10),
+				TimelySource:               rec.TimelySource,
+				TimelyTarget:               rec.TimelyTarget,
+				TimelyHead:                 rec.TimelyHead,
+				ProposedBlocks:             strconv.FormatUint(rec.ProposedBlocks, 10),
+				Aggregations:               strconv.FormatUint(rec.Aggregations, 10),
+				SyncCommitteeExpected:      strconv.FormatUint(rec.SyncCommitteeExpected, 10),
+				SyncCommitteeContributions: strconv.FormatUint(rec.SyncCommitteeContributions, 10),
+			}
+		}
+		resp.Data[i] = vp
+	}
+	network.WriteJson(w, resp)
+}
+
+// MonitoredValidators is an HTTP handler which returns the indices of the validators tracked by the validator
+// monitor.
+func (s *Server) MonitoredValidators(w http.ResponseWriter, _ *http.Request) {
+	if !s.monitorEnabled(w) {
+		return
+	}
+	writeMonitoredValidators(w, s.ValidatorMonitor.TrackedIndices())
+}
+
+// TrackValidators is an HTTP handler which adds the validators given as a JSON array of strings in the request body
+// to the validators tracked by the validator monitor. It returns the updated list of tracked validators.
+func (s *Server) TrackValidators(w http.ResponseWriter, r *http.Request) {
+	if !s.monitorEnabled(w) {
+		return
+	}
+	indices, errJson := requestedValidators(r)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	if err := s.ValidatorMonitor.TrackValidators(r.Context(), indices); err != nil {
+		code := http.StatusInternalServerError
+		if errors.Is(err, monitor.ErrUnknownValidator) {
+			code = http.StatusBadRequest
+		}
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not track validators").Error(),
+			Code:    code,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	writeMonitoredValidators(w, s.ValidatorMonitor.TrackedIndices())
+}
+
+// UntrackValidators is an HTTP handler which removes the validators given as a JSON array of strings in the request
+// body from the validators tracked by the validator monitor. It returns the updated list of tracked validators.
+func (s *Server) UntrackValidators(w http.ResponseWriter, r *http.Request) {
+	if !s.monitorEnabled(w) {
+		return
+	}
+	indices, errJson := requestedValidators(r)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	if err := s.ValidatorMonitor.UntrackValidators(r.Context(), indices); err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not untrack validators").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	writeMonitoredValidators(w, s.ValidatorMonitor.TrackedIndices())
+}
+
+func (s *Server) monitorEnabled(w http.ResponseWriter) bool {
+	if s.ValidatorMonitor == nil {
+		errJson := &network.DefaultErrorJson{
+			Message: "validator monitor is not running",
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return false
+	}
+	return true
+}
+
+func writeMonitoredValidators(w http.ResponseWriter, indices []primitives.ValidatorIndex) {
+	resp := &MonitoredValidatorsResponse{Data: make([]string, len(indices))}
+	for i, idx := range indices {
+		resp.Data[i] = strconv.FormatUint(uint64(idx), 10)
+	}
+	network.WriteJson(w, resp)
+}
@@ -0,0 +1,187 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sort"
+	"strings"
+	"testing"
+
+	"github.com/pkg/errors"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
+	montypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor/types"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+type mockTracker struct {
+	tracked map[primitives.ValidatorIndex]bool
+}
+
+func (m *mockTracker) TrackedIndices() []primitives.ValidatorIndex {
+	indices := make([]primitives.ValidatorIndex, 0, len(m.tracked))
+	for idx := range m.tracked {
+		indices = append(indices, idx)
+	}
+	sort.Slice(indices, func(i, j int) bool { return indices[i] < indices[j] })
+	return indices
+}
+
+func (m *mockTracker) TrackValidators(_ context.Context, indices []primitives.ValidatorIndex) error {
+	for _, idx := range indices {
+		if idx >= 100 {
+			return errors.Wrapf(monitor.ErrUnknownValidator, "validator %d", idx)
+		}
+	}
+	for _, idx := range indices {
+		m.tracked[idx] = true
+	}
+	return nil
+}
+
+func (m *mockTracker) UntrackValidators(_ context.Context, indices []primitives.ValidatorIndex) error {
+	for _, idx := range indices {
+		delete(m.tracked, idx)
+	}
+	return nil
+}
+
+func TestValidatorPerformance(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	currentSlot := params.BeaconConfig().SlotsPerEpoch * 3
+	s := &Server{
+		BeaconDB:           beaconDB,
+		GenesisTimeFetcher: &mock.ChainService{Slot: &currentSlot},
+	}
+	var records []*montypes.ValidatorPerformance
+	for epoch := primitives.Epoch(1); epoch <= 4; epoch++ {
+		records = append(records, &montypes.ValidatorPerformance{
+			ValidatorIndex:      1,
+			Epoch:               epoch,
+			StartBalance:        uint64(epoch) * 10,
+			EndBalance:          uint64(epoch)*10 + 1,
+			AttestationIncluded: epoch != 2,
+			ProposedBlocks:      1,
+		})
+	}
+	require.NoError(t, beaconDB.SaveValidatorPerformance(ctx, records))
+
+	t.Run("default end epoch", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/performance?start_epoch=2", strings.NewReader(`["1","5"]`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ValidatorPerformance(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorPerformanceResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, "1", resp.Data[0].Index)
+		require.Equal(t, 2, len(resp.Data[0].Performance))
+		assert.DeepEqual(t, &EpochPerformance{
+			Epoch:                      "2",
+			StartBalance:               "20",
+			EndBalance:                 "21",
+			AttestationIncluded:        false,
+			AttestedSlot:               "0",
+			InclusionSlot:              "0",
+			ProposedBlocks:             "1",
+			Aggregations:               "0",
+			SyncCommitteeExpected:      "0",
+			SyncCommitteeContributions: "0",
+		}, resp.Data[0].Performance[0])
+		assert.Equal(t, "3", resp.Data[0].Performance[1].Epoch)
+		assert.Equal(t, true, resp.Data[0].Performance[1].AttestationIncluded)
+		// Validator 5 is not tracked.
+		assert.Equal(t, "5", resp.Data[1].Index)
+		assert.Equal(t, 0, len(resp.Data[1].Performance))
+	})
+	t.Run("end epoch", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/performance?start_epoch=0&end_epoch=4", strings.NewReader(`["1"]`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ValidatorPerformance(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &ValidatorPerformanceResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		assert.Equal(t, 4, len(resp.Data[0].Performance))
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		for url, body := range map[string]string{
+			"http://foo.example/prysm/validators/performance":                                    `["1"]`,
+			"http://foo.example/prysm/validators/performance?start_epoch=3&end_epoch=2":          `["1"]`,
+			"http://foo.example/prysm/validators/performance?start_epoch=0":                      `[]`,
+			"http://foo.example/prysm/validators/performance?start_epoch=1":                      `["foo"]`,
+			"http://foo.example/prysm/validators/performance?start_epoch=0&end_epoch=9999999999": `["1"]`,
+		} {
+			request := httptest.NewRequest("POST", url, strings.NewReader(body))
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			s.ValidatorPerformance(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code, url)
+		}
+	})
+}
+
+func TestMonitoredValidators(t *testing.T) {
+	tracker := &mockTracker{tracked: map[primitives.ValidatorIndex]bool{3: true}}
+	s := &Server{ValidatorMonitor: tracker}
+
+	t.Run("track", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/monitor", strings.NewReader(`["5","1"]`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.TrackValidators(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &MonitoredValidatorsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, []string{"1", "3", "5"}, resp.Data)
+	})
+	t.Run("unknown validator", func(t *testing.T) {
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/monitor", strings.NewReader(`["100"]`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.TrackValidators(writer, request)
+		assert.Equal(t, http.StatusBadRequest, writer.Code)
+		e := &network.DefaultErrorJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), e))
+		assert.StringContains(t, "unknown validator", e.Message)
+	})
+	t.Run("untrack", func(t *testing.T) {
+		request := httptest.NewRequest("DELETE", "http://foo.example/prysm/validators/monitor", strings.NewReader(`["3"]`))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.UntrackValidators(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &MonitoredValidatorsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, []string{"1", "5"}, resp.Data)
+	})
+	t.Run("list", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/prysm/validators/monitor", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.MonitoredValidators(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &MonitoredValidatorsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.DeepEqual(t, []string{"1", "5"}, resp.Data)
+	})
+	t.Run("monitor not running", func(t *testing.T) {
+		s := &Server{}
+		request := httptest.NewRequest("GET", "http://foo.example/prysm/validators/monitor", nil)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.MonitoredValidators(writer, request)
+		assert.Equal(t, http.StatusServiceUnavailable, writer.Code)
+	})
+}
@@ -0,0 +1,127 @@
+package validator
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+)
+
+// maxProposerRegistrationKeys is the maximum number of public keys a single proposer registrations request may
+// contain, as every key is looked up at each relay.
+const maxProposerRegistrationKeys = 1000
+
+// ProposerRegistrations is an HTTP handler which returns, for each of the public keys given as a JSON array of hex
+// strings in the request body, the fee recipient and the builder registration stored by the beacon node, as well as
+// the registration known by each of the configured relays. The index, fee recipient and registration are omitted when
+// the beacon node does not have them.
+func (s *Server) ProposerRegistrations(w http.ResponseWriter, r *http.Request) {
+	pubKeys, errJson := requestedPubKeys(r)
+	if errJson != nil {
+		network.WriteError(w, errJson)
+		return
+	}
+	resp := &ProposerRegistrationsResponse{Data: make([]*ProposerRegistration, len(pubKeys))}
+	for i, pubKey := range pubKeys {
+		pr := &ProposerRegistration{
+			Pubkey: hexutil.Encode(pubKey[:]),
+			Relays: make([]*RelayRegistration, 0),
+		}
+		if idx, ok := s.HeadFetcher.HeadPublicKeyToValidatorIndex(pubKey); ok {
+			pr.Index = strconv.FormatUint(uint64(idx), 10)
+			feeRecipient, err := s.BeaconDB.FeeRecipientByValidatorID(r.Context(), idx)
+			switch {
+			case err == nil:
+				pr.FeeRecipient = hexutil.Encode(feeRecipient.Bytes())
+			case !errors.Is(err, db.ErrNotFound):
+				errJson := &network.DefaultErrorJson{
+					Message: errors.Wrapf(err, "could not get fee recipient of validator %d", idx).Error(),
+					Code:    http.StatusInternalServerError,
+				}
+				network.WriteError(w, errJson)
+				return
+			}
+			if s.BlockBuilder != nil && s.BlockBuilder.Configured() {
+				reg, err := s.BlockBuilder.RegistrationByValidatorID(r.Context(), idx)
+				switch {
+				case err == nil:
+					pr.Registration = registrationJson(reg)
+				case !errors.Is(err, db.ErrNotFound) && !errors.Is(err, cache.ErrNotFound):
+					errJson := &network.DefaultErrorJson{
+						Message: errors.Wrapf(err, "could not get registration of validator %d", idx).Error(),
+						Code:    http.StatusInternalServerError,
+					}
+					network.WriteError(w, errJson)
+					return
+				}
+			}
+		}
+		if s.BlockBuilder != nil && s.BlockBuilder.Configured() {
+			for _, rr := range s.BlockBuilder.RelayRegistrations(r.Context(), pubKey) {
+				relayReg := &RelayRegistration{URL: rr.URL}
+				if rr.Err != nil {
+					relayReg.Error = rr.Err.Error()
+				} else {
+					relayReg.Registration = registrationJson(rr.Registration)
+				}
+				pr.Relays = append(pr.Relays, relayReg)
+			}
+		}
+		resp.Data[i] = pr
+	}
+	network.WriteJson(w, resp)
+}
+
+func registrationJson(reg *ethpb.ValidatorRegistrationV1) *Registration {
+	return &Registration{
+		FeeRecipient: hexutil.Encode(reg.FeeRecipient),
+		GasLimit:     strconv.FormatUint(reg.GasLimit, 10),
+		Timestamp:    strconv.FormatUint(reg.Timestamp, 10),
+	}
+}
+
+func requestedPubKeys(r *http.Request) ([][fieldparams.BLSPubkeyLength]byte, *network.DefaultErrorJson) {
+	var rawKeys []string
+	if r.Body != nil && r.Body != http.NoBody {
+		if err := json.NewDecoder(r.Body).Decode(&rawKeys); err != nil && err != io.EOF {
+			return nil, &network.DefaultErrorJson{
+				Message: errors.Wrapf(err, "could not decode public keys").Error(),
+				Code:    http.StatusBadRequest,
+			}
+		}
+	}
+	if len(rawKeys) == 0 {
+		return nil, &network.DefaultErrorJson{
+			Message: "no public keys requested",
+			Code:    http.StatusBadRequest,
+		}
+	}
+	if len(rawKeys) > maxProposerRegistrationKeys {
+		return nil, &network.DefaultErrorJson{
+			Message: fmt.Sprintf("too many public keys requested, at most %d are allowed", maxProposerRegistrationKeys),
+			Code:    http.StatusBadRequest,
+		}
+	}
+	pubKeys := make([][fieldparams.BLSPubkeyLength]byte, len(rawKeys))
+	for i, raw := range rawKeys {
+		decoded, err := hexutil.Decode(raw)
+		if err != nil || len(decoded) != fieldparams.BLSPubkeyLength {
+			return nil, &network.DefaultErrorJson{
+				Message: fmt.Sprintf("invalid public key %s", raw),
+				Code:    http.StatusBadRequest,
+			}
+		}
+		pubKeys[i] = bytesutil.ToBytes48(decoded)
+	}
+	return pubKeys, nil
+}
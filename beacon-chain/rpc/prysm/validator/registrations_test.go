@@ -0,0 +1,80 @@
+package validator
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	builderservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/builder"
+	buildertesting "github.com/prysmaticlabs/prysm/v4/beacon-chain/builder/testing"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestProposerRegistrations(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	pubKey := bytesutil.ToBytes48([]byte("pubkey"))
+	feeRecipient := common.HexToAddress("0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9")
+	require.NoError(t, beaconDB.SaveFeeRecipientsByValidatorIDs(ctx, []primitives.ValidatorIndex{0}, []common.Address{feeRecipient}))
+	reg := &ethpb.ValidatorRegistrationV1{Pubkey: pubKey[:], FeeRecipient: feeRecipient.Bytes(), GasLimit: 30000000, Timestamp: 42}
+	require.NoError(t, beaconDB.SaveRegistrationsByValidatorIDs(ctx, []primitives.ValidatorIndex{0}, []*ethpb.ValidatorRegistrationV1{reg}))
+	s := &Server{
+		BeaconDB:    beaconDB,
+		HeadFetcher: &mock.ChainService{},
+		BlockBuilder: &buildertesting.MockBuilderService{
+			HasConfigured: true,
+			Cfg:           &buildertesting.Config{BeaconDB: beaconDB},
+			RelayRegistrationsMap: map[[48]byte][]*builderservice.RelayRegistration{
+				pubKey: {
+					{URL: "http://relay1", Registration: reg},
+					{URL: "http://relay2", Err: errors.New("not found")},
+				},
+			},
+		},
+	}
+
+	t.Run("ok", func(t *testing.T) {
+		body := `["` + hexutil.Encode(pubKey[:]) + `"]`
+		request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/proposer_registrations", strings.NewReader(body))
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ProposerRegistrations(writer, request)
+		assert.Equal(t, http.StatusOK, writer.Code)
+		resp := &ProposerRegistrationsResponse{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		require.Equal(t, 1, len(resp.Data))
+		expectedReg := &Registration{FeeRecipient: hexutil.Encode(feeRecipient.Bytes()), GasLimit: "30000000", Timestamp: "42"}
+		assert.DeepEqual(t, &ProposerRegistration{
+			Pubkey:       hexutil.Encode(pubKey[:]),
+			Index:        "0",
+			FeeRecipient: hexutil.Encode(feeRecipient.Bytes()),
+			Registration: expectedReg,
+			Relays: []*RelayRegistration{
+				{URL: "http://relay1", Registration: expectedReg},
+				{URL: "http://relay2", Error: "not found"},
+			},
+		}, resp.Data[0])
+	})
+	t.Run("invalid requests", func(t *testing.T) {
+		for _, body := range []string{``, `[]`, `["foo"]`, `["0x1234"]`} {
+			request := httptest.NewRequest("POST", "http://foo.example/prysm/validators/proposer_registrations", strings.NewReader(body))
+			writer := httptest.NewRecorder()
+			writer.Body = &bytes.Buffer{}
+			s.ProposerRegistrations(writer, request)
+			assert.Equal(t, http.StatusBadRequest, writer.Code, body)
+		}
+	})
+}
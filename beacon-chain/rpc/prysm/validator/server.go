@@ -0,0 +1,16 @@
+package validator
+
+import (
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/builder"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
+)
+
+type Server struct {
+	BeaconDB           db.ReadOnlyDatabase
+	GenesisTimeFetcher blockchain.TimeFetcher
+	ValidatorMonitor   monitor.ValidatorTracker
+	HeadFetcher        blockchain.HeadFetcher
+	BlockBuilder       builder.BlockBuilder
+}
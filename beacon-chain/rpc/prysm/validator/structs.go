@@ -0,0 +1,70 @@
+package validator
+
+type ValidatorHistoryResponse struct {
+	Data []*ValidatorHistory `json:"data"`
+}
+
+type ValidatorHistory struct {
+	Index   string             `json:"index"`
+	History []*ValidatorRecord `json:"history"`
+}
+
+type ValidatorRecord struct {
+	Epoch            string `json:"epoch"`
+	Balance          string `json:"balance"`
+	EffectiveBalance string `json:"effective_balance"`
+	Status           string `json:"status"`
+}
+
+type ValidatorPerformanceResponse struct {
+	Data []*ValidatorPerformance `json:"data"`
+}
+
+type ValidatorPerformance struct {
+	Index       string              `json:"index"`
+	Performance []*EpochPerformance `json:"performance"`
+}
+
+type EpochPerformance struct {
+	Epoch                      string `json:"epoch"`
+	StartBalance               string `json:"start_balance"`
+	EndBalance                 string `json:"end_balance"`
+	AttestationIncluded        bool   `json:"attestation_included"`
+	AttestedSlot               string `json:"attested_slot"`
+	InclusionSlot              string `json:"inclusion_slot"`
+	TimelySource               bool   `json:"timely_source"`
+	TimelyTarget               bool   `json:"timely_target"`
+	TimelyHead                 bool   `json:"timely_head"`
+	ProposedBlocks             string `json:"proposed_blocks"`
+	Aggregations               string `json:"aggregations"`
+	SyncCommitteeExpected      string `json:"sync_committee_expected"`
+	SyncCommitteeContributions string `json:"sync_committee_contributions"`
+}
+
+type MonitoredValidatorsResponse struct {
+	Data []string `json:"data"`
+}
+
+type ProposerRegistrationsResponse struct {
+	Data []*ProposerRegistration `json:"data"`
+}
+
+type ProposerRegistration struct {
+	Pubkey       string               `json:"pubkey"`
+	Index        string               `json:"index,omitempty"`
+	FeeRecipient string               `json:"fee_recipient,omitempty"`
+	Registration *Registration        `json:"registration,omitempty"`
+	Relays       []*RelayRegistration `json:"relays"`
+}
+
+type Registration struct {
+	FeeRecipient string `json:"fee_recipient"`
+	GasLimit     string `json:"gas_limit"`
+	Timestamp    string `json:"timestamp"`
+}
+
+type RelayRegistration struct {
+	URL          string        `json:"url"`
+	Registration *Registration `json:"registration,omitempty"`
+	Error        string        `json:"error,omitempty"`
+}
@@ -6,6 +6,7 @@ import (
 	"context"
 	"fmt"
 	"net"
+	"net/http"
 	"sync"
 
 	"github.com/gorilla/mux"
@@ -23,6 +24,7 @@ import (
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/execution"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/monitor"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/attestations"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/blstoexec"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/slashings"
@@ -32,14 +34,20 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/beacon"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/debug"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/events"
+	lightclient "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/light-client"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/node"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/rewards"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/eth/validator"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/lookup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/burn"
+	nodeprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/node"
+	slasherprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/slasher"
 	beaconv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/beacon"
 	debugv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/debug"
 	nodev1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/node"
+	slasherv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/slasher"
 	validatorv1alpha1 "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/v1alpha1/validator"
+	validatorprysm "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/prysm/validator"
 	slasherservice "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
@@ -89,6 +97,7 @@ type Config struct {
 	ForkFetcher                   blockchain.ForkFetcher
 	ForkchoiceFetcher             blockchain.ForkchoiceFetcher
 	FinalizationFetcher           blockchain.FinalizationFetcher
+	LightClientFetcher            blockchain.LightClientFetcher
 	AttestationReceiver           blockchain.AttestationReceiver
 	BlockReceiver                 blockchain.BlockReceiver
 	ExecutionChainService         execution.Chain
@@ -102,12 +111,14 @@ type Config struct {
 	ExitPool                      voluntaryexits.PoolManager
 	SlashingsPool                 slashings.PoolManager
 	SlashingChecker               slasherservice.SlashingChecker
+	SlashingInspector             slasherservice.SlashingInspector
 	SyncCommitteeObjectPool       synccommittee.Pool
 	BLSChangesPool                blstoexec.PoolManager
 	SyncService                   chainSync.Checker
 	Broadcaster                   p2p.Broadcaster
 	PeersFetcher                  p2p.PeersProvider
 	PeerManager                   p2p.PeerManager
+	PeerAdmin                     p2p.PeerAdmin
 	MetadataProvider              p2p.MetadataProvider
 	DepositFetcher                depositcache.DepositFetcher
 	PendingDepositFetcher         depositcache.PendingDepositsFetcher
@@ -122,6 +133,8 @@ type Config struct {
 	BlockBuilder                  builder.BlockBuilder
 	Router                        *mux.Router
 	ClockWaiter                   startup.ClockWaiter
+	ValidatorMonitor              monitor.ValidatorTracker
+	AdminAPIToken                 string
 }
 
 // NewService instantiates a new RPC service instance that will
@@ -210,9 +223,67 @@ func (s *Service) Start() {
 		Blocker:               blocker,
 		OptimisticModeFetcher: s.cfg.OptimisticModeFetcher,
 		FinalizationFetcher:   s.cfg.FinalizationFetcher,
+		TimeFetcher:           s.cfg.GenesisTimeFetcher,
 		ReplayerBuilder:       ch,
 	}
 	s.cfg.Router.HandleFunc("/eth/v1/beacon/rewards/blocks/{block_id}", rewardsServer.BlockRewards)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/rewards/attestations/{epoch}", rewardsServer.AttestationRewards)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/rewards/sync_committee/{block_id}", rewardsServer.SyncCommitteeRewards)
+
+	if features.Get().EnableLightClient {
+		lightClientServer := &lightclient.Server{
+			BeaconDB:           s.cfg.BeaconDB,
+			LightClientFetcher: s.cfg.LightClientFetcher,
+		}
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/bootstrap/{block_root}", lightClientServer.GetLightClientBootstrap)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/updates", lightClientServer.GetLightClientUpdatesByRange)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/finality_update", lightClientServer.GetLightClientFinalityUpdate)
+		s.cfg.Router.HandleFunc("/eth/v1/beacon/light_client/optimistic_update", lightClientServer.GetLightClientOptimisticUpdate)
+	}
+
+	nodeServerPrysm := &nodeprysm.Server{
+		BeaconDB:  s.cfg.BeaconDB,
+		PeerAdmin: s.cfg.PeerAdmin,
+	}
+	s.cfg.Router.HandleFunc("/prysm/node/history", nodeServerPrysm.History)
+	s.cfg.Router.HandleFunc("/prysm/node/peers", s.requireAdminToken(nodeServerPrysm.AddPeer)).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/node/peers/{peer_id}", s.requireAdminToken(nodeServerPrysm.DisconnectPeer)).Methods(http.MethodDelete)
+	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers", nodeServerPrysm.TrustedPeers).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers", s.requireAdminToken(nodeServerPrysm.TrustPeer)).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/node/trusted_peers", s.requireAdminToken(nodeServerPrysm.UntrustPeer)).Methods(http.MethodDelete)
+	s.cfg.Router.HandleFunc("/prysm/node/bans", nodeServerPrysm.Bans).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/node/bans", s.requireAdminToken(nodeServerPrysm.Ban)).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/node/bans", s.requireAdminToken(nodeServerPrysm.Unban)).Methods(http.MethodDelete)
+
+	burnServer := &burn.Server{
+		BeaconDB:         s.cfg.BeaconDB,
+		CanonicalFetcher: s.cfg.ChainInfoFetcher,
+		TimeFetcher:      s.cfg.GenesisTimeFetcher,
+		ReplayerBuilder:  ch,
+	}
+	s.cfg.Router.HandleFunc("/prysm/pulse/burn", burnServer.EpochBurn)
+	s.cfg.Router.HandleFunc("/prysm/pulse/burn/validators/{epoch}", burnServer.ValidatorBurn)
+
+	validatorServerPrysm := &validatorprysm.Server{
+		BeaconDB:           s.cfg.BeaconDB,
+		GenesisTimeFetcher: s.cfg.GenesisTimeFetcher,
+		ValidatorMonitor:   s.cfg.ValidatorMonitor,
+		HeadFetcher:        s.cfg.HeadFetcher,
+		BlockBuilder:       s.cfg.BlockBuilder,
+	}
+	s.cfg.Router.HandleFunc("/prysm/validators/history", validatorServerPrysm.ValidatorHistory)
+	s.cfg.Router.HandleFunc("/prysm/validators/performance", validatorServerPrysm.ValidatorPerformance)
+	s.cfg.Router.HandleFunc("/prysm/validators/monitor", validatorServerPrysm.MonitoredValidators).Methods(http.MethodGet)
+	s.cfg.Router.HandleFunc("/prysm/validators/monitor", s.requireAdminToken(validatorServerPrysm.TrackValidators)).Methods(http.MethodPost)
+	s.cfg.Router.HandleFunc("/prysm/validators/monitor", s.requireAdminToken(validatorServerPrysm.UntrackValidators)).Methods(http.MethodDelete)
+	s.cfg.Router.HandleFunc("/prysm/validators/proposer_registrations", s.requireAdminToken(validatorServerPrysm.ProposerRegistrations)).Methods(http.MethodPost)
+
+	slasherServerPrysm := &slasherprysm.Server{
+		SlashingInspector: s.cfg.SlashingInspector,
+	}
+	s.cfg.Router.HandleFunc("/prysm/slasher/slashings", slasherServerPrysm.DetectedSlashings)
+	s.cfg.Router.HandleFunc("/prysm/slasher/slashings/stream", slasherServerPrysm.StreamDetectedSlashings)
+	s.cfg.Router.HandleFunc("/prysm/slasher/validators/{validator_index}/spans", slasherServerPrysm.ValidatorSpans)
 
 	validatorServer := &validatorv1alpha1.Server{
 		Ctx:                    s.ctx,
@@ -310,6 +381,7 @@ func (s *Service) Start() {
 		StateNotifier:               s.cfg.StateNotifier,
 		BlockNotifier:               s.cfg.BlockNotifier,
 		AttestationNotifier:         s.cfg.OperationNotifier,
+		OperationNotifier:           s.cfg.OperationNotifier,
 		Broadcaster:                 s.cfg.Broadcaster,
 		StateGen:                    s.cfg.StateGen,
 		SyncChecker:                 s.cfg.SyncService,
@@ -344,6 +416,7 @@ func (s *Service) Start() {
 	}
 	s.cfg.Router.HandleFunc("/eth/v2/beacon/blocks", beaconChainServerV1.PublishBlockV2)
 	s.cfg.Router.HandleFunc("/eth/v2/beacon/blinded_blocks", beaconChainServerV1.PublishBlindedBlockV2)
+	s.cfg.Router.HandleFunc("/eth/v1/beacon/deposit_snapshot", beaconChainServerV1.GetDepositSnapshot).Methods(http.MethodGet)
 	ethpbv1alpha1.RegisterNodeServer(s.grpcServer, nodeServer)
 	ethpbservice.RegisterBeaconNodeServer(s.grpcServer, nodeServerV1)
 	ethpbv1alpha1.RegisterHealthServer(s.grpcServer, nodeServer)
@@ -383,6 +456,11 @@ func (s *Service) Start() {
 	}
 	ethpbv1alpha1.RegisterBeaconNodeValidatorServer(s.grpcServer, validatorServer)
 	ethpbservice.RegisterBeaconValidatorServer(s.grpcServer, validatorServerV1)
+	if features.Get().EnableSlasher {
+		ethpbv1alpha1.RegisterSlasherServer(s.grpcServer, &slasherv1alpha1.Server{
+			SlashingChecker: s.cfg.SlashingChecker,
+		})
+	}
 	// Register reflection service on gRPC server.
 	reflection.Register(s.grpcServer)
 
@@ -2,15 +2,19 @@ package slasher
 
 import (
 	"context"
+	"time"
 
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
 
-// Verifies attester slashings, logs them, and submits them to the slashing operations pool
-// in the beacon node if they pass validation.
+// Verifies attester slashings, logs them, records them as detected, and submits them to the
+// slashing operations pool in the beacon node if they pass validation.
 func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*ethpb.AttesterSlashing) error {
 	var beaconState state.BeaconState
 	var err error
@@ -20,6 +24,7 @@ func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*eth
 			return err
 		}
 	}
+	detected := make([]*slashertypes.DetectedSlashing, 0, len(slashings))
 	for _, sl := range slashings {
 		if err := s.verifyAttSignature(ctx, sl.Attestation_1); err != nil {
 			log.WithError(err).WithField("a", sl.Attestation_1).Warn(
@@ -36,17 +41,21 @@ func (s *Service) processAttesterSlashings(ctx context.Context, slashings []*eth
 
 		// Log the slashing event and insert into the beacon node's operations pool.
 		logAttesterSlashing(sl)
+		detected = append(detected, &slashertypes.DetectedSlashing{DetectedAt: time.Now(), AttesterSlashing: sl})
 		if err := s.serviceCfg.SlashingPoolInserter.InsertAttesterSlashing(
 			ctx, beaconState, sl,
 		); err != nil {
 			log.WithError(err).Error("Could not insert attester slashing into operations pool")
+		} else {
+			s.sendOperationEvent(opfeed.AttesterSlashingReceived, &opfeed.AttesterSlashingReceivedData{AttesterSlashing: sl})
 		}
 	}
+	s.recordDetectedSlashings(ctx, detected)
 	return nil
 }
 
-// Verifies proposer slashings, logs them, and submits them to the slashing operations pool
-// in the beacon node if they pass validation.
+// Verifies proposer slashings, logs them, records them as detected, and submits them to the
+// slashing operations pool in the beacon node if they pass validation.
 func (s *Service) processProposerSlashings(ctx context.Context, slashings []*ethpb.ProposerSlashing) error {
 	var beaconState state.BeaconState
 	var err error
@@ -56,6 +65,7 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 			return err
 		}
 	}
+	detected := make([]*slashertypes.DetectedSlashing, 0, len(slashings))
 	for _, sl := range slashings {
 		if err := s.verifyBlockSignature(ctx, sl.Header_1); err != nil {
 			log.WithError(err).WithField("a", sl.Header_1).Warn(
@@ -71,13 +81,31 @@ func (s *Service) processProposerSlashings(ctx context.Context, slashings []*eth
 		}
 		// Log the slashing event and insert into the beacon node's operations pool.
 		logProposerSlashing(sl)
+		detected = append(detected, &slashertypes.DetectedSlashing{DetectedAt: time.Now(), ProposerSlashing: sl})
 		if err := s.serviceCfg.SlashingPoolInserter.InsertProposerSlashing(ctx, beaconState, sl); err != nil {
 			log.WithError(err).Error("Could not insert proposer slashing into operations pool")
+		} else {
+			s.sendOperationEvent(opfeed.ProposerSlashingReceived, &opfeed.ProposerSlashingReceivedData{ProposerSlashing: sl})
 		}
 	}
+	s.recordDetectedSlashings(ctx, detected)
 	return nil
 }
 
+// Persists the slashings found by slasher so that they can be inspected later on,
+// and sends them to the subscribers of the detected slashings feed.
+func (s *Service) recordDetectedSlashings(ctx context.Context, detected []*slashertypes.DetectedSlashing) {
+	if len(detected) == 0 {
+		return
+	}
+	if err := s.serviceCfg.Database.SaveDetectedSlashings(ctx, detected); err != nil {
+		log.WithError(err).Error("Could not save detected slashings")
+	}
+	for _, sl := range detected {
+		s.detectedSlashingsFeed.Send(sl)
+	}
+}
+
 func (s *Service) verifyBlockSignature(ctx context.Context, header *ethpb.SignedBeaconBlockHeader) error {
 	parentState, err := s.serviceCfg.StateGen.StateByRoot(ctx, bytesutil.ToBytes32(header.Header.ParentRoot))
 	if err != nil {
@@ -93,3 +121,14 @@ func (s *Service) verifyAttSignature(ctx context.Context, att *ethpb.IndexedAtte
 	}
 	return blocks.VerifyIndexedAttestation(ctx, preState, att)
 }
+
+// Notifies the operation feed subscribers, if any, of a slashing submitted to the operations pool.
+func (s *Service) sendOperationEvent(typ feed.EventType, data interface{}) {
+	if s.serviceCfg.OperationNotifier == nil {
+		return
+	}
+	s.serviceCfg.OperationNotifier.OperationFeed().Send(&feed.Event{
+		Type: typ,
+		Data: data,
+	})
+}
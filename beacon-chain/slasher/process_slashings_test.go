@@ -5,10 +5,13 @@ import (
 	"testing"
 
 	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
 	slashingsmock "github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/slashings/mock"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
@@ -47,6 +50,7 @@ func TestService_processAttesterSlashings(t *testing.T) {
 			AttestationStateFetcher: mockChain,
 			StateGen:                stategen.New(beaconDB, doublylinkedtree.New()),
 			SlashingPoolInserter:    &slashingsmock.PoolMock{},
+			OperationNotifier:       &mock.MockOperationNotifier{},
 			HeadStateFetcher:        mockChain,
 		},
 	}
@@ -120,9 +124,31 @@ func TestService_processAttesterSlashings(t *testing.T) {
 			},
 		}
 
+		detectedCh := make(chan *slashertypes.DetectedSlashing, 1)
+		sub := s.SubscribeDetectedSlashings(detectedCh)
+		defer sub.Unsubscribe()
+		opCh := make(chan *feed.Event, 1)
+		opSub := s.serviceCfg.OperationNotifier.OperationFeed().Subscribe(opCh)
+		defer opSub.Unsubscribe()
+
 		err = s.processAttesterSlashings(ctx, slashings)
 		require.NoError(tt, err)
 		require.LogsDoNotContain(tt, hook, "Invalid signature")
+
+		// The slashing is persisted and sent to subscribers.
+		detected, _, err := slasherDB.DetectedSlashings(ctx, nil, 10, nil)
+		require.NoError(tt, err)
+		require.Equal(tt, 1, len(detected))
+		require.DeepSSZEqual(tt, slashings[0], detected[0].AttesterSlashing)
+		sl := <-detectedCh
+		require.DeepSSZEqual(tt, slashings[0], sl.AttesterSlashing)
+
+		// The slashing is sent to the operation feed once in the operations pool.
+		e := <-opCh
+		require.Equal(tt, feed.EventType(opfeed.AttesterSlashingReceived), e.Type)
+		data, ok := e.Data.(*opfeed.AttesterSlashingReceivedData)
+		require.Equal(tt, true, ok)
+		require.DeepSSZEqual(tt, slashings[0], data.AttesterSlashing)
 	})
 }
 
@@ -154,6 +180,7 @@ func TestService_processProposerSlashings(t *testing.T) {
 			AttestationStateFetcher: mockChain,
 			StateGen:                stategen.New(beaconDB, doublylinkedtree.New()),
 			SlashingPoolInserter:    &slashingsmock.PoolMock{},
+			OperationNotifier:       &mock.MockOperationNotifier{},
 			HeadStateFetcher:        mockChain,
 		},
 	}
@@ -246,8 +273,30 @@ func TestService_processProposerSlashings(t *testing.T) {
 			},
 		}
 
+		detectedCh := make(chan *slashertypes.DetectedSlashing, 1)
+		sub := s.SubscribeDetectedSlashings(detectedCh)
+		defer sub.Unsubscribe()
+		opCh := make(chan *feed.Event, 1)
+		opSub := s.serviceCfg.OperationNotifier.OperationFeed().Subscribe(opCh)
+		defer opSub.Unsubscribe()
+
 		err = s.processProposerSlashings(ctx, slashings)
 		require.NoError(tt, err)
 		require.LogsDoNotContain(tt, hook, "Invalid signature")
+
+		// The slashing is persisted and sent to subscribers.
+		detected, _, err := slasherDB.DetectedSlashings(ctx, nil, 10, nil)
+		require.NoError(tt, err)
+		require.Equal(tt, 1, len(detected))
+		require.DeepSSZEqual(tt, slashings[0], detected[0].ProposerSlashing)
+		sl := <-detectedCh
+		require.DeepSSZEqual(tt, slashings[0], sl.ProposerSlashing)
+
+		// The slashing is sent to the operation feed once in the operations pool.
+		e := <-opCh
+		require.Equal(tt, feed.EventType(opfeed.ProposerSlashingReceived), e.Type)
+		data, ok := e.Data.(*opfeed.ProposerSlashingReceivedData)
+		require.Equal(tt, true, ok)
+		require.DeepSSZEqual(tt, slashings[0], data.ProposerSlashing)
 	})
 }
@@ -216,6 +216,12 @@ func (s *Service) pruneSlasherDataWithinSlidingWindow(ctx context.Context, curre
 	if err != nil {
 		return errors.Wrap(err, "Could not prune proposals")
 	}
+	numPrunedSlashings, err := s.serviceCfg.Database.PruneDetectedSlashingsAtEpoch(
+		ctx, maxPruningEpoch,
+	)
+	if err != nil {
+		return errors.Wrap(err, "Could not prune detected slashings")
+	}
 	fields := logrus.Fields{}
 	if numPrunedAtts > 0 {
 		fields["numPrunedAtts"] = numPrunedAtts
@@ -223,6 +229,9 @@ func (s *Service) pruneSlasherDataWithinSlidingWindow(ctx context.Context, curre
 	if numPrunedProposals > 0 {
 		fields["numPrunedProposals"] = numPrunedProposals
 	}
+	if numPrunedSlashings > 0 {
+		fields["numPrunedSlashings"] = numPrunedSlashings
+	}
 	fields["elapsed"] = time.Since(start)
 	log.WithFields(fields).Info("Done pruning old attestations and proposals for slasher")
 	return nil
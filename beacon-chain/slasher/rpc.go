@@ -4,6 +4,7 @@ import (
 	"context"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/async/event"
 	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
@@ -12,6 +13,10 @@ import (
 	"google.golang.org/grpc/status"
 )
 
+// ErrSpansOutOfRange is returned when the spans of a validator are requested for epochs
+// slasher does not keep spans for.
+var ErrSpansOutOfRange = errors.New("requested epochs are outside of slasher history")
+
 // HighestAttestations committed for an input list of validator indices.
 func (s *Service) HighestAttestations(
 	ctx context.Context, validatorIndices []primitives.ValidatorIndex,
@@ -77,3 +82,63 @@ func (s *Service) IsSlashableAttestation(
 	}
 	return attesterSlashings, nil
 }
+
+// DetectedSlashings returns up to limit slashings found by slasher which match the filter, in the order they were
+// first detected, starting at the given page token, along with the token of the next page.
+func (s *Service) DetectedSlashings(
+	ctx context.Context, pageToken []byte, limit int, filter func(*slashertypes.DetectedSlashing) bool,
+) ([]*slashertypes.DetectedSlashing, []byte, error) {
+	detected, next, err := s.serviceCfg.Database.DetectedSlashings(ctx, pageToken, limit, filter)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "could not get detected slashings from database")
+	}
+	return detected, next, nil
+}
+
+// SubscribeDetectedSlashings subscribes to the slashings found by slasher once they are verified.
+func (s *Service) SubscribeDetectedSlashings(ch chan<- *slashertypes.DetectedSlashing) event.Subscription {
+	return s.detectedSlashingsFeed.Subscribe(ch)
+}
+
+// ValidatorSpans returns the min and max spans stored for a validator index at each
+// epoch from start to end. Spans are only kept for the epochs of the last history
+// length epochs, up to the current epoch.
+func (s *Service) ValidatorSpans(
+	ctx context.Context, validatorIdx primitives.ValidatorIndex, start, end primitives.Epoch,
+) ([]*slashertypes.EpochSpans, error) {
+	currentEpoch := slots.EpochsSinceGenesis(s.genesisTime)
+	oldestEpoch := primitives.Epoch(0)
+	if currentEpoch >= s.params.historyLength {
+		oldestEpoch = currentEpoch - s.params.historyLength + 1
+	}
+	if end < start || start < oldestEpoch || end > currentEpoch {
+		return nil, errors.Wrapf(ErrSpansOutOfRange, "spans are kept for epochs %d to %d", oldestEpoch, currentEpoch)
+	}
+	spans := make([]*slashertypes.EpochSpans, 0, end-start+1)
+	chunkIndices := make([]uint64, 0)
+	for epoch := start; epoch <= end; epoch++ {
+		spans = append(spans, &slashertypes.EpochSpans{Epoch: epoch})
+		chunkIdx := s.params.chunkIndex(epoch)
+		if len(chunkIndices) == 0 || chunkIndices[len(chunkIndices)-1] != chunkIdx {
+			chunkIndices = append(chunkIndices, chunkIdx)
+		}
+	}
+	for _, kind := range []slashertypes.ChunkKind{slashertypes.MinSpan, slashertypes.MaxSpan} {
+		chunks, err := s.loadChunks(ctx, &chunkUpdateArgs{
+			kind:                kind,
+			validatorChunkIndex: s.params.validatorChunkIndex(validatorIdx),
+		}, chunkIndices)
+		if err != nil {
+			return nil, err
+		}
+		for _, span := range spans {
+			distance := chunks[s.params.chunkIndex(span.Epoch)].Chunk()[s.params.cellIndex(validatorIdx, span.Epoch)]
+			if kind == slashertypes.MinSpan {
+				span.MinSpan = distance
+			} else {
+				span.MaxSpan = distance
+			}
+		}
+	}
+	return spans, nil
+}
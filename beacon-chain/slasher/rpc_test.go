@@ -2,6 +2,7 @@ package slasher
 
 import (
 	"context"
+	"math"
 	"testing"
 	"time"
 
@@ -198,3 +199,64 @@ func TestService_HighestAttestations(t *testing.T) {
 		require.DeepEqual(t, &ethpb.HighestAttestation{ValidatorIndex: 1, HighestSourceEpoch: 0, HighestTargetEpoch: 1}, atts[0])
 	})
 }
+
+func TestService_ValidatorSpans(t *testing.T) {
+	ctx := context.Background()
+	slasherDB := dbtest.SetupSlasherDB(t)
+	slasherParams := DefaultParams()
+
+	currentEpoch := primitives.Epoch(20)
+	totalSlots := uint64(currentEpoch) * uint64(params.BeaconConfig().SlotsPerEpoch)
+	secondsSinceGenesis := time.Duration(totalSlots*params.BeaconConfig().SecondsPerSlot) * time.Second
+	s := &Service{
+		serviceCfg: &ServiceConfig{
+			Database: slasherDB,
+		},
+		params:      slasherParams,
+		genesisTime: time.Now().Add(-secondsSinceGenesis),
+	}
+
+	// Validator 1 has a min span at epoch 2 in the first chunk, and a max span at epoch 17 in the second one.
+	validatorIdx := primitives.ValidatorIndex(1)
+	minChunk := EmptyMinSpanChunksSlice(slasherParams)
+	require.NoError(t, setChunkDataAtEpoch(slasherParams, minChunk.Chunk(), validatorIdx, 2, 5))
+	require.NoError(t, slasherDB.SaveSlasherChunks(
+		ctx, slashertypes.MinSpan, [][]byte{slasherParams.flatSliceID(0, 0)}, [][]uint16{minChunk.Chunk()},
+	))
+	maxChunk := EmptyMaxSpanChunksSlice(slasherParams)
+	require.NoError(t, setChunkDataAtEpoch(slasherParams, maxChunk.Chunk(), validatorIdx, 17, 19))
+	require.NoError(t, slasherDB.SaveSlasherChunks(
+		ctx, slashertypes.MaxSpan, [][]byte{slasherParams.flatSliceID(0, 1)}, [][]uint16{maxChunk.Chunk()},
+	))
+
+	spans, err := s.ValidatorSpans(ctx, validatorIdx, 1, currentEpoch)
+	require.NoError(t, err)
+	require.Equal(t, int(currentEpoch), len(spans))
+	for _, sp := range spans {
+		wantMin, wantMax := uint16(math.MaxUint16), uint16(0)
+		switch sp.Epoch {
+		case 2:
+			wantMin = 3
+		case 17:
+			wantMax = 2
+		}
+		assert.Equal(t, wantMin, sp.MinSpan, "epoch %d", sp.Epoch)
+		assert.Equal(t, wantMax, sp.MaxSpan, "epoch %d", sp.Epoch)
+	}
+
+	// Other validators of the same validator chunk are not affected.
+	spans, err = s.ValidatorSpans(ctx, 2, 2, 2)
+	require.NoError(t, err)
+	require.Equal(t, 1, len(spans))
+	assert.Equal(t, uint16(math.MaxUint16), spans[0].MinSpan)
+
+	_, err = s.ValidatorSpans(ctx, validatorIdx, 2, 1)
+	require.ErrorIs(t, err, ErrSpansOutOfRange)
+	_, err = s.ValidatorSpans(ctx, validatorIdx, 0, currentEpoch+1)
+	require.ErrorIs(t, err, ErrSpansOutOfRange)
+	s.params = &Parameters{chunkSize: 16, validatorChunkSize: 256, historyLength: 16}
+	_, err = s.ValidatorSpans(ctx, validatorIdx, 4, currentEpoch)
+	require.ErrorIs(t, err, ErrSpansOutOfRange)
+	_, err = s.ValidatorSpans(ctx, validatorIdx, 5, currentEpoch)
+	require.NoError(t, err)
+}
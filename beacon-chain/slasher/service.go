@@ -10,9 +10,11 @@ import (
 
 	"github.com/prysmaticlabs/prysm/v4/async/event"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain"
+	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	statefeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/state"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/slashings"
+	slashertypes "github.com/prysmaticlabs/prysm/v4/beacon-chain/slasher/types"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state/stategen"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
@@ -37,6 +39,7 @@ type ServiceConfig struct {
 	AttestationStateFetcher blockchain.AttestationStateFetcher
 	StateGen                stategen.StateManager
 	SlashingPoolInserter    slashings.PoolInserter
+	OperationNotifier       opfeed.Notifier
 	HeadStateFetcher        blockchain.HeadFetcher
 	SyncChecker             sync.Checker
 	ClockWaiter             startup.ClockWaiter
@@ -51,6 +54,18 @@ type SlashingChecker interface {
 	) ([]*ethpb.HighestAttestation, error)
 }
 
+// SlashingInspector is an interface for services exposing the slashings found by slasher
+// and the min and max spans it uses for slashing detection.
+type SlashingInspector interface {
+	DetectedSlashings(
+		ctx context.Context, pageToken []byte, limit int, filter func(*slashertypes.DetectedSlashing) bool,
+	) ([]*slashertypes.DetectedSlashing, []byte, error)
+	SubscribeDetectedSlashings(ch chan<- *slashertypes.DetectedSlashing) event.Subscription
+	ValidatorSpans(
+		ctx context.Context, validatorIdx primitives.ValidatorIndex, start, end primitives.Epoch,
+	) ([]*slashertypes.EpochSpans, error)
+}
+
 // Service defining a slasher implementation as part of
 // the beacon node, able to detect eth2 slashable offenses.
 type Service struct {
@@ -67,6 +82,7 @@ type Service struct {
 	blocksSlotTicker               *slots.SlotTicker
 	pruningSlotTicker              *slots.SlotTicker
 	latestEpochWrittenForValidator map[primitives.ValidatorIndex]primitives.Epoch
+	detectedSlashingsFeed          event.Feed
 }
 
 // New instantiates a new slasher from configuration values.
@@ -1,6 +1,8 @@
 package types
 
 import (
+	"time"
+
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
@@ -51,3 +53,19 @@ type AttestedEpochForValidator struct {
 	ValidatorIndex primitives.ValidatorIndex
 	Epoch          primitives.Epoch
 }
+
+// DetectedSlashing is a slashable offense found by slasher, along with
+// the time it was detected at. Only one of the slashings is set.
+type DetectedSlashing struct {
+	DetectedAt       time.Time
+	AttesterSlashing *ethpb.AttesterSlashing
+	ProposerSlashing *ethpb.ProposerSlashing
+}
+
+// EpochSpans contains the min and max span distances stored
+// by slasher for a validator at an epoch.
+type EpochSpans struct {
+	Epoch   primitives.Epoch
+	MinSpan uint16
+	MaxSpan uint16
+}
@@ -266,6 +266,10 @@ func (s *State) latestAncestor(ctx context.Context, blockRoot [32]byte) (state.B
 	if err := blocks.BeaconBlockIsNil(b); err != nil {
 		return nil, err
 	}
+	earliest, err := s.beaconDB.EarliestAvailableSlot(ctx)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not retrieve earliest available slot")
+	}
 
 	for {
 		if ctx.Err() != nil {
@@ -309,6 +313,10 @@ func (s *State) latestAncestor(ctx context.Context, blockRoot [32]byte) (state.B
 			return s, errors.Wrap(err, "failed to retrieve state from db")
 		}
 
+		// Return an error if the parent block has been pruned.
+		if ps < earliest {
+			return nil, errors.Wrapf(ErrNoDataForSlot, "slot %d has been pruned from db", ps)
+		}
 		b, err = s.beaconDB.Block(ctx, parentRoot)
 		if err != nil {
 			return nil, errors.Wrap(err, "failed to retrieve block from db")
@@ -49,6 +49,13 @@ func (c *CanonicalHistory) BlockRootForSlot(ctx context.Context, target primitiv
 	if currentSlot := c.cs.CurrentSlot(); target > currentSlot {
 		return [32]byte{}, errors.Wrap(ErrFutureSlotRequested, fmt.Sprintf("requested=%d, current=%d", target, currentSlot))
 	}
+	earliest, err := c.h.EarliestAvailableSlot(ctx)
+	if err != nil {
+		return [32]byte{}, errors.Wrap(err, "could not retrieve earliest available slot")
+	}
+	if target > params.BeaconConfig().GenesisSlot && target < earliest {
+		return [32]byte{}, errors.Wrap(ErrNoDataForSlot, fmt.Sprintf("slot %d has been pruned from db, earliest available slot is %d", target, earliest))
+	}
 
 	slotAbove := target + 1
 	// don't bother searching for candidate roots when we know the target slot is genesis
@@ -31,6 +31,27 @@ func TestChainForSlotFuture(t *testing.T) {
 	require.ErrorIs(t, err, ErrFutureSlotRequested)
 }
 
+func TestBlockRootForSlotPruned(t *testing.T) {
+	ctx := context.Background()
+	var begin, end primitives.Slot = 100, 155
+	specs := []mockHistorySpec{
+		{slot: begin, savedState: true, canonicalBlock: true},
+		{slot: end, canonicalBlock: true},
+	}
+	hist := newMockHistory(t, specs, end+1)
+	hist.earliest = begin
+	ch := &CanonicalHistory{h: hist, cc: hist, cs: hist}
+
+	_, err := ch.BlockRootForSlot(ctx, begin-1)
+	require.ErrorIs(t, err, ErrNoDataForSlot)
+	r, err := ch.BlockRootForSlot(ctx, begin)
+	require.NoError(t, err)
+	require.Equal(t, hist.slotMap[begin], r)
+	r, err = ch.BlockRootForSlot(ctx, 0)
+	require.NoError(t, err)
+	require.Equal(t, hist.slotMap[0], r)
+}
+
 func TestBestForSlot(t *testing.T) {
 	derp := errors.New("fake hash tree root method no hash good")
 	var goodHTR [32]byte
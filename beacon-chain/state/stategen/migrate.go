@@ -5,7 +5,11 @@ import (
 	"encoding/hex"
 	"fmt"
 
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	"github.com/sirupsen/logrus"
 	"go.opencensus.io/trace"
@@ -45,56 +49,15 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 		}
 
 		if slot%s.slotsPerArchivedPoint == 0 && slot != 0 {
-			cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
+			aRoot, aState, err := s.migratedState(ctx, slot)
 			if err != nil {
-				return fmt.Errorf("could not get epoch boundary state for slot %d", slot)
-			}
-
-			var aRoot [32]byte
-			var aState state.BeaconState
-
-			// When the epoch boundary state is not in cache due to skip slot scenario,
-			// we have to regenerate the state which will represent epoch boundary.
-			// By finding the highest available block below epoch boundary slot, we
-			// generate the state for that block root.
-			if exists {
-				aRoot = cached.root
-				aState = cached.state
-			} else {
-				_, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, slot)
-				if err != nil {
-					return err
-				}
-				// Given the block has been finalized, the db should not have more than one block in a given slot.
-				// We should error out when this happens.
-				if len(roots) != 1 {
-					return errUnknownBlock
-				}
-				aRoot = roots[0]
-				// There's no need to generate the state if the state already exists in the DB.
-				// We can skip saving the state.
-				if !s.beaconDB.HasState(ctx, aRoot) {
-					aState, err = s.StateByRoot(ctx, aRoot)
-					if err != nil {
-						return err
-					}
-				}
+				return err
 			}
+			// The state diffs following the archived point are saved against its state.
+			s.stateDiffBase = aRoot
 
 			if s.beaconDB.HasState(ctx, aRoot) {
-				// If you are migrating a state and its already part of the hot state cache saved to the db,
-				// you can just remove it from the hot state cache as it becomes redundant.
-				s.saveHotStateDB.lock.Lock()
-				roots := s.saveHotStateDB.blockRootsOfSavedStates
-				for i := 0; i < len(roots); i++ {
-					if aRoot == roots[i] {
-						s.saveHotStateDB.blockRootsOfSavedStates = append(roots[:i], roots[i+1:]...)
-						// There shouldn't be duplicated roots in `blockRootsOfSavedStates`.
-						// Break here is ok.
-						break
-					}
-				}
-				s.saveHotStateDB.lock.Unlock()
+				s.keepHotStateInDB(aRoot)
 				continue
 			}
 
@@ -106,6 +69,10 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 					"slot": aState.Slot(),
 					"root": hex.EncodeToString(bytesutil.Trunc(aRoot[:])),
 				}).Info("Saved state in DB")
+		} else if s.saveStateDiffs && slot%params.BeaconConfig().SlotsPerEpoch == 0 && slot != 0 {
+			if err := s.migrateStateDiff(ctx, slot); err != nil {
+				return err
+			}
 		}
 	}
 
@@ -120,3 +87,100 @@ func (s *State) MigrateToCold(ctx context.Context, fRoot [32]byte) error {
 
 	return nil
 }
+
+// migratedState returns the block root and the state of the epoch boundary slot being migrated. The state is nil
+// when it does not need to be regenerated because it is already saved in the DB.
+func (s *State) migratedState(ctx context.Context, slot primitives.Slot) ([32]byte, state.BeaconState, error) {
+	cached, exists, err := s.epochBoundaryStateCache.getBySlot(slot)
+	if err != nil {
+		return [32]byte{}, nil, fmt.Errorf("could not get epoch boundary state for slot %d", slot)
+	}
+	if exists {
+		return cached.root, cached.state, nil
+	}
+
+	// When the epoch boundary state is not in cache due to skip slot scenario,
+	// we have to regenerate the state which will represent epoch boundary.
+	// By finding the highest available block below epoch boundary slot, we
+	// generate the state for that block root.
+	_, roots, err := s.beaconDB.HighestRootsBelowSlot(ctx, slot)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	// Given the block has been finalized, the db should not have more than one block in a given slot.
+	// We should error out when this happens.
+	if len(roots) != 1 {
+		return [32]byte{}, nil, errUnknownBlock
+	}
+	aRoot := roots[0]
+	// There's no need to generate the state if the state already exists in the DB.
+	// We can skip saving the state.
+	if s.beaconDB.HasState(ctx, aRoot) {
+		return aRoot, nil, nil
+	}
+	aState, err := s.StateByRoot(ctx, aRoot)
+	if err != nil {
+		return [32]byte{}, nil, err
+	}
+	return aRoot, aState, nil
+}
+
+// keepHotStateInDB is used when migrating a state which is already part of the hot states saved to the db: the state
+// is removed from the hot states so that it is not deleted with them.
+func (s *State) keepHotStateInDB(root [32]byte) {
+	s.saveHotStateDB.lock.Lock()
+	defer s.saveHotStateDB.lock.Unlock()
+	roots := s.saveHotStateDB.blockRootsOfSavedStates
+	for i := 0; i < len(roots); i++ {
+		if root == roots[i] {
+			s.saveHotStateDB.blockRootsOfSavedStates = append(roots[:i], roots[i+1:]...)
+			// There shouldn't be duplicated roots in `blockRootsOfSavedStates`.
+			// Break here is ok.
+			break
+		}
+	}
+}
+
+// migrateStateDiff saves the state of an epoch boundary slot in between two archived points as a diff from the state
+// of the last archived point. The state is saved in full, and the next diffs are saved against it, when there is no
+// full state of the same fork to diff against, which happens after a fork or when the node starts saving diffs.
+func (s *State) migrateStateDiff(ctx context.Context, slot primitives.Slot) error {
+	aRoot, aState, err := s.migratedState(ctx, slot)
+	if err != nil {
+		return err
+	}
+	if s.beaconDB.HasState(ctx, aRoot) {
+		s.keepHotStateInDB(aRoot)
+		return nil
+	}
+
+	if s.stateDiffBase == [32]byte{} {
+		s.stateDiffBase = s.beaconDB.ArchivedPointRoot(ctx, slot-slot%s.slotsPerArchivedPoint)
+	}
+	if s.stateDiffBase != [32]byte{} {
+		err := s.beaconDB.SaveStateDiff(ctx, aState, aRoot, s.stateDiffBase)
+		if err == nil {
+			log.WithFields(
+				logrus.Fields{
+					"slot": aState.Slot(),
+					"root": hex.EncodeToString(bytesutil.Trunc(aRoot[:])),
+					"base": hex.EncodeToString(bytesutil.Trunc(s.stateDiffBase[:])),
+				}).Debug("Saved state diff in DB")
+			return nil
+		}
+		if !errors.Is(err, db.ErrStateDiffBase) {
+			return err
+		}
+	}
+
+	if err := s.beaconDB.SaveState(ctx, aState, aRoot); err != nil {
+		return err
+	}
+	s.stateDiffBase = aRoot
+	log.WithFields(
+		logrus.Fields{
+			"slot": aState.Slot(),
+			"root": hex.EncodeToString(bytesutil.Trunc(aRoot[:])),
+		}).Info("Saved state in DB")
+	return nil
+}
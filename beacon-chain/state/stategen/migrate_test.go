@@ -7,6 +7,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/blocks"
 	testDB "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	consensusblocks "github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
@@ -219,3 +221,44 @@ func TestMigrateToCold_ParallelCalls(t *testing.T) {
 	assert.DeepEqual(t, [][32]byte{r7}, service.saveHotStateDB.blockRootsOfSavedStates, "Did not remove all saved hot state roots")
 	require.LogsContain(t, hook, "Saved state in DB")
 }
+
+func TestMigrateToCold_StateDiffs(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := testDB.SetupDB(t)
+
+	service := New(beaconDB, doublylinkedtree.New(), WithStateDiffs())
+	slotsPerEpoch := params.BeaconConfig().SlotsPerEpoch
+	service.slotsPerArchivedPoint = 2 * slotsPerEpoch
+	beaconState, _ := util.DeterministicGenesisState(t, 32)
+
+	var states []state.BeaconState
+	var roots [][32]byte
+	for i := primitives.Slot(1); i <= 3; i++ {
+		st := beaconState.Copy()
+		require.NoError(t, st.SetSlot(i*slotsPerEpoch))
+		require.NoError(t, st.UpdateBalancesAtIndex(primitives.ValidatorIndex(i), uint64(i)))
+		root := [32]byte{byte(i)}
+		require.NoError(t, service.epochBoundaryStateCache.put(root, st))
+		states = append(states, st)
+		roots = append(roots, root)
+	}
+	b := util.NewBeaconBlock()
+	b.Block.Slot = 3*slotsPerEpoch + 1
+	fRoot, err := b.Block.HashTreeRoot()
+	require.NoError(t, err)
+	util.SaveBlock(t, ctx, service.beaconDB, b)
+	require.NoError(t, service.MigrateToCold(ctx, fRoot))
+
+	for i, root := range roots {
+		require.Equal(t, true, service.beaconDB.HasState(ctx, root))
+		gotState, err := service.beaconDB.State(ctx, root)
+		require.NoError(t, err)
+		assert.DeepSSZEqual(t, states[i].ToProtoUnsafe(), gotState.ToProtoUnsafe())
+	}
+	// Without an archived state to diff against, the first epoch state is saved in full. The state of the archived
+	// point is saved in full and the next epoch state as a diff, which is not an archived point.
+	assert.Equal(t, roots[0], service.beaconDB.ArchivedPointRoot(ctx, slotsPerEpoch))
+	assert.Equal(t, roots[1], service.beaconDB.ArchivedPointRoot(ctx, 2*slotsPerEpoch))
+	assert.Equal(t, [32]byte{}, service.beaconDB.ArchivedPointRoot(ctx, 3*slotsPerEpoch))
+	assert.Equal(t, roots[1], service.stateDiffBase)
+}
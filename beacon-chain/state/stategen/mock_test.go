@@ -81,6 +81,7 @@ type mockHistory struct {
 	states                         map[[32]byte]state.BeaconState
 	hiddenStates                   map[[32]byte]state.BeaconState
 	current                        primitives.Slot
+	earliest                       primitives.Slot
 	overrideHighestSlotBlocksBelow func(context.Context, primitives.Slot) (primitives.Slot, [][32]byte, error)
 }
 
@@ -121,6 +122,10 @@ func (m *mockHistory) HighestRootsBelowSlot(_ context.Context, slot primitives.S
 	return 0, [][32]byte{}, nil
 }
 
+func (m *mockHistory) EarliestAvailableSlot(_ context.Context) (primitives.Slot, error) {
+	return m.earliest, nil
+}
+
 var errGenesisBlockNotFound = errors.New("canonical genesis block not found in db")
 
 func (m *mockHistory) GenesisBlockRoot(_ context.Context) ([32]byte, error) {
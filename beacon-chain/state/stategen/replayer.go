@@ -30,6 +30,7 @@ type HistoryAccessor interface {
 	GenesisBlockRoot(ctx context.Context) ([32]byte, error)
 	Block(ctx context.Context, blockRoot [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error)
 	StateOrError(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+	EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error)
 }
 
 // CanonicalChecker determines whether the given block root is canonical.
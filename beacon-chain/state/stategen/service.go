@@ -12,7 +12,6 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
-	"github.com/prysmaticlabs/prysm/v4/beacon-chain/sync/backfill"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
@@ -50,9 +49,14 @@ type State struct {
 	finalizedInfo           *finalizedInfo
 	epochBoundaryStateCache *epochBoundaryState
 	saveHotStateDB          *saveHotStateDbConfig
-	backfillStatus          *backfill.Status
+	backfillStatus          SlotCoverer
 	migrationLock           *sync.Mutex
 	fc                      forkchoice.ForkChoicer
+	// saveStateDiffs enables saving the epoch boundary states in between the archived points as diffs.
+	saveStateDiffs bool
+	// stateDiffBase is the block root of the full state the state diffs are saved against. It is only accessed
+	// with the migration lock held.
+	stateDiffBase [32]byte
 }
 
 // This tracks the config in the event of long non-finality,
@@ -77,12 +81,26 @@ type finalizedInfo struct {
 // StateGenOption is a functional option for controlling the initialization of a *State value
 type StateGenOption func(*State)
 
-func WithBackfillStatus(bfs *backfill.Status) StateGenOption {
+// SlotCoverer reports whether the block history for a given slot is available in the database.
+// It is implemented by the backfill Status for nodes initialized via checkpoint sync.
+type SlotCoverer interface {
+	SlotCovered(primitives.Slot) bool
+}
+
+func WithBackfillStatus(bfs SlotCoverer) StateGenOption {
 	return func(sg *State) {
 		sg.backfillStatus = bfs
 	}
 }
 
+// WithStateDiffs saves the finalized states of the epoch boundaries in between two archived points as diffs from the
+// last archived state, so that they can be loaded without replaying blocks.
+func WithStateDiffs() StateGenOption {
+	return func(sg *State) {
+		sg.saveStateDiffs = true
+	}
+}
+
 // New returns a new state management object.
 func New(beaconDB db.NoHeadAccessDatabase, fc forkchoice.ForkChoicer, opts ...StateGenOption) *State {
 	s := &State{
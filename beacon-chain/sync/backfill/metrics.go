@@ -0,0 +1,47 @@
+package backfill
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	backfillRemainingSlots = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_remaining_slots",
+			Help: "Number of slots between genesis and the lowest backfilled block that still need to be backfilled.",
+		},
+	)
+	backfillLowestSlot = promauto.NewGauge(
+		prometheus.GaugeOpts{
+			Name: "backfill_lowest_slot",
+			Help: "Slot of the lowest block of the contiguous chain between the backfill position and the checkpoint sync origin.",
+		},
+	)
+	backfillBlocksImported = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "backfill_blocks_imported_total",
+			Help: "Number of historical blocks verified and saved by backfill.",
+		},
+	)
+	backfillBatchesImported = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Name: "backfill_batches_imported_total",
+			Help: "Number of block batches verified and saved by backfill.",
+		},
+	)
+	backfillBatchFailures = promauto.NewCounterVec(
+		prometheus.CounterOpts{
+			Name: "backfill_batch_failures_total",
+			Help: "Number of backfill batches which could not be downloaded or verified, by reason.",
+		},
+		[]string{"reason"},
+	)
+	backfillBatchVerifySeconds = promauto.NewHistogram(
+		prometheus.HistogramOpts{
+			Name:    "backfill_batch_verify_seconds",
+			Help:    "Time spent verifying the parent chain and proposer signatures of a backfill batch.",
+			Buckets: []float64{0.01, 0.05, 0.1, 0.25, 0.5, 1, 2, 5},
+		},
+	)
+)
@@ -0,0 +1,427 @@
+package backfill
+
+import (
+	"context"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/peer"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	prysmsync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/rand"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/runtime"
+	prysmTime "github.com/prysmaticlabs/prysm/v4/time"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/sirupsen/logrus"
+)
+
+var _ runtime.Service = (*Service)(nil)
+
+const (
+	// defaultBatchSize is the number of blocks requested from a single peer at once.
+	defaultBatchSize = 64
+	// peerPollingInterval is how long the service waits before looking for peers again when none are suitable.
+	peerPollingInterval = 5 * time.Second
+	// maxPeersPerBatch caps how many peers are tried for a single batch before picking a new peer set.
+	maxPeersPerBatch = 8
+)
+
+var (
+	errNoPeersAvailable = errors.New("no suitable peers available to backfill from")
+	errHistoryPruned    = errors.New("backfill reached the history pruned from the database")
+)
+
+// BeaconDB describes the set of DB methods that the backfill Service needs to function.
+type BeaconDB interface {
+	BackfillDB
+	SaveBlocks(ctx context.Context, blocks []interfaces.ReadOnlySignedBeaconBlock) error
+	BackfillFinalizedIndex(ctx context.Context, blocks []interfaces.ReadOnlySignedBeaconBlock, finalizedChildRoot [32]byte) error
+	State(ctx context.Context, blockRoot [32]byte) (state.BeaconState, error)
+	EarliestAvailableSlot(ctx context.Context) (primitives.Slot, error)
+}
+
+// ServiceOption represents a functional option for the backfill Service constructor.
+type ServiceOption func(*Service) error
+
+// WithBatchSize sets the number of blocks requested from a peer in a single request.
+// A value of zero keeps the default batch size.
+func WithBatchSize(n uint64) ServiceOption {
+	return func(s *Service) error {
+		if n > 0 {
+			s.batchSize = n
+		}
+		return nil
+	}
+}
+
+// WithBlocksPerSecond limits the download bandwidth of the service to the given number of blocks per second.
+// A value of zero disables backfill.
+func WithBlocksPerSecond(n uint64) ServiceOption {
+	return func(s *Service) error {
+		s.blocksPerSecond = n
+		return nil
+	}
+}
+
+// WithInitialSyncComplete makes the service wait for initial sync to finish before it starts
+// competing with it for peer bandwidth.
+func WithInitialSyncComplete(c chan struct{}) ServiceOption {
+	return func(s *Service) error {
+		s.initialSyncComplete = c
+		return nil
+	}
+}
+
+// lowestBlock tracks the lowest block of the contiguous chain of blocks ending at the origin.
+type lowestBlock struct {
+	slot       primitives.Slot
+	root       [32]byte
+	parentRoot [32]byte
+}
+
+// Service downloads the blocks between genesis and the checkpoint sync origin, working backwards
+// from the origin block. Each batch is checked to be the ancestor chain of the lowest block already
+// in the database and to carry valid proposer signatures before it is saved, so the database always
+// holds a contiguous, verified chain from the backfill position up to the origin.
+type Service struct {
+	ctx                 context.Context
+	cancel              context.CancelFunc
+	db                  BeaconDB
+	su                  *Status
+	p2p                 p2p.P2P
+	cw                  startup.ClockWaiter
+	clock               *startup.Clock
+	initialSyncComplete chan struct{}
+	batchSize           uint64
+	blocksPerSecond     uint64
+	nextRequest         time.Time
+	rand                *rand.Rand
+	verifier            *verifier
+	genesisRoot         [32]byte
+	low                 lowestBlock
+}
+
+// NewService initializes the backfill Service. Like all implementations of the runtime.Service
+// interface, the service does not begin work until Start is called.
+func NewService(ctx context.Context, db BeaconDB, su *Status, p p2p.P2P, cw startup.ClockWaiter, opts ...ServiceOption) (*Service, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	s := &Service{
+		ctx:             ctx,
+		cancel:          cancel,
+		db:              db,
+		su:              su,
+		p2p:             p,
+		cw:              cw,
+		batchSize:       defaultBatchSize,
+		blocksPerSecond: defaultBatchSize,
+		rand:            rand.NewGenerator(),
+	}
+	for _, o := range opts {
+		if err := o(s); err != nil {
+			cancel()
+			return nil, err
+		}
+	}
+	if s.batchSize > params.BeaconNetworkConfig().MaxRequestBlocks {
+		s.batchSize = params.BeaconNetworkConfig().MaxRequestBlocks
+	}
+	return s, nil
+}
+
+// Start runs the backfill loop until the gap between genesis and the origin is closed.
+func (s *Service) Start() {
+	if s.su.Complete() {
+		log.Debug("Node history is complete, exiting backfill service")
+		return
+	}
+	if s.blocksPerSecond == 0 {
+		log.WithField("missingSlots", s.su.EndGap()-s.su.StartGap()).Warn("Backfill is disabled, blocks below the checkpoint sync origin will not be available")
+		return
+	}
+	clock, err := s.cw.WaitForClock(s.ctx)
+	if err != nil {
+		log.WithError(err).Error("Backfill service failed to receive startup event")
+		return
+	}
+	s.clock = clock
+	if s.initialSyncComplete != nil {
+		select {
+		case <-s.ctx.Done():
+			return
+		case <-s.initialSyncComplete:
+		}
+	}
+	if err := s.initialize(s.ctx); err != nil {
+		log.WithError(err).Error("Could not initialize backfill service")
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"lowestSlot": s.low.slot,
+		"originSlot": s.su.OriginSlot(),
+	}).Info("Starting backfill of blocks below the checkpoint sync origin")
+	if err := s.run(s.ctx); err != nil {
+		if errors.Is(s.ctx.Err(), context.Canceled) {
+			return
+		}
+		if errors.Is(err, errHistoryPruned) {
+			log.WithField("lowestSlot", s.low.slot).Info("Backfill stopped at the earliest slot kept by beacon DB pruning")
+			return
+		}
+		log.WithError(err).Error("Backfill service stopped unexpectedly")
+		return
+	}
+	log.Info("Backfill complete, node history is available back to genesis")
+}
+
+// Stop the backfill service.
+func (s *Service) Stop() error {
+	s.cancel()
+	return nil
+}
+
+// Status of the backfill service.
+func (s *Service) Status() error {
+	return nil
+}
+
+// initialize loads the lowest backfilled block, which is where the service resumes after a restart,
+// and the origin state used to look up proposer public keys.
+func (s *Service) initialize(ctx context.Context) error {
+	var err error
+	s.genesisRoot, err = s.db.GenesisBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve genesis block root")
+	}
+	originRoot, err := s.db.OriginCheckpointBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve origin checkpoint block root")
+	}
+	st, err := s.db.State(ctx, originRoot)
+	if err != nil {
+		return errors.Wrapf(err, "could not retrieve origin state for root=%#x", originRoot)
+	}
+	if st == nil || st.IsNil() {
+		return errors.Errorf("origin state for root=%#x not found", originRoot)
+	}
+	s.verifier = newVerifier(st)
+
+	lowRoot, err := s.db.BackfillBlockRoot(ctx)
+	if err != nil {
+		return errors.Wrap(err, "could not retrieve backfill block root")
+	}
+	if lowRoot == s.genesisRoot {
+		lowRoot = originRoot
+	}
+	b, err := s.db.Block(ctx, lowRoot)
+	if err != nil {
+		return errors.Wrapf(err, "could not retrieve lowest backfilled block, root=%#x", lowRoot)
+	}
+	if err := blocks.BeaconBlockIsNil(b); err != nil {
+		return err
+	}
+	s.low = lowestBlock{
+		slot:       b.Block().Slot(),
+		root:       lowRoot,
+		parentRoot: b.Block().ParentRoot(),
+	}
+	s.updateMetrics()
+	return nil
+}
+
+// run downloads batches below the lowest backfilled block until it is linked to genesis, or until it reaches
+// the history deleted by beacon DB pruning, which would only be pruned again.
+func (s *Service) run(ctx context.Context) error {
+	// reqEnd is the exclusive upper bound of the next request. It is only lower than the slot of
+	// the lowest block when peers reported the slots in between to be empty.
+	reqEnd := s.low.slot
+	for !s.linkedToGenesis() {
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+		earliest, err := s.db.EarliestAvailableSlot(ctx)
+		if err != nil {
+			return errors.Wrap(err, "could not retrieve earliest available slot")
+		}
+		if earliest > params.BeaconConfig().GenesisSlot && s.low.slot <= earliest {
+			return errHistoryPruned
+		}
+		if reqEnd <= params.BeaconConfig().GenesisSlot+1 {
+			// Peers claimed every slot down to genesis is empty, but the lowest block does not
+			// descend from genesis. Start over from the lowest verified block.
+			log.WithField("lowestSlot", s.low.slot).Warn("Could not find parent of lowest backfilled block, retrying")
+			backfillBatchFailures.WithLabelValues("unlinked").Inc()
+			reqEnd = s.low.slot
+			if err := s.sleep(ctx, peerPollingInterval); err != nil {
+				return err
+			}
+			continue
+		}
+		start := params.BeaconConfig().GenesisSlot + 1
+		if uint64(reqEnd-start) > s.batchSize {
+			start = reqEnd - primitives.Slot(s.batchSize)
+		}
+		contiguous := reqEnd == s.low.slot
+		imported, err := s.importBatch(ctx, start, reqEnd, contiguous)
+		switch {
+		case err == nil && imported:
+			reqEnd = s.low.slot
+		case err == nil:
+			// No blocks in range, keep looking lower for the parent.
+			reqEnd = start
+		case errors.Is(err, errNoPeersAvailable):
+			log.WithField("slot", start).Debug("Waiting for suitable peers to backfill from")
+			if err := s.sleep(ctx, peerPollingInterval); err != nil {
+				return err
+			}
+		case errors.Is(err, errUnexpectedParent):
+			// The blocks above this range may have been withheld by a previous peer, start over
+			// from the lowest verified block.
+			reqEnd = s.low.slot
+		default:
+			return err
+		}
+	}
+	s.su.MarkComplete()
+	s.updateMetrics()
+	return nil
+}
+
+// importBatch requests the blocks in [start, end) from peers until one of them returns a batch that
+// verifies. It returns true if blocks were saved, or false if the range was found to be empty.
+// Peers are only penalized for a batch that does not link to the lowest block when the range is
+// directly below it, otherwise the mismatch may have been caused by a previous peer.
+func (s *Service) importBatch(ctx context.Context, start, end primitives.Slot, contiguous bool) (bool, error) {
+	pids, err := s.peers()
+	if err != nil {
+		return false, err
+	}
+	req := &ethpb.BeaconBlocksByRangeRequest{
+		StartSlot: start,
+		Count:     uint64(end - start),
+		Step:      1,
+	}
+	for _, pid := range pids {
+		if err := s.waitForBandwidth(ctx, req.Count); err != nil {
+			return false, err
+		}
+		blks, err := prysmsync.SendBeaconBlocksByRangeRequest(ctx, s.clock, s.p2p, pid, req, nil)
+		if err != nil {
+			log.WithError(err).WithField("peer", pid).Debug("Could not request blocks by range")
+			backfillBatchFailures.WithLabelValues("request").Inc()
+			continue
+		}
+		if len(blks) == 0 {
+			return false, nil
+		}
+		verifyStart := prysmTime.Now()
+		vbs, err := s.verifier.verify(blks, s.low.parentRoot)
+		backfillBatchVerifySeconds.Observe(time.Since(verifyStart).Seconds())
+		if err != nil {
+			fields := logrus.Fields{"peer": pid, "start": start, "count": req.Count}
+			if errors.Is(err, errUnexpectedParent) && !contiguous {
+				log.WithError(err).WithFields(fields).Debug("Backfill batch does not link to lowest block")
+				backfillBatchFailures.WithLabelValues("unlinked").Inc()
+				return false, err
+			}
+			log.WithError(err).WithFields(fields).Debug("Peer returned an invalid backfill batch")
+			backfillBatchFailures.WithLabelValues("invalid").Inc()
+			s.p2p.Peers().Scorers().BadResponsesScorer().Increment(pid)
+			continue
+		}
+		if err := s.save(ctx, vbs); err != nil {
+			return false, err
+		}
+		s.p2p.Peers().Scorers().BlockProviderScorer().IncrementProcessedBlocks(pid, uint64(len(vbs)))
+		return true, nil
+	}
+	return false, errNoPeersAvailable
+}
+
+// save writes a verified batch to the database, adds it to the finalized index and moves the backfill
+// position down to the lowest block of the batch.
+func (s *Service) save(ctx context.Context, vbs []verifiedBlock) error {
+	blks := make([]interfaces.ReadOnlySignedBeaconBlock, len(vbs))
+	for i := range vbs {
+		blks[i] = vbs[i].block
+	}
+	if err := s.db.SaveBlocks(ctx, blks); err != nil {
+		return errors.Wrap(err, "could not save backfill blocks")
+	}
+	if err := s.db.BackfillFinalizedIndex(ctx, blks, s.low.root); err != nil {
+		return errors.Wrap(err, "could not update finalized index with backfill blocks")
+	}
+	lowest := vbs[0]
+	if err := s.su.Advance(ctx, lowest.block.Block().Slot(), lowest.root); err != nil {
+		return errors.Wrap(err, "could not update backfill status")
+	}
+	s.low = lowestBlock{
+		slot:       lowest.block.Block().Slot(),
+		root:       lowest.root,
+		parentRoot: lowest.block.Block().ParentRoot(),
+	}
+	backfillBlocksImported.Add(float64(len(vbs)))
+	backfillBatchesImported.Inc()
+	s.updateMetrics()
+	log.WithFields(logrus.Fields{
+		"lowestSlot": s.low.slot,
+		"blocks":     len(vbs),
+	}).Debug("Backfilled batch of blocks")
+	return nil
+}
+
+// peers returns peers which have finalized past the origin, in a random order weighted by their block provider score.
+func (s *Service) peers() ([]peer.ID, error) {
+	_, pids := s.p2p.Peers().BestFinalized(params.BeaconConfig().MaxPeersToSync, slots.ToEpoch(s.su.OriginSlot()))
+	if len(pids) == 0 {
+		return nil, errNoPeersAvailable
+	}
+	pids = s.p2p.Peers().Scorers().BlockProviderScorer().WeightSorted(s.rand, pids, nil)
+	if len(pids) > maxPeersPerBatch {
+		pids = pids[:maxPeersPerBatch]
+	}
+	return pids, nil
+}
+
+// waitForBandwidth blocks until downloading count more blocks keeps the service within its configured
+// number of blocks per second.
+func (s *Service) waitForBandwidth(ctx context.Context, count uint64) error {
+	if err := s.sleep(ctx, time.Until(s.nextRequest)); err != nil {
+		return err
+	}
+	s.nextRequest = prysmTime.Now().Add(time.Duration(count) * time.Second / time.Duration(s.blocksPerSecond))
+	return nil
+}
+
+func (s *Service) sleep(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+func (s *Service) linkedToGenesis() bool {
+	return s.low.parentRoot == s.genesisRoot || s.low.slot <= params.BeaconConfig().GenesisSlot
+}
+
+func (s *Service) updateMetrics() {
+	backfillLowestSlot.Set(float64(s.low.slot))
+	if s.linkedToGenesis() || s.su.Complete() {
+		backfillRemainingSlots.Set(0)
+		return
+	}
+	backfillRemainingSlots.Set(float64(s.low.slot - params.BeaconConfig().GenesisSlot))
+}
@@ -0,0 +1,294 @@
+package backfill
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/p2p/enr"
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	dbtest "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/peers"
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	prysmsync "github.com/prysmaticlabs/prysm/v4/beacon-chain/sync"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+// signedChain builds a chain of signed blocks on top of the given parent, skipping the given slots.
+func signedChain(t *testing.T, st state.ReadOnlyBeaconState, keys []bls.SecretKey, parent [32]byte, upTo primitives.Slot, skip map[primitives.Slot]bool) []interfaces.ReadOnlySignedBeaconBlock {
+	chain := make([]interfaces.ReadOnlySignedBeaconBlock, 0)
+	for slot := primitives.Slot(1); slot <= upTo; slot++ {
+		if skip[slot] {
+			continue
+		}
+		b := util.NewBeaconBlock()
+		b.Block.Slot = slot
+		b.Block.ParentRoot = parent[:]
+		b.Block.ProposerIndex = primitives.ValidatorIndex(uint64(slot) % uint64(len(keys)))
+		fork, err := forks.Fork(slots.ToEpoch(slot))
+		require.NoError(t, err)
+		domain, err := signing.ComputeDomain(params.BeaconConfig().DomainBeaconProposer, fork.CurrentVersion, st.GenesisValidatorsRoot())
+		require.NoError(t, err)
+		sr, err := signing.ComputeSigningRoot(b.Block, domain)
+		require.NoError(t, err)
+		b.Signature = keys[b.Block.ProposerIndex].Sign(sr[:]).Marshal()
+		sb, err := blocks.NewSignedBeaconBlock(b)
+		require.NoError(t, err)
+		parent, err = b.Block.HashTreeRoot()
+		require.NoError(t, err)
+		chain = append(chain, sb)
+	}
+	return chain
+}
+
+func TestVerifier(t *testing.T) {
+	st, keys := util.DeterministicGenesisState(t, 8)
+	genesisRoot := bytesutil.ToBytes32([]byte("genesis"))
+	chain := signedChain(t, st, keys, genesisRoot, 10, nil)
+	v := newVerifier(st)
+	top, err := chain[len(chain)-1].Block().HashTreeRoot()
+	require.NoError(t, err)
+
+	t.Run("valid batch", func(t *testing.T) {
+		vbs, err := v.verify(chain, top)
+		require.NoError(t, err)
+		require.Equal(t, len(chain), len(vbs))
+		assert.Equal(t, primitives.Slot(1), vbs[0].block.Block().Slot())
+		assert.Equal(t, genesisRoot, vbs[0].block.Block().ParentRoot())
+	})
+	t.Run("unexpected top root", func(t *testing.T) {
+		_, err := v.verify(chain, genesisRoot)
+		require.ErrorIs(t, err, errUnexpectedParent)
+	})
+	t.Run("gap in parent chain", func(t *testing.T) {
+		gapped := append([]interfaces.ReadOnlySignedBeaconBlock{}, chain[:4]...)
+		gapped = append(gapped, chain[5:]...)
+		_, err := v.verify(gapped, top)
+		require.ErrorIs(t, err, errUnexpectedParent)
+	})
+	t.Run("bad signature", func(t *testing.T) {
+		bad := signedChain(t, st, keys, genesisRoot, 10, nil)
+		pb, err := bad[3].Proto()
+		require.NoError(t, err)
+		pb.(*ethpb.SignedBeaconBlock).Signature = keys[0].Sign([]byte("not a block")).Marshal()
+		bad[3], err = blocks.NewSignedBeaconBlock(pb)
+		require.NoError(t, err)
+		_, err = v.verify(bad, top)
+		require.ErrorIs(t, err, errInvalidSignatures)
+	})
+	t.Run("unknown proposer", func(t *testing.T) {
+		unknown := signedChain(t, st, keys, genesisRoot, 1, nil)
+		pb, err := unknown[0].Proto()
+		require.NoError(t, err)
+		pb.(*ethpb.SignedBeaconBlock).Block.ProposerIndex = 100
+		unknown[0], err = blocks.NewSignedBeaconBlock(pb)
+		require.NoError(t, err)
+		r, err := unknown[0].Block().HashTreeRoot()
+		require.NoError(t, err)
+		_, err = v.verify(unknown, r)
+		require.ErrorIs(t, err, errProposerOutOfRange)
+	})
+}
+
+func servePeer(t *testing.T, host *p2ptest.TestP2P, chain []interfaces.ReadOnlySignedBeaconBlock, finalized primitives.Epoch) *p2ptest.TestP2P {
+	bySlot := make(map[primitives.Slot]interfaces.ReadOnlySignedBeaconBlock)
+	for _, b := range chain {
+		bySlot[b.Block().Slot()] = b
+	}
+	p := p2ptest.NewTestP2P(t)
+	topic := fmt.Sprintf("%s/ssz_snappy", p2p.RPCBlocksByRangeTopicV1)
+	p.SetStreamHandler(topic, func(stream network.Stream) {
+		defer func() {
+			assert.NoError(t, stream.Close())
+		}()
+		req := &ethpb.BeaconBlocksByRangeRequest{}
+		assert.NoError(t, p.Encoding().DecodeWithMaxLength(stream, req))
+		for s := req.StartSlot; s < req.StartSlot.Add(req.Count); s++ {
+			b, ok := bySlot[s]
+			if !ok {
+				continue
+			}
+			assert.NoError(t, prysmsync.WriteBlockChunk(stream, startup.NewClock(time.Now(), [32]byte{}), p.Encoding(), b))
+		}
+	})
+	p.Connect(host)
+	host.Peers().Add(new(enr.Record), p.PeerID(), nil, network.DirOutbound)
+	host.Peers().SetConnectionState(p.PeerID(), peers.PeerConnected)
+	host.Peers().SetChainState(p.PeerID(), &ethpb.Status{
+		ForkDigest:     params.BeaconConfig().GenesisForkVersion,
+		FinalizedRoot:  bytesutil.PadTo([]byte("finalized_root"), 32),
+		FinalizedEpoch: finalized,
+		HeadRoot:       bytesutil.PadTo([]byte("head_root"), 32),
+		HeadSlot:       params.BeaconConfig().SlotsPerEpoch.Mul(uint64(finalized + 1)),
+	})
+	return p
+}
+
+func saveOrigin(t *testing.T, beaconDB db.HeadAccessDatabase, st state.BeaconState, origin interfaces.ReadOnlySignedBeaconBlock) {
+	sb, err := st.MarshalSSZ()
+	require.NoError(t, err)
+	bb, err := origin.MarshalSSZ()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveOrigin(context.Background(), sb, bb))
+}
+
+func TestService_BackfillToGenesis(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	st, keys := util.DeterministicGenesisState(t, 8)
+
+	gb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	genesisRoot, err := gb.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, gb))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	skip := map[primitives.Slot]bool{1: true, 7: true, 8: true, 9: true, 20: true}
+	chain := signedChain(t, st, keys, genesisRoot, 40, skip)
+	origin := chain[len(chain)-1]
+	saveOrigin(t, beaconDB, st, origin)
+
+	su := NewStatus(beaconDB)
+	require.NoError(t, su.Reload(ctx))
+	require.Equal(t, false, su.Complete())
+	require.Equal(t, false, su.SlotCovered(10))
+
+	host := p2ptest.NewTestP2P(t)
+	servePeer(t, host, chain, slots.ToEpoch(origin.Block().Slot())+1)
+
+	s, err := NewService(ctx, beaconDB, su, host, startup.NewClockSynchronizer(), WithBatchSize(8), WithBlocksPerSecond(1000))
+	require.NoError(t, err)
+	s.clock = startup.NewClock(time.Now(), bytesutil.ToBytes32(st.GenesisValidatorsRoot()))
+	require.NoError(t, s.initialize(ctx))
+	require.Equal(t, origin.Block().Slot(), s.low.slot)
+	require.NoError(t, s.run(ctx))
+
+	require.Equal(t, true, su.Complete())
+	for _, b := range chain {
+		r, err := b.Block().HashTreeRoot()
+		require.NoError(t, err)
+		require.Equal(t, true, beaconDB.HasBlock(ctx, r))
+		require.Equal(t, true, beaconDB.IsFinalizedBlock(ctx, r))
+		require.Equal(t, true, su.SlotCovered(b.Block().Slot()))
+	}
+	lowest, err := chain[0].Block().HashTreeRoot()
+	require.NoError(t, err)
+	bfRoot, err := beaconDB.BackfillBlockRoot(ctx)
+	require.NoError(t, err)
+	require.Equal(t, lowest, bfRoot)
+
+	// A restarted node picks up the completed backfill from the database.
+	reloaded := NewStatus(beaconDB)
+	require.NoError(t, reloaded.Reload(ctx))
+	require.Equal(t, true, reloaded.Complete())
+}
+
+// prunedDB reports history below the given slot as pruned.
+type prunedDB struct {
+	db.HeadAccessDatabase
+	earliest primitives.Slot
+}
+
+func (p *prunedDB) EarliestAvailableSlot(_ context.Context) (primitives.Slot, error) {
+	return p.earliest, nil
+}
+
+func TestService_StopsAtPrunedHistory(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	st, keys := util.DeterministicGenesisState(t, 8)
+
+	gb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	genesisRoot, err := gb.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, gb))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	chain := signedChain(t, st, keys, genesisRoot, 40, nil)
+	origin := chain[len(chain)-1]
+	saveOrigin(t, beaconDB, st, origin)
+
+	su := NewStatus(beaconDB)
+	require.NoError(t, su.Reload(ctx))
+	host := p2ptest.NewTestP2P(t)
+	servePeer(t, host, chain, slots.ToEpoch(origin.Block().Slot())+1)
+
+	s, err := NewService(ctx, &prunedDB{HeadAccessDatabase: beaconDB, earliest: 20}, su, host, startup.NewClockSynchronizer(), WithBatchSize(8), WithBlocksPerSecond(1000))
+	require.NoError(t, err)
+	s.clock = startup.NewClock(time.Now(), bytesutil.ToBytes32(st.GenesisValidatorsRoot()))
+	require.NoError(t, s.initialize(ctx))
+	require.ErrorIs(t, s.run(ctx), errHistoryPruned)
+
+	require.Equal(t, primitives.Slot(16), s.low.slot)
+	require.Equal(t, false, su.Complete())
+	require.Equal(t, primitives.Slot(16), su.EndGap())
+	below, err := chain[14].Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.Equal(t, false, beaconDB.HasBlock(ctx, below))
+}
+
+func TestService_ResumesFromBackfillPosition(t *testing.T) {
+	ctx := context.Background()
+	beaconDB := dbtest.SetupDB(t)
+	st, keys := util.DeterministicGenesisState(t, 8)
+
+	gb, err := blocks.NewSignedBeaconBlock(util.NewBeaconBlock())
+	require.NoError(t, err)
+	genesisRoot, err := gb.Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBlock(ctx, gb))
+	require.NoError(t, beaconDB.SaveGenesisBlockRoot(ctx, genesisRoot))
+
+	chain := signedChain(t, st, keys, genesisRoot, 20, nil)
+	saveOrigin(t, beaconDB, st, chain[len(chain)-1])
+	// Blocks above slot 12 were backfilled before the restart.
+	require.NoError(t, beaconDB.SaveBlocks(ctx, chain[11:]))
+	lowRoot, err := chain[11].Block().HashTreeRoot()
+	require.NoError(t, err)
+	require.NoError(t, beaconDB.SaveBackfillBlockRoot(ctx, lowRoot))
+
+	su := NewStatus(beaconDB)
+	require.NoError(t, su.Reload(ctx))
+	require.Equal(t, primitives.Slot(12), su.EndGap())
+
+	s, err := NewService(ctx, beaconDB, su, p2ptest.NewTestP2P(t), startup.NewClockSynchronizer())
+	require.NoError(t, err)
+	require.NoError(t, s.initialize(ctx))
+	require.Equal(t, primitives.Slot(12), s.low.slot)
+	require.Equal(t, lowRoot, s.low.root)
+	require.Equal(t, chain[11].Block().ParentRoot(), s.low.parentRoot)
+}
+
+func TestService_WaitForBandwidth(t *testing.T) {
+	s := &Service{blocksPerSecond: 100}
+	ctx := context.Background()
+	start := time.Now()
+	require.NoError(t, s.waitForBandwidth(ctx, 10))
+	require.NoError(t, s.waitForBandwidth(ctx, 10))
+	// the second request for 10 blocks must wait for the 100ms the first request was allotted.
+	assert.Equal(t, true, time.Since(start) >= 90*time.Millisecond)
+
+	cctx, cancel := context.WithCancel(ctx)
+	cancel()
+	s.nextRequest = time.Now().Add(time.Hour)
+	require.ErrorIs(t, s.waitForBandwidth(cctx, 10), context.Canceled)
+}
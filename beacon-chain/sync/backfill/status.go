@@ -2,9 +2,11 @@ package backfill
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
@@ -19,12 +21,15 @@ func NewStatus(store BackfillDB) *Status {
 
 // Status provides a way to update and query the status of a backfill process that may be necessary to track when
 // a node was initialized via checkpoint sync. With checkpoint sync, there will be a gap in node history from genesis
-// until the checkpoint sync origin block. Status provides the means to update the value keeping track of the lower
-// end of the missing block range via the Advance() method, to check whether a Slot is missing from the database
-// via the SlotCovered() method, and to see the current StartGap() and EndGap().
+// until the checkpoint sync origin block. Backfill fills that gap backwards, starting from the origin block, so
+// Status keeps track of the upper end of the missing block range via the Advance() method (which moves it down
+// towards genesis), to check whether a Slot is missing from the database via the SlotCovered() method, and to see
+// the current StartGap() and EndGap().
 type Status struct {
+	lock        sync.RWMutex
 	start       primitives.Slot
 	end         primitives.Slot
+	origin      primitives.Slot
 	store       BackfillDB
 	genesisSync bool
 }
@@ -33,11 +38,13 @@ type Status struct {
 // If the slot is <= StartGap(), or >= EndGap(), the result is true.
 // If the slot is between StartGap() and EndGap(), the result is false.
 func (s *Status) SlotCovered(sl primitives.Slot) bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	// short circuit if the node was synced from genesis
 	if s.genesisSync {
 		return true
 	}
-	if s.StartGap() < sl && sl < s.EndGap() {
+	if s.start < sl && sl < s.end {
 		return false
 	}
 	return true
@@ -45,29 +52,65 @@ func (s *Status) SlotCovered(sl primitives.Slot) bool {
 
 // StartGap returns the slot at the beginning of the range that needs to be backfilled.
 func (s *Status) StartGap() primitives.Slot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	return s.start
 }
 
 // EndGap returns the slot at the end of the range that needs to be backfilled.
 func (s *Status) EndGap() primitives.Slot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
 	return s.end
 }
 
+// OriginSlot returns the slot of the checkpoint sync origin block, ie the point where backfill begins.
+func (s *Status) OriginSlot() primitives.Slot {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.origin
+}
+
+// Complete returns true if there is no gap left to backfill, either because the node was synced
+// from genesis or because backfill has linked the origin block all the way back to genesis.
+func (s *Status) Complete() bool {
+	s.lock.RLock()
+	defer s.lock.RUnlock()
+	return s.genesisSync || s.end <= s.start+1
+}
+
 var ErrAdvancePastOrigin = errors.New("cannot advance backfill Status beyond the origin checkpoint slot")
 
-// Advance advances the backfill position to the given slot & root.
+// Advance advances the backfill position to the given slot & root, which must be the lowest block
+// of the contiguous chain of blocks between the origin checkpoint and the backfill position.
 // It updates the backfill block root entry in the database,
 // and also updates the Status value's copy of the backfill position slot.
 func (s *Status) Advance(ctx context.Context, upTo primitives.Slot, root [32]byte) error {
-	if upTo > s.end {
-		return errors.Wrapf(ErrAdvancePastOrigin, "advance slot=%d, origin slot=%d", upTo, s.end)
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	if upTo > s.origin {
+		return errors.Wrapf(ErrAdvancePastOrigin, "advance slot=%d, origin slot=%d", upTo, s.origin)
+	}
+	if err := s.store.SaveBackfillBlockRoot(ctx, root); err != nil {
+		return err
 	}
-	s.start = upTo
-	return s.store.SaveBackfillBlockRoot(ctx, root)
+	s.end = upTo
+	return nil
+}
+
+// MarkComplete closes the backfill gap once the block at the backfill position has been found to
+// be a direct descendant of the genesis block. Any slots between genesis and that block are
+// skipped slots and are therefore considered to be covered.
+func (s *Status) MarkComplete() {
+	s.lock.Lock()
+	defer s.lock.Unlock()
+	s.end = s.start
 }
 
 // Reload queries the database for backfill status, initializing the internal data and validating the database state.
 func (s *Status) Reload(ctx context.Context) error {
+	s.lock.Lock()
+	defer s.lock.Unlock()
 	cpRoot, err := s.store.OriginCheckpointBlockRoot(ctx)
 	if err != nil {
 		// mark genesis sync and short circuit further lookups
@@ -84,9 +127,10 @@ func (s *Status) Reload(ctx context.Context) error {
 	if err := blocks.BeaconBlockIsNil(cpBlock); err != nil {
 		return err
 	}
-	s.end = cpBlock.Block().Slot()
+	s.origin = cpBlock.Block().Slot()
+	s.start = params.BeaconConfig().GenesisSlot
 
-	_, err = s.store.GenesisBlockRoot(ctx)
+	genesisRoot, err := s.store.GenesisBlockRoot(ctx)
 	if err != nil {
 		if errors.Is(err, db.ErrNotFoundGenesisBlockRoot) {
 			return errors.Wrap(err, "genesis block root required for checkpoint sync")
@@ -101,6 +145,12 @@ func (s *Status) Reload(ctx context.Context) error {
 		}
 		return err
 	}
+	// Databases initialized before backfill was implemented record the genesis block root as the
+	// backfill position. Nothing below the origin block has been downloaded in that case.
+	if bfRoot == genesisRoot {
+		s.end = s.origin
+		return nil
+	}
 	bfBlock, err := s.store.Block(ctx, bfRoot)
 	if err != nil {
 		return errors.Wrapf(err, "error retrieving block for backfill root=%#x", bfRoot)
@@ -108,7 +158,10 @@ func (s *Status) Reload(ctx context.Context) error {
 	if err := blocks.BeaconBlockIsNil(bfBlock); err != nil {
 		return err
 	}
-	s.start = bfBlock.Block().Slot()
+	s.end = bfBlock.Block().Slot()
+	if bfBlock.Block().ParentRoot() == genesisRoot {
+		s.end = s.start
+	}
 	return nil
 }
 
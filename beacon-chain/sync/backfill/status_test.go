@@ -121,19 +121,26 @@ func TestAdvance(t *testing.T) {
 			return nil
 		},
 	}
-	s := &Status{end: 100, store: mdb}
+	s := &Status{end: 100, origin: 100, store: mdb}
 	var root [32]byte
 	copy(root[:], []byte{0x23, 0x23})
 	require.NoError(t, s.Advance(ctx, 90, root))
 	require.Equal(t, root, saveBackfillBuf[0])
-	not := s.SlotCovered(95)
-	require.Equal(t, false, not)
+	// backfill moves towards genesis, so the slots between the new position and the origin are covered.
+	require.Equal(t, true, s.SlotCovered(95))
+	require.Equal(t, false, s.SlotCovered(89))
+	require.Equal(t, primitives.Slot(90), s.EndGap())
+	require.Equal(t, false, s.Complete())
 
 	// this should still be len 1 after failing to advance
 	require.Equal(t, 1, len(saveBackfillBuf))
-	require.ErrorIs(t, s.Advance(ctx, s.end+1, root), ErrAdvancePastOrigin)
+	require.ErrorIs(t, s.Advance(ctx, s.origin+1, root), ErrAdvancePastOrigin)
 	// this has an element in it from the previous test, there shouldn't be an additional one
 	require.Equal(t, 1, len(saveBackfillBuf))
+
+	s.MarkComplete()
+	require.Equal(t, true, s.Complete())
+	require.Equal(t, true, s.SlotCovered(89))
 }
 
 func goodBlockRoot(root [32]byte) func(ctx context.Context) ([32]byte, error) {
@@ -142,12 +149,16 @@ func goodBlockRoot(root [32]byte) func(ctx context.Context) ([32]byte, error) {
 	}
 }
 
-func setupTestBlock(slot primitives.Slot) (interfaces.ReadOnlySignedBeaconBlock, error) {
+func setupTestBlock(slot primitives.Slot, parentRoot [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
 	bRaw := util.NewBeaconBlock()
 	b, err := blocks.NewSignedBeaconBlock(bRaw)
 	if err != nil {
 		return nil, err
 	}
+	b, err = blocktest.SetBlockParentRoot(b, parentRoot)
+	if err != nil {
+		return nil, err
+	}
 	return blocktest.SetBlockSlot(b, slot)
 }
 
@@ -158,13 +169,23 @@ func TestReload(t *testing.T) {
 	originSlot := primitives.Slot(100)
 	var originRoot [32]byte
 	copy(originRoot[:], []byte{0x01})
-	originBlock, err := setupTestBlock(originSlot)
+	var backfillRoot [32]byte
+	copy(backfillRoot[:], []byte{0x02})
+	var backfillParentRoot [32]byte
+	copy(backfillParentRoot[:], []byte{0x03})
+	var linkedRoot [32]byte
+	copy(linkedRoot[:], []byte{0x04})
+	genesisRoot := params.BeaconConfig().ZeroHash
+
+	originBlock, err := setupTestBlock(originSlot, backfillRoot)
 	require.NoError(t, err)
 
 	backfillSlot := primitives.Slot(50)
-	var backfillRoot [32]byte
-	copy(originRoot[:], []byte{0x02})
-	backfillBlock, err := setupTestBlock(backfillSlot)
+	backfillBlock, err := setupTestBlock(backfillSlot, backfillParentRoot)
+	require.NoError(t, err)
+
+	linkedSlot := primitives.Slot(3)
+	linkedBlock, err := setupTestBlock(linkedSlot, genesisRoot)
 	require.NoError(t, err)
 
 	cases := []struct {
@@ -320,7 +341,7 @@ func TestReload(t *testing.T) {
 		{
 			name: "complete happy path",
 			db: &mockBackfillDB{
-				genesisBlockRoot:          goodBlockRoot(params.BeaconConfig().ZeroHash),
+				genesisBlockRoot:          goodBlockRoot(genesisRoot),
 				originCheckpointBlockRoot: goodBlockRoot(originRoot),
 				block: func(ctx context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
 					switch root {
@@ -334,7 +355,41 @@ func TestReload(t *testing.T) {
 				backfillBlockRoot: goodBlockRoot(backfillRoot),
 			},
 			err:      derp,
-			expected: &Status{genesisSync: false, start: backfillSlot, end: originSlot},
+			expected: &Status{genesisSync: false, start: 0, end: backfillSlot, origin: originSlot},
+		},
+		{
+			name: "backfill position is the genesis root, nothing backfilled yet",
+			db: &mockBackfillDB{
+				genesisBlockRoot:          goodBlockRoot(genesisRoot),
+				originCheckpointBlockRoot: goodBlockRoot(originRoot),
+				block: func(ctx context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+					switch root {
+					case originRoot:
+						return originBlock, nil
+					}
+					return nil, errors.New("not derp")
+				},
+				backfillBlockRoot: goodBlockRoot(genesisRoot),
+			},
+			expected: &Status{genesisSync: false, start: 0, end: originSlot, origin: originSlot},
+		},
+		{
+			name: "backfill linked to genesis",
+			db: &mockBackfillDB{
+				genesisBlockRoot:          goodBlockRoot(genesisRoot),
+				originCheckpointBlockRoot: goodBlockRoot(originRoot),
+				block: func(ctx context.Context, root [32]byte) (interfaces.ReadOnlySignedBeaconBlock, error) {
+					switch root {
+					case originRoot:
+						return originBlock, nil
+					case linkedRoot:
+						return linkedBlock, nil
+					}
+					return nil, errors.New("not derp")
+				},
+				backfillBlockRoot: goodBlockRoot(linkedRoot),
+			},
+			expected: &Status{genesisSync: false, start: 0, end: 0, origin: originSlot},
 		},
 	}
 
@@ -354,5 +409,6 @@ func TestReload(t *testing.T) {
 		require.Equal(t, c.expected.genesisSync, s.genesisSync)
 		require.Equal(t, c.expected.start, s.start)
 		require.Equal(t, c.expected.end, s.end)
+		require.Equal(t, c.expected.origin, s.origin)
 	}
 }
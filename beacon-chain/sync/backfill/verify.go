@@ -0,0 +1,109 @@
+package backfill
+
+import (
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/interfaces"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/crypto/bls"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+var (
+	errUnexpectedParent   = errors.New("block root does not match the expected parent root")
+	errProposerOutOfRange = errors.New("proposer index is not in the origin state validator registry")
+	errInvalidSignatures  = errors.New("batch contains an invalid proposer signature")
+)
+
+// verifiedBlock is a block whose position in the chain and proposer signature have been checked.
+type verifiedBlock struct {
+	root  [32]byte
+	block interfaces.ReadOnlySignedBeaconBlock
+}
+
+// verifier checks that batches of blocks downloaded during backfill form a chain ending at the
+// lowest block already in the database, and that every block carries a valid proposer signature.
+// Proposer public keys are taken from the origin state: validators are never removed from the
+// registry, so every proposer of a block below the origin is present in it.
+type verifier struct {
+	keys state.ReadOnlyBeaconState
+	gvr  [32]byte
+}
+
+func newVerifier(keys state.ReadOnlyBeaconState) *verifier {
+	return &verifier{
+		keys: keys,
+		gvr:  bytesutil.ToBytes32(keys.GenesisValidatorsRoot()),
+	}
+}
+
+// verify checks a batch of blocks sorted by slot in ascending order. The highest block of the batch
+// must have the given expected root, ie be the parent of the current lowest backfilled block.
+func (v *verifier) verify(blks []interfaces.ReadOnlySignedBeaconBlock, expectedRoot [32]byte) ([]verifiedBlock, error) {
+	vbs := make([]verifiedBlock, len(blks))
+	// walk the batch from the top, since we only know the expected root of the highest block.
+	for i := len(blks) - 1; i >= 0; i-- {
+		if err := blocks.BeaconBlockIsNil(blks[i]); err != nil {
+			return nil, err
+		}
+		root, err := blks[i].Block().HashTreeRoot()
+		if err != nil {
+			return nil, errors.Wrapf(err, "could not compute root of block at slot %d", blks[i].Block().Slot())
+		}
+		if root != expectedRoot {
+			return nil, errors.Wrapf(errUnexpectedParent, "slot=%d, root=%#x, expected=%#x", blks[i].Block().Slot(), root, expectedRoot)
+		}
+		vbs[i] = verifiedBlock{root: root, block: blks[i]}
+		expectedRoot = blks[i].Block().ParentRoot()
+	}
+
+	set := bls.NewSet()
+	for _, vb := range vbs {
+		sb, err := v.signatureBatch(vb.block)
+		if err != nil {
+			return nil, err
+		}
+		set.Join(sb)
+	}
+	if len(set.Signatures) == 0 {
+		return vbs, nil
+	}
+	ok, err := set.Verify()
+	if err != nil {
+		return nil, errors.Wrap(err, "could not verify proposer signatures")
+	}
+	if !ok {
+		return nil, errInvalidSignatures
+	}
+	return vbs, nil
+}
+
+func (v *verifier) signatureBatch(b interfaces.ReadOnlySignedBeaconBlock) (*bls.SignatureBatch, error) {
+	blk := b.Block()
+	proposer := blk.ProposerIndex()
+	if uint64(proposer) >= uint64(v.keys.NumValidators()) {
+		return nil, errors.Wrapf(errProposerOutOfRange, "slot=%d, proposer=%d", blk.Slot(), proposer)
+	}
+	domain, err := v.domain(slots.ToEpoch(blk.Slot()))
+	if err != nil {
+		return nil, err
+	}
+	pub := v.keys.PubkeyAtIndex(proposer)
+	sig := b.Signature()
+	return signing.BlockSignatureBatch(pub[:], sig[:], domain, blk.HashTreeRoot)
+}
+
+// domain computes the proposer signing domain using the fork version scheduled for the given epoch,
+// rather than the fork recorded in the origin state, since backfilled blocks can predate several forks.
+func (v *verifier) domain(epoch primitives.Epoch) ([]byte, error) {
+	fork, err := forks.Fork(epoch)
+	if err != nil {
+		return nil, errors.Wrapf(err, "could not determine fork for epoch %d", epoch)
+	}
+	return signing.ComputeDomain(params.BeaconConfig().DomainBeaconProposer, fork.CurrentVersion, v.gvr[:])
+}
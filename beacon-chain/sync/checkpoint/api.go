@@ -2,28 +2,51 @@ package checkpoint
 
 import (
 	"context"
+	"sync"
 
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/state"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
 	log "github.com/sirupsen/logrus"
 )
 
 // APIInitializer manages initializing the beacon node using checkpoint sync, retrieving the checkpoint state and root
-// from the remote beacon node api.
+// from the remote beacon node apis. The checkpoint is only used if a quorum of the beacon nodes agree on it.
 type APIInitializer struct {
-	c *beacon.Client
+	clients []*beacon.Client
+	quorum  int
 }
 
 // NewAPIInitializer creates an APIInitializer, handling the set up of a beacon node api client
-// using the provided host string.
-func NewAPIInitializer(beaconNodeHost string) (*APIInitializer, error) {
-	c, err := beacon.NewClient(beaconNodeHost)
-	if err != nil {
-		return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", beaconNodeHost)
+// for each of the provided host strings. A quorum of 0 defaults to a majority of the hosts.
+func NewAPIInitializer(beaconNodeHosts []string, quorum int) (*APIInitializer, error) {
+	if len(beaconNodeHosts) == 0 {
+		return nil, errors.New("no checkpoint sync beacon node provided")
+	}
+	if quorum == 0 {
+		quorum = len(beaconNodeHosts)/2 + 1
+	}
+	if quorum < 0 || quorum > len(beaconNodeHosts) {
+		return nil, errors.Errorf("checkpoint sync quorum %d must be between 1 and the number of beacon nodes (%d)", quorum, len(beaconNodeHosts))
+	}
+	seen := make(map[string]bool, len(beaconNodeHosts))
+	clients := make([]*beacon.Client, len(beaconNodeHosts))
+	for i, host := range beaconNodeHosts {
+		c, err := beacon.NewClient(host)
+		if err != nil {
+			return nil, errors.Wrapf(err, "unable to parse beacon node url or hostname - %s", host)
+		}
+		if seen[c.NodeURL()] {
+			return nil, errors.Errorf("checkpoint sync beacon node %s provided more than once", host)
+		}
+		seen[c.NodeURL()] = true
+		clients[i] = c
 	}
-	return &APIInitializer{c: c}, nil
+	return &APIInitializer{clients: clients, quorum: quorum}, nil
 }
 
 // Initialize downloads origin state and block for checkpoint sync and initializes database records to
@@ -38,9 +61,113 @@ func (dl *APIInitializer) Initialize(ctx context.Context, d db.Database) error {
 			return errors.Wrap(err, "error while checking database for origin root")
 		}
 	}
-	od, err := beacon.DownloadFinalizedData(ctx, dl.c)
+	results := downloadFinalizedData(ctx, dl.clients)
+	agreed, err := selectQuorum(results, dl.quorum)
 	if err != nil {
 		return errors.Wrap(err, "Error retrieving checkpoint origin state and block")
 	}
-	return d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes())
+	od := agreed[0].data
+	if err := d.SaveOrigin(ctx, od.StateBytes(), od.BlockBytes()); err != nil {
+		return err
+	}
+	dl.saveDepositSnapshot(ctx, d, agreed, od.State())
+	return nil
+}
+
+// saveDepositSnapshot downloads the deposit snapshots of all the agreeing beacon nodes, so that the deposit tree can be
+// bootstrapped from the snapshot a quorum of them agree on instead of scanning all deposit logs. Failures are not
+// fatal, the node falls back to scanning deposit logs from the deposit contract deployment block.
+func (dl *APIInitializer) saveDepositSnapshot(ctx context.Context, d db.Database, providers []*providerResult, st state.ReadOnlyBeaconState) {
+	snapshots := downloadDepositSnapshots(ctx, providers, st)
+	snapshot, err := selectDepositSnapshot(snapshots, dl.quorum)
+	if err != nil {
+		log.WithError(err).Warn("No valid deposit snapshot available, deposit logs will be scanned from the deposit contract deployment block")
+		return
+	}
+	if err := d.SaveDepositSnapshot(ctx, snapshot); err != nil {
+		log.WithError(err).Warn("Could not save deposit snapshot")
+		return
+	}
+	log.WithField("depositCount", snapshot.DepositCount()).Info("Initialized deposit tree from deposit snapshot")
+}
+
+// depositSnapshotKey identifies the deposit tree of a deposit snapshot and the execution block it was taken at.
+type depositSnapshotKey struct {
+	depositRoot        [32]byte
+	depositCount       uint64
+	executionBlockHash [32]byte
+}
+
+// downloadDepositSnapshots downloads the deposit snapshots of the beacon nodes concurrently. The beacon nodes which
+// failed or served an invalid snapshot are logged and left out.
+func downloadDepositSnapshots(ctx context.Context, providers []*providerResult, st state.ReadOnlyBeaconState) []*depositsnapshot.DepositTreeSnapshot {
+	snapshots := make([]*depositsnapshot.DepositTreeSnapshot, len(providers))
+	var wg sync.WaitGroup
+	for i, p := range providers {
+		wg.Add(1)
+		go func(i int, p *providerResult) {
+			defer wg.Done()
+			snapshot, err := p.client.GetDepositSnapshot(ctx)
+			if err != nil {
+				log.WithError(err).WithField("provider", p.host()).Warn("Could not retrieve deposit snapshot")
+				return
+			}
+			if err := verifyDepositSnapshot(snapshot, st); err != nil {
+				log.WithError(err).WithField("provider", p.host()).Warn("Ignoring invalid deposit snapshot")
+				return
+			}
+			snapshots[i] = snapshot
+		}(i, p)
+	}
+	wg.Wait()
+	valid := make([]*depositsnapshot.DepositTreeSnapshot, 0, len(snapshots))
+	for _, snapshot := range snapshots {
+		if snapshot != nil {
+			valid = append(valid, snapshot)
+		}
+	}
+	return valid
+}
+
+// selectDepositSnapshot returns the deposit snapshot served by the most beacon nodes, if at least quorum beacon nodes
+// agree on its deposit root, deposit count and execution block hash.
+func selectDepositSnapshot(snapshots []*depositsnapshot.DepositTreeSnapshot, quorum int) (*depositsnapshot.DepositTreeSnapshot, error) {
+	support := make(map[depositSnapshotKey]int)
+	var best *depositsnapshot.DepositTreeSnapshot
+	var bestKey depositSnapshotKey
+	for _, snapshot := range snapshots {
+		key := depositSnapshotKey{
+			depositRoot:        snapshot.DepositRoot(),
+			depositCount:       snapshot.DepositCount(),
+			executionBlockHash: snapshot.ExecutionBlockHash(),
+		}
+		support[key]++
+		if best == nil || support[key] > support[bestKey] {
+			best, bestKey = snapshot, key
+		}
+	}
+	if best == nil || support[bestKey] < quorum {
+		return nil, errors.Errorf("required %d beacon nodes to agree on the deposit snapshot, got %d", quorum, support[bestKey])
+	}
+	return best, nil
+}
+
+// verifyDepositSnapshot checks that the deposit snapshot is consistent and is the deposit tree of the eth1 data of the
+// origin state, which does not include any deposits which have not been processed by the origin state. The root of a
+// snapshot of any other deposit count can not be checked without the execution client, so such snapshots are rejected.
+func verifyDepositSnapshot(snapshot *depositsnapshot.DepositTreeSnapshot, st state.ReadOnlyBeaconState) error {
+	if _, err := depositsnapshot.FromSnapshot(*snapshot); err != nil {
+		return err
+	}
+	if snapshot.DepositCount() > st.Eth1DepositIndex() {
+		return errors.Errorf("snapshot deposit count %d is higher than the origin state deposit index %d", snapshot.DepositCount(), st.Eth1DepositIndex())
+	}
+	eth1Data := st.Eth1Data()
+	if snapshot.DepositCount() != eth1Data.DepositCount {
+		return errors.Errorf("snapshot deposit count %d does not match the origin state deposit count %d", snapshot.DepositCount(), eth1Data.DepositCount)
+	}
+	if snapshot.DepositRoot() != bytesutil.ToBytes32(eth1Data.DepositRoot) {
+		return errors.Errorf("snapshot deposit root %#x does not match the origin state deposit root %#x", snapshot.DepositRoot(), eth1Data.DepositRoot)
+	}
+	return nil
 }
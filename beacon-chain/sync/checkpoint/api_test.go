@@ -0,0 +1,67 @@
+package checkpoint
+
+import (
+	"testing"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache/depositsnapshot"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+)
+
+func TestVerifyDepositSnapshot(t *testing.T) {
+	tree := depositsnapshot.New()
+	for i := 0; i < 6; i++ {
+		leaf := make([]byte, 32)
+		leaf[0] = byte(i + 1)
+		require.NoError(t, tree.Insert(leaf, i))
+	}
+	require.NoError(t, tree.Finalize(3, common.Hash{'a'}, 100))
+	snapshot, err := tree.GetSnapshot()
+	require.NoError(t, err)
+	root := snapshot.DepositRoot()
+
+	st, err := util.NewBeaconState()
+	require.NoError(t, err)
+	require.NoError(t, st.SetEth1DepositIndex(4))
+	require.NoError(t, st.SetEth1Data(&ethpb.Eth1Data{DepositCount: 4, DepositRoot: root[:]}))
+	require.NoError(t, verifyDepositSnapshot(&snapshot, st))
+
+	require.NoError(t, st.SetEth1Data(&ethpb.Eth1Data{DepositCount: 4, DepositRoot: make([]byte, 32)}))
+	require.ErrorContains(t, "does not match the origin state deposit root", verifyDepositSnapshot(&snapshot, st))
+
+	require.NoError(t, st.SetEth1Data(&ethpb.Eth1Data{DepositCount: 5, DepositRoot: root[:]}))
+	require.ErrorContains(t, "does not match the origin state deposit count", verifyDepositSnapshot(&snapshot, st))
+
+	require.NoError(t, st.SetEth1DepositIndex(3))
+	require.ErrorContains(t, "is higher than the origin state deposit index", verifyDepositSnapshot(&snapshot, st))
+}
+
+func TestSelectDepositSnapshot(t *testing.T) {
+	newSnapshot := func(count int, blockHash common.Hash) *depositsnapshot.DepositTreeSnapshot {
+		tree := depositsnapshot.New()
+		for i := 0; i < count; i++ {
+			leaf := make([]byte, 32)
+			leaf[0] = byte(i + 1)
+			require.NoError(t, tree.Insert(leaf, i))
+		}
+		require.NoError(t, tree.Finalize(int64(count-1), blockHash, 100))
+		snapshot, err := tree.GetSnapshot()
+		require.NoError(t, err)
+		return &snapshot
+	}
+	a := newSnapshot(4, common.Hash{'a'})
+	b := newSnapshot(4, common.Hash{'b'})
+	c := newSnapshot(3, common.Hash{'a'})
+
+	selected, err := selectDepositSnapshot([]*depositsnapshot.DepositTreeSnapshot{b, a, c, newSnapshot(4, common.Hash{'a'})}, 2)
+	require.NoError(t, err)
+	require.Equal(t, a.ExecutionBlockHash(), selected.ExecutionBlockHash())
+	require.Equal(t, a.DepositCount(), selected.DepositCount())
+
+	_, err = selectDepositSnapshot([]*depositsnapshot.DepositTreeSnapshot{a, b, c}, 2)
+	require.ErrorContains(t, "required 2 beacon nodes to agree on the deposit snapshot, got 1", err)
+	_, err = selectDepositSnapshot(nil, 1)
+	require.ErrorContains(t, "got 0", err)
+}
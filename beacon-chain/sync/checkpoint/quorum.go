@@ -0,0 +1,128 @@
+package checkpoint
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	log "github.com/sirupsen/logrus"
+)
+
+var errNoQuorum = errors.New("checkpoint sync beacon nodes did not reach quorum on the finalized checkpoint")
+
+// originCheckpoint identifies the finalized checkpoint served by a checkpoint sync beacon node.
+type originCheckpoint struct {
+	blockRoot [32]byte
+	stateRoot [32]byte
+	epoch     primitives.Epoch
+}
+
+func (c originCheckpoint) String() string {
+	return fmt.Sprintf("epoch=%d block_root=%#x state_root=%#x", c.epoch, c.blockRoot, c.stateRoot)
+}
+
+// providerResult is the outcome of downloading the finalized checkpoint from a single beacon node.
+type providerResult struct {
+	client     *beacon.Client
+	data       *beacon.OriginData
+	checkpoint originCheckpoint
+	err        error
+}
+
+// host returns the host of the beacon node, leaving out any credentials or paths of its url.
+func (p *providerResult) host() string {
+	return p.client.BaseURL().Host
+}
+
+// downloadFinalizedData downloads the finalized state and block from all beacon nodes concurrently.
+func downloadFinalizedData(ctx context.Context, clients []*beacon.Client) []*providerResult {
+	results := make([]*providerResult, len(clients))
+	var wg sync.WaitGroup
+	for i, c := range clients {
+		wg.Add(1)
+		go func(i int, c *beacon.Client) {
+			defer wg.Done()
+			r := &providerResult{client: c}
+			r.data, r.err = beacon.DownloadFinalizedData(ctx, c)
+			if r.err == nil {
+				r.checkpoint = originCheckpoint{
+					blockRoot: r.data.BlockRoot(),
+					stateRoot: r.data.StateRoot(),
+					epoch:     slots.ToEpoch(r.data.State().Slot()),
+				}
+			}
+			results[i] = r
+		}(i, c)
+	}
+	wg.Wait()
+	return results
+}
+
+// selectQuorum returns the results of the beacon nodes agreeing on the finalized checkpoint served by the most
+// beacon nodes, if at least quorum beacon nodes agree on it. Beacon nodes which failed or disagreed are logged.
+func selectQuorum(results []*providerResult, quorum int) ([]*providerResult, error) {
+	groups := make(map[originCheckpoint][]*providerResult)
+	for _, r := range results {
+		if r.err != nil {
+			log.WithError(r.err).WithField("provider", r.host()).Warn("Could not download checkpoint from checkpoint sync beacon node")
+			continue
+		}
+		groups[r.checkpoint] = append(groups[r.checkpoint], r)
+	}
+	checkpoints := make([]originCheckpoint, 0, len(groups))
+	for c := range groups {
+		checkpoints = append(checkpoints, c)
+	}
+	// Order by support, then by the latest epoch, so that the selection does not depend on map iteration.
+	sort.Slice(checkpoints, func(i, j int) bool {
+		a, b := checkpoints[i], checkpoints[j]
+		if len(groups[a]) != len(groups[b]) {
+			return len(groups[a]) > len(groups[b])
+		}
+		return a.epoch > b.epoch
+	})
+	if len(checkpoints) == 0 || len(groups[checkpoints[0]]) < quorum {
+		descriptions := make([]string, len(checkpoints))
+		for i, c := range checkpoints {
+			descriptions[i] = fmt.Sprintf("%s served by [%s]", c, hosts(groups[c]))
+		}
+		return nil, errors.Wrapf(errNoQuorum, "required %d of %d beacon nodes to agree, got: %s", quorum, len(results), strings.Join(descriptions, "; "))
+	}
+
+	agreed := checkpoints[0]
+	for _, c := range checkpoints[1:] {
+		for _, r := range groups[c] {
+			log.WithFields(log.Fields{
+				"provider":        r.host(),
+				"epoch":           c.epoch,
+				"blockRoot":       fmt.Sprintf("%#x", c.blockRoot),
+				"stateRoot":       fmt.Sprintf("%#x", c.stateRoot),
+				"agreedEpoch":     agreed.epoch,
+				"agreedBlockRoot": fmt.Sprintf("%#x", agreed.blockRoot),
+				"agreedStateRoot": fmt.Sprintf("%#x", agreed.stateRoot),
+			}).Warn("Checkpoint sync beacon node disagrees with the quorum")
+		}
+	}
+	log.WithFields(log.Fields{
+		"epoch":     agreed.epoch,
+		"blockRoot": fmt.Sprintf("%#x", agreed.blockRoot),
+		"stateRoot": fmt.Sprintf("%#x", agreed.stateRoot),
+		"providers": hosts(groups[agreed]),
+		"quorum":    fmt.Sprintf("%d/%d", len(groups[agreed]), len(results)),
+	}).Info("Checkpoint sync beacon nodes reached quorum on the finalized checkpoint")
+	return groups[agreed], nil
+}
+
+func hosts(results []*providerResult) string {
+	h := make([]string, len(results))
+	for i, r := range results {
+		h[i] = r.host()
+	}
+	return strings.Join(h, ", ")
+}
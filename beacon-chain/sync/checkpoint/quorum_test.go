@@ -0,0 +1,72 @@
+package checkpoint
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/api/client/beacon"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestNewAPIInitializer(t *testing.T) {
+	_, err := NewAPIInitializer(nil, 0)
+	require.ErrorContains(t, "no checkpoint sync beacon node provided", err)
+
+	dl, err := NewAPIInitializer([]string{"http://a.example:3500", "http://b.example:3500", "http://c.example:3500"}, 0)
+	require.NoError(t, err)
+	assert.Equal(t, 2, dl.quorum, "quorum should default to a majority")
+	assert.Equal(t, 3, len(dl.clients))
+
+	_, err = NewAPIInitializer([]string{"http://a.example:3500"}, 2)
+	require.ErrorContains(t, "must be between 1 and the number of beacon nodes", err)
+	_, err = NewAPIInitializer([]string{"http://a.example:3500", "http://a.example:3500"}, 1)
+	require.ErrorContains(t, "provided more than once", err)
+}
+
+func TestSelectQuorum(t *testing.T) {
+	result := func(host string, c originCheckpoint, err error) *providerResult {
+		client, cErr := beacon.NewClient(host)
+		require.NoError(t, cErr)
+		return &providerResult{client: client, checkpoint: c, err: err}
+	}
+	honest := originCheckpoint{blockRoot: [32]byte{'a'}, stateRoot: [32]byte{'b'}, epoch: 100}
+	fake := originCheckpoint{blockRoot: [32]byte{'c'}, stateRoot: [32]byte{'d'}, epoch: 200}
+
+	t.Run("quorum reached", func(t *testing.T) {
+		hook := logTest.NewGlobal()
+		results := []*providerResult{
+			result("http://a.example:3500", honest, nil),
+			result("http://b.example:3500", fake, nil),
+			result("http://c.example:3500", honest, nil),
+			result("http://d.example:3500", originCheckpoint{}, errors.New("connection refused")),
+		}
+		agreed, err := selectQuorum(results, 2)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(agreed))
+		assert.Equal(t, honest, agreed[0].checkpoint)
+		assert.Equal(t, honest, agreed[1].checkpoint)
+		assert.LogsContain(t, hook, "Checkpoint sync beacon node disagrees with the quorum")
+		assert.LogsContain(t, hook, "b.example:3500")
+		assert.LogsContain(t, hook, "Could not download checkpoint from checkpoint sync beacon node")
+	})
+	t.Run("no quorum", func(t *testing.T) {
+		results := []*providerResult{
+			result("http://a.example:3500", honest, nil),
+			result("http://b.example:3500", fake, nil),
+			result("http://c.example:3500", originCheckpoint{}, errors.New("connection refused")),
+		}
+		_, err := selectQuorum(results, 2)
+		require.ErrorIs(t, err, errNoQuorum)
+		require.ErrorContains(t, "a.example:3500", err)
+		require.ErrorContains(t, "b.example:3500", err)
+	})
+	t.Run("all failed", func(t *testing.T) {
+		results := []*providerResult{
+			result("http://a.example:3500", originCheckpoint{}, errors.New("connection refused")),
+		}
+		_, err := selectQuorum(results, 1)
+		require.ErrorIs(t, err, errNoQuorum)
+	})
+}
@@ -38,6 +38,18 @@ func (s *Service) beaconBlocksByRangeRPCHandler(ctx context.Context, msg interfa
 		return err
 	}
 
+	// Blocks below the earliest available slot were pruned, an empty response would read as skipped slots.
+	earliest, err := s.cfg.beaconDB.EarliestAvailableSlot(ctx)
+	if err != nil {
+		s.writeErrorResponseToStream(responseCodeServerError, p2ptypes.ErrGeneric.Error(), stream)
+		tracing.AnnotateError(span, err)
+		return err
+	}
+	if earliest > 0 && rp.start < earliest {
+		s.writeErrorResponseToStream(responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+		return nil
+	}
+
 	blockLimiter, err := s.rateLimiter.topicCollector(string(stream.Protocol()))
 	if err != nil {
 		return err
@@ -410,6 +410,61 @@ func TestRPCBeaconBlocksByRange_ReturnsGenesisBlock(t *testing.T) {
 	}
 }
 
+func TestRPCBeaconBlocksByRange_PrunedRange(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	d := db.SetupDB(t)
+	ctx := context.Background()
+
+	var prevRoot [32]byte
+	for i := primitives.Slot(0); i <= 64; i++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = i
+		blk.Block.ParentRoot = prevRoot[:]
+		rt, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		if i == 0 {
+			require.NoError(t, d.SaveGenesisBlockRoot(ctx, rt))
+		}
+		util.SaveBlock(t, ctx, d, blk)
+		if i%32 == 0 {
+			st, err := util.NewBeaconState()
+			require.NoError(t, err)
+			require.NoError(t, st.SetSlot(i))
+			require.NoError(t, d.SaveState(ctx, st, rt))
+		}
+		prevRoot = rt
+	}
+	require.NoError(t, d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: prevRoot[:]}))
+	earliest, err := d.DeleteHistoricalDataBeforeSlot(ctx, 32, 1000)
+	require.NoError(t, err)
+	require.Equal(t, primitives.Slot(32), earliest)
+
+	clock := startup.NewClock(time.Unix(0, 0), [32]byte{})
+	r := &Service{cfg: &config{p2p: p1, beaconDB: d, clock: clock, chain: &chainMock.ChainService{}}, rateLimiter: newRateLimiter(p1)}
+	pcl := protocol.ID(p2p.RPCBlocksByRangeTopicV1)
+	topic := string(pcl)
+	r.rateLimiter.limiterMap[topic] = leakybucket.NewCollector(10000, 10000, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, p2ptypes.ErrResourceUnavailable.Error(), stream)
+	})
+
+	stream1, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := &ethpb.BeaconBlocksByRangeRequest{StartSlot: 10, Step: 1, Count: 4}
+	require.NoError(t, r.beaconBlocksByRangeRPCHandler(ctx, req, stream1))
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
 func TestRPCBeaconBlocksByRange_RPCHandlerRateLimitOverflow(t *testing.T) {
 	d := db.SetupDB(t)
 	saveBlocks := func(req *ethpb.BeaconBlocksByRangeRequest) {
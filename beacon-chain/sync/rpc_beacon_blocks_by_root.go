@@ -72,6 +72,7 @@ func (s *Service) beaconBlocksRootRPCHandler(ctx context.Context, msg interface{
 			return err
 		}
 		if err := blocks.BeaconBlockIsNil(blk); err != nil {
+			// Unknown roots, including those of pruned blocks, are skipped so the remaining blocks are still served.
 			continue
 		}
 
@@ -81,6 +81,68 @@ func TestRecentBeaconBlocksRPCHandler_ReturnsBlocks(t *testing.T) {
 	}
 }
 
+func TestRecentBeaconBlocksRPCHandler_PrunedHistory(t *testing.T) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	d := db.SetupDB(t)
+	ctx := context.Background()
+
+	var prevRoot [32]byte
+	roots := make([][32]byte, 0, 65)
+	for i := primitives.Slot(0); i <= 64; i++ {
+		blk := util.NewBeaconBlock()
+		blk.Block.Slot = i
+		blk.Block.ParentRoot = prevRoot[:]
+		root, err := blk.Block.HashTreeRoot()
+		require.NoError(t, err)
+		if i == 0 {
+			require.NoError(t, d.SaveGenesisBlockRoot(ctx, root))
+		}
+		util.SaveBlock(t, ctx, d, blk)
+		if i%32 == 0 {
+			st, err := util.NewBeaconState()
+			require.NoError(t, err)
+			require.NoError(t, st.SetSlot(i))
+			require.NoError(t, d.SaveState(ctx, st, root))
+		}
+		roots = append(roots, root)
+		prevRoot = root
+	}
+	require.NoError(t, d.SaveFinalizedCheckpoint(ctx, &ethpb.Checkpoint{Epoch: 2, Root: prevRoot[:]}))
+	_, err := d.DeleteHistoricalDataBeforeSlot(ctx, 32, 1000)
+	require.NoError(t, err)
+
+	r := &Service{cfg: &config{p2p: p1, beaconDB: d, clock: startup.NewClock(time.Unix(0, 0), [32]byte{})}, rateLimiter: newRateLimiter(p1)}
+	r.cfg.chain = &mock.ChainService{ValidatorsRoot: [32]byte{}}
+	pcl := protocol.ID(p2p.RPCBlocksByRootTopicV1)
+	topic := string(pcl)
+	r.rateLimiter.limiterMap[topic] = leakybucket.NewCollector(10000, 10000, time.Second, false)
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		// The pruned block is skipped and the known blocks are still served.
+		for _, slot := range []primitives.Slot{40, 50} {
+			expectSuccess(t, stream)
+			res := util.NewBeaconBlock()
+			assert.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, res))
+			assert.Equal(t, slot, res.Block.Slot)
+		}
+	})
+
+	stream1, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	blkRoots := p2pTypes.BeaconBlockByRootsReq{roots[40], roots[10], roots[50]}
+	require.NoError(t, r.beaconBlocksRootRPCHandler(ctx, &blkRoots, stream1))
+
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
 func TestRecentBeaconBlocksRPCHandler_ReturnsBlocks_ReconstructsPayload(t *testing.T) {
 	p1 := p2ptest.NewTestP2P(t)
 	p2 := p2ptest.NewTestP2P(t)
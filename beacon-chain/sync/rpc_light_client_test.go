@@ -0,0 +1,222 @@
+package sync
+
+import (
+	"context"
+	"io"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/libp2p/go-libp2p/core/network"
+	"github.com/libp2p/go-libp2p/core/protocol"
+	ssz "github.com/prysmaticlabs/fastssz"
+	"github.com/prysmaticlabs/go-bitfield"
+	mock "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
+	db "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/encoder"
+	p2ptest "github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/testing"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/p2p/types"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/startup"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	leakybucket "github.com/prysmaticlabs/prysm/v4/container/leaky-bucket"
+	"github.com/prysmaticlabs/prysm/v4/network/forks"
+	ethpbv1 "github.com/prysmaticlabs/prysm/v4/proto/eth/v1"
+	ethpbv2 "github.com/prysmaticlabs/prysm/v4/proto/eth/v2"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+)
+
+func testLightClientHeader(slot primitives.Slot) *ethpbv1.BeaconBlockHeader {
+	return &ethpbv1.BeaconBlockHeader{
+		Slot:       slot,
+		ParentRoot: make([]byte, 32),
+		StateRoot:  make([]byte, 32),
+		BodyRoot:   make([]byte, 32),
+	}
+}
+
+func testLightClientSyncCommittee() *ethpbv2.SyncCommittee {
+	pubkeys := make([][]byte, params.BeaconConfig().SyncCommitteeSize)
+	for i := range pubkeys {
+		pubkeys[i] = make([]byte, 48)
+	}
+	return &ethpbv2.SyncCommittee{Pubkeys: pubkeys, AggregatePubkey: make([]byte, 48)}
+}
+
+func testLightClientBranch(length int) [][]byte {
+	branch := make([][]byte, length)
+	for i := range branch {
+		branch[i] = make([]byte, 32)
+	}
+	return branch
+}
+
+func testLightClientUpdate(signatureSlot primitives.Slot) *ethpbv2.LightClientUpdate {
+	return &ethpbv2.LightClientUpdate{
+		AttestedHeader:          testLightClientHeader(signatureSlot - 1),
+		NextSyncCommittee:       testLightClientSyncCommittee(),
+		NextSyncCommitteeBranch: testLightClientBranch(5),
+		FinalizedHeader:         testLightClientHeader(0),
+		FinalityBranch:          testLightClientBranch(6),
+		SyncAggregate: &ethpbv1.SyncAggregate{
+			SyncCommitteeBits:      bitfield.NewBitvector512(),
+			SyncCommitteeSignature: make([]byte, 96),
+		},
+		SignatureSlot: signatureSlot,
+	}
+}
+
+func setupLightClientService(t *testing.T, topic string) (*Service, *p2ptest.TestP2P, *p2ptest.TestP2P) {
+	p1 := p2ptest.NewTestP2P(t)
+	p2 := p2ptest.NewTestP2P(t)
+	p1.Connect(p2)
+	assert.Equal(t, 1, len(p1.BHost.Network().Peers()), "Expected peers to be connected")
+	r := &Service{
+		cfg: &config{
+			beaconDB: db.SetupDB(t),
+			p2p:      p1,
+			chain:    &mock.ChainService{},
+			clock:    startup.NewClock(time.Now(), [32]byte{}),
+		},
+		rateLimiter: newRateLimiter(p1),
+	}
+	r.rateLimiter.limiterMap[topic] = leakybucket.NewCollector(10, 10, time.Second, false)
+	return r, p1, p2
+}
+
+// expectLightClientChunk reads a successful response chunk with the fork digest of the given slot as context.
+func expectLightClientChunk(t *testing.T, r *Service, stream network.Stream, slot primitives.Slot, out ssz.Unmarshaler) {
+	expectSuccess(t, stream)
+	digest := make([]byte, forkDigestLength)
+	_, err := io.ReadFull(stream, digest)
+	require.NoError(t, err)
+	want, err := forks.ForkDigestFromEpoch(slots.ToEpoch(slot), make([]byte, 32))
+	require.NoError(t, err)
+	assert.DeepEqual(t, want[:], digest)
+	require.NoError(t, r.cfg.p2p.Encoding().DecodeWithMaxLength(stream, out))
+}
+
+func TestLightClientBootstrapRPCHandler(t *testing.T) {
+	pcl := protocol.ID(p2p.RPCLightClientBootstrapTopicV1 + encoder.ProtocolSuffixSSZSnappy)
+	r, p1, p2 := setupLightClientService(t, string(pcl))
+	ctx := context.Background()
+
+	bootstrap := &ethpbv2.LightClientBootstrap{
+		Header:                     testLightClientHeader(32),
+		CurrentSyncCommittee:       testLightClientSyncCommittee(),
+		CurrentSyncCommitteeBranch: testLightClientBranch(5),
+	}
+	root := [32]byte{'a'}
+	require.NoError(t, r.cfg.beaconDB.SaveLightClientBootstrap(ctx, root, bootstrap))
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		out := &ethpbv2.LightClientBootstrap{}
+		expectLightClientChunk(t, r, stream, bootstrap.Header.Slot, out)
+		assert.DeepEqual(t, bootstrap, out)
+	})
+	stream, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := types.LightClientBootstrapReq(root)
+	require.NoError(t, r.lightClientBootstrapRPCHandler(ctx, &req, stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	// Unknown roots are reported as unavailable.
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+	})
+	stream, err = p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req = types.LightClientBootstrapReq{'b'}
+	require.NoError(t, r.lightClientBootstrapRPCHandler(ctx, &req, stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientUpdatesByRangeRPCHandler(t *testing.T) {
+	pcl := protocol.ID(p2p.RPCLightClientUpdatesByRangeTopicV1 + encoder.ProtocolSuffixSSZSnappy)
+	r, p1, p2 := setupLightClientService(t, string(pcl))
+	ctx := context.Background()
+
+	periodSlots := params.BeaconConfig().SlotsPerEpoch.Mul(uint64(params.BeaconConfig().EpochsPerSyncCommitteePeriod))
+	// Periods 1 and 2 have updates, period 3 is missing and period 4 has an update.
+	for _, period := range []uint64{1, 2, 4} {
+		update := testLightClientUpdate(periodSlots.Mul(period) + 2)
+		require.NoError(t, r.cfg.beaconDB.SaveLightClientUpdate(ctx, period, update))
+	}
+
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		for _, period := range []uint64{1, 2} {
+			out := &ethpbv2.LightClientUpdate{}
+			expectLightClientChunk(t, r, stream, periodSlots.Mul(period)+1, out)
+			assert.Equal(t, periodSlots.Mul(period)+2, out.SignatureSlot)
+		}
+		// The response stops at the first missing period.
+		_, _, err := ReadStatusCode(stream, r.cfg.p2p.Encoding())
+		require.ErrorIs(t, err, io.EOF)
+	})
+	stream, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	req := &ethpbv2.LightClientUpdatesByRangeRequest{StartPeriod: 1, Count: 4}
+	require.NoError(t, r.lightClientUpdatesByRangeRPCHandler(ctx, req, stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
+
+func TestLightClientFinalityUpdateRPCHandler(t *testing.T) {
+	pcl := protocol.ID(p2p.RPCLightClientFinalityUpdateTopicV1 + encoder.ProtocolSuffixSSZSnappy)
+	r, p1, p2 := setupLightClientService(t, string(pcl))
+	ctx := context.Background()
+
+	// No update was computed yet.
+	var wg sync.WaitGroup
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		expectFailure(t, responseCodeResourceUnavailable, types.ErrResourceUnavailable.Error(), stream)
+	})
+	stream, err := p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, r.lightClientFinalityUpdateRPCHandler(ctx, new(interface{}), stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+
+	update := testLightClientUpdate(100)
+	finality := &ethpbv2.LightClientFinalityUpdate{
+		AttestedHeader:  update.AttestedHeader,
+		FinalizedHeader: update.FinalizedHeader,
+		FinalityBranch:  update.FinalityBranch,
+		SyncAggregate:   update.SyncAggregate,
+		SignatureSlot:   update.SignatureSlot,
+	}
+	r.cfg.chain = &mock.ChainService{FinalityUpdate: finality}
+	wg.Add(1)
+	p2.BHost.SetStreamHandler(pcl, func(stream network.Stream) {
+		defer wg.Done()
+		out := &ethpbv2.LightClientFinalityUpdate{}
+		expectLightClientChunk(t, r, stream, finality.AttestedHeader.Slot, out)
+		assert.DeepEqual(t, finality, out)
+	})
+	stream, err = p1.BHost.NewStream(ctx, p2.BHost.ID(), pcl)
+	require.NoError(t, err)
+	require.NoError(t, r.lightClientFinalityUpdateRPCHandler(ctx, new(interface{}), stream))
+	if util.WaitTimeout(&wg, 1*time.Second) {
+		t.Fatal("Did not receive stream within 1 sec")
+	}
+}
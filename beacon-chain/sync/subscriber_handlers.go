@@ -5,6 +5,8 @@ import (
 	"fmt"
 
 	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"google.golang.org/protobuf/proto"
 )
@@ -40,6 +42,14 @@ func (s *Service) attesterSlashingSubscriber(ctx context.Context, msg proto.Mess
 		if err := s.cfg.slashingPool.InsertAttesterSlashing(ctx, headState, aSlashing); err != nil {
 			return errors.Wrap(err, "could not insert attester slashing into pool")
 		}
+		if s.cfg.operationNotifier != nil {
+			s.cfg.operationNotifier.OperationFeed().Send(&feed.Event{
+				Type: opfeed.AttesterSlashingReceived,
+				Data: &opfeed.AttesterSlashingReceivedData{
+					AttesterSlashing: aSlashing,
+				},
+			})
+		}
 		s.setAttesterSlashingIndicesSeen(aSlashing.Attestation_1.AttestingIndices, aSlashing.Attestation_2.AttestingIndices)
 	}
 	return nil
@@ -61,6 +71,14 @@ func (s *Service) proposerSlashingSubscriber(ctx context.Context, msg proto.Mess
 		if err := s.cfg.slashingPool.InsertProposerSlashing(ctx, headState, pSlashing); err != nil {
 			return errors.Wrap(err, "could not insert proposer slashing into pool")
 		}
+		if s.cfg.operationNotifier != nil {
+			s.cfg.operationNotifier.OperationFeed().Send(&feed.Event{
+				Type: opfeed.ProposerSlashingReceived,
+				Data: &opfeed.ProposerSlashingReceivedData{
+					ProposerSlashing: pSlashing,
+				},
+			})
+		}
 		s.setProposerSlashingIndexSeen(pSlashing.Header_1.Header.ProposerIndex)
 	}
 	return nil
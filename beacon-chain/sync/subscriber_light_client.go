@@ -0,0 +1,13 @@
+package sync
+
+import (
+	"context"
+
+	"google.golang.org/protobuf/proto"
+)
+
+// lightClientUpdateSubscriber is a no-op, as valid light client updates are the ones computed locally
+// and only need to be forwarded to peers.
+func (_ *Service) lightClientUpdateSubscriber(_ context.Context, _ proto.Message) error {
+	return nil
+}
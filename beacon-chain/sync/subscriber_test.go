@@ -14,6 +14,8 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/async/abool"
 	mockChain "github.com/prysmaticlabs/prysm/v4/beacon-chain/blockchain/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/cache"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed"
+	opfeed "github.com/prysmaticlabs/prysm/v4/beacon-chain/core/feed/operation"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/signing"
 	db "github.com/prysmaticlabs/prysm/v4/beacon-chain/db/testing"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/operations/slashings"
@@ -235,6 +237,43 @@ func TestSubscribe_ReceivesProposerSlashing(t *testing.T) {
 	assert.Equal(t, 1, len(ps), "Expected proposer slashing")
 }
 
+func TestSubscribe_SlashingsSendOperationEvents(t *testing.T) {
+	ctx := context.Background()
+	beaconState, privKeys := util.DeterministicGenesisState(t, 64)
+	chainService := &mockChain.ChainService{State: beaconState}
+	r := Service{
+		ctx: ctx,
+		cfg: &config{
+			slashingPool:      slashings.NewPool(),
+			chain:             chainService,
+			operationNotifier: chainService.OperationNotifier(),
+		},
+		seenAttesterSlashingCache: make(map[uint64]bool),
+		seenProposerSlashingCache: lruwrpr.New(10),
+	}
+	opChannel := make(chan *feed.Event, 2)
+	opSub := r.cfg.operationNotifier.OperationFeed().Subscribe(opChannel)
+	defer opSub.Unsubscribe()
+
+	attesterSlashing, err := util.GenerateAttesterSlashingForValidator(beaconState, privKeys[1], 1)
+	require.NoError(t, err)
+	require.NoError(t, r.attesterSlashingSubscriber(ctx, attesterSlashing))
+	proposerSlashing, err := util.GenerateProposerSlashingForValidator(beaconState, privKeys[2], 2)
+	require.NoError(t, err)
+	require.NoError(t, r.proposerSlashingSubscriber(ctx, proposerSlashing))
+
+	e := <-opChannel
+	assert.Equal(t, feed.EventType(opfeed.AttesterSlashingReceived), e.Type)
+	attesterData, ok := e.Data.(*opfeed.AttesterSlashingReceivedData)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, attesterSlashing, attesterData.AttesterSlashing)
+	e = <-opChannel
+	assert.Equal(t, feed.EventType(opfeed.ProposerSlashingReceived), e.Type)
+	proposerData, ok := e.Data.(*opfeed.ProposerSlashingReceivedData)
+	require.Equal(t, true, ok)
+	assert.DeepEqual(t, proposerSlashing, proposerData.ProposerSlashing)
+}
+
 func TestSubscribe_HandlesPanic(t *testing.T) {
 	p := p2ptest.NewTestP2P(t)
 	chain := &mockChain.ChainService{
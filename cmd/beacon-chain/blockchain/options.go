@@ -5,6 +5,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/core/helpers"
 	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/beacon-chain/flags"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
 	"github.com/urfave/cli/v2"
 )
 
@@ -20,5 +21,9 @@ func FlagOptions(c *cli.Context) ([]blockchain.Option, error) {
 		blockchain.WithMaxGoroutines(maxRoutines),
 		blockchain.WithWeakSubjectivityCheckpoint(wsCheckpt),
 	}
+	if c.Bool(flags.ValidatorHistory.Name) {
+		retention := primitives.Epoch(c.Uint64(flags.ValidatorHistoryRetentionEpochs.Name))
+		opts = append(opts, blockchain.WithValidatorHistory(retention))
+	}
 	return opts, nil
 }
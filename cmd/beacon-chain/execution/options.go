@@ -32,6 +32,43 @@ func FlagOptions(c *cli.Context) ([]execution.Option, error) {
 	if len(jwtSecret) > 0 {
 		opts = append(opts, execution.WithHttpEndpointAndJWTSecret(endpoint, jwtSecret))
 	}
+	backupOpts, err := parseBackupEndpoints(c, jwtSecret)
+	if err != nil {
+		return nil, err
+	}
+	return append(opts, backupOpts...), nil
+}
+
+// Parses the backup execution client endpoints together with their JWT secrets. A backup uses the JWT secret of the
+// primary execution client unless a secret is given for each backup.
+func parseBackupEndpoints(c *cli.Context, primarySecret []byte) ([]execution.Option, error) {
+	if c.String(flags.ExecutionBackupEndpoints.Name) == "" {
+		return nil, nil
+	}
+	endpoints := strings.Split(c.String(flags.ExecutionBackupEndpoints.Name), ",")
+	secrets := make([][]byte, len(endpoints))
+	if c.String(flags.ExecutionBackupJWTSecrets.Name) != "" {
+		files := strings.Split(c.String(flags.ExecutionBackupJWTSecrets.Name), ",")
+		if len(files) != len(endpoints) {
+			return nil, fmt.Errorf("%d backup execution endpoints but %d JWT secrets were given with %s",
+				len(endpoints), len(files), flags.ExecutionBackupJWTSecrets.Name)
+		}
+		for i, f := range files {
+			secret, err := jwtSecretFromFile(strings.TrimSpace(f))
+			if err != nil {
+				return nil, errors.Wrapf(err, "could not read JWT secret of backup execution endpoint %d", i)
+			}
+			secrets[i] = secret
+		}
+	} else {
+		for i := range secrets {
+			secrets[i] = primarySecret
+		}
+	}
+	opts := make([]execution.Option, len(endpoints))
+	for i, endpoint := range endpoints {
+		opts[i] = execution.WithBackupHttpEndpoint(strings.TrimSpace(endpoint), secrets[i])
+	}
 	return opts, nil
 }
 
@@ -48,6 +85,10 @@ func parseJWTSecretFromFile(c *cli.Context) ([]byte, error) {
 	if jwtSecretFile == "" {
 		return nil, nil
 	}
+	return jwtSecretFromFile(jwtSecretFile)
+}
+
+func jwtSecretFromFile(jwtSecretFile string) ([]byte, error) {
 	enc, err := file.ReadFileAsBytes(jwtSecretFile)
 	if err != nil {
 		return nil, err
@@ -99,3 +99,55 @@ func TestPowchainPreregistration_EmptyWeb3Provider(t *testing.T) {
 	_, err := parseExecutionChainEndpoint(ctx)
 	assert.ErrorContains(t, "you need to specify", err)
 }
+
+func Test_parseBackupEndpoints(t *testing.T) {
+	t.Run("no backups", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.ExecutionBackupEndpoints.Name, "", "")
+		ctx := cli.NewContext(&app, set, nil)
+		opts, err := parseBackupEndpoints(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, 0, len(opts))
+	})
+	t.Run("primary secret", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.ExecutionBackupEndpoints.Name, "http://backup1:8551,http://backup2:8551", "")
+		ctx := cli.NewContext(&app, set, nil)
+		opts, err := parseBackupEndpoints(ctx, []byte("secret"))
+		require.NoError(t, err)
+		require.Equal(t, 2, len(opts))
+	})
+	t.Run("secret per backup", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		fullPath := filepath.Join(t.TempDir(), "foohex")
+		secret := bytesutil.ToBytes32([]byte("foo"))
+		require.NoError(t, file.WriteFile(fullPath, []byte(fmt.Sprintf("%#x", secret))))
+		set.String(flags.ExecutionBackupEndpoints.Name, "http://backup1:8551,http://backup2:8551", "")
+		set.String(flags.ExecutionBackupJWTSecrets.Name, fullPath+","+fullPath, "")
+		ctx := cli.NewContext(&app, set, nil)
+		opts, err := parseBackupEndpoints(ctx, nil)
+		require.NoError(t, err)
+		require.Equal(t, 2, len(opts))
+	})
+	t.Run("wrong number of secrets", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.ExecutionBackupEndpoints.Name, "http://backup1:8551,http://backup2:8551", "")
+		set.String(flags.ExecutionBackupJWTSecrets.Name, "/tmp/secret", "")
+		ctx := cli.NewContext(&app, set, nil)
+		_, err := parseBackupEndpoints(ctx, nil)
+		require.ErrorContains(t, "2 backup execution endpoints but 1 JWT secrets", err)
+	})
+	t.Run("unreadable secret", func(t *testing.T) {
+		app := cli.App{}
+		set := flag.NewFlagSet("test", 0)
+		set.String(flags.ExecutionBackupEndpoints.Name, "http://backup1:8551", "")
+		set.String(flags.ExecutionBackupJWTSecrets.Name, "/tmp/askdjkajsd", "")
+		ctx := cli.NewContext(&app, set, nil)
+		_, err := parseBackupEndpoints(ctx, nil)
+		require.ErrorContains(t, "no such file", err)
+	})
+}
@@ -9,10 +9,11 @@ import (
 )
 
 var (
-	// MevRelayEndpoint provides an HTTP access endpoint to a MEV builder network.
+	// MevRelayEndpoint provides an HTTP access endpoint to a MEV builder network, or a comma-separated list of endpoints.
 	MevRelayEndpoint = &cli.StringFlag{
-		Name:  "http-mev-relay",
-		Usage: "A MEV builder relay string http endpoint, this wil be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder",
+		Name: "http-mev-relay",
+		Usage: "A MEV builder relay string http endpoint, this wil be used to interact MEV builder network using API defined in: https://ethereum.github.io/builder-specs/#/Builder. " +
+			"Several comma-separated relays may be given, in which case validators are registered with all of them and the highest bid is used",
 		Value: "",
 	}
 	MaxBuilderConsecutiveMissedSlots = &cli.IntFlag{
@@ -25,6 +26,16 @@ var (
 		Usage: "Number of total skip slot to fallback from using relay/builder to local execution engine for block construction in last epoch rolling window",
 		Value: 8,
 	}
+	MaxRelayConsecutiveFailures = &cli.IntFlag{
+		Name:  "max-relay-consecutive-failures",
+		Usage: "Number of consecutive failures of a relay after which it is not used for block construction for an epoch. The local execution engine is used once every relay is disabled",
+		Value: 3,
+	}
+	MaxRelayEpochFailures = &cli.IntFlag{
+		Name:  "max-relay-epoch-failures",
+		Usage: "Number of slots in the last epoch rolling window in which a relay failed after which it is not used for block construction. The local execution engine is used once every relay is disabled",
+		Value: 5,
+	}
 	LocalBlockValueBoost = &cli.Float64Flag{
 		Name: "local-block-value-boost",
 		Usage: "A percentage boost for local block construction. This is used to prioritize local block construction over relay/builder block construction" +
@@ -54,6 +65,22 @@ var (
 			"This is not required if using an IPC connection.",
 		Value: "",
 	}
+	// ExecutionBackupEndpoints provides HTTP access endpoints to backup execution clients, used when the one given
+	// with --execution-endpoint is unavailable or not synced.
+	ExecutionBackupEndpoints = &cli.StringFlag{
+		Name: "execution-backup-endpoints",
+		Usage: "A comma separated list of backup execution client http endpoints, in order of preference. The beacon " +
+			"node switches to the first healthy and synced backup when the execution client given with " +
+			"--execution-endpoint is unhealthy, and switches back once it recovers. Fork choice updates are also sent " +
+			"to the backups so that they stay synced.",
+	}
+	// ExecutionBackupJWTSecrets provides the paths to the JWT secrets of the backup execution clients.
+	ExecutionBackupJWTSecrets = &cli.StringFlag{
+		Name: "execution-backup-jwt-secrets",
+		Usage: "A comma separated list of paths to files containing the hex-encoded JWT secrets of the backup " +
+			"execution clients, in the same order as --execution-backup-endpoints. If not set, the secret given with " +
+			"--jwt-secret is used for all the backups.",
+	}
 	// DepositContractFlag defines a flag for the deposit contract address.
 	DepositContractFlag = &cli.StringFlag{
 		Name:  "deposit-contract",
@@ -118,6 +145,13 @@ var (
 			"(browser enforced). This flag has no effect if not used with --grpc-gateway-port.",
 		Value: "http://localhost:4200,http://localhost:7500,http://127.0.0.1:4200,http://127.0.0.1:7500,http://0.0.0.0:4200,http://0.0.0.0:7500,http://localhost:3000,http://0.0.0.0:3000,http://127.0.0.1:3000",
 	}
+	// AdminAPITokenFile specifies the file holding the bearer token required by the administrative HTTP endpoints.
+	AdminAPITokenFile = &cli.StringFlag{
+		Name: "admin-api-token-file",
+		Usage: "Path to a file holding a secret token. The administrative HTTP endpoints of the gateway, such as " +
+			"the endpoints changing the validators tracked by the validator monitor, require it as a bearer token " +
+			"in the Authorization header. These endpoints are disabled if the flag is not set.",
+	}
 	// MinSyncPeers specifies the required number of successful peer handshakes in order
 	// to start syncing with external peers.
 	MinSyncPeers = &cli.IntFlag{
@@ -152,6 +186,12 @@ var (
 		Usage: "The slot durations of when an archived state gets saved in the beaconDB.",
 		Value: 2048,
 	}
+	// SaveStateDiffs enables saving the finalized epoch states in between the archived points as state diffs.
+	SaveStateDiffs = &cli.BoolFlag{
+		Name: "save-state-diffs",
+		Usage: "Saves the finalized state of every epoch in between two archived points as a compact diff from the " +
+			"last archived state, so that historical states are loaded without replaying blocks.",
+	}
 	// BlockBatchLimit specifies the requested block batch size.
 	BlockBatchLimit = &cli.IntFlag{
 		Name:  "block-batch-limit",
@@ -164,11 +204,60 @@ var (
 		Usage: "The factor by which block batch limit may increase on burst.",
 		Value: 2,
 	}
+	// BackfillBlocksPerSecond limits the bandwidth used to download the blocks below the checkpoint sync origin.
+	BackfillBlocksPerSecond = &cli.Uint64Flag{
+		Name: "backfill-blocks-per-second",
+		Usage: "The maximum number of historical blocks per second that a checkpoint synced node downloads from peers " +
+			"to backfill its history down to genesis. Set to 0 to disable backfill.",
+		Value: 64,
+	}
+	// BeaconDBPruning enables deleting finalized blocks and states which are older than the retention window.
+	BeaconDBPruning = &cli.BoolFlag{
+		Name: "beacon-db-pruning",
+		Usage: "Periodically deletes finalized blocks, states and their indices which are older than the retention " +
+			"window set by --pruner-retention-epochs. Pruned history cannot be served to peers or API clients.",
+	}
+	// PrunerRetentionEpochs sets the number of epochs of history kept when beacon DB pruning is enabled.
+	PrunerRetentionEpochs = &cli.Uint64Flag{
+		Name: "pruner-retention-epochs",
+		Usage: "The number of epochs of finalized history kept in the database when --beacon-db-pruning is set. " +
+			"Set to 0 to keep the weak subjectivity period of the chain.",
+		Value: 0,
+	}
+	// EraDir sets a directory of era files to import into the database at startup.
+	EraDir = &cli.StringFlag{
+		Name: "era-dir",
+		Usage: "A directory of era files whose blocks and states are verified and imported into the database at " +
+			"startup. A database holding only the genesis state is seeded from them, and the history below the " +
+			"checkpoint sync origin of a checkpoint synced database is backfilled from them. Eras already imported " +
+			"in a previous run are skipped.",
+	}
+	// ValidatorHistory enables the per epoch index of validator balances and statuses.
+	ValidatorHistory = &cli.BoolFlag{
+		Name: "validator-history",
+		Usage: "Maintains an index of the balances, effective balances and statuses of all validators at the start " +
+			"of every finalized epoch, served by the /prysm/validators/history endpoint. The snapshots are taken from the " +
+			"epoch transitions of the imported blocks, so the index starts at the first epoch imported once it is enabled.",
+	}
+	// ValidatorHistoryRetentionEpochs sets the number of epochs kept in the validator history index.
+	ValidatorHistoryRetentionEpochs = &cli.Uint64Flag{
+		Name: "validator-history-retention-epochs",
+		Usage: "The number of finalized epochs kept in the validator history index when --validator-history is set. " +
+			"Set to 0 to keep all epochs.",
+		Value: 0,
+	}
 	// EnableDebugRPCEndpoints as /v1/beacon/state.
 	EnableDebugRPCEndpoints = &cli.BoolFlag{
 		Name:  "enable-debug-rpc-endpoints",
 		Usage: "Enables the debug rpc service, containing utility endpoints such as /eth/v1alpha1/beacon/state.",
 	}
+	// ForkChoiceRecordFile specifies the file to which the inputs of fork choice are recorded.
+	ForkChoiceRecordFile = &cli.StringFlag{
+		Name: "forkchoice-record-file",
+		Usage: "Appends every input of fork choice (blocks, attestations, checkpoints, ticks) to the given file, " +
+			"so that head decisions can be replayed offline with `prysmctl forkchoice replay`. The inputs of every " +
+			"run of the beacon node are appended to the file.",
+	}
 	// SubscribeToAllSubnets defines a flag to specify whether to subscribe to all possible attestation/sync subnets or not.
 	SubscribeToAllSubnets = &cli.BoolFlag{
 		Name:  "subscribe-all-subnets",
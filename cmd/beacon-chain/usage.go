@@ -54,6 +54,7 @@ var appHelpFlagGroups = []flagGroup{
 			cmd.RelayNode,
 			cmd.P2PUDPPort,
 			cmd.P2PTCPPort,
+			cmd.P2PQUICPort,
 			cmd.DataDirFlag,
 			cmd.VerbosityFlag,
 			cmd.EnableTracingFlag,
@@ -104,14 +105,25 @@ var appHelpFlagGroups = []flagGroup{
 			flags.GRPCGatewayHost,
 			flags.GRPCGatewayPort,
 			flags.GPRCGatewayCorsDomain,
+			flags.AdminAPITokenFile,
 			flags.ExecutionEngineEndpoint,
 			flags.ExecutionEngineHeaders,
 			flags.ExecutionJWTSecretFlag,
+			flags.ExecutionBackupEndpoints,
+			flags.ExecutionBackupJWTSecrets,
 			flags.SetGCPercent,
 			flags.SlotsPerArchivedPoint,
+			flags.SaveStateDiffs,
 			flags.BlockBatchLimit,
 			flags.BlockBatchLimitBurstFactor,
+			flags.BackfillBlocksPerSecond,
+			flags.BeaconDBPruning,
+			flags.PrunerRetentionEpochs,
+			flags.EraDir,
+			flags.ValidatorHistory,
+			flags.ValidatorHistoryRetentionEpochs,
 			flags.EnableDebugRPCEndpoints,
+			flags.ForkChoiceRecordFile,
 			flags.EnableRegistrationCache,
 			flags.SubscribeToAllSubnets,
 			flags.HistoricalSlasherNode,
@@ -123,11 +135,14 @@ var appHelpFlagGroups = []flagGroup{
 			flags.MevRelayEndpoint,
 			flags.MaxBuilderEpochMissedSlots,
 			flags.MaxBuilderConsecutiveMissedSlots,
+			flags.MaxRelayEpochFailures,
+			flags.MaxRelayConsecutiveFailures,
 			flags.EngineEndpointTimeoutSeconds,
 			flags.SlasherDirFlag,
 			checkpoint.BlockPath,
 			checkpoint.StatePath,
 			checkpoint.RemoteURL,
+			checkpoint.Quorum,
 			genesis.StatePath,
 			genesis.BeaconAPIURL,
 		},
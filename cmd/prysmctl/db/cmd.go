@@ -9,6 +9,8 @@ var Commands = []*cli.Command{
 		Subcommands: []*cli.Command{
 			queryCmd,
 			bucketsCmd,
+			exportEraCmd,
+			importEraCmd,
 		},
 	},
 }
@@ -0,0 +1,166 @@
+package db
+
+import (
+	"fmt"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/era"
+	"github.com/prysmaticlabs/prysm/v4/beacon-chain/db/kv"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var exportEraFlags = struct {
+	Path            string
+	OutputDir       string
+	StartEra        uint64
+	EndEra          uint64
+	ConfigName      string
+	ChainConfigFile string
+}{}
+
+var importEraFlags = struct {
+	Path            string
+	EraDir          string
+	ConfigName      string
+	ChainConfigFile string
+}{}
+
+var exportEraCmd = &cli.Command{
+	Name:  "export-era",
+	Usage: "export the finalized blocks and era states of the db as era files",
+	Action: func(cliCtx *cli.Context) error {
+		if err := exportEraAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not export era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db",
+			Destination: &exportEraFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "output-dir",
+			Usage:       "directory to write the era files to",
+			Destination: &exportEraFlags.OutputDir,
+			Required:    true,
+		},
+		&cli.Uint64Flag{
+			Name:        "start-era",
+			Usage:       "first era to export",
+			Destination: &exportEraFlags.StartEra,
+		},
+		&cli.Uint64Flag{
+			Name:        "end-era",
+			Usage:       "last era to export, defaults to the last finalized era",
+			Destination: &exportEraFlags.EndEra,
+		},
+		&cli.StringFlag{
+			Name:        "config-name",
+			Usage:       "name of the network config of the db, --chain-config-file will override this flag",
+			Destination: &exportEraFlags.ConfigName,
+			Value:       params.MainnetName,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to a YAML file with the chain config values of the db",
+			Destination: &exportEraFlags.ChainConfigFile,
+		},
+	},
+}
+
+var importEraCmd = &cli.Command{
+	Name:  "import-era",
+	Usage: "verify era files and import their blocks and states into the db",
+	Action: func(cliCtx *cli.Context) error {
+		if err := importEraAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not import era files")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "path",
+			Usage:       "path to directory containing beaconchain.db, which must hold the genesis state",
+			Destination: &importEraFlags.Path,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "era-dir",
+			Usage:       "directory containing the era files to import",
+			Destination: &importEraFlags.EraDir,
+			Required:    true,
+		},
+		&cli.StringFlag{
+			Name:        "config-name",
+			Usage:       "name of the network config of the db, --chain-config-file will override this flag",
+			Destination: &importEraFlags.ConfigName,
+			Value:       params.MainnetName,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to a YAML file with the chain config values of the db",
+			Destination: &importEraFlags.ChainConfigFile,
+		},
+	},
+}
+
+func exportEraAction(cliCtx *cli.Context) error {
+	flags := exportEraFlags
+	if err := setEraConfig(flags.ConfigName, flags.ChainConfigFile); err != nil {
+		return err
+	}
+	db, err := kv.NewKVStore(cliCtx.Context, flags.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open db at %s", flags.Path)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	end := flags.EndEra
+	if !cliCtx.IsSet("end-era") {
+		if end, err = era.LastFinalizedEra(cliCtx.Context, db); err != nil {
+			return err
+		}
+	}
+	paths, err := era.Export(cliCtx.Context, db, flags.OutputDir, flags.StartEra, end)
+	if err != nil {
+		return err
+	}
+	log.Infof("Exported %d era files to %s", len(paths), flags.OutputDir)
+	return nil
+}
+
+func importEraAction(cliCtx *cli.Context) error {
+	flags := importEraFlags
+	if err := setEraConfig(flags.ConfigName, flags.ChainConfigFile); err != nil {
+		return err
+	}
+	db, err := kv.NewKVStore(cliCtx.Context, flags.Path)
+	if err != nil {
+		return errors.Wrapf(err, "could not open db at %s", flags.Path)
+	}
+	defer func() {
+		if err := db.Close(); err != nil {
+			log.WithError(err).Error("Could not close db")
+		}
+	}()
+	return era.Import(cliCtx.Context, db, flags.EraDir)
+}
+
+func setEraConfig(configName, chainConfigFile string) error {
+	if chainConfigFile != "" {
+		return params.LoadChainConfigFile(chainConfigFile, nil)
+	}
+	cfg, err := params.ByName(configName)
+	if err != nil {
+		return fmt.Errorf("unable to find config using name %s: %v", configName, err)
+	}
+	return params.SetActive(cfg.Copy())
+}
@@ -0,0 +1,13 @@
+package forkchoice
+
+import "github.com/urfave/cli/v2"
+
+var Commands = []*cli.Command{
+	{
+		Name:  "forkchoice",
+		Usage: "commands to debug the fork choice of a beacon node",
+		Subcommands: []*cli.Command{
+			replayCmd,
+		},
+	},
+}
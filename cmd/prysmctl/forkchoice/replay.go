@@ -0,0 +1,116 @@
+package forkchoice
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/pkg/errors"
+	doublylinkedtree "github.com/prysmaticlabs/prysm/v4/beacon-chain/forkchoice/doubly-linked-tree"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+var replayFlags = struct {
+	Log             string
+	MismatchesOnly  bool
+	ConfigName      string
+	ChainConfigFile string
+}{}
+
+var replayCmd = &cli.Command{
+	Name:  "replay",
+	Usage: "replay a fork choice log written with --forkchoice-record-file and print the head and weights after each input",
+	Action: func(cliCtx *cli.Context) error {
+		if err := replayAction(cliCtx); err != nil {
+			log.WithError(err).Fatal("Could not replay fork choice log")
+		}
+		return nil
+	},
+	Flags: []cli.Flag{
+		&cli.StringFlag{
+			Name:        "log",
+			Usage:       "path to the fork choice log",
+			Destination: &replayFlags.Log,
+			Required:    true,
+		},
+		&cli.BoolFlag{
+			Name:        "mismatches-only",
+			Usage:       "only print the inputs which failed or after which the replayed head differs from the recorded one",
+			Destination: &replayFlags.MismatchesOnly,
+		},
+		&cli.StringFlag{
+			Name:        "config-name",
+			Usage:       "name of the network config of the recording node, --chain-config-file will override this flag",
+			Destination: &replayFlags.ConfigName,
+			Value:       params.MainnetName,
+		},
+		&cli.StringFlag{
+			Name:        "chain-config-file",
+			Usage:       "path to a YAML file with the chain config values of the recording node",
+			Destination: &replayFlags.ChainConfigFile,
+		},
+	},
+}
+
+func replayAction(cliCtx *cli.Context) error {
+	flags := replayFlags
+	if err := setConfig(flags.ConfigName, flags.ChainConfigFile); err != nil {
+		return err
+	}
+	f, err := os.Open(flags.Log)
+	if err != nil {
+		return errors.Wrap(err, "could not open fork choice log")
+	}
+	defer func() {
+		if err := f.Close(); err != nil {
+			log.WithError(err).Error("Could not close fork choice log")
+		}
+	}()
+
+	var steps, mismatches int
+	err = doublylinkedtree.Replay(cliCtx.Context, f, func(step *doublylinkedtree.ReplayStep) error {
+		steps++
+		mismatch := step.Err != nil || step.Mismatch()
+		if mismatch {
+			mismatches++
+		}
+		if flags.MismatchesOnly && !mismatch {
+			return nil
+		}
+		printStep(step)
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	log.WithField("inputs", steps).WithField("mismatches", mismatches).Info("Replayed fork choice log")
+	return nil
+}
+
+func printStep(step *doublylinkedtree.ReplayStep) {
+	fmt.Printf("#%d %s %s\n", step.Index, step.Time.UTC().Format("2006-01-02T15:04:05Z"), step.Input)
+	if step.Err != nil {
+		fmt.Printf("  error: %v\n", step.Err)
+	}
+	if step.HeadWeight != nil {
+		fmt.Printf("  head: %#x slot=%d weight=%s\n", step.Head, step.HeadSlot, step.HeadWeight)
+	}
+	if step.Mismatch() {
+		fmt.Printf("  recorded head: %#x\n", *step.RecordedHead)
+	}
+	for _, tip := range step.Tips {
+		fmt.Printf("  tip: %#x slot=%d weight=%s\n", tip.Root, tip.Slot, tip.Weight)
+	}
+}
+
+func setConfig(configName, chainConfigFile string) error {
+	if chainConfigFile != "" {
+		return params.LoadChainConfigFile(chainConfigFile, nil)
+	}
+	cfg, err := params.ByName(configName)
+	if err != nil {
+		return fmt.Errorf("unable to find config using name %s: %v", configName, err)
+	}
+	return params.SetActive(cfg.Copy())
+}
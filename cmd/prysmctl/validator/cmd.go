@@ -9,6 +9,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/cmd/validator/accounts"
 	"github.com/prysmaticlabs/prysm/v4/cmd/validator/flags"
 	"github.com/prysmaticlabs/prysm/v4/config/features"
+	shared "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-shared"
 	log "github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
 )
@@ -69,6 +70,28 @@ var (
 		Aliases: []string{"t"},
 		Usage:   "keymanager API bearer token, note: currently required but may be removed in the future, this is the same token as the web ui token.",
 	}
+
+	SharedSlashingProtectionPathFlag = &cli.StringFlag{
+		Name:  "shared-slashing-protection-path",
+		Usage: "path to the file of slashing protection records shared between validator clients using the same keys",
+	}
+
+	ListenAddressFlag = &cli.StringFlag{
+		Name:  "listen-address",
+		Usage: "host:port to serve the shared slashing protection records on",
+		Value: "127.0.0.1:9500",
+	}
+
+	SharedSlashingProtectionTokenFileFlag = &cli.StringFlag{
+		Name:  "token-file",
+		Usage: "path to a file holding the bearer token required from the validator clients, which they read from --validators-external-signer-shared-slashing-protection-token-file",
+	}
+
+	SharedSlashingProtectionHistoryFlag = &cli.Uint64Flag{
+		Name:  "history-epochs",
+		Usage: "number of epochs of shared slashing protection records kept below the latest record of each key, older records are pruned, 0 keeps all the records",
+		Value: uint64(shared.DefaultHistoryEpochs),
+	}
 )
 
 var Commands = []*cli.Command{
@@ -141,6 +164,44 @@ var Commands = []*cli.Command{
 					return nil
 				},
 			},
+			{
+				Name:  "proposer-settings-preview",
+				Usage: "Display the effective proposer settings of each validator key, and flag the differences with what the beacon node and its relays have.",
+				Flags: []cli.Flag{
+					cmd.ConfigFileFlag,
+					TokenFlag,
+					ValidatorHostFlag,
+				},
+				Before: func(cliCtx *cli.Context) error {
+					return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+				},
+				Action: func(cliCtx *cli.Context) error {
+					if err := previewProposerSettings(cliCtx); err != nil {
+						log.WithError(err).Fatal("Could not preview proposer settings")
+					}
+					return nil
+				},
+			},
+			{
+				Name:  "shared-slashing-protection",
+				Usage: "Serve the slashing protection records shared between validator clients using the same keys, such as active/passive pairs signing with the same web3signer.",
+				Flags: []cli.Flag{
+					cmd.ConfigFileFlag,
+					SharedSlashingProtectionPathFlag,
+					ListenAddressFlag,
+					SharedSlashingProtectionTokenFileFlag,
+					SharedSlashingProtectionHistoryFlag,
+				},
+				Before: func(cliCtx *cli.Context) error {
+					return cmd.LoadFlagsFromConfig(cliCtx, cliCtx.Command.Flags)
+				},
+				Action: func(cliCtx *cli.Context) error {
+					if err := serveSharedSlashingProtection(cliCtx); err != nil {
+						log.WithError(err).Fatal("Could not serve shared slashing protection records")
+					}
+					return nil
+				},
+			},
 			{
 				Name:    "exit",
 				Aliases: []string{"e", "voluntary-exit"},
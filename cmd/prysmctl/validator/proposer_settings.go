@@ -104,6 +104,54 @@ func getProposerSettings(c *cli.Context, r io.Reader) error {
 	return nil
 }
 
+func previewProposerSettings(c *cli.Context) error {
+	ctx, span := trace.StartSpan(c.Context, "prysmctl.previewProposerSettings")
+	defer span.End()
+	if !c.IsSet(TokenFlag.Name) {
+		return errNoFlag(TokenFlag.Name)
+	}
+	cl, err := validator.NewClient(c.String(ValidatorHostFlag.Name), client.WithAuthenticationToken(c.String(TokenFlag.Name)))
+	if err != nil {
+		return err
+	}
+	preview, err := cl.GetProposerSettingsPreview(ctx)
+	if err != nil {
+		return err
+	}
+
+	log.Infoln("===============PREVIEWING PROPOSER SETTINGS===============")
+	if preview.BeaconNodeError != "" {
+		log.WithField("error", preview.BeaconNodeError).Warn("Could not compare the settings with the beacon node")
+	}
+	var mismatched int
+	for _, p := range preview.Data {
+		fields := log.Fields{
+			"source":         p.Source,
+			"feeRecipient":   p.FeeRecipient,
+			"builderEnabled": p.BuilderEnabled,
+		}
+		if p.BuilderEnabled {
+			fields["gasLimit"] = p.GasLimit
+		}
+		if p.BeaconNode != nil && p.BeaconNode.Index != "" {
+			fields["index"] = p.BeaconNode.Index
+		}
+		log.WithFields(fields).Infof("Validator: %s", p.Pubkey)
+		for _, m := range p.Mismatches {
+			log.Warnf("Validator: %s. Mismatch: %s", p.Pubkey, m)
+		}
+		if len(p.Mismatches) > 0 {
+			mismatched++
+		}
+	}
+	if mismatched > 0 {
+		log.Warnf("%d of %d validator(s) have settings which differ from the beacon node or its relays", mismatched, len(preview.Data))
+	} else if preview.BeaconNodeError == "" {
+		log.Infof("The settings of all %d validator(s) match the beacon node and its relays", len(preview.Data))
+	}
+	return nil
+}
+
 func validateIsExecutionAddress(input string) error {
 	if !bytesutil.IsHex([]byte(input)) || !(len(input) == common.AddressLength*2+2) {
 		return errors.New("no default address entered")
@@ -105,6 +105,41 @@ func TestGetProposerSettings(t *testing.T) {
 	require.NoError(t, err)
 }
 
+func TestPreviewProposerSettings(t *testing.T) {
+	key := "0x855ae9c6184d6edd46351b375f16f541b2d33b0ed0da9be4571b13938588aee840ba606a946f0e8023ae3a4b2a43b4d4"
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, "/v2/validator/proposer-settings/preview", r.URL.Path)
+		w.Header().Set("Content-Type", "application/json")
+		err := json.NewEncoder(w).Encode(&apimiddleware.ProposerSettingsPreviewResponseJson{
+			Data: []*apimiddleware.ProposerSettingsPreviewJson{
+				{
+					Pubkey:         key,
+					Source:         "proposer_config",
+					FeeRecipient:   "0xb698D697092822185bF0311052215d5B5e1F3944",
+					BuilderEnabled: true,
+					GasLimit:       "30000000",
+					BeaconNode:     &apimiddleware.ProposerRegistrationJson{Pubkey: key, Index: "12"},
+					Mismatches:     []string{"beacon node has no builder registration"},
+				},
+			},
+		})
+		require.NoError(t, err)
+	}))
+	defer srv.Close()
+	hook := logtest.NewGlobal()
+	app := cli.App{}
+	set := flag.NewFlagSet("test", 0)
+	set.String("validator-host", srv.URL, "")
+	set.String("token", "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.e30.VXjrSItV_Kmwg_XilpscyPm2SPIsstytYLtr_AuJI8I", "")
+	assert.NoError(t, set.Set("token", "eyJhbGciOiJIUzI1NiIsInR5cCI6IkpXVCJ9.e30.VXjrSItV_Kmwg_XilpscyPm2SPIsstytYLtr_AuJI8I"))
+	cliCtx := cli.NewContext(&app, set, nil)
+
+	require.NoError(t, previewProposerSettings(cliCtx))
+	assert.LogsContain(t, hook, fmt.Sprintf("Validator: %s", key))
+	assert.LogsContain(t, hook, "Mismatch: beacon node has no builder registration")
+	assert.LogsContain(t, hook, "1 of 1 validator(s) have settings which differ")
+}
+
 func TestValidateValidateIsExecutionAddress(t *testing.T) {
 	t.Run("Happy Path", func(t *testing.T) {
 		err := validateIsExecutionAddress("0xb698D697092822185bF0311052215d5B5e1F3933")
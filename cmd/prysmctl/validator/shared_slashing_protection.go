@@ -0,0 +1,70 @@
+package validator
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+	shared "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-shared"
+	log "github.com/sirupsen/logrus"
+	"github.com/urfave/cli/v2"
+)
+
+// Serves the slashing protection records shared between validator clients using the same keys, so they can be
+// configured with --validators-external-signer-shared-slashing-protection=http://<listen address> and the token of
+// --token-file.
+func serveSharedSlashingProtection(c *cli.Context) error {
+	if !c.IsSet(SharedSlashingProtectionPathFlag.Name) {
+		return errNoFlag(SharedSlashingProtectionPathFlag.Name)
+	}
+	if !c.IsSet(SharedSlashingProtectionTokenFileFlag.Name) {
+		return errNoFlag(SharedSlashingProtectionTokenFileFlag.Name)
+	}
+	tokenFile := c.String(SharedSlashingProtectionTokenFileFlag.Name)
+	enc, err := file.ReadFileAsBytes(tokenFile)
+	if err != nil {
+		return errors.Wrap(err, "could not read shared slashing protection token file")
+	}
+	token := strings.TrimSpace(string(enc))
+	if token == "" {
+		return fmt.Errorf("shared slashing protection token file %s is empty", tokenFile)
+	}
+	srv, err := newSharedSlashingProtectionServer(
+		c.String(SharedSlashingProtectionPathFlag.Name),
+		c.String(ListenAddressFlag.Name),
+		token,
+		primitives.Epoch(c.Uint64(SharedSlashingProtectionHistoryFlag.Name)),
+	)
+	if err != nil {
+		return err
+	}
+	go func() {
+		<-c.Context.Done()
+		ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(ctx); err != nil {
+			log.WithError(err).Error("Could not shut down shared slashing protection server")
+		}
+	}()
+	log.WithFields(log.Fields{
+		"address": srv.Addr,
+		"path":    c.String(SharedSlashingProtectionPathFlag.Name),
+	}).Info("Serving shared slashing protection records")
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
+		return errors.Wrap(err, "could not serve shared slashing protection records")
+	}
+	return nil
+}
+
+func newSharedSlashingProtectionServer(path, addr, token string, historyEpochs primitives.Epoch) (*http.Server, error) {
+	store, err := shared.NewFileStore(path, shared.WithHistoryEpochs(historyEpochs))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not open shared slashing protection records")
+	}
+	return &http.Server{Addr: addr, Handler: shared.NewHandler(store, token), ReadHeaderTimeout: time.Second}, nil
+}
@@ -0,0 +1,35 @@
+package validator
+
+import (
+	"context"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	shared "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-shared"
+)
+
+func TestNewSharedSlashingProtectionServer(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "protection.db")
+	srv, err := newSharedSlashingProtectionServer(path, "127.0.0.1:9500", "token", shared.DefaultHistoryEpochs)
+	require.NoError(t, err)
+	require.Equal(t, "127.0.0.1:9500", srv.Addr)
+	ts := httptest.NewServer(srv.Handler)
+	defer ts.Close()
+	u, err := url.Parse(ts.URL)
+	require.NoError(t, err)
+	store := shared.NewHTTPStore(u, "token")
+
+	// Requests without the token are rejected.
+	require.ErrorContains(t, "responded with status 401", shared.NewHTTPStore(u, "other").CheckAndSaveProposal(context.Background(), [48]byte{1}, 10, [32]byte{1}))
+
+	// Both validator clients check against the records in the file.
+	ctx := context.Background()
+	require.NoError(t, store.CheckAndSaveProposal(ctx, [48]byte{1}, 10, [32]byte{1}))
+	other, err := shared.NewFileStore(path)
+	require.NoError(t, err)
+	require.ErrorIs(t, other.CheckAndSaveProposal(ctx, [48]byte{1}, 10, [32]byte{2}), shared.ErrSlashable)
+	require.ErrorIs(t, store.CheckAndSaveProposal(ctx, [48]byte{1}, 9, [32]byte{2}), shared.ErrSlashable)
+}
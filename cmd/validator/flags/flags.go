@@ -40,12 +40,20 @@ var (
 		Usage: "Beacon node RPC gateway provider endpoint",
 		Value: "127.0.0.1:3500",
 	}
-	// BeaconRESTApiProviderFlag defines a beacon node REST API endpoint.
+	// BeaconRESTApiProviderFlag defines a beacon node REST API endpoint, or a comma-separated list of endpoints.
 	BeaconRESTApiProviderFlag = &cli.StringFlag{
-		Name:  "beacon-rest-api-provider",
-		Usage: "Beacon node REST API provider endpoint",
+		Name: "beacon-rest-api-provider",
+		Usage: "Beacon node REST API provider endpoint. Several comma-separated endpoints may be given, in order of " +
+			"priority, in which case the validator fails over to the next healthy beacon node",
 		Value: "http://127.0.0.1:3500",
 	}
+	// BeaconAdminAPITokenFileFlag defines the file holding the admin API token of the beacon nodes.
+	BeaconAdminAPITokenFileFlag = &cli.StringFlag{
+		Name: "beacon-admin-api-token-file",
+		Usage: "Path to a file holding the token set with --admin-api-token-file on the beacon nodes. It is sent to " +
+			"the administrative beacon node endpoints the validator client calls, such as the proposer registrations " +
+			"used by the proposer settings preview.",
+	}
 	// CertFlag defines a flag for the node's TLS certificate.
 	CertFlag = &cli.StringFlag{
 		Name:  "tls-cert",
@@ -297,6 +305,33 @@ var (
 		Name:  "validators-external-signer-public-keys",
 		Usage: "comma separated list of public keys OR an external url endpoint for the validator to retrieve public keys from for usage with web3signer",
 	}
+	// Web3SignerKeyFileFlag defines the file persisting the web3signer keys imported through the keymanager API, along
+	// with the url of the web3signer holding each of them.
+	Web3SignerKeyFileFlag = &cli.StringFlag{
+		Name: "validators-external-signer-key-file",
+		Usage: "Path of the file persisting the web3signer public keys imported through the keymanager API, and the url " +
+			"of the web3signer holding each of them, across restarts. Defaults to web3signer-keys.json in the datadir",
+		Value: "",
+	}
+	// SharedSlashingProtectionFlag defines the location of the slashing protection records shared between the validator
+	// clients using the same web3signer keys.
+	// example with a service, e.g. served by `prysmctl validator shared-slashing-protection`:
+	// --validators-external-signer-shared-slashing-protection=http://localhost:9500
+	// example with a file: --validators-external-signer-shared-slashing-protection=/mnt/shared/slashing-protection.db
+	SharedSlashingProtectionFlag = &cli.StringFlag{
+		Name: "validators-external-signer-shared-slashing-protection",
+		Usage: "URL of a shared slashing protection service, or path to a shared slashing protection bolt file, " +
+			"used to check and record every block and attestation before it is signed by web3signer, so that " +
+			"validator clients using the same keys never both sign conflicting messages",
+		Value: "",
+	}
+	// SharedSlashingProtectionTokenFileFlag defines the file holding the token of the shared slashing protection service.
+	SharedSlashingProtectionTokenFileFlag = &cli.StringFlag{
+		Name: "validators-external-signer-shared-slashing-protection-token-file",
+		Usage: "Path to a file holding the bearer token of the shared slashing protection service, as set with " +
+			"--token-file on `prysmctl validator shared-slashing-protection`. Required when " +
+			"--validators-external-signer-shared-slashing-protection is a URL",
+	}
 
 	// KeymanagerKindFlag defines the kind of keymanager desired by a user during wallet creation.
 	KeymanagerKindFlag = &cli.StringFlag{
@@ -138,6 +138,11 @@ var (
 		Name:  "enable-beacon-rest-api",
 		Usage: "Experimental enable of the beacon REST API when querying a beacon node",
 	}
+	EnableBeaconRESTApiBroadcast = &cli.BoolFlag{
+		Name: "enable-beacon-rest-api-broadcast",
+		Usage: "Publishes blocks, attestations, aggregates and sync committee messages to all beacon nodes given to " +
+			"--beacon-rest-api-provider instead of only the active one",
+	}
 	enableVerboseSigVerification = &cli.BoolFlag{
 		Name:  "enable-verbose-sig-verification",
 		Usage: "Enables identifying invalid signatures if batch verification fails when processing block",
@@ -158,6 +163,14 @@ var (
 		Name:  "disable-resource-manager",
 		Usage: "Disables running the libp2p resource manager",
 	}
+	enableQUIC = &cli.BoolFlag{
+		Name:  "enable-quic",
+		Usage: "Enables the QUIC transport for libp2p alongside TCP and advertises it in the node's ENR",
+	}
+	enableLightClient = &cli.BoolFlag{
+		Name:  "enable-lightclient",
+		Usage: "Enables the light client server, which computes light client data from the processed blocks and serves it over the beacon API and p2p",
+	}
 )
 
 // devModeFlags holds list of flags that are set when development mode is on.
@@ -180,6 +193,7 @@ var ValidatorFlags = append(deprecatedFlags, []cli.Flag{
 	enableSlashingProtectionPruning,
 	enableDoppelGangerProtection,
 	EnableBeaconRESTApi,
+	EnableBeaconRESTApiBroadcast,
 }...)
 
 // E2EValidatorFlags contains a list of the validator feature flags to be tested in E2E.
@@ -214,6 +228,8 @@ var BeaconChainFlags = append(deprecatedBeaconFlags, append(deprecatedFlags, []c
 	aggregateSecondInterval,
 	aggregateThirdInterval,
 	disableResourceManager,
+	enableQUIC,
+	enableLightClient,
 }...)...)
 
 // E2EBeaconChainFlags contains a list of the beacon chain feature flags to be tested in E2E.
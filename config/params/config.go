@@ -208,6 +208,8 @@ type BeaconChainConfig struct {
 	// Mev-boost circuit breaker
 	MaxBuilderConsecutiveMissedSlots primitives.Slot // MaxBuilderConsecutiveMissedSlots defines the number of consecutive skip slot to fallback from using relay/builder to local execution engine for block construction.
 	MaxBuilderEpochMissedSlots       primitives.Slot // MaxBuilderEpochMissedSlots is defines the number of total skip slot (per epoch rolling windows) to fallback from using relay/builder to local execution engine for block construction.
+	MaxRelayConsecutiveFailures      primitives.Slot // MaxRelayConsecutiveFailures defines the number of consecutive failures after which a relay is not used for block construction for an epoch.
+	MaxRelayEpochFailures            primitives.Slot // MaxRelayEpochFailures defines the number of slots (per epoch rolling windows) in which a relay failed after which it is not used for block construction.
 	LocalBlockValueBoost             uint64          // LocalBlockValueBoost is the value boost for local block construction. This is used to prioritize local block construction over relay/builder block construction.
 
 	// Execution engine timeout value
@@ -95,7 +95,7 @@ func TestPulseChainValidatorRewardsMock(t *testing.T) {
 			require.NoError(t, beaconState.SetPreviousParticipationBits(participation))
 			validators, balance, err = altair.ProcessEpochParticipation(context.Background(), beaconState, balance, validators)
 			require.NoError(t, err)
-			beaconState, err = altair.ProcessRewardsAndPenaltiesPrecompute(beaconState, balance, validators)
+			beaconState, err = altair.ProcessRewardsAndPenaltiesPrecompute(context.Background(), beaconState, balance, validators)
 			require.NoError(t, err)
 		}
 	}
@@ -37,7 +37,7 @@ func processRewardsAndPenaltiesPrecomputeWrapper(t *testing.T, st state.BeaconSt
 	vp, bp, err = precompute.ProcessAttestations(ctx, st, vp, bp)
 	require.NoError(t, err)
 
-	st, err = precompute.ProcessRewardsAndPenaltiesPrecompute(st, bp, vp, precompute.AttestationsDelta, precompute.ProposersDelta)
+	st, err = precompute.ProcessRewardsAndPenaltiesPrecompute(ctx, st, bp, vp, precompute.AttestationsDelta, precompute.ProposersDelta)
 	require.NoError(t, err, "Could not process reward")
 
 	return st, nil
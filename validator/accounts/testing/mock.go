@@ -89,6 +89,8 @@ func (_ *Wallet) InitializeKeymanager(_ context.Context, _ iface.InitKeymanagerC
 
 type MockValidator struct {
 	Km               keymanager.IKeymanager
+	Duties           map[[48]byte]*iface2.KeyDuties
+	DutyResults      []*iface2.DutyResult
 	proposerSettings *validatorserviceconfig.ProposerSettings
 }
 
@@ -211,3 +213,13 @@ func (m *MockValidator) ProposerSettings() *validatorserviceconfig.ProposerSetti
 func (m *MockValidator) SetProposerSettings(settings *validatorserviceconfig.ProposerSettings) {
 	m.proposerSettings = settings
 }
+
+// RecentDutyResults for mocking
+func (m *MockValidator) RecentDutyResults() []*iface2.DutyResult {
+	return m.DutyResults
+}
+
+// CurrentDuties for mocking
+func (m *MockValidator) CurrentDuties() map[[48]byte]*iface2.KeyDuties {
+	return m.Duties
+}
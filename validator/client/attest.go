@@ -60,6 +60,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	duty, err := v.duty(pubKey)
 	if err != nil {
 		log.WithError(err).Error("Could not fetch validator assignment")
+		v.dutyResults.add(slot, pubKey, iface.RoleAttester, err)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -71,6 +72,11 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 		return
 	}
 
+	var dutyErr error
+	defer func() {
+		v.dutyResults.add(slot, pubKey, iface.RoleAttester, dutyErr)
+	}()
+
 	req := &ethpb.AttestationDataRequest{
 		Slot:           slot,
 		CommitteeIndex: duty.CommitteeIndex,
@@ -78,6 +84,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	data, err := v.validatorClient.GetAttestationData(ctx, req)
 	if err != nil {
 		log.WithError(err).Error("Could not request attestation to sign at slot")
+		dutyErr = err
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -93,6 +100,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	_, signingRoot, err := v.getDomainAndSigningRoot(ctx, indexedAtt.Data)
 	if err != nil {
 		log.WithError(err).Error("Could not get domain and signing root from attestation")
+		dutyErr = err
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -100,9 +108,20 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 		return
 	}
 
+	if err := v.sharedAttestationCheck(ctx, pubKey, data, signingRoot); err != nil {
+		log.WithError(err).Error("Failed attestation slashing protection check")
+		dutyErr = err
+		log.WithFields(
+			attestationLogFields(pubKey, indexedAtt),
+		).Debug("Attempted slashable attestation details")
+		tracing.AnnotateError(span, err)
+		return
+	}
+
 	sig, _, err := v.signAtt(ctx, pubKey, data, slot)
 	if err != nil {
 		log.WithError(err).Error("Could not sign attestation")
+		dutyErr = err
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -121,6 +140,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	}
 	if !found {
 		log.Errorf("Validator ID %d not found in committee of %v", duty.ValidatorIndex, duty.Committee)
+		dutyErr = fmt.Errorf("validator ID %d not found in committee", duty.ValidatorIndex)
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -139,6 +159,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	indexedAtt.Signature = sig
 	if err := v.slashableAttestationCheck(ctx, indexedAtt, pubKey, signingRoot); err != nil {
 		log.WithError(err).Error("Failed attestation slashing protection check")
+		dutyErr = err
 		log.WithFields(
 			attestationLogFields(pubKey, indexedAtt),
 		).Debug("Attempted slashable attestation details")
@@ -148,6 +169,7 @@ func (v *validator) SubmitAttestation(ctx context.Context, slot primitives.Slot,
 	attResp, err := v.validatorClient.ProposeAttestation(ctx, attestation)
 	if err != nil {
 		log.WithError(err).Error("Could not submit attestation to beacon node")
+		dutyErr = err
 		if v.emitAccountMetrics {
 			ValidatorAttestFailVec.WithLabelValues(fmtKey).Inc()
 		}
@@ -2,7 +2,8 @@ package beacon_api
 
 import (
 	"context"
-	"net/http"
+	"encoding/base64"
+	neturl "net/url"
 	"reflect"
 	"strconv"
 	"time"
@@ -18,7 +19,6 @@ import (
 )
 
 type beaconApiBeaconChainClient struct {
-	fallbackClient          iface.BeaconChainClient
 	jsonRestHandler         jsonRestHandler
 	stateValidatorsProvider stateValidatorsProvider
 }
@@ -144,31 +144,110 @@ func (c beaconApiBeaconChainClient) GetChainHead(ctx context.Context, _ *empty.E
 }
 
 func (c beaconApiBeaconChainClient) ListValidatorBalances(ctx context.Context, in *ethpb.ListValidatorBalancesRequest) (*ethpb.ValidatorBalances, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.ListValidatorBalances(ctx, in)
+	page, err := parsePageToken(in.PageToken)
+	if err != nil {
+		return nil, err
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.ListValidatorBalances is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
-}
+	pubkeys := make([]string, len(in.PublicKeys))
+	for idx, pubkey := range in.PublicKeys {
+		pubkeys[idx] = hexutil.Encode(pubkey)
+	}
 
-func (c beaconApiBeaconChainClient) ListValidators(ctx context.Context, in *ethpb.ListValidatorsRequest) (*ethpb.Validators, error) {
-	pageSize := in.PageSize
+	var stateValidators *apimiddleware.StateValidatorsResponseJson
+	var epoch primitives.Epoch
 
-	// We follow the gRPC behavior here, which returns a maximum of 250 results when pageSize == 0
-	if pageSize == 0 {
-		pageSize = 250
+	switch queryFilter := in.QueryFilter.(type) {
+	case *ethpb.ListValidatorBalancesRequest_Epoch:
+		slot, err := slots.EpochStart(queryFilter.Epoch)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to get first slot for epoch `%d`", queryFilter.Epoch)
+		}
+		if stateValidators, err = c.stateValidatorsProvider.GetStateValidatorsForSlot(ctx, slot, pubkeys, in.Indices, nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to get state validators for slot `%d`", slot)
+		}
+		epoch = queryFilter.Epoch
+	case *ethpb.ListValidatorBalancesRequest_Genesis:
+		if stateValidators, err = c.stateValidatorsProvider.GetStateValidatorsForSlot(ctx, 0, pubkeys, in.Indices, nil); err != nil {
+			return nil, errors.Wrapf(err, "failed to get genesis state validators")
+		}
+		epoch = 0
+	case nil:
+		if stateValidators, err = c.stateValidatorsProvider.GetStateValidatorsForHead(ctx, pubkeys, in.Indices, nil); err != nil {
+			return nil, errors.Wrap(err, "failed to get head state validators")
+		}
+
+		blockHeader, err := c.getHeadBlockHeaders(ctx)
+		if err != nil {
+			return nil, errors.Wrap(err, "failed to get head block headers")
+		}
+
+		slot, err := strconv.ParseUint(blockHeader.Data.Header.Message.Slot, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse header slot `%s`", blockHeader.Data.Header.Message.Slot)
+		}
+
+		epoch = slots.ToEpoch(primitives.Slot(slot))
+	default:
+		return nil, errors.Errorf("unsupported query filter type `%v`", reflect.TypeOf(queryFilter))
+	}
+
+	if stateValidators.Data == nil {
+		return nil, errors.New("state validators data is nil")
 	}
 
-	var pageToken uint64
-	var err error
+	start, end, nextPageToken := pageBounds(in.PageSize, page, len(stateValidators.Data))
+
+	balances := make([]*ethpb.ValidatorBalances_Balance, end-start)
+	for idx := start; idx < end; idx++ {
+		stateValidator := stateValidators.Data[idx]
+
+		if stateValidator.Validator == nil {
+			return nil, errors.Errorf("state validator at index `%d` is nil", idx)
+		}
+
+		pubkey, err := hexutil.Decode(stateValidator.Validator.PublicKey)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to decode validator pubkey `%s`", stateValidator.Validator.PublicKey)
+		}
+
+		validatorIndex, err := strconv.ParseUint(stateValidator.Index, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator index `%s`", stateValidator.Index)
+		}
+
+		balance, err := strconv.ParseUint(stateValidator.Balance, 10, 64)
+		if err != nil {
+			return nil, errors.Wrapf(err, "failed to parse validator balance `%s`", stateValidator.Balance)
+		}
+
+		validatorStatus, ok := beaconAPITogRPCValidatorStatus[stateValidator.Status]
+		if !ok {
+			return nil, errors.Errorf("invalid validator status `%s`", stateValidator.Status)
+		}
 
-	if in.PageToken != "" {
-		if pageToken, err = strconv.ParseUint(in.PageToken, 10, 64); err != nil {
-			return nil, errors.Wrapf(err, "failed to parse page token `%s`", in.PageToken)
+		balances[idx-start] = &ethpb.ValidatorBalances_Balance{
+			PublicKey: pubkey,
+			Index:     primitives.ValidatorIndex(validatorIndex),
+			Balance:   balance,
+			Status:    validatorStatus.String(),
 		}
 	}
 
+	return &ethpb.ValidatorBalances{
+		Epoch:         epoch,
+		Balances:      balances,
+		NextPageToken: nextPageToken,
+		TotalSize:     int32(len(stateValidators.Data)),
+	}, nil
+}
+
+func (c beaconApiBeaconChainClient) ListValidators(ctx context.Context, in *ethpb.ListValidatorsRequest) (*ethpb.Validators, error) {
+	page, err := parsePageToken(in.PageToken)
+	if err != nil {
+		return nil, err
+	}
+
 	var statuses []string
 	if in.Active {
 		statuses = []string{"active"}
@@ -221,15 +300,7 @@ func (c beaconApiBeaconChainClient) ListValidators(ctx context.Context, in *ethp
 		return nil, errors.New("state validators data is nil")
 	}
 
-	start := pageToken * uint64(pageSize)
-	if start > uint64(len(stateValidators.Data)) {
-		start = uint64(len(stateValidators.Data))
-	}
-
-	end := start + uint64(pageSize)
-	if end > uint64(len(stateValidators.Data)) {
-		end = uint64(len(stateValidators.Data))
-	}
+	start, end, nextPageToken := pageBounds(in.PageSize, page, len(stateValidators.Data))
 
 	validators := make([]*ethpb.Validators_ValidatorContainer, end-start)
 	for idx := start; idx < end; idx++ {
@@ -294,11 +365,6 @@ func (c beaconApiBeaconChainClient) ListValidators(ctx context.Context, in *ethp
 		}
 	}
 
-	var nextPageToken string
-	if end < uint64(len(stateValidators.Data)) {
-		nextPageToken = strconv.FormatUint(pageToken+1, 10)
-	}
-
 	return &ethpb.Validators{
 		TotalSize:     int32(len(stateValidators.Data)),
 		Epoch:         epoch,
@@ -307,42 +373,104 @@ func (c beaconApiBeaconChainClient) ListValidators(ctx context.Context, in *ethp
 	}, nil
 }
 
-func (c beaconApiBeaconChainClient) GetValidatorQueue(ctx context.Context, in *empty.Empty) (*ethpb.ValidatorQueue, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.GetValidatorQueue(ctx, in)
+// GetValidatorQueue has no equivalent in the standard Beacon API, so it uses the Prysm-specific REST API.
+func (c beaconApiBeaconChainClient) GetValidatorQueue(ctx context.Context, _ *empty.Empty) (*ethpb.ValidatorQueue, error) {
+	const endpoint = "/eth/v1alpha1/validators/queue"
+
+	validatorQueue := &ethpb.ValidatorQueue{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, endpoint, &protoJsonResponse{message: validatorQueue}); err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", endpoint)
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.GetValidatorQueue is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
+	return validatorQueue, nil
 }
 
+// GetValidatorPerformance has no equivalent in the standard Beacon API, so it uses the Prysm-specific REST API.
 func (c beaconApiBeaconChainClient) GetValidatorPerformance(ctx context.Context, in *ethpb.ValidatorPerformanceRequest) (*ethpb.ValidatorPerformanceResponse, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.GetValidatorPerformance(ctx, in)
+	const endpoint = "/eth/v1alpha1/validators/performance"
+
+	queryParams := neturl.Values{}
+	for _, pubkey := range in.PublicKeys {
+		queryParams.Add("public_keys", base64.StdEncoding.EncodeToString(pubkey))
+	}
+	for _, index := range in.Indices {
+		queryParams.Add("indices", uint64ToString(index))
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.GetValidatorPerformance is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
+	validatorPerformance := &ethpb.ValidatorPerformanceResponse{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, buildURL(endpoint, queryParams), &protoJsonResponse{message: validatorPerformance}); err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", endpoint)
+	}
+
+	return validatorPerformance, nil
 }
 
+// GetValidatorParticipation has no equivalent in the standard Beacon API, so it uses the Prysm-specific REST API.
 func (c beaconApiBeaconChainClient) GetValidatorParticipation(ctx context.Context, in *ethpb.GetValidatorParticipationRequest) (*ethpb.ValidatorParticipationResponse, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.GetValidatorParticipation(ctx, in)
+	const endpoint = "/eth/v1alpha1/validators/participation"
+
+	queryParams := neturl.Values{}
+	switch queryFilter := in.QueryFilter.(type) {
+	case *ethpb.GetValidatorParticipationRequest_Epoch:
+		queryParams.Add("epoch", uint64ToString(queryFilter.Epoch))
+	case *ethpb.GetValidatorParticipationRequest_Genesis:
+		queryParams.Add("genesis", strconv.FormatBool(queryFilter.Genesis))
+	case nil:
+	default:
+		return nil, errors.Errorf("unsupported query filter type `%v`", reflect.TypeOf(queryFilter))
+	}
+
+	validatorParticipation := &ethpb.ValidatorParticipationResponse{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, buildURL(endpoint, queryParams), &protoJsonResponse{message: validatorParticipation}); err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", endpoint)
+	}
+
+	return validatorParticipation, nil
+}
+
+// parsePageToken returns the page number encoded in the given page token. An empty token denotes the first page.
+func parsePageToken(pageToken string) (uint64, error) {
+	if pageToken == "" {
+		return 0, nil
+	}
+
+	page, err := strconv.ParseUint(pageToken, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse page token `%s`", pageToken)
 	}
 
-	// TODO: Implement me
-	panic("beaconApiBeaconChainClient.GetValidatorParticipation is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiBeaconChainClientWithFallback.")
+	return page, nil
 }
 
-func NewBeaconApiBeaconChainClientWithFallback(host string, timeout time.Duration, fallbackClient iface.BeaconChainClient) iface.BeaconChainClient {
-	jsonRestHandler := beaconApiJsonRestHandler{
-		httpClient: http.Client{Timeout: timeout},
-		host:       host,
+// pageBounds returns the range of items belonging to the requested page, along with the token of the next page.
+func pageBounds(pageSize int32, page uint64, total int) (start, end uint64, nextPageToken string) {
+	// We follow the gRPC behavior here, which returns a maximum of 250 results when pageSize == 0
+	if pageSize == 0 {
+		pageSize = 250
+	}
+
+	start = page * uint64(pageSize)
+	if start > uint64(total) {
+		start = uint64(total)
 	}
 
+	end = start + uint64(pageSize)
+	if end > uint64(total) {
+		end = uint64(total)
+	}
+
+	if end < uint64(total) {
+		nextPageToken = strconv.FormatUint(page+1, 10)
+	}
+
+	return start, end, nextPageToken
+}
+
+func NewBeaconApiBeaconChainClient(host string, timeout time.Duration) iface.BeaconChainClient {
+	jsonRestHandler := newJsonRestHandler(host, timeout)
+
 	return &beaconApiBeaconChainClient{
 		jsonRestHandler:         jsonRestHandler,
-		fallbackClient:          fallbackClient,
 		stateValidatorsProvider: beaconApiStateValidatorsProvider{jsonRestHandler: jsonRestHandler},
 	}
 }
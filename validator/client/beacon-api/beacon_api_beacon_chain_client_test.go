@@ -925,3 +925,222 @@ func TestGetChainHead(t *testing.T) {
 		assert.DeepEqual(t, expectedChainHead, chainHead)
 	})
 }
+
+func TestListValidatorBalances(t *testing.T) {
+	t.Run("invalid token", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		beaconChainClient := beaconApiBeaconChainClient{}
+		_, err := beaconChainClient.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{
+			PageToken: "foo",
+		})
+		assert.ErrorContains(t, "failed to parse page token `foo`", err)
+	})
+
+	t.Run("fails to get validators for epoch filter", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			nil,
+			errors.New("foo error"),
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{stateValidatorsProvider: stateValidatorsProvider}
+		_, err := beaconChainClient.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{
+			QueryFilter: &ethpb.ListValidatorBalancesRequest_Epoch{Epoch: 1},
+		})
+		assert.ErrorContains(t, "failed to get state validators for slot `32`: foo error", err)
+	})
+
+	t.Run("invalid validator status", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(ctx, gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return(
+			&apimiddleware.StateValidatorsResponseJson{
+				Data: []*apimiddleware.ValidatorContainerJson{
+					{
+						Index:     "1",
+						Balance:   "2",
+						Status:    "foo",
+						Validator: &apimiddleware.ValidatorJson{PublicKey: hexutil.Encode([]byte{3})},
+					},
+				},
+			},
+			nil,
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{stateValidatorsProvider: stateValidatorsProvider}
+		_, err := beaconChainClient.ListValidatorBalances(ctx, &ethpb.ListValidatorBalancesRequest{
+			QueryFilter: &ethpb.ListValidatorBalancesRequest_Epoch{Epoch: 1},
+		})
+		assert.ErrorContains(t, "invalid validator status `foo`", err)
+	})
+
+	t.Run("returns paginated balances", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		pubkeys := [][]byte{{1}, {2}, {3}}
+		stateValidatorsProvider := mock.NewMockstateValidatorsProvider(ctrl)
+		stateValidatorsProvider.EXPECT().GetStateValidatorsForSlot(
+			ctx,
+			primitives.Slot(64),
+			[]string{hexutil.Encode(pubkeys[0]), hexutil.Encode(pubkeys[1]), hexutil.Encode(pubkeys[2])},
+			[]primitives.ValidatorIndex{4},
+			nil,
+		).Return(
+			&apimiddleware.StateValidatorsResponseJson{
+				Data: []*apimiddleware.ValidatorContainerJson{
+					{Index: "1", Balance: "11", Status: "active_ongoing", Validator: &apimiddleware.ValidatorJson{PublicKey: hexutil.Encode(pubkeys[0])}},
+					{Index: "2", Balance: "22", Status: "exited_slashed", Validator: &apimiddleware.ValidatorJson{PublicKey: hexutil.Encode(pubkeys[1])}},
+					{Index: "3", Balance: "33", Status: "pending_queued", Validator: &apimiddleware.ValidatorJson{PublicKey: hexutil.Encode(pubkeys[2])}},
+				},
+			},
+			nil,
+		).Times(2)
+
+		beaconChainClient := beaconApiBeaconChainClient{stateValidatorsProvider: stateValidatorsProvider}
+		request := &ethpb.ListValidatorBalancesRequest{
+			QueryFilter: &ethpb.ListValidatorBalancesRequest_Epoch{Epoch: 2},
+			PublicKeys:  pubkeys,
+			Indices:     []primitives.ValidatorIndex{4},
+			PageSize:    2,
+		}
+
+		balances, err := beaconChainClient.ListValidatorBalances(ctx, request)
+		require.NoError(t, err)
+		assert.DeepEqual(t, &ethpb.ValidatorBalances{
+			Epoch: 2,
+			Balances: []*ethpb.ValidatorBalances_Balance{
+				{PublicKey: pubkeys[0], Index: 1, Balance: 11, Status: "ACTIVE"},
+				{PublicKey: pubkeys[1], Index: 2, Balance: 22, Status: "EXITED"},
+			},
+			NextPageToken: "1",
+			TotalSize:     3,
+		}, balances)
+
+		request.PageToken = balances.NextPageToken
+		balances, err = beaconChainClient.ListValidatorBalances(ctx, request)
+		require.NoError(t, err)
+		assert.DeepEqual(t, &ethpb.ValidatorBalances{
+			Epoch: 2,
+			Balances: []*ethpb.ValidatorBalances_Balance{
+				{PublicKey: pubkeys[2], Index: 3, Balance: 33, Status: "PENDING"},
+			},
+			TotalSize: 3,
+		}, balances)
+	})
+}
+
+func TestGetValidatorQueue(t *testing.T) {
+	const endpoint = "/eth/v1alpha1/validators/queue"
+
+	t.Run("fails to query REST endpoint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, endpoint, gomock.Any()).Return(nil, errors.New("foo error"))
+
+		beaconChainClient := beaconApiBeaconChainClient{jsonRestHandler: jsonRestHandler}
+		_, err := beaconChainClient.GetValidatorQueue(ctx, &emptypb.Empty{})
+		assert.ErrorContains(t, "failed to query /eth/v1alpha1/validators/queue: foo error", err)
+	})
+
+	t.Run("decodes the gateway response", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, endpoint, gomock.Any()).DoAndReturn(
+			gatewayResponse(`{"churnLimit":"4","activationValidatorIndices":["1","2"],"exitValidatorIndices":[],"unknownField":true}`),
+		)
+
+		beaconChainClient := beaconApiBeaconChainClient{jsonRestHandler: jsonRestHandler}
+		queue, err := beaconChainClient.GetValidatorQueue(ctx, &emptypb.Empty{})
+		require.NoError(t, err)
+		assert.DeepEqual(t, &ethpb.ValidatorQueue{
+			ChurnLimit:                 4,
+			ActivationValidatorIndices: []primitives.ValidatorIndex{1, 2},
+		}, queue)
+	})
+}
+
+func TestGetValidatorPerformance(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	ctx := context.Background()
+
+	jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+	jsonRestHandler.EXPECT().GetRestJsonResponse(
+		ctx,
+		"/eth/v1alpha1/validators/performance?indices=2&indices=3&public_keys=AQ%3D%3D",
+		gomock.Any(),
+	).DoAndReturn(
+		gatewayResponse(`{"currentEffectiveBalances":["32000000000"],"missingValidators":["AQ=="]}`),
+	)
+
+	beaconChainClient := beaconApiBeaconChainClient{jsonRestHandler: jsonRestHandler}
+	performance, err := beaconChainClient.GetValidatorPerformance(ctx, &ethpb.ValidatorPerformanceRequest{
+		PublicKeys: [][]byte{{1}},
+		Indices:    []primitives.ValidatorIndex{2, 3},
+	})
+	require.NoError(t, err)
+	assert.DeepEqual(t, &ethpb.ValidatorPerformanceResponse{
+		CurrentEffectiveBalances: []uint64{32000000000},
+		MissingValidators:        [][]byte{{1}},
+	}, performance)
+}
+
+func TestGetValidatorParticipation(t *testing.T) {
+	testCases := []struct {
+		name             string
+		request          *ethpb.GetValidatorParticipationRequest
+		expectedEndpoint string
+	}{
+		{
+			name:             "epoch filter",
+			request:          &ethpb.GetValidatorParticipationRequest{QueryFilter: &ethpb.GetValidatorParticipationRequest_Epoch{Epoch: 5}},
+			expectedEndpoint: "/eth/v1alpha1/validators/participation?epoch=5",
+		},
+		{
+			name:             "genesis filter",
+			request:          &ethpb.GetValidatorParticipationRequest{QueryFilter: &ethpb.GetValidatorParticipationRequest_Genesis{Genesis: true}},
+			expectedEndpoint: "/eth/v1alpha1/validators/participation?genesis=true",
+		},
+		{
+			name:             "nil filter",
+			request:          &ethpb.GetValidatorParticipationRequest{},
+			expectedEndpoint: "/eth/v1alpha1/validators/participation",
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, testCase.expectedEndpoint, gomock.Any()).DoAndReturn(
+				gatewayResponse(`{"epoch":"5","finalized":true}`),
+			)
+
+			beaconChainClient := beaconApiBeaconChainClient{jsonRestHandler: jsonRestHandler}
+			participation, err := beaconChainClient.GetValidatorParticipation(ctx, testCase.request)
+			require.NoError(t, err)
+			assert.DeepEqual(t, &ethpb.ValidatorParticipationResponse{Epoch: 5, Finalized: true}, participation)
+		})
+	}
+}
@@ -12,6 +12,8 @@ import (
 	"github.com/pkg/errors"
 	"github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
 	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"google.golang.org/protobuf/encoding/protojson"
+	"google.golang.org/protobuf/proto"
 
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 )
@@ -28,6 +30,17 @@ var beaconAPITogRPCValidatorStatus = map[string]ethpb.ValidatorStatus{
 	"withdrawal_done":     ethpb.ValidatorStatus_EXITED,
 }
 
+// protoJsonResponse decodes a response of the Prysm-specific REST API (/eth/v1alpha1/...) into a protobuf message.
+// That API is served by the gRPC gateway, which encodes messages using the protobuf JSON mapping rather than
+// the JSON structs of the standard Beacon API.
+type protoJsonResponse struct {
+	message proto.Message
+}
+
+func (r *protoJsonResponse) UnmarshalJSON(data []byte) error {
+	return protojson.UnmarshalOptions{DiscardUnknown: true}.Unmarshal(data, r.message)
+}
+
 func validRoot(root string) bool {
 	matchesRegex, err := regexp.MatchString("^0x[a-fA-F0-9]{64}$", root)
 	if err != nil {
@@ -41,7 +54,7 @@ func uint64ToString[T uint64 | primitives.Slot | primitives.ValidatorIndex | pri
 }
 
 func buildURL(path string, queryParams ...neturl.Values) string {
-	if len(queryParams) == 0 {
+	if len(queryParams) == 0 || len(queryParams[0]) == 0 {
 		return path
 	}
 
@@ -79,6 +79,12 @@ func TestBuildURL_NoParams(t *testing.T) {
 	assert.Equal(t, wanted, actual)
 }
 
+func TestBuildURL_EmptyParams(t *testing.T) {
+	wanted := "/aaa/bbb/ccc"
+	actual := buildURL("/aaa/bbb/ccc", url.Values{})
+	assert.Equal(t, wanted, actual)
+}
+
 func TestBuildURL_WithParams(t *testing.T) {
 	params := url.Values{}
 	params.Add("xxxx", "1")
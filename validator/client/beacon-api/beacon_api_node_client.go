@@ -2,8 +2,10 @@ package beacon_api
 
 import (
 	"context"
-	"net/http"
+	"fmt"
+	neturl "net/url"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -16,7 +18,6 @@ import (
 )
 
 type beaconApiNodeClient struct {
-	fallbackClient  iface.NodeClient
 	jsonRestHandler jsonRestHandler
 	genesisProvider genesisProvider
 }
@@ -75,33 +76,76 @@ func (c *beaconApiNodeClient) GetGenesis(ctx context.Context, _ *empty.Empty) (*
 	}, nil
 }
 
-func (c *beaconApiNodeClient) GetVersion(ctx context.Context, in *empty.Empty) (*ethpb.Version, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.GetVersion(ctx, in)
+func (c *beaconApiNodeClient) GetVersion(ctx context.Context, _ *empty.Empty) (*ethpb.Version, error) {
+	const endpoint = "/eth/v1/node/version"
+
+	versionResponse := apimiddleware.VersionResponseJson{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, endpoint, &versionResponse); err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", endpoint)
+	}
+
+	if versionResponse.Data == nil {
+		return nil, errors.New("version data is nil")
 	}
 
-	// TODO: Implement me
-	panic("beaconApiNodeClient.GetVersion is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiNodeClientWithFallback.")
+	return &ethpb.Version{
+		Version: versionResponse.Data.Version,
+	}, nil
 }
 
-func (c *beaconApiNodeClient) ListPeers(ctx context.Context, in *empty.Empty) (*ethpb.Peers, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.ListPeers(ctx, in)
+// ListPeers returns the peers the beacon node is connected to, in the same format as the gRPC API.
+func (c *beaconApiNodeClient) ListPeers(ctx context.Context, _ *empty.Empty) (*ethpb.Peers, error) {
+	const endpoint = "/eth/v1/node/peers"
+
+	queryParams := neturl.Values{}
+	queryParams.Add("state", "connected")
+
+	peersResponse := apimiddleware.PeersResponseJson{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, buildURL(endpoint, queryParams), &peersResponse); err != nil {
+		return nil, errors.Wrapf(err, "failed to query %s", endpoint)
+	}
+
+	peers := make([]*ethpb.Peer, len(peersResponse.Data))
+	for idx, peer := range peersResponse.Data {
+		if peer == nil {
+			return nil, errors.Errorf("peer at index `%d` is nil", idx)
+		}
+
+		connectionState, ok := ethpb.ConnectionState_value[strings.ToUpper(peer.State)]
+		if !ok {
+			return nil, errors.Errorf("invalid peer connection state `%s`", peer.State)
+		}
+
+		direction, ok := ethpb.PeerDirection_value[strings.ToUpper(peer.Direction)]
+		if !ok {
+			return nil, errors.Errorf("invalid peer direction `%s`", peer.Direction)
+		}
+
+		// The gRPC API returns the peer's address including its ID, and the ENR without the `enr:` prefix.
+		address := peer.Address
+		if !strings.Contains(address, "/p2p/") {
+			address = fmt.Sprintf("%s/p2p/%s", address, peer.PeerId)
+		}
+
+		peers[idx] = &ethpb.Peer{
+			Address:         address,
+			Direction:       ethpb.PeerDirection(direction),
+			ConnectionState: ethpb.ConnectionState(connectionState),
+			PeerId:          peer.PeerId,
+			Enr:             strings.TrimPrefix(peer.Enr, "enr:"),
+		}
 	}
 
-	// TODO: Implement me
-	panic("beaconApiNodeClient.ListPeers is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiNodeClientWithFallback.")
+	return &ethpb.Peers{
+		Peers: peers,
+	}, nil
 }
 
-func NewNodeClientWithFallback(host string, timeout time.Duration, fallbackClient iface.NodeClient) iface.NodeClient {
-	jsonRestHandler := beaconApiJsonRestHandler{
-		httpClient: http.Client{Timeout: timeout},
-		host:       host,
-	}
+func NewBeaconApiNodeClient(host string, timeout time.Duration) iface.NodeClient {
+	jsonRestHandler := newJsonRestHandler(host, timeout)
 
 	return &beaconApiNodeClient{
 		jsonRestHandler: jsonRestHandler,
-		fallbackClient:  fallbackClient,
 		genesisProvider: beaconApiGenesisProvider{jsonRestHandler: jsonRestHandler},
 	}
 }
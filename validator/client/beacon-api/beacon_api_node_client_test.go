@@ -228,3 +228,171 @@ func TestGetSyncStatus(t *testing.T) {
 		})
 	}
 }
+
+func TestGetVersion(t *testing.T) {
+	const versionEndpoint = "/eth/v1/node/version"
+
+	testCases := []struct {
+		name                 string
+		restEndpointResponse apimiddleware.VersionResponseJson
+		restEndpointError    error
+		expectedResponse     *ethpb.Version
+		expectedError        string
+	}{
+		{
+			name:              "fails to query REST endpoint",
+			restEndpointError: errors.New("foo error"),
+			expectedError:     "failed to query /eth/v1/node/version: foo error",
+		},
+		{
+			name:                 "returns nil version data",
+			restEndpointResponse: apimiddleware.VersionResponseJson{Data: nil},
+			expectedError:        "version data is nil",
+		},
+		{
+			name: "returns proper version response",
+			restEndpointResponse: apimiddleware.VersionResponseJson{
+				Data: &apimiddleware.VersionJson{
+					Version: "Prysm/v4.0.0 (linux amd64)",
+				},
+			},
+			expectedResponse: &ethpb.Version{
+				Version: "Prysm/v4.0.0 (linux amd64)",
+			},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			versionResponse := apimiddleware.VersionResponseJson{}
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().GetRestJsonResponse(
+				ctx,
+				versionEndpoint,
+				&versionResponse,
+			).Return(
+				nil,
+				testCase.restEndpointError,
+			).SetArg(
+				2,
+				testCase.restEndpointResponse,
+			)
+
+			nodeClient := &beaconApiNodeClient{jsonRestHandler: jsonRestHandler}
+			version, err := nodeClient.GetVersion(ctx, &emptypb.Empty{})
+
+			if testCase.expectedResponse == nil {
+				assert.ErrorContains(t, testCase.expectedError, err)
+			} else {
+				assert.DeepEqual(t, testCase.expectedResponse, version)
+			}
+		})
+	}
+}
+
+func TestListPeers(t *testing.T) {
+	const peersEndpoint = "/eth/v1/node/peers?state=connected"
+
+	testCases := []struct {
+		name                 string
+		restEndpointResponse apimiddleware.PeersResponseJson
+		restEndpointError    error
+		expectedResponse     *ethpb.Peers
+		expectedError        string
+	}{
+		{
+			name:              "fails to query REST endpoint",
+			restEndpointError: errors.New("foo error"),
+			expectedError:     "failed to query /eth/v1/node/peers: foo error",
+		},
+		{
+			name:                 "nil peer",
+			restEndpointResponse: apimiddleware.PeersResponseJson{Data: []*apimiddleware.PeerJson{nil}},
+			expectedError:        "peer at index `0` is nil",
+		},
+		{
+			name: "invalid connection state",
+			restEndpointResponse: apimiddleware.PeersResponseJson{Data: []*apimiddleware.PeerJson{
+				{State: "foo", Direction: "inbound"},
+			}},
+			expectedError: "invalid peer connection state `foo`",
+		},
+		{
+			name: "invalid direction",
+			restEndpointResponse: apimiddleware.PeersResponseJson{Data: []*apimiddleware.PeerJson{
+				{State: "connected", Direction: "bar"},
+			}},
+			expectedError: "invalid peer direction `bar`",
+		},
+		{
+			name: "returns proper peers response",
+			restEndpointResponse: apimiddleware.PeersResponseJson{Data: []*apimiddleware.PeerJson{
+				{
+					PeerId:    "peer1",
+					Enr:       "enr:foo",
+					Address:   "/ip4/1.2.3.4/tcp/13000",
+					State:     "connected",
+					Direction: "inbound",
+				},
+				{
+					PeerId:    "peer2",
+					Enr:       "bar",
+					Address:   "/ip4/5.6.7.8/tcp/13000/p2p/peer2",
+					State:     "connected",
+					Direction: "outbound",
+				},
+			}},
+			expectedResponse: &ethpb.Peers{Peers: []*ethpb.Peer{
+				{
+					Address:         "/ip4/1.2.3.4/tcp/13000/p2p/peer1",
+					Direction:       ethpb.PeerDirection_INBOUND,
+					ConnectionState: ethpb.ConnectionState_CONNECTED,
+					PeerId:          "peer1",
+					Enr:             "foo",
+				},
+				{
+					Address:         "/ip4/5.6.7.8/tcp/13000/p2p/peer2",
+					Direction:       ethpb.PeerDirection_OUTBOUND,
+					ConnectionState: ethpb.ConnectionState_CONNECTED,
+					PeerId:          "peer2",
+					Enr:             "bar",
+				},
+			}},
+		},
+	}
+
+	for _, testCase := range testCases {
+		t.Run(testCase.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			defer ctrl.Finish()
+			ctx := context.Background()
+
+			peersResponse := apimiddleware.PeersResponseJson{}
+			jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+			jsonRestHandler.EXPECT().GetRestJsonResponse(
+				ctx,
+				peersEndpoint,
+				&peersResponse,
+			).Return(
+				nil,
+				testCase.restEndpointError,
+			).SetArg(
+				2,
+				testCase.restEndpointResponse,
+			)
+
+			nodeClient := &beaconApiNodeClient{jsonRestHandler: jsonRestHandler}
+			peers, err := nodeClient.ListPeers(ctx, &emptypb.Empty{})
+
+			if testCase.expectedResponse == nil {
+				assert.ErrorContains(t, testCase.expectedError, err)
+			} else {
+				assert.DeepEqual(t, testCase.expectedResponse, peers)
+			}
+		})
+	}
+}
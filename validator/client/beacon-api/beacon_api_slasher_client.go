@@ -1,45 +1,69 @@
 package beacon_api
 
 import (
+	"bytes"
 	"context"
-	"net/http"
+	"encoding/base64"
+	neturl "net/url"
 	"time"
 
+	"github.com/pkg/errors"
 	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
 	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"google.golang.org/protobuf/encoding/protojson"
 )
 
 type beaconApiSlasherClient struct {
-	fallbackClient  iface.SlasherClient
 	jsonRestHandler jsonRestHandler
 }
 
+// IsSlashableAttestation has no equivalent in the standard Beacon API, so it uses the Prysm-specific REST API
+// which is only available when the beacon node runs the slasher.
 func (c beaconApiSlasherClient) IsSlashableAttestation(ctx context.Context, in *ethpb.IndexedAttestation) (*ethpb.AttesterSlashingResponse, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.IsSlashableAttestation(ctx, in)
+	const endpoint = "/eth/v1alpha1/slasher/attestations/slashable"
+
+	marshalledAttestation, err := protojson.Marshal(in)
+	if err != nil {
+		return nil, errors.Wrap(err, "failed to marshal indexed attestation")
+	}
+
+	response := &ethpb.AttesterSlashingResponse{}
+	if _, err := c.jsonRestHandler.PostRestJson(ctx, endpoint, nil, bytes.NewBuffer(marshalledAttestation), &protoJsonResponse{message: response}); err != nil {
+		return nil, errors.Wrapf(err, "failed to send POST data to `%s` REST URL", endpoint)
 	}
 
-	// TODO: Implement me
-	panic("beaconApiSlasherClient.IsSlashableAttestation is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiSlasherClientWithFallback.")
+	return response, nil
 }
 
+// IsSlashableBlock has no equivalent in the standard Beacon API, so it uses the Prysm-specific REST API
+// which is only available when the beacon node runs the slasher.
 func (c beaconApiSlasherClient) IsSlashableBlock(ctx context.Context, in *ethpb.SignedBeaconBlockHeader) (*ethpb.ProposerSlashingResponse, error) {
-	if c.fallbackClient != nil {
-		return c.fallbackClient.IsSlashableBlock(ctx, in)
+	const endpoint = "/eth/v1alpha1/slasher/blocks/slashable"
+
+	if in == nil || in.Header == nil {
+		return nil, errors.New("block header is nil")
 	}
 
-	// TODO: Implement me
-	panic("beaconApiSlasherClient.IsSlashableBlock is not implemented. To use a fallback client, pass a fallback client as the last argument of NewBeaconApiSlasherClientWithFallback.")
-}
+	queryParams := neturl.Values{}
+	queryParams.Add("header.slot", uint64ToString(in.Header.Slot))
+	queryParams.Add("header.proposer_index", uint64ToString(in.Header.ProposerIndex))
+	queryParams.Add("header.parent_root", base64.StdEncoding.EncodeToString(in.Header.ParentRoot))
+	queryParams.Add("header.state_root", base64.StdEncoding.EncodeToString(in.Header.StateRoot))
+	queryParams.Add("header.body_root", base64.StdEncoding.EncodeToString(in.Header.BodyRoot))
+	queryParams.Add("signature", base64.StdEncoding.EncodeToString(in.Signature))
 
-func NewSlasherClientWithFallback(host string, timeout time.Duration, fallbackClient iface.SlasherClient) iface.SlasherClient {
-	jsonRestHandler := beaconApiJsonRestHandler{
-		httpClient: http.Client{Timeout: timeout},
-		host:       host,
+	response := &ethpb.ProposerSlashingResponse{}
+	if _, err := c.jsonRestHandler.GetRestJsonResponse(ctx, buildURL(endpoint, queryParams), &protoJsonResponse{message: response}); err != nil {
+		return nil, errors.Wrapf(err, "failed to get json response from `%s` REST endpoint", endpoint)
 	}
 
+	return response, nil
+}
+
+func NewBeaconApiSlasherClient(host string, timeout time.Duration) iface.SlasherClient {
+	jsonRestHandler := newJsonRestHandler(host, timeout)
+
 	return &beaconApiSlasherClient{
 		jsonRestHandler: jsonRestHandler,
-		fallbackClient:  fallbackClient,
 	}
 }
@@ -0,0 +1,137 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/beacon-api/mock"
+	"google.golang.org/protobuf/encoding/protojson"
+)
+
+func TestIsSlashableAttestation(t *testing.T) {
+	const endpoint = "/eth/v1alpha1/slasher/attestations/slashable"
+
+	attestation := &ethpb.IndexedAttestation{
+		AttestingIndices: []uint64{1, 2},
+		Data: &ethpb.AttestationData{
+			Slot:            3,
+			BeaconBlockRoot: make([]byte, 32),
+			Source:          &ethpb.Checkpoint{Epoch: 1, Root: make([]byte, 32)},
+			Target:          &ethpb.Checkpoint{Epoch: 2, Root: make([]byte, 32)},
+		},
+		Signature: make([]byte, 96),
+	}
+	marshalledAttestation, err := protojson.Marshal(attestation)
+	require.NoError(t, err)
+
+	t.Run("fails to query REST endpoint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().PostRestJson(ctx, endpoint, nil, bytes.NewBuffer(marshalledAttestation), gomock.Any()).Return(
+			nil,
+			errors.New("foo error"),
+		)
+
+		slasherClient := beaconApiSlasherClient{jsonRestHandler: jsonRestHandler}
+		_, err := slasherClient.IsSlashableAttestation(ctx, attestation)
+		assert.ErrorContains(t, "failed to send POST data to `/eth/v1alpha1/slasher/attestations/slashable` REST URL: foo error", err)
+	})
+
+	t.Run("decodes the gateway response", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().PostRestJson(ctx, endpoint, nil, bytes.NewBuffer(marshalledAttestation), gomock.Any()).DoAndReturn(
+			func(_ context.Context, _ string, _ map[string]string, _ *bytes.Buffer, responseJson interface{}) (*apimiddleware.DefaultErrorJson, error) {
+				return nil, json.Unmarshal([]byte(`{"attesterSlashings":[{"attestation_1":{"attestingIndices":["1"]}}]}`), responseJson)
+			},
+		)
+
+		slasherClient := beaconApiSlasherClient{jsonRestHandler: jsonRestHandler}
+		response, err := slasherClient.IsSlashableAttestation(ctx, attestation)
+		require.NoError(t, err)
+		assert.DeepEqual(t, &ethpb.AttesterSlashingResponse{
+			AttesterSlashings: []*ethpb.AttesterSlashing{
+				{Attestation_1: &ethpb.IndexedAttestation{AttestingIndices: []uint64{1}}},
+			},
+		}, response)
+	})
+}
+
+func TestIsSlashableBlock(t *testing.T) {
+	t.Run("nil header", func(t *testing.T) {
+		slasherClient := beaconApiSlasherClient{}
+		_, err := slasherClient.IsSlashableBlock(context.Background(), &ethpb.SignedBeaconBlockHeader{})
+		assert.ErrorContains(t, "block header is nil", err)
+	})
+
+	t.Run("fails to query REST endpoint", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(ctx, gomock.Any(), gomock.Any()).Return(
+			nil,
+			errors.New("foo error"),
+		)
+
+		slasherClient := beaconApiSlasherClient{jsonRestHandler: jsonRestHandler}
+		_, err := slasherClient.IsSlashableBlock(ctx, &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{}})
+		assert.ErrorContains(t, "failed to get json response from `/eth/v1alpha1/slasher/blocks/slashable` REST endpoint: foo error", err)
+	})
+
+	t.Run("decodes the gateway response", func(t *testing.T) {
+		ctrl := gomock.NewController(t)
+		defer ctrl.Finish()
+		ctx := context.Background()
+
+		jsonRestHandler := mock.NewMockjsonRestHandler(ctrl)
+		jsonRestHandler.EXPECT().GetRestJsonResponse(
+			ctx,
+			"/eth/v1alpha1/slasher/blocks/slashable?header.body_root=Aw%3D%3D&header.parent_root=AQ%3D%3D&header.proposer_index=2&header.slot=1&header.state_root=Ag%3D%3D&signature=BA%3D%3D",
+			gomock.Any(),
+		).DoAndReturn(
+			gatewayResponse(`{"proposerSlashings":[{"header_1":{"header":{"slot":"1"}}}]}`),
+		)
+
+		slasherClient := beaconApiSlasherClient{jsonRestHandler: jsonRestHandler}
+		response, err := slasherClient.IsSlashableBlock(ctx, &ethpb.SignedBeaconBlockHeader{
+			Header: &ethpb.BeaconBlockHeader{
+				Slot:          1,
+				ProposerIndex: 2,
+				ParentRoot:    []byte{1},
+				StateRoot:     []byte{2},
+				BodyRoot:      []byte{3},
+			},
+			Signature: []byte{4},
+		})
+		require.NoError(t, err)
+		assert.DeepEqual(t, &ethpb.ProposerSlashingResponse{
+			ProposerSlashings: []*ethpb.ProposerSlashing{
+				{Header_1: &ethpb.SignedBeaconBlockHeader{Header: &ethpb.BeaconBlockHeader{Slot: 1}}},
+			},
+		}, response)
+	})
+}
+
+// gatewayResponse returns a mock implementation of GetRestJsonResponse that decodes the given body,
+// as served by the gRPC gateway, into the response object.
+func gatewayResponse(body string) func(context.Context, string, interface{}) (*apimiddleware.DefaultErrorJson, error) {
+	return func(_ context.Context, _ string, responseJson interface{}) (*apimiddleware.DefaultErrorJson, error) {
+		return nil, json.Unmarshal([]byte(body), responseJson)
+	}
+}
@@ -0,0 +1,39 @@
+package beacon_api
+
+import (
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+)
+
+var (
+	// beaconNodeActiveGauge is 1 for the beacon node currently serving the requests of the validator.
+	beaconNodeActiveGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_active",
+			Help:      "1 if the beacon node is the one the validator currently sends REST API requests to, 0 otherwise",
+		},
+		[]string{
+			"host",
+		},
+	)
+	// beaconNodeHealthyGauge is 1 for the beacon nodes that passed the last health check.
+	beaconNodeHealthyGauge = promauto.NewGaugeVec(
+		prometheus.GaugeOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_healthy",
+			Help:      "1 if the beacon node is ready, synced and not behind the other beacon nodes, 0 otherwise",
+		},
+		[]string{
+			"host",
+		},
+	)
+	// beaconNodeFailoverCount counts the switches of the active beacon node.
+	beaconNodeFailoverCount = promauto.NewCounter(
+		prometheus.CounterOpts{
+			Namespace: "validator",
+			Name:      "beacon_node_failovers_total",
+			Help:      "The number of times the validator switched to another beacon node",
+		},
+	)
+)
@@ -0,0 +1,367 @@
+package beacon_api
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/api/gateway/apimiddleware"
+	rpcmiddleware "github.com/prysmaticlabs/prysm/v4/beacon-chain/rpc/apimiddleware"
+	"github.com/prysmaticlabs/prysm/v4/config/features"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/sirupsen/logrus"
+)
+
+// maxHeadSlotLag is the number of slots the head of a beacon node can be behind the most advanced beacon node
+// before the node is considered unhealthy.
+const maxHeadSlotLag = 2
+
+// broadcastEndpoints are the endpoints publishing validator messages which are sent to all beacon nodes when
+// broadcasting is enabled, so that the messages reach the network even if the active beacon node is poorly connected.
+var broadcastEndpoints = map[string]bool{
+	"/eth/v1/beacon/blocks":                     true,
+	"/eth/v1/beacon/blinded_blocks":             true,
+	"/eth/v1/beacon/pool/attestations":          true,
+	"/eth/v1/validator/aggregate_and_proofs":    true,
+	"/eth/v1/beacon/pool/sync_committees":       true,
+	"/eth/v1/validator/contribution_and_proofs": true,
+}
+
+var (
+	multipleEndpointsHandlers     = make(map[string]*multipleEndpointsJsonRestHandler)
+	multipleEndpointsHandlersLock sync.Mutex
+)
+
+// newJsonRestHandler returns the jsonRestHandler querying the beacon node REST API at host. When host is a
+// comma-separated list of endpoints, the same handler is returned for every client built from the list so that
+// they all agree on which beacon node is active.
+func newJsonRestHandler(host string, timeout time.Duration) jsonRestHandler {
+	hosts := strings.Split(host, ",")
+	if len(hosts) == 1 {
+		return beaconApiJsonRestHandler{
+			httpClient: http.Client{Timeout: timeout},
+			host:       host,
+		}
+	}
+
+	multipleEndpointsHandlersLock.Lock()
+	defer multipleEndpointsHandlersLock.Unlock()
+	key := host + "/" + timeout.String()
+	if handler, ok := multipleEndpointsHandlers[key]; ok {
+		return handler
+	}
+	for i := range hosts {
+		hosts[i] = strings.TrimSpace(hosts[i])
+	}
+	handler := newMultipleEndpointsJsonRestHandler(hosts, timeout, features.Get().EnableBeaconRESTApiBroadcast)
+	multipleEndpointsHandlers[key] = handler
+	return handler
+}
+
+// multipleEndpointsJsonRestHandler spreads the requests of the validator across several beacon nodes. Requests are
+// sent to the active beacon node, which is the first healthy node in the configured order, and are retried on the
+// other nodes when the active node cannot serve them. The health of the nodes is checked in the background at most
+// once per slot.
+type multipleEndpointsJsonRestHandler struct {
+	handlers            []beaconApiJsonRestHandler
+	hostLabels          []string
+	healthCheckInterval time.Duration
+	broadcast           bool
+
+	lock            sync.RWMutex
+	healthy         []bool
+	active          int
+	lastHealthCheck time.Time
+	checkingHealth  bool
+}
+
+func newMultipleEndpointsJsonRestHandler(hosts []string, timeout time.Duration, broadcast bool) *multipleEndpointsJsonRestHandler {
+	c := &multipleEndpointsJsonRestHandler{
+		handlers:            make([]beaconApiJsonRestHandler, len(hosts)),
+		hostLabels:          make([]string, len(hosts)),
+		healthCheckInterval: time.Duration(params.BeaconConfig().SecondsPerSlot) * time.Second,
+		broadcast:           broadcast,
+		healthy:             make([]bool, len(hosts)),
+	}
+	for i, host := range hosts {
+		c.handlers[i] = beaconApiJsonRestHandler{
+			httpClient: http.Client{Timeout: timeout},
+			host:       host,
+		}
+		c.hostLabels[i] = redactedHost(host)
+		c.healthy[i] = true
+		beaconNodeHealthyGauge.WithLabelValues(c.hostLabels[i]).Set(1)
+		beaconNodeActiveGauge.WithLabelValues(c.hostLabels[i]).Set(0)
+	}
+	beaconNodeActiveGauge.WithLabelValues(c.hostLabels[0]).Set(1)
+	return c
+}
+
+// GetRestJsonResponse sends a GET request to apiEndpoint of the active beacon node, failing over to the other
+// beacon nodes if the request fails.
+func (c *multipleEndpointsJsonRestHandler) GetRestJsonResponse(ctx context.Context, apiEndpoint string, responseJson interface{}) (*apimiddleware.DefaultErrorJson, error) {
+	if responseJson == nil {
+		return nil, errors.New("responseJson is nil")
+	}
+	return c.send(ctx, func(handler beaconApiJsonRestHandler) (*apimiddleware.DefaultErrorJson, error) {
+		return handler.GetRestJsonResponse(ctx, apiEndpoint, responseJson)
+	})
+}
+
+// PostRestJson sends a POST request to apiEndpoint of the active beacon node, failing over to the other beacon nodes
+// if the request fails. When broadcasting is enabled, messages published by the validator are sent to all beacon
+// nodes at once.
+func (c *multipleEndpointsJsonRestHandler) PostRestJson(ctx context.Context, apiEndpoint string, headers map[string]string, data *bytes.Buffer, responseJson interface{}) (*apimiddleware.DefaultErrorJson, error) {
+	if data == nil {
+		return nil, errors.New("POST data is nil")
+	}
+	// The body is read by every attempt, so each one gets its own buffer.
+	body := data.Bytes()
+	if c.broadcast && broadcastEndpoints[apiEndpoint] {
+		return c.broadcastPost(ctx, apiEndpoint, headers, body, responseJson)
+	}
+	return c.send(ctx, func(handler beaconApiJsonRestHandler) (*apimiddleware.DefaultErrorJson, error) {
+		return handler.PostRestJson(ctx, apiEndpoint, headers, bytes.NewBuffer(body), responseJson)
+	})
+}
+
+// send runs the request against the beacon nodes in failover order until one of them serves it.
+func (c *multipleEndpointsJsonRestHandler) send(
+	ctx context.Context,
+	request func(handler beaconApiJsonRestHandler) (*apimiddleware.DefaultErrorJson, error),
+) (*apimiddleware.DefaultErrorJson, error) {
+	c.maybeCheckHealth()
+
+	var errJson *apimiddleware.DefaultErrorJson
+	var err error
+	for _, i := range c.failoverOrder() {
+		errJson, err = request(c.handlers[i])
+		if !shouldFailOver(ctx, errJson, err) {
+			c.lock.Lock()
+			c.healthy[i] = true
+			beaconNodeHealthyGauge.WithLabelValues(c.hostLabels[i]).Set(1)
+			c.setActive(i)
+			c.lock.Unlock()
+			return errJson, err
+		}
+		log.WithError(err).WithField("host", c.hostLabels[i]).Debug("Beacon node request failed")
+		c.lock.Lock()
+		c.healthy[i] = false
+		beaconNodeHealthyGauge.WithLabelValues(c.hostLabels[i]).Set(0)
+		c.lock.Unlock()
+	}
+	return errJson, err
+}
+
+// broadcastPost sends the request to all beacon nodes concurrently. The response is only decoded from the active
+// beacon node, and the request succeeds if any beacon node accepts it.
+func (c *multipleEndpointsJsonRestHandler) broadcastPost(
+	ctx context.Context,
+	apiEndpoint string,
+	headers map[string]string,
+	body []byte,
+	responseJson interface{},
+) (*apimiddleware.DefaultErrorJson, error) {
+	c.maybeCheckHealth()
+
+	c.lock.RLock()
+	active := c.active
+	c.lock.RUnlock()
+
+	errJsons := make([]*apimiddleware.DefaultErrorJson, len(c.handlers))
+	errs := make([]error, len(c.handlers))
+	var wg sync.WaitGroup
+	for i, handler := range c.handlers {
+		var resp interface{}
+		if i == active {
+			resp = responseJson
+		}
+		wg.Add(1)
+		go func(i int, handler beaconApiJsonRestHandler, resp interface{}) {
+			defer wg.Done()
+			errJsons[i], errs[i] = handler.PostRestJson(ctx, apiEndpoint, headers, bytes.NewBuffer(body), resp)
+		}(i, handler, resp)
+	}
+	wg.Wait()
+
+	accepted := false
+	for i, err := range errs {
+		if err != nil {
+			log.WithError(err).WithFields(logrus.Fields{
+				"endpoint": apiEndpoint,
+				"host":     c.hostLabels[i],
+			}).Debug("Could not broadcast message to beacon node")
+			continue
+		}
+		accepted = true
+	}
+	if accepted {
+		return nil, nil
+	}
+	return errJsons[active], errs[active]
+}
+
+// failoverOrder returns the indices of the beacon nodes in the order requests should be tried: the active node
+// first, then the other healthy nodes and finally the unhealthy nodes, as their health may have changed since
+// the last check.
+func (c *multipleEndpointsJsonRestHandler) failoverOrder() []int {
+	c.lock.RLock()
+	defer c.lock.RUnlock()
+
+	order := make([]int, 0, len(c.handlers))
+	order = append(order, c.active)
+	for i := range c.handlers {
+		if i != c.active && c.healthy[i] {
+			order = append(order, i)
+		}
+	}
+	for i := range c.handlers {
+		if i != c.active && !c.healthy[i] {
+			order = append(order, i)
+		}
+	}
+	return order
+}
+
+// setActive makes the beacon node at index i the active node. The caller must hold the lock.
+func (c *multipleEndpointsJsonRestHandler) setActive(i int) {
+	if i == c.active {
+		return
+	}
+	log.WithFields(logrus.Fields{
+		"previousHost": c.hostLabels[c.active],
+		"host":         c.hostLabels[i],
+	}).Warn("Switched to another beacon node")
+	beaconNodeActiveGauge.WithLabelValues(c.hostLabels[c.active]).Set(0)
+	beaconNodeActiveGauge.WithLabelValues(c.hostLabels[i]).Set(1)
+	beaconNodeFailoverCount.Inc()
+	c.active = i
+}
+
+// maybeCheckHealth starts a health check of the beacon nodes in the background if the last one is older than the
+// health check interval.
+func (c *multipleEndpointsJsonRestHandler) maybeCheckHealth() {
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	if c.checkingHealth || time.Since(c.lastHealthCheck) < c.healthCheckInterval {
+		return
+	}
+	c.checkingHealth = true
+
+	go func() {
+		ctx, cancel := context.WithTimeout(context.Background(), c.healthCheckInterval)
+		defer cancel()
+		c.checkHealth(ctx)
+
+		c.lock.Lock()
+		defer c.lock.Unlock()
+		c.checkingHealth = false
+		c.lastHealthCheck = time.Now()
+	}()
+}
+
+// checkHealth queries the health and sync status of all beacon nodes, and makes the first healthy node the active
+// one. A beacon node is healthy if it is ready, not syncing and its head is no more than maxHeadSlotLag slots behind
+// the most advanced beacon node.
+func (c *multipleEndpointsJsonRestHandler) checkHealth(ctx context.Context) {
+	ready := make([]bool, len(c.handlers))
+	headSlots := make([]primitives.Slot, len(c.handlers))
+	var wg sync.WaitGroup
+	for i, handler := range c.handlers {
+		wg.Add(1)
+		go func(i int, handler beaconApiJsonRestHandler) {
+			defer wg.Done()
+			headSlot, err := nodeHeadSlot(ctx, handler)
+			if err != nil {
+				log.WithError(err).WithField("host", c.hostLabels[i]).Debug("Beacon node is not healthy")
+				return
+			}
+			ready[i] = true
+			headSlots[i] = headSlot
+		}(i, handler)
+	}
+	wg.Wait()
+
+	var bestHeadSlot primitives.Slot
+	for i := range c.handlers {
+		if ready[i] && headSlots[i] > bestHeadSlot {
+			bestHeadSlot = headSlots[i]
+		}
+	}
+
+	c.lock.Lock()
+	defer c.lock.Unlock()
+	for i := range c.handlers {
+		c.healthy[i] = ready[i] && headSlots[i]+maxHeadSlotLag >= bestHeadSlot
+		if c.healthy[i] {
+			beaconNodeHealthyGauge.WithLabelValues(c.hostLabels[i]).Set(1)
+		} else {
+			beaconNodeHealthyGauge.WithLabelValues(c.hostLabels[i]).Set(0)
+		}
+	}
+	for i := range c.handlers {
+		if c.healthy[i] {
+			c.setActive(i)
+			return
+		}
+	}
+}
+
+// nodeHeadSlot returns the head slot of the beacon node, or an error if the node is not ready or is syncing.
+func nodeHeadSlot(ctx context.Context, handler beaconApiJsonRestHandler) (primitives.Slot, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, handler.host+"/eth/v1/node/health", nil)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to create request with context")
+	}
+	resp, err := handler.httpClient.Do(req)
+	if err != nil {
+		return 0, errors.Wrap(err, "failed to query node health")
+	}
+	if err := resp.Body.Close(); err != nil {
+		return 0, errors.Wrap(err, "failed to close response body")
+	}
+	if resp.StatusCode != http.StatusOK {
+		return 0, errors.Errorf("node health status %d", resp.StatusCode)
+	}
+
+	syncingResponse := rpcmiddleware.SyncingResponseJson{}
+	if _, err := handler.GetRestJsonResponse(ctx, "/eth/v1/node/syncing", &syncingResponse); err != nil {
+		return 0, errors.Wrap(err, "failed to get sync status")
+	}
+	if syncingResponse.Data == nil {
+		return 0, errors.New("sync status data is nil")
+	}
+	if syncingResponse.Data.IsSyncing {
+		return 0, errors.New("node is syncing")
+	}
+	headSlot, err := strconv.ParseUint(syncingResponse.Data.HeadSlot, 10, 64)
+	if err != nil {
+		return 0, errors.Wrapf(err, "failed to parse head slot %s", syncingResponse.Data.HeadSlot)
+	}
+	return primitives.Slot(headSlot), nil
+}
+
+// shouldFailOver returns true if the request failed in a way another beacon node may not. Client errors are
+// returned as they are, since other beacon nodes would reject the request as well.
+func shouldFailOver(ctx context.Context, errJson *apimiddleware.DefaultErrorJson, err error) bool {
+	if err == nil || ctx.Err() != nil {
+		return false
+	}
+	return errJson == nil || errJson.Code >= http.StatusInternalServerError
+}
+
+// redactedHost removes the credentials the host may contain, so that it can be logged and used as a metric label.
+func redactedHost(host string) string {
+	u, err := url.Parse(host)
+	if err != nil {
+		return host
+	}
+	return u.Redacted()
+}
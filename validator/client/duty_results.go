@@ -0,0 +1,77 @@
+package client
+
+import (
+	"sync"
+	"time"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+// dutyResultsSize is the number of attestation and proposal results kept in memory.
+const dutyResultsSize = 128
+
+// dutyResults keeps the results of the last attestations and proposals of the validator keys, for the status
+// dashboard. The zero value is ready to use.
+type dutyResults struct {
+	lock    sync.RWMutex
+	results []*iface.DutyResult
+	next    int
+}
+
+func (d *dutyResults) add(slot primitives.Slot, pubKey [fieldparams.BLSPubkeyLength]byte, role iface.ValidatorRole, err error) {
+	result := &iface.DutyResult{Slot: slot, PubKey: pubKey, Role: role, Err: err, Time: time.Now()}
+	d.lock.Lock()
+	defer d.lock.Unlock()
+	if len(d.results) < dutyResultsSize {
+		d.results = append(d.results, result)
+		return
+	}
+	d.results[d.next] = result
+	d.next = (d.next + 1) % dutyResultsSize
+}
+
+// recent returns the results, the most recent first.
+func (d *dutyResults) recent() []*iface.DutyResult {
+	d.lock.RLock()
+	defer d.lock.RUnlock()
+	results := make([]*iface.DutyResult, 0, len(d.results))
+	for i := len(d.results) - 1; i >= 0; i-- {
+		results = append(results, d.results[(d.next+i)%len(d.results)])
+	}
+	return results
+}
+
+// RecentDutyResults returns the results of the last attestations and proposals of the validator keys, the most
+// recent first.
+func (v *validator) RecentDutyResults() []*iface.DutyResult {
+	return v.dutyResults.recent()
+}
+
+// CurrentDuties returns a copy of the duties of the validator keys fetched for the current epoch. Unlike RolesAt, it
+// does not sign selection proofs to tell the aggregators apart, so it is safe to call outside of the validator loop.
+func (v *validator) CurrentDuties() map[[fieldparams.BLSPubkeyLength]byte]*iface.KeyDuties {
+	v.dutiesLock.RLock()
+	defer v.dutiesLock.RUnlock()
+	duties := make(map[[fieldparams.BLSPubkeyLength]byte]*iface.KeyDuties)
+	if v.duties == nil {
+		return duties
+	}
+	for i, duty := range v.duties.Duties {
+		if duty == nil {
+			continue
+		}
+		keyDuties := &iface.KeyDuties{
+			AttesterSlot:    duty.AttesterSlot,
+			ProposerSlots:   append([]primitives.Slot(nil), duty.ProposerSlots...),
+			IsSyncCommittee: duty.IsSyncCommittee,
+		}
+		if i < len(v.duties.NextEpochDuties) && v.duties.NextEpochDuties[i] != nil {
+			keyDuties.IsNextSyncCommittee = v.duties.NextEpochDuties[i].IsSyncCommittee
+		}
+		duties[bytesutil.ToBytes48(duty.PublicKey)] = keyDuties
+	}
+	return duties
+}
@@ -0,0 +1,59 @@
+package client
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+)
+
+func TestDutyResults(t *testing.T) {
+	v := &validator{}
+	assert.Equal(t, 0, len(v.RecentDutyResults()))
+
+	for i := 0; i < dutyResultsSize+10; i++ {
+		var err error
+		if i%2 == 0 {
+			err = errors.New("failed")
+		}
+		v.dutyResults.add(primitives.Slot(i), [48]byte{byte(i)}, iface.RoleAttester, err)
+	}
+	results := v.RecentDutyResults()
+	require.Equal(t, dutyResultsSize, len(results))
+	for i, result := range results {
+		assert.Equal(t, primitives.Slot(dutyResultsSize+10-1-i), result.Slot)
+	}
+	assert.Equal(t, nil, results[0].Err)
+	assert.NotNil(t, results[1].Err)
+}
+
+func TestCurrentDuties(t *testing.T) {
+	v := &validator{}
+	assert.Equal(t, 0, len(v.CurrentDuties()))
+
+	v.duties = &ethpb.DutiesResponse{
+		Duties: []*ethpb.DutiesResponse_Duty{
+			{PublicKey: []byte{1}, AttesterSlot: 3, ProposerSlots: []primitives.Slot{5}, IsSyncCommittee: true},
+			nil,
+		},
+		NextEpochDuties: []*ethpb.DutiesResponse_Duty{
+			{PublicKey: []byte{1}, IsSyncCommittee: false},
+		},
+	}
+	duties := v.CurrentDuties()
+	require.Equal(t, 1, len(duties))
+	keyDuties := duties[[48]byte{1}]
+	require.NotNil(t, keyDuties)
+	assert.Equal(t, primitives.Slot(3), keyDuties.AttesterSlot)
+	assert.DeepEqual(t, []primitives.Slot{5}, keyDuties.ProposerSlots)
+	assert.Equal(t, true, keyDuties.IsSyncCommittee)
+	assert.Equal(t, false, keyDuties.IsNextSyncCommittee)
+
+	// The duties are a copy.
+	keyDuties.ProposerSlots[0] = 6
+	assert.Equal(t, primitives.Slot(5), v.duties.Duties[0].ProposerSlots[0])
+}
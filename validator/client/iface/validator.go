@@ -35,6 +35,25 @@ const (
 	RoleSyncCommitteeAggregator
 )
 
+// DutyResult is the outcome of an attestation or a block proposal of a validator key.
+type DutyResult struct {
+	Slot   primitives.Slot
+	PubKey [fieldparams.BLSPubkeyLength]byte
+	Role   ValidatorRole
+	// Err is the reason the duty could not be performed, nil if the duty was submitted to the beacon node.
+	Err  error
+	Time time.Time
+}
+
+// KeyDuties are the duties of a validator key in the epoch of the last duties fetched from the beacon node.
+type KeyDuties struct {
+	AttesterSlot  primitives.Slot
+	ProposerSlots []primitives.Slot
+	// IsSyncCommittee is whether the key is in the sync committee of the epoch, and IsNextSyncCommittee of the next one.
+	IsSyncCommittee     bool
+	IsNextSyncCommittee bool
+}
+
 // Validator interface defines the primary methods of a validator client.
 type Validator interface {
 	Done()
@@ -64,6 +83,8 @@ type Validator interface {
 	SignValidatorRegistrationRequest(ctx context.Context, signer SigningFunc, newValidatorRegistration *ethpb.ValidatorRegistrationV1) (*ethpb.SignedValidatorRegistrationV1, error)
 	ProposerSettings() *validatorserviceconfig.ProposerSettings
 	SetProposerSettings(*validatorserviceconfig.ProposerSettings)
+	RecentDutyResults() []*DutyResult
+	CurrentDuties() map[[fieldparams.BLSPubkeyLength]byte]*KeyDuties
 }
 
 // SigningFunc interface defines a type for the a function that signs a message
@@ -9,12 +9,11 @@ import (
 )
 
 func NewNodeClient(validatorConn validatorHelpers.NodeConnection) iface.NodeClient {
-	grpcClient := grpcApi.NewNodeClient(validatorConn.GetGrpcClientConn())
 	featureFlags := features.Get()
 
 	if featureFlags.EnableBeaconRESTApi {
-		return beaconApi.NewNodeClientWithFallback(validatorConn.GetBeaconApiUrl(), validatorConn.GetBeaconApiTimeout(), grpcClient)
+		return beaconApi.NewBeaconApiNodeClient(validatorConn.GetBeaconApiUrl(), validatorConn.GetBeaconApiTimeout())
 	} else {
-		return grpcClient
+		return grpcApi.NewNodeClient(validatorConn.GetGrpcClientConn())
 	}
 }
@@ -32,6 +32,7 @@ import (
 	"github.com/prysmaticlabs/prysm/v4/validator/keymanager"
 	"github.com/prysmaticlabs/prysm/v4/validator/keymanager/local"
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v4/validator/keymanager/remote-web3signer"
+	shared "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-shared"
 	"go.opencensus.io/plugin/ocgrpc"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -74,6 +75,7 @@ type ValidatorService struct {
 	grpcHeaders           []string
 	graffiti              []byte
 	Web3SignerConfig      *remoteweb3signer.SetupConfig
+	sharedProtection      shared.Store
 	proposerSettings      *validatorserviceconfig.ProposerSettings
 }
 
@@ -97,6 +99,7 @@ type Config struct {
 	GraffitiFlag               string
 	Endpoint                   string
 	Web3SignerConfig           *remoteweb3signer.SetupConfig
+	SharedSlashingProtection   shared.Store
 	ProposerSettings           *validatorserviceconfig.ProposerSettings
 	BeaconApiEndpoint          string
 	BeaconApiTimeout           time.Duration
@@ -127,6 +130,7 @@ func NewValidatorService(ctx context.Context, cfg *Config) (*ValidatorService, e
 		interopKeysConfig:     cfg.InteropKeysConfig,
 		graffitiStruct:        cfg.GraffitiStruct,
 		Web3SignerConfig:      cfg.Web3SignerConfig,
+		sharedProtection:      cfg.SharedSlashingProtection,
 		proposerSettings:      cfg.ProposerSettings,
 	}
 
@@ -226,6 +230,7 @@ func (v *ValidatorService) Start() {
 		graffitiOrderedIndex:           graffitiOrderedIndex,
 		eipImportBlacklistedPublicKeys: slashablePublicKeys,
 		Web3SignerConfig:               v.Web3SignerConfig,
+		sharedSlashingProtection:       v.sharedProtection,
 		proposerSettings:               v.proposerSettings,
 		walletInitializedChannel:       make(chan *wallet.Wallet, 1),
 	}
@@ -281,6 +286,17 @@ func (v *ValidatorService) ProposerSettings() *validatorserviceconfig.ProposerSe
 	return nil
 }
 
+// CurrentDuties returns a copy of the duties of the validator keys fetched for the current epoch.
+func (v *ValidatorService) CurrentDuties() map[[fieldparams.BLSPubkeyLength]byte]*iface.KeyDuties {
+	return v.validator.CurrentDuties()
+}
+
+// RecentDutyResults returns the results of the last attestations and proposals of the validator keys, the most
+// recent first.
+func (v *ValidatorService) RecentDutyResults() []*iface.DutyResult {
+	return v.validator.RecentDutyResults()
+}
+
 // SetProposerSettings sets the proposer settings on the validator service as well as the underlying validator
 func (v *ValidatorService) SetProposerSettings(ctx context.Context, settings *validatorserviceconfig.ProposerSettings) error {
 	if v.db == nil {
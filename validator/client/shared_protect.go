@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	shared "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-shared"
+)
+
+const (
+	failedSharedAttestationCheckErr = "could not check attestation against shared slashing protection records"
+	failedSharedProposalCheckErr    = "could not check block against shared slashing protection records"
+	failedSharedReconcileErr        = "could not reconcile local slashing protection history with shared records"
+)
+
+// Checks the attestation against the slashing protection records shared with other validator clients using the
+// same keys, and records it, before it is signed.
+func (v *validator) sharedAttestationCheck(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, data *ethpb.AttestationData, signingRoot [32]byte,
+) error {
+	if v.sharedSlashingProtection == nil {
+		return nil
+	}
+	if err := v.reconcileSharedProtection(ctx, pubKey); err != nil {
+		return errors.Wrap(err, failedSharedReconcileErr)
+	}
+	if err := v.sharedSlashingProtection.CheckAndSaveAttestation(ctx, pubKey, data.Source.Epoch, data.Target.Epoch, signingRoot); err != nil {
+		if v.emitAccountMetrics {
+			ValidatorAttestFailVec.WithLabelValues(fmt.Sprintf("%#x", pubKey)).Inc()
+		}
+		return errors.Wrap(err, failedSharedAttestationCheckErr)
+	}
+	return nil
+}
+
+// Checks the proposal against the slashing protection records shared with other validator clients using the same
+// keys, and records it, before it is signed.
+func (v *validator) sharedProposalCheck(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, signingRoot [32]byte,
+) error {
+	if v.sharedSlashingProtection == nil {
+		return nil
+	}
+	if err := v.reconcileSharedProtection(ctx, pubKey); err != nil {
+		return errors.Wrap(err, failedSharedReconcileErr)
+	}
+	if err := v.sharedSlashingProtection.CheckAndSaveProposal(ctx, pubKey, slot, signingRoot); err != nil {
+		if v.emitAccountMetrics {
+			ValidatorProposeFailVec.WithLabelValues(fmt.Sprintf("%#x", pubKey)).Inc()
+		}
+		return errors.Wrap(err, failedSharedProposalCheckErr)
+	}
+	return nil
+}
+
+// Records the local slashing protection history of the key in the shared store the first time the key is checked,
+// so the shared records are never behind what this client has already signed.
+func (v *validator) reconcileSharedProtection(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte) error {
+	v.sharedReconciledLock.Lock()
+	defer v.sharedReconciledLock.Unlock()
+	if v.sharedReconciled[pubKey] {
+		return nil
+	}
+	if err := shared.Reconcile(ctx, v.sharedSlashingProtection, v.db, pubKey); err != nil {
+		return err
+	}
+	if v.sharedReconciled == nil {
+		v.sharedReconciled = make(map[[fieldparams.BLSPubkeyLength]byte]bool)
+	}
+	v.sharedReconciled[pubKey] = true
+	return nil
+}
@@ -0,0 +1,96 @@
+package client
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/blocks"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	shared "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-shared"
+)
+
+func Test_sharedAttestationCheck(t *testing.T) {
+	validator, _, validatorKey, finish := setup(t)
+	defer finish()
+	// The other validator client using the same key records its messages in the same file.
+	path := filepath.Join(t.TempDir(), "protection.db")
+	other, err := shared.NewFileStore(path)
+	require.NoError(t, err)
+	validator.sharedSlashingProtection, err = shared.NewFileStore(path)
+	require.NoError(t, err)
+
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	data := &ethpb.AttestationData{
+		BeaconBlockRoot: bytesutil.PadTo([]byte("great block"), 32),
+		Source:          &ethpb.Checkpoint{Epoch: 4, Root: make([]byte, 32)},
+		Target:          &ethpb.Checkpoint{Epoch: 5, Root: make([]byte, 32)},
+	}
+	ctx := context.Background()
+	require.NoError(t, other.CheckAndSaveAttestation(ctx, pubKey, 4, 5, [32]byte{1}))
+	err = validator.sharedAttestationCheck(ctx, pubKey, data, [32]byte{2})
+	require.ErrorContains(t, failedSharedAttestationCheckErr, err)
+	require.ErrorIs(t, err, shared.ErrSlashable)
+
+	data.Target.Epoch = 6
+	require.NoError(t, validator.sharedAttestationCheck(ctx, pubKey, data, [32]byte{2}))
+	require.ErrorIs(t, other.CheckAndSaveAttestation(ctx, pubKey, 5, 6, [32]byte{1}), shared.ErrSlashable)
+
+	// Without shared records, attestations are only checked against the local slashing protection database.
+	validator.sharedSlashingProtection = nil
+	data.Target.Epoch = 5
+	require.NoError(t, validator.sharedAttestationCheck(ctx, pubKey, data, [32]byte{2}))
+}
+
+func TestSignBlock_SharedSlashingProtection(t *testing.T) {
+	validator, m, _, finish := setup(t)
+	defer finish()
+	kp := testKeyFromBytes(t, []byte{1})
+	validator.keyManager = newMockKeymanager(t, kp)
+	path := filepath.Join(t.TempDir(), "protection.db")
+	other, err := shared.NewFileStore(path)
+	require.NoError(t, err)
+	validator.sharedSlashingProtection, err = shared.NewFileStore(path)
+	require.NoError(t, err)
+	m.validatorClient.EXPECT().
+		DomainData(gomock.Any(), gomock.Any()).
+		Return(&ethpb.DomainResponse{SignatureDomain: make([]byte, 32)}, nil)
+
+	// The other validator client signed a different block at the slot.
+	ctx := context.Background()
+	require.NoError(t, other.CheckAndSaveProposal(ctx, kp.pub, 1, [32]byte{1}))
+	blk := util.NewBeaconBlock()
+	blk.Block.Slot = 1
+	b, err := blocks.NewBeaconBlock(blk.Block)
+	require.NoError(t, err)
+	_, _, err = validator.signBlock(ctx, kp.pub, 0, 1, b)
+	require.ErrorContains(t, failedSharedProposalCheckErr, err)
+	require.ErrorIs(t, err, shared.ErrSlashable)
+}
+
+func Test_sharedProposalCheck_ReconcilesLocalHistory(t *testing.T) {
+	validator, _, validatorKey, finish := setup(t)
+	defer finish()
+	path := filepath.Join(t.TempDir(), "protection.db")
+	other, err := shared.NewFileStore(path)
+	require.NoError(t, err)
+	validator.sharedSlashingProtection, err = shared.NewFileStore(path)
+	require.NoError(t, err)
+
+	// The block at slot 10 was signed before shared slashing protection was enabled.
+	var pubKey [fieldparams.BLSPubkeyLength]byte
+	copy(pubKey[:], validatorKey.PublicKey().Marshal())
+	ctx := context.Background()
+	require.NoError(t, validator.db.SaveProposalHistoryForSlot(ctx, pubKey, 10, bytesutil.PadTo([]byte{1}, 32)))
+
+	require.NoError(t, validator.sharedProposalCheck(ctx, pubKey, 11, [32]byte{2}))
+	require.ErrorIs(t, other.CheckAndSaveProposal(ctx, pubKey, 10, [32]byte{3}), shared.ErrSlashable)
+	require.ErrorIs(t, other.CheckAndSaveProposal(ctx, pubKey, 11, [32]byte{3}), shared.ErrSlashable)
+	require.NoError(t, other.CheckAndSaveProposal(ctx, pubKey, 12, [32]byte{3}))
+}
@@ -284,3 +284,13 @@ func (f *FakeValidator) ProposerSettings() *validatorserviceconfig.ProposerSetti
 func (f *FakeValidator) SetProposerSettings(settings *validatorserviceconfig.ProposerSettings) {
 	f.proposerSettings = settings
 }
+
+// RecentDutyResults for mocking
+func (_ *FakeValidator) RecentDutyResults() []*iface.DutyResult {
+	return nil
+}
+
+// CurrentDuties for mocking
+func (_ *FakeValidator) CurrentDuties() map[[fieldparams.BLSPubkeyLength]byte]*iface.KeyDuties {
+	return nil
+}
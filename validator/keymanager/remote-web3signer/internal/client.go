@@ -38,6 +38,7 @@ type SignatureResponse struct {
 type HttpSignerClient interface {
 	Sign(ctx context.Context, pubKey string, request SignRequestJson) (bls.Signature, error)
 	GetPublicKeys(ctx context.Context, url string) ([][48]byte, error)
+	GetServerStatus(ctx context.Context) (string, error)
 }
 
 // ApiClient a wrapper object around web3signer APIs. Please refer to the docs from Consensys' web3signer project.
@@ -128,11 +129,16 @@ func (client *ApiClient) GetServerStatus(ctx context.Context) (string, error) {
 	if err != nil {
 		return "", err
 	}
-	var status string
-	if err := unmarshalResponse(resp.Body, &status); err != nil {
-		return "", err
+	defer closeBody(resp.Body)
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("web3signer is not up, Upcheck Request URL: %v, Status: %v", client.BaseURL.String()+requestPath, resp.StatusCode)
+	}
+	// Web3Signer answers with a plain text status, which may also be sent as a json string.
+	body, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return "", errors.Wrap(err, "failed to read response body")
 	}
-	return status, nil
+	return strings.Trim(strings.TrimSpace(string(body)), `"`), nil
 }
 
 // doRequest is a utility method for requests.
@@ -0,0 +1,87 @@
+package remote_web3signer
+
+import (
+	"encoding/json"
+	"path/filepath"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/io/file"
+)
+
+// remoteKeysFile is the content of the file persisting the keys added to the keymanager at runtime.
+type remoteKeysFile struct {
+	Keys []*remoteKey `json:"keys"`
+}
+
+// remoteKey is a key added to the keymanager, with the url of the web3signer holding it. The url is empty for
+// the keys held by the web3signer of the keymanager.
+type remoteKey struct {
+	Pubkey string `json:"pubkey"`
+	URL    string `json:"url,omitempty"`
+}
+
+// loadKeyFile adds the keys persisted in the key file of the keymanager, if any.
+func (km *Keymanager) loadKeyFile() error {
+	if km.keyFilePath == "" || !file.FileExists(km.keyFilePath) {
+		return nil
+	}
+	enc, err := file.ReadFileAsBytes(km.keyFilePath)
+	if err != nil {
+		return errors.Wrap(err, "could not read remote keys file")
+	}
+	var keysFile remoteKeysFile
+	if err := json.Unmarshal(enc, &keysFile); err != nil {
+		return errors.Wrap(err, "could not unmarshal remote keys file")
+	}
+	for _, key := range keysFile.Keys {
+		decoded, err := hexutil.Decode(key.Pubkey)
+		if err != nil {
+			return errors.Wrapf(err, "could not decode public key %s from remote keys file", key.Pubkey)
+		}
+		pubKey := bytesutil.ToBytes48(decoded)
+		signerURL, err := km.signerURL(key.URL)
+		if err != nil {
+			return errors.Wrapf(err, "invalid web3signer url for public key %s in remote keys file", key.Pubkey)
+		}
+		if signerURL != "" {
+			if err := km.addSigner(signerURL); err != nil {
+				return err
+			}
+			km.signerURLs[pubKey] = signerURL
+		}
+		km.importedKeys = append(km.importedKeys, pubKey)
+		if !containsKey(km.providedPublicKeys, pubKey) {
+			km.providedPublicKeys = append(km.providedPublicKeys, pubKey)
+		}
+	}
+	return nil
+}
+
+// saveKeyFile persists the keys added to the keymanager at runtime in its key file, if it has one.
+// The caller must hold the keymanager lock.
+func (km *Keymanager) saveKeyFile() error {
+	if km.keyFilePath == "" {
+		return nil
+	}
+	keysFile := &remoteKeysFile{Keys: make([]*remoteKey, len(km.importedKeys))}
+	for i, pubKey := range km.importedKeys {
+		keysFile.Keys[i] = &remoteKey{Pubkey: hexutil.Encode(pubKey[:]), URL: km.signerURLs[pubKey]}
+	}
+	enc, err := json.MarshalIndent(keysFile, "", "\t")
+	if err != nil {
+		return errors.Wrap(err, "could not marshal remote keys")
+	}
+	dir := filepath.Dir(km.keyFilePath)
+	hasDir, err := file.HasDir(dir)
+	if err != nil {
+		return err
+	}
+	if !hasDir {
+		if err := file.MkdirAll(dir); err != nil {
+			return errors.Wrapf(err, "could not create directory %s", dir)
+		}
+	}
+	return file.WriteFile(km.keyFilePath, enc)
+}
@@ -6,6 +6,7 @@ import (
 	"encoding/json"
 	"fmt"
 	"path/filepath"
+	"sync"
 
 	"github.com/ethereum/go-ethereum/common/hexutil"
 	"github.com/go-playground/validator/v10"
@@ -40,21 +41,36 @@ type SetupConfig struct {
 	// a static list of public keys to be passed by the user to determine what accounts should sign.
 	// This will provide a layer of safety against slashing if the web3signer is shared across validators.
 	ProvidedPublicKeys [][48]byte
+
+	// KeyFilePath is the file in which the keys added at runtime are persisted, along with the url of the web3signer
+	// holding each of them, so that they are loaded again on restart. The keys are not persisted if it is empty.
+	KeyFilePath string
 }
 
 // Keymanager defines the web3signer keymanager.
 type Keymanager struct {
 	client                internal.HttpSignerClient
+	baseEndpoint          string
+	baseLabel             string
 	genesisValidatorsRoot []byte
 	publicKeysURL         string
 	providedPublicKeys    [][48]byte
 	accountsChangedFeed   *event.Feed
 	validator             *validator.Validate
 	publicKeysUrlCalled   bool
+	keyFilePath           string
+	lock                  sync.RWMutex
+	// importedKeys are the keys added at runtime, which are persisted in the key file.
+	importedKeys [][48]byte
+	// signerURLs are the urls of the web3signers holding keys, for the keys not held by the base endpoint.
+	signerURLs map[[48]byte]string
+	// signers are the web3signers other than the base endpoint, by url.
+	signers          map[string]*signer
+	unhealthySigners map[string]bool
 }
 
 // NewKeymanager instantiates a new web3signer key manager.
-func NewKeymanager(_ context.Context, cfg *SetupConfig) (*Keymanager, error) {
+func NewKeymanager(ctx context.Context, cfg *SetupConfig) (*Keymanager, error) {
 	if cfg.BaseEndpoint == "" || !bytesutil.IsValidRoot(cfg.GenesisValidatorsRoot) {
 		return nil, fmt.Errorf("invalid setup config, one or more configs are empty: BaseEndpoint: %v, GenesisValidatorsRoot: %#x", cfg.BaseEndpoint, cfg.GenesisValidatorsRoot)
 	}
@@ -62,21 +78,34 @@ func NewKeymanager(_ context.Context, cfg *SetupConfig) (*Keymanager, error) {
 	if err != nil {
 		return nil, errors.Wrap(err, "could not create apiClient")
 	}
-	return &Keymanager{
+	km := &Keymanager{
 		client:                internal.HttpSignerClient(client),
+		baseEndpoint:          client.BaseURL.String(),
+		baseLabel:             signerLabel(client.BaseURL.String()),
 		genesisValidatorsRoot: cfg.GenesisValidatorsRoot,
 		accountsChangedFeed:   new(event.Feed),
 		publicKeysURL:         cfg.PublicKeysURL,
 		providedPublicKeys:    cfg.ProvidedPublicKeys,
 		validator:             validator.New(),
 		publicKeysUrlCalled:   false,
-	}, nil
+		keyFilePath:           cfg.KeyFilePath,
+		signerURLs:            make(map[[48]byte]string),
+		signers:               make(map[string]*signer),
+		unhealthySigners:      make(map[string]bool),
+	}
+	if err := km.loadKeyFile(); err != nil {
+		return nil, err
+	}
+	go km.monitorSigners(ctx)
+	return km, nil
 }
 
 // FetchValidatingPublicKeys fetches the validating public keys
 // from the remote server or from the provided keys if there are no existing public keys set
 // or provides the existing keys in the keymanager.
 func (km *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][fieldparams.BLSPubkeyLength]byte, error) {
+	km.lock.Lock()
+	defer km.lock.Unlock()
 	if km.publicKeysURL != "" && !km.publicKeysUrlCalled {
 		providedPublicKeys, err := km.client.GetPublicKeys(ctx, km.publicKeysURL)
 		if err != nil {
@@ -85,6 +114,12 @@ func (km *Keymanager) FetchValidatingPublicKeys(ctx context.Context) ([][fieldpa
 		}
 		// makes sure that if the public keys are deleted the validator does not call URL again.
 		km.publicKeysUrlCalled = true
+		// keys added at runtime in a previous run are kept.
+		for _, key := range km.importedKeys {
+			if !containsKey(providedPublicKeys, key) {
+				providedPublicKeys = append(providedPublicKeys, key)
+			}
+		}
 		km.providedPublicKeys = providedPublicKeys
 	}
 	return km.providedPublicKeys, nil
@@ -100,7 +135,14 @@ func (km *Keymanager) Sign(ctx context.Context, request *validatorpb.SignRequest
 
 	signRequestsTotal.Inc()
 
-	return km.client.Sign(ctx, hexutil.Encode(request.PublicKey), signRequest)
+	client, label := km.signerFor(bytesutil.ToBytes48(request.PublicKey))
+	signerSignRequestsTotal.WithLabelValues(label).Inc()
+	sig, err := client.Sign(ctx, hexutil.Encode(request.PublicKey), signRequest)
+	if err != nil {
+		signerErroredResponsesTotal.WithLabelValues(label).Inc()
+		return nil, err
+	}
+	return sig, nil
 }
 
 // getSignRequestJson returns a json request based on the SignRequest type.
@@ -333,33 +375,64 @@ func DisplayRemotePublicKeys(validatingPubKeys [][48]byte) {
 
 // AddPublicKeys imports a list of public keys into the keymanager for web3signer use. Returns status with message.
 func (km *Keymanager) AddPublicKeys(ctx context.Context, pubKeys [][fieldparams.BLSPubkeyLength]byte) ([]*ethpbservice.ImportedRemoteKeysStatus, error) {
+	return km.AddRemotePublicKeys(ctx, pubKeys, make([]string, len(pubKeys)))
+}
+
+// AddRemotePublicKeys imports a list of public keys into the keymanager, each of them held by the web3signer at the
+// url with the same index, or by the web3signer of the keymanager if that url is empty. Returns status with message.
+func (km *Keymanager) AddRemotePublicKeys(ctx context.Context, pubKeys [][fieldparams.BLSPubkeyLength]byte, urls []string) ([]*ethpbservice.ImportedRemoteKeysStatus, error) {
 	if ctx == nil {
 		return nil, errors.New("context is nil")
 	}
+	if len(urls) != len(pubKeys) {
+		return nil, fmt.Errorf("got %d urls for %d public keys", len(urls), len(pubKeys))
+	}
+	km.lock.Lock()
 	importedRemoteKeysStatuses := make([]*ethpbservice.ImportedRemoteKeysStatus, len(pubKeys))
 	for i, pubKey := range pubKeys {
-		found := false
-		for _, key := range km.providedPublicKeys {
-			if bytes.Equal(key[:], pubKey[:]) {
-				found = true
-				break
-			}
-		}
-		if found {
+		if containsKey(km.providedPublicKeys, pubKey) {
 			importedRemoteKeysStatuses[i] = &ethpbservice.ImportedRemoteKeysStatus{
 				Status:  ethpbservice.ImportedRemoteKeysStatus_DUPLICATE,
 				Message: fmt.Sprintf("Duplicate pubkey: %v, already in use", hexutil.Encode(pubKey[:])),
 			}
 			continue
 		}
+		signerURL, err := km.signerURL(urls[i])
+		if err == nil && signerURL != "" {
+			err = km.addSigner(signerURL)
+		}
+		if err != nil {
+			importedRemoteKeysStatuses[i] = &ethpbservice.ImportedRemoteKeysStatus{
+				Status:  ethpbservice.ImportedRemoteKeysStatus_ERROR,
+				Message: fmt.Sprintf("Invalid web3signer url for pubkey %v: %v", hexutil.Encode(pubKey[:]), err),
+			}
+			continue
+		}
+		if signerURL != "" {
+			km.signerURLs[pubKey] = signerURL
+		}
 		km.providedPublicKeys = append(km.providedPublicKeys, pubKey)
+		km.importedKeys = append(km.importedKeys, pubKey)
 		importedRemoteKeysStatuses[i] = &ethpbservice.ImportedRemoteKeysStatus{
 			Status:  ethpbservice.ImportedRemoteKeysStatus_IMPORTED,
 			Message: fmt.Sprintf("Successfully added pubkey: %v", hexutil.Encode(pubKey[:])),
 		}
-		log.Debug("Added pubkey to keymanager for web3signer", "pubkey", hexutil.Encode(pubKey[:]))
+		label := km.baseLabel
+		if signerURL != "" {
+			label = signerLabel(signerURL)
+		}
+		log.WithFields(log.Fields{
+			"pubkey": hexutil.Encode(pubKey[:]),
+			"signer": label,
+		}).Debug("Added pubkey to keymanager for web3signer")
 	}
-	km.accountsChangedFeed.Send(km.providedPublicKeys)
+	if err := km.saveKeyFile(); err != nil {
+		log.WithError(err).Error("Could not persist web3signer public keys")
+	}
+	pubKeysCopy := make([][fieldparams.BLSPubkeyLength]byte, len(km.providedPublicKeys))
+	copy(pubKeysCopy, km.providedPublicKeys)
+	km.lock.Unlock()
+	km.accountsChangedFeed.Send(pubKeysCopy)
 	return importedRemoteKeysStatuses, nil
 }
 
@@ -368,8 +441,10 @@ func (km *Keymanager) DeletePublicKeys(ctx context.Context, pubKeys [][fieldpara
 	if ctx == nil {
 		return nil, errors.New("context is nil")
 	}
+	km.lock.Lock()
 	deletedRemoteKeysStatuses := make([]*ethpbservice.DeletedRemoteKeysStatus, len(pubKeys))
 	if len(km.providedPublicKeys) == 0 {
+		km.lock.Unlock()
 		for i := range deletedRemoteKeysStatuses {
 			deletedRemoteKeysStatuses[i] = &ethpbservice.DeletedRemoteKeysStatus{
 				Status:  ethpbservice.DeletedRemoteKeysStatus_NOT_FOUND,
@@ -382,6 +457,8 @@ func (km *Keymanager) DeletePublicKeys(ctx context.Context, pubKeys [][fieldpara
 		for in, key := range km.providedPublicKeys {
 			if bytes.Equal(key[:], pubkey[:]) {
 				km.providedPublicKeys = append(km.providedPublicKeys[:in], km.providedPublicKeys[in+1:]...)
+				km.importedKeys = removeKey(km.importedKeys, pubkey)
+				delete(km.signerURLs, pubkey)
 				deletedRemoteKeysStatuses[i] = &ethpbservice.DeletedRemoteKeysStatus{
 					Status:  ethpbservice.DeletedRemoteKeysStatus_DELETED,
 					Message: fmt.Sprintf("Successfully deleted pubkey: %v", hexutil.Encode(pubkey[:])),
@@ -397,6 +474,31 @@ func (km *Keymanager) DeletePublicKeys(ctx context.Context, pubKeys [][fieldpara
 			}
 		}
 	}
-	km.accountsChangedFeed.Send(km.providedPublicKeys)
+	km.removeUnusedSigners()
+	if err := km.saveKeyFile(); err != nil {
+		log.WithError(err).Error("Could not persist web3signer public keys")
+	}
+	pubKeysCopy := make([][fieldparams.BLSPubkeyLength]byte, len(km.providedPublicKeys))
+	copy(pubKeysCopy, km.providedPublicKeys)
+	km.lock.Unlock()
+	km.accountsChangedFeed.Send(pubKeysCopy)
 	return deletedRemoteKeysStatuses, nil
 }
+
+func containsKey(keys [][fieldparams.BLSPubkeyLength]byte, key [fieldparams.BLSPubkeyLength]byte) bool {
+	for _, k := range keys {
+		if k == key {
+			return true
+		}
+	}
+	return false
+}
+
+func removeKey(keys [][fieldparams.BLSPubkeyLength]byte, key [fieldparams.BLSPubkeyLength]byte) [][fieldparams.BLSPubkeyLength]byte {
+	for i, k := range keys {
+		if k == key {
+			return append(keys[:i], keys[i+1:]...)
+		}
+	}
+	return keys
+}
@@ -74,4 +74,16 @@ var (
 		Name: "remote_web3signer_validator_registration_sign_requests_total",
 		Help: "Total number of validator registration sign requests",
 	})
+	signerSignRequestsTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "remote_web3signer_signer_sign_requests_total",
+		Help: "Total number of sign requests sent to each web3signer",
+	}, []string{"signer"})
+	signerErroredResponsesTotal = promauto.NewCounterVec(prometheus.CounterOpts{
+		Name: "remote_web3signer_signer_errored_responses_total",
+		Help: "Total number of sign requests which failed, for each web3signer",
+	}, []string{"signer"})
+	signerUp = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Name: "remote_web3signer_signer_up",
+		Help: "Whether each web3signer answered its last health check (1) or not (0)",
+	}, []string{"signer"})
 )
@@ -0,0 +1,154 @@
+package remote_web3signer
+
+import (
+	"context"
+	"net/url"
+	"time"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/validator/keymanager/remote-web3signer/internal"
+	log "github.com/sirupsen/logrus"
+)
+
+// signerHealthCheckInterval is the time between two health checks of the web3signers used by the keymanager.
+var signerHealthCheckInterval = 30 * time.Second
+
+// signer is a web3signer holding some of the keys of the keymanager.
+type signer struct {
+	client internal.HttpSignerClient
+	// label identifies the signer in metrics and logs, it is the url of the signer without its credentials.
+	label string
+}
+
+// signerLabel returns the url of a signer without the password it may contain.
+func signerLabel(signerURL string) string {
+	u, err := url.Parse(signerURL)
+	if err != nil {
+		return signerURL
+	}
+	return u.Redacted()
+}
+
+// signerURL validates the url of a web3signer given for a key and returns it in canonical form. An empty url is
+// returned for the web3signer of the keymanager, which is used when no url is given.
+func (km *Keymanager) signerURL(rawURL string) (string, error) {
+	if rawURL == "" {
+		return "", nil
+	}
+	client, err := internal.NewApiClient(rawURL)
+	if err != nil {
+		return "", err
+	}
+	signerURL := client.BaseURL.String()
+	if signerURL == km.baseEndpoint {
+		return "", nil
+	}
+	return signerURL, nil
+}
+
+// addSigner creates a client for the web3signer at the given url, if the keymanager does not have one yet.
+// The caller must hold the keymanager lock.
+func (km *Keymanager) addSigner(signerURL string) error {
+	if _, ok := km.signers[signerURL]; ok {
+		return nil
+	}
+	client, err := internal.NewApiClient(signerURL)
+	if err != nil {
+		return errors.Wrap(err, "could not create apiClient")
+	}
+	km.signers[signerURL] = &signer{client: client, label: signerLabel(signerURL)}
+	return nil
+}
+
+// removeUnusedSigners drops the clients of the web3signers which do not hold any key of the keymanager anymore.
+// The caller must hold the keymanager lock.
+func (km *Keymanager) removeUnusedSigners() {
+	used := make(map[string]bool, len(km.signers))
+	for _, signerURL := range km.signerURLs {
+		used[signerURL] = true
+	}
+	for signerURL, s := range km.signers {
+		if used[signerURL] {
+			continue
+		}
+		delete(km.signers, signerURL)
+		delete(km.unhealthySigners, s.label)
+		signerUp.DeleteLabelValues(s.label)
+		signerSignRequestsTotal.DeleteLabelValues(s.label)
+		signerErroredResponsesTotal.DeleteLabelValues(s.label)
+	}
+}
+
+// signerFor returns the client of the web3signer holding a key, and the label of the web3signer.
+func (km *Keymanager) signerFor(pubKey [fieldparams.BLSPubkeyLength]byte) (internal.HttpSignerClient, string) {
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+	if signerURL, ok := km.signerURLs[pubKey]; ok {
+		if s, ok := km.signers[signerURL]; ok {
+			return s.client, s.label
+		}
+	}
+	return km.client, km.baseLabel
+}
+
+// SignerURL returns the url of the web3signer holding a key.
+func (km *Keymanager) SignerURL(pubKey [fieldparams.BLSPubkeyLength]byte) string {
+	km.lock.RLock()
+	defer km.lock.RUnlock()
+	if signerURL, ok := km.signerURLs[pubKey]; ok {
+		return signerURL
+	}
+	return km.baseEndpoint
+}
+
+// monitorSigners periodically checks that the web3signers used by the keymanager are up, until the context is
+// canceled.
+func (km *Keymanager) monitorSigners(ctx context.Context) {
+	ticker := time.NewTicker(signerHealthCheckInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			km.checkSigners(ctx)
+		}
+	}
+}
+
+// checkSigners checks the upcheck endpoint of every web3signer used by the keymanager, and logs the signers which
+// went down or came back up since the previous check.
+func (km *Keymanager) checkSigners(ctx context.Context) {
+	km.lock.RLock()
+	signers := make([]*signer, 0, len(km.signers)+1)
+	signers = append(signers, &signer{client: km.client, label: km.baseLabel})
+	for _, s := range km.signers {
+		signers = append(signers, s)
+	}
+	km.lock.RUnlock()
+
+	for _, s := range signers {
+		status, err := s.client.GetServerStatus(ctx)
+		km.lock.Lock()
+		wasHealthy := !km.unhealthySigners[s.label]
+		if err != nil {
+			km.unhealthySigners[s.label] = true
+		} else {
+			delete(km.unhealthySigners, s.label)
+		}
+		km.lock.Unlock()
+
+		if err != nil {
+			signerUp.WithLabelValues(s.label).Set(0)
+			if wasHealthy {
+				log.WithError(err).WithField("signer", s.label).Error("Web3signer is not up")
+			}
+			continue
+		}
+		signerUp.WithLabelValues(s.label).Set(1)
+		if !wasHealthy {
+			log.WithFields(log.Fields{"signer": s.label, "status": status}).Info("Web3signer is up again")
+		}
+	}
+}
@@ -65,6 +65,17 @@ type PublicKeyAdder interface {
 	AddPublicKeys(ctx context.Context, publicKeys [][fieldparams.BLSPubkeyLength]byte) ([]*ethpbservice.ImportedRemoteKeysStatus, error)
 }
 
+// RemotePublicKeyAdder allows adding public keys to the keymanager, along with the url of the remote signer holding
+// each of them.
+type RemotePublicKeyAdder interface {
+	AddRemotePublicKeys(ctx context.Context, publicKeys [][fieldparams.BLSPubkeyLength]byte, urls []string) ([]*ethpbservice.ImportedRemoteKeysStatus, error)
+}
+
+// SignerURLFetcher returns the url of the remote signer holding a public key.
+type SignerURLFetcher interface {
+	SignerURL(publicKey [fieldparams.BLSPubkeyLength]byte) string
+}
+
 // PublicKeyDeleter allows deleting public keys set in keymanager.
 type PublicKeyDeleter interface {
 	DeletePublicKeys(ctx context.Context, publicKeys [][fieldparams.BLSPubkeyLength]byte) ([]*ethpbservice.DeletedRemoteKeysStatus, error)
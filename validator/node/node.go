@@ -58,6 +58,7 @@ import (
 	remoteweb3signer "github.com/prysmaticlabs/prysm/v4/validator/keymanager/remote-web3signer"
 	"github.com/prysmaticlabs/prysm/v4/validator/rpc"
 	validatormiddleware "github.com/prysmaticlabs/prysm/v4/validator/rpc/apimiddleware"
+	shared "github.com/prysmaticlabs/prysm/v4/validator/slashing-protection-shared"
 	"github.com/prysmaticlabs/prysm/v4/validator/web"
 	"github.com/sirupsen/logrus"
 	"github.com/urfave/cli/v2"
@@ -65,6 +66,9 @@ import (
 	"k8s.io/apimachinery/pkg/util/yaml"
 )
 
+// web3SignerKeyFileName is the name of the file persisting the web3signer keys imported at runtime, in the datadir.
+const web3SignerKeyFileName = "web3signer-keys.json"
+
 // ValidatorClient defines an instance of an Ethereum validator that manages
 // the entire lifecycle of services attached to it participating in proof of stake.
 type ValidatorClient struct {
@@ -408,6 +412,11 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		return err
 	}
 
+	sharedProtection, err := sharedSlashingProtection(c.cliCtx, wsc)
+	if err != nil {
+		return err
+	}
+
 	bpc, err := proposerSettings(c.cliCtx, c.db)
 	if err != nil {
 		return err
@@ -431,6 +440,7 @@ func (c *ValidatorClient) registerValidatorService(cliCtx *cli.Context) error {
 		WalletInitializedFeed:      c.walletInitialized,
 		GraffitiStruct:             gStruct,
 		Web3SignerConfig:           wsc,
+		SharedSlashingProtection:   sharedProtection,
 		ProposerSettings:           bpc,
 		BeaconApiTimeout:           time.Second * 30,
 		BeaconApiEndpoint:          c.cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
@@ -460,6 +470,10 @@ func Web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error
 		if cliCtx.IsSet(flags.WalletPasswordFileFlag.Name) {
 			log.Warnf("%s was provided while using web3signer and will be ignored", flags.WalletPasswordFileFlag.Name)
 		}
+		web3signerConfig.KeyFilePath = cliCtx.String(flags.Web3SignerKeyFileFlag.Name)
+		if web3signerConfig.KeyFilePath == "" {
+			web3signerConfig.KeyFilePath = filepath.Join(cliCtx.String(cmd.DataDirFlag.Name), web3SignerKeyFileName)
+		}
 
 		if publicKeysSlice := cliCtx.StringSlice(flags.Web3SignerPublicValidatorKeysFlag.Name); len(publicKeysSlice) > 0 {
 			pks := make([]string, 0)
@@ -490,6 +504,35 @@ func Web3SignerConfig(cliCtx *cli.Context) (*remoteweb3signer.SetupConfig, error
 	return web3signerConfig, nil
 }
 
+// sharedSlashingProtection returns the slashing protection records shared with the other validator clients using
+// the same web3signer keys, if any.
+func sharedSlashingProtection(cliCtx *cli.Context, wsc *remoteweb3signer.SetupConfig) (shared.Store, error) {
+	location := cliCtx.String(flags.SharedSlashingProtectionFlag.Name)
+	if location == "" {
+		return nil, nil
+	}
+	if wsc == nil {
+		return nil, fmt.Errorf("%s can only be used with %s", flags.SharedSlashingProtectionFlag.Name, flags.Web3SignerURLFlag.Name)
+	}
+	var token string
+	if tokenFile := cliCtx.String(flags.SharedSlashingProtectionTokenFileFlag.Name); tokenFile != "" {
+		enc, err := file.ReadFileAsBytes(tokenFile)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not read shared slashing protection token file")
+		}
+		token = strings.TrimSpace(string(enc))
+		if token == "" {
+			return nil, fmt.Errorf("shared slashing protection token file %s is empty", tokenFile)
+		}
+	}
+	store, err := shared.New(location, token)
+	if err != nil {
+		return nil, errors.Wrap(err, "could not set up shared slashing protection")
+	}
+	log.WithField("location", location).Info("Checking blocks and attestations against shared slashing protection records before signing")
+	return store, nil
+}
+
 func proposerSettings(cliCtx *cli.Context, db iface.ValidatorDB) (*validatorServiceConfig.ProposerSettings, error) {
 	var fileConfig *validatorpb.ProposerSettingsPayload
 
@@ -690,6 +733,17 @@ func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context) error {
 	walletDir := cliCtx.String(flags.WalletDirFlag.Name)
 	grpcHeaders := c.cliCtx.String(flags.GrpcHeadersFlag.Name)
 	clientCert := c.cliCtx.String(flags.CertFlag.Name)
+	var beaconAdminApiToken string
+	if tokenFile := cliCtx.String(flags.BeaconAdminAPITokenFileFlag.Name); tokenFile != "" {
+		token, err := file.ReadFileAsBytes(tokenFile)
+		if err != nil {
+			return errors.Wrap(err, "could not read beacon node admin API token file")
+		}
+		beaconAdminApiToken = strings.TrimSpace(string(token))
+		if beaconAdminApiToken == "" {
+			return fmt.Errorf("beacon node admin API token file %s is empty", tokenFile)
+		}
+	}
 	server := rpc.NewServer(cliCtx.Context, &rpc.Config{
 		ValDB:                    c.db,
 		Host:                     rpcHost,
@@ -711,6 +765,9 @@ func (c *ValidatorClient) registerRPCService(cliCtx *cli.Context) error {
 		ClientGrpcRetryDelay:     grpcRetryDelay,
 		ClientGrpcHeaders:        strings.Split(grpcHeaders, ","),
 		ClientWithCert:           clientCert,
+		BeaconApiEndpoint:        cliCtx.String(flags.BeaconRESTApiProviderFlag.Name),
+		BeaconApiTimeout:         time.Second * 30,
+		BeaconAdminApiToken:      beaconAdminApiToken,
 	})
 	return c.services.RegisterService(server)
 }
@@ -783,6 +840,15 @@ func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
 		}
 	}
 
+	var rpcServer *rpc.Server
+	if err := c.services.FetchService(&rpcServer); err != nil {
+		return err
+	}
+	// Handlers registered on the router before the gateway is created take precedence over the gateway patterns.
+	router := mux.NewRouter()
+	router.HandleFunc("/v2/validator/proposer-settings/preview", rpcServer.ProposerSettingsPreview).Methods(http.MethodGet)
+	router.HandleFunc("/dashboard", rpcServer.Dashboard).Methods(http.MethodGet)
+
 	// remove "/accounts/", "/v2/" after WebUI DEPRECATED
 	pbHandler := &gateway.PbMux{
 		Registrations: registrations,
@@ -790,7 +856,7 @@ func (c *ValidatorClient) registerRPCGatewayService(cliCtx *cli.Context) error {
 		Mux:           gwmux,
 	}
 	opts := []gateway.Option{
-		gateway.WithRouter(mux.NewRouter()),
+		gateway.WithRouter(router),
 		gateway.WithRemoteAddr(rpcAddr),
 		gateway.WithGatewayAddr(gatewayAddress),
 		gateway.WithMaxCallRecvMsgSize(maxCallSize),
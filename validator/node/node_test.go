@@ -12,6 +12,7 @@ import (
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/prysmaticlabs/prysm/v4/cmd"
 	"github.com/prysmaticlabs/prysm/v4/cmd/validator/flags"
 	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
 	"github.com/prysmaticlabs/prysm/v4/config/params"
@@ -87,6 +88,7 @@ func TestWeb3SignerConfig(t *testing.T) {
 	type args struct {
 		baseURL          string
 		publicKeysOrURLs []string
+		keyFile          string
 	}
 	tests := []struct {
 		name       string
@@ -109,6 +111,7 @@ func TestWeb3SignerConfig(t *testing.T) {
 					bytepubkey1,
 					bytepubkey2,
 				},
+				KeyFilePath: filepath.Join("datadir", "web3signer-keys.json"),
 			},
 		},
 		{
@@ -116,12 +119,14 @@ func TestWeb3SignerConfig(t *testing.T) {
 			args: &args{
 				baseURL:          "http://localhost:8545",
 				publicKeysOrURLs: []string{"http://localhost:8545/api/v1/eth2/publicKeys"},
+				keyFile:          "keys.json",
 			},
 			want: &remoteweb3signer.SetupConfig{
 				BaseEndpoint:          "http://localhost:8545",
 				GenesisValidatorsRoot: nil,
 				PublicKeysURL:         "http://localhost:8545/api/v1/eth2/publicKeys",
 				ProvidedPublicKeys:    nil,
+				KeyFilePath:           "keys.json",
 			},
 		},
 		{
@@ -187,6 +192,8 @@ func TestWeb3SignerConfig(t *testing.T) {
 			app := cli.App{}
 			set := flag.NewFlagSet(tt.name, 0)
 			set.String("validators-external-signer-url", tt.args.baseURL, "baseUrl")
+			set.String(flags.Web3SignerKeyFileFlag.Name, tt.args.keyFile, "")
+			set.String(cmd.DataDirFlag.Name, "datadir", "")
 			c := &cli.StringSliceFlag{
 				Name: "validators-external-signer-public-keys",
 			}
@@ -100,3 +100,42 @@ type SetGasLimitRequestJson struct {
 type DeleteGasLimitRequestJson struct {
 	Pubkey string `json:"pubkey" hex:"true"`
 }
+
+type ProposerSettingsPreviewResponseJson struct {
+	Data            []*ProposerSettingsPreviewJson `json:"data"`
+	BeaconNodeError string                         `json:"beacon_node_error,omitempty"`
+}
+
+type ProposerSettingsPreviewJson struct {
+	Pubkey         string                    `json:"pubkey"`
+	Source         string                    `json:"source"`
+	FeeRecipient   string                    `json:"fee_recipient"`
+	BuilderEnabled bool                      `json:"builder_enabled"`
+	GasLimit       string                    `json:"gas_limit"`
+	BeaconNode     *ProposerRegistrationJson `json:"beacon_node,omitempty"`
+	Mismatches     []string                  `json:"mismatches"`
+}
+
+type ProposerRegistrationsResponseJson struct {
+	Data []*ProposerRegistrationJson `json:"data"`
+}
+
+type ProposerRegistrationJson struct {
+	Pubkey       string                     `json:"pubkey"`
+	Index        string                     `json:"index,omitempty"`
+	FeeRecipient string                     `json:"fee_recipient,omitempty"`
+	Registration *ValidatorRegistrationJson `json:"registration,omitempty"`
+	Relays       []*RelayRegistrationJson   `json:"relays"`
+}
+
+type ValidatorRegistrationJson struct {
+	FeeRecipient string `json:"fee_recipient"`
+	GasLimit     string `json:"gas_limit"`
+	Timestamp    string `json:"timestamp"`
+}
+
+type RelayRegistrationJson struct {
+	URL          string                     `json:"url"`
+	Registration *ValidatorRegistrationJson `json:"registration,omitempty"`
+	Error        string                     `json:"error,omitempty"`
+}
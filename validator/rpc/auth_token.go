@@ -155,6 +155,7 @@ func logValidatorWebAuth(validatorWebAddr, token string, tokenPath string) {
 			"the Prysm web interface",
 	)
 	log.Info(webAuthURL)
+	log.Infof("The validator client status dashboard is served at http://%s/dashboard?token=%s", validatorWebAddr, url.QueryEscape(token))
 	log.Infof("Validator CLient JWT for RPC and REST authentication set at:%s", tokenPath)
 }
 
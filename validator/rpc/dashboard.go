@@ -0,0 +1,396 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html/template"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// dashboardPage is the data rendered by the dashboard template.
+type dashboardPage struct {
+	Generated      string
+	RefreshSeconds uint64
+	Slot           string
+	Epoch          string
+	BeaconNode     string
+	Connected      bool
+	Syncing        bool
+	Keys           []*dashboardKey
+	Results        []*dashboardResult
+	Errors         []string
+}
+
+// dashboardKey is the row of a validator key in the dashboard.
+type dashboardKey struct {
+	PubKey          string
+	ShortPubKey     string
+	Index           string
+	Status          string
+	NextDuty        string
+	FeeRecipient    string
+	SettingsSource  string
+	BuilderEnabled  bool
+	GasLimit        string
+	HighestProposal string
+	LowestSource    string
+	LowestTarget    string
+}
+
+// dashboardResult is the row of an attestation or a proposal in the dashboard.
+type dashboardResult struct {
+	Time   string
+	Slot   primitives.Slot
+	PubKey string
+	Duty   string
+	Error  string
+	Failed bool
+}
+
+var dashboardTemplate = template.Must(template.New("dashboard").Parse(dashboardHTML))
+
+// Dashboard is an HTTP handler which renders the status page of the validator client: the status, next duty,
+// proposer settings and slashing protection watermarks of every key, the results of the last attestations and
+// proposals, and the beacon node connection. The page does not load any script or external resource and reloads
+// itself every slot. As browsers cannot set the Authorization header, the auth token may also be passed in the
+// token query parameter.
+func (s *Server) Dashboard(w http.ResponseWriter, r *http.Request) {
+	if r.Header.Get("Authorization") == "" && r.URL.Query().Get("token") != "" {
+		r.Header.Set("Authorization", "Bearer "+r.URL.Query().Get("token"))
+	}
+	if err := s.authorizeHTTP(r); err != nil {
+		http.Error(w, err.Error(), http.StatusUnauthorized)
+		return
+	}
+	if s.validatorService == nil {
+		http.Error(w, "validator service not ready", http.StatusServiceUnavailable)
+		return
+	}
+
+	page := s.dashboardPage(r.Context())
+	var buf bytes.Buffer
+	if err := dashboardTemplate.Execute(&buf, page); err != nil {
+		log.WithError(err).Error("Could not render dashboard")
+		http.Error(w, "could not render dashboard", http.StatusInternalServerError)
+		return
+	}
+	w.Header().Set("Content-Type", "text/html; charset=utf-8")
+	w.Header().Set("Cache-Control", "no-store")
+	// The token is part of the url, it must not leak to other sites.
+	w.Header().Set("Referrer-Policy", "no-referrer")
+	w.Header().Set("X-Frame-Options", "DENY")
+	w.Header().Set("X-Content-Type-Options", "nosniff")
+	w.Header().Set("Content-Security-Policy", "default-src 'none'; style-src 'unsafe-inline'")
+	w.WriteHeader(http.StatusOK)
+	if _, err := w.Write(buf.Bytes()); err != nil {
+		log.WithError(err).Error("Failed to write http response")
+	}
+}
+
+// dashboardPage gathers the data of the dashboard. Errors are shown on the page instead of failing the request, so
+// that the dashboard remains useful when the beacon node is down.
+func (s *Server) dashboardPage(ctx context.Context) *dashboardPage {
+	page := &dashboardPage{
+		Generated:      time.Now().UTC().Format(time.RFC1123),
+		RefreshSeconds: params.BeaconConfig().SecondsPerSlot,
+		Slot:           "-",
+		Epoch:          "-",
+	}
+
+	var pubKeys [][fieldparams.BLSPubkeyLength]byte
+	km, err := s.validatorService.Keymanager()
+	if err != nil {
+		page.Errors = append(page.Errors, errors.Wrap(err, "could not get keymanager").Error())
+	} else if pubKeys, err = km.FetchValidatingPublicKeys(ctx); err != nil {
+		page.Errors = append(page.Errors, errors.Wrap(err, "could not fetch validating public keys").Error())
+	}
+
+	keys := make(map[[fieldparams.BLSPubkeyLength]byte]*dashboardKey, len(pubKeys))
+	settings := s.validatorService.ProposerSettings()
+	for _, pubKey := range pubKeys {
+		eff := resolveProposerSettings(settings, pubKey)
+		key := &dashboardKey{
+			PubKey:          hexutil.Encode(pubKey[:]),
+			ShortPubKey:     fmt.Sprintf("%#x", bytesutil.Trunc(pubKey[:])),
+			Index:           "-",
+			Status:          "unknown",
+			NextDuty:        "-",
+			FeeRecipient:    eff.feeRecipient.Hex(),
+			SettingsSource:  eff.source,
+			BuilderEnabled:  eff.builderEnabled,
+			GasLimit:        strconv.FormatUint(eff.gasLimit, 10),
+			HighestProposal: "-",
+			LowestSource:    "-",
+			LowestTarget:    "-",
+		}
+		if err := s.slashingProtectionWatermarks(ctx, pubKey, key); err != nil {
+			page.Errors = append(page.Errors, err.Error())
+		}
+		keys[pubKey] = key
+		page.Keys = append(page.Keys, key)
+	}
+
+	if s.syncChecker != nil && s.genesisFetcher != nil {
+		conn, err := s.GetBeaconNodeConnection(ctx, &emptypb.Empty{})
+		if err != nil {
+			page.Errors = append(page.Errors, errors.Wrap(err, "could not get beacon node connection").Error())
+		} else {
+			page.BeaconNode = conn.BeaconNodeEndpoint
+			page.Connected = conn.Connected
+			page.Syncing = conn.Syncing
+			if conn.Connected {
+				s.fillDashboardDuties(ctx, page, conn.GenesisTime, pubKeys, keys)
+			}
+		}
+	}
+
+	for _, result := range s.validatorService.RecentDutyResults() {
+		row := &dashboardResult{
+			Time:   result.Time.UTC().Format(time.TimeOnly),
+			Slot:   result.Slot,
+			PubKey: fmt.Sprintf("%#x", bytesutil.Trunc(result.PubKey[:])),
+			Duty:   roleName(result.Role),
+		}
+		if result.Err != nil {
+			row.Failed = true
+			row.Error = result.Err.Error()
+		}
+		page.Results = append(page.Results, row)
+	}
+	return page
+}
+
+// fillDashboardDuties sets the current slot, and the status and the next duty of the keys, which require the beacon
+// node.
+func (s *Server) fillDashboardDuties(
+	ctx context.Context,
+	page *dashboardPage,
+	genesisTime uint64,
+	pubKeys [][fieldparams.BLSPubkeyLength]byte,
+	keys map[[fieldparams.BLSPubkeyLength]byte]*dashboardKey,
+) {
+	slot := slots.CurrentSlot(genesisTime)
+	epoch := slots.ToEpoch(slot)
+	page.Slot = strconv.FormatUint(uint64(slot), 10)
+	page.Epoch = strconv.FormatUint(uint64(epoch), 10)
+	if len(pubKeys) == 0 {
+		return
+	}
+
+	if s.beaconNodeValidatorClient != nil {
+		req := &ethpb.MultipleValidatorStatusRequest{PublicKeys: make([][]byte, len(pubKeys))}
+		for i := range pubKeys {
+			req.PublicKeys[i] = pubKeys[i][:]
+		}
+		resp, err := s.beaconNodeValidatorClient.MultipleValidatorStatus(ctx, req)
+		if err != nil {
+			page.Errors = append(page.Errors, errors.Wrap(err, "could not get validator statuses").Error())
+		} else {
+			for i, pk := range resp.PublicKeys {
+				key, ok := keys[bytesutil.ToBytes48(pk)]
+				if !ok || i >= len(resp.Statuses) {
+					continue
+				}
+				key.Status = strings.ToLower(resp.Statuses[i].Status.String())
+				if i < len(resp.Indices) && resp.Statuses[i].Status != ethpb.ValidatorStatus_UNKNOWN_STATUS {
+					key.Index = strconv.FormatUint(uint64(resp.Indices[i]), 10)
+				}
+			}
+		}
+	}
+
+	// The validator client only knows the duties of the current epoch.
+	end, err := slots.EpochEnd(epoch)
+	if err != nil {
+		page.Errors = append(page.Errors, err.Error())
+		return
+	}
+	// The duties are a copy taken under the lock of the validator, which tells the aggregators apart only when the
+	// duties are performed, since it requires signing selection proofs.
+	duties := s.validatorService.CurrentDuties()
+	for pubKey, key := range keys {
+		keyDuties, ok := duties[pubKey]
+		if !ok {
+			continue
+		}
+		for sl := slot; sl <= end; sl++ {
+			roles := dutyRolesAt(keyDuties, sl)
+			if len(roles) == 0 {
+				continue
+			}
+			names := make([]string, 0, len(roles))
+			for _, role := range roles {
+				names = append(names, roleName(role))
+			}
+			key.NextDuty = fmt.Sprintf("slot %d: %s", sl, strings.Join(names, ", "))
+			break
+		}
+	}
+	for _, key := range keys {
+		if key.NextDuty == "-" {
+			key.NextDuty = "none this epoch"
+		}
+	}
+}
+
+// slashingProtectionWatermarks sets the highest signed proposal and the lowest signed source and target epochs of a
+// key, which bound what the slashing protection lets the key sign.
+func (s *Server) slashingProtectionWatermarks(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, key *dashboardKey) error {
+	if s.valDB == nil {
+		return nil
+	}
+	proposal, exists, err := s.valDB.HighestSignedProposal(ctx, pubKey)
+	if err != nil {
+		return errors.Wrapf(err, "could not get highest signed proposal of %s", key.ShortPubKey)
+	}
+	if exists {
+		key.HighestProposal = strconv.FormatUint(uint64(proposal), 10)
+	}
+	source, exists, err := s.valDB.LowestSignedSourceEpoch(ctx, pubKey)
+	if err != nil {
+		return errors.Wrapf(err, "could not get lowest signed source epoch of %s", key.ShortPubKey)
+	}
+	if exists {
+		key.LowestSource = strconv.FormatUint(uint64(source), 10)
+	}
+	target, exists, err := s.valDB.LowestSignedTargetEpoch(ctx, pubKey)
+	if err != nil {
+		return errors.Wrapf(err, "could not get lowest signed target epoch of %s", key.ShortPubKey)
+	}
+	if exists {
+		key.LowestTarget = strconv.FormatUint(uint64(target), 10)
+	}
+	return nil
+}
+
+// dutyRolesAt returns the roles of a key at a slot of the epoch of its duties, like the validator does before it checks
+// whether the key is an aggregator.
+func dutyRolesAt(duties *iface.KeyDuties, slot primitives.Slot) []iface.ValidatorRole {
+	var roles []iface.ValidatorRole
+	for _, proposerSlot := range duties.ProposerSlots {
+		if proposerSlot != 0 && proposerSlot == slot {
+			roles = append(roles, iface.RoleProposer)
+			break
+		}
+	}
+	if duties.AttesterSlot == slot {
+		roles = append(roles, iface.RoleAttester)
+	}
+	// At the last slot of an epoch, the sync committee members sign for the first slot of the next epoch.
+	if (slots.IsEpochEnd(slot) && duties.IsNextSyncCommittee) || (!slots.IsEpochEnd(slot) && duties.IsSyncCommittee) {
+		roles = append(roles, iface.RoleSyncCommittee)
+	}
+	return roles
+}
+
+func roleName(role iface.ValidatorRole) string {
+	switch role {
+	case iface.RoleAttester:
+		return "attester"
+	case iface.RoleProposer:
+		return "proposer"
+	case iface.RoleAggregator:
+		return "aggregator"
+	case iface.RoleSyncCommittee:
+		return "sync committee"
+	case iface.RoleSyncCommitteeAggregator:
+		return "sync committee aggregator"
+	default:
+		return "unknown"
+	}
+}
+
+const dashboardHTML = `<!DOCTYPE html>
+<html lang="en">
+<head>
+<meta charset="utf-8">
+<meta http-equiv="refresh" content="{{.RefreshSeconds}}">
+<title>Validator client status</title>
+<style>
+body { font-family: sans-serif; margin: 1.5em; color: #222; }
+h1 { font-size: 1.4em; }
+h2 { font-size: 1.1em; margin-top: 1.5em; }
+table { border-collapse: collapse; font-size: 0.9em; }
+th, td { border: 1px solid #ccc; padding: 0.3em 0.6em; text-align: left; }
+th { background: #f0f0f0; }
+.mono { font-family: monospace; }
+.ok { color: #1a7f37; }
+.bad { color: #c62828; }
+.errors { color: #c62828; }
+</style>
+</head>
+<body>
+<h1>Validator client status</h1>
+<p>Generated {{.Generated}}, slot {{.Slot}}, epoch {{.Epoch}}.</p>
+{{if .Errors}}<ul class="errors">{{range .Errors}}<li>{{.}}</li>{{end}}</ul>{{end}}
+
+<h2>Beacon node</h2>
+<table>
+<tr><th>Endpoint</th><td class="mono">{{.BeaconNode}}</td></tr>
+<tr><th>Connected</th><td>{{if .Connected}}<span class="ok">yes</span>{{else}}<span class="bad">no</span>{{end}}</td></tr>
+<tr><th>Syncing</th><td>{{if .Syncing}}<span class="bad">yes</span>{{else}}no{{end}}</td></tr>
+</table>
+
+<h2>Keys</h2>
+{{if .Keys}}
+<table>
+<tr>
+<th>Public key</th><th>Index</th><th>Status</th><th>Next duty</th>
+<th>Fee recipient</th><th>Settings</th><th>Builder</th><th>Gas limit</th>
+<th>Highest signed proposal</th><th>Lowest signed source</th><th>Lowest signed target</th>
+</tr>
+{{range .Keys}}
+<tr>
+<td class="mono" title="{{.PubKey}}">{{.ShortPubKey}}</td>
+<td>{{.Index}}</td>
+<td>{{.Status}}</td>
+<td>{{.NextDuty}}</td>
+<td class="mono">{{.FeeRecipient}}</td>
+<td>{{.SettingsSource}}</td>
+<td>{{if .BuilderEnabled}}enabled{{else}}disabled{{end}}</td>
+<td>{{.GasLimit}}</td>
+<td>{{.HighestProposal}}</td>
+<td>{{.LowestSource}}</td>
+<td>{{.LowestTarget}}</td>
+</tr>
+{{end}}
+</table>
+{{else}}
+<p>No validating keys.</p>
+{{end}}
+
+<h2>Recent attestations and proposals</h2>
+{{if .Results}}
+<table>
+<tr><th>Time (UTC)</th><th>Slot</th><th>Public key</th><th>Duty</th><th>Result</th></tr>
+{{range .Results}}
+<tr>
+<td>{{.Time}}</td>
+<td>{{.Slot}}</td>
+<td class="mono">{{.PubKey}}</td>
+<td>{{.Duty}}</td>
+<td>{{if .Failed}}<span class="bad">{{.Error}}</span>{{else}}<span class="ok">submitted</span>{{end}}</td>
+</tr>
+{{end}}
+</table>
+{{else}}
+<p>No attestation or proposal since the validator client started.</p>
+{{end}}
+</body>
+</html>
+`
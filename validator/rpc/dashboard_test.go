@@ -0,0 +1,127 @@
+package rpc
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/golang/mock/gomock"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	validatormock "github.com/prysmaticlabs/prysm/v4/testing/validator-mock"
+	"github.com/prysmaticlabs/prysm/v4/time/slots"
+	"github.com/prysmaticlabs/prysm/v4/validator/accounts/iface"
+	mock "github.com/prysmaticlabs/prysm/v4/validator/accounts/testing"
+	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v4/validator/client"
+	clientiface "github.com/prysmaticlabs/prysm/v4/validator/client/iface"
+	dbtest "github.com/prysmaticlabs/prysm/v4/validator/db/testing"
+	remoteweb3signer "github.com/prysmaticlabs/prysm/v4/validator/keymanager/remote-web3signer"
+)
+
+func TestServer_Dashboard(t *testing.T) {
+	ctx := context.Background()
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+	key1 := bytesutil.ToBytes48(hexutil.MustDecode("0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"))
+	key2 := bytesutil.ToBytes48(hexutil.MustDecode("0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"))
+	recipient := common.HexToAddress("0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9")
+
+	root := make([]byte, fieldparams.RootLength)
+	root[0] = 1
+	w := wallet.NewWalletForWeb3Signer()
+	config := &remoteweb3signer.SetupConfig{
+		BaseEndpoint:          "http://example.com",
+		GenesisValidatorsRoot: root,
+		ProvidedPublicKeys:    [][fieldparams.BLSPubkeyLength]byte{key1, key2},
+	}
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false, Web3SignerConfig: config})
+	require.NoError(t, err)
+
+	// The mock genesis fetcher returns a genesis at the unix epoch.
+	slot := slots.CurrentSlot(0)
+	epochEnd, err := slots.EpochEnd(slots.ToEpoch(slot))
+	require.NoError(t, err)
+	m := &mock.MockValidator{
+		Km: km,
+		Duties: map[[48]byte]*clientiface.KeyDuties{
+			key1: {AttesterSlot: epochEnd, IsNextSyncCommittee: true},
+			key2: {AttesterSlot: epochEnd - params.BeaconConfig().SlotsPerEpoch},
+		},
+		DutyResults: []*clientiface.DutyResult{
+			{Slot: 42, PubKey: key2, Role: clientiface.RoleProposer, Err: errors.New("beacon node is syncing"), Time: time.Now()},
+			{Slot: 41, PubKey: key1, Role: clientiface.RoleAttester, Time: time.Now()},
+		},
+	}
+	m.SetProposerSettings(&validatorserviceconfig.ProposerSettings{
+		DefaultConfig: &validatorserviceconfig.ProposerOption{
+			FeeRecipientConfig: &validatorserviceconfig.FeeRecipientConfig{FeeRecipient: recipient},
+		},
+	})
+	vs, err := client.NewValidatorService(ctx, &client.Config{Validator: m})
+	require.NoError(t, err)
+
+	validatorDB := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{key1, key2})
+	require.NoError(t, validatorDB.SaveProposalHistoryForSlot(ctx, key1, 1234, make([]byte, 32)))
+
+	validatorClient := validatormock.NewMockValidatorClient(ctrl)
+	validatorClient.EXPECT().MultipleValidatorStatus(gomock.Any(), gomock.Any()).Return(&ethpb.MultipleValidatorStatusResponse{
+		PublicKeys: [][]byte{key1[:], key2[:]},
+		Statuses: []*ethpb.ValidatorStatusResponse{
+			{Status: ethpb.ValidatorStatus_ACTIVE},
+			{Status: ethpb.ValidatorStatus_UNKNOWN_STATUS},
+		},
+		Indices: []primitives.ValidatorIndex{7, 0},
+	}, nil)
+
+	s := &Server{
+		validatorService:          vs,
+		syncChecker:               &mockSyncChecker{syncing: false},
+		genesisFetcher:            &mockGenesisFetcher{},
+		nodeGatewayEndpoint:       "localhost:3500",
+		beaconNodeValidatorClient: validatorClient,
+		valDB:                     validatorDB,
+		jwtSecret:                 []byte("testKey"),
+	}
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/dashboard?token=invalid", nil)
+		writer := httptest.NewRecorder()
+		s.Dashboard(writer, request)
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	})
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest(http.MethodGet, "http://foo.example/dashboard?token="+url.QueryEscape(token), nil)
+		writer := httptest.NewRecorder()
+		s.Dashboard(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		assert.Equal(t, "text/html; charset=utf-8", writer.Header().Get("Content-Type"))
+		assert.Equal(t, "no-referrer", writer.Header().Get("Referrer-Policy"))
+		body := writer.Body.String()
+		assert.StringContains(t, "localhost:3500", body)
+		assert.StringContains(t, "<td>active</td>", body)
+		assert.StringContains(t, "<td>unknown_status</td>", body)
+		assert.StringContains(t, "<td>7</td>", body)
+		assert.StringContains(t, fmt.Sprintf("slot %d: attester, sync committee", epochEnd), body)
+		assert.StringContains(t, "none this epoch", body)
+		assert.StringContains(t, recipient.Hex(), body)
+		assert.StringContains(t, "<td>1234</td>", body)
+		assert.StringContains(t, "beacon node is syncing", body)
+		assert.StringContains(t, "submitted", body)
+	})
+}
@@ -2,7 +2,9 @@ package rpc
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"strings"
 
 	"github.com/golang-jwt/jwt/v4"
@@ -55,6 +57,19 @@ func (s *Server) authorize(ctx context.Context) error {
 	return nil
 }
 
+// authorizeHTTP checks that an HTTP request served outside of the gRPC gateway carries a valid token.
+func (s *Server) authorizeHTTP(r *http.Request) error {
+	authHeader := r.Header.Get("Authorization")
+	if !strings.HasPrefix(authHeader, "Bearer ") {
+		return errors.New("invalid auth header, needs Bearer {token}")
+	}
+	token := strings.TrimPrefix(authHeader, "Bearer ")
+	if _, err := jwt.Parse(token, s.validateJWT); err != nil {
+		return fmt.Errorf("could not parse JWT token: %v", err)
+	}
+	return nil
+}
+
 func (s *Server) validateJWT(token *jwt.Token) (interface{}, error) {
 	if _, ok := token.Method.(*jwt.SigningMethodHMAC); !ok {
 		return nil, fmt.Errorf("unexpected JWT signing method: %v", token.Header["alg"])
@@ -0,0 +1,272 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/network"
+	"github.com/prysmaticlabs/prysm/v4/validator/rpc/apimiddleware"
+)
+
+const (
+	// proposerRegistrationsPath is the beacon node endpoint returning the fee recipients and builder registrations
+	// known by the beacon node and its relays.
+	proposerRegistrationsPath = "/prysm/validators/proposer_registrations"
+	// proposerRegistrationsBatchSize is the maximum number of keys the beacon node accepts in a single request.
+	proposerRegistrationsBatchSize = 1000
+
+	settingsSourceProposerConfig = "proposer_config"
+	settingsSourceDefaultConfig  = "default_config"
+	settingsSourceNone           = "none"
+)
+
+// effectiveProposerSettings are the proposer settings a validator key is registered with.
+type effectiveProposerSettings struct {
+	source         string
+	feeRecipient   common.Address
+	builderEnabled bool
+	gasLimit       uint64
+}
+
+// resolveProposerSettings returns the settings the validator client uses when preparing the proposer and registering
+// a key with the builder, mirroring the way the registrations are built: the per key proposer config overrides the
+// default config, which is only used if it has a fee recipient.
+func resolveProposerSettings(settings *validatorserviceconfig.ProposerSettings, pubKey [fieldparams.BLSPubkeyLength]byte) *effectiveProposerSettings {
+	eff := &effectiveProposerSettings{
+		source:       settingsSourceNone,
+		feeRecipient: common.HexToAddress(params.BeaconConfig().EthBurnAddressHex),
+		gasLimit:     params.BeaconConfig().DefaultBuilderGasLimit,
+	}
+	if settings == nil {
+		return eff
+	}
+	if settings.DefaultConfig != nil && settings.DefaultConfig.FeeRecipientConfig != nil {
+		eff.source = settingsSourceDefaultConfig
+		eff.feeRecipient = settings.DefaultConfig.FeeRecipientConfig.FeeRecipient
+		if bc := settings.DefaultConfig.BuilderConfig; bc != nil && bc.Enabled {
+			eff.gasLimit = uint64(bc.GasLimit)
+			eff.builderEnabled = true
+		}
+	}
+	if settings.ProposeConfig != nil {
+		option, ok := settings.ProposeConfig[pubKey]
+		if ok && option != nil && option.FeeRecipientConfig != nil {
+			eff.source = settingsSourceProposerConfig
+			eff.feeRecipient = option.FeeRecipientConfig.FeeRecipient
+			if bc := option.BuilderConfig; bc != nil {
+				eff.builderEnabled = bc.Enabled
+				if bc.Enabled {
+					eff.gasLimit = uint64(bc.GasLimit)
+				}
+			}
+		}
+	}
+	return eff
+}
+
+// ProposerSettingsPreview is an HTTP handler which returns, for each key of the validator client, the effective
+// proposer settings the key is registered with, what the beacon node and its relays currently know about the key,
+// and the mismatches between the two. It does not send anything to the beacon node besides the lookup, the response
+// is a dry run of the next proposer preparation and builder registration.
+func (s *Server) ProposerSettingsPreview(w http.ResponseWriter, r *http.Request) {
+	if err := s.authorizeHTTP(r); err != nil {
+		network.WriteError(w, &network.DefaultErrorJson{Message: err.Error(), Code: http.StatusUnauthorized})
+		return
+	}
+	if s.validatorService == nil {
+		network.WriteError(w, &network.DefaultErrorJson{Message: "validator service not ready", Code: http.StatusServiceUnavailable})
+		return
+	}
+	km, err := s.validatorService.Keymanager()
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not get keymanager").Error(),
+			Code:    http.StatusServiceUnavailable,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+	pubKeys, err := km.FetchValidatingPublicKeys(r.Context())
+	if err != nil {
+		errJson := &network.DefaultErrorJson{
+			Message: errors.Wrap(err, "could not fetch validating public keys").Error(),
+			Code:    http.StatusInternalServerError,
+		}
+		network.WriteError(w, errJson)
+		return
+	}
+
+	resp := &apimiddleware.ProposerSettingsPreviewResponseJson{Data: make([]*apimiddleware.ProposerSettingsPreviewJson, len(pubKeys))}
+	if len(pubKeys) == 0 {
+		network.WriteJson(w, resp)
+		return
+	}
+	registrations, err := s.beaconProposerRegistrations(r.Context(), pubKeys)
+	if err != nil {
+		resp.BeaconNodeError = err.Error()
+	}
+	settings := s.validatorService.ProposerSettings()
+	for i, pubKey := range pubKeys {
+		eff := resolveProposerSettings(settings, pubKey)
+		preview := &apimiddleware.ProposerSettingsPreviewJson{
+			Pubkey:         hexutil.Encode(pubKey[:]),
+			Source:         eff.source,
+			FeeRecipient:   eff.feeRecipient.Hex(),
+			BuilderEnabled: eff.builderEnabled,
+			GasLimit:       strconv.FormatUint(eff.gasLimit, 10),
+			BeaconNode:     registrations[pubKey],
+			Mismatches:     make([]string, 0),
+		}
+		if preview.BeaconNode != nil {
+			preview.Mismatches = proposerSettingsMismatches(eff, preview.BeaconNode)
+		}
+		resp.Data[i] = preview
+	}
+	network.WriteJson(w, resp)
+}
+
+// beaconProposerRegistrations returns the fee recipients and builder registrations known by the beacon node and its
+// relays for the given keys. The beacon node endpoints are tried in turn until one of them answers.
+func (s *Server) beaconProposerRegistrations(
+	ctx context.Context,
+	pubKeys [][fieldparams.BLSPubkeyLength]byte,
+) (map[[fieldparams.BLSPubkeyLength]byte]*apimiddleware.ProposerRegistrationJson, error) {
+	if s.beaconApiEndpoint == "" {
+		return nil, errors.New("no beacon node REST API endpoint configured")
+	}
+	var bodies [][]byte
+	for start := 0; start < len(pubKeys); start += proposerRegistrationsBatchSize {
+		end := start + proposerRegistrationsBatchSize
+		if end > len(pubKeys) {
+			end = len(pubKeys)
+		}
+		hexKeys := make([]string, 0, end-start)
+		for _, pubKey := range pubKeys[start:end] {
+			hexKeys = append(hexKeys, hexutil.Encode(pubKey[:]))
+		}
+		body, err := json.Marshal(hexKeys)
+		if err != nil {
+			return nil, errors.Wrap(err, "could not marshal public keys")
+		}
+		bodies = append(bodies, body)
+	}
+	httpClient := &http.Client{Timeout: s.beaconApiTimeout}
+	var errs []string
+endpoints:
+	for _, endpoint := range strings.Split(s.beaconApiEndpoint, ",") {
+		byKey := make(map[[fieldparams.BLSPubkeyLength]byte]*apimiddleware.ProposerRegistrationJson, len(pubKeys))
+		for _, body := range bodies {
+			regs, err := requestProposerRegistrations(ctx, httpClient, strings.TrimSuffix(endpoint, "/"), s.beaconAdminApiToken, body)
+			if err != nil {
+				errs = append(errs, fmt.Sprintf("%s: %v", endpoint, err))
+				continue endpoints
+			}
+			for _, reg := range regs {
+				decoded, err := hexutil.Decode(reg.Pubkey)
+				if err != nil || len(decoded) != fieldparams.BLSPubkeyLength {
+					return nil, fmt.Errorf("beacon node %s returned an invalid public key %s", endpoint, reg.Pubkey)
+				}
+				byKey[bytesutil.ToBytes48(decoded)] = reg
+			}
+		}
+		return byKey, nil
+	}
+	return nil, fmt.Errorf("could not get proposer registrations from the beacon node: %s", strings.Join(errs, "; "))
+}
+
+// requestProposerRegistrations calls the proposer registrations endpoint of a beacon node, which is an admin endpoint
+// requiring the admin API token of the beacon node.
+func requestProposerRegistrations(
+	ctx context.Context, httpClient *http.Client, endpoint, adminToken string, body []byte,
+) ([]*apimiddleware.ProposerRegistrationJson, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, endpoint+proposerRegistrationsPath, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Wrap(err, "could not create request")
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if adminToken != "" {
+		req.Header.Set("Authorization", "Bearer "+adminToken)
+	}
+	httpResp, err := httpClient.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer func() {
+		if err := httpResp.Body.Close(); err != nil {
+			log.WithError(err).Debug("Could not close response body")
+		}
+	}()
+	if httpResp.StatusCode != http.StatusOK {
+		errJson := &network.DefaultErrorJson{}
+		if err := json.NewDecoder(io.LimitReader(httpResp.Body, 1<<20)).Decode(errJson); err != nil || errJson.Message == "" {
+			return nil, fmt.Errorf("unexpected status code %d", httpResp.StatusCode)
+		}
+		return nil, fmt.Errorf("unexpected status code %d: %s", httpResp.StatusCode, errJson.Message)
+	}
+	resp := &apimiddleware.ProposerRegistrationsResponseJson{}
+	if err := json.NewDecoder(httpResp.Body).Decode(resp); err != nil {
+		return nil, errors.Wrap(err, "could not decode response")
+	}
+	return resp.Data, nil
+}
+
+// proposerSettingsMismatches lists the differences between the effective settings of a key and what the beacon node
+// and its relays know about it.
+func proposerSettingsMismatches(eff *effectiveProposerSettings, bn *apimiddleware.ProposerRegistrationJson) []string {
+	mismatches := make([]string, 0)
+	if bn.Index == "" {
+		// The beacon node does not store anything for validators which are not in its validator set yet.
+		return mismatches
+	}
+	feeRecipient := eff.feeRecipient.Hex()
+	gasLimit := strconv.FormatUint(eff.gasLimit, 10)
+	switch {
+	case bn.FeeRecipient == "":
+		mismatches = append(mismatches, "beacon node has no fee recipient")
+	case !strings.EqualFold(bn.FeeRecipient, feeRecipient):
+		mismatches = append(mismatches, fmt.Sprintf("beacon node fee recipient is %s", bn.FeeRecipient))
+	}
+	if !eff.builderEnabled {
+		if bn.Registration != nil {
+			mismatches = append(mismatches, "beacon node has a builder registration but the builder is disabled")
+		}
+		return mismatches
+	}
+	if bn.Registration == nil {
+		mismatches = append(mismatches, "beacon node has no builder registration")
+	} else {
+		mismatches = append(mismatches, registrationMismatches("beacon node", bn.Registration, feeRecipient, gasLimit)...)
+	}
+	for _, relay := range bn.Relays {
+		if relay.Registration == nil {
+			mismatches = append(mismatches, fmt.Sprintf("relay %s has no builder registration: %s", relay.URL, relay.Error))
+			continue
+		}
+		mismatches = append(mismatches, registrationMismatches("relay "+relay.URL, relay.Registration, feeRecipient, gasLimit)...)
+	}
+	return mismatches
+}
+
+func registrationMismatches(holder string, reg *apimiddleware.ValidatorRegistrationJson, feeRecipient, gasLimit string) []string {
+	var mismatches []string
+	if !strings.EqualFold(reg.FeeRecipient, feeRecipient) {
+		mismatches = append(mismatches, fmt.Sprintf("%s registration fee recipient is %s", holder, reg.FeeRecipient))
+	}
+	if reg.GasLimit != gasLimit {
+		mismatches = append(mismatches, fmt.Sprintf("%s registration gas limit is %s", holder, reg.GasLimit))
+	}
+	return mismatches
+}
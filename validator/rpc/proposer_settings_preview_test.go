@@ -0,0 +1,161 @@
+package rpc
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	validatorserviceconfig "github.com/prysmaticlabs/prysm/v4/config/validator/service"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/validator"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/validator/accounts/iface"
+	mock "github.com/prysmaticlabs/prysm/v4/validator/accounts/testing"
+	"github.com/prysmaticlabs/prysm/v4/validator/accounts/wallet"
+	"github.com/prysmaticlabs/prysm/v4/validator/client"
+	remoteweb3signer "github.com/prysmaticlabs/prysm/v4/validator/keymanager/remote-web3signer"
+	"github.com/prysmaticlabs/prysm/v4/validator/rpc/apimiddleware"
+)
+
+func TestServer_ProposerSettingsPreview(t *testing.T) {
+	ctx := context.Background()
+	key1 := bytesutil.ToBytes48(hexutil.MustDecode("0x93247f2209abcacf57b75a51dafae777f9dd38bc7053d1af526f220a7489a6d3a2753e5f3e8b1cfe39b56f43611df74a"))
+	key2 := bytesutil.ToBytes48(hexutil.MustDecode("0xaf2e7ba294e03438ea819bd4033c6c1bf6b04320ee2075b77273c08d02f8a61bcc303c2c06bd3713cb442072ae591493"))
+	recipient1 := common.HexToAddress("0x046Fb65722E7b2455012BFEBf6177F1D2e9738D9")
+	recipient2 := common.HexToAddress("0x055Fb65722E7b2455012BFEBf6177F1D2e9738D8")
+
+	root := make([]byte, fieldparams.RootLength)
+	root[0] = 1
+	w := wallet.NewWalletForWeb3Signer()
+	config := &remoteweb3signer.SetupConfig{
+		BaseEndpoint:          "http://example.com",
+		GenesisValidatorsRoot: root,
+		ProvidedPublicKeys:    [][fieldparams.BLSPubkeyLength]byte{key1, key2},
+	}
+	km, err := w.InitializeKeymanager(ctx, iface.InitKeymanagerConfig{ListenForChanges: false, Web3SignerConfig: config})
+	require.NoError(t, err)
+	m := &mock.MockValidator{Km: km}
+	m.SetProposerSettings(&validatorserviceconfig.ProposerSettings{
+		ProposeConfig: map[[fieldparams.BLSPubkeyLength]byte]*validatorserviceconfig.ProposerOption{
+			key1: {
+				FeeRecipientConfig: &validatorserviceconfig.FeeRecipientConfig{FeeRecipient: recipient1},
+				BuilderConfig:      &validatorserviceconfig.BuilderConfig{Enabled: true, GasLimit: validator.Uint64(30000000)},
+			},
+		},
+		DefaultConfig: &validatorserviceconfig.ProposerOption{
+			FeeRecipientConfig: &validatorserviceconfig.FeeRecipientConfig{FeeRecipient: recipient2},
+		},
+	})
+	vs, err := client.NewValidatorService(ctx, &client.Config{Validator: m})
+	require.NoError(t, err)
+
+	beaconNode := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		require.Equal(t, proposerRegistrationsPath, r.URL.Path)
+		require.Equal(t, "Bearer admin-token", r.Header.Get("Authorization"))
+		var keys []string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&keys))
+		require.DeepEqual(t, []string{hexutil.Encode(key1[:]), hexutil.Encode(key2[:])}, keys)
+		resp := &apimiddleware.ProposerRegistrationsResponseJson{
+			Data: []*apimiddleware.ProposerRegistrationJson{
+				{
+					Pubkey:       hexutil.Encode(key1[:]),
+					Index:        "1",
+					FeeRecipient: hexutil.Encode(recipient1[:]),
+					Registration: &apimiddleware.ValidatorRegistrationJson{
+						FeeRecipient: hexutil.Encode(recipient1[:]),
+						GasLimit:     "25000000",
+						Timestamp:    "42",
+					},
+					Relays: []*apimiddleware.RelayRegistrationJson{
+						{
+							URL: "http://relay1",
+							Registration: &apimiddleware.ValidatorRegistrationJson{
+								FeeRecipient: hexutil.Encode(recipient1[:]),
+								GasLimit:     "30000000",
+								Timestamp:    "42",
+							},
+						},
+						{URL: "http://relay2", Error: "not found"},
+					},
+				},
+				{
+					Pubkey:       hexutil.Encode(key2[:]),
+					Index:        "2",
+					FeeRecipient: hexutil.Encode(recipient1[:]),
+					Relays:       []*apimiddleware.RelayRegistrationJson{},
+				},
+			},
+		}
+		require.NoError(t, json.NewEncoder(w).Encode(resp))
+	}))
+	defer beaconNode.Close()
+
+	s := &Server{
+		validatorService:    vs,
+		jwtSecret:           []byte("testKey"),
+		beaconApiEndpoint:   "http://127.0.0.1:1," + beaconNode.URL,
+		beaconApiTimeout:    time.Second,
+		beaconAdminApiToken: "admin-token",
+	}
+	token, err := createTokenString(s.jwtSecret)
+	require.NoError(t, err)
+
+	t.Run("unauthorized", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/v2/validator/proposer-settings/preview", nil)
+		writer := httptest.NewRecorder()
+		s.ProposerSettingsPreview(writer, request)
+		assert.Equal(t, http.StatusUnauthorized, writer.Code)
+	})
+	t.Run("ok", func(t *testing.T) {
+		request := httptest.NewRequest("GET", "http://foo.example/v2/validator/proposer-settings/preview", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ProposerSettingsPreview(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &apimiddleware.ProposerSettingsPreviewResponseJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.Equal(t, "", resp.BeaconNodeError)
+		require.Equal(t, 2, len(resp.Data))
+
+		assert.Equal(t, settingsSourceProposerConfig, resp.Data[0].Source)
+		assert.Equal(t, recipient1.Hex(), resp.Data[0].FeeRecipient)
+		assert.Equal(t, true, resp.Data[0].BuilderEnabled)
+		assert.Equal(t, "30000000", resp.Data[0].GasLimit)
+		assert.Equal(t, "1", resp.Data[0].BeaconNode.Index)
+		assert.DeepEqual(t, []string{
+			"beacon node registration gas limit is 25000000",
+			"relay http://relay2 has no builder registration: not found",
+		}, resp.Data[0].Mismatches)
+
+		assert.Equal(t, settingsSourceDefaultConfig, resp.Data[1].Source)
+		assert.Equal(t, recipient2.Hex(), resp.Data[1].FeeRecipient)
+		assert.Equal(t, false, resp.Data[1].BuilderEnabled)
+		assert.DeepEqual(t, []string{
+			"beacon node fee recipient is " + hexutil.Encode(recipient1[:]),
+		}, resp.Data[1].Mismatches)
+	})
+	t.Run("beacon node unavailable", func(t *testing.T) {
+		s.beaconApiEndpoint = "http://127.0.0.1:1"
+		request := httptest.NewRequest("GET", "http://foo.example/v2/validator/proposer-settings/preview", nil)
+		request.Header.Set("Authorization", "Bearer "+token)
+		writer := httptest.NewRecorder()
+		writer.Body = &bytes.Buffer{}
+		s.ProposerSettingsPreview(writer, request)
+		require.Equal(t, http.StatusOK, writer.Code)
+		resp := &apimiddleware.ProposerSettingsPreviewResponseJson{}
+		require.NoError(t, json.Unmarshal(writer.Body.Bytes(), resp))
+		assert.NotEqual(t, "", resp.BeaconNodeError)
+		require.Equal(t, 2, len(resp.Data))
+		assert.Equal(t, true, resp.Data[0].BeaconNode == nil)
+		assert.Equal(t, 0, len(resp.Data[0].Mismatches))
+	})
+}
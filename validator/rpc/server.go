@@ -52,6 +52,9 @@ type Config struct {
 	WalletInitializedFeed    *event.Feed
 	NodeGatewayEndpoint      string
 	Wallet                   *wallet.Wallet
+	BeaconApiEndpoint        string
+	BeaconApiTimeout         time.Duration
+	BeaconAdminApiToken      string
 }
 
 // Server defining a gRPC server for the remote signer API.
@@ -93,6 +96,7 @@ type Server struct {
 	validatorGatewayPort      int
 	beaconApiEndpoint         string
 	beaconApiTimeout          time.Duration
+	beaconAdminApiToken       string
 }
 
 // NewServer instantiates a new gRPC server.
@@ -126,6 +130,9 @@ func NewServer(ctx context.Context, cfg *Config) *Server {
 		validatorMonitoringPort:  cfg.ValidatorMonitoringPort,
 		validatorGatewayHost:     cfg.ValidatorGatewayHost,
 		validatorGatewayPort:     cfg.ValidatorGatewayPort,
+		beaconApiEndpoint:        cfg.BeaconApiEndpoint,
+		beaconApiTimeout:         cfg.BeaconApiTimeout,
+		beaconAdminApiToken:      cfg.BeaconAdminApiToken,
 	}
 }
 
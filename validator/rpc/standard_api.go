@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"fmt"
+	"net/url"
 
 	"github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/common/hexutil"
@@ -281,9 +282,13 @@ func (s *Server) ListRemoteKeys(ctx context.Context, _ *empty.Empty) (*ethpbserv
 	}
 	keystoreResponse := make([]*ethpbservice.ListRemoteKeysResponse_Keystore, len(pubKeys))
 	for i := 0; i < len(pubKeys); i++ {
+		signerURL := s.validatorService.Web3SignerConfig.BaseEndpoint
+		if fetcher, ok := km.(keymanager.SignerURLFetcher); ok {
+			signerURL = fetcher.SignerURL(pubKeys[i])
+		}
 		keystoreResponse[i] = &ethpbservice.ListRemoteKeysResponse_Keystore{
 			Pubkey:   pubKeys[i][:],
-			Url:      s.validatorService.Web3SignerConfig.BaseEndpoint,
+			Url:      redactSignerURL(signerURL),
 			Readonly: true,
 		}
 	}
@@ -292,6 +297,20 @@ func (s *Server) ListRemoteKeys(ctx context.Context, _ *empty.Empty) (*ethpbserv
 	}, nil
 }
 
+// redactSignerURL strips the userinfo, query and fragment of a web3signer url, which may hold credentials. Urls
+// which cannot be parsed are not returned at all.
+func redactSignerURL(signerURL string) string {
+	u, err := url.Parse(signerURL)
+	if err != nil {
+		return ""
+	}
+	u.User = nil
+	u.RawQuery = ""
+	u.Fragment = ""
+	u.RawFragment = ""
+	return u.String()
+}
+
 // ImportRemoteKeys imports a list of public keys defined for web3signer keymanager type.
 func (s *Server) ImportRemoteKeys(ctx context.Context, req *ethpbservice.ImportRemoteKeysRequest) (*ethpbservice.ImportRemoteKeysResponse, error) {
 	if !s.walletInitialized {
@@ -307,25 +326,21 @@ func (s *Server) ImportRemoteKeys(ctx context.Context, req *ethpbservice.ImportR
 	if s.wallet.KeymanagerKind() != keymanager.Web3Signer {
 		return nil, status.Errorf(codes.FailedPrecondition, "Prysm Wallet is not of type Web3Signer. Please execute validator client with web3signer flags.")
 	}
-	adder, ok := km.(keymanager.PublicKeyAdder)
+	adder, ok := km.(keymanager.RemotePublicKeyAdder)
 	if !ok {
 		statuses := groupImportRemoteKeysErrors(req, "Keymanager kind cannot import public keys for web3signer keymanager type.")
 		return &ethpbservice.ImportRemoteKeysResponse{Data: statuses}, nil
 	}
 
 	remoteKeys := make([][fieldparams.BLSPubkeyLength]byte, len(req.RemoteKeys))
-	isUrlUsed := false
+	urls := make([]string, len(req.RemoteKeys))
 	for i, obj := range req.RemoteKeys {
 		remoteKeys[i] = bytesutil.ToBytes48(obj.Pubkey)
-		if obj.Url != "" {
-			isUrlUsed = true
-		}
-	}
-	if isUrlUsed {
-		log.Warnf("Setting web3signer base url for imported keys is not supported. Prysm only uses the url from --validators-external-signer-url flag for web3signer.")
+		// Keys without an url are held by the web3signer from the --validators-external-signer-url flag.
+		urls[i] = obj.Url
 	}
 
-	statuses, err := adder.AddPublicKeys(ctx, remoteKeys)
+	statuses, err := adder.AddRemotePublicKeys(ctx, remoteKeys, urls)
 	if err != nil {
 		sts := groupImportRemoteKeysErrors(req, fmt.Sprintf("Could not add keys;error: %v", err))
 		return &ethpbservice.ImportRemoteKeysResponse{Data: sts}, nil
@@ -0,0 +1,14 @@
+// Package shared implements slashing protection records shared between validator clients using the same keys,
+// such as active/passive validator client pairs signing with the same web3signer. Every proposal and attestation
+// is checked against and recorded in the shared store before it is signed, so that a validator client refuses
+// to sign what the other validator client already signed conflicting messages for, which its own local
+// slashing protection database does not know about.
+//
+// The shared store is either a bolt database file accessible to all the validator clients, or a service
+// implementing the HTTP interface served by NewHandler, such as `prysmctl validator shared-slashing-protection`,
+// which only accepts requests carrying its bearer token.
+//
+// The local slashing protection history of each key is reconciled into the shared store before the key's first
+// message is checked, so that validator clients enabling shared slashing protection on keys that already signed
+// messages are protected from conflicting with them.
+package shared
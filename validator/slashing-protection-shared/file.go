@@ -0,0 +1,290 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/config/params"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	bolt "go.etcd.io/bbolt"
+	"go.opencensus.io/trace"
+)
+
+const (
+	// fileLockTimeout is how long to wait for the other validator clients to release the bolt file.
+	fileLockTimeout = 2 * time.Second
+	// DefaultHistoryEpochs is the number of epochs of records kept below the latest record of each key by default.
+	DefaultHistoryEpochs = primitives.Epoch(4096)
+)
+
+var (
+	proposalsBucket    = []byte("proposals")
+	attestationsBucket = []byte("attestations")
+	// minSourcesBucket maps public keys to the lowest source epoch allowed for their attestations, which is the
+	// highest source epoch of their pruned attestations.
+	minSourcesBucket = []byte("min-sources")
+)
+
+// FileStore stores the shared records in a bolt database file. The file is only opened while checking and
+// recording messages, as bolt files can only be opened by one process at a time. The messages checked concurrently
+// by the validator client are serialized in process, and the ones waiting for the file are checked and recorded in a
+// single opening of the file and a single transaction.
+//
+// Proposals are stored in a bucket per public key, mapping slots to signing roots. Attestations are stored in a
+// bucket per public key, mapping target epochs to source epochs followed by signing roots. Slots and epochs are
+// big endian encoded so that the records are sorted.
+//
+// Records more than historyEpochs below the latest record of the key are pruned when a message is recorded. The
+// latest records are always kept, and messages below the lowest kept record are rejected, so pruning does not let
+// slashable messages through.
+type FileStore struct {
+	path          string
+	historyEpochs primitives.Epoch
+
+	// fileLock is held by the update opening the file, pendingLock protects the updates waiting for it.
+	fileLock    sync.Mutex
+	pendingLock sync.Mutex
+	pending     []*fileUpdate
+}
+
+// fileUpdate is a check and record of a message waiting to be applied to the file.
+type fileUpdate struct {
+	fn   func(tx *bolt.Tx) error
+	err  error
+	done chan struct{}
+}
+
+// FileStoreOption configures a FileStore.
+type FileStoreOption func(*FileStore)
+
+// WithHistoryEpochs sets the number of epochs of records kept below the latest record of each key. Zero keeps all
+// the records.
+func WithHistoryEpochs(epochs primitives.Epoch) FileStoreOption {
+	return func(s *FileStore) {
+		s.historyEpochs = epochs
+	}
+}
+
+// NewFileStore returns a store using the bolt database file at the given path, which is created if it does not
+// exist. It keeps DefaultHistoryEpochs of records unless configured otherwise.
+func NewFileStore(path string, opts ...FileStoreOption) (*FileStore, error) {
+	// The directory may be shared with other users, so its permissions are not enforced.
+	if err := os.MkdirAll(filepath.Dir(path), params.BeaconIoConfig().ReadWriteExecutePermissions); err != nil {
+		return nil, errors.Wrapf(err, "could not create directory of %s", path)
+	}
+	s := &FileStore{path: path, historyEpochs: DefaultHistoryEpochs}
+	for _, opt := range opts {
+		opt(s)
+	}
+	if err := s.update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{proposalsBucket, attestationsBucket, minSourcesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+	return s, nil
+}
+
+// CheckAndSaveProposal --
+func (s *FileStore) CheckAndSaveProposal(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, signingRoot [32]byte,
+) error {
+	_, span := trace.StartSpan(ctx, "shared.FileStore.CheckAndSaveProposal")
+	defer span.End()
+
+	return s.update(func(tx *bolt.Tx) error {
+		bkt, err := tx.Bucket(proposalsBucket).CreateBucketIfNotExists(pubKey[:])
+		if err != nil {
+			return err
+		}
+		key := bytesutil.Uint64ToBytesBigEndian(uint64(slot))
+		c := bkt.Cursor()
+		lowest, _ := c.First()
+		if existing := bkt.Get(key); existing != nil {
+			if signingRoot == params.BeaconConfig().ZeroHash || !bytes.Equal(existing, signingRoot[:]) {
+				return errors.Wrapf(ErrSlashable, "a different block was signed at slot %d", slot)
+			}
+			return nil
+		}
+		if lowest != nil && bytesutil.BytesToUint64BigEndian(lowest) > uint64(slot) {
+			return errors.Wrapf(ErrSlashable, "slot %d is lower than the lowest signed slot %d",
+				slot, bytesutil.BytesToUint64BigEndian(lowest))
+		}
+		if err := bkt.Put(key, signingRoot[:]); err != nil {
+			return err
+		}
+		if s.historyEpochs == 0 {
+			return nil
+		}
+		// Proposals below the pruned ones are still rejected as lower than the lowest signed slot.
+		_, err = pruneBelow(bkt, uint64(s.historyEpochs)*uint64(params.BeaconConfig().SlotsPerEpoch))
+		return err
+	})
+}
+
+// CheckAndSaveAttestation --
+func (s *FileStore) CheckAndSaveAttestation(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, source, target primitives.Epoch, signingRoot [32]byte,
+) error {
+	_, span := trace.StartSpan(ctx, "shared.FileStore.CheckAndSaveAttestation")
+	defer span.End()
+
+	return s.update(func(tx *bolt.Tx) error {
+		bkt, err := tx.Bucket(attestationsBucket).CreateBucketIfNotExists(pubKey[:])
+		if err != nil {
+			return err
+		}
+		key := bytesutil.Uint64ToBytesBigEndian(uint64(target))
+		if existing := bkt.Get(key); existing != nil {
+			if signingRoot != params.BeaconConfig().ZeroHash && bytes.Equal(existing[8:], signingRoot[:]) {
+				return nil
+			}
+			return errors.Wrapf(ErrSlashable, "a different attestation was signed with target epoch %d", target)
+		}
+		c := bkt.Cursor()
+		if lowest, _ := c.First(); lowest != nil && bytesutil.BytesToUint64BigEndian(lowest) > uint64(target) {
+			return errors.Wrapf(ErrSlashable, "target epoch %d is lower than the lowest signed target epoch %d",
+				target, bytesutil.BytesToUint64BigEndian(lowest))
+		}
+		// An attestation with a lower source could surround a pruned attestation.
+		minSources := tx.Bucket(minSourcesBucket)
+		if enc := minSources.Get(pubKey[:]); enc != nil && bytesutil.BytesToUint64BigEndian(enc) > uint64(source) {
+			return errors.Wrapf(ErrSlashable, "source epoch %d is lower than the source epoch %d of a pruned attestation",
+				source, bytesutil.BytesToUint64BigEndian(enc))
+		}
+		for k, v := c.First(); k != nil; k, v = c.Next() {
+			recordedTarget := primitives.Epoch(bytesutil.BytesToUint64BigEndian(k))
+			recordedSource := primitives.Epoch(bytesutil.BytesToUint64BigEndian(v[:8]))
+			if source < recordedSource && target > recordedTarget {
+				return errors.Wrapf(ErrSlashable, "attestation with source %d and target %d surrounds the attestation "+
+					"with source %d and target %d", source, target, recordedSource, recordedTarget)
+			}
+			if source > recordedSource && target < recordedTarget {
+				return errors.Wrapf(ErrSlashable, "attestation with source %d and target %d is surrounded by the "+
+					"attestation with source %d and target %d", source, target, recordedSource, recordedTarget)
+			}
+		}
+		if err := bkt.Put(key, append(bytesutil.Uint64ToBytesBigEndian(uint64(source)), signingRoot[:]...)); err != nil {
+			return err
+		}
+		if s.historyEpochs == 0 {
+			return nil
+		}
+		pruned, err := pruneBelow(bkt, uint64(s.historyEpochs))
+		if err != nil || len(pruned) == 0 {
+			return err
+		}
+		var minSource uint64
+		if enc := minSources.Get(pubKey[:]); enc != nil {
+			minSource = bytesutil.BytesToUint64BigEndian(enc)
+		}
+		for _, v := range pruned {
+			if prunedSource := bytesutil.BytesToUint64BigEndian(v[:8]); prunedSource > minSource {
+				minSource = prunedSource
+			}
+		}
+		return minSources.Put(pubKey[:], bytesutil.Uint64ToBytesBigEndian(minSource))
+	})
+}
+
+// pruneBelow deletes the records of the bucket whose key is more than distance below the latest key, and returns
+// their values.
+func pruneBelow(bkt *bolt.Bucket, distance uint64) ([][]byte, error) {
+	c := bkt.Cursor()
+	latest, _ := c.Last()
+	if latest == nil || bytesutil.BytesToUint64BigEndian(latest) <= distance {
+		return nil, nil
+	}
+	cutoff := bytesutil.BytesToUint64BigEndian(latest) - distance
+	var keys, values [][]byte
+	for k, v := c.First(); k != nil && bytesutil.BytesToUint64BigEndian(k) < cutoff; k, v = c.Next() {
+		keys = append(keys, bytesutil.SafeCopyBytes(k))
+		values = append(values, bytesutil.SafeCopyBytes(v))
+	}
+	for _, k := range keys {
+		if err := bkt.Delete(k); err != nil {
+			return nil, err
+		}
+	}
+	return values, nil
+}
+
+// update applies fn to the file, along with the other updates waiting for the file.
+func (s *FileStore) update(fn func(tx *bolt.Tx) error) error {
+	u := &fileUpdate{fn: fn, done: make(chan struct{})}
+	s.pendingLock.Lock()
+	s.pending = append(s.pending, u)
+	s.pendingLock.Unlock()
+
+	s.fileLock.Lock()
+	s.pendingLock.Lock()
+	batch := s.pending
+	s.pending = nil
+	s.pendingLock.Unlock()
+	// The batch is empty when the update was applied by the previous holder of the lock.
+	if len(batch) > 0 {
+		s.apply(batch)
+	}
+	s.fileLock.Unlock()
+
+	<-u.done
+	return u.err
+}
+
+// apply opens the file once for a batch of updates. The updates are committed in a single transaction with bolt's
+// Batch, which retries the other updates without an update which failed.
+func (s *FileStore) apply(batch []*fileUpdate) {
+	defer func() {
+		for _, u := range batch {
+			close(u.done)
+		}
+	}()
+	db, err := bolt.Open(s.path, params.BeaconIoConfig().ReadWritePermissions, &bolt.Options{Timeout: fileLockTimeout})
+	if err != nil {
+		if errors.Is(err, bolt.ErrTimeout) {
+			err = fmt.Errorf("shared slashing protection file %s is locked by another process", s.path)
+		} else {
+			err = errors.Wrapf(err, "could not open shared slashing protection file %s", s.path)
+		}
+		for _, u := range batch {
+			u.err = err
+		}
+		return
+	}
+
+	if len(batch) == 1 {
+		batch[0].err = db.Update(batch[0].fn)
+	} else {
+		// The transaction is committed as soon as all the updates joined it.
+		db.MaxBatchSize = len(batch)
+		var wg sync.WaitGroup
+		for _, u := range batch {
+			wg.Add(1)
+			go func(u *fileUpdate) {
+				defer wg.Done()
+				u.err = db.Batch(u.fn)
+			}(u)
+		}
+		wg.Wait()
+	}
+
+	if err := db.Close(); err != nil {
+		for _, u := range batch {
+			if u.err == nil {
+				u.err = err
+			}
+		}
+	}
+}
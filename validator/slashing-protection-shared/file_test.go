@@ -0,0 +1,144 @@
+package shared
+
+import (
+	"context"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	bolt "go.etcd.io/bbolt"
+)
+
+var (
+	pubKey  = [48]byte{1}
+	rootA   = [32]byte{'a'}
+	rootB   = [32]byte{'b'}
+	zero32  = [32]byte{}
+	otherPk = [48]byte{2}
+)
+
+func TestFileStore_CheckAndSaveProposal(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileStore(filepath.Join(t.TempDir(), "shared", "protection.db"))
+	require.NoError(t, err)
+
+	require.NoError(t, s.CheckAndSaveProposal(ctx, pubKey, 10, rootA))
+	// The same block may be signed again.
+	require.NoError(t, s.CheckAndSaveProposal(ctx, pubKey, 10, rootA))
+	err = s.CheckAndSaveProposal(ctx, pubKey, 10, rootB)
+	require.ErrorIs(t, err, ErrSlashable)
+	assert.ErrorContains(t, "a different block was signed at slot 10", err)
+	require.ErrorIs(t, s.CheckAndSaveProposal(ctx, pubKey, 9, rootB), ErrSlashable)
+	require.NoError(t, s.CheckAndSaveProposal(ctx, pubKey, 11, rootB))
+	// Records are kept per public key.
+	require.NoError(t, s.CheckAndSaveProposal(ctx, otherPk, 9, rootB))
+
+	// Records are shared with the other stores using the same file.
+	other, err := NewFileStore(s.path)
+	require.NoError(t, err)
+	require.ErrorIs(t, other.CheckAndSaveProposal(ctx, pubKey, 11, rootA), ErrSlashable)
+	// A block signed with an unknown signing root cannot be signed again.
+	require.NoError(t, other.CheckAndSaveProposal(ctx, pubKey, 12, zero32))
+	require.ErrorIs(t, other.CheckAndSaveProposal(ctx, pubKey, 12, zero32), ErrSlashable)
+}
+
+func TestFileStore_CheckAndSaveAttestation(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileStore(filepath.Join(t.TempDir(), "protection.db"))
+	require.NoError(t, err)
+
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, pubKey, 4, 5, rootA))
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, pubKey, 4, 5, rootA))
+	tests := []struct {
+		name           string
+		source, target uint64
+		err            string
+	}{
+		{name: "double vote", source: 3, target: 5, err: "a different attestation was signed with target epoch 5"},
+		{name: "target lower than lowest", source: 1, target: 2, err: "target epoch 2 is lower than the lowest signed target epoch 5"},
+		{name: "surrounding", source: 3, target: 7, err: "surrounds the attestation with source 4 and target 5"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := s.CheckAndSaveAttestation(ctx, pubKey, primitives.Epoch(tt.source), primitives.Epoch(tt.target), rootB)
+			require.ErrorIs(t, err, ErrSlashable)
+			assert.ErrorContains(t, tt.err, err)
+		})
+	}
+
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, pubKey, 5, 10, rootB))
+	err = s.CheckAndSaveAttestation(ctx, pubKey, 6, 9, rootB)
+	require.ErrorIs(t, err, ErrSlashable)
+	assert.ErrorContains(t, "is surrounded by the attestation with source 5 and target 10", err)
+	err = s.CheckAndSaveAttestation(ctx, pubKey, 3, 11, rootB)
+	require.ErrorIs(t, err, ErrSlashable)
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, pubKey, 10, 11, rootB))
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, otherPk, 0, 1, rootB))
+}
+
+func TestFileStore_ConcurrentUpdates(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileStore(filepath.Join(t.TempDir(), "protection.db"))
+	require.NoError(t, err)
+
+	// Concurrent proposals of different blocks at the same slot are batched, only one of them is recorded.
+	const n = 16
+	errs := make([]error, n)
+	var wg sync.WaitGroup
+	for i := 0; i < n; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			errs[i] = s.CheckAndSaveProposal(ctx, pubKey, 10, [32]byte{byte(i + 1)})
+			if err := s.CheckAndSaveProposal(ctx, [48]byte{byte(i + 10)}, 10, rootA); err != nil {
+				errs[i] = err
+			}
+		}(i)
+	}
+	wg.Wait()
+	recorded := 0
+	for _, err := range errs {
+		if err == nil {
+			recorded++
+			continue
+		}
+		require.ErrorIs(t, err, ErrSlashable)
+	}
+	assert.Equal(t, 1, recorded)
+	for i := 0; i < n; i++ {
+		require.NoError(t, s.CheckAndSaveProposal(ctx, [48]byte{byte(i + 10)}, 10, rootA))
+	}
+}
+
+func TestFileStore_PruneHistory(t *testing.T) {
+	ctx := context.Background()
+	s, err := NewFileStore(filepath.Join(t.TempDir(), "protection.db"), WithHistoryEpochs(4))
+	require.NoError(t, err)
+	count := func(bucket []byte) int {
+		var n int
+		require.NoError(t, s.update(func(tx *bolt.Tx) error {
+			n = tx.Bucket(bucket).Bucket(pubKey[:]).Stats().KeyN
+			return nil
+		}))
+		return n
+	}
+
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, pubKey, 1, 2, rootA))
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, pubKey, 2, 3, rootA))
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, pubKey, 3, 10, rootA))
+	assert.Equal(t, 1, count(attestationsBucket))
+	// Attestations which could surround the pruned attestations are still rejected.
+	err = s.CheckAndSaveAttestation(ctx, pubKey, 1, 11, rootA)
+	require.ErrorIs(t, err, ErrSlashable)
+	assert.ErrorContains(t, "source epoch 1 is lower than the source epoch 2 of a pruned attestation", err)
+	require.ErrorIs(t, s.CheckAndSaveAttestation(ctx, pubKey, 2, 5, rootA), ErrSlashable)
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, pubKey, 3, 11, rootA))
+
+	require.NoError(t, s.CheckAndSaveProposal(ctx, pubKey, 1, rootA))
+	require.NoError(t, s.CheckAndSaveProposal(ctx, pubKey, 4*32+10, rootA))
+	assert.Equal(t, 1, count(proposalsBucket))
+	require.ErrorIs(t, s.CheckAndSaveProposal(ctx, pubKey, 2, rootA), ErrSlashable)
+}
@@ -0,0 +1,116 @@
+package shared
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+)
+
+// NewHandler returns the HTTP interface of the given store, which lets validator clients on different hosts share
+// a store, such as a file store, through an HTTPStore. As any client able to record messages can prevent validators
+// from signing, requests must carry the given token in a `Authorization: Bearer <token>` header, and all requests
+// are rejected when the token is empty.
+func NewHandler(store Store, token string) http.Handler {
+	mux := http.NewServeMux()
+	mux.HandleFunc(proposalsPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		req := &ProposalJson{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode request body"))
+			return
+		}
+		pubKey, signingRoot, err := decodeKeyAndRoot(req.PublicKey, req.SigningRoot)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		slot, err := strconv.ParseUint(req.Slot, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid slot"))
+			return
+		}
+		writeResult(w, store.CheckAndSaveProposal(r.Context(), pubKey, primitives.Slot(slot), signingRoot))
+	})
+	mux.HandleFunc(attestationsPath, func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			writeError(w, http.StatusMethodNotAllowed, errors.New("method not allowed"))
+			return
+		}
+		req := &AttestationJson{}
+		if err := json.NewDecoder(r.Body).Decode(req); err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "could not decode request body"))
+			return
+		}
+		pubKey, signingRoot, err := decodeKeyAndRoot(req.PublicKey, req.SigningRoot)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, err)
+			return
+		}
+		source, err := strconv.ParseUint(req.SourceEpoch, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid source epoch"))
+			return
+		}
+		target, err := strconv.ParseUint(req.TargetEpoch, 10, 64)
+		if err != nil {
+			writeError(w, http.StatusBadRequest, errors.Wrap(err, "invalid target epoch"))
+			return
+		}
+		writeResult(w, store.CheckAndSaveAttestation(r.Context(), pubKey, primitives.Epoch(source), primitives.Epoch(target), signingRoot))
+	})
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if token == "" {
+			writeError(w, http.StatusForbidden, errors.New("no token is configured for the shared slashing protection service"))
+			return
+		}
+		reqToken, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || subtle.ConstantTimeCompare([]byte(reqToken), []byte(token)) != 1 {
+			writeError(w, http.StatusUnauthorized, errors.New("missing or invalid token"))
+			return
+		}
+		mux.ServeHTTP(w, r)
+	})
+}
+
+func decodeKeyAndRoot(pubKey, signingRoot string) ([fieldparams.BLSPubkeyLength]byte, [32]byte, error) {
+	key, err := hexutil.Decode(pubKey)
+	if err != nil || len(key) != fieldparams.BLSPubkeyLength {
+		return [fieldparams.BLSPubkeyLength]byte{}, [32]byte{}, errors.New("invalid public key")
+	}
+	root, err := hexutil.Decode(signingRoot)
+	if err != nil || len(root) != 32 {
+		return [fieldparams.BLSPubkeyLength]byte{}, [32]byte{}, errors.New("invalid signing root")
+	}
+	return bytesutil.ToBytes48(key), bytesutil.ToBytes32(root), nil
+}
+
+func writeResult(w http.ResponseWriter, err error) {
+	switch {
+	case err == nil:
+		w.WriteHeader(http.StatusOK)
+	case errors.Is(err, ErrSlashable):
+		writeError(w, http.StatusConflict, err)
+	default:
+		log.WithError(err).Error("Could not check message against shared slashing protection records")
+		writeError(w, http.StatusInternalServerError, err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, err error) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(&ErrorJson{Message: err.Error()}); err != nil {
+		log.WithError(err).Error("Could not write error response")
+	}
+}
@@ -0,0 +1,96 @@
+package shared
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/prysmaticlabs/prysm/v4/testing/assert"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+)
+
+func TestHTTPStore(t *testing.T) {
+	ctx := context.Background()
+	fs, err := NewFileStore(filepath.Join(t.TempDir(), "protection.db"))
+	require.NoError(t, err)
+	srv := httptest.NewServer(NewHandler(fs, "token"))
+	t.Cleanup(srv.Close)
+
+	_, err = New(srv.URL, "")
+	require.ErrorContains(t, "a token is required", err)
+	s, err := New(srv.URL, "token")
+	require.NoError(t, err)
+	require.NoError(t, s.CheckAndSaveProposal(ctx, pubKey, 10, rootA))
+	err = s.CheckAndSaveProposal(ctx, pubKey, 10, rootB)
+	require.ErrorIs(t, err, ErrSlashable)
+	assert.ErrorContains(t, "a different block was signed at slot 10", err)
+
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, pubKey, 4, 5, rootA))
+	err = s.CheckAndSaveAttestation(ctx, pubKey, 3, 6, rootA)
+	require.ErrorIs(t, err, ErrSlashable)
+	assert.ErrorContains(t, "surrounds the attestation", err)
+
+	// Records of the service are shared with the validator clients using the file directly.
+	require.ErrorIs(t, fs.CheckAndSaveAttestation(ctx, pubKey, 3, 5, rootB), ErrSlashable)
+}
+
+func TestHTTPStore_Errors(t *testing.T) {
+	ctx := context.Background()
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		writeError(w, http.StatusServiceUnavailable, context.DeadlineExceeded)
+	}))
+	t.Cleanup(srv.Close)
+	u, err := url.Parse(srv.URL)
+	require.NoError(t, err)
+
+	// Messages are not signed when the service cannot check them.
+	err = NewHTTPStore(u, "token").CheckAndSaveProposal(ctx, pubKey, 10, rootA)
+	assert.ErrorContains(t, "responded with status 503: context deadline exceeded", err)
+	assert.Equal(t, false, strings.Contains(err.Error(), ErrSlashable.Error()))
+}
+
+func TestHandler_InvalidRequest(t *testing.T) {
+	fs, err := NewFileStore(filepath.Join(t.TempDir(), "protection.db"))
+	require.NoError(t, err)
+	h := NewHandler(fs, "token")
+	request := func(method, path, body string) *http.Request {
+		req := httptest.NewRequest(method, path, strings.NewReader(body))
+		req.Header.Set("Authorization", "Bearer token")
+		return req
+	}
+
+	rec := httptest.NewRecorder()
+	h.ServeHTTP(rec, request(http.MethodPost, proposalsPath, `{"pubkey":"0x01","slot":"1","signing_root":"0x01"}`))
+	assert.Equal(t, http.StatusBadRequest, rec.Code)
+	assert.StringContains(t, "invalid public key", rec.Body.String())
+
+	rec = httptest.NewRecorder()
+	h.ServeHTTP(rec, request(http.MethodGet, attestationsPath, ""))
+	assert.Equal(t, http.StatusMethodNotAllowed, rec.Code)
+}
+
+func TestHandler_Unauthorized(t *testing.T) {
+	fs, err := NewFileStore(filepath.Join(t.TempDir(), "protection.db"))
+	require.NoError(t, err)
+	body := `{"pubkey":"0x01","slot":"1","signing_root":"0x01"}`
+
+	rec := httptest.NewRecorder()
+	NewHandler(fs, "token").ServeHTTP(rec, httptest.NewRequest(http.MethodPost, proposalsPath, strings.NewReader(body)))
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	req := httptest.NewRequest(http.MethodPost, proposalsPath, strings.NewReader(body))
+	req.Header.Set("Authorization", "Bearer other")
+	rec = httptest.NewRecorder()
+	NewHandler(fs, "token").ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusUnauthorized, rec.Code)
+
+	// The service is not usable without a token.
+	req.Header.Set("Authorization", "Bearer ")
+	rec = httptest.NewRecorder()
+	NewHandler(fs, "").ServeHTTP(rec, req)
+	assert.Equal(t, http.StatusForbidden, rec.Code)
+}
@@ -0,0 +1,127 @@
+package shared
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	"go.opencensus.io/trace"
+)
+
+const (
+	proposalsPath    = "/v1/slashing-protection/proposals"
+	attestationsPath = "/v1/slashing-protection/attestations"
+	httpTimeout      = 5 * time.Second
+)
+
+// ProposalJson is the request body of the proposals endpoint.
+type ProposalJson struct {
+	PublicKey   string `json:"pubkey"`
+	Slot        string `json:"slot"`
+	SigningRoot string `json:"signing_root"`
+}
+
+// AttestationJson is the request body of the attestations endpoint.
+type AttestationJson struct {
+	PublicKey   string `json:"pubkey"`
+	SourceEpoch string `json:"source_epoch"`
+	TargetEpoch string `json:"target_epoch"`
+	SigningRoot string `json:"signing_root"`
+}
+
+// ErrorJson is the response body of the endpoints when the message is rejected or cannot be checked.
+type ErrorJson struct {
+	Message string `json:"message"`
+}
+
+// HTTPStore checks and records messages with a service implementing the interface served by Handler. Messages are
+// posted to the proposals and attestations endpoints, which respond with 200 OK once the message is recorded and
+// 409 Conflict when it is slashable.
+type HTTPStore struct {
+	baseURL *url.URL
+	token   string
+	client  *http.Client
+}
+
+// NewHTTPStore returns a store using the service at the given URL, authenticated with the given bearer token.
+func NewHTTPStore(baseURL *url.URL, token string) *HTTPStore {
+	return &HTTPStore{
+		baseURL: baseURL,
+		token:   token,
+		client:  &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// CheckAndSaveProposal --
+func (s *HTTPStore) CheckAndSaveProposal(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, signingRoot [32]byte,
+) error {
+	ctx, span := trace.StartSpan(ctx, "shared.HTTPStore.CheckAndSaveProposal")
+	defer span.End()
+
+	return s.post(ctx, proposalsPath, &ProposalJson{
+		PublicKey:   hexutil.Encode(pubKey[:]),
+		Slot:        strconv.FormatUint(uint64(slot), 10),
+		SigningRoot: hexutil.Encode(signingRoot[:]),
+	})
+}
+
+// CheckAndSaveAttestation --
+func (s *HTTPStore) CheckAndSaveAttestation(
+	ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, source, target primitives.Epoch, signingRoot [32]byte,
+) error {
+	ctx, span := trace.StartSpan(ctx, "shared.HTTPStore.CheckAndSaveAttestation")
+	defer span.End()
+
+	return s.post(ctx, attestationsPath, &AttestationJson{
+		PublicKey:   hexutil.Encode(pubKey[:]),
+		SourceEpoch: strconv.FormatUint(uint64(source), 10),
+		TargetEpoch: strconv.FormatUint(uint64(target), 10),
+		SigningRoot: hexutil.Encode(signingRoot[:]),
+	})
+}
+
+func (s *HTTPStore) post(ctx context.Context, path string, body interface{}) error {
+	enc, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.baseURL.JoinPath(path).String(), bytes.NewReader(enc))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("Authorization", "Bearer "+s.token)
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return errors.Wrap(err, "could not reach shared slashing protection service")
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			log.WithError(err).Error("Could not close response body")
+		}
+	}()
+	if resp.StatusCode == http.StatusOK {
+		return nil
+	}
+	errJson := &ErrorJson{}
+	if b, err := io.ReadAll(resp.Body); err == nil {
+		if err := json.Unmarshal(b, errJson); err != nil {
+			errJson.Message = string(b)
+		}
+	}
+	if resp.StatusCode == http.StatusConflict {
+		return errors.Wrap(ErrSlashable, errJson.Message)
+	}
+	return fmt.Errorf("shared slashing protection service responded with status %d: %s", resp.StatusCode, errJson.Message)
+}
@@ -0,0 +1,5 @@
+package shared
+
+import "github.com/sirupsen/logrus"
+
+var log = logrus.WithField("prefix", "slashing-protection-shared")
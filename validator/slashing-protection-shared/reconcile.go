@@ -0,0 +1,69 @@
+package shared
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/encoding/bytesutil"
+	"github.com/prysmaticlabs/prysm/v4/validator/db/iface"
+	"go.opencensus.io/trace"
+)
+
+// Reconcile records the latest proposal and the attestation with the highest target epoch of the local slashing
+// protection history of the public key in the shared store, so that the other validator clients sharing the store
+// do not sign messages conflicting with them. Older local records are covered as well, as the store rejects the
+// messages lower than its lowest records, similarly to the minimal interchange format of EIP-3076.
+//
+// A local record which cannot be recorded in the shared store is only logged: it means the validator clients
+// signed conflicting messages before sharing the store, or that the shared records already cover it.
+func Reconcile(ctx context.Context, store Store, db iface.ValidatorDB, pubKey [fieldparams.BLSPubkeyLength]byte) error {
+	ctx, span := trace.StartSpan(ctx, "shared.Reconcile")
+	defer span.End()
+
+	proposals, err := db.ProposalHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not get local proposal history")
+	}
+	if len(proposals) > 0 {
+		latest := proposals[0]
+		for _, p := range proposals[1:] {
+			if p.Slot > latest.Slot {
+				latest = p
+			}
+		}
+		err := store.CheckAndSaveProposal(ctx, pubKey, latest.Slot, bytesutil.ToBytes32(latest.SigningRoot))
+		if err := reconcileResult(err, pubKey); err != nil {
+			return errors.Wrap(err, "could not record local proposal history")
+		}
+	}
+
+	atts, err := db.AttestationHistoryForPubKey(ctx, pubKey)
+	if err != nil {
+		return errors.Wrap(err, "could not get local attestation history")
+	}
+	if len(atts) > 0 {
+		latest := atts[0]
+		for _, a := range atts[1:] {
+			if a.Target > latest.Target {
+				latest = a
+			}
+		}
+		err := store.CheckAndSaveAttestation(ctx, pubKey, latest.Source, latest.Target, latest.SigningRoot)
+		if err := reconcileResult(err, pubKey); err != nil {
+			return errors.Wrap(err, "could not record local attestation history")
+		}
+	}
+	return nil
+}
+
+func reconcileResult(err error, pubKey [fieldparams.BLSPubkeyLength]byte) error {
+	if errors.Is(err, ErrSlashable) {
+		log.WithError(err).WithField("pubkey", fmt.Sprintf("%#x", pubKey)).Warn(
+			"Local slashing protection history is not recorded in the shared store",
+		)
+		return nil
+	}
+	return err
+}
@@ -0,0 +1,52 @@
+package shared
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+	ethpb "github.com/prysmaticlabs/prysm/v4/proto/prysm/v1alpha1"
+	"github.com/prysmaticlabs/prysm/v4/testing/require"
+	"github.com/prysmaticlabs/prysm/v4/testing/util"
+	dbtest "github.com/prysmaticlabs/prysm/v4/validator/db/testing"
+	logTest "github.com/sirupsen/logrus/hooks/test"
+)
+
+func TestReconcile(t *testing.T) {
+	ctx := context.Background()
+	db := dbtest.SetupDB(t, [][fieldparams.BLSPubkeyLength]byte{pubKey})
+	s, err := NewFileStore(filepath.Join(t.TempDir(), "protection.db"))
+	require.NoError(t, err)
+
+	// Nothing is recorded without local history.
+	require.NoError(t, Reconcile(ctx, s, db, pubKey))
+
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 8, rootA[:]))
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 10, rootA[:]))
+	for _, epochs := range [][2]primitives.Epoch{{1, 2}, {4, 5}, {2, 3}} {
+		att := util.HydrateIndexedAttestation(&ethpb.IndexedAttestation{
+			Data: &ethpb.AttestationData{
+				Source: &ethpb.Checkpoint{Epoch: epochs[0]},
+				Target: &ethpb.Checkpoint{Epoch: epochs[1]},
+			},
+		})
+		require.NoError(t, db.SaveAttestationForPubKey(ctx, pubKey, rootA, att))
+	}
+	require.NoError(t, Reconcile(ctx, s, db, pubKey))
+
+	// The other validator clients cannot sign below or conflicting with the latest local records.
+	require.ErrorIs(t, s.CheckAndSaveProposal(ctx, pubKey, 9, rootB), ErrSlashable)
+	require.ErrorIs(t, s.CheckAndSaveProposal(ctx, pubKey, 10, rootB), ErrSlashable)
+	require.NoError(t, s.CheckAndSaveProposal(ctx, pubKey, 11, rootB))
+	require.ErrorIs(t, s.CheckAndSaveAttestation(ctx, pubKey, 3, 4, rootB), ErrSlashable)
+	require.ErrorIs(t, s.CheckAndSaveAttestation(ctx, pubKey, 4, 5, rootB), ErrSlashable)
+	require.NoError(t, s.CheckAndSaveAttestation(ctx, pubKey, 5, 6, rootB))
+
+	// Local records conflicting with the shared records are logged.
+	hook := logTest.NewGlobal()
+	require.NoError(t, db.SaveProposalHistoryForSlot(ctx, pubKey, 11, rootA[:]))
+	require.NoError(t, Reconcile(ctx, s, db, pubKey))
+	require.LogsContain(t, hook, "Local slashing protection history is not recorded in the shared store")
+}
@@ -0,0 +1,43 @@
+package shared
+
+import (
+	"context"
+	"net/url"
+	"strings"
+
+	"github.com/pkg/errors"
+	fieldparams "github.com/prysmaticlabs/prysm/v4/config/fieldparams"
+	"github.com/prysmaticlabs/prysm/v4/consensus-types/primitives"
+)
+
+// ErrSlashable is returned when a proposal or attestation conflicts with the shared slashing protection records.
+var ErrSlashable = errors.New("rejected by shared slashing protection")
+
+// Store checks proposals and attestations against the shared slashing protection records, and records them if
+// they are not slashable. Checking and recording is atomic, so that only one of the validator clients sharing the
+// store may sign conflicting messages.
+type Store interface {
+	// CheckAndSaveProposal returns ErrSlashable if a different block was recorded at the slot, or if the slot is
+	// lower than the lowest recorded proposal slot, and records the proposal otherwise.
+	CheckAndSaveProposal(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, slot primitives.Slot, signingRoot [32]byte) error
+	// CheckAndSaveAttestation returns ErrSlashable if the attestation is a double or surround vote of a recorded
+	// attestation, or if its target is lower than the lowest recorded target, and records the attestation
+	// otherwise.
+	CheckAndSaveAttestation(ctx context.Context, pubKey [fieldparams.BLSPubkeyLength]byte, source, target primitives.Epoch, signingRoot [32]byte) error
+}
+
+// New returns the shared store at the given location, which is either the http(s) URL of a service implementing
+// the interface served by Handler, authenticated with the given token, or the path of a bolt database file.
+func New(location, token string) (Store, error) {
+	if strings.HasPrefix(location, "http://") || strings.HasPrefix(location, "https://") {
+		u, err := url.ParseRequestURI(location)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid shared slashing protection url %s", location)
+		}
+		if token == "" {
+			return nil, errors.New("a token is required to use a shared slashing protection service")
+		}
+		return NewHTTPStore(u, token), nil
+	}
+	return NewFileStore(location)
+}